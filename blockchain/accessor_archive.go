@@ -0,0 +1,111 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+// Every block commits its state tries to the database and stores their roots under its hash. An archive node keeps
+// the tries of all the blocks of the past and serves the state at any of them.
+
+// IsArchive - whether the node keeps and serves the state of every block of the past
+func (blockchain *BlockChain) IsArchive() bool {
+	return blockchain.config.Archive
+}
+
+// GetShardRootsHashByBlockHash - roots of the state tries stored by a block of the shard
+func (blockchain *BlockChain) GetShardRootsHashByBlockHash(shardID byte, blockHash common.Hash) (*ShardRootHash, error) {
+	data, err := rawdbv2.GetShardRootsHash(blockchain.GetShardChainDatabase(shardID), shardID, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	sRH := &ShardRootHash{}
+	err = json.Unmarshal(data, sRH)
+	return sRH, err
+}
+
+// GetBeaconRootsHashByBlockHash - roots of the state tries stored by a beacon block
+func (blockchain *BlockChain) GetBeaconRootsHashByBlockHash(blockHash common.Hash) (*BeaconRootHash, error) {
+	data, err := rawdbv2.GetBeaconRootsHash(blockchain.GetBeaconChainDatabase(), blockHash)
+	if err != nil {
+		return nil, err
+	}
+	bRH := &BeaconRootHash{}
+	err = json.Unmarshal(data, bRH)
+	return bRH, err
+}
+
+// GetShardTransactionStateDBByBlockHash - transaction state of the shard at one of its blocks
+func (blockchain *BlockChain) GetShardTransactionStateDBByBlockHash(shardID byte, blockHash common.Hash) (*statedb.StateDB, error) {
+	sRH, err := blockchain.GetShardRootsHashByBlockHash(shardID, blockHash)
+	if err != nil {
+		return nil, NewBlockChainError(GetHistoricalStateError, fmt.Errorf("no state of shard %+v at block %+v, error %+v", shardID, blockHash.String(), err))
+	}
+	return statedb.NewWithPrefixTrie(sRH.TransactionStateDBRootHash, statedb.NewDatabaseAccessWarper(blockchain.GetShardChainDatabase(shardID)))
+}
+
+// GetBeaconConsensusStateDBByBlockHash - consensus state of the beacon at one of its blocks
+func (blockchain *BlockChain) GetBeaconConsensusStateDBByBlockHash(blockHash common.Hash) (*statedb.StateDB, error) {
+	bRH, err := blockchain.GetBeaconRootsHashByBlockHash(blockHash)
+	if err != nil {
+		return nil, NewBlockChainError(GetHistoricalStateError, fmt.Errorf("no beacon state at block %+v, error %+v", blockHash.String(), err))
+	}
+	return statedb.NewWithPrefixTrie(bRH.ConsensusStateDBRootHash, statedb.NewDatabaseAccessWarper(blockchain.GetBeaconChainDatabase()))
+}
+
+// GetBeaconFeatureStateDBByBlockHash - feature state (pde, portal, bridge) of the beacon at one of its blocks
+func (blockchain *BlockChain) GetBeaconFeatureStateDBByBlockHash(blockHash common.Hash) (*statedb.StateDB, error) {
+	bRH, err := blockchain.GetBeaconRootsHashByBlockHash(blockHash)
+	if err != nil {
+		return nil, NewBlockChainError(GetHistoricalStateError, fmt.Errorf("no beacon state at block %+v, error %+v", blockHash.String(), err))
+	}
+	return statedb.NewWithPrefixTrie(bRH.FeatureStateDBRootHash, statedb.NewDatabaseAccessWarper(blockchain.GetBeaconChainDatabase()))
+}
+
+// GetListOutputCoinsByKeysetAtBlock - same as GetListOutputCoinsByKeyset, with the state of the shard at one of its
+// blocks: the coins received and spent by the block and the blocks before it
+func (blockchain *BlockChain) GetListOutputCoinsByKeysetAtBlock(keyset *incognitokey.KeySet, shardID byte, tokenID *common.Hash, blockHash common.Hash) ([]*privacy.OutputCoin, error) {
+	transactionStateDB, err := blockchain.GetShardTransactionStateDBByBlockHash(shardID, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	// cached coins are the coins of the best view
+	return blockchain.getListOutputCoinsByKeyset(transactionStateDB, false, keyset, shardID, tokenID)
+}
+
+// GetBeaconCommitteesByBlockHash - beacon state holding only the epoch, the committees and the substitute validators
+// of the beacon and the shards at a beacon block
+func (blockchain *BlockChain) GetBeaconCommitteesByBlockHash(blockHash common.Hash) (*BeaconBestState, error) {
+	beaconBlock, _, err := blockchain.GetBeaconBlockByHash(blockHash)
+	if err != nil {
+		return nil, NewBlockChainError(GetHistoricalStateError, fmt.Errorf("no beacon block %+v, error %+v", blockHash.String(), err))
+	}
+	consensusStateDB, err := blockchain.GetBeaconConsensusStateDBByBlockHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	beaconState := &BeaconBestState{
+		Epoch:            beaconBlock.Header.Epoch,
+		ActiveShards:     blockchain.GetBeaconBestState().ActiveShards,
+		consensusStateDB: consensusStateDB,
+	}
+	if err := beaconState.RestoreBeaconCommittee(); err != nil {
+		return nil, err
+	}
+	if err := beaconState.RestoreBeaconPendingValidator(); err != nil {
+		return nil, err
+	}
+	if err := beaconState.RestoreShardCommittee(); err != nil {
+		return nil, err
+	}
+	if err := beaconState.RestoreShardPendingValidator(); err != nil {
+		return nil, err
+	}
+	return beaconState, nil
+}
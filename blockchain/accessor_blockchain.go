@@ -136,7 +136,7 @@ func (blockchain *BlockChain) GetShardBlockByHeight(height uint64, shardID byte)
 	}
 	data, err := rawdbv2.GetShardBlockByHash(blockchain.GetShardChainDatabase(shardID), *blkhash)
 	if err != nil {
-		return nil, err
+		return nil, blockchain.getPrunedShardBlockError(shardID, *blkhash, err)
 	}
 	shardBlock := NewShardBlock()
 	err = json.Unmarshal(data, shardBlock)
@@ -161,7 +161,7 @@ func (blockchain *BlockChain) GetShardBlockByHeightV1(height uint64, shardID byt
 func (blockchain *BlockChain) GetShardBlockByHashWithShardID(hash common.Hash, shardID byte) (*ShardBlock, uint64, error) {
 	shardBlockBytes, err := rawdbv2.GetShardBlockByHash(blockchain.GetShardChainDatabase(shardID), hash)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, blockchain.getPrunedShardBlockError(shardID, hash, err)
 	}
 	shardBlock := NewShardBlock()
 	err = json.Unmarshal(shardBlockBytes, shardBlock)
@@ -187,7 +187,51 @@ func (blockchain *BlockChain) GetShardBlockByHash(hash common.Hash) (*ShardBlock
 			return shardBlock, shardBlock.Header.Height, nil
 		}
 	}
-	return nil, 0, NewBlockChainError(GetShardBlockByHashError, fmt.Errorf("Not found shard block by hash %+v", hash))
+	err := NewBlockChainError(GetShardBlockByHashError, fmt.Errorf("Not found shard block by hash %+v", hash))
+	for _, i := range blockchain.GetShardIDs() {
+		if prunedErr := blockchain.getPrunedShardBlockError(byte(i), hash, err); prunedErr != err {
+			return nil, 0, prunedErr
+		}
+	}
+	return nil, 0, err
+}
+
+// IsFinalizedShardBlock - whether the block is the block of the final chain of the shard at its height
+func (blockchain *BlockChain) IsFinalizedShardBlock(shardID byte, height uint64, hash common.Hash) bool {
+	finalizedHash, err := rawdbv2.GetFinalizedShardBlockHashByIndex(blockchain.GetShardChainDatabase(shardID), shardID, height)
+	if err != nil {
+		return false
+	}
+	return finalizedHash.IsEqual(&hash)
+}
+
+// GetShardBlockFinality - return the number of blocks from the block to the best block of the shard, the block included,
+// and whether the block is final
+func (blockchain *BlockChain) GetShardBlockFinality(shardID byte, height uint64, hash common.Hash) (uint64, bool) {
+	confirmations := uint64(0)
+	if bestHeight := blockchain.ShardChain[shardID].GetBestViewHeight(); bestHeight >= height {
+		confirmations = bestHeight - height + 1
+	}
+	return confirmations, blockchain.IsFinalizedShardBlock(shardID, height, hash)
+}
+
+// IsFinalizedBeaconBlock - whether the block is the block of the final beacon chain at its height
+func (blockchain *BlockChain) IsFinalizedBeaconBlock(height uint64, hash common.Hash) bool {
+	finalizedHash, err := rawdbv2.GetFinalizedBeaconBlockHashByIndex(blockchain.GetBeaconChainDatabase(), height)
+	if err != nil {
+		return false
+	}
+	return finalizedHash.IsEqual(&hash)
+}
+
+// GetBeaconBlockFinality - return the number of blocks from the block to the best beacon block, the block included,
+// and whether the block is final
+func (blockchain *BlockChain) GetBeaconBlockFinality(height uint64, hash common.Hash) (uint64, bool) {
+	confirmations := uint64(0)
+	if bestHeight := blockchain.BeaconChain.GetBestViewHeight(); bestHeight >= height {
+		confirmations = bestHeight - height + 1
+	}
+	return confirmations, blockchain.IsFinalizedBeaconBlock(height, hash)
 }
 
 func (blockchain *BlockChain) GetBestStateShardRewardStateDB(shardID byte) *statedb.StateDB {
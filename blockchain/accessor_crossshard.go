@@ -0,0 +1,115 @@
+package blockchain
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// Status of the outputs of a transaction sent to another shard
+const (
+	CrossShardTxPending   = "pending"   // the receiving shard has not processed the outputs yet
+	CrossShardTxIncluded  = "included"  // a block of the receiving shard processed the outputs, it is not final yet
+	CrossShardTxFinalized = "finalized" // the block of the receiving shard is final
+)
+
+type CrossShardTxReceipt struct {
+	ToShardID   byte
+	Status      string
+	BlockHeight uint64
+	BlockHash   common.Hash
+}
+
+type CrossShardTxStatus struct {
+	TxHash         common.Hash
+	FromShardID    byte
+	BlockHeight    uint64
+	BlockHash      common.Hash
+	BlockFinalized bool
+	Receipts       []CrossShardTxReceipt
+}
+
+// Status - status of the transaction: the least advanced status of its receipts,
+// or the status of its block when it has no output to another shard
+func (status *CrossShardTxStatus) Status() string {
+	if len(status.Receipts) == 0 {
+		if status.BlockFinalized {
+			return CrossShardTxFinalized
+		}
+		return CrossShardTxIncluded
+	}
+	result := CrossShardTxFinalized
+	for _, receipt := range status.Receipts {
+		switch receipt.Status {
+		case CrossShardTxPending:
+			return CrossShardTxPending
+		case CrossShardTxIncluded:
+			result = CrossShardTxIncluded
+		}
+	}
+	return result
+}
+
+// GetCrossShardTxStatus - return the shard block of a transaction and,
+// for every other shard receiving its outputs, the shard block which processed them
+func (blockchain *BlockChain) GetCrossShardTxStatus(txHash common.Hash) (*CrossShardTxStatus, error) {
+	fromShardID, blockHash, blockHeight, _, tx, err := blockchain.GetTransactionByHash(txHash)
+	if err != nil {
+		return nil, err
+	}
+	status := &CrossShardTxStatus{
+		TxHash:         txHash,
+		FromShardID:    fromShardID,
+		BlockHeight:    blockHeight,
+		BlockHash:      blockHash,
+		BlockFinalized: blockchain.IsFinalizedShardBlock(fromShardID, blockHeight, blockHash),
+		Receipts:       []CrossShardTxReceipt{},
+	}
+	for _, i := range blockchain.GetShardIDs() {
+		toShardID := byte(i)
+		if toShardID == fromShardID {
+			continue
+		}
+		outputCoins, tokenPrivacyData := getCrossShardData([]metadata.Transaction{tx}, toShardID)
+		if len(outputCoins) == 0 && len(tokenPrivacyData) == 0 {
+			continue
+		}
+		receipt := CrossShardTxReceipt{ToShardID: toShardID, Status: CrossShardTxPending}
+		height, hash, err := rawdbv2.GetCrossShardReceipt(blockchain.GetShardChainDatabase(toShardID), blockHash)
+		if err != nil {
+			return nil, NewBlockChainError(GetCrossShardTxStatusError, err)
+		}
+		if hash != nil {
+			receipt.BlockHeight = height
+			receipt.BlockHash = *hash
+			receipt.Status = CrossShardTxFinalized
+		} else if height, hash := blockchain.getUnfinalizedCrossShardReceipt(toShardID, blockHash); hash != nil {
+			receipt.BlockHeight = height
+			receipt.BlockHash = *hash
+			receipt.Status = CrossShardTxIncluded
+		}
+		status.Receipts = append(status.Receipts, receipt)
+	}
+	return status, nil
+}
+
+// getUnfinalizedCrossShardReceipt - return the block of the best chain of the shard, not final yet, which processed
+// the cross shard outputs of the block fromBlockHash, receipts are only stored once the processing block is final
+func (blockchain *BlockChain) getUnfinalizedCrossShardReceipt(shardID byte, fromBlockHash common.Hash) (uint64, *common.Hash) {
+	multiView := blockchain.ShardChain[shardID].multiView
+	finalHeight := multiView.GetFinalView().GetHeight()
+	for view := multiView.GetBestView(); view != nil && view.GetHeight() > finalHeight; view = multiView.GetViewByHash(*view.GetPreviousHash()) {
+		shardBlock, ok := view.GetBlock().(*ShardBlock)
+		if !ok {
+			break
+		}
+		for _, crossTransactions := range shardBlock.Body.CrossTransactions {
+			for _, crossTransaction := range crossTransactions {
+				if crossTransaction.BlockHash.IsEqual(&fromBlockHash) {
+					return shardBlock.Header.Height, shardBlock.Hash()
+				}
+			}
+		}
+	}
+	return 0, nil
+}
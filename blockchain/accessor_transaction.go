@@ -27,9 +27,17 @@ import (
 // in case private key: return unspent outputcoin tx
 // in case read only key: return all outputcoin tx with amount value
 // in case payment address: return all outputcoin tx with no amount value
+// a stealth output coin is found with the read only key only, its randomness is the one its receiver spends it with
 func DecryptOutputCoinByKey(transactionStateDB *statedb.StateDB, outCoinTemp *privacy.OutputCoin, keySet *incognitokey.KeySet, tokenID *common.Hash, shardID byte) *privacy.OutputCoin {
-	pubkeyCompress := outCoinTemp.CoinDetails.GetPublicKey().ToBytesS()
-	if bytes.Equal(pubkeyCompress, keySet.PaymentAddress.Pk[:]) {
+	isOwned := false
+	var stealthOffset *privacy.Scalar
+	if outCoinTemp.CoinDetails.GetVersion() == privacy.CoinVersionStealth {
+		stealthOffset, isOwned = outCoinTemp.CoinDetails.GetStealthOffset(keySet.ReadonlyKey.Rk, keySet.PaymentAddress.Pk)
+	} else {
+		pubkeyCompress := outCoinTemp.CoinDetails.GetPublicKey().ToBytesS()
+		isOwned = bytes.Equal(pubkeyCompress, keySet.PaymentAddress.Pk[:])
+	}
+	if isOwned {
 		result := &privacy.OutputCoin{
 			CoinDetails:          outCoinTemp.CoinDetails,
 			CoinDetailsEncrypted: outCoinTemp.CoinDetailsEncrypted,
@@ -43,6 +51,9 @@ func DecryptOutputCoinByKey(transactionStateDB *statedb.StateDB, outCoinTemp *pr
 				}
 			}
 		}
+		if stealthOffset != nil && result.CoinDetails.GetRandomness() != nil {
+			result.CoinDetails.SetRandomness(new(privacy.Scalar).Add(result.CoinDetails.GetRandomness(), stealthOffset))
+		}
 		if len(keySet.PrivateKey) > 0 {
 			// check spent with private key
 			result.CoinDetails.SetSerialNumber(
@@ -84,11 +95,14 @@ func (blockchain *BlockChain) GetTransactionByHash(txHash common.Hash) (byte, co
 			continue
 		}
 		// error is nil
-		shardBlock, _, err := blockchain.GetShardBlockByHashWithShardID(blockHash, shardID)
+		shardBlock, tx, err := blockchain.getStoredTransaction(shardID, blockHash, index, txHash)
 		if err != nil {
+			if IsBlockPrunedError(err) {
+				return byte(255), common.Hash{}, 0, -1, nil, err
+			}
 			continue
 		}
-		return shardBlock.Header.ShardID, blockHash, shardBlock.GetHeight(), index, shardBlock.Body.Transactions[index], nil
+		return shardBlock.Header.ShardID, blockHash, shardBlock.GetHeight(), index, tx, nil
 	}
 	return byte(255), common.Hash{}, 0, -1, nil, NewBlockChainError(GetTransactionFromDatabaseError, fmt.Errorf("Not found transaction with tx hash %+v", txHash))
 }
@@ -99,11 +113,14 @@ func (blockchain *BlockChain) GetTransactionByHashWithShardID(txHash common.Hash
 		return common.Hash{}, -1, nil, NewBlockChainError(GetTransactionFromDatabaseError, fmt.Errorf("Not found transaction with tx hash %+v", txHash))
 	}
 	// error is nil
-	shardBlock, _, err := blockchain.GetShardBlockByHashWithShardID(blockHash, shardID)
+	_, tx, err := blockchain.getStoredTransaction(shardID, blockHash, index, txHash)
 	if err != nil {
+		if IsBlockPrunedError(err) {
+			return common.Hash{}, -1, nil, err
+		}
 		return common.Hash{}, -1, nil, NewBlockChainError(GetTransactionFromDatabaseError, fmt.Errorf("Not found transaction with tx hash %+v", txHash))
 	}
-	return blockHash, index, shardBlock.Body.Transactions[index], nil
+	return blockHash, index, tx, nil
 }
 
 // GetTransactionHashByReceiver - return list tx id which receiver get from any sender
@@ -279,13 +296,25 @@ func (blockchain *BlockChain) BuildResponseTransactionFromTxsWithMetadata(view *
 //in case payment-address: return all outputcoin tx with no amount value
 //- Param #2: coinType - which type of joinsplitdesc(COIN or BOND)
 func (blockchain *BlockChain) GetListOutputCoinsByKeyset(keyset *incognitokey.KeySet, shardID byte, tokenID *common.Hash) ([]*privacy.OutputCoin, error) {
+	transactionStateDB := blockchain.GetBestStateShard(shardID).GetCopiedTransactionStateDB()
+	return blockchain.getListOutputCoinsByKeyset(transactionStateDB, blockchain.config.MemCache != nil, keyset, shardID, tokenID)
+}
+
+// GetListFinalizedOutputCoinsByKeyset - same as GetListOutputCoinsByKeyset, with the state of the final view of the shard
+// instead of its best view: the coins received and spent by final blocks only
+func (blockchain *BlockChain) GetListFinalizedOutputCoinsByKeyset(keyset *incognitokey.KeySet, shardID byte, tokenID *common.Hash) ([]*privacy.OutputCoin, error) {
+	transactionStateDB := blockchain.ShardChain[shardID].GetFinalViewState().GetCopiedTransactionStateDB()
+	// cached coins are the coins of the best view
+	return blockchain.getListOutputCoinsByKeyset(transactionStateDB, false, keyset, shardID, tokenID)
+}
+
+func (blockchain *BlockChain) getListOutputCoinsByKeyset(transactionStateDB *statedb.StateDB, useCache bool, keyset *incognitokey.KeySet, shardID byte, tokenID *common.Hash) ([]*privacy.OutputCoin, error) {
 	var outCointsInBytes [][]byte
 	var err error
-	transactionStateDB := blockchain.GetBestStateShard(shardID).GetCopiedTransactionStateDB()
 	if keyset == nil {
 		return nil, NewBlockChainError(GetListOutputCoinsByKeysetError, fmt.Errorf("invalid key set, got keyset %+v", keyset))
 	}
-	if blockchain.config.MemCache != nil {
+	if useCache {
 		// get from cache
 		cachedKey := memcache.GetListOutputcoinCachedKey(keyset.PaymentAddress.Pk[:], tokenID, shardID)
 		cachedData, _ := blockchain.config.MemCache.Get(cachedKey)
@@ -311,6 +340,14 @@ func (blockchain *BlockChain) GetListOutputCoinsByKeyset(keyset *incognitokey.Ke
 			return nil, err
 		}
 	}
+	// stealth output coins are not stored by receiver, the read only key finds its own ones among those of the shard
+	if len(keyset.ReadonlyKey.Rk) > 0 {
+		stealthOutCoinsInBytes, err := blockchain.getStealthOutputCoinsByKeyset(transactionStateDB, useCache, keyset, shardID, tokenID)
+		if err != nil {
+			return nil, err
+		}
+		outCointsInBytes = append(outCointsInBytes, stealthOutCoinsInBytes...)
+	}
 	// convert from []byte to object
 	outCoins := make([]*privacy.OutputCoin, 0)
 	for _, item := range outCointsInBytes {
@@ -332,6 +369,67 @@ func (blockchain *BlockChain) GetListOutputCoinsByKeyset(keyset *incognitokey.Ke
 	return results, nil
 }
 
+// stealthOutputCoinCheckpoint - the stealth output coins of a receiver among the first Length stealth output coins of
+// a shard, the hash of the last one tells whether the checkpoint was made on the current view
+type stealthOutputCoinCheckpoint struct {
+	Length             uint64
+	LastOutputCoinHash common.Hash
+	OutputCoins        [][]byte
+}
+
+// getStealthOutputCoinsByKeyset - get the stealth output coins of the receiver of keyset, with the cache the checkpoint
+// of the receiver is kept and only the coins stored since the previous call are scanned
+func (blockchain *BlockChain) getStealthOutputCoinsByKeyset(transactionStateDB *statedb.StateDB, useCache bool, keyset *incognitokey.KeySet, shardID byte, tokenID *common.Hash) ([][]byte, error) {
+	checkpoint := stealthOutputCoinCheckpoint{}
+	var cachedKey []byte
+	if useCache {
+		cachedKey = memcache.GetListStealthOutputcoinCachedKey(keyset.ReadonlyKey.Rk, keyset.PaymentAddress.Pk, tokenID, shardID)
+		cachedData, _ := blockchain.config.MemCache.Get(cachedKey)
+		if cachedData != nil && len(cachedData) > 0 {
+			_ = json.Unmarshal(cachedData, &checkpoint)
+		}
+	}
+	fromIndex := uint64(0)
+	if checkpoint.Length > 0 {
+		fromIndex = checkpoint.Length - 1
+	}
+	stealthOutCoinsInBytes, length, err := statedb.GetStealthOutcoins(transactionStateDB, *tokenID, shardID, fromIndex)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint.Length > 0 {
+		if len(stealthOutCoinsInBytes) > 0 && common.HashH(stealthOutCoinsInBytes[0]) == checkpoint.LastOutputCoinHash {
+			stealthOutCoinsInBytes = stealthOutCoinsInBytes[1:]
+		} else {
+			// the checkpoint was made on another view, every coin is scanned again
+			checkpoint = stealthOutputCoinCheckpoint{}
+			stealthOutCoinsInBytes, length, err = statedb.GetStealthOutcoins(transactionStateDB, *tokenID, shardID, 0)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, item := range stealthOutCoinsInBytes {
+		outCoin := &privacy.OutputCoin{}
+		outCoin.Init()
+		if err := outCoin.SetBytes(item); err != nil {
+			continue
+		}
+		if _, isOwned := outCoin.CoinDetails.GetStealthOffset(keyset.ReadonlyKey.Rk, keyset.PaymentAddress.Pk); isOwned {
+			checkpoint.OutputCoins = append(checkpoint.OutputCoins, item)
+		}
+	}
+	if useCache && len(stealthOutCoinsInBytes) > 0 {
+		checkpoint.Length = length
+		checkpoint.LastOutputCoinHash = common.HashH(stealthOutCoinsInBytes[len(stealthOutCoinsInBytes)-1])
+		cachedData, err := json.Marshal(checkpoint)
+		if err == nil {
+			blockchain.config.MemCache.PutExpired(cachedKey, cachedData, 24*time.Hour)
+		}
+	}
+	return checkpoint.OutputCoins, nil
+}
+
 // CreateAndSaveTxViewPointFromBlock - fetch data from block, put into txviewpoint variable and save into db
 // still storage full data of commitments, serial number, snderivator to check double spend
 // this function only work for transaction transfer token/prv within shard
@@ -519,11 +617,20 @@ func (blockchain *BlockChain) StoreCommitmentsFromTxViewPoint(stateDB *statedb.S
 			if err != nil {
 				return err
 			}
-			// outputs
+			// outputs, a stealth output coin is stored after the other ones of the shard as its receiver scans them in order
 			outputCoinArray := view.mapOutputCoins[k]
 			outputCoinBytesArray := make([][]byte, 0)
+			stealthOutputCoinBytesArray := make([][]byte, 0)
 			for _, outputCoin := range outputCoinArray {
-				outputCoinBytesArray = append(outputCoinBytesArray, outputCoin.Bytes())
+				if outputCoin.CoinDetails.GetVersion() == privacy.CoinVersionStealth {
+					stealthOutputCoinBytesArray = append(stealthOutputCoinBytesArray, outputCoin.Bytes())
+				} else {
+					outputCoinBytesArray = append(outputCoinBytesArray, outputCoin.Bytes())
+				}
+			}
+			err = statedb.StoreStealthOutputCoins(stateDB, *view.tokenID, publicKeyBytes, stealthOutputCoinBytesArray, publicKeyShardID)
+			if err != nil {
+				return err
 			}
 			err = statedb.StoreOutputCoins(stateDB, *view.tokenID, publicKeyBytes, outputCoinBytesArray, publicKeyShardID)
 			// clear cached data
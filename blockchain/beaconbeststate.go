@@ -11,6 +11,7 @@ import (
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 )
 
@@ -164,6 +165,22 @@ func (beaconBestState *BeaconBestState) InitStateRootHash(bc *BlockChain) error
 	return nil
 }
 
+// backupInfo - the chain state covered by a backup of the beacon database taken at this view
+func (beaconBestState *BeaconBestState) backupInfo() incdb.BackupInfo {
+	return incdb.BackupInfo{
+		ChainID:   common.BeaconChainDataBaseID,
+		Epoch:     beaconBestState.Epoch,
+		Height:    beaconBestState.BeaconHeight,
+		BlockHash: beaconBestState.BestBlockHash,
+		Roots: map[string]common.Hash{
+			"ConsensusStateDBRootHash": beaconBestState.ConsensusStateDBRootHash,
+			"FeatureStateDBRootHash":   beaconBestState.FeatureStateDBRootHash,
+			"RewardStateDBRootHash":    beaconBestState.RewardStateDBRootHash,
+			"SlashStateDBRootHash":     beaconBestState.SlashStateDBRootHash,
+		},
+	}
+}
+
 func (beaconBestState *BeaconBestState) MarshalJSON() ([]byte, error) {
 	type Alias BeaconBestState
 	b, err := json.Marshal(&struct {
@@ -667,11 +684,5 @@ func (blockchain *BlockChain) GetBeaconRootsHash(stateDB *statedb.StateDB, heigh
 	if e != nil {
 		return nil, e
 	}
-	data, e := rawdbv2.GetBeaconRootsHash(blockchain.GetBeaconChainDatabase(), *h)
-	if e != nil {
-		return nil, e
-	}
-	bRH := &BeaconRootHash{}
-	err := json.Unmarshal(data, bRH)
-	return bRH, err
+	return blockchain.GetBeaconRootsHashByBlockHash(*h)
 }
@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"context"
 	"encoding/json"
 	"sync"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/tracing"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incognitokey"
@@ -157,14 +159,19 @@ func (chain *BeaconChain) GetLastProposerIndex() int {
 }
 
 func (chain *BeaconChain) CreateNewBlock(version int, proposer string, round int, startTime int64) (common.BlockInterface, error) {
+	ctx, span := tracing.StartSpan(context.Background(), "blockchain.CreateNewBeaconBlock", tracing.Uint64("height", chain.GetBestViewHeight()+1))
+	defer span.End()
 	newBlock, err := chain.Blockchain.NewBlockBeacon(chain.GetBestView().(*BeaconBestState), version, proposer, round, startTime)
 	if err != nil {
+		span.SetError(err)
 		return nil, err
 	}
 	if version == 2 {
 		newBlock.Header.Proposer = proposer
 		newBlock.Header.ProposeTime = startTime
 	}
+	span.SetAttributes(tracing.String("blockHash", newBlock.Hash().String()), tracing.Int("instructions", len(newBlock.Body.Instructions)))
+	tracing.RememberBlock(newBlock.Hash().String(), ctx)
 
 	return newBlock, nil
 }
@@ -176,6 +183,10 @@ func (chain *BeaconChain) CreateNewBlockFromOldBlock(oldBlock common.BlockInterf
 	json.Unmarshal(b, &newBlock)
 	newBlock.Header.Proposer = proposer
 	newBlock.Header.ProposeTime = startTime
+	ctx, span := tracing.StartSpan(tracing.ContextForBlock(oldBlock.Hash().String()), "blockchain.CreateNewBeaconBlockFromOldBlock",
+		tracing.String("oldBlockHash", oldBlock.Hash().String()), tracing.String("blockHash", newBlock.Hash().String()))
+	tracing.RememberBlock(newBlock.Hash().String(), ctx)
+	span.End()
 	return newBlock, nil
 }
 
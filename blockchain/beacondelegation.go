@@ -0,0 +1,261 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// getEpochFromBeaconHeight returns epoch of beacon block at beaconHeight, epochs start at 1 with block 1
+func getEpochFromBeaconHeight(beaconHeight uint64, chainParamEpoch uint64) uint64 {
+	if beaconHeight == 0 {
+		return 1
+	}
+	return (beaconHeight-1)/chainParamEpoch + 1
+}
+
+// currentDelegations caches delegated amounts read from the feature state db while building instructions of a block,
+// so requests in one block see changes of the requests before them
+type currentDelegations struct {
+	stateDB *statedb.StateDB
+	amounts map[string]uint64
+}
+
+func newCurrentDelegations(stateDB *statedb.StateDB) *currentDelegations {
+	return &currentDelegations{stateDB: stateDB, amounts: make(map[string]uint64)}
+}
+
+func (cd *currentDelegations) get(committeePublicKey string, delegatorAddress string) uint64 {
+	key := committeePublicKey + "-" + delegatorAddress
+	if amount, ok := cd.amounts[key]; ok {
+		return amount
+	}
+	amount, err := statedb.GetDelegation(cd.stateDB, committeePublicKey, delegatorAddress)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	cd.amounts[key] = amount
+	return amount
+}
+
+func (cd *currentDelegations) set(committeePublicKey string, delegatorAddress string, amount uint64) {
+	cd.amounts[committeePublicKey+"-"+delegatorAddress] = amount
+}
+
+func buildDelegationInst(metaType int, shardID byte, status string, content interface{}) ([]string, error) {
+	contentBytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		status,
+		string(contentBytes),
+	}, nil
+}
+
+// buildDelegationCommissionInst accepts a commission request, its sender was checked to be the staker by the shard
+func buildDelegationCommissionInst(contentBytes []byte) ([]string, error) {
+	var commissionAction metadata.DelegationCommissionRequestAction
+	err := json.Unmarshal(contentBytes, &commissionAction)
+	if err != nil {
+		return nil, err
+	}
+	return buildDelegationInst(metadata.DelegationCommissionMeta, commissionAction.ShardID, common.DelegationAcceptedChainStatus, metadata.DelegationCommissionAcceptedContent{
+		CommitteePublicKey: commissionAction.Meta.CommitteePublicKey,
+		CommissionPercent:  commissionAction.Meta.CommissionPercent,
+		TxReqID:            commissionAction.TxReqID,
+		ShardID:            commissionAction.ShardID,
+	})
+}
+
+// buildDelegationInstructions accepts or rejects delegation requests of shards in order, then pays back
+// undelegations released in the epoch of beaconHeight to the shard of their delegator. Requests before
+// BeaconHeightBreakPointDelegation are ignored
+func (blockchain *BlockChain) buildDelegationInstructions(
+	stateDB *statedb.StateDB,
+	beaconHeight uint64,
+	delegationActions [][]string,
+) [][]string {
+	instructions := [][]string{}
+	if beaconHeight < blockchain.GetBeaconHeightBreakPointDelegation() {
+		return instructions
+	}
+	epoch := getEpochFromBeaconHeight(beaconHeight, blockchain.config.ChainParams.Epoch)
+	delegations := newCurrentDelegations(stateDB)
+	for _, action := range delegationActions {
+		metaType, err := strconv.Atoi(action[0])
+		if err != nil {
+			continue
+		}
+		contentStr := action[1]
+		contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while decoding content string of delegation action: %+v", err)
+			continue
+		}
+		if metaType == metadata.DelegationCommissionMeta {
+			inst, err := buildDelegationCommissionInst(contentBytes)
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			instructions = append(instructions, inst)
+			continue
+		}
+		var delegationAction metadata.DelegationRequestAction
+		err = json.Unmarshal(contentBytes, &delegationAction)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling delegation request action: %+v", err)
+			continue
+		}
+		meta := delegationAction.Meta
+		content := metadata.DelegationAcceptedContent{
+			DelegatorAddressStr:   meta.DelegatorAddressStr,
+			CommitteePublicKey:    meta.CommitteePublicKey,
+			NewCommitteePublicKey: meta.NewCommitteePublicKey,
+			Amount:                meta.Amount,
+			TxReqID:               delegationAction.TxReqID,
+			ShardID:               delegationAction.ShardID,
+		}
+		delegated := delegations.get(meta.CommitteePublicKey, meta.DelegatorAddressStr)
+		if metaType != metadata.DelegateMeta && delegated < meta.Amount {
+			instructions = append(instructions, []string{
+				strconv.Itoa(metaType),
+				strconv.Itoa(int(delegationAction.ShardID)),
+				common.DelegationRejectedChainStatus,
+				contentStr,
+			})
+			continue
+		}
+		switch metaType {
+		case metadata.DelegateMeta:
+			delegations.set(meta.CommitteePublicKey, meta.DelegatorAddressStr, delegated+meta.Amount)
+		case metadata.UndelegateMeta:
+			delegations.set(meta.CommitteePublicKey, meta.DelegatorAddressStr, delegated-meta.Amount)
+			// unbondings are only read in their release epoch, it must come after the epoch of the undelegation
+			content.ReleaseEpoch = epoch + blockchain.config.ChainParams.DelegationUnbondingEpochs
+			if content.ReleaseEpoch == epoch {
+				content.ReleaseEpoch++
+			}
+		case metadata.RedelegateMeta:
+			delegations.set(meta.CommitteePublicKey, meta.DelegatorAddressStr, delegated-meta.Amount)
+			newDelegated := delegations.get(meta.NewCommitteePublicKey, meta.DelegatorAddressStr)
+			delegations.set(meta.NewCommitteePublicKey, meta.DelegatorAddressStr, newDelegated+meta.Amount)
+		default:
+			continue
+		}
+		inst, err := buildDelegationInst(metaType, delegationAction.ShardID, common.DelegationAcceptedChainStatus, content)
+		if err != nil {
+			Logger.log.Error(err)
+			continue
+		}
+		instructions = append(instructions, inst)
+	}
+
+	for _, unbonding := range statedb.GetUnbondingDelegationsByReleaseEpoch(stateDB, epoch) {
+		keyWallet, err := wallet.Base58CheckDeserialize(unbonding.DelegatorAddress())
+		if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+			Logger.log.Errorf("ERROR: delegator address %+v of unbonding delegation %+v is invalid", unbonding.DelegatorAddress(), unbonding.TxReqID().String())
+			continue
+		}
+		shardID := common.GetShardIDFromLastByte(keyWallet.KeySet.PaymentAddress.Pk[len(keyWallet.KeySet.PaymentAddress.Pk)-1])
+		inst, err := buildDelegationInst(metadata.UndelegateMeta, shardID, common.DelegationUnbondedChainStatus, metadata.DelegationAcceptedContent{
+			DelegatorAddressStr: unbonding.DelegatorAddress(),
+			CommitteePublicKey:  unbonding.CommitteePublicKey(),
+			Amount:              unbonding.Amount(),
+			ReleaseEpoch:        unbonding.ReleaseEpoch(),
+			TxReqID:             unbonding.TxReqID(),
+			ShardID:             shardID,
+		})
+		if err != nil {
+			Logger.log.Error(err)
+			continue
+		}
+		instructions = append(instructions, inst)
+	}
+	return instructions
+}
+
+// processDelegationInstructions stores delegations, unbonding delegations and commissions of accepted instructions
+// of beaconBlock, from BeaconHeightBreakPointDelegation on
+func (blockchain *BlockChain) processDelegationInstructions(stateDB *statedb.StateDB, beaconBlock *BeaconBlock) error {
+	if beaconBlock.Header.Height < blockchain.GetBeaconHeightBreakPointDelegation() {
+		return nil
+	}
+	for _, inst := range beaconBlock.Body.Instructions {
+		if len(inst) != 4 {
+			continue
+		}
+		metaType, err := strconv.Atoi(inst[0])
+		if err != nil {
+			continue
+		}
+		if metaType != metadata.DelegateMeta && metaType != metadata.UndelegateMeta && metaType != metadata.RedelegateMeta &&
+			metaType != metadata.DelegationCommissionMeta {
+			continue
+		}
+		if inst[2] == common.DelegationRejectedChainStatus {
+			continue
+		}
+		if metaType == metadata.DelegationCommissionMeta {
+			var content metadata.DelegationCommissionAcceptedContent
+			err = json.Unmarshal([]byte(inst[3]), &content)
+			if err != nil {
+				return err
+			}
+			err = statedb.StoreDelegationCommission(stateDB, content.CommitteePublicKey, content.CommissionPercent)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		var content metadata.DelegationAcceptedContent
+		err = json.Unmarshal([]byte(inst[3]), &content)
+		if err != nil {
+			return err
+		}
+		if inst[2] == common.DelegationUnbondedChainStatus {
+			statedb.DeleteUnbondingDelegation(stateDB, content.ReleaseEpoch, content.TxReqID)
+			continue
+		}
+		delegated, err := statedb.GetDelegation(stateDB, content.CommitteePublicKey, content.DelegatorAddressStr)
+		if err != nil {
+			return err
+		}
+		switch metaType {
+		case metadata.DelegateMeta:
+			err = statedb.StoreDelegation(stateDB, content.CommitteePublicKey, content.DelegatorAddressStr, delegated+content.Amount)
+		case metadata.UndelegateMeta:
+			err = statedb.StoreDelegation(stateDB, content.CommitteePublicKey, content.DelegatorAddressStr, delegated-content.Amount)
+			if err == nil {
+				err = statedb.StoreUnbondingDelegation(stateDB, statedb.NewUnbondingDelegationStateWithValue(
+					content.TxReqID,
+					content.CommitteePublicKey,
+					content.DelegatorAddressStr,
+					content.Amount,
+					content.ReleaseEpoch,
+				))
+			}
+		case metadata.RedelegateMeta:
+			err = statedb.StoreDelegation(stateDB, content.CommitteePublicKey, content.DelegatorAddressStr, delegated-content.Amount)
+			if err == nil {
+				var newDelegated uint64
+				newDelegated, err = statedb.GetDelegation(stateDB, content.NewCommitteePublicKey, content.DelegatorAddressStr)
+				if err == nil {
+					err = statedb.StoreDelegation(stateDB, content.NewCommitteePublicKey, content.DelegatorAddressStr, newDelegated+content.Amount)
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// currentHTLCs caches htlcs read from the feature state db while building instructions of a block, so a claim and
+// a refund of one htlc in one block do not both pay it out
+type currentHTLCs struct {
+	stateDB *statedb.StateDB
+	htlcs   map[common.Hash]*statedb.HTLCState
+}
+
+func newCurrentHTLCs(stateDB *statedb.StateDB) *currentHTLCs {
+	return &currentHTLCs{stateDB: stateDB, htlcs: make(map[common.Hash]*statedb.HTLCState)}
+}
+
+func (ch *currentHTLCs) get(lockTxID common.Hash) (*statedb.HTLCState, bool) {
+	if htlc, ok := ch.htlcs[lockTxID]; ok {
+		return htlc, htlc != nil
+	}
+	htlc, has, err := statedb.GetHTLC(ch.stateDB, lockTxID)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	if !has {
+		htlc = nil
+	}
+	ch.htlcs[lockTxID] = htlc
+	return htlc, htlc != nil
+}
+
+func (ch *currentHTLCs) set(htlc *statedb.HTLCState) {
+	ch.htlcs[htlc.LockTxID()] = htlc
+}
+
+func getShardIDFromPaymentAddress(paymentAddressStr string) (byte, bool) {
+	keyWallet, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+		return 0, false
+	}
+	pk := keyWallet.KeySet.PaymentAddress.Pk
+	return common.GetShardIDFromLastByte(pk[len(pk)-1]), true
+}
+
+func buildHTLCInst(metaType int, shardID byte, status string, content metadata.HTLCAcceptedContent) ([]string, error) {
+	contentBytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		status,
+		string(contentBytes),
+	}, nil
+}
+
+// buildHTLCInstructions locks, claims or refunds htlcs of requests of shards in order. A lock whose timeout is not
+// after beaconHeight is rejected and refunded, a claim is accepted with the preimage of a locked htlc before its
+// timeout and a refund of a locked htlc from its timeout on. Requests before BeaconHeightBreakPointHTLC are ignored
+func (blockchain *BlockChain) buildHTLCInstructions(
+	stateDB *statedb.StateDB,
+	beaconHeight uint64,
+	htlcActions [][]string,
+) [][]string {
+	instructions := [][]string{}
+	if beaconHeight < blockchain.GetBeaconHeightBreakPointHTLC() {
+		return instructions
+	}
+	htlcs := newCurrentHTLCs(stateDB)
+	for _, action := range htlcActions {
+		metaType, err := strconv.Atoi(action[0])
+		if err != nil {
+			continue
+		}
+		contentBytes, err := base64.StdEncoding.DecodeString(action[1])
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while decoding content string of htlc action: %+v", err)
+			continue
+		}
+		var status string
+		var content metadata.HTLCAcceptedContent
+		if metaType == metadata.HTLCLockRequestMeta {
+			var lockAction metadata.HTLCLockRequestAction
+			err = json.Unmarshal(contentBytes, &lockAction)
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while unmarshaling htlc lock request action: %+v", err)
+				continue
+			}
+			meta := lockAction.Meta
+			content = metadata.HTLCAcceptedContent{
+				LockTxID:            lockAction.TxReqID,
+				SenderAddressStr:    meta.SenderAddressStr,
+				ReceiverAddressStr:  meta.ReceiverAddressStr,
+				TokenIDStr:          meta.TokenIDStr,
+				Amount:              meta.Amount,
+				HashLock:            meta.HashLock,
+				TimeoutBeaconHeight: meta.TimeoutBeaconHeight,
+				TxReqID:             lockAction.TxReqID,
+				ShardID:             lockAction.ShardID,
+			}
+			if _, has := htlcs.get(lockAction.TxReqID); has || meta.TimeoutBeaconHeight <= beaconHeight {
+				status = common.HTLCRejectedChainStatus
+			} else {
+				status = common.HTLCLockedChainStatus
+				htlcs.set(statedb.NewHTLCStateWithValue(
+					lockAction.TxReqID,
+					meta.SenderAddressStr,
+					meta.ReceiverAddressStr,
+					meta.TokenIDStr,
+					meta.Amount,
+					meta.HashLock,
+					meta.TimeoutBeaconHeight,
+					status,
+				))
+			}
+		} else {
+			var settleAction metadata.HTLCSettleRequestAction
+			err = json.Unmarshal(contentBytes, &settleAction)
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while unmarshaling htlc settle request action: %+v", err)
+				continue
+			}
+			meta := settleAction.Meta
+			content = metadata.HTLCAcceptedContent{
+				LockTxID: meta.LockTxID,
+				Preimage: meta.Preimage,
+				TxReqID:  settleAction.TxReqID,
+				ShardID:  settleAction.ShardID,
+			}
+			status = common.HTLCRejectedChainStatus
+			htlc, has := htlcs.get(meta.LockTxID)
+			if has && htlc.Status() == common.HTLCLockedChainStatus {
+				switch metaType {
+				case metadata.HTLCClaimRequestMeta:
+					if beaconHeight < htlc.TimeoutBeaconHeight() && metadata.IsHTLCPreimage(meta.Preimage, htlc.HashLock()) {
+						status = common.HTLCClaimedChainStatus
+					}
+				case metadata.HTLCRefundRequestMeta:
+					if beaconHeight >= htlc.TimeoutBeaconHeight() {
+						status = common.HTLCRefundedChainStatus
+					}
+				}
+			}
+			if status != common.HTLCRejectedChainStatus {
+				content.SenderAddressStr = htlc.SenderAddress()
+				content.ReceiverAddressStr = htlc.ReceiverAddress()
+				content.TokenIDStr = htlc.TokenID()
+				content.Amount = htlc.Amount()
+				content.HashLock = htlc.HashLock()
+				content.TimeoutBeaconHeight = htlc.TimeoutBeaconHeight()
+				settled := *htlc
+				settled.SetStatus(status)
+				settled.SetPreimage(meta.Preimage)
+				settled.SetSettleTxID(settleAction.TxReqID)
+				htlcs.set(&settled)
+			}
+		}
+		// the response tx is built by the shard of the payee
+		if payeeAddressStr := metadata.GetHTLCPayeeAddress(metaType, status, content); payeeAddressStr != "" {
+			shardID, ok := getShardIDFromPaymentAddress(payeeAddressStr)
+			if !ok {
+				Logger.log.Errorf("ERROR: payee address %+v of htlc %+v is invalid", payeeAddressStr, content.LockTxID.String())
+				continue
+			}
+			content.ShardID = shardID
+		}
+		inst, err := buildHTLCInst(metaType, content.ShardID, status, content)
+		if err != nil {
+			Logger.log.Error(err)
+			continue
+		}
+		instructions = append(instructions, inst)
+	}
+	return instructions
+}
+
+// processHTLCInstructions stores htlcs locked, claimed or refunded by instructions of beaconBlock, from
+// BeaconHeightBreakPointHTLC on
+func (blockchain *BlockChain) processHTLCInstructions(stateDB *statedb.StateDB, beaconBlock *BeaconBlock) error {
+	if beaconBlock.Header.Height < blockchain.GetBeaconHeightBreakPointHTLC() {
+		return nil
+	}
+	for _, inst := range beaconBlock.Body.Instructions {
+		if len(inst) != 4 {
+			continue
+		}
+		metaType, err := strconv.Atoi(inst[0])
+		if err != nil {
+			continue
+		}
+		if metaType != metadata.HTLCLockRequestMeta && metaType != metadata.HTLCClaimRequestMeta && metaType != metadata.HTLCRefundRequestMeta {
+			continue
+		}
+		if inst[2] == common.HTLCRejectedChainStatus {
+			continue
+		}
+		var content metadata.HTLCAcceptedContent
+		err = json.Unmarshal([]byte(inst[3]), &content)
+		if err != nil {
+			return err
+		}
+		htlc := statedb.NewHTLCStateWithValue(
+			content.LockTxID,
+			content.SenderAddressStr,
+			content.ReceiverAddressStr,
+			content.TokenIDStr,
+			content.Amount,
+			content.HashLock,
+			content.TimeoutBeaconHeight,
+			inst[2],
+		)
+		if metaType != metadata.HTLCLockRequestMeta {
+			htlc.SetPreimage(content.Preimage)
+			htlc.SetSettleTxID(content.TxReqID)
+		}
+		err = statedb.StoreHTLC(stateDB, htlc)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -20,17 +20,20 @@ import (
 type PortalProducerSuite struct {
 	suite.Suite
 	currentPortalState *CurrentPortalState
+	portalParams       PortalParams
 }
 
 func (suite *PortalProducerSuite) SetupTest() {
 	suite.currentPortalState = &CurrentPortalState{
 		CustodianPoolState:      map[string]*statedb.CustodianState{},
 		ExchangeRatesRequests:   map[string]*metadata.ExchangeRatesRequestStatus{},
-		FinalExchangeRatesState: map[string]*statedb.FinalExchangeRatesState{},
+		FinalExchangeRatesState: nil,
 		WaitingPortingRequests:  map[string]*statedb.WaitingPortingRequest{},
 		WaitingRedeemRequests:   map[string]*statedb.RedeemRequest{},
+		MatchedRedeemRequests:   map[string]*statedb.RedeemRequest{},
 		LiquidationPool:         map[string]*statedb.LiquidationPool{},
 	}
+	suite.portalParams = ChainTestParam.PortalParams[0]
 }
 
 /************************ Porting request test ************************/
@@ -60,52 +63,42 @@ type AutoLiquidationExchangeRatesTestCase struct {
 	Output       func() LiquidationExchangeRatesExcepted
 }
 
-func (suite *PortalProducerSuite) SetupExchangeRates(beaconHeight uint64) {
+func (suite *PortalProducerSuite) SetupExchangeRates() {
 	rates := make(map[string]statedb.FinalExchangeRatesDetail)
-	rates["b832e5d3b1f01a4f0623f7fe91d6673461e1f5d37d91fe78c5c2e6183ff39696"] = statedb.FinalExchangeRatesDetail{
+	rates[common.PortalBTCIDStr] = statedb.FinalExchangeRatesDetail{
 		Amount: 8000000000,
 	}
-	rates["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"] = statedb.FinalExchangeRatesDetail{
+	rates[common.PortalBNBIDStr] = statedb.FinalExchangeRatesDetail{
 		Amount: 20000000,
 	}
-	rates["0000000000000000000000000000000000000000000000000000000000000004"] = statedb.FinalExchangeRatesDetail{
+	rates[common.PRVIDStr] = statedb.FinalExchangeRatesDetail{
 		Amount: 500000,
 	}
 
-	exchangeRates := make(map[string]*statedb.FinalExchangeRatesState)
-	exchangeRatesKey := statedb.GeneratePortalFinalExchangeRatesStateObjectKey(beaconHeight)
-	exchangeRates[exchangeRatesKey.String()] = statedb.NewFinalExchangeRatesStateWithValue(rates)
-
-	suite.currentPortalState.FinalExchangeRatesState = exchangeRates
+	suite.currentPortalState.FinalExchangeRatesState = statedb.NewFinalExchangeRatesStateWithValue(rates)
 }
 
-func (suite *PortalProducerSuite) SetupExchangeRatesWithValue(beaconHeight uint64, btc uint64, bnb uint64, prv uint64) {
+func (suite *PortalProducerSuite) SetupExchangeRatesWithValue(btc uint64, bnb uint64, prv uint64) {
 	rates := make(map[string]statedb.FinalExchangeRatesDetail)
-	rates["b832e5d3b1f01a4f0623f7fe91d6673461e1f5d37d91fe78c5c2e6183ff39696"] = statedb.FinalExchangeRatesDetail{
+	rates[common.PortalBTCIDStr] = statedb.FinalExchangeRatesDetail{
 		Amount: btc,
 	}
-	rates["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"] = statedb.FinalExchangeRatesDetail{
+	rates[common.PortalBNBIDStr] = statedb.FinalExchangeRatesDetail{
 		Amount: bnb,
 	}
-	rates["0000000000000000000000000000000000000000000000000000000000000004"] = statedb.FinalExchangeRatesDetail{
+	rates[common.PRVIDStr] = statedb.FinalExchangeRatesDetail{
 		Amount: prv,
 	}
 
-	exchangeRates := make(map[string]*statedb.FinalExchangeRatesState)
-	exchangeRatesKey := statedb.GeneratePortalFinalExchangeRatesStateObjectKey(beaconHeight)
-	exchangeRates[exchangeRatesKey.String()] = statedb.NewFinalExchangeRatesStateWithValue(rates)
-
-	suite.currentPortalState.FinalExchangeRatesState = exchangeRates
+	suite.currentPortalState.FinalExchangeRatesState = statedb.NewFinalExchangeRatesStateWithValue(rates)
 }
 
-func (suite *PortalProducerSuite) SetupOneCustodian(beaconHeight uint64) {
-	remoteAddresses := make([]statedb.RemoteAddress, 0)
-	remoteAddresses = append(
-		remoteAddresses,
-		*statedb.NewRemoteAddressWithValue("b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b", "bnb136ns6lfw4zs5hg4n85vdthaad7hq5m4gtkgf234"),
-	)
+func (suite *PortalProducerSuite) SetupOneCustodian() {
+	remoteAddresses := map[string]string{
+		common.PortalBNBIDStr: "bnb136ns6lfw4zs5hg4n85vdthaad7hq5m4gtkgf234",
+	}
 
-	custodianKey := statedb.GenerateCustodianStateObjectKey(beaconHeight, "12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ")
+	custodianKey := statedb.GenerateCustodianStateObjectKey("12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ")
 	newCustodian := statedb.NewCustodianStateWithValue(
 		"12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ",
 		100000,
@@ -113,7 +106,7 @@ func (suite *PortalProducerSuite) SetupOneCustodian(beaconHeight uint64) {
 		nil,
 		nil,
 		remoteAddresses,
-		0,
+		nil,
 	)
 
 	custodian := make(map[string]*statedb.CustodianState)
@@ -121,14 +114,12 @@ func (suite *PortalProducerSuite) SetupOneCustodian(beaconHeight uint64) {
 	suite.currentPortalState.CustodianPoolState = custodian
 }
 
-func (suite *PortalProducerSuite) SetupMultipleCustodian(beaconHeight uint64) {
-	remoteAddresses := make([]statedb.RemoteAddress, 0)
-	remoteAddresses = append(
-		remoteAddresses,
-		*statedb.NewRemoteAddressWithValue("b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b", "bnb136ns6lfw4zs5hg4n85vdthaad7hq5m4gtkgf234"),
-	)
+func (suite *PortalProducerSuite) SetupMultipleCustodian() {
+	remoteAddresses := map[string]string{
+		common.PortalBNBIDStr: "bnb136ns6lfw4zs5hg4n85vdthaad7hq5m4gtkgf234",
+	}
 
-	custodianKey := statedb.GenerateCustodianStateObjectKey(beaconHeight, "12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ")
+	custodianKey := statedb.GenerateCustodianStateObjectKey("12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ")
 	newCustodian := statedb.NewCustodianStateWithValue(
 		"12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ",
 		100000,
@@ -136,10 +127,10 @@ func (suite *PortalProducerSuite) SetupMultipleCustodian(beaconHeight uint64) {
 		nil,
 		nil,
 		remoteAddresses,
-		0,
+		nil,
 	)
 
-	custodianKey2 := statedb.GenerateCustodianStateObjectKey(beaconHeight, "12Rwz4HXkVABgRnSb5Gfu1FaJ7auo3fLNXVGFhxx1dSytxHpWhbkimT1Mv5Z2oCMsssSXTVsapY8QGBZd2J4mPiCTzJAtMyCzb4dDcy")
+	custodianKey2 := statedb.GenerateCustodianStateObjectKey("12Rwz4HXkVABgRnSb5Gfu1FaJ7auo3fLNXVGFhxx1dSytxHpWhbkimT1Mv5Z2oCMsssSXTVsapY8QGBZd2J4mPiCTzJAtMyCzb4dDcy")
 	newCustodian2 := statedb.NewCustodianStateWithValue(
 		"12Rwz4HXkVABgRnSb5Gfu1FaJ7auo3fLNXVGFhxx1dSytxHpWhbkimT1Mv5Z2oCMsssSXTVsapY8QGBZd2J4mPiCTzJAtMyCzb4dDcy",
 		90000,
@@ -147,7 +138,7 @@ func (suite *PortalProducerSuite) SetupMultipleCustodian(beaconHeight uint64) {
 		nil,
 		nil,
 		remoteAddresses,
-		0,
+		nil,
 	)
 
 	custodian := make(map[string]*statedb.CustodianState)
@@ -156,50 +147,46 @@ func (suite *PortalProducerSuite) SetupMultipleCustodian(beaconHeight uint64) {
 	suite.currentPortalState.CustodianPoolState = custodian
 }
 
-func (suite *PortalProducerSuite) SetupMultipleCustodianContainPToken(beaconHeight uint64) {
-	remoteAddresses := make([]statedb.RemoteAddress, 0)
-	remoteAddresses = append(
-		remoteAddresses,
-		*statedb.NewRemoteAddressWithValue("b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b", "bnb136ns6lfw4zs5hg4n85vdthaad7hq5m4gtkgf234"),
-	)
-
-	exchangeRatesKey := statedb.GeneratePortalFinalExchangeRatesStateObjectKey(beaconHeight)
+func (suite *PortalProducerSuite) SetupMultipleCustodianContainPToken() {
+	remoteAddresses := map[string]string{
+		common.PortalBNBIDStr: "bnb136ns6lfw4zs5hg4n85vdthaad7hq5m4gtkgf234",
+	}
 
-	convertExchangeRatesObj := NewConvertExchangeRatesObject(suite.currentPortalState.FinalExchangeRatesState[exchangeRatesKey.String()])
-	totalPTokenAfterUp150PercentUnit64 := up150Percent(1000)   //return nano pBTC, pBNB
-	totalPTokenAfterUp150PercentUnit64_2 := up150Percent(2000) //return nano pBTC, pBNB
+	convertExchangeRatesObj := NewConvertExchangeRatesObject(suite.currentPortalState.FinalExchangeRatesState)
+	totalPTokenAfterUp150PercentUnit64 := up150Percent(1000, suite.portalParams.MinPercentLockedCollateral)   //return nano pBTC, pBNB
+	totalPTokenAfterUp150PercentUnit64_2 := up150Percent(2000, suite.portalParams.MinPercentLockedCollateral) //return nano pBTC, pBNB
 
-	totalPRV, _ := convertExchangeRatesObj.ExchangePToken2PRVByTokenId("b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b", totalPTokenAfterUp150PercentUnit64)
-	totalPRV_2, _ := convertExchangeRatesObj.ExchangePToken2PRVByTokenId("b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b", totalPTokenAfterUp150PercentUnit64_2)
+	totalPRV, _ := convertExchangeRatesObj.ExchangePToken2PRVByTokenId(common.PortalBNBIDStr, totalPTokenAfterUp150PercentUnit64)
+	totalPRV_2, _ := convertExchangeRatesObj.ExchangePToken2PRVByTokenId(common.PortalBNBIDStr, totalPTokenAfterUp150PercentUnit64_2)
 
-	custodianKey := statedb.GenerateCustodianStateObjectKey(beaconHeight, "12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ")
+	custodianKey := statedb.GenerateCustodianStateObjectKey("12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ")
 	newCustodian := statedb.NewCustodianStateWithValue(
 		"12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ",
 		100000,
 		100000,
 		map[string]uint64{
-			"b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b": 1000,
+			common.PortalBNBIDStr: 1000,
 		},
 		map[string]uint64{
-			"b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b": totalPRV,
+			common.PortalBNBIDStr: totalPRV,
 		},
 		remoteAddresses,
-		0,
+		nil,
 	)
 
-	custodianKey2 := statedb.GenerateCustodianStateObjectKey(beaconHeight, "12Rwz4HXkVABgRnSb5Gfu1FaJ7auo3fLNXVGFhxx1dSytxHpWhbkimT1Mv5Z2oCMsssSXTVsapY8QGBZd2J4mPiCTzJAtMyCzb4dDcy")
+	custodianKey2 := statedb.GenerateCustodianStateObjectKey("12Rwz4HXkVABgRnSb5Gfu1FaJ7auo3fLNXVGFhxx1dSytxHpWhbkimT1Mv5Z2oCMsssSXTVsapY8QGBZd2J4mPiCTzJAtMyCzb4dDcy")
 	newCustodian2 := statedb.NewCustodianStateWithValue(
 		"12Rwz4HXkVABgRnSb5Gfu1FaJ7auo3fLNXVGFhxx1dSytxHpWhbkimT1Mv5Z2oCMsssSXTVsapY8QGBZd2J4mPiCTzJAtMyCzb4dDcy",
 		90000,
 		90000,
 		map[string]uint64{
-			"b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b": 2000,
+			common.PortalBNBIDStr: 2000,
 		},
 		map[string]uint64{
-			"b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b": totalPRV_2,
+			common.PortalBNBIDStr: totalPRV_2,
 		},
 		remoteAddresses,
-		0,
+		nil,
 	)
 
 	custodian := make(map[string]*statedb.CustodianState)
@@ -208,7 +195,7 @@ func (suite *PortalProducerSuite) SetupMultipleCustodianContainPToken(beaconHeig
 	suite.currentPortalState.CustodianPoolState = custodian
 }
 
-func (suite *PortalProducerSuite) SetupMockBlockChain(trieMock *mocks.Trie) *BlockChain {
+func (suite *PortalProducerSuite) SetupMockStateDB(trieMock *mocks.Trie) *statedb.StateDB {
 	root := common.Hash{}
 	wrapperDBMock := new(mocks.DatabaseAccessWarper)
 	wrapperDBMock.On("OpenPrefixTrie", root).Return(
@@ -223,20 +210,7 @@ func (suite *PortalProducerSuite) SetupMockBlockChain(trieMock *mocks.Trie) *Blo
 
 	root1 := common.Hash{}
 	stateDb, _ := statedb.NewWithPrefixTrie(root1, wrapperDBMock)
-
-	beaconBestState := &BeaconBestState{
-		featureStateDB: stateDb,
-	}
-
-	bestState := &BestState{
-		Beacon: beaconBestState,
-	}
-
-	blockChain := &BlockChain{
-		BestState: bestState,
-	}
-
-	return blockChain
+	return stateDb
 }
 
 func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
@@ -247,7 +221,7 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
 				meta, _ := metadata.NewPortalUserRegister(
 					"1",
 					"12S5pBBRDf1GqfRHouvCV86sWaHzNfvakAWpVMvNnWu2k299xWCgQzLLc9wqPYUHfMYGDprPvQ794dbi6UU1hfRN4tPiU61txWWenhC", //100.000 prv
-					"b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b",
+					common.PortalBNBIDStr,
 					1000,
 					4,
 					metadata.PortalUserRegisterMeta,
@@ -267,7 +241,7 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
 					Custodian1: []string{
 						"12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ", //address
 						"40000", //free collateral
-						"1000",  //hold pToken
+						"0",     //hold pToken
 						"60000", //lock prv amount
 					},
 				}
@@ -279,7 +253,7 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
 				meta, _ := metadata.NewPortalUserRegister(
 					"2",
 					"12S5pBBRDf1GqfRHouvCV86sWaHzNfvakAWpVMvNnWu2k299xWCgQzLLc9wqPYUHfMYGDprPvQ794dbi6UU1hfRN4tPiU61txWWenhC", //100.000 prv
-					"b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b",
+					common.PortalBNBIDStr,
 					100,
 					4,
 					metadata.PortalUserRegisterMeta,
@@ -299,7 +273,7 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
 					Custodian1: []string{
 						"12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ", //address
 						"34000", //free collateral
-						"1100",  //hold pToken
+						"0",     //hold pToken
 						"66000", //lock prv amount
 					},
 				}
@@ -309,8 +283,8 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
 
 	//reset
 	suite.SetupTest()
-	suite.SetupExchangeRates(1)
-	suite.SetupOneCustodian(1)
+	suite.SetupExchangeRates()
+	suite.SetupOneCustodian()
 	suite.verifyPortingRequest(happyCases)
 
 	pickMultipleCustodianCases := []PortingRequestTestCase{
@@ -320,7 +294,7 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
 				meta, _ := metadata.NewPortalUserRegister(
 					"1",
 					"12S5pBBRDf1GqfRHouvCV86sWaHzNfvakAWpVMvNnWu2k299xWCgQzLLc9wqPYUHfMYGDprPvQ794dbi6UU1hfRN4tPiU61txWWenhC", //100.000 prv
-					"b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b",
+					common.PortalBNBIDStr,
 					2000,
 					8,
 					metadata.PortalUserRegisterMeta,
@@ -339,15 +313,15 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
 					ChainStatus: common.PortalPortingRequestAcceptedChainStatus,
 					Custodian1: []string{
 						"12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ", //address
-						"0",      //free collateral
-						"1667",   //hold pToken
-						"100000", //lock prv amount
+						"40",    //free collateral
+						"0",     //hold pToken
+						"99960", //lock prv amount
 					},
 					Custodian2: []string{
 						"12Rwz4HXkVABgRnSb5Gfu1FaJ7auo3fLNXVGFhxx1dSytxHpWhbkimT1Mv5Z2oCMsssSXTVsapY8QGBZd2J4mPiCTzJAtMyCzb4dDcy", //address
-						"70000", //free collateral
-						"333",   //hold pToken
-						"20000", //lock prv amount
+						"69960", //free collateral
+						"0",     //hold pToken
+						"20040", //lock prv amount
 					},
 				}
 			},
@@ -358,7 +332,7 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
 				meta, _ := metadata.NewPortalUserRegister(
 					"2",
 					"12S5pBBRDf1GqfRHouvCV86sWaHzNfvakAWpVMvNnWu2k299xWCgQzLLc9wqPYUHfMYGDprPvQ794dbi6UU1hfRN4tPiU61txWWenhC", //100.000 prv
-					"b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b",
+					common.PortalBNBIDStr,
 					1000,
 					4,
 					metadata.PortalUserRegisterMeta,
@@ -377,15 +351,15 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
 					ChainStatus: common.PortalPortingRequestAcceptedChainStatus,
 					Custodian1: []string{
 						"12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ", //address
-						"0",      //free collateral
-						"1667",   //hold pToken
-						"100000", //lock prv amount
+						"40",    //free collateral
+						"0",     //hold pToken
+						"99960", //lock prv amount
 					},
 					Custodian2: []string{
 						"12Rwz4HXkVABgRnSb5Gfu1FaJ7auo3fLNXVGFhxx1dSytxHpWhbkimT1Mv5Z2oCMsssSXTVsapY8QGBZd2J4mPiCTzJAtMyCzb4dDcy", //address
-						"10000", //free collateral
-						"1333",  //hold pToken
-						"80000", //lock prv amount
+						"9960",  //free collateral
+						"0",     //hold pToken
+						"80040", //lock prv amount
 					},
 				}
 			},
@@ -394,8 +368,8 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
 
 	//reset
 	suite.SetupTest()
-	suite.SetupExchangeRates(1)
-	suite.SetupMultipleCustodian(1)
+	suite.SetupExchangeRates()
+	suite.SetupMultipleCustodian()
 	suite.verifyPortingRequest(pickMultipleCustodianCases)
 
 	waitingPortingRequest := []PortingRequestTestCase{
@@ -405,7 +379,7 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
 				meta, _ := metadata.NewPortalUserRegister(
 					"1",
 					"12S5pBBRDf1GqfRHouvCV86sWaHzNfvakAWpVMvNnWu2k299xWCgQzLLc9wqPYUHfMYGDprPvQ794dbi6UU1hfRN4tPiU61txWWenhC", //100.000 prv
-					"b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b",
+					common.PortalBNBIDStr,
 					2000,
 					8,
 					metadata.PortalUserRegisterMeta,
@@ -424,15 +398,15 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
 					ChainStatus: common.PortalPortingRequestAcceptedChainStatus,
 					Custodian1: []string{
 						"12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ", //address
-						"0",      //free collateral
-						"1667",   //hold pToken
-						"100000", //lock prv amount
+						"40",    //free collateral
+						"0",     //hold pToken
+						"99960", //lock prv amount
 					},
 					Custodian2: []string{
 						"12Rwz4HXkVABgRnSb5Gfu1FaJ7auo3fLNXVGFhxx1dSytxHpWhbkimT1Mv5Z2oCMsssSXTVsapY8QGBZd2J4mPiCTzJAtMyCzb4dDcy", //address
-						"70000", //free collateral
-						"333",   //hold pToken
-						"20000", //lock prv amount
+						"69960", //free collateral
+						"0",     //hold pToken
+						"20040", //lock prv amount
 					},
 				}
 			},
@@ -443,7 +417,7 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
 				meta, _ := metadata.NewPortalUserRegister(
 					"1",
 					"12S5pBBRDf1GqfRHouvCV86sWaHzNfvakAWpVMvNnWu2k299xWCgQzLLc9wqPYUHfMYGDprPvQ794dbi6UU1hfRN4tPiU61txWWenhC", //100.000 prv
-					"b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b",
+					common.PortalBNBIDStr,
 					1000,
 					4,
 					metadata.PortalUserRegisterMeta,
@@ -467,8 +441,8 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForPortingRequest() {
 
 	//reset
 	suite.SetupTest()
-	suite.SetupExchangeRates(1)
-	suite.SetupMultipleCustodian(1)
+	suite.SetupExchangeRates()
+	suite.SetupMultipleCustodian()
 	suite.verifyPortingRequest(waitingPortingRequest)
 }
 
@@ -541,19 +515,19 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForLiquidationTPExchangeR
 					TpValue: 120,
 					Custodian1: []string{
 						"12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ", //address
-						"100000", //free collateral
+						"107500", //free collateral
 						"0",      //hold pToken
 						"0",      //lock prv amount
 					},
 					Custodian2: []string{
 						"12Rwz4HXkVABgRnSb5Gfu1FaJ7auo3fLNXVGFhxx1dSytxHpWhbkimT1Mv5Z2oCMsssSXTVsapY8QGBZd2J4mPiCTzJAtMyCzb4dDcy", //address
-						"90000", //free collateral
-						"0",     //hold pToken
-						"0",     //lock prv amount
+						"105000", //free collateral
+						"0",      //hold pToken
+						"0",      //lock prv amount
 					},
 					LiquidationPool: []uint64{
 						3000,   //lock ptoken
-						180000, //lock amount collateral
+						157500, //lock amount collateral
 					},
 				}
 			},
@@ -561,8 +535,8 @@ func (suite *PortalProducerSuite) TestBuildInstructionsForLiquidationTPExchangeR
 	}
 
 	suite.SetupTest()
-	suite.SetupExchangeRates(1)
-	suite.SetupMultipleCustodianContainPToken(1)
+	suite.SetupExchangeRates()
+	suite.SetupMultipleCustodianContainPToken()
 	suite.verifyAutoLiquidationExchangeRates(exchangeRatesChange)
 }
 
@@ -570,11 +544,12 @@ func (suite *PortalProducerSuite) verifyAutoLiquidationExchangeRates(testCases [
 	beaconHeight := uint64(1)
 
 	for _, testCase := range testCases {
-		suite.SetupExchangeRatesWithValue(beaconHeight, testCase.Input["btc"], testCase.Input["bnb"], testCase.Input["prv"])
+		suite.SetupExchangeRatesWithValue(testCase.Input["btc"], testCase.Input["bnb"], testCase.Input["prv"])
 
 		value, _ := buildInstForLiquidationTopPercentileExchangeRates(
 			beaconHeight,
 			suite.currentPortalState,
+			suite.portalParams,
 		)
 
 		fmt.Printf("Testcase %v: instruction %#v", testCase.TestCaseName, value)
@@ -584,14 +559,14 @@ func (suite *PortalProducerSuite) verifyAutoLiquidationExchangeRates(testCases [
 			var actionData metadata.PortalLiquidateTopPercentileExchangeRatesContent
 			json.Unmarshal([]byte(value[0][3]), &actionData)
 
-			if actionData.TP["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"].TPKey != testCase.Output().TpValue { //free collateral
-				suite.T().Errorf("tp is not equal, %v != %v", actionData.TP["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"].TPKey, testCase.Output().TpValue)
+			if actionData.TP[common.PortalBNBIDStr].TPKey != testCase.Output().TpValue { //free collateral
+				suite.T().Errorf("tp is not equal, %v != %v", actionData.TP[common.PortalBNBIDStr].TPKey, testCase.Output().TpValue)
 			}
 		}
 
 		//custodian 1
 		if testCase.Output().Custodian1 != nil {
-			custodianKey := statedb.GenerateCustodianStateObjectKey(beaconHeight, testCase.Output().Custodian1[0])
+			custodianKey := statedb.GenerateCustodianStateObjectKey(testCase.Output().Custodian1[0])
 			custodian, ok := suite.currentPortalState.CustodianPoolState[custodianKey.String()]
 			if !ok {
 				suite.T().Errorf("custodian %v not found", custodianKey.String())
@@ -611,17 +586,17 @@ func (suite *PortalProducerSuite) verifyAutoLiquidationExchangeRates(testCases [
 				suite.T().Errorf("free collateral is not equal, %v != %v", i1, freeCollateral)
 			}
 
-			if i2 != holdPublicToken["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"] {
-				suite.T().Errorf("hold public token is not equal, %v != %v", i2, holdPublicToken["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"])
+			if i2 != holdPublicToken[common.PortalBNBIDStr] {
+				suite.T().Errorf("hold public token is not equal, %v != %v", i2, holdPublicToken[common.PortalBNBIDStr])
 			}
 
-			if i3 != lockedAmountCollateral["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"] {
-				suite.T().Errorf("lock amount collateral is not equal, %v != %v", i3, lockedAmountCollateral["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"])
+			if i3 != lockedAmountCollateral[common.PortalBNBIDStr] {
+				suite.T().Errorf("lock amount collateral is not equal, %v != %v", i3, lockedAmountCollateral[common.PortalBNBIDStr])
 			}
 		}
 
 		if testCase.Output().Custodian2 != nil {
-			custodianKey := statedb.GenerateCustodianStateObjectKey(beaconHeight, testCase.Output().Custodian2[0])
+			custodianKey := statedb.GenerateCustodianStateObjectKey(testCase.Output().Custodian2[0])
 			custodian, ok := suite.currentPortalState.CustodianPoolState[custodianKey.String()]
 			if !ok {
 				suite.T().Errorf("custodian %v not found", custodianKey.String())
@@ -641,26 +616,26 @@ func (suite *PortalProducerSuite) verifyAutoLiquidationExchangeRates(testCases [
 				suite.T().Errorf("free collateral is not equal, %v != %v", i1, freeCollateral)
 			}
 
-			if i2 != holdPublicToken["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"] {
-				suite.T().Errorf("hold public token is not equal, %v != %v", i2, holdPublicToken["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"])
+			if i2 != holdPublicToken[common.PortalBNBIDStr] {
+				suite.T().Errorf("hold public token is not equal, %v != %v", i2, holdPublicToken[common.PortalBNBIDStr])
 			}
 
-			if i3 != lockedAmountCollateral["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"] {
-				suite.T().Errorf("lock amount collateral is not equal, %v != %v", i3, lockedAmountCollateral["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"])
+			if i3 != lockedAmountCollateral[common.PortalBNBIDStr] {
+				suite.T().Errorf("lock amount collateral is not equal, %v != %v", i3, lockedAmountCollateral[common.PortalBNBIDStr])
 			}
 		}
 
 		//liquidation pool
 		if testCase.Output().LiquidationPool != nil {
-			liquidationPoolKey := statedb.GeneratePortalLiquidationPoolObjectKey(beaconHeight)
+			liquidationPoolKey := statedb.GeneratePortalLiquidationPoolObjectKey()
 			liquidationPool, ok := suite.currentPortalState.LiquidationPool[liquidationPoolKey.String()]
 
-			if ok && testCase.Output().LiquidationPool[0] != liquidationPool.Rates()["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"].PubTokenAmount {
-				suite.T().Errorf("hold public token is not equal, %v != %v", testCase.Output().LiquidationPool[0], liquidationPool.Rates()["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"].PubTokenAmount)
+			if ok && testCase.Output().LiquidationPool[0] != liquidationPool.Rates()[common.PortalBNBIDStr].PubTokenAmount {
+				suite.T().Errorf("hold public token is not equal, %v != %v", testCase.Output().LiquidationPool[0], liquidationPool.Rates()[common.PortalBNBIDStr].PubTokenAmount)
 			}
 
-			if ok && testCase.Output().LiquidationPool[1] != liquidationPool.Rates()["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"].CollateralAmount {
-				suite.T().Errorf("hold amount collateral is not equal, %v != %v", testCase.Output().LiquidationPool[1], liquidationPool.Rates()["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"].CollateralAmount)
+			if ok && testCase.Output().LiquidationPool[1] != liquidationPool.Rates()[common.PortalBNBIDStr].CollateralAmount {
+				suite.T().Errorf("hold amount collateral is not equal, %v != %v", testCase.Output().LiquidationPool[1], liquidationPool.Rates()[common.PortalBNBIDStr].CollateralAmount)
 			}
 		}
 	}
@@ -677,14 +652,17 @@ func (suite *PortalProducerSuite) verifyPortingRequest(testCases []PortingReques
 		key := statedb.GeneratePortalStatusObjectKey(statedb.PortalPortingRequestStatusPrefix(), []byte(testCase.Input().Meta.UniqueRegisterId))
 		trieMock.On("TryGet", key[:]).Return(nil, nil)
 
-		blockChain := suite.SetupMockBlockChain(trieMock)
+		stateDB := suite.SetupMockStateDB(trieMock)
+		blockChain := &BlockChain{}
 
 		value, err := blockChain.buildInstructionsForPortingRequest(
+			stateDB,
 			actionContentBase64Str,
 			testCase.Input().ShardID,
 			testCase.Input().Meta.Type,
 			suite.currentPortalState,
 			beaconHeight,
+			suite.portalParams,
 		)
 
 		fmt.Printf("Testcase %v: instruction %#v", testCase.TestCaseName, value)
@@ -713,7 +691,7 @@ func (suite *PortalProducerSuite) verifyPortingRequest(testCases []PortingReques
 
 		for _, itemCustodian := range portingRequestContent.Custodian {
 			//update custodian state
-			custodianKey := statedb.GenerateCustodianStateObjectKey(beaconHeight, itemCustodian.IncAddress)
+			custodianKey := statedb.GenerateCustodianStateObjectKey(itemCustodian.IncAddress)
 			custodian := suite.currentPortalState.CustodianPoolState[custodianKey.String()]
 
 			if testCase.Output().Custodian1 != nil && itemCustodian.IncAddress == testCase.Output().Custodian1[0] {
@@ -731,12 +709,12 @@ func (suite *PortalProducerSuite) verifyPortingRequest(testCases []PortingReques
 					suite.T().Errorf("free collateral is not equal, %v != %v", i1, freeCollateral)
 				}
 
-				if i2 != holdPublicToken["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"] {
-					suite.T().Errorf("hold public token is not equal, %v != %v", i2, holdPublicToken["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"])
+				if i2 != holdPublicToken[common.PortalBNBIDStr] {
+					suite.T().Errorf("hold public token is not equal, %v != %v", i2, holdPublicToken[common.PortalBNBIDStr])
 				}
 
-				if i3 != lockedAmountCollateral["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"] {
-					suite.T().Errorf("lock amount collateral is not equal, %v != %v", i3, lockedAmountCollateral["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"])
+				if i3 != lockedAmountCollateral[common.PortalBNBIDStr] {
+					suite.T().Errorf("lock amount collateral is not equal, %v != %v", i3, lockedAmountCollateral[common.PortalBNBIDStr])
 				}
 			}
 
@@ -755,12 +733,12 @@ func (suite *PortalProducerSuite) verifyPortingRequest(testCases []PortingReques
 					suite.T().Errorf("free collateral is not equal, %v != %v", i1, freeCollateral)
 				}
 
-				if i2 != holdPublicToken["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"] {
-					suite.T().Errorf("hold public token is not equal, %v != %v", i2, holdPublicToken["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"])
+				if i2 != holdPublicToken[common.PortalBNBIDStr] {
+					suite.T().Errorf("hold public token is not equal, %v != %v", i2, holdPublicToken[common.PortalBNBIDStr])
 				}
 
-				if i3 != lockedAmountCollateral["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"] {
-					suite.T().Errorf("lock amount collateral is not equal, %v != %v", i3, lockedAmountCollateral["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"])
+				if i3 != lockedAmountCollateral[common.PortalBNBIDStr] {
+					suite.T().Errorf("lock amount collateral is not equal, %v != %v", i3, lockedAmountCollateral[common.PortalBNBIDStr])
 				}
 			}
 		}
@@ -770,7 +748,7 @@ func (suite *PortalProducerSuite) verifyPortingRequest(testCases []PortingReques
 /************************ Custodian deposit test ************************/
 const ShardIDHardCode = 0
 const BeaconHeight = 1
-const BNBTokenID = "b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"
+const BNBTokenID = common.PortalBNBIDStr
 const BNBRemoteAddress = "tbnb1fau9kq605jwkyfea2knw495we8cpa47r9r6uxv"
 
 type CustodianDepositOutput struct {
@@ -782,7 +760,7 @@ type CustodianDepositOutput struct {
 
 type CustodianDepositInput struct {
 	IncognitoAddress string
-	RemoteAddresses  []statedb.RemoteAddress
+	RemoteAddresses  map[string]string
 	DepositedAmount  uint64
 }
 
@@ -818,7 +796,7 @@ func buildPortalCustodianDepositAction(
 
 func buildPortalCustodianDepositContent(
 	custodianAddressStr string,
-	remoteAddresses []statedb.RemoteAddress,
+	remoteAddresses map[string]string,
 	depositedAmount uint64,
 ) string {
 	custodianDepositContent := metadata.PortalCustodianDepositContent{
@@ -838,10 +816,8 @@ func getTestCasesForCustodianDeposit() []*CustodianDepositTestCase {
 			TestCaseName: "Custodian deposit when custodian pool is empty",
 			Input: CustodianDepositInput{
 				IncognitoAddress: "12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ",
-				RemoteAddresses: []statedb.RemoteAddress{
-					*statedb.NewRemoteAddressWithValue(
-						BNBTokenID,
-						BNBRemoteAddress),
+				RemoteAddresses: map[string]string{
+					BNBTokenID: BNBRemoteAddress,
 				},
 				DepositedAmount: 1000 * 1e9,
 			},
@@ -855,10 +831,8 @@ func getTestCasesForCustodianDeposit() []*CustodianDepositTestCase {
 			TestCaseName: "Custodian deposit when custodian pool has one custodian before",
 			Input: CustodianDepositInput{
 				IncognitoAddress: "12Rwz4HXkVABgRnSb5Gfu1FaJ7auo3fLNXVGFhxx1dSytxHpWhbkimT1Mv5Z2oCMsssSXTVsapY8QGBZd2J4mPiCTzJAtMyCzb4dDcy",
-				RemoteAddresses: []statedb.RemoteAddress{
-					*statedb.NewRemoteAddressWithValue(
-						BNBTokenID,
-						BNBRemoteAddress),
+				RemoteAddresses: map[string]string{
+					BNBTokenID: BNBRemoteAddress,
 				},
 				DepositedAmount: 2000 * 1e9,
 			},
@@ -871,10 +845,8 @@ func getTestCasesForCustodianDeposit() []*CustodianDepositTestCase {
 			TestCaseName: "Custodian deposit more",
 			Input: CustodianDepositInput{
 				IncognitoAddress: "12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ",
-				RemoteAddresses: []statedb.RemoteAddress{
-					*statedb.NewRemoteAddressWithValue(
-						BNBTokenID,
-						BNBRemoteAddress),
+				RemoteAddresses: map[string]string{
+					BNBTokenID: BNBRemoteAddress,
 				},
 				DepositedAmount: 3000 * 1e9,
 			},
@@ -901,7 +873,7 @@ func getTestCasesForCustodianDeposit() []*CustodianDepositTestCase {
 				testcases[i].Input.DepositedAmount,
 				nil, nil,
 				testcases[i].Input.RemoteAddresses,
-				0,
+				nil,
 			)
 			custodianPool[custodianKey.String()] = custodianState
 		} else {
@@ -938,7 +910,7 @@ func (suite *PortalProducerSuite) TestCustodianDeposit() {
 		shardID := byte(ShardIDHardCode)
 		metaType, _ := strconv.Atoi(action[0])
 		contentStr := action[1]
-		newInsts, err := bc.buildInstructionsForCustodianDeposit(contentStr, shardID, metaType, suite.currentPortalState, uint64(BeaconHeight))
+		newInsts, err := bc.buildInstructionsForCustodianDeposit(contentStr, shardID, metaType, suite.currentPortalState, uint64(BeaconHeight), suite.portalParams)
 
 		// compare results to Outputs of test case
 		suite.Nil(err)
@@ -1028,30 +1000,25 @@ func buildPortalRedeemRequestContent(
 	return string(redeemRequestContentBytes)
 }
 
-func (suite *PortalProducerSuite) SetupRedeemRequest(beaconHeight uint64) {
+func (suite *PortalProducerSuite) SetupRedeemRequest() {
 	// set up exchange rates
 	rates := make(map[string]statedb.FinalExchangeRatesDetail)
-	rates["b832e5d3b1f01a4f0623f7fe91d6673461e1f5d37d91fe78c5c2e6183ff39696"] = statedb.FinalExchangeRatesDetail{
+	rates[common.PortalBTCIDStr] = statedb.FinalExchangeRatesDetail{
 		Amount: 8000000000,
 	}
-	rates["b2655152784e8639fa19521a7035f331eea1f1e911b2f3200a507ebb4554387b"] = statedb.FinalExchangeRatesDetail{
+	rates[common.PortalBNBIDStr] = statedb.FinalExchangeRatesDetail{
 		Amount: 20000000,
 	}
-	rates["0000000000000000000000000000000000000000000000000000000000000004"] = statedb.FinalExchangeRatesDetail{
+	rates[common.PRVIDStr] = statedb.FinalExchangeRatesDetail{
 		Amount: 500000,
 	}
 
-	exchangeRates := make(map[string]*statedb.FinalExchangeRatesState)
-	exchangeRatesKey := statedb.GeneratePortalFinalExchangeRatesStateObjectKey(beaconHeight)
-	exchangeRates[exchangeRatesKey.String()] = statedb.NewFinalExchangeRatesStateWithValue(rates)
-	suite.currentPortalState.FinalExchangeRatesState = exchangeRates
+	suite.currentPortalState.FinalExchangeRatesState = statedb.NewFinalExchangeRatesStateWithValue(rates)
 
 	// set up custodian pool
-	remoteAddresses := make([]statedb.RemoteAddress, 0)
-	remoteAddresses = append(
-		remoteAddresses,
-		*statedb.NewRemoteAddressWithValue(BNBTokenID, BNBRemoteAddress),
-	)
+	remoteAddresses := map[string]string{
+		BNBTokenID: BNBRemoteAddress,
+	}
 
 	custodianStates := []*statedb.CustodianState{
 		statedb.NewCustodianStateWithValue(
@@ -1065,7 +1032,7 @@ func (suite *PortalProducerSuite) SetupRedeemRequest(beaconHeight uint64) {
 				BNBTokenID: 600 * 1e9, // lock 600 PRV
 			},
 			remoteAddresses,
-			0,
+			nil,
 		),
 		statedb.NewCustodianStateWithValue(
 			"12Rwz4HXkVABgRnSb5Gfu1FaJ7auo3fLNXVGFhxx1dSytxHpWhbkimT1Mv5Z2oCMsssSXTVsapY8QGBZd2J4mPiCTzJAtMyCzb4dDcy",
@@ -1078,13 +1045,13 @@ func (suite *PortalProducerSuite) SetupRedeemRequest(beaconHeight uint64) {
 				BNBTokenID: 3000 * 1e9, // lock 3000 PRV
 			},
 			remoteAddresses,
-			0,
+			nil,
 		),
 	}
 
 	custodian := make(map[string]*statedb.CustodianState)
 	for _, cus := range custodianStates {
-		custodianKey := statedb.GenerateCustodianStateObjectKey(beaconHeight, cus.GetIncognitoAddress())
+		custodianKey := statedb.GenerateCustodianStateObjectKey(cus.GetIncognitoAddress())
 		custodian[custodianKey.String()] = cus
 	}
 
@@ -1150,7 +1117,7 @@ func getTestCasesForRedeemRequest() []*RedeemRequestTestCase {
 	//		testcases[i].Input.RemoteAddresses,
 	//		testcases[i].Input.DepositedAmount)
 	//
-	//	custodianKey := statedb.GenerateCustodianStateObjectKey(uint64(BeaconHeight), testcases[i].Input.IncognitoAddress)
+	//	custodianKey := statedb.GenerateCustodianStateObjectKey(testcases[i].Input.IncognitoAddress)
 	//	if custodianPool[custodianKey.String()] == nil {
 	//		custodianState := statedb.NewCustodianStateWithValue(
 	//			testcases[i].Input.IncognitoAddress,
@@ -20,6 +20,7 @@ import (
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/pkg/errors"
 )
 
@@ -34,7 +35,13 @@ import (
 //	Return:
 //	- No error: valid and can be sign
 //	- Error: invalid new block
-func (blockchain *BlockChain) VerifyPreSignBeaconBlock(beaconBlock *BeaconBlock, isPreSign bool) error {
+func (blockchain *BlockChain) VerifyPreSignBeaconBlock(beaconBlock *BeaconBlock, isPreSign bool) (err error) {
+	_, span := tracing.StartSpan(tracing.ContextForBlock(beaconBlock.Hash().String()), "blockchain.VerifyPreSignBeaconBlock",
+		tracing.Uint64("height", beaconBlock.GetHeight()), tracing.Int("instructions", len(beaconBlock.Body.Instructions)))
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
 	//get view that block link to
 	preHash := beaconBlock.Header.PreviousBlockHash
 	view := blockchain.BeaconChain.GetViewByHash(preHash)
@@ -86,8 +93,14 @@ CONTINUE_VERIFY:
 // var bcStart time.Time
 // var bcAllTime time.Duration
 
-func (blockchain *BlockChain) InsertBeaconBlock(beaconBlock *BeaconBlock, shouldValidate bool) error {
+func (blockchain *BlockChain) InsertBeaconBlock(beaconBlock *BeaconBlock, shouldValidate bool) (err error) {
 	blockHash := beaconBlock.Hash()
+	ctx, span := tracing.StartSpan(tracing.ContextForBlock(blockHash.String()), "blockchain.InsertBeaconBlock",
+		tracing.Uint64("height", beaconBlock.GetHeight()), tracing.Bool("shouldValidate", shouldValidate))
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
 	Logger.log.Infof("BEACON | InsertBeaconBlock  %+v with hash %+v", beaconBlock.Header.Height, blockHash.String())
 	// if beaconBlock.GetHeight() == 2 {
 	// 	bcTmp = 0
@@ -149,7 +162,7 @@ func (blockchain *BlockChain) InsertBeaconBlock(beaconBlock *BeaconBlock, should
 	}
 
 	// Backup beststate
-	err := rawdbv2.CleanUpPreviousBeaconBestState(blockchain.GetBeaconChainDatabase())
+	err = rawdbv2.CleanUpPreviousBeaconBestState(blockchain.GetBeaconChainDatabase())
 	if err != nil {
 		return NewBlockChainError(CleanBackUpError, err)
 	}
@@ -163,7 +176,10 @@ func (blockchain *BlockChain) InsertBeaconBlock(beaconBlock *BeaconBlock, should
 	Logger.log.Debugf("BEACON | Update BestState With Beacon Block, Beacon Block Height %+v with hash %+v", beaconBlock.Header.Height, blockHash)
 	// Update best state with new beaconBlock
 
+	_, updateSpan := tracing.StartSpan(ctx, "blockchain.updateBeaconBestState")
 	newBestState, err := curView.updateBeaconBestState(beaconBlock, blockchain, committeeChange)
+	updateSpan.SetError(err)
+	updateSpan.End()
 	if err != nil {
 		return err
 	}
@@ -192,7 +208,11 @@ func (blockchain *BlockChain) InsertBeaconBlock(beaconBlock *BeaconBlock, should
 	}
 
 	Logger.log.Infof("BEACON | Process Store Beacon Block Height %+v with hash %+v", beaconBlock.Header.Height, blockHash)
-	if err := blockchain.processStoreBeaconBlock(newBestState, beaconBlock, committeeChange); err != nil {
+	_, storeSpan := tracing.StartSpan(ctx, "blockchain.processStoreBeaconBlock")
+	err = blockchain.processStoreBeaconBlock(newBestState, beaconBlock, committeeChange)
+	storeSpan.SetError(err)
+	storeSpan.End()
+	if err != nil {
 		return err
 	}
 
@@ -1459,6 +1479,18 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 	}
 	//}
 
+	// execute, store Delegation Instruction
+	err = blockchain.processDelegationInstructions(newBestState.featureStateDB, beaconBlock)
+	if err != nil {
+		return NewBlockChainError(ProcessDelegationInstructionError, err)
+	}
+
+	// execute, store HTLC Instruction
+	err = blockchain.processHTLCInstructions(newBestState.featureStateDB, beaconBlock)
+	if err != nil {
+		return NewBlockChainError(ProcessHTLCInstructionError, err)
+	}
+
 	// execute, store Ralaying Instruction
 	err = blockchain.processRelayingInstructions(beaconBlock)
 	if err != nil {
@@ -1470,50 +1502,27 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 	//	return err
 	//}
 
-	consensusRootHash, err := newBestState.consensusStateDB.Commit(true)
-	if err != nil {
-		return err
-	}
-	err = newBestState.consensusStateDB.Database().TrieDB().Commit(consensusRootHash, false)
+	// the state and the block are written in one batch, the state databases are committed in parallel
+	batch := blockchain.GetBeaconChainDatabase().NewBatch()
+	stateDBs := []*statedb.StateDB{
+		newBestState.consensusStateDB,
+		newBestState.featureStateDB,
+		newBestState.rewardStateDB,
+		newBestState.slashStateDB,
+	}
+	startTimeCommitState := time.Now()
+	roots, err := statedb.CommitStateDBs(batch, stateDBs...)
 	if err != nil {
 		return err
 	}
-
+	beaconCommitStateTimer.UpdateSince(startTimeCommitState)
+	consensusRootHash, featureRootHash, rewardRootHash, slashRootHash := roots[0], roots[1], roots[2], roots[3]
 	newBestState.ConsensusStateDBRootHash = consensusRootHash
-	featureRootHash, err := newBestState.featureStateDB.Commit(true)
-	if err != nil {
-		return err
-	}
-	err = newBestState.featureStateDB.Database().TrieDB().Commit(featureRootHash, false)
-	if err != nil {
-		return err
-	}
 	newBestState.FeatureStateDBRootHash = featureRootHash
-	rewardRootHash, err := newBestState.rewardStateDB.Commit(true)
-	if err != nil {
-		return err
-	}
-	err = newBestState.rewardStateDB.Database().TrieDB().Commit(rewardRootHash, false)
-	if err != nil {
-		return err
-	}
 	newBestState.RewardStateDBRootHash = rewardRootHash
-	slashRootHash, err := newBestState.slashStateDB.Commit(true)
-	if err != nil {
-		return err
-	}
-	err = newBestState.slashStateDB.Database().TrieDB().Commit(slashRootHash, false)
-	if err != nil {
-		return err
-	}
 	newBestState.SlashStateDBRootHash = slashRootHash
-	newBestState.consensusStateDB.ClearObjects()
-	newBestState.rewardStateDB.ClearObjects()
-	newBestState.featureStateDB.ClearObjects()
-	newBestState.slashStateDB.ClearObjects()
 	//statedb===========================END
 
-	batch := blockchain.GetBeaconChainDatabase().NewBatch()
 	//State Root Hash
 	bRH := BeaconRootHash{
 		ConsensusStateDBRootHash: consensusRootHash,
@@ -1537,11 +1546,13 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 	newFinalView := blockchain.BeaconChain.multiView.GetFinalView()
 
 	storeBlock := newFinalView.GetBlock()
+	finalizedBlocks := []*BeaconBlock{}
 	for finalView == nil || storeBlock.GetHeight() > finalView.GetHeight() {
 		err := rawdbv2.StoreFinalizedBeaconBlockHashByIndex(batch, storeBlock.GetHeight(), *storeBlock.Hash())
 		if err != nil {
 			return NewBlockChainError(StoreBeaconBlockError, err)
 		}
+		finalizedBlocks = append(finalizedBlocks, storeBlock.(*BeaconBlock))
 		if storeBlock.GetHeight() == 1 {
 			break
 		}
@@ -1565,6 +1576,14 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 	if err := batch.Write(); err != nil {
 		return NewBlockChainError(StoreBeaconBlockError, err)
 	}
+	if err := statedb.UncacheStateDBs(batch, stateDBs...); err != nil {
+		return NewBlockChainError(StoreBeaconBlockError, err)
+	}
+	// notify the blocks becoming final, lowest first, publishing in the order blocks are stored keeps the heights of
+	// the notifications increasing
+	for i := len(finalizedBlocks) - 1; i >= 0; i-- {
+		blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.FinalizedBeaconBlockTopic, finalizedBlocks[i]))
+	}
 	beaconStoreBlockTimer.UpdateSince(startTimeProcessStoreBeaconBlock)
 
 	if !blockchain.config.ChainParams.IsBackup {
@@ -1572,7 +1591,7 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 	}
 	if (newBestState.GetHeight()+1)%blockchain.config.ChainParams.Epoch == 0 {
 
-		err := blockchain.GetBeaconChainDatabase().Backup(fmt.Sprintf("../../backup/beacon/%d", newBestState.Epoch))
+		err := blockchain.GetBeaconChainDatabase().Backup("../../backup/beacon", newBestState.backupInfo())
 		if err != nil {
 			blockchain.GetBeaconChainDatabase().RemoveBackup(fmt.Sprintf("../../backup/beacon/%d", newBestState.Epoch))
 			return nil
@@ -56,7 +56,14 @@ func (blockchain *BlockChain) collectStatefulActions(
 			metadata.PortalLiquidationCustodianDepositMetaV2,
 			metadata.PortalLiquidationCustodianDepositResponseMeta,
 			metadata.PortalReqMatchingRedeemMeta,
-			metadata.PortalTopUpWaitingPortingRequestMeta:
+			metadata.PortalTopUpWaitingPortingRequestMeta,
+			metadata.DelegateMeta,
+			metadata.UndelegateMeta,
+			metadata.RedelegateMeta,
+			metadata.DelegationCommissionMeta,
+			metadata.HTLCLockRequestMeta,
+			metadata.HTLCClaimRequestMeta,
+			metadata.HTLCRefundRequestMeta:
 			statefulInsts = append(statefulInsts, inst)
 
 		default:
@@ -131,6 +138,10 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 	portalReqMatchingRedeemActionsByShardID := map[byte][][]string{}
 	portalTopUpWaitingPortingActionsByShardID := map[byte][][]string{}
 
+	// delegation instructions, in order of shards
+	delegationActions := [][]string{}
+	htlcActions := [][]string{}
+
 	var keys []int
 	for k := range statefulActionsByShardID {
 		keys = append(keys, int(k))
@@ -265,6 +276,10 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 					action,
 					shardID,
 				)
+			case metadata.DelegateMeta, metadata.UndelegateMeta, metadata.RedelegateMeta, metadata.DelegationCommissionMeta:
+				delegationActions = append(delegationActions, action)
+			case metadata.HTLCLockRequestMeta, metadata.HTLCClaimRequestMeta, metadata.HTLCRefundRequestMeta:
+				htlcActions = append(htlcActions, action)
 			case metadata.RelayingBNBHeaderMeta:
 				pm.relayingChains[metadata.RelayingBNBHeaderMeta].putAction(action)
 			case metadata.RelayingBTCHeaderMeta:
@@ -282,6 +297,18 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 		}
 	}
 
+	// handle delegation instructions, before features which may return early on error
+	delegationInsts := blockchain.buildDelegationInstructions(stateDB, beaconHeight, delegationActions)
+	if len(delegationInsts) > 0 {
+		instructions = append(instructions, delegationInsts...)
+	}
+
+	// handle htlc instructions
+	htlcInsts := blockchain.buildHTLCInstructions(stateDB, beaconHeight, htlcActions)
+	if len(htlcInsts) > 0 {
+		instructions = append(instructions, htlcInsts...)
+	}
+
 	pdeInsts, err := blockchain.handlePDEInsts(
 		beaconHeight-1, currentPDEState,
 		pdeContributionActionsByShardID,
@@ -62,6 +62,8 @@ type Config struct {
 	Server            Server
 	ConsensusEngine   ConsensusEngine
 	Highway           Highway
+	Archive           bool   // keep and serve the state of every block of the past
+	PruneEpochs       uint64 // delete the body of the finalized shard blocks older than this number of epochs, 0 keeps them
 }
 
 func NewBlockChain(config *Config, isTest bool) *BlockChain {
@@ -88,6 +90,9 @@ func (blockchain *BlockChain) Init(config *Config) error {
 	if config.ChainParams == nil {
 		return NewBlockChainError(UnExpectedError, errors.New("Chain parameters is not config"))
 	}
+	if err := config.ChainParams.checkBreakPoints(); err != nil {
+		return NewBlockChainError(UnExpectedError, err)
+	}
 	blockchain.config = *config
 	blockchain.config.IsBlockGenStarted = false
 	blockchain.IsTest = false
@@ -573,19 +578,13 @@ func (blockchain *BlockChain) GetShardStakingTx(shardView *ShardBestState) (map[
 			if err != nil { //no transaction in this node
 				continue
 			}
-			shardBlockBytes, err := rawdbv2.GetShardBlockByHash(sdb, blockHash)
+			shardBlock, txData, err := blockchain.getStoredTransaction(byte(shardID), blockHash, txindex, *stakingTxHash)
 			if err != nil { //no transaction in this node
 				panic("Have transaction but cannot found block")
 			}
-			shardBlock := NewShardBlock()
-			err = json.Unmarshal(shardBlockBytes, shardBlock)
-			if err != nil {
-				panic("Cannot unmarshal shardblock")
-			}
 			if shardBlock.GetShardID() != int(shardID) {
 				continue
 			}
-			txData := shardBlock.Body.Transactions[txindex]
 			committeePk := txData.GetMetadata().(*metadata.StakingMetadata).CommitteePublicKey
 			shardStakingTx[committeePk] = stakingtx
 		}
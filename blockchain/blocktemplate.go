@@ -0,0 +1,166 @@
+package blockchain
+
+import (
+	"math"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// blockTemplateQuota is the share of common.MaxBlockSize in percent txs of a metadata type can take in a shard block,
+// so requests to pdex can not starve normal transfers. Metadata types not listed are only limited by the block size
+var blockTemplateQuota = map[int]uint64{
+	metadata.PDETradeRequestMeta:                   30,
+	metadata.PDECrossPoolTradeRequestMeta:          30,
+	metadata.PDEContributionMeta:                   10,
+	metadata.PDEPRVRequiredContributionRequestMeta: 10,
+	metadata.PDEWithdrawalRequestMeta:              10,
+	metadata.PDEFeeWithdrawalRequestMeta:           10,
+}
+
+// blockTemplateTotalQuota is the share of common.MaxBlockSize in percent txs of all metadata types of
+// blockTemplateQuota can take together, the rest of the block is kept for transfers and the other txs
+const blockTemplateTotalQuota = 50
+
+// BlockTemplateTx is a tx chosen for the next shard block
+type BlockTemplateTx struct {
+	TxHash       string
+	MetadataType int
+	Size         uint64 // KB
+	Fee          uint64 // nano PRV, fee in token is converted with the PRV pool of pdex
+	FeePerKB     uint64
+}
+
+// BlockTemplate is the list of pending txs a shard producer tries to put in its next block, by priority
+type BlockTemplate struct {
+	ShardID      byte
+	BeaconHeight uint64
+	Txs          []BlockTemplateTx
+	Size         uint64
+	Fee          uint64
+	MetadataSize map[int]uint64 // size of txs of each metadata type with a quota
+	QuotaSize    uint64         // size of txs of all metadata types with a quota
+	NumSkipped   int            // pending txs left out by the block size or a quota
+}
+
+type blockTemplateCandidate struct {
+	tx           metadata.Transaction
+	hash         common.Hash
+	metadataType int
+	size         uint64
+	fee          uint64
+}
+
+func (candidate blockTemplateCandidate) feePerKB() float64 {
+	return float64(candidate.fee) / float64(candidate.size)
+}
+
+// feeInPRV returns fee of tx in nano PRV, a fee in token without PRV pool on pdex is ignored
+func feeInPRV(tx metadata.Transaction, beaconHeight uint64, beaconFeatureStateDB *statedb.StateDB) uint64 {
+	fee := tx.GetTxFee()
+	feeToken := tx.GetTxFeeToken()
+	if tx.GetType() != common.TxCustomTokenPrivacyType || feeToken == 0 || beaconFeatureStateDB == nil {
+		return fee
+	}
+	feeTokenInPRV, err := metadata.ConvertPrivacyTokenToNativeToken(feeToken, tx.GetTokenID(), int64(beaconHeight), beaconFeatureStateDB)
+	if err != nil {
+		Logger.log.Debugf("Block template: can not convert fee of tx %+v to PRV %+v", tx.Hash().String(), err)
+		return fee
+	}
+	return fee + uint64(math.Floor(feeTokenInPRV))
+}
+
+// packBlockTemplate orders candidates by fee per KB and takes each one which still fits in maxSize, in the quota
+// of its metadata type and in totalQuota percent of maxSize shared by all metadata types with a quota.
+// Skipping a tx that does not fit instead of stopping lets smaller txs fill the remaining space
+func packBlockTemplate(candidates []blockTemplateCandidate, maxSize uint64, quota map[int]uint64, totalQuota uint64) ([]blockTemplateCandidate, uint64, map[int]uint64) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		feePerKBi, feePerKBj := candidates[i].feePerKB(), candidates[j].feePerKB()
+		if feePerKBi != feePerKBj {
+			return feePerKBi > feePerKBj
+		}
+		if candidates[i].fee != candidates[j].fee {
+			return candidates[i].fee > candidates[j].fee
+		}
+		return candidates[i].hash.String() < candidates[j].hash.String()
+	})
+
+	chosen := []blockTemplateCandidate{}
+	totalSize := uint64(0)
+	metadataSize := make(map[int]uint64)
+	quotaSize := uint64(0)
+	for _, candidate := range candidates {
+		if totalSize+candidate.size >= maxSize {
+			continue
+		}
+		if percent, ok := quota[candidate.metadataType]; ok {
+			if (metadataSize[candidate.metadataType]+candidate.size)*100 > maxSize*percent {
+				continue
+			}
+			if (quotaSize+candidate.size)*100 > maxSize*totalQuota {
+				continue
+			}
+			metadataSize[candidate.metadataType] += candidate.size
+			quotaSize += candidate.size
+		}
+		totalSize += candidate.size
+		chosen = append(chosen, candidate)
+		if totalSize+1 >= maxSize {
+			break
+		}
+	}
+	return chosen, totalSize, metadataSize
+}
+
+// NewBlockTemplate chooses pending txs of the block generator for the next block of shardID,
+// txs are returned in the order they should be verified and added
+func (blockGenerator *BlockGenerator) NewBlockTemplate(shardID byte, beaconHeight uint64) (*BlockTemplate, []metadata.Transaction) {
+	sourceTxns := blockGenerator.GetPendingTxsV2()
+	var beaconFeatureStateDB *statedb.StateDB
+	if beaconBestState := blockGenerator.chain.GetBeaconBestState(); beaconBestState != nil {
+		beaconFeatureStateDB = beaconBestState.GetBeaconFeatureStateDB()
+	}
+
+	candidates := make([]blockTemplateCandidate, 0, len(sourceTxns))
+	for _, tx := range sourceTxns {
+		size := tx.GetTxActualSize()
+		if size == 0 {
+			size = 1
+		}
+		candidates = append(candidates, blockTemplateCandidate{
+			tx:           tx,
+			hash:         *tx.Hash(),
+			metadataType: tx.GetMetadataType(),
+			size:         size,
+			fee:          feeInPRV(tx, beaconHeight, beaconFeatureStateDB),
+		})
+	}
+	chosen, totalSize, metadataSize := packBlockTemplate(candidates, common.MaxBlockSize, blockTemplateQuota, blockTemplateTotalQuota)
+
+	template := &BlockTemplate{
+		ShardID:      shardID,
+		BeaconHeight: beaconHeight,
+		Txs:          make([]BlockTemplateTx, 0, len(chosen)),
+		Size:         totalSize,
+		MetadataSize: metadataSize,
+		NumSkipped:   len(candidates) - len(chosen),
+	}
+	for _, size := range metadataSize {
+		template.QuotaSize += size
+	}
+	txs := make([]metadata.Transaction, 0, len(chosen))
+	for _, candidate := range chosen {
+		template.Fee += candidate.fee
+		template.Txs = append(template.Txs, BlockTemplateTx{
+			TxHash:       candidate.hash.String(),
+			MetadataType: candidate.metadataType,
+			Size:         candidate.size,
+			Fee:          candidate.fee,
+			FeePerKB:     uint64(candidate.feePerKB()),
+		})
+		txs = append(txs, candidate.tx)
+	}
+	return template, txs
+}
@@ -0,0 +1,99 @@
+package blockchain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCandidate(name string, metadataType int, size uint64, fee uint64) blockTemplateCandidate {
+	return blockTemplateCandidate{
+		hash:         common.HashH([]byte(name)),
+		metadataType: metadataType,
+		size:         size,
+		fee:          fee,
+	}
+}
+
+func candidateHashes(candidates []blockTemplateCandidate) []common.Hash {
+	hashes := []common.Hash{}
+	for _, candidate := range candidates {
+		hashes = append(hashes, candidate.hash)
+	}
+	return hashes
+}
+
+func TestPackBlockTemplateOrderByFeePerKB(t *testing.T) {
+	low := newTestCandidate("low", metadata.InvalidMeta, 2, 20)
+	high := newTestCandidate("high", metadata.InvalidMeta, 1, 100)
+	medium := newTestCandidate("medium", metadata.InvalidMeta, 4, 200)
+	chosen, size, _ := packBlockTemplate([]blockTemplateCandidate{low, high, medium}, 100, nil, 100)
+	assert.Equal(t, candidateHashes([]blockTemplateCandidate{high, medium, low}), candidateHashes(chosen))
+	assert.Equal(t, uint64(7), size)
+}
+
+func TestPackBlockTemplateFillRemainingSpace(t *testing.T) {
+	// big does not fit after first, small still does
+	first := newTestCandidate("first", metadata.InvalidMeta, 6, 600)
+	big := newTestCandidate("big", metadata.InvalidMeta, 5, 400)
+	small := newTestCandidate("small", metadata.InvalidMeta, 2, 20)
+	chosen, size, _ := packBlockTemplate([]blockTemplateCandidate{small, big, first}, 10, nil, 100)
+	assert.Equal(t, candidateHashes([]blockTemplateCandidate{first, small}), candidateHashes(chosen))
+	assert.Equal(t, uint64(8), size)
+}
+
+func TestPackBlockTemplateQuota(t *testing.T) {
+	quota := map[int]uint64{metadata.PDETradeRequestMeta: 30}
+	candidates := []blockTemplateCandidate{}
+	for i := 0; i < 5; i++ {
+		candidates = append(candidates, newTestCandidate("trade"+string(rune('a'+i)), metadata.PDETradeRequestMeta, 10, 10000))
+	}
+	transfer := newTestCandidate("transfer", metadata.InvalidMeta, 10, 100)
+	candidates = append(candidates, transfer)
+
+	chosen, size, metadataSize := packBlockTemplate(candidates, 100, quota, 100)
+	assert.Equal(t, 4, len(chosen))
+	assert.Equal(t, uint64(40), size)
+	assert.Equal(t, uint64(30), metadataSize[metadata.PDETradeRequestMeta])
+	assert.Equal(t, transfer.hash, chosen[3].hash)
+}
+
+func TestPackBlockTemplateTotalQuota(t *testing.T) {
+	// every pdex request outbids the transfers, together they could fill the block with their own quotas
+	candidates := []blockTemplateCandidate{}
+	for metadataType := range blockTemplateQuota {
+		for i := 0; i < 5; i++ {
+			name := fmt.Sprintf("pde %d %d", metadataType, i)
+			candidates = append(candidates, newTestCandidate(name, metadataType, 5, 100000))
+		}
+	}
+	transfers := []blockTemplateCandidate{}
+	for i := 0; i < 20; i++ {
+		transfer := newTestCandidate(fmt.Sprintf("transfer %d", i), metadata.InvalidMeta, 5, 100)
+		transfers = append(transfers, transfer)
+		candidates = append(candidates, transfer)
+	}
+
+	chosen, size, metadataSize := packBlockTemplate(candidates, 200, blockTemplateQuota, blockTemplateTotalQuota)
+	quotaSize := uint64(0)
+	for metadataType, percent := range blockTemplateQuota {
+		assert.True(t, metadataSize[metadataType]*100 <= 200*percent)
+		quotaSize += metadataSize[metadataType]
+	}
+	assert.Equal(t, uint64(100), quotaSize)
+
+	// the transfers take the rest of the block, after the pdex requests paying more
+	numTransfers := 0
+	for i, candidate := range chosen {
+		if candidate.metadataType == metadata.InvalidMeta {
+			numTransfers++
+		} else {
+			assert.Equal(t, 0, numTransfers, "pdex request %d after a transfer", i)
+		}
+	}
+	assert.Equal(t, 19, numTransfers)
+	assert.Equal(t, uint64(195), size)
+}
@@ -9,7 +9,6 @@ import (
 	"testing"
 
 	"github.com/incognitochain/incognito-chain/common/base58"
-	"github.com/incognitochain/incognito-chain/mocks"
 	"github.com/pkg/errors"
 )
 
@@ -37,11 +36,13 @@ func TestGenerateInstruction(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
-			bc, shardID, beaconHeight, beaconBlocks, shardPendingValidator, shardCommittee := getGenerateInstructionTestcase(tc.pending, tc.val)
+			bc, view, shardID, beaconHeight, beaconBlocks, shardPendingValidator, shardCommittee := getGenerateInstructionTestcase(tc.pending, tc.val)
 
 			insts, _, _, err := bc.generateInstruction(
+				view,
 				shardID,
 				beaconHeight,
+				false,
 				beaconBlocks,
 				shardPendingValidator,
 				shardCommittee,
@@ -79,6 +80,7 @@ func TestGenerateInstruction(t *testing.T) {
 
 func getGenerateInstructionTestcase(pending, val int) (
 	*BlockChain,
+	*ShardBestState,
 	byte,
 	uint64,
 	[]*BeaconBlock,
@@ -86,8 +88,6 @@ func getGenerateInstructionTestcase(pending, val int) (
 	[]string,
 ) {
 	beaconHeight := uint64(100)
-	db := &mocks.DatabaseInterface{}
-	db.On("GetProducersBlackList", beaconHeight).Return(nil, nil)
 	bc := &BlockChain{
 		config: Config{
 			ChainParams: &Params{
@@ -95,26 +95,21 @@ func getGenerateInstructionTestcase(pending, val int) (
 				Offset:     1,
 				SwapOffset: 1,
 			},
-			DataBase: db,
-		},
-		BestState: &BestState{
-			Shard: map[byte]*ShardBestState{
-				byte(1): &ShardBestState{
-					ShardHeight:            1000,
-					NumOfBlocksByProducers: map[string]uint64{},
-					MaxShardCommitteeSize:  TestNetShardCommitteeSize,
-					MinShardCommitteeSize:  TestNetMinShardCommitteeSize,
-				},
-			},
 		},
 	}
+	view := &ShardBestState{
+		ShardHeight:            1000,
+		NumOfBlocksByProducers: map[string]uint64{},
+		MaxShardCommitteeSize:  TestNetShardCommitteeSize,
+		MinShardCommitteeSize:  TestNetMinShardCommitteeSize,
+	}
 
 	shardID := byte(1)
 	beaconBlocks := []*BeaconBlock{}
 	vals := keyStore()
 	shardPendingValidator := vals[:pending]
 	shardCommittee := vals[pending : pending+val]
-	return bc, shardID, beaconHeight, beaconBlocks, shardPendingValidator, shardCommittee
+	return bc, view, shardID, beaconHeight, beaconBlocks, shardPendingValidator, shardCommittee
 }
 
 func keyStore() []string {
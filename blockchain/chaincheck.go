@@ -0,0 +1,527 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// A block is stored with its state roots, the finalized block index and the views of its chain in one batch, but the
+// trie nodes of its states and the transaction index of a block not yet final are written before the batch. A node
+// stopped in the middle of storing a block, or a damaged disk, leaves a database the node fails on much later.
+// CheckBeaconDatabase and CheckShardDatabase walk the finalized block index of a chain and report what is wrong,
+// TruncateBeaconDatabase and TruncateShardDatabase remove it when the chain is good up to its final view.
+
+// Kinds of ChainProblem
+const (
+	ChainViewsProblem   = "views"   // the stored views can not be restored, or one of them has no good block
+	ChainIndexProblem   = "index"   // the finalized block index misses a height, or goes past the final view
+	ChainBlockProblem   = "block"   // a block is missing, can not be decoded or is not the block of its hash or height
+	ChainLinkProblem    = "link"    // a block does not point to the finalized block before it
+	ChainRootsProblem   = "roots"   // the state roots of a block are missing or a state can not be opened
+	ChainTxIndexProblem = "txindex" // a transaction has no index, or its index points to a missing block
+	ChainReplayProblem  = "replay"  // the state roots of a block are not the ones given by replaying the blocks
+)
+
+// ChainProblem - an inconsistency of the database of a chain
+type ChainProblem struct {
+	Kind    string
+	Height  uint64      // height of the block, 0 for the entries not bound to a finalized height
+	Hash    common.Hash // hash of the block, or of the transaction for the transaction index
+	Message string
+}
+
+// ChainReport - result of the check of the database of a chain
+type ChainReport struct {
+	ChainID        int    // common.BeaconChainDataBaseID for the beacon chain, the shard ID otherwise
+	FinalHeight    uint64 // height of the final view stored, 0 when the views can not be restored
+	IndexedHeight  uint64 // highest height of the finalized block index
+	LastGoodHeight uint64 // the finalized blocks and their states are good up to this height
+	Problems       []*ChainProblem
+}
+
+// ChainCheckOptions - range of the finalized heights to check, ToHeight 0 is the end of the index
+type ChainCheckOptions struct {
+	FromHeight uint64
+	ToHeight   uint64
+}
+
+// AddProblem - record a problem, a problem of a finalized block lowers the last good height below the block
+func (report *ChainReport) AddProblem(kind string, height uint64, hash common.Hash, format string, a ...interface{}) {
+	report.Problems = append(report.Problems, &ChainProblem{
+		Kind:    kind,
+		Height:  height,
+		Hash:    hash,
+		Message: fmt.Sprintf(format, a...),
+	})
+	if height > 0 && kind != ChainViewsProblem && kind != ChainTxIndexProblem && height <= report.LastGoodHeight {
+		report.LastGoodHeight = height - 1
+	}
+}
+
+// NeedRebuild - whether the chain is damaged at or below its final view: the node can not restart from the database,
+// which must be rebuilt from the good blocks
+func (report *ChainReport) NeedRebuild() bool {
+	return len(report.Problems) > 0 && (report.FinalHeight == 0 || report.LastGoodHeight < report.FinalHeight)
+}
+
+// RepairPlan - the steps which bring the database back to a good state
+func (report *ChainReport) RepairPlan() []string {
+	if len(report.Problems) == 0 {
+		return nil
+	}
+	if report.NeedRebuild() {
+		return []string{
+			fmt.Sprintf("rebuild the database by replaying the finalized blocks up to height %v (checkchain --replay --truncate), then sync from there", report.LastGoodHeight),
+			"or restore a backup of the chain, or resync it",
+		}
+	}
+	plan := []string{}
+	views, missingTxIndexes, danglingTxIndexes := 0, 0, 0
+	for _, problem := range report.Problems {
+		switch {
+		case problem.Kind == ChainViewsProblem:
+			views++
+		case problem.Kind == ChainTxIndexProblem && problem.Height > 0:
+			missingTxIndexes++
+		case problem.Kind == ChainTxIndexProblem:
+			danglingTxIndexes++
+		}
+	}
+	if views > 0 {
+		plan = append(plan, fmt.Sprintf("drop the %v views of blocks not final which are not good, with the views built on them", views))
+	}
+	if report.IndexedHeight > report.FinalHeight {
+		plan = append(plan, fmt.Sprintf("remove the finalized block index from height %v to %v, above the final view", report.FinalHeight+1, report.IndexedHeight))
+	}
+	if missingTxIndexes > 0 {
+		plan = append(plan, fmt.Sprintf("index again the %v transactions of finalized blocks with a wrong index", missingTxIndexes))
+	}
+	if danglingTxIndexes > 0 {
+		plan = append(plan, fmt.Sprintf("remove the %v transaction index entries of blocks not stored", danglingTxIndexes))
+	}
+	return plan
+}
+
+// checkedChain - access to the blocks and the states of a chain, shared by the checks of the beacon and the shards
+type checkedChain struct {
+	db            incdb.Database
+	stateDB       statedb.DatabaseAccessWarper
+	report        *ChainReport
+	finalizedHash func(height uint64) (*common.Hash, error)
+	getBlock      func(hash common.Hash) (common.BlockInterface, error)
+	getRoots      func(hash common.Hash) ([]common.Hash, error)
+	checkBlock    func(height uint64, block common.BlockInterface)
+}
+
+// checkStoredBlock - the block of hash is stored, decodes and is the block of its hash
+func (chain *checkedChain) checkStoredBlock(hash common.Hash) (common.BlockInterface, error) {
+	block, err := chain.getBlock(hash)
+	if err != nil {
+		return nil, err
+	}
+	if blockHash := block.Hash(); !blockHash.IsEqual(&hash) {
+		return nil, fmt.Errorf("block %v is stored under hash %v", blockHash.String(), hash.String())
+	}
+	return block, nil
+}
+
+// checkRoots - the state roots of the block of hash are stored and open
+func (chain *checkedChain) checkRoots(hash common.Hash) error {
+	roots, err := chain.getRoots(hash)
+	if err != nil {
+		return err
+	}
+	for _, root := range roots {
+		if _, err := statedb.NewWithPrefixTrie(root, chain.stateDB); err != nil {
+			return fmt.Errorf("state root %v can not be opened, error %v", root.String(), err)
+		}
+	}
+	return nil
+}
+
+// checkFinalizedBlocks - walk the finalized block index from the height of options up to its end
+func (chain *checkedChain) checkFinalizedBlocks(options ChainCheckOptions) {
+	report := chain.report
+	from := options.FromHeight
+	if from < 1 {
+		from = 1
+	}
+	var prevHash *common.Hash
+	if from > 1 {
+		prevHash, _ = chain.finalizedHash(from - 1)
+	}
+	// the index ends at the final view, a gap below it is a missing height
+	report.LastGoodHeight = ^uint64(0)
+	endOfIndex := false
+	for height := from; options.ToHeight == 0 || height <= options.ToHeight; height++ {
+		hash, err := chain.finalizedHash(height)
+		if err != nil {
+			if height <= report.FinalHeight {
+				report.AddProblem(ChainIndexProblem, height, common.Hash{}, "no finalized block at height %v below the final view %v", height, report.FinalHeight)
+				prevHash = nil
+				continue
+			}
+			endOfIndex = true
+			break
+		}
+		report.IndexedHeight = height
+		if height == report.FinalHeight+1 && report.FinalHeight > 0 {
+			report.AddProblem(ChainIndexProblem, height, *hash, "finalized block index goes past the final view %v", report.FinalHeight)
+		}
+		block, err := chain.checkStoredBlock(*hash)
+		if err != nil {
+			report.AddProblem(ChainBlockProblem, height, *hash, "%v", err)
+			prevHash = hash
+			continue
+		}
+		if block.GetHeight() != height {
+			report.AddProblem(ChainBlockProblem, height, *hash, "block of height %v is indexed at height %v", block.GetHeight(), height)
+		}
+		if prevHash != nil && block.GetPrevHash() != *prevHash {
+			report.AddProblem(ChainLinkProblem, height, *hash, "block points to %v, the finalized block before it is %v", block.GetPrevHash().String(), prevHash.String())
+		}
+		if err := chain.checkRoots(*hash); err != nil {
+			report.AddProblem(ChainRootsProblem, height, *hash, "%v", err)
+		}
+		if chain.checkBlock != nil {
+			chain.checkBlock(height, block)
+		}
+		prevHash = hash
+	}
+	// the heights past the checked range are taken as good
+	goodHeight := report.IndexedHeight
+	if !endOfIndex && report.FinalHeight > goodHeight {
+		goodHeight = report.FinalHeight
+	}
+	if report.LastGoodHeight > goodHeight {
+		report.LastGoodHeight = goodHeight
+	}
+}
+
+// checkViews - every view of the chain has a good block and good state roots, the first view is the final view
+func (chain *checkedChain) checkViews(views []common.Hash) {
+	for _, hash := range views {
+		block, err := chain.checkStoredBlock(hash)
+		if err != nil {
+			chain.report.AddProblem(ChainViewsProblem, 0, hash, "view has no good block, error %v", err)
+			continue
+		}
+		if err := chain.checkRoots(hash); err != nil {
+			chain.report.AddProblem(ChainViewsProblem, 0, hash, "view of height %v has no good state, error %v", block.GetHeight(), err)
+		}
+	}
+}
+
+func newCheckedBeaconChain(db incdb.Database, report *ChainReport) *checkedChain {
+	return &checkedChain{
+		db:      db,
+		stateDB: statedb.NewDatabaseAccessWarper(db),
+		report:  report,
+		finalizedHash: func(height uint64) (*common.Hash, error) {
+			return rawdbv2.GetFinalizedBeaconBlockHashByIndex(db, height)
+		},
+		getBlock: func(hash common.Hash) (common.BlockInterface, error) {
+			data, err := rawdbv2.GetBeaconBlockByHash(db, hash)
+			if err != nil {
+				return nil, fmt.Errorf("block is not stored")
+			}
+			block := NewBeaconBlock()
+			if err := json.Unmarshal(data, block); err != nil {
+				return nil, err
+			}
+			return block, nil
+		},
+		getRoots: func(hash common.Hash) ([]common.Hash, error) {
+			data, err := rawdbv2.GetBeaconRootsHash(db, hash)
+			if err != nil {
+				return nil, fmt.Errorf("no state roots, error %v", err)
+			}
+			bRH := &BeaconRootHash{}
+			if err := json.Unmarshal(data, bRH); err != nil {
+				return nil, err
+			}
+			return []common.Hash{bRH.ConsensusStateDBRootHash, bRH.FeatureStateDBRootHash, bRH.RewardStateDBRootHash, bRH.SlashStateDBRootHash}, nil
+		},
+	}
+}
+
+func newCheckedShardChain(db incdb.Database, shardID byte, report *ChainReport) *checkedChain {
+	chain := &checkedChain{
+		db:      db,
+		stateDB: statedb.NewDatabaseAccessWarper(db),
+		report:  report,
+		finalizedHash: func(height uint64) (*common.Hash, error) {
+			return rawdbv2.GetFinalizedShardBlockHashByIndex(db, shardID, height)
+		},
+		getBlock: func(hash common.Hash) (common.BlockInterface, error) {
+			data, err := rawdbv2.GetShardBlockByHash(db, hash)
+			if err != nil {
+				// a pruned block is checked by its header
+				data, err = rawdbv2.GetPrunedShardBlockByHash(db, hash)
+				if err != nil {
+					return nil, fmt.Errorf("block is not stored")
+				}
+			}
+			block := NewShardBlock()
+			if err := json.Unmarshal(data, block); err != nil {
+				return nil, err
+			}
+			if block.Header.ShardID != shardID {
+				return nil, fmt.Errorf("block of shard %v is stored in the database of shard %v", block.Header.ShardID, shardID)
+			}
+			return block, nil
+		},
+		getRoots: func(hash common.Hash) ([]common.Hash, error) {
+			data, err := rawdbv2.GetShardRootsHash(db, shardID, hash)
+			if err != nil {
+				return nil, fmt.Errorf("no state roots, error %v", err)
+			}
+			sRH := &ShardRootHash{}
+			if err := json.Unmarshal(data, sRH); err != nil {
+				return nil, err
+			}
+			return []common.Hash{sRH.ConsensusStateDBRootHash, sRH.TransactionStateDBRootHash, sRH.FeatureStateDBRootHash, sRH.RewardStateDBRootHash, sRH.SlashStateDBRootHash}, nil
+		},
+	}
+	// the transactions of a finalized block are indexed in it
+	chain.checkBlock = func(height uint64, block common.BlockInterface) {
+		blockHash := block.Hash()
+		for index, tx := range block.(*ShardBlock).Body.Transactions {
+			txBlockHash, txIndex, err := rawdbv2.GetTransactionByHash(db, *tx.Hash())
+			if err != nil {
+				report.AddProblem(ChainTxIndexProblem, height, *tx.Hash(), "transaction of the finalized block is not indexed")
+			} else if !txBlockHash.IsEqual(blockHash) || txIndex != index {
+				report.AddProblem(ChainTxIndexProblem, height, *tx.Hash(), "transaction of the finalized block is indexed at %v index %v", txBlockHash.String(), txIndex)
+			}
+		}
+	}
+	return chain
+}
+
+// CheckBeaconDatabase - check the stored views, the finalized blocks and their states of the beacon chain
+func CheckBeaconDatabase(db incdb.Database, options ChainCheckOptions) (*ChainReport, error) {
+	report := &ChainReport{ChainID: common.BeaconChainDataBaseID}
+	chain := newCheckedBeaconChain(db, report)
+	views, err := getStoredBeaconViews(db)
+	if err != nil {
+		report.AddProblem(ChainViewsProblem, 0, common.Hash{}, "%v", err)
+	} else {
+		report.FinalHeight = views[0].BeaconHeight
+		hashes := []common.Hash{}
+		for _, view := range views {
+			hashes = append(hashes, view.BestBlockHash)
+		}
+		chain.checkViews(hashes)
+	}
+	chain.checkFinalizedBlocks(options)
+	return report, nil
+}
+
+// CheckShardDatabase - check the stored views, the finalized blocks, their states and the transaction index of a
+// shard chain
+func CheckShardDatabase(db incdb.Database, shardID byte, options ChainCheckOptions) (*ChainReport, error) {
+	report := &ChainReport{ChainID: int(shardID)}
+	chain := newCheckedShardChain(db, shardID, report)
+	views, err := getStoredShardViews(db, shardID)
+	if err != nil {
+		report.AddProblem(ChainViewsProblem, 0, common.Hash{}, "%v", err)
+	} else {
+		report.FinalHeight = views[0].ShardHeight
+		hashes := []common.Hash{}
+		for _, view := range views {
+			hashes = append(hashes, view.BestBlockHash)
+		}
+		chain.checkViews(hashes)
+	}
+	chain.checkFinalizedBlocks(options)
+	// an index written for a block which was never stored
+	err = rawdbv2.IterateTransactionIndex(db, func(txHash common.Hash, blockHash common.Hash, index int) error {
+		has, err := rawdbv2.HasShardBlock(db, blockHash)
+		if err != nil {
+			return err
+		}
+		// the staking transactions of a pruned block stay indexed
+		if !has {
+			has, err = rawdbv2.HasPrunedShardBlock(db, blockHash)
+			if err != nil {
+				return err
+			}
+		}
+		if !has {
+			report.AddProblem(ChainTxIndexProblem, 0, txHash, "transaction is indexed at block %v which is not stored", blockHash.String())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func getStoredBeaconViews(db incdb.Database) ([]*BeaconBestState, error) {
+	data, err := rawdbv2.GetBeaconViews(db)
+	if err != nil {
+		return nil, fmt.Errorf("no stored views, error %v", err)
+	}
+	views := []*BeaconBestState{}
+	if err := json.Unmarshal(data, &views); err != nil {
+		return nil, fmt.Errorf("stored views can not be decoded, error %v", err)
+	}
+	if len(views) == 0 {
+		return nil, fmt.Errorf("no stored views")
+	}
+	return views, nil
+}
+
+func getStoredShardViews(db incdb.Database, shardID byte) ([]*ShardBestState, error) {
+	data, err := rawdbv2.GetShardBestState(db, shardID)
+	if err != nil {
+		return nil, fmt.Errorf("no stored views, error %v", err)
+	}
+	views := []*ShardBestState{}
+	if err := json.Unmarshal(data, &views); err != nil {
+		return nil, fmt.Errorf("stored views can not be decoded, error %v", err)
+	}
+	if len(views) == 0 {
+		return nil, fmt.Errorf("no stored views")
+	}
+	return views, nil
+}
+
+// keptViews - indexes of the views to keep: the final view, and the views built on kept views which are not bad. The
+// views are stored in breadth first order from the final view
+func (chain *checkedChain) keptViews(hashes []common.Hash) []int {
+	bad := make(map[common.Hash]bool)
+	for _, problem := range chain.report.Problems {
+		if problem.Kind == ChainViewsProblem {
+			bad[problem.Hash] = true
+		}
+	}
+	kept := []int{0}
+	keptHashes := map[common.Hash]bool{hashes[0]: true}
+	for i := 1; i < len(hashes); i++ {
+		if bad[hashes[i]] {
+			continue
+		}
+		block, err := chain.getBlock(hashes[i])
+		if err == nil && keptHashes[block.GetPrevHash()] {
+			kept = append(kept, i)
+			keptHashes[hashes[i]] = true
+		}
+	}
+	return kept
+}
+
+// truncate - fix in batch the problems of the transaction index and of the index past the final view
+func (chain *checkedChain) truncate(batch incdb.Batch, deleteFinalizedHash func(height uint64) error) error {
+	report := chain.report
+	for height := report.FinalHeight + 1; height <= report.IndexedHeight; height++ {
+		if err := deleteFinalizedHash(height); err != nil {
+			return err
+		}
+	}
+	reindexed := make(map[uint64]bool)
+	for _, problem := range report.Problems {
+		if problem.Kind != ChainTxIndexProblem {
+			continue
+		}
+		if problem.Height == 0 {
+			if err := batch.Delete(rawdbv2.GetTransactionHashKey(problem.Hash)); err != nil {
+				return err
+			}
+			continue
+		}
+		if reindexed[problem.Height] {
+			continue
+		}
+		reindexed[problem.Height] = true
+		hash, err := chain.finalizedHash(problem.Height)
+		if err != nil {
+			return err
+		}
+		block, err := chain.getBlock(*hash)
+		if err != nil {
+			return err
+		}
+		for index, tx := range block.(*ShardBlock).Body.Transactions {
+			if err := rawdbv2.StoreTransactionIndex(batch, *tx.Hash(), *hash, index); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// TruncateBeaconDatabase - drop the bad views of blocks not final and the finalized block index above the final view,
+// the chain must be good up to its final view
+func TruncateBeaconDatabase(db incdb.Database, report *ChainReport) error {
+	if report.NeedRebuild() {
+		return fmt.Errorf("beacon chain is damaged below its final view %v, the database must be rebuilt up to height %v", report.FinalHeight, report.LastGoodHeight)
+	}
+	chain := newCheckedBeaconChain(db, report)
+	batch := db.NewBatch()
+	views, err := getStoredBeaconViews(db)
+	if err != nil {
+		return err
+	}
+	hashes := []common.Hash{}
+	for _, view := range views {
+		hashes = append(hashes, view.BestBlockHash)
+	}
+	newViews := []*BeaconBestState{}
+	for _, i := range chain.keptViews(hashes) {
+		newViews = append(newViews, views[i])
+	}
+	data, err := json.Marshal(newViews)
+	if err != nil {
+		return err
+	}
+	if err := rawdbv2.StoreBeaconViews(batch, data); err != nil {
+		return err
+	}
+	err = chain.truncate(batch, func(height uint64) error {
+		return rawdbv2.DeleteFinalizedBeaconBlockHashByIndex(batch, height)
+	})
+	if err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// TruncateShardDatabase - drop the bad views of blocks not final, the finalized block index above the final view and
+// the transaction index entries of blocks not stored, and index again the transactions of the finalized blocks. The
+// chain must be good up to its final view
+func TruncateShardDatabase(db incdb.Database, shardID byte, report *ChainReport) error {
+	if report.NeedRebuild() {
+		return fmt.Errorf("shard %v is damaged below its final view %v, the database must be rebuilt up to height %v", shardID, report.FinalHeight, report.LastGoodHeight)
+	}
+	chain := newCheckedShardChain(db, shardID, report)
+	batch := db.NewBatch()
+	views, err := getStoredShardViews(db, shardID)
+	if err != nil {
+		return err
+	}
+	hashes := []common.Hash{}
+	for _, view := range views {
+		hashes = append(hashes, view.BestBlockHash)
+	}
+	newViews := []*ShardBestState{}
+	for _, i := range chain.keptViews(hashes) {
+		newViews = append(newViews, views[i])
+	}
+	if err := rawdbv2.StoreShardBestState(batch, shardID, newViews); err != nil {
+		return err
+	}
+	err = chain.truncate(batch, func(height uint64) error {
+		return rawdbv2.DeleteFinalizedShardBlockHashByIndex(batch, shardID, height)
+	})
+	if err != nil {
+		return err
+	}
+	return batch.Write()
+}
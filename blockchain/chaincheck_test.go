@@ -0,0 +1,139 @@
+package blockchain
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+)
+
+// setupTestShardDatabase stores a shard chain of blocks 1 to 4, final at height 3 with a view at height 4
+func setupTestShardDatabase(t *testing.T) (incdb.Database, []common.Hash) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_chaincheck_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dbPath) })
+	db, err := incdb.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	hashes := []common.Hash{{}}
+	for height := uint64(1); height <= 4; height++ {
+		block := NewShardBlock()
+		block.Header = ShardHeader{
+			Version:           SHARD_BLOCK_VERSION,
+			Height:            height,
+			PreviousBlockHash: hashes[height-1],
+			Round:             1,
+			Epoch:             1,
+			Timestamp:         int64(height),
+			BeaconHeight:      1,
+			TotalTxsFee:       make(map[common.Hash]uint64),
+		}
+		if height > 1 {
+			block.ValidationData = "validation"
+			block.Header.CommitteeRoot = common.HashH([]byte("committee"))
+		}
+		hash := block.Header.Hash()
+		hashes = append(hashes, hash)
+		if err := rawdbv2.StoreShardBlock(db, hash, block); err != nil {
+			t.Fatal(err)
+		}
+		sRH := ShardRootHash{
+			ConsensusStateDBRootHash:   common.EmptyRoot,
+			TransactionStateDBRootHash: common.EmptyRoot,
+			FeatureStateDBRootHash:     common.EmptyRoot,
+			RewardStateDBRootHash:      common.EmptyRoot,
+			SlashStateDBRootHash:       common.EmptyRoot,
+		}
+		if err := rawdbv2.StoreShardRootsHash(db, 0, hash, sRH); err != nil {
+			t.Fatal(err)
+		}
+		if height <= 3 {
+			if err := rawdbv2.StoreFinalizedShardBlockHashByIndex(db, 0, height, hash); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	views := []*ShardBestState{
+		{BestBlockHash: hashes[3], ShardHeight: 3},
+		{BestBlockHash: hashes[4], ShardHeight: 4},
+	}
+	if err := rawdbv2.StoreShardBestState(db, 0, views); err != nil {
+		t.Fatal(err)
+	}
+	return db, hashes
+}
+
+func TestCheckShardDatabase(t *testing.T) {
+	db, _ := setupTestShardDatabase(t)
+	report, err := CheckShardDatabase(db, 0, ChainCheckOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Problems) != 0 || report.FinalHeight != 3 || report.IndexedHeight != 3 || report.LastGoodHeight != 3 {
+		t.Fatalf("unexpected report %+v, problems %+v", report, report.Problems)
+	}
+	if plan := report.RepairPlan(); len(plan) != 0 {
+		t.Errorf("unexpected repair plan %+v", plan)
+	}
+}
+
+func TestTruncateShardDatabase(t *testing.T) {
+	db, hashes := setupTestShardDatabase(t)
+	// the view at height 4 loses its state, a transaction is indexed at a block never stored
+	if err := db.Delete(rawdbv2.GetShardRootsHashKey(0, hashes[4])); err != nil {
+		t.Fatal(err)
+	}
+	if err := rawdbv2.StoreTransactionIndex(db, common.HashH([]byte("tx")), common.HashH([]byte("block")), 0); err != nil {
+		t.Fatal(err)
+	}
+	report, err := CheckShardDatabase(db, 0, ChainCheckOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Problems) != 2 || report.LastGoodHeight != 3 || report.NeedRebuild() {
+		t.Fatalf("unexpected report %+v, problems %+v", report, report.Problems)
+	}
+	if plan := report.RepairPlan(); len(plan) != 2 {
+		t.Errorf("unexpected repair plan %+v", plan)
+	}
+	if err := TruncateShardDatabase(db, 0, report); err != nil {
+		t.Fatal(err)
+	}
+	report, err = CheckShardDatabase(db, 0, ChainCheckOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Problems) != 0 {
+		t.Errorf("unexpected problems after truncate %+v", report.Problems)
+	}
+	views, err := getStoredShardViews(db, 0)
+	if err != nil || len(views) != 1 {
+		t.Errorf("unexpected views after truncate %+v, error %+v", views, err)
+	}
+}
+
+func TestCheckShardDatabaseBelowFinalView(t *testing.T) {
+	db, hashes := setupTestShardDatabase(t)
+	// the chain is broken below the final view
+	if err := db.Delete(rawdbv2.GetShardRootsHashKey(0, hashes[2])); err != nil {
+		t.Fatal(err)
+	}
+	report, err := CheckShardDatabase(db, 0, ChainCheckOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Problems) != 1 || report.Problems[0].Kind != ChainRootsProblem || report.LastGoodHeight != 1 || !report.NeedRebuild() {
+		t.Fatalf("unexpected report %+v, problems %+v", report, report.Problems)
+	}
+	if err := TruncateShardDatabase(db, 0, report); err == nil {
+		t.Errorf("truncate of a chain damaged below its final view must fail")
+	}
+}
@@ -3,9 +3,12 @@ package blockchain
 import (
 	"encoding/json"
 	"io/ioutil"
+	"math"
 	"os"
 	"strings"
 	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
 )
 
 //Network fixed params
@@ -61,6 +64,18 @@ const (
 	MainNetActiveShards           = 8
 	MainNetStakingAmountShard     = 1750000000000 // 1750 PRV = 1750 * 10^9 nano PRV
 
+	MainnetDelegationUnbondingEpochs   = 4
+	MainnetDelegationCommissionPercent = 10
+
+	// txs with one-out-of-many proofs of ring sizes 16, 32 and 64 are not accepted on mainnet until scheduled
+	MainnetBeaconHeightBreakPointRingSize = math.MaxUint64
+	// txs with stealth output coins are not accepted on mainnet until scheduled
+	MainnetBeaconHeightBreakPointStealthAddress = math.MaxUint64
+	// hashed time-locked payments are not accepted on mainnet until scheduled
+	MainnetBeaconHeightBreakPointHTLC = math.MaxUint64
+	// delegations and the reward split with delegators are not accepted on mainnet until scheduled
+	MainnetBeaconHeightBreakPointDelegation = math.MaxUint64
+
 	MainnetMinBeaconBlkInterval = 40 * time.Second //second
 	MainnetMaxBeaconBlkCreation = 10 * time.Second //second
 	MainnetMinShardBlkInterval  = 40 * time.Second //second
@@ -120,6 +135,18 @@ const (
 	TestNetActiveShards           = 8
 	TestNetStakingAmountShard     = 1750000000000 // 1750 PRV = 1750 * 10^9 nano PRV
 
+	TestnetDelegationUnbondingEpochs   = 1
+	TestnetDelegationCommissionPercent = 10
+
+	// txs with one-out-of-many proofs of ring sizes 16, 32 and 64 are accepted on testnet from this beacon height
+	TestnetBeaconHeightBreakPointRingSize = 2500000
+	// txs with stealth output coins are accepted on testnet from this beacon height
+	TestnetBeaconHeightBreakPointStealthAddress = 2500000
+	// hashed time-locked payments are accepted on testnet from this beacon height
+	TestnetBeaconHeightBreakPointHTLC = 2500000
+	// delegations and the reward split with delegators are accepted on testnet from this beacon height
+	TestnetBeaconHeightBreakPointDelegation = 2500000
+
 	TestNetMinBeaconBlkInterval = 10 * time.Second //second
 	TestNetMaxBeaconBlkCreation = 8 * time.Second  //second
 	TestNetMinShardBlkInterval  = 10 * time.Second //second
@@ -167,6 +194,10 @@ func init() {
 	if len(os.Args) > 0 && (strings.Contains(os.Args[0], "test") || strings.Contains(os.Args[0], "Test")) {
 		return
 	}
+	// the devnet generates its committees, it does not read the key lists
+	if (len(os.Args) > 1 && os.Args[1] == "devnet") || common.IndexOfStr("--devnet", os.Args) > -1 {
+		return
+	}
 	var keyData []byte
 	var keyDataV2 []byte
 	var err error
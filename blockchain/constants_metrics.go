@@ -11,6 +11,7 @@ var (
 	shardVerifyWithBestStateTimer          = metrics.NewRegisteredTimer("shard/verify/withbeststate", nil)
 	shardVerifyPostProcessingTimer         = metrics.NewRegisteredTimer("shard/verify/postprocessing", nil)
 	shardStoreBlockTimer                   = metrics.NewRegisteredTimer("shard/storeblock", nil)
+	shardCommitStateTimer                  = metrics.NewRegisteredTimer("shard/storeblock/commitstate", nil)
 	shardUpdateBestStateTimer              = metrics.NewRegisteredTimer("shard/updatebeststate", nil)
 
 	beaconInsertBlockTimer                  = metrics.NewRegisteredTimer("beacon/insert", nil)
@@ -19,5 +20,6 @@ var (
 	beaconVerifyWithBestStateTimer          = metrics.NewRegisteredTimer("beacon/verify/withbeststate", nil)
 	beaconVerifyPostProcessingTimer         = metrics.NewRegisteredTimer("beacon/verify/postprocessing", nil)
 	beaconStoreBlockTimer                   = metrics.NewRegisteredTimer("beacon/storeblock", nil)
+	beaconCommitStateTimer                  = metrics.NewRegisteredTimer("beacon/storeblock/commitstate", nil)
 	beaconUpdateBestStateTimer              = metrics.NewRegisteredTimer("beacon/updatebeststate", nil)
 )
@@ -182,6 +182,11 @@ const (
 	GetShardBlockHeightByHashError
 	GetShardBlockByHashError
 	ResponsedTransactionFromBeaconInstructionsError
+	ProcessDelegationInstructionError
+	GetCrossShardTxStatusError
+	GetHistoricalStateError
+	ShardBlockPrunedError
+	ProcessHTLCInstructionError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -346,6 +351,11 @@ var ErrCodeMessage = map[int]struct {
 	GetShardBlockHeightByHashError:                    {-1155, "Get Shard Block Height By Hash Error"},
 	GetShardBlockByHashError:                          {-1156, "Get Shard Block By Hash Error"},
 	ShardStakingTxRootHashError:                       {-1157, "Build Shard StakingTX error"},
+	ProcessDelegationInstructionError:                 {-1158, "Process Delegation Instruction Error"},
+	GetCrossShardTxStatusError:                        {-1159, "Get Cross Shard Tx Status Error"},
+	GetHistoricalStateError:                           {-1160, "Get Historical State Error"},
+	ShardBlockPrunedError:                             {-1161, "Shard Block Pruned Error"},
+	ProcessHTLCInstructionError:                       {-1162, "Process HTLC Instruction Error"},
 	GetListOutputCoinsByKeysetError:                   {-2000, "Get List Output Coins By Keyset Error"},
 	GetTotalLockedCollateralError:                     {-3000, "Get Total Locked Collateral Error"},
 	ResponsedTransactionFromBeaconInstructionsError:   {-3100, "Build Transaction Response From Beacon Instructions Error"},
@@ -0,0 +1,101 @@
+package blockchain
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// buildHTLCResponseTx pays out the htlc of an instruction to its payee in shardID, instructions which pay nothing
+// out build no tx
+func (blockGenerator *BlockGenerator) buildHTLCResponseTx(
+	metaType int,
+	status string,
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+	shardView *ShardBestState,
+	beaconView *BeaconBestState,
+) (metadata.Transaction, error) {
+	var content metadata.HTLCAcceptedContent
+	err := json.Unmarshal([]byte(contentStr), &content)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling htlc content: %+v", err)
+		return nil, nil
+	}
+	if content.ShardID != shardID {
+		return nil, nil
+	}
+	payeeAddressStr := metadata.GetHTLCPayeeAddress(metaType, status, content)
+	if payeeAddressStr == "" {
+		return nil, nil
+	}
+	Logger.log.Infof("[HTLC] Paying out htlc %+v, status %+v", content.LockTxID.String(), status)
+	meta := metadata.NewHTLCResponse(status, content.TxReqID, content.LockTxID)
+	tokenID, err := common.Hash{}.NewHashFromStr(content.TokenIDStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while converting tokenid to hash: %+v", err)
+		return nil, nil
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(payeeAddressStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while deserializing payee address string: %+v", err)
+		return nil, nil
+	}
+	receiverAddr := keyWallet.KeySet.PaymentAddress
+	// the locked currency is PRV
+	if tokenID.IsEqual(&common.PRVCoinID) {
+		resTx := new(transaction.Tx)
+		err = resTx.InitTxSalary(
+			content.Amount,
+			&receiverAddr,
+			producerPrivateKey,
+			shardView.GetCopiedTransactionStateDB(),
+			meta,
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while initializing htlc response (normal) tx: %+v", err)
+			return nil, nil
+		}
+		return resTx, nil
+	}
+	// in case the locked currency is privacy custom token
+	receiver := &privacy.PaymentInfo{
+		Amount:         content.Amount,
+		PaymentAddress: receiverAddr,
+	}
+	tokenParams := &transaction.CustomTokenPrivacyParamTx{
+		PropertyID:  tokenID.String(),
+		Amount:      content.Amount,
+		TokenTxType: transaction.CustomTokenInit,
+		Receiver:    []*privacy.PaymentInfo{receiver},
+		TokenInput:  []*privacy.InputCoin{},
+		Mintable:    true,
+	}
+	resTx := &transaction.TxCustomTokenPrivacy{}
+	initErr := resTx.Init(
+		transaction.NewTxPrivacyTokenInitParams(
+			producerPrivateKey,
+			[]*privacy.PaymentInfo{},
+			nil,
+			0,
+			tokenParams,
+			shardView.GetCopiedTransactionStateDB(),
+			meta,
+			false,
+			false,
+			shardID,
+			nil,
+			beaconView.GetBeaconFeatureStateDB(),
+		),
+	)
+	if initErr != nil {
+		Logger.log.Errorf("ERROR: an error occured while initializing htlc response (privacy custom token) tx: %+v", initErr)
+		return nil, nil
+	}
+	return resTx, nil
+}
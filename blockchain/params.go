@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -31,48 +32,63 @@ to differentiate network as well as addresses and keys for one network
 from those intended for use on another network
 */
 type Params struct {
-	Name                             string // Name defines a human-readable identifier for the network.
-	Net                              uint32 // Net defines the magic bytes used to identify the network.
-	DefaultPort                      string // DefaultPort defines the default peer-to-peer port for the network.
-	GenesisParams                    *GenesisParams
-	MaxShardCommitteeSize            int
-	MinShardCommitteeSize            int
-	MaxBeaconCommitteeSize           int
-	MinBeaconCommitteeSize           int
-	MinShardBlockInterval            time.Duration
-	MaxShardBlockCreation            time.Duration
-	MinBeaconBlockInterval           time.Duration
-	MaxBeaconBlockCreation           time.Duration
-	StakingAmountShard               uint64
-	ActiveShards                     int
-	GenesisBeaconBlock               *BeaconBlock // GenesisBlock defines the first block of the chain.
-	GenesisShardBlock                *ShardBlock  // GenesisBlock defines the first block of the chain.
-	BasicReward                      uint64
-	Epoch                            uint64
-	RandomTime                       uint64
-	SlashLevels                      []SlashLevel
-	EthContractAddressStr            string // smart contract of ETH for bridge
-	Offset                           int    // default offset for swap policy, is used for cases that good producers length is less than max committee size
-	SwapOffset                       int    // is used for case that good producers length is equal to max committee size
-	IncognitoDAOAddress              string
-	CentralizedWebsitePaymentAddress string //centralized website's pubkey
-	CheckForce                       bool   // true on testnet and false on mainnet
-	ChainVersion                     string
-	AssignOffset                     int
-	ConsensusV2Epoch                 uint64
-	BeaconHeightBreakPointBurnAddr   uint64
-	BNBRelayingHeaderChainID         string
-	BTCRelayingHeaderChainID         string
-	BTCDataFolderName                string
-	BNBFullNodeProtocol              string
-	BNBFullNodeHost                  string
-	BNBFullNodePort                  string
-	PortalParams                     map[uint64]PortalParams
-	PortalFeederAddress              string
-	EpochBreakPointSwapNewKey        []uint64
-	IsBackup                         bool
-	PreloadAddress                   string
-	ReplaceStakingTxHeight           uint64
+	Name                                 string // Name defines a human-readable identifier for the network.
+	Net                                  uint32 // Net defines the magic bytes used to identify the network.
+	DefaultPort                          string // DefaultPort defines the default peer-to-peer port for the network.
+	GenesisParams                        *GenesisParams
+	MaxShardCommitteeSize                int
+	MinShardCommitteeSize                int
+	MaxBeaconCommitteeSize               int
+	MinBeaconCommitteeSize               int
+	MinShardBlockInterval                time.Duration
+	MaxShardBlockCreation                time.Duration
+	MinBeaconBlockInterval               time.Duration
+	MaxBeaconBlockCreation               time.Duration
+	StakingAmountShard                   uint64
+	ActiveShards                         int
+	GenesisBeaconBlock                   *BeaconBlock // GenesisBlock defines the first block of the chain.
+	GenesisShardBlock                    *ShardBlock  // GenesisBlock defines the first block of the chain.
+	BasicReward                          uint64
+	Epoch                                uint64
+	RandomTime                           uint64
+	SlashLevels                          []SlashLevel
+	EthContractAddressStr                string // smart contract of ETH for bridge
+	Offset                               int    // default offset for swap policy, is used for cases that good producers length is less than max committee size
+	SwapOffset                           int    // is used for case that good producers length is equal to max committee size
+	IncognitoDAOAddress                  string
+	CentralizedWebsitePaymentAddress     string //centralized website's pubkey
+	CheckForce                           bool   // true on testnet and false on mainnet
+	ChainVersion                         string
+	AssignOffset                         int
+	ConsensusV2Epoch                     uint64
+	BeaconHeightBreakPointBurnAddr       uint64
+	BNBRelayingHeaderChainID             string
+	BTCRelayingHeaderChainID             string
+	BTCDataFolderName                    string
+	BNBFullNodeProtocol                  string
+	BNBFullNodeHost                      string
+	BNBFullNodePort                      string
+	PortalParams                         map[uint64]PortalParams
+	PortalFeederAddress                  string
+	EpochBreakPointSwapNewKey            []uint64
+	IsBackup                             bool
+	PreloadAddress                       string
+	ReplaceStakingTxHeight               uint64
+	DelegationUnbondingEpochs            uint64 // epochs undelegated PRV stays locked before it is paid back, at least 1
+	DelegationCommissionPercent          uint64 // share of the reward of delegators kept by a validator which has not set its own
+	BeaconHeightBreakPointRingSize       uint64 // from this beacon height txs can use ring sizes 16, 32 and 64
+	BeaconHeightBreakPointStealthAddress uint64 // from this beacon height txs can have stealth output coins
+	BeaconHeightBreakPointHTLC           uint64 // from this beacon height htlcs can be locked, claimed and refunded
+	BeaconHeightBreakPointDelegation     uint64 // from this beacon height PRV can be delegated and rewards are split with delegators
+}
+
+// checkBreakPoints rejects break points which let a tx use a feature before the fork it depends on, a stealth tx
+// has the version of a tx choosing its ring size
+func (params *Params) checkBreakPoints() error {
+	if params.BeaconHeightBreakPointStealthAddress < params.BeaconHeightBreakPointRingSize {
+		return fmt.Errorf("stealth address break point %d is before ring size break point %d", params.BeaconHeightBreakPointStealthAddress, params.BeaconHeightBreakPointRingSize)
+	}
+	return nil
 }
 
 type GenesisParams struct {
@@ -173,10 +189,16 @@ func init() {
 				MinPercentRedeemFee:                  0.01,
 			},
 		},
-		EpochBreakPointSwapNewKey: TestnetReplaceCommitteeEpoch,
-		ReplaceStakingTxHeight:    1,
-		IsBackup:                  false,
-		PreloadAddress:            "",
+		EpochBreakPointSwapNewKey:            TestnetReplaceCommitteeEpoch,
+		ReplaceStakingTxHeight:               1,
+		IsBackup:                             false,
+		PreloadAddress:                       "",
+		DelegationUnbondingEpochs:            TestnetDelegationUnbondingEpochs,
+		BeaconHeightBreakPointRingSize:       TestnetBeaconHeightBreakPointRingSize,
+		BeaconHeightBreakPointStealthAddress: TestnetBeaconHeightBreakPointStealthAddress,
+		BeaconHeightBreakPointHTLC:           TestnetBeaconHeightBreakPointHTLC,
+		BeaconHeightBreakPointDelegation:     TestnetBeaconHeightBreakPointDelegation,
+		DelegationCommissionPercent:          TestnetDelegationCommissionPercent,
 	}
 	// END TESTNET
 	// FOR MAINNET
@@ -251,10 +273,16 @@ func init() {
 				MinPercentRedeemFee:                  0.01,
 			},
 		},
-		EpochBreakPointSwapNewKey: MainnetReplaceCommitteeEpoch,
-		ReplaceStakingTxHeight:    559380,
-		IsBackup:                  false,
-		PreloadAddress:            "",
+		EpochBreakPointSwapNewKey:            MainnetReplaceCommitteeEpoch,
+		ReplaceStakingTxHeight:               559380,
+		IsBackup:                             false,
+		PreloadAddress:                       "",
+		DelegationUnbondingEpochs:            MainnetDelegationUnbondingEpochs,
+		BeaconHeightBreakPointRingSize:       MainnetBeaconHeightBreakPointRingSize,
+		BeaconHeightBreakPointStealthAddress: MainnetBeaconHeightBreakPointStealthAddress,
+		BeaconHeightBreakPointHTLC:           MainnetBeaconHeightBreakPointHTLC,
+		BeaconHeightBreakPointDelegation:     MainnetBeaconHeightBreakPointDelegation,
+		DelegationCommissionPercent:          MainnetDelegationCommissionPercent,
 	}
 	if IsTestNet {
 		GenesisParam = genesisParamsTestnetNew
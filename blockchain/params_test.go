@@ -0,0 +1,25 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckBreakPoints(t *testing.T) {
+	assert.Equal(t, nil, ChainTestParam.checkBreakPoints())
+	assert.Equal(t, nil, ChainMainParam.checkBreakPoints())
+
+	params := ChainTestParam
+	params.BeaconHeightBreakPointStealthAddress = params.BeaconHeightBreakPointRingSize
+	assert.Equal(t, nil, params.checkBreakPoints())
+
+	// a stealth tx would choose its ring size before the ring size fork
+	params.BeaconHeightBreakPointStealthAddress = params.BeaconHeightBreakPointRingSize - 1
+	assert.NotEqual(t, nil, params.checkBreakPoints())
+	err := new(BlockChain).Init(&Config{DataBase: map[int]incdb.Database{}, ChainParams: &params})
+	if assert.NotEqual(t, nil, err) {
+		assert.Contains(t, err.Error(), "stealth address break point")
+	}
+}
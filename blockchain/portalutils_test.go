@@ -6,7 +6,7 @@ import (
 )
 
 func TestCalculatePortingFees(t *testing.T) {
-	result := CalculatePortingFees(3106511852580)
+	result := CalculatePortingFees(3106511852580, 0.01)
 	assert.Equal(t, result, uint64(310651185))
 }
 
@@ -18,10 +18,7 @@ func TestCurrentPortalStateStruct(t *testing.T) {
 	assert.Equal(t, len(currentPortalState.ExchangeRatesRequests), 0)
 	assert.Equal(t, len(currentPortalState.WaitingPortingRequests), 0)
 	assert.Equal(t, len(currentPortalState.WaitingRedeemRequests), 0)
-	assert.Equal(t, len(currentPortalState.FinalExchangeRatesState), 0)
-
-	finalExchangeRates := currentPortalState.FinalExchangeRatesState["abc"]
-	assert.Equal(t, finalExchangeRates.Rates, nil)
+	assert.Nil(t, currentPortalState.FinalExchangeRatesState)
 
 	_, ok := currentPortalState.CustodianPoolState["abc"]
 	assert.Equal(t, ok, false)
@@ -0,0 +1,185 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// A pruning node deletes the body of the finalized shard blocks older than a number of epochs, with the index of
+// their transactions. The header and the validation data of a pruned block stay, and so does the state: the serial
+// numbers and the commitments of the transactions are in the transaction state, not in the blocks. The staking
+// transactions are kept with their index, the return of a stake reads its staking transaction.
+
+// maxPrunedBlocksPerStore - blocks pruned after storing a block, a node enabling pruning catches up block by block
+const maxPrunedBlocksPerStore = 100
+
+// IsPruning - whether the node deletes the body of the old finalized shard blocks
+func (blockchain *BlockChain) IsPruning() bool {
+	return blockchain.config.PruneEpochs > 0
+}
+
+// GetShardPrunedHeight - height of the shard up to which the finalized blocks have no body, 0 if none is pruned
+func (blockchain *BlockChain) GetShardPrunedHeight(shardID byte) uint64 {
+	height, err := rawdbv2.GetShardPrunedHeight(blockchain.GetShardChainDatabase(shardID), shardID)
+	if err != nil {
+		Logger.log.Error(err)
+		return 0
+	}
+	return height
+}
+
+// IsBlockPrunedError - whether err is returned for a block whose body is pruned
+func IsBlockPrunedError(err error) bool {
+	blockChainError, ok := err.(*BlockChainError)
+	return ok && blockChainError.Code == ErrCodeMessage[ShardBlockPrunedError].Code
+}
+
+// getPrunedShardBlockError - the error returned for a block which is not stored, pruned or not
+func (blockchain *BlockChain) getPrunedShardBlockError(shardID byte, hash common.Hash, err error) error {
+	pruned, hasErr := rawdbv2.HasPrunedShardBlock(blockchain.GetShardChainDatabase(shardID), hash)
+	if hasErr == nil && pruned {
+		return NewBlockChainError(ShardBlockPrunedError, fmt.Errorf("block %+v of shard %+v is pruned, blocks are kept for %+v epochs", hash.String(), shardID, blockchain.config.PruneEpochs))
+	}
+	return err
+}
+
+// isKeptTransaction - whether a transaction of a pruned block is still read to validate the next blocks
+func isKeptTransaction(tx metadata.Transaction) bool {
+	if tx.GetMetadata() == nil {
+		return false
+	}
+	_, ok := tx.GetMetadata().(*metadata.StakingMetadata)
+	return ok
+}
+
+// getKeptTransaction - a kept transaction of a pruned block
+func (blockchain *BlockChain) getKeptTransaction(shardID byte, txHash common.Hash) (metadata.Transaction, error) {
+	data, err := rawdbv2.GetKeptTransaction(blockchain.GetShardChainDatabase(shardID), txHash)
+	if err != nil {
+		return nil, err
+	}
+	body := ShardBody{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+	if len(body.Transactions) != 1 {
+		return nil, fmt.Errorf("kept transaction %+v is not stored", txHash.String())
+	}
+	return body.Transactions[0], nil
+}
+
+// getStoredTransaction - a transaction of a block of the shard, from the block or, once pruned, from the kept
+// transactions
+func (blockchain *BlockChain) getStoredTransaction(shardID byte, blockHash common.Hash, index int, txHash common.Hash) (*ShardBlock, metadata.Transaction, error) {
+	shardBlock, _, err := blockchain.GetShardBlockByHashWithShardID(blockHash, shardID)
+	if err == nil {
+		if index < 0 || index >= len(shardBlock.Body.Transactions) {
+			return nil, nil, fmt.Errorf("transaction %+v is indexed at index %+v of block %+v", txHash.String(), index, blockHash.String())
+		}
+		return shardBlock, shardBlock.Body.Transactions[index], nil
+	}
+	if !IsBlockPrunedError(err) {
+		return nil, nil, err
+	}
+	tx, keptErr := blockchain.getKeptTransaction(shardID, txHash)
+	if keptErr != nil {
+		return nil, nil, err
+	}
+	prunedBlock, prunedErr := blockchain.getPrunedShardBlock(shardID, blockHash)
+	if prunedErr != nil {
+		return nil, nil, prunedErr
+	}
+	return prunedBlock, tx, nil
+}
+
+// getPrunedShardBlock - the header and the validation data of a pruned block, with an empty body
+func (blockchain *BlockChain) getPrunedShardBlock(shardID byte, hash common.Hash) (*ShardBlock, error) {
+	data, err := rawdbv2.GetPrunedShardBlockByHash(blockchain.GetShardChainDatabase(shardID), hash)
+	if err != nil {
+		return nil, err
+	}
+	shardBlock := NewShardBlock()
+	if err := json.Unmarshal(data, shardBlock); err != nil {
+		return nil, err
+	}
+	return shardBlock, nil
+}
+
+// pruneShardBlocks - delete the body of the finalized blocks of the shard older than the retention window of the
+// final view, and the index of their transactions
+func (blockchain *BlockChain) pruneShardBlocks(shardID byte) error {
+	finalView := blockchain.ShardChain[shardID].GetFinalView().(*ShardBestState)
+	if finalView.Epoch <= blockchain.config.PruneEpochs {
+		return nil
+	}
+	return pruneShardDatabase(blockchain.GetShardChainDatabase(shardID), shardID, finalView.ShardHeight, finalView.Epoch-blockchain.config.PruneEpochs)
+}
+
+// pruneShardDatabase - delete the body of the finalized blocks below the final height of the epochs before keptEpoch,
+// at most maxPrunedBlocksPerStore blocks in one batch
+func pruneShardDatabase(db incdb.Database, shardID byte, finalHeight uint64, keptEpoch uint64) error {
+	prunedHeight, err := rawdbv2.GetShardPrunedHeight(db, shardID)
+	if err != nil {
+		return err
+	}
+	// the genesis block is kept
+	if prunedHeight == 0 {
+		prunedHeight = 1
+	}
+	batch := db.NewBatch()
+	height := prunedHeight + 1
+	for ; height < finalHeight && height <= prunedHeight+maxPrunedBlocksPerStore; height++ {
+		hash, err := rawdbv2.GetFinalizedShardBlockHashByIndex(db, shardID, height)
+		if err != nil {
+			return err
+		}
+		data, err := rawdbv2.GetShardBlockByHash(db, *hash)
+		if err != nil {
+			return err
+		}
+		shardBlock := NewShardBlock()
+		if err := json.Unmarshal(data, shardBlock); err != nil {
+			return err
+		}
+		if shardBlock.Header.Epoch >= keptEpoch {
+			break
+		}
+		for _, tx := range shardBlock.Body.Transactions {
+			if isKeptTransaction(tx) {
+				if err := rawdbv2.StoreKeptTransaction(batch, *tx.Hash(), ShardBody{Transactions: []metadata.Transaction{tx}}); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := rawdbv2.DeleteTransactionIndex(batch, *tx.Hash()); err != nil {
+				return err
+			}
+		}
+		prunedBlock := &ShardBlock{
+			ValidationData: shardBlock.ValidationData,
+			Header:         shardBlock.Header,
+		}
+		if err := rawdbv2.StorePrunedShardBlock(batch, *hash, prunedBlock); err != nil {
+			return err
+		}
+		if err := rawdbv2.DeleteShardBlock(batch, *hash); err != nil {
+			return err
+		}
+	}
+	if height == prunedHeight+1 {
+		return nil
+	}
+	if err := rawdbv2.StoreShardPrunedHeight(batch, shardID, height-1); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	Logger.log.Infof("SHARD %+v | pruned blocks from height %+v to %+v", shardID, prunedHeight+1, height-1)
+	return nil
+}
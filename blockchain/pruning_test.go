@@ -0,0 +1,52 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+)
+
+func TestPruneShardDatabase(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	db, hashes := setupTestShardDatabase(t)
+	// the blocks of epoch 1 are kept while epoch 1 is in the retention window
+	if err := pruneShardDatabase(db, 0, 3, 1); err != nil {
+		t.Fatal(err)
+	}
+	if height, err := rawdbv2.GetShardPrunedHeight(db, 0); err != nil || height != 0 {
+		t.Fatalf("unexpected pruned height %+v, error %+v", height, err)
+	}
+	// a staking transaction of the block stays indexed
+	if err := rawdbv2.StoreTransactionIndex(db, common.HashH([]byte("staking")), hashes[2], 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := pruneShardDatabase(db, 0, 3, 2); err != nil {
+		t.Fatal(err)
+	}
+	// the genesis block and the final block are kept
+	if height, err := rawdbv2.GetShardPrunedHeight(db, 0); err != nil || height != 2 {
+		t.Fatalf("unexpected pruned height %+v, error %+v", height, err)
+	}
+	for height, pruned := range map[int]bool{1: false, 2: true, 3: false} {
+		has, err := rawdbv2.HasShardBlock(db, hashes[height])
+		if err != nil || has == pruned {
+			t.Errorf("block of height %+v is stored %+v, error %+v", height, has, err)
+		}
+		has, err = rawdbv2.HasPrunedShardBlock(db, hashes[height])
+		if err != nil || has != pruned {
+			t.Errorf("block of height %+v is pruned %+v, error %+v", height, has, err)
+		}
+	}
+	// a pruned chain is good, its pruned blocks are checked by their header
+	report, err := CheckShardDatabase(db, 0, ChainCheckOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Problems) != 0 || report.LastGoodHeight != 3 {
+		t.Fatalf("unexpected report %+v, problems %+v", report, report.Problems)
+	}
+	if err := pruneShardDatabase(db, 0, 3, 2); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -24,6 +24,22 @@ func (blockchain *BlockChain) GetBeaconHeightBreakPointBurnAddr() uint64 {
 	return blockchain.config.ChainParams.BeaconHeightBreakPointBurnAddr
 }
 
+func (blockchain *BlockChain) GetBeaconHeightBreakPointRingSize() uint64 {
+	return blockchain.config.ChainParams.BeaconHeightBreakPointRingSize
+}
+
+func (blockchain *BlockChain) GetBeaconHeightBreakPointStealthAddress() uint64 {
+	return blockchain.config.ChainParams.BeaconHeightBreakPointStealthAddress
+}
+
+func (blockchain *BlockChain) GetBeaconHeightBreakPointHTLC() uint64 {
+	return blockchain.config.ChainParams.BeaconHeightBreakPointHTLC
+}
+
+func (blockchain *BlockChain) GetBeaconHeightBreakPointDelegation() uint64 {
+	return blockchain.config.ChainParams.BeaconHeightBreakPointDelegation
+}
+
 func (blockchain *BlockChain) GetBurningAddress(beaconHeight uint64) string {
 	breakPoint := blockchain.GetBeaconHeightBreakPointBurnAddr()
 	if beaconHeight == 0 {
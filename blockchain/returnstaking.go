@@ -210,12 +210,11 @@ func (blockchain *BlockChain) getReturnStakingInfoFromBeaconInstructions(
 					if err != nil {
 						continue
 					}
-					shardBlock, _, err := blockchain.GetShardBlockByHash(blockHash)
-					if err != nil || shardBlock == nil {
-						Logger.log.Error("ERROR", err, "NO Transaction in block with hash", blockHash, "and index", index, "contains", shardBlock.Body.Transactions[index])
+					_, txData, err := blockchain.getStoredTransaction(shardID, blockHash, index, stakerInfo.TxStakingID())
+					if err != nil {
+						Logger.log.Error("ERROR", err, "NO Transaction in block with hash", blockHash, "and index", index)
 						continue
 					}
-					txData := shardBlock.Body.Transactions[index]
 					txMeta, ok := txData.GetMetadata().(*metadata.StakingMetadata)
 					if !ok {
 						Logger.log.Error("Can not parse meta data of this tx %v", txData.Hash().String())
@@ -1,7 +1,9 @@
 package blockchain
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"strconv"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -67,7 +69,10 @@ func (blockchain *BlockChain) addShardRewardRequestToBeacon(beaconBlock *BeaconB
 }
 
 func (blockchain *BlockChain) processSalaryInstructions(rewardStateDB *statedb.StateDB, beaconBlocks []*BeaconBlock, shardID byte) error {
+	cKeys := make(map[int][]string)
 	cInfos := make(map[int][]*statedb.StakerInfo)
+	delegations := make(map[string]map[string]uint64)
+	commissions := make(map[string]uint64)
 	isInit := false
 	epoch := uint64(0)
 	for _, beaconBlock := range beaconBlocks {
@@ -88,6 +93,16 @@ func (blockchain *BlockChain) processSalaryInstructions(rewardStateDB *statedb.S
 			}
 			if shardToProcess == int(shardID) {
 				switch metaType {
+				case metadata.UndelegateMeta:
+					if l[2] != common.DelegationUnbondedChainStatus || beaconBlock.Header.Height < blockchain.GetBeaconHeightBreakPointDelegation() {
+						continue
+					}
+					err = blockchain.addUnbondedDelegation(rewardStateDB, l[3])
+					if err != nil {
+						return NewBlockChainError(ProcessSalaryInstructionsError, err)
+					}
+					continue
+
 				case metadata.BeaconRewardRequestMeta:
 					beaconBlkRewardInfo, err := metadata.NewBeaconBlockRewardInfoFromStr(l[3])
 					if err != nil {
@@ -141,9 +156,16 @@ func (blockchain *BlockChain) processSalaryInstructions(rewardStateDB *statedb.S
 					if err != nil {
 						return NewBlockChainError(ProcessSalaryInstructionsError, err)
 					}
-					cInfos = statedb.GetAllCommitteeStakeInfo(beaconConsensusStateDB, blockchain.GetShardIDs())
+					cKeys, cInfos, err = statedb.GetAllCommitteeStakeInfoWithKey(beaconConsensusStateDB, blockchain.GetShardIDs())
+					if err != nil {
+						return NewBlockChainError(ProcessSalaryInstructionsError, err)
+					}
+					delegations, commissions, err = blockchain.getCommitteeDelegations(height, cKeys)
+					if err != nil {
+						return NewBlockChainError(ProcessSalaryInstructionsError, err)
+					}
 				}
-				err = blockchain.addShardCommitteeRewardV2(rewardStateDB, shardID, shardRewardInfo, cInfos[int(shardToProcess)])
+				err = blockchain.addShardCommitteeRewardV2(rewardStateDB, shardID, shardRewardInfo, cKeys[int(shardToProcess)], cInfos[int(shardToProcess)], delegations, commissions)
 				if err != nil {
 					return err
 				}
@@ -159,21 +181,18 @@ func (blockchain *BlockChain) addShardCommitteeRewardV2(
 	rewardStateDB *statedb.StateDB,
 	shardID byte,
 	rewardInfoShardToProcess *metadata.ShardBlockRewardInfo,
+	cKeys []string,
 	cStakeInfos []*statedb.StakerInfo,
+	delegations map[string]map[string]uint64,
+	commissions map[string]uint64,
 ) (
 	err error,
 ) {
 	committeeSize := len(cStakeInfos)
-	for _, candidate := range cStakeInfos {
-		if common.GetShardIDFromLastByte(candidate.RewardReceiver().Pk[common.PublicKeySize-1]) == shardID {
-			for key, value := range rewardInfoShardToProcess.ShardReward {
-				tempPK := base58.Base58Check{}.Encode(candidate.RewardReceiver().Pk, common.Base58Version)
-				Logger.log.Criticalf("Add Committee Reward ShardCommitteeReward, Public Key %+v, reward %+v, token %+v", tempPK, value/uint64(committeeSize), key)
-				err = statedb.AddCommitteeReward(rewardStateDB, tempPK, value/uint64(committeeSize), key)
-				if err != nil {
-					return NewBlockChainError(ProcessSalaryInstructionsError, err)
-				}
-			}
+	for i, candidate := range cStakeInfos {
+		err = blockchain.addCommitteeMemberReward(rewardStateDB, shardID, rewardInfoShardToProcess.ShardReward, committeeSize, candidate.RewardReceiver().Pk, delegations[cKeys[i]], commissions[cKeys[i]])
+		if err != nil {
+			return err
 		}
 	}
 	return nil
@@ -200,6 +219,134 @@ func (blockchain *BlockChain) addShardCommitteeReward(rewardStateDB *statedb.Sta
 	return nil
 }
 
+// addCommitteeMemberReward adds the share of one committee member in shardReward to its reward receiver and its
+// delegators, the member keeps commissionPercent of the share of its delegators. Only receivers in shardID are paid as
+// each shard pays its own receivers
+func (blockchain *BlockChain) addCommitteeMemberReward(
+	rewardStateDB *statedb.StateDB,
+	shardID byte,
+	shardReward map[common.Hash]uint64,
+	committeeSize int,
+	rewardReceiverPk []byte,
+	delegations map[string]uint64,
+	commissionPercent uint64,
+) error {
+	delegatorPKs := make(map[string]string)
+	for delegatorAddress := range delegations {
+		wl, err := wallet.Base58CheckDeserialize(delegatorAddress)
+		if err != nil {
+			return NewBlockChainError(ProcessSalaryInstructionsError, err)
+		}
+		if common.GetShardIDFromLastByte(wl.KeySet.PaymentAddress.Pk[common.PublicKeySize-1]) == shardID {
+			delegatorPKs[delegatorAddress] = base58.Base58Check{}.Encode(wl.KeySet.PaymentAddress.Pk, common.Base58Version)
+		}
+	}
+	isReceiverInShard := common.GetShardIDFromLastByte(rewardReceiverPk[common.PublicKeySize-1]) == shardID
+	if !isReceiverInShard && len(delegatorPKs) == 0 {
+		return nil
+	}
+	for key, value := range shardReward {
+		validatorReward, delegatorRewards := splitCommitteeReward(value/uint64(committeeSize), blockchain.config.ChainParams.StakingAmountShard, delegations, commissionPercent)
+		if isReceiverInShard {
+			tempPK := base58.Base58Check{}.Encode(rewardReceiverPk, common.Base58Version)
+			Logger.log.Criticalf("Add Committee Reward ShardCommitteeReward, Public Key %+v, reward %+v, token %+v", tempPK, validatorReward, key)
+			err := statedb.AddCommitteeReward(rewardStateDB, tempPK, validatorReward, key)
+			if err != nil {
+				return NewBlockChainError(ProcessSalaryInstructionsError, err)
+			}
+		}
+		for delegatorAddress, tempPK := range delegatorPKs {
+			if delegatorRewards[delegatorAddress] == 0 {
+				continue
+			}
+			Logger.log.Criticalf("Add Committee Reward DelegatorReward, Public Key %+v, reward %+v, token %+v", tempPK, delegatorRewards[delegatorAddress], key)
+			err := statedb.AddCommitteeReward(rewardStateDB, tempPK, delegatorRewards[delegatorAddress], key)
+			if err != nil {
+				return NewBlockChainError(ProcessSalaryInstructionsError, err)
+			}
+		}
+	}
+	return nil
+}
+
+// splitCommitteeReward splits reward of a committee member by stake share between its own stake validatorStake and
+// delegations. The validator keeps commissionPercent of what delegators earn and the rounding remainder
+func splitCommitteeReward(reward uint64, validatorStake uint64, delegations map[string]uint64, commissionPercent uint64) (uint64, map[string]uint64) {
+	delegatorRewards := make(map[string]uint64)
+	totalStake := new(big.Int).SetUint64(validatorStake)
+	for _, amount := range delegations {
+		totalStake.Add(totalStake, new(big.Int).SetUint64(amount))
+	}
+	if len(delegations) == 0 || totalStake.Sign() == 0 || commissionPercent >= 100 {
+		return reward, delegatorRewards
+	}
+	// reward * amount * (100 - commission) / (totalStake * 100)
+	denominator := new(big.Int).Mul(totalStake, big.NewInt(100))
+	validatorReward := reward
+	for delegatorAddress, amount := range delegations {
+		temp := new(big.Int).Mul(new(big.Int).SetUint64(reward), new(big.Int).SetUint64(amount))
+		temp.Mul(temp, new(big.Int).SetUint64(100-commissionPercent))
+		temp.Div(temp, denominator)
+		delegatorRewards[delegatorAddress] = temp.Uint64()
+		validatorReward -= delegatorRewards[delegatorAddress]
+	}
+	return validatorReward, delegatorRewards
+}
+
+// getCommitteeDelegations returns delegations to committee members of cKeys at beacon height and the commissions of
+// the delegated members, DelegationCommissionPercent for the ones which have not set theirs. There are none before
+// BeaconHeightBreakPointDelegation so rewards are not split
+func (blockchain *BlockChain) getCommitteeDelegations(height uint64, cKeys map[int][]string) (map[string]map[string]uint64, map[string]uint64, error) {
+	delegations := make(map[string]map[string]uint64)
+	commissions := make(map[string]uint64)
+	if height < blockchain.GetBeaconHeightBreakPointDelegation() {
+		return delegations, commissions, nil
+	}
+	beaconFeatureRootHash, err := blockchain.GetBeaconFeatureRootHash(blockchain.GetBeaconBestState(), height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Beacon Feature Root Hash of Height %+v not found ,error %+v", height, err)
+	}
+	beaconFeatureStateDB, err := statedb.NewWithPrefixTrie(beaconFeatureRootHash, statedb.NewDatabaseAccessWarper(blockchain.GetBeaconChainDatabase()))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, keys := range cKeys {
+		for _, key := range keys {
+			validatorDelegations := statedb.GetDelegationsByValidator(beaconFeatureStateDB, key)
+			if len(validatorDelegations) == 0 {
+				continue
+			}
+			delegations[key] = validatorDelegations
+			commission, has, err := statedb.GetDelegationCommission(beaconFeatureStateDB, key)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !has {
+				commission = blockchain.config.ChainParams.DelegationCommissionPercent
+			}
+			commissions[key] = commission
+		}
+	}
+	return delegations, commissions, nil
+}
+
+// addUnbondedDelegation pays back an undelegation whose unbonding period is over to the reward balance of its
+// delegator, it is withdrawn like a committee reward
+func (blockchain *BlockChain) addUnbondedDelegation(rewardStateDB *statedb.StateDB, contentStr string) error {
+	var content metadata.DelegationAcceptedContent
+	err := json.Unmarshal([]byte(contentStr), &content)
+	if err != nil {
+		return err
+	}
+	wl, err := wallet.Base58CheckDeserialize(content.DelegatorAddressStr)
+	if err != nil {
+		return err
+	}
+	tempPK := base58.Base58Check{}.Encode(wl.KeySet.PaymentAddress.Pk, common.Base58Version)
+	Logger.log.Criticalf("Add Committee Reward UnbondedDelegation, Public Key %+v, reward %+v, token %+v", tempPK, content.Amount, common.PRVCoinID)
+	return statedb.AddCommitteeReward(rewardStateDB, tempPK, content.Amount, common.PRVCoinID)
+}
+
 func (blockchain *BlockChain) buildRewardInstructionByEpoch(curView *BeaconBestState, blkHeight, epoch uint64, rewardStateDB *statedb.StateDB, isSplitRewardForCustodian bool, percentCustodianRewards uint64) ([][]string, map[common.Hash]uint64, error) {
 
 	var resInst [][]string
@@ -0,0 +1,34 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCommitteeReward(t *testing.T) {
+	// validator stakes 1000, delegators 1000 and 2000, 10% commission on delegator shares
+	validatorReward, delegatorRewards := splitCommitteeReward(4000, 1000, map[string]uint64{
+		"delegator1": 1000,
+		"delegator2": 2000,
+	}, 10)
+	assert.Equal(t, uint64(900), delegatorRewards["delegator1"])
+	assert.Equal(t, uint64(1800), delegatorRewards["delegator2"])
+	assert.Equal(t, uint64(1300), validatorReward)
+}
+
+func TestSplitCommitteeRewardKeepsRemainder(t *testing.T) {
+	validatorReward, delegatorRewards := splitCommitteeReward(10, 1, map[string]uint64{
+		"delegator1": 1,
+		"delegator2": 1,
+	}, 0)
+	assert.Equal(t, uint64(3), delegatorRewards["delegator1"])
+	assert.Equal(t, uint64(3), delegatorRewards["delegator2"])
+	assert.Equal(t, uint64(4), validatorReward)
+}
+
+func TestSplitCommitteeRewardWithoutDelegation(t *testing.T) {
+	validatorReward, delegatorRewards := splitCommitteeReward(4000, 1000, nil, 10)
+	assert.Equal(t, uint64(4000), validatorReward)
+	assert.Empty(t, delegatorRewards)
+}
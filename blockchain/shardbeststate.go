@@ -8,7 +8,6 @@ import (
 	"sort"
 	"time"
 
-	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incdb"
 
@@ -171,6 +170,23 @@ func (shardBestState *ShardBestState) InitStateRootHash(db incdb.Database, bc *B
 	return nil
 }
 
+// backupInfo - the chain state covered by a backup of the shard database taken at this view
+func (shardBestState *ShardBestState) backupInfo() incdb.BackupInfo {
+	return incdb.BackupInfo{
+		ChainID:   int(shardBestState.ShardID),
+		Epoch:     shardBestState.Epoch,
+		Height:    shardBestState.ShardHeight,
+		BlockHash: shardBestState.BestBlockHash,
+		Roots: map[string]common.Hash{
+			"ConsensusStateDBRootHash":   shardBestState.ConsensusStateDBRootHash,
+			"TransactionStateDBRootHash": shardBestState.TransactionStateDBRootHash,
+			"FeatureStateDBRootHash":     shardBestState.FeatureStateDBRootHash,
+			"RewardStateDBRootHash":      shardBestState.RewardStateDBRootHash,
+			"SlashStateDBRootHash":       shardBestState.SlashStateDBRootHash,
+		},
+	}
+}
+
 // Get role of a public key base on best state shard
 func (shardBestState *ShardBestState) GetBytes() []byte {
 	res := []byte{}
@@ -382,11 +398,5 @@ func (blockchain *BlockChain) GetShardRootsHash(shardBestState *ShardBestState,
 	if err != nil {
 		return nil, err
 	}
-	data, err := rawdbv2.GetShardRootsHash(blockchain.GetShardChainDatabase(shardID), shardID, *h)
-	if err != nil {
-		return nil, err
-	}
-	sRH := &ShardRootHash{}
-	err = json.Unmarshal(data, sRH)
-	return sRH, err
+	return blockchain.GetShardRootsHashByBlockHash(shardID, *h)
 }
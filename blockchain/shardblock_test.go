@@ -0,0 +1,55 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// stored shard blocks, as written to the database by json.Marshal, with the header hashes their producers computed
+var storedShardBlocks = []struct {
+	name string
+	data string
+	hash string
+	fees map[string]uint64
+}{
+	{
+		"without fee",
+		`{"ValidationData":"{\"ProducerBLSSig\":\"\"}","Body":{"Instructions":[],"CrossTransactions":{},"Transactions":[]},"Header":{"Producer":"","ProducerPubKeyStr":"","ShardID":1,"Version":1,"PreviousBlockHash":"c5b6fba8a01ae56c748110b4b822c5266bc0cb67900dce0f969ff27620c04263","Height":20,"Round":1,"Epoch":2,"CrossShardBitMap":null,"BeaconHeight":15,"BeaconHash":"41561aa266fb25ee84063b27bb2f7a8b999421db914033a2c9ac4a41eeb94b64","TotalTxsFee":{},"ConsensusType":"","Timestamp":1600000000,"TxRoot":"0000000000000000000000000000000000000000000000000000000000000000","ShardTxRoot":"0000000000000000000000000000000000000000000000000000000000000000","CrossTransactionRoot":"0000000000000000000000000000000000000000000000000000000000000000","InstructionsRoot":"0000000000000000000000000000000000000000000000000000000000000000","CommitteeRoot":"2b0dbffd73d47c54ed6132d6de41fc4f0b00d0b1872e467a889b507e928e880f","PendingValidatorRoot":"0000000000000000000000000000000000000000000000000000000000000000","StakingTxRoot":"0000000000000000000000000000000000000000000000000000000000000000","InstructionMerkleRoot":"0000000000000000000000000000000000000000000000000000000000000000","Proposer":"","ProposeTime":0}}`,
+		"f495886f79a2c8ad4240531bd22f63a0c28e0d8565adeacf023d8eb7ae0ff87c",
+		map[string]uint64{},
+	},
+	{
+		"with prv and token fee",
+		`{"ValidationData":"{\"ProducerBLSSig\":\"\"}","Body":{"Instructions":[],"CrossTransactions":{},"Transactions":[]},"Header":{"Producer":"","ProducerPubKeyStr":"","ShardID":1,"Version":1,"PreviousBlockHash":"c5b6fba8a01ae56c748110b4b822c5266bc0cb67900dce0f969ff27620c04263","Height":20,"Round":1,"Epoch":2,"CrossShardBitMap":null,"BeaconHeight":15,"BeaconHash":"41561aa266fb25ee84063b27bb2f7a8b999421db914033a2c9ac4a41eeb94b64","TotalTxsFee":{"0000000000000000000000000000000000000000000000000000000000000004":1200,"054a89adcb1464e90a8de08a6d633912d238b4e3e7144c1d66a7dfce2bf50d8a":35},"ConsensusType":"","Timestamp":1600000000,"TxRoot":"0000000000000000000000000000000000000000000000000000000000000000","ShardTxRoot":"0000000000000000000000000000000000000000000000000000000000000000","CrossTransactionRoot":"0000000000000000000000000000000000000000000000000000000000000000","InstructionsRoot":"0000000000000000000000000000000000000000000000000000000000000000","CommitteeRoot":"2b0dbffd73d47c54ed6132d6de41fc4f0b00d0b1872e467a889b507e928e880f","PendingValidatorRoot":"0000000000000000000000000000000000000000000000000000000000000000","StakingTxRoot":"0000000000000000000000000000000000000000000000000000000000000000","InstructionMerkleRoot":"0000000000000000000000000000000000000000000000000000000000000000","Proposer":"","ProposeTime":0}}`,
+		"031565b43d70d29fa5b4be0097c0744b48aa22828ae4a7551e42464d14ad1c0c",
+		map[string]uint64{
+			"0000000000000000000000000000000000000000000000000000000000000004": 1200,
+			"054a89adcb1464e90a8de08a6d633912d238b4e3e7144c1d66a7dfce2bf50d8a": 35,
+		},
+	},
+}
+
+func TestDecodeStoredShardBlock(t *testing.T) {
+	for _, stored := range storedShardBlocks {
+		t.Run(stored.name, func(t *testing.T) {
+			shardBlock := NewShardBlock()
+			if err := json.Unmarshal([]byte(stored.data), shardBlock); err != nil {
+				t.Fatal(err)
+			}
+			if hash := shardBlock.Header.Hash(); hash.String() != stored.hash {
+				t.Fatalf("want header hash %+v but got %+v", stored.hash, hash.String())
+			}
+			if len(shardBlock.Header.TotalTxsFee) != len(stored.fees) {
+				t.Fatalf("want fees %+v but got %+v", stored.fees, shardBlock.Header.TotalTxsFee)
+			}
+			for tokenID, fee := range stored.fees {
+				hash, _ := common.Hash{}.NewHashFromStr(tokenID)
+				if shardBlock.Header.TotalTxsFee[*hash] != fee {
+					t.Fatalf("want fee %+v of token %+v but got %+v", fee, tokenID, shardBlock.Header.TotalTxsFee[*hash])
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"context"
 	"encoding/json"
 	"sync"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/tracing"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incognitokey"
@@ -43,6 +45,10 @@ func (chain *ShardChain) GetViewByHash(hash common.Hash) multiview.View {
 	return chain.multiView.GetViewByHash(hash)
 }
 
+func (chain *ShardChain) GetFinalViewState() *ShardBestState {
+	return chain.multiView.GetFinalView().(*ShardBestState)
+}
+
 func (chain *ShardChain) GetBestState() *ShardBestState {
 	return chain.multiView.GetBestView().(*ShardBestState)
 }
@@ -148,10 +154,14 @@ func (chain *ShardChain) GetLastProposerIndex() int {
 }
 
 func (chain *ShardChain) CreateNewBlock(version int, proposer string, round int, startTime int64) (common.BlockInterface, error) {
+	ctx, span := tracing.StartSpan(context.Background(), "blockchain.CreateNewShardBlock",
+		tracing.Int("shardID", chain.GetShardID()), tracing.Uint64("height", chain.GetBestViewHeight()+1))
+	defer span.End()
 	Logger.log.Infof("Begin Start New Block Shard %+v", time.Now())
-	newBlock, err := chain.Blockchain.NewBlockShard(chain.GetBestState(), version, proposer, round, time.Unix(startTime, 0))
+	newBlock, err := chain.Blockchain.NewBlockShard(ctx, chain.GetBestState(), version, proposer, round, time.Unix(startTime, 0))
 	Logger.log.Infof("Finish New Block Shard %+v", time.Now())
 	if err != nil {
+		span.SetError(err)
 		Logger.log.Error(err)
 		return nil, err
 	}
@@ -159,6 +169,8 @@ func (chain *ShardChain) CreateNewBlock(version int, proposer string, round int,
 		newBlock.Header.Proposer = proposer
 		newBlock.Header.ProposeTime = startTime
 	}
+	span.SetAttributes(tracing.String("blockHash", newBlock.Hash().String()), tracing.Int("txs", len(newBlock.Body.Transactions)))
+	tracing.RememberBlock(newBlock.Hash().String(), ctx)
 
 	Logger.log.Infof("Finish Create New Block")
 	return newBlock, nil
@@ -170,6 +182,10 @@ func (chain *ShardChain) CreateNewBlockFromOldBlock(oldBlock common.BlockInterfa
 	json.Unmarshal(b, &newBlock)
 	newBlock.Header.Proposer = proposer
 	newBlock.Header.ProposeTime = startTime
+	ctx, span := tracing.StartSpan(tracing.ContextForBlock(oldBlock.Hash().String()), "blockchain.CreateNewShardBlockFromOldBlock",
+		tracing.String("oldBlockHash", oldBlock.Hash().String()), tracing.String("blockHash", newBlock.Hash().String()))
+	tracing.RememberBlock(newBlock.Hash().String(), ctx)
+	span.End()
 	return newBlock, nil
 }
 
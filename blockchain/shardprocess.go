@@ -18,6 +18,7 @@ import (
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/pkg/errors"
 )
@@ -25,7 +26,13 @@ import (
 // VerifyPreSignShardBlock Verify Shard Block Before Signing
 // Used for PBFT consensus
 // this block doesn't have full information (incomplete block)
-func (blockchain *BlockChain) VerifyPreSignShardBlock(shardBlock *ShardBlock, shardID byte) error {
+func (blockchain *BlockChain) VerifyPreSignShardBlock(shardBlock *ShardBlock, shardID byte) (err error) {
+	traceCtx, span := tracing.StartSpan(tracing.ContextForBlock(shardBlock.Hash().String()), "blockchain.VerifyPreSignShardBlock",
+		tracing.Int("shardID", int(shardID)), tracing.Uint64("height", shardBlock.GetHeight()), tracing.Int("txs", len(shardBlock.Body.Transactions)))
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
 	//get view that block link to
 	preHash := shardBlock.Header.PreviousBlockHash
 	view := blockchain.ShardChain[int(shardID)].GetViewByHash(preHash)
@@ -69,7 +76,11 @@ func (blockchain *BlockChain) VerifyPreSignShardBlock(shardBlock *ShardBlock, sh
 		}
 	}
 	ctx, _ = context.WithTimeout(context.Background(), time.Second*5)
-	if checkBeaconUntilTimeout(ctx) != nil {
+	_, beaconSpan := tracing.StartSpan(traceCtx, "blockchain.waitForBeacon", tracing.Uint64("beaconHeight", shardBlock.Header.BeaconHeight))
+	err = checkBeaconUntilTimeout(ctx)
+	beaconSpan.SetError(err)
+	beaconSpan.End()
+	if err != nil {
 		return errors.New(fmt.Sprintf("Beacon %d not ready, latest is %d", shardBlock.Header.BeaconHeight, blockchain.GetBeaconBestState().BeaconHeight))
 	}
 
@@ -79,7 +90,7 @@ func (blockchain *BlockChain) VerifyPreSignShardBlock(shardBlock *ShardBlock, sh
 	}
 
 	//========Verify shardBlock only
-	if err := blockchain.verifyPreProcessingShardBlock(curView, shardBlock, beaconBlocks, shardID, true); err != nil {
+	if err := blockchain.verifyPreProcessingShardBlock(traceCtx, curView, shardBlock, beaconBlocks, shardID, true); err != nil {
 		return err
 	}
 	//========Verify shardBlock with previous best state
@@ -104,10 +115,16 @@ func (blockchain *BlockChain) VerifyPreSignShardBlock(shardBlock *ShardBlock, sh
 
 // InsertShardBlock Insert Shard Block into blockchain
 // this block must have full information (complete block)
-func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldValidate bool) error {
+func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldValidate bool) (err error) {
 	blockHash := shardBlock.Header.Hash()
 	blockHeight := shardBlock.Header.Height
 	shardID := shardBlock.Header.ShardID
+	ctx, span := tracing.StartSpan(tracing.ContextForBlock(blockHash.String()), "blockchain.InsertShardBlock",
+		tracing.Int("shardID", int(shardID)), tracing.Uint64("height", blockHeight), tracing.Bool("shouldValidate", shouldValidate))
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
 
 	Logger.log.Infof("SHARD %+v | InsertShardBlock %+v with hash %+v \n", shardID, blockHeight, blockHash)
 	blockchain.ShardChain[int(shardID)].insertLock.Lock()
@@ -140,7 +157,7 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldVal
 	}
 	if shouldValidate {
 		Logger.log.Infof("SHARD %+v | Verify Pre Processing, block height %+v with hash %+vt \n", shardID, blockHeight, blockHash)
-		if err := blockchain.verifyPreProcessingShardBlock(curView, shardBlock, beaconBlocks, shardID, false); err != nil {
+		if err := blockchain.verifyPreProcessingShardBlock(ctx, curView, shardBlock, beaconBlocks, shardID, false); err != nil {
 			return err
 		}
 	} else {
@@ -162,7 +179,10 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldVal
 	}
 
 	Logger.log.Debugf("SHARD %+v | Update ShardBestState, block height %+v with hash %+v \n", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
+	_, updateSpan := tracing.StartSpan(ctx, "blockchain.updateShardBestState")
 	newBestState, err := curView.updateShardBestState(blockchain, shardBlock, beaconBlocks, committeeChange)
+	updateSpan.SetError(err)
+	updateSpan.End()
 	if err != nil {
 		return err
 	}
@@ -189,7 +209,10 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldVal
 	}
 	Logger.log.Infof("SHARD %+v | Store New Shard Block And Update Data, block height %+v with hash %+v \n", shardID, blockHeight, blockHash)
 	//========Store new  Shard block and new shard bestState
+	_, storeSpan := tracing.StartSpan(ctx, "blockchain.processStoreShardBlock")
 	err = blockchain.processStoreShardBlock(newBestState, shardBlock, committeeChange, beaconBlocks)
+	storeSpan.SetError(err)
+	storeSpan.End()
 	if err != nil {
 
 		return err
@@ -252,7 +275,9 @@ func (shardBestState *ShardBestState) updateNumOfBlocksByProducers(shardBlock *S
 //	- Validate transaction created from miner via instruction
 //	- Validate Response Transaction From Transaction with Metadata
 //	- ALL Transaction in block: see in verifyTransactionFromNewBlock
-func (blockchain *BlockChain) verifyPreProcessingShardBlock(curView *ShardBestState, shardBlock *ShardBlock, beaconBlocks []*BeaconBlock, shardID byte, isPreSign bool) error {
+func (blockchain *BlockChain) verifyPreProcessingShardBlock(ctx context.Context, curView *ShardBestState, shardBlock *ShardBlock, beaconBlocks []*BeaconBlock, shardID byte, isPreSign bool) error {
+	ctx, span := tracing.StartSpan(ctx, "blockchain.verifyPreProcessingShardBlock")
+	defer span.End()
 	startTimeVerifyPreProcessingShardBlock := time.Now()
 	Logger.log.Debugf("SHARD %+v | Begin verifyPreProcessingShardBlock Block with height %+v at hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, shardBlock.Hash())
 	if shardBlock.Header.ShardID != shardID {
@@ -428,7 +453,7 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlock(curView *ShardBestSt
 	shardVerifyPreprocesingTimer.UpdateSince(startTimeVerifyPreProcessingShardBlock)
 	// Get cross shard shardBlock from pool
 	if isPreSign {
-		err := blockchain.verifyPreProcessingShardBlockForSigning(curView, shardBlock, beaconBlocks, txInstructions, shardID)
+		err := blockchain.verifyPreProcessingShardBlockForSigning(ctx, curView, shardBlock, beaconBlocks, txInstructions, shardID)
 		if err != nil {
 			return err
 		}
@@ -443,14 +468,14 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlock(curView *ShardBestSt
 //	- Get Cross Output Data from cross shard block (shard pool) and verify cross transaction hash
 //	- Get Cross Tx Custom Token from cross shard block (shard pool) then verify
 //
-func (blockchain *BlockChain) verifyPreProcessingShardBlockForSigning(curView *ShardBestState, shardBlock *ShardBlock, beaconBlocks []*BeaconBlock, txInstructions [][]string, shardID byte) error {
+func (blockchain *BlockChain) verifyPreProcessingShardBlockForSigning(ctx context.Context, curView *ShardBestState, shardBlock *ShardBlock, beaconBlocks []*BeaconBlock, txInstructions [][]string, shardID byte) error {
 	var err error
 	var isOldBeaconHeight = false
 	startTimeVerifyPreProcessingShardBlockForSigning := time.Now()
 	// Verify Transaction
 	//get beacon height from shard block
 	beaconHeight := shardBlock.Header.BeaconHeight
-	if err := blockchain.verifyTransactionFromNewBlock(shardID, shardBlock.Body.Transactions, int64(beaconHeight), curView); err != nil {
+	if err := blockchain.verifyTransactionFromNewBlock(ctx, shardID, shardBlock.Body.Transactions, int64(beaconHeight), curView); err != nil {
 		return NewBlockChainError(TransactionFromNewBlockError, err)
 	}
 	// Verify Instruction
@@ -488,7 +513,10 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlockForSigning(curView *S
 			crossShardRequired[fromShard] = append(crossShardRequired[fromShard], crossTransaction.BlockHeight)
 		}
 	}
+	_, crossSpan := tracing.StartSpan(ctx, "blockchain.waitCrossShardBlocks", tracing.Int("fromShards", len(crossShardRequired)))
 	crossShardBlksFromPool, err := blockchain.config.Syncker.GetCrossShardBlocksForShardValidator(toShard, crossShardRequired)
+	crossSpan.SetError(err)
+	crossSpan.End()
 	if err != nil {
 		return NewBlockChainError(CrossShardBlockError, fmt.Errorf("Unable to get required crossShard blocks from pool in time"))
 	}
@@ -955,10 +983,12 @@ func (blockchain *BlockChain) verifyPostProcessingShardBlock(shardBestState *Sha
 //	9. Not accept a salary tx
 //	10. Check duplicate staker public key in block
 //	11. Check duplicate Init Custom Token in block
-func (blockchain *BlockChain) verifyTransactionFromNewBlock(shardID byte, txs []metadata.Transaction, beaconHeight int64, curView *ShardBestState) error {
+func (blockchain *BlockChain) verifyTransactionFromNewBlock(ctx context.Context, shardID byte, txs []metadata.Transaction, beaconHeight int64, curView *ShardBestState) error {
 	if len(txs) == 0 {
 		return nil
 	}
+	ctx, span := tracing.StartSpan(ctx, "blockchain.verifyTransactionFromNewBlock", tracing.Int("txs", len(txs)))
+	defer span.End()
 	isEmpty := blockchain.config.TempTxPool.EmptyPool()
 	if !isEmpty {
 		panic("TempTxPool Is not Empty")
@@ -970,7 +1000,7 @@ func (blockchain *BlockChain) verifyTransactionFromNewBlock(shardID byte, txs []
 			listTxs = append(listTxs, tx)
 		}
 	}
-	_, err := blockchain.config.TempTxPool.MaybeAcceptBatchTransactionForBlockProducing(shardID, listTxs, beaconHeight, curView)
+	_, err := blockchain.config.TempTxPool.MaybeAcceptBatchTransactionForBlockProducing(ctx, shardID, listTxs, beaconHeight, curView)
 	if err != nil {
 		Logger.log.Errorf("Batching verify transactions from new block err: %+v\n Trying verify one by one", err)
 		for index, tx := range listTxs {
@@ -1091,63 +1121,27 @@ func (blockchain *BlockChain) processStoreShardBlock(newShardState *ShardBestSta
 		return NewBlockChainError(StoreShardBlockError, err)
 	}
 
-	// consensus root hash
-	consensusRootHash, err := newShardState.consensusStateDB.Commit(true) // Store data to memory
-	if err != nil {
-		return NewBlockChainError(StoreShardBlockError, err)
-	}
-	err = newShardState.consensusStateDB.Database().TrieDB().Commit(consensusRootHash, false) // Save data to disk database
+	// the state and the block are written in one batch, the state databases are committed in parallel
+	batchData := blockchain.GetShardChainDatabase(shardID).NewBatch()
+	stateDBs := []*statedb.StateDB{
+		newShardState.consensusStateDB,
+		newShardState.transactionStateDB,
+		newShardState.featureStateDB,
+		newShardState.rewardStateDB,
+		newShardState.slashStateDB,
+	}
+	startTimeCommitState := time.Now()
+	roots, err := statedb.CommitStateDBs(batchData, stateDBs...)
 	if err != nil {
 		return NewBlockChainError(StoreShardBlockError, err)
 	}
-
+	shardCommitStateTimer.UpdateSince(startTimeCommitState)
+	consensusRootHash, transactionRootHash, featureRootHash, rewardRootHash, slashRootHash := roots[0], roots[1], roots[2], roots[3], roots[4]
 	newShardState.ConsensusStateDBRootHash = consensusRootHash
-	// transaction root hash
-	transactionRootHash, err := newShardState.transactionStateDB.Commit(true)
-	if err != nil {
-		return NewBlockChainError(StoreShardBlockError, err)
-	}
-	err = newShardState.transactionStateDB.Database().TrieDB().Commit(transactionRootHash, false)
-	if err != nil {
-		return NewBlockChainError(StoreShardBlockError, err)
-	}
 	newShardState.TransactionStateDBRootHash = transactionRootHash
-	// feature root hash
-	featureRootHash, err := newShardState.featureStateDB.Commit(true)
-	if err != nil {
-		return NewBlockChainError(StoreShardBlockError, err)
-	}
-	err = newShardState.featureStateDB.Database().TrieDB().Commit(featureRootHash, false)
-	if err != nil {
-		return NewBlockChainError(StoreShardBlockError, err)
-	}
 	newShardState.FeatureStateDBRootHash = featureRootHash
-	// reward root hash
-	rewardRootHash, err := newShardState.rewardStateDB.Commit(true)
-	if err != nil {
-		return NewBlockChainError(StoreShardBlockError, err)
-	}
-	err = newShardState.rewardStateDB.Database().TrieDB().Commit(rewardRootHash, false)
-	if err != nil {
-		return NewBlockChainError(StoreShardBlockError, err)
-	}
 	newShardState.RewardStateDBRootHash = rewardRootHash
-	// slash root hash
-	slashRootHash, err := newShardState.slashStateDB.Commit(true)
-	if err != nil {
-		return NewBlockChainError(StoreShardBlockError, err)
-	}
-	err = newShardState.slashStateDB.Database().TrieDB().Commit(slashRootHash, false)
-	if err != nil {
-		return NewBlockChainError(StoreShardBlockError, err)
-	}
-	newShardState.consensusStateDB.ClearObjects()
-	newShardState.transactionStateDB.ClearObjects()
-	newShardState.featureStateDB.ClearObjects()
-	newShardState.rewardStateDB.ClearObjects()
-	newShardState.slashStateDB.ClearObjects()
 
-	batchData := blockchain.GetShardChainDatabase(shardID).NewBatch()
 	sRH := ShardRootHash{
 		ConsensusStateDBRootHash:   consensusRootHash,
 		FeatureStateDBRootHash:     featureRootHash,
@@ -1169,12 +1163,28 @@ func (blockchain *BlockChain) processStoreShardBlock(newShardState *ShardBestSta
 	newFinalView := blockchain.ShardChain[shardID].multiView.GetFinalView()
 
 	storeBlock := newFinalView.GetBlock()
-
+	finalizedBlocks := []*ShardBlock{}
 	for finalView == nil || storeBlock.GetHeight() > finalView.GetHeight() {
 		err := rawdbv2.StoreFinalizedShardBlockHashByIndex(batchData, shardID, storeBlock.GetHeight(), *storeBlock.Hash())
 		if err != nil {
 			return NewBlockChainError(StoreBeaconBlockError, err)
 		}
+		finalizedBlock := storeBlock.(*ShardBlock)
+		// blocks of other forks may have indexed the same txs, index them in the final block
+		for index, tx := range finalizedBlock.Body.Transactions {
+			if err := rawdbv2.StoreTransactionIndex(batchData, *tx.Hash(), *finalizedBlock.Hash(), index); err != nil {
+				return NewBlockChainError(FetchAndStoreTransactionError, err)
+			}
+		}
+		// the cross shard outputs are only receipted by final blocks, a fork processing them leaves no receipt
+		for _, crossTransactions := range finalizedBlock.Body.CrossTransactions {
+			for _, crossTransaction := range crossTransactions {
+				if err := rawdbv2.StoreCrossShardReceipt(batchData, crossTransaction.BlockHash, finalizedBlock.Header.Height, *finalizedBlock.Hash()); err != nil {
+					return NewBlockChainError(StoreShardBlockError, err)
+				}
+			}
+		}
+		finalizedBlocks = append(finalizedBlocks, finalizedBlock)
 		if storeBlock.GetHeight() == 1 {
 			break
 		}
@@ -1198,6 +1208,20 @@ func (blockchain *BlockChain) processStoreShardBlock(newShardState *ShardBestSta
 	if err := batchData.Write(); err != nil {
 		return NewBlockChainError(StoreShardBlockError, err)
 	}
+	if err := statedb.UncacheStateDBs(batchData, stateDBs...); err != nil {
+		return NewBlockChainError(StoreShardBlockError, err)
+	}
+	// notify the blocks becoming final, lowest first, publishing in the order blocks are stored keeps the heights of
+	// the notifications increasing
+	for i := len(finalizedBlocks) - 1; i >= 0; i-- {
+		blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.FinalizedShardBlockTopic, finalizedBlocks[i]))
+	}
+
+	if blockchain.IsPruning() {
+		if err := blockchain.pruneShardBlocks(shardBlock.Header.ShardID); err != nil {
+			Logger.log.Errorf("SHARD %+v | prune blocks error %+v", shardBlock.Header.ShardID, err)
+		}
+	}
 
 	if !blockchain.config.ChainParams.IsBackup {
 		return nil
@@ -1211,7 +1235,7 @@ func (blockchain *BlockChain) processStoreShardBlock(newShardState *ShardBestSta
 	}
 
 	if backupPoint {
-		err := blockchain.GetShardChainDatabase(newShardState.ShardID).Backup(fmt.Sprintf("../../backup/shard%d/%d", newShardState.ShardID, newShardState.Epoch))
+		err := blockchain.GetShardChainDatabase(newShardState.ShardID).Backup(fmt.Sprintf("../../backup/shard%d", newShardState.ShardID), newShardState.backupInfo())
 		if err != nil {
 			blockchain.GetShardChainDatabase(newShardState.ShardID).RemoveBackup(fmt.Sprintf("../../backup/shard%d/%d", newShardState.ShardID, newShardState.Epoch))
 		}
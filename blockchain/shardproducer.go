@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -13,6 +14,7 @@ import (
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/transaction"
 )
 
@@ -51,7 +53,9 @@ import (
 //	3. Build Shard Block Essential Data for Header
 //	4. Update Cloned ShardBestState with New Shard Block
 //	5. Create Root Hash from New Shard Block and updated Clone Shard Beststate Data
-func (blockchain *BlockChain) NewBlockShard(curView *ShardBestState, version int, proposer string, round int, start time.Time) (*ShardBlock, error) {
+func (blockchain *BlockChain) NewBlockShard(ctx context.Context, curView *ShardBestState, version int, proposer string, round int, start time.Time) (*ShardBlock, error) {
+	ctx, span := tracing.StartSpan(ctx, "blockchain.NewBlockShard")
+	defer span.End()
 	var (
 		transactionsForNewBlock = make([]metadata.Transaction, 0)
 		totalTxsFee             = make(map[common.Hash]uint64)
@@ -130,12 +134,15 @@ func (blockchain *BlockChain) NewBlockShard(curView *ShardBestState, version int
 	//==========Build block body============
 	// Get Transaction For new Block
 	// Get Cross output coin from other shard && produce cross shard transaction
+	_, crossSpan := tracing.StartSpan(ctx, "blockchain.getCrossShardData")
 	crossTransactions := blockchain.config.BlockGen.getCrossShardData(shardID, shardBestState.BeaconHeight, beaconHeight)
+	crossSpan.SetAttributes(tracing.Int("fromShards", len(crossTransactions)))
+	crossSpan.End()
 	Logger.log.Critical("Cross Transaction: ", crossTransactions)
 	// Get Transaction for new block
 	// // startStep = time.Now()
 	blockCreationLeftOver := curView.BlockMaxCreateTime.Nanoseconds() - time.Since(start).Nanoseconds()
-	txsToAddFromBlock, err := blockchain.config.BlockGen.getTransactionForNewBlock(curView, &tempPrivateKey, shardID, beaconBlocks, blockCreationLeftOver, beaconHeight)
+	txsToAddFromBlock, err := blockchain.config.BlockGen.getTransactionForNewBlock(ctx, curView, &tempPrivateKey, shardID, beaconBlocks, blockCreationLeftOver, beaconHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -183,7 +190,10 @@ func (blockchain *BlockChain) NewBlockShard(curView *ShardBestState, version int
 	}
 	//============Update Shard BestState=============
 	// startStep = time.Now()
+	_, updateSpan := tracing.StartSpan(ctx, "blockchain.updateShardBestState")
 	newShardBestState, err := shardBestState.updateShardBestState(blockchain, newShardBlock, beaconBlocks, committeeChange)
+	updateSpan.SetError(err)
+	updateSpan.End()
 	if err != nil {
 		return nil, err
 	}
@@ -265,8 +275,10 @@ func (blockchain *BlockChain) NewBlockShard(curView *ShardBestState, version int
 // 3. Build response Transaction For Shard
 // 4. Build response Transaction For Beacon
 // 5. Return valid transaction from pending, response transactions from shard and beacon
-func (blockGenerator *BlockGenerator) getTransactionForNewBlock(curView *ShardBestState, privatekey *privacy.PrivateKey, shardID byte, beaconBlocks []*BeaconBlock, blockCreation int64, beaconHeight uint64) ([]metadata.Transaction, error) {
-	txsToAdd, txToRemove, _ := blockGenerator.getPendingTransaction(shardID, beaconBlocks, blockCreation, beaconHeight, curView)
+func (blockGenerator *BlockGenerator) getTransactionForNewBlock(ctx context.Context, curView *ShardBestState, privatekey *privacy.PrivateKey, shardID byte, beaconBlocks []*BeaconBlock, blockCreation int64, beaconHeight uint64) ([]metadata.Transaction, error) {
+	ctx, span := tracing.StartSpan(ctx, "blockchain.getTransactionForNewBlock")
+	defer span.End()
+	txsToAdd, txToRemove, _ := blockGenerator.getPendingTransaction(ctx, shardID, beaconBlocks, blockCreation, beaconHeight, curView)
 	if len(txsToAdd) == 0 {
 		Logger.log.Info("Creating empty block...")
 	}
@@ -277,7 +289,10 @@ func (blockGenerator *BlockGenerator) getTransactionForNewBlock(curView *ShardBe
 	cError = make(chan error)
 	go func() {
 		var err error
+		_, responseSpan := tracing.StartSpan(ctx, "blockchain.buildResponseTxsFromBeaconInstructions")
 		responseTxsBeacon, errInstructions, err = blockGenerator.buildResponseTxsFromBeaconInstructions(curView, beaconBlocks, privatekey, shardID)
+		responseSpan.SetError(err)
+		responseSpan.End()
 		cError <- err
 	}()
 	nilCount := 0
@@ -404,6 +419,11 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(cur
 				if len(l) >= 4 && l[2] == common.PortalTopUpWaitingPortingRejectedChainStatus {
 					newTx, err = curView.buildPortalRejectedTopUpWaitingPortingTx(l[3], producerPrivateKey, shardID)
 				}
+			// htlc
+			case metadata.HTLCLockRequestMeta, metadata.HTLCClaimRequestMeta, metadata.HTLCRefundRequestMeta:
+				if len(l) >= 4 && beaconBlock.Header.Height >= blockGenerator.chain.GetBeaconHeightBreakPointHTLC() {
+					newTx, err = blockGenerator.buildHTLCResponseTx(metaType, l[2], l[3], producerPrivateKey, shardID, curView, beaconView)
+				}
 			default:
 				continue
 			}
@@ -650,36 +670,31 @@ func (blockGenerator *BlockGenerator) getCrossShardData(toShard byte, lastBeacon
 	Verify Transaction with these condition: defined in mempool.go
 */
 func (blockGenerator *BlockGenerator) getPendingTransaction(
+	ctx context.Context,
 	shardID byte,
 	beaconBlocks []*BeaconBlock,
 	blockCreationTimeLeftOver int64,
 	beaconHeight uint64,
 	curView *ShardBestState,
 ) (txsToAdd []metadata.Transaction, txToRemove []metadata.Transaction, totalFee uint64) {
+	ctx, span := tracing.StartSpan(ctx, "blockchain.getPendingTransaction")
+	defer func() {
+		span.SetAttributes(tracing.Int("txsToAdd", len(txsToAdd)), tracing.Int("txsToRemove", len(txToRemove)))
+		span.End()
+	}()
 	spareTime := SpareTime * time.Millisecond
 	maxBlockCreationTimeLeftTime := blockCreationTimeLeftOver - spareTime.Nanoseconds()
 	startTime := time.Now()
-	sourceTxns := blockGenerator.GetPendingTxsV2()
 	var elasped int64
-	Logger.log.Info("Number of transaction get from Block Generator: ", len(sourceTxns))
 	isEmpty := blockGenerator.chain.config.TempTxPool.EmptyPool()
 	if !isEmpty {
 		return []metadata.Transaction{}, []metadata.Transaction{}, 0
 	}
+	// highest fee per KB first, within the block size and the quota of each metadata type
+	template, preparedTxForNewBlock := blockGenerator.NewBlockTemplate(shardID, beaconHeight)
+	Logger.log.Infof("Block template: %+v of %+v transactions from Block Generator, size %+v KB, fee %+v",
+		len(preparedTxForNewBlock), len(preparedTxForNewBlock)+template.NumSkipped, template.Size, template.Fee)
 	currentSize := uint64(0)
-	preparedTxForNewBlock := []metadata.Transaction{}
-	for _, tx := range sourceTxns {
-		tempSize := tx.GetTxActualSize()
-		if currentSize+tempSize >= common.MaxBlockSize {
-			break
-		}
-		preparedTxForNewBlock = append(preparedTxForNewBlock, tx)
-		elasped = time.Since(startTime).Nanoseconds()
-		if elasped >= maxBlockCreationTimeLeftTime {
-			Logger.log.Info("Shard Producer/Elapsed, Break: ", elasped)
-			break
-		}
-	}
 	listBatchTxs := []metadata.Transaction{}
 	for index, tx := range preparedTxForNewBlock {
 		elasped = time.Since(startTime).Nanoseconds()
@@ -689,7 +704,7 @@ func (blockGenerator *BlockGenerator) getPendingTransaction(
 		}
 		listBatchTxs = append(listBatchTxs, tx)
 		if ((index+1)%TransactionBatchSize == 0) || (index == len(preparedTxForNewBlock)-1) {
-			tempTxDesc, err := blockGenerator.chain.config.TempTxPool.MaybeAcceptBatchTransactionForBlockProducing(shardID, listBatchTxs, int64(beaconHeight), curView)
+			tempTxDesc, err := blockGenerator.chain.config.TempTxPool.MaybeAcceptBatchTransactionForBlockProducing(ctx, shardID, listBatchTxs, int64(beaconHeight), curView)
 			if err != nil {
 				Logger.log.Errorf("SHARD %+v | Verify Batch Transaction for new block error %+v", shardID, err)
 				for _, tx2 := range listBatchTxs {
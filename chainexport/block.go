@@ -0,0 +1,314 @@
+package chainexport
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// Kinds of the actions, from the metadata type of their transaction
+const (
+	ActionPDETrade        = "pdetrade"
+	ActionPDEContribution = "pdecontribution"
+	ActionPDEWithdrawal   = "pdewithdrawal"
+	ActionPortal          = "portal"
+	ActionStaking         = "staking"
+	ActionBridgeIssuance  = "bridgeissuance"
+	ActionBridgeBurn      = "bridgeburn"
+	ActionReward          = "reward"
+	ActionRelaying        = "relaying"
+	ActionHTLC            = "htlc"
+	ActionOther           = "other"
+)
+
+// Changes of the committees, from the instructions of the beacon blocks
+const (
+	ChangeStake         = "stake"
+	ChangeAssign        = "assign"
+	ChangeSwapIn        = "swapin"
+	ChangeSwapOut       = "swapout"
+	ChangeStopAutoStake = "stopautostake"
+)
+
+// Block - a finalized block of a chain with the rows exported for it
+type Block struct {
+	ChainID          int // common.BeaconChainDataBaseID for the beacon chain
+	Height           uint64
+	Hash             common.Hash
+	PreviousHash     common.Hash
+	Epoch            uint64
+	Round            int
+	Timestamp        int64
+	Producer         string
+	Version          int
+	BeaconHeight     uint64 // beacon height a shard block is built on, 0 for a beacon block
+	Txs              []Tx
+	Actions          []Action
+	Instructions     []Instruction // beacon block only
+	CommitteeChanges []CommitteeChange
+}
+
+type Tx struct {
+	Index        int
+	Hash         common.Hash
+	Type         string
+	Fee          uint64
+	FeeToken     uint64
+	TokenID      string
+	MetadataType int
+	Privacy      bool
+	Size         uint64
+	LockTime     int64
+}
+
+// Action - the metadata of a transaction. The token, the amount and the address are set for the requests analysts
+// query the most, the whole metadata is in Data
+type Action struct {
+	TxIndex      int
+	TxHash       common.Hash
+	Kind         string
+	MetadataType int
+	TokenID      string
+	Amount       uint64
+	Address      string
+	Data         string // metadata in json
+}
+
+type Instruction struct {
+	Index  int
+	Action string
+	Data   string // instruction in json
+}
+
+// CommitteeChange - a committee public key staked, assigned, swapped or stopping auto staking by an instruction
+type CommitteeChange struct {
+	InstructionIndex int
+	Change           string
+	Chain            string // beacon or shard, empty for stop auto staking
+	ShardID          int    // -1 when it is not a shard committee
+	PublicKey        string
+}
+
+// NewShardBlock - the rows of a shard block
+func NewShardBlock(shardBlock *blockchain.ShardBlock) (*Block, error) {
+	header := &shardBlock.Header
+	block := &Block{
+		ChainID:      int(header.ShardID),
+		Height:       header.Height,
+		Hash:         *shardBlock.Hash(),
+		PreviousHash: header.PreviousBlockHash,
+		Epoch:        header.Epoch,
+		Round:        header.Round,
+		Timestamp:    header.Timestamp,
+		Producer:     header.Producer,
+		Version:      header.Version,
+		BeaconHeight: header.BeaconHeight,
+	}
+	for index, tx := range shardBlock.Body.Transactions {
+		block.Txs = append(block.Txs, Tx{
+			Index:        index,
+			Hash:         *tx.Hash(),
+			Type:         tx.GetType(),
+			Fee:          tx.GetTxFee(),
+			FeeToken:     tx.GetTxFeeToken(),
+			TokenID:      tx.GetTokenID().String(),
+			MetadataType: tx.GetMetadataType(),
+			Privacy:      tx.IsPrivacy(),
+			Size:         tx.GetTxActualSize(),
+			LockTime:     tx.GetLockTime(),
+		})
+		meta := tx.GetMetadata()
+		if meta == nil {
+			continue
+		}
+		action, err := newAction(index, *tx.Hash(), meta)
+		if err != nil {
+			return nil, err
+		}
+		block.Actions = append(block.Actions, *action)
+	}
+	return block, nil
+}
+
+// NewBeaconBlock - the rows of a beacon block
+func NewBeaconBlock(beaconBlock *blockchain.BeaconBlock) *Block {
+	return newBeaconBlock(beaconBlock.Hash(), &beaconBlock.Header, beaconBlock.Body.Instructions)
+}
+
+// newBeaconBlock - the rows of the beacon block of hash, a block read from the RPC only has some fields of its
+// header and can not be hashed again
+func newBeaconBlock(hash *common.Hash, header *blockchain.BeaconHeader, instructions [][]string) *Block {
+	block := &Block{
+		ChainID:      common.BeaconChainDataBaseID,
+		Height:       header.Height,
+		Hash:         *hash,
+		PreviousHash: header.PreviousBlockHash,
+		Epoch:        header.Epoch,
+		Round:        header.Round,
+		Timestamp:    header.Timestamp,
+		Producer:     header.Producer,
+		Version:      header.Version,
+	}
+	for index, instruction := range instructions {
+		if len(instruction) == 0 {
+			continue
+		}
+		data, _ := json.Marshal(instruction)
+		block.Instructions = append(block.Instructions, Instruction{
+			Index:  index,
+			Action: instruction[0],
+			Data:   string(data),
+		})
+		block.CommitteeChanges = append(block.CommitteeChanges, getCommitteeChanges(index, instruction)...)
+	}
+	return block
+}
+
+func newAction(txIndex int, txHash common.Hash, meta metadata.Metadata) (*Action, error) {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	action := &Action{
+		TxIndex:      txIndex,
+		TxHash:       txHash,
+		Kind:         getActionKind(meta.GetType()),
+		MetadataType: meta.GetType(),
+		Data:         string(data),
+	}
+	switch meta := meta.(type) {
+	case *metadata.PDETradeRequest:
+		action.TokenID, action.Amount, action.Address = meta.TokenIDToSellStr, meta.SellAmount, meta.TraderAddressStr
+	case *metadata.PDECrossPoolTradeRequest:
+		action.TokenID, action.Amount, action.Address = meta.TokenIDToSellStr, meta.SellAmount, meta.TraderAddressStr
+	case *metadata.PDEContribution:
+		action.TokenID, action.Amount, action.Address = meta.TokenIDStr, meta.ContributedAmount, meta.ContributorAddressStr
+	case *metadata.StakingMetadata:
+		action.TokenID, action.Amount, action.Address = common.PRVCoinID.String(), meta.StakingAmountShard, meta.FunderPaymentAddress
+	case *metadata.DelegationRequest:
+		action.TokenID, action.Amount, action.Address = common.PRVCoinID.String(), meta.Amount, meta.DelegatorAddressStr
+	case *metadata.HTLCLockRequest:
+		action.TokenID, action.Amount, action.Address = meta.TokenIDStr, meta.Amount, meta.ReceiverAddressStr
+	case *metadata.IssuingRequest:
+		action.TokenID, action.Amount, action.Address = meta.TokenID.String(), meta.DepositedAmount, serializePaymentAddress(meta.ReceiverAddress)
+	case *metadata.IssuingETHRequest:
+		action.TokenID = meta.IncTokenID.String()
+	case *metadata.ContractingRequest:
+		action.TokenID, action.Amount, action.Address = meta.TokenID.String(), meta.BurnedAmount, serializePaymentAddress(meta.BurnerAddress)
+	case *metadata.BurningRequest:
+		action.TokenID, action.Amount, action.Address = meta.TokenID.String(), meta.BurningAmount, meta.RemoteAddress
+	case *metadata.PortalUserRegister:
+		action.TokenID, action.Amount, action.Address = meta.PTokenId, meta.RegisterAmount, meta.IncogAddressStr
+	case *metadata.PortalRedeemRequest:
+		action.TokenID, action.Amount, action.Address = meta.TokenID, meta.RedeemAmount, meta.RedeemerIncAddressStr
+	case *metadata.PortalCustodianDeposit:
+		action.TokenID, action.Amount, action.Address = common.PRVCoinID.String(), meta.DepositedAmount, meta.IncogAddressStr
+	}
+	return action, nil
+}
+
+func getActionKind(metaType int) string {
+	switch metaType {
+	case metadata.PDETradeRequestMeta, metadata.PDETradeResponseMeta,
+		metadata.PDECrossPoolTradeRequestMeta, metadata.PDECrossPoolTradeResponseMeta:
+		return ActionPDETrade
+	case metadata.PDEContributionMeta, metadata.PDEPRVRequiredContributionRequestMeta, metadata.PDEContributionResponseMeta:
+		return ActionPDEContribution
+	case metadata.PDEWithdrawalRequestMeta, metadata.PDEWithdrawalResponseMeta,
+		metadata.PDEFeeWithdrawalRequestMeta, metadata.PDEFeeWithdrawalResponseMeta, metadata.PDETradingFeesDistributionMeta:
+		return ActionPDEWithdrawal
+	case metadata.ShardStakingMeta, metadata.BeaconStakingMeta, metadata.StopAutoStakingMeta, metadata.ReturnStakingMeta,
+		metadata.DelegateMeta, metadata.UndelegateMeta, metadata.RedelegateMeta, metadata.DelegationCommissionMeta:
+		return ActionStaking
+	case metadata.IssuingRequestMeta, metadata.IssuingResponseMeta, metadata.IssuingETHRequestMeta, metadata.IssuingETHResponseMeta:
+		return ActionBridgeIssuance
+	case metadata.ContractingRequestMeta, metadata.BurningRequestMeta, metadata.BurningForDepositToSCRequestMeta:
+		return ActionBridgeBurn
+	case metadata.AcceptedBlockRewardInfoMeta, metadata.ShardBlockSalaryResponseMeta, metadata.BeaconRewardRequestMeta,
+		metadata.BeaconSalaryResponseMeta, metadata.IncDAORewardRequestMeta, metadata.ShardBlockRewardRequestMeta,
+		metadata.WithDrawRewardRequestMeta, metadata.WithDrawRewardResponseMeta:
+		return ActionReward
+	case metadata.RelayingBNBHeaderMeta, metadata.RelayingBTCHeaderMeta:
+		return ActionRelaying
+	case metadata.HTLCLockRequestMeta, metadata.HTLCClaimRequestMeta, metadata.HTLCRefundRequestMeta, metadata.HTLCResponseMeta:
+		return ActionHTLC
+	}
+	if (metaType >= metadata.PortalCustodianDepositMeta && metaType <= metadata.PortalLiquidationCustodianDepositResponseMetaV2) ||
+		metaType == metadata.PortalTopUpWaitingPortingRequestMeta || metaType == metadata.PortalTopUpWaitingPortingResponseMeta {
+		return ActionPortal
+	}
+	return ActionOther
+}
+
+// getCommitteeChanges - the keys of a stake, assign, swap or stop auto staking instruction:
+// ["stake" "key1,key2" "shard|beacon" ...]
+// ["assign" "key1,key2" "shard" "{shardID}"]
+// ["swap" "inKey1,inKey2" "outKey1,outKey2" "shard" "{shardID}" ...] or ["swap" "in" "out" "beacon" ...]
+// ["stopautostake" "key1,key2"]
+func getCommitteeChanges(index int, instruction []string) []CommitteeChange {
+	changes := []CommitteeChange{}
+	add := func(change string, keys string, chain string, shardID int) {
+		for _, key := range strings.Split(keys, ",") {
+			if key == "" {
+				continue
+			}
+			changes = append(changes, CommitteeChange{
+				InstructionIndex: index,
+				Change:           change,
+				Chain:            chain,
+				ShardID:          shardID,
+				PublicKey:        key,
+			})
+		}
+	}
+	switch instruction[0] {
+	case blockchain.StakeAction:
+		if len(instruction) > 2 {
+			add(ChangeStake, instruction[1], instruction[2], -1)
+		}
+	case blockchain.AssignAction:
+		if len(instruction) > 3 {
+			add(ChangeAssign, instruction[1], instruction[2], getShardID(instruction[2], instruction[3]))
+		}
+	case blockchain.SwapAction:
+		if len(instruction) > 3 {
+			shardID := -1
+			if len(instruction) > 4 {
+				shardID = getShardID(instruction[3], instruction[4])
+			}
+			add(ChangeSwapIn, instruction[1], instruction[3], shardID)
+			add(ChangeSwapOut, instruction[2], instruction[3], shardID)
+		}
+	case blockchain.StopAutoStake:
+		if len(instruction) > 1 {
+			add(ChangeStopAutoStake, instruction[1], "", -1)
+		}
+	}
+	return changes
+}
+
+func getShardID(chain string, value string) int {
+	if chain != "shard" {
+		return -1
+	}
+	shardID, err := strconv.Atoi(value)
+	if err != nil {
+		return -1
+	}
+	return shardID
+}
+
+func serializePaymentAddress(address privacy.PaymentAddress) string {
+	if len(address.Pk) == 0 {
+		return ""
+	}
+	keyWallet := wallet.KeyWallet{}
+	keyWallet.KeySet.PaymentAddress = address
+	return keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+}
@@ -0,0 +1,109 @@
+// Package chainexport exports the finalized blocks of the chains to a SQL database for analytics: the blocks, their
+// transactions, the actions of the transactions with metadata, the beacon instructions and the committee changes. A
+// checkpoint is kept for each chain, with the last block exported, so an export resumes where it stopped
+package chainexport
+
+import (
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// logBlocks - the export of a chain is logged every logBlocks blocks
+const logBlocks = 1000
+
+// Exporter - export the finalized blocks of a source to a store
+type Exporter struct {
+	source Source
+	store  Store
+}
+
+func NewExporter(source Source, store Store) *Exporter {
+	return &Exporter{
+		source: source,
+		store:  store,
+	}
+}
+
+// Sync exports the blocks of the chain from its checkpoint up to the final height of the source, until stop is
+// closed, and returns the number of blocks exported. When the block of the checkpoint is not in the final chain of
+// the source anymore, after the database of the node was rebuilt for example, the checkpoint moves back to the last
+// exported block which still is, and the rows of the blocks above it are deleted
+func (exporter *Exporter) Sync(chainID int, stop <-chan struct{}) (int, error) {
+	finalHeight, err := exporter.source.GetFinalHeight(chainID)
+	if err != nil {
+		return 0, err
+	}
+	height, hash, err := exporter.getCommonHeight(chainID, finalHeight)
+	if err != nil {
+		return 0, err
+	}
+	exported := 0
+	for height < finalHeight {
+		select {
+		case <-stop:
+			return exported, nil
+		default:
+		}
+		block, err := exporter.source.GetBlock(chainID, height+1)
+		if err != nil {
+			return exported, err
+		}
+		if block.PreviousHash != hash {
+			return exported, fmt.Errorf("block %v of chain %v at height %v does not follow the exported block %v, the final chain of the source changed", block.Hash.String(), chainID, block.Height, hash.String())
+		}
+		if err := exporter.store.WriteBlock(block); err != nil {
+			return exported, err
+		}
+		height, hash = block.Height, block.Hash
+		exported++
+		if height%logBlocks == 0 {
+			Logger.log.Infof("Chain %v is exported up to height %v of %v", chainID, height, finalHeight)
+		}
+	}
+	return exported, nil
+}
+
+// getCommonHeight returns the last exported block of the chain which is in the final chain of the source, the rows
+// of the blocks above it are rolled back
+func (exporter *Exporter) getCommonHeight(chainID int, finalHeight uint64) (uint64, common.Hash, error) {
+	checkpoint, err := exporter.store.GetCheckpoint(chainID)
+	if err != nil {
+		return 0, common.Hash{}, err
+	}
+	if checkpoint == nil {
+		return 0, common.Hash{}, nil
+	}
+	height, hash := checkpoint.Height, checkpoint.Hash
+	for height > 0 {
+		if height <= finalHeight {
+			block, err := exporter.source.GetBlock(chainID, height)
+			if err != nil {
+				return 0, common.Hash{}, err
+			}
+			if block.Hash == hash {
+				break
+			}
+		}
+		height--
+		if height == 0 {
+			hash = common.Hash{}
+			break
+		}
+		var ok bool
+		hash, ok, err = exporter.store.GetBlockHash(chainID, height)
+		if err != nil {
+			return 0, common.Hash{}, err
+		}
+		if !ok {
+			return 0, common.Hash{}, fmt.Errorf("no block of chain %v exported at height %v below the checkpoint %v", chainID, height, checkpoint.Height)
+		}
+	}
+	if height < checkpoint.Height {
+		if err := exporter.store.Rollback(chainID, height); err != nil {
+			return 0, common.Hash{}, err
+		}
+		Logger.log.Infof("Chain %v is rolled back from height %v to %v, the final chain of the source changed", chainID, checkpoint.Height, height)
+	}
+	return height, hash, nil
+}
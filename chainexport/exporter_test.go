@@ -0,0 +1,175 @@
+package chainexport
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSource - chains of blocks made by the test, the final chain of each chain can be forked
+type testSource struct {
+	chains map[int][]*Block
+}
+
+func (source *testSource) GetFinalHeight(chainID int) (uint64, error) {
+	return uint64(len(source.chains[chainID])), nil
+}
+
+func (source *testSource) GetBlock(chainID int, height uint64) (*Block, error) {
+	blocks := source.chains[chainID]
+	if height == 0 || height > uint64(len(blocks)) {
+		return nil, fmt.Errorf("no block of chain %v at height %v", chainID, height)
+	}
+	return blocks[height-1], nil
+}
+
+// extend appends count blocks to the chain from height, the blocks of a fork get other hashes
+func (source *testSource) extend(chainID int, height uint64, count int, fork string) {
+	blocks := source.chains[chainID][:height]
+	for i := 0; i < count; i++ {
+		block := &Block{
+			ChainID: chainID,
+			Height:  uint64(len(blocks) + 1),
+			Hash:    common.HashH([]byte(fmt.Sprintf("%v-%v-%v", chainID, len(blocks)+1, fork))),
+		}
+		if len(blocks) > 0 {
+			block.PreviousHash = blocks[len(blocks)-1].Hash
+		}
+		if chainID == common.BeaconChainDataBaseID {
+			block.Instructions = []Instruction{{Index: 0, Action: "swap", Data: `["swap","a,b","c","shard","0"]`}}
+			block.CommitteeChanges = getCommitteeChanges(0, []string{"swap", "a,b", "c", "shard", "0"})
+		} else {
+			txHash := common.HashH(block.Hash[:])
+			block.Txs = []Tx{{Index: 0, Hash: txHash, Type: common.TxNormalType, Fee: 100, TokenID: common.PRVCoinID.String(), MetadataType: metadata.PDETradeRequestMeta}}
+			block.Actions = []Action{{TxIndex: 0, TxHash: txHash, Kind: getActionKind(metadata.PDETradeRequestMeta), MetadataType: metadata.PDETradeRequestMeta, Amount: 1 << 63, Data: "{}"}}
+		}
+		blocks = append(blocks, block)
+	}
+	source.chains[chainID] = blocks
+}
+
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	count := 0
+	assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM "+table).Scan(&count))
+	return count
+}
+
+func TestExportResumeAndRollback(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	dir, err := ioutil.TempDir("", "chainexport")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "export.db")
+	store, err := NewSQLiteStore(path)
+	assert.Nil(t, err)
+	defer store.Close()
+	reader, err := sql.Open("sqlite3", path)
+	assert.Nil(t, err)
+	defer reader.Close()
+
+	beaconID := common.BeaconChainDataBaseID
+	source := &testSource{chains: make(map[int][]*Block)}
+	source.extend(beaconID, 0, 5, "")
+	source.extend(0, 0, 5, "")
+	exporter := NewExporter(source, store)
+	stop := make(chan struct{})
+	for _, chainID := range []int{beaconID, 0} {
+		exported, err := exporter.Sync(chainID, stop)
+		assert.Nil(t, err)
+		assert.Equal(t, 5, exported)
+	}
+	assert.Equal(t, 10, countRows(t, reader, "blocks"))
+	assert.Equal(t, 5, countRows(t, reader, "txs"))
+	assert.Equal(t, 5, countRows(t, reader, "actions"))
+	assert.Equal(t, 5, countRows(t, reader, "beacon_instructions"))
+	assert.Equal(t, 15, countRows(t, reader, "committee_changes"))
+	var fee int64
+	assert.Nil(t, reader.QueryRow("SELECT fee FROM txs WHERE height = 1").Scan(&fee))
+	assert.Equal(t, int64(100), fee)
+
+	// resume from the checkpoint
+	source.extend(0, 5, 3, "")
+	exported, err := exporter.Sync(0, stop)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, exported)
+	checkpoint, err := store.GetCheckpoint(0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(8), checkpoint.Height)
+	assert.Equal(t, source.chains[0][7].Hash, checkpoint.Hash)
+
+	// the final chain forks at height 6, the rows of the blocks 7 and 8 are replaced
+	source.extend(0, 6, 4, "fork")
+	exported, err = exporter.Sync(0, stop)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, exported)
+	checkpoint, err = store.GetCheckpoint(0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), checkpoint.Height)
+	for height := uint64(1); height <= 10; height++ {
+		hash, ok, err := store.GetBlockHash(0, height)
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, source.chains[0][height-1].Hash, hash)
+	}
+	assert.Equal(t, 10, countRows(t, reader, "txs"))
+	var txHash string
+	assert.Nil(t, reader.QueryRow("SELECT tx_hash FROM actions WHERE shard_id = 0 AND height = 7").Scan(&txHash))
+	assert.Equal(t, common.HashH(source.chains[0][6].Hash[:]).String(), txHash)
+
+	// the chain of the source is shorter than the checkpoint, like a database truncated to its final view
+	source.chains[beaconID] = source.chains[beaconID][:3]
+	exported, err = exporter.Sync(beaconID, stop)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, exported)
+	checkpoint, err = store.GetCheckpoint(beaconID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), checkpoint.Height)
+	assert.Equal(t, 3, countRows(t, reader, "beacon_instructions"))
+	assert.Equal(t, 9, countRows(t, reader, "committee_changes"))
+
+	// a store opened again keeps its checkpoints
+	assert.Nil(t, store.Close())
+	store, err = NewSQLiteStore(path)
+	assert.Nil(t, err)
+	defer store.Close()
+	checkpoint, err = store.GetCheckpoint(0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), checkpoint.Height)
+}
+
+func TestGetCommitteeChanges(t *testing.T) {
+	changes := getCommitteeChanges(2, []string{"swap", "in1,in2", "out1", "shard", "1", "{}"})
+	assert.Equal(t, []CommitteeChange{
+		{InstructionIndex: 2, Change: ChangeSwapIn, Chain: "shard", ShardID: 1, PublicKey: "in1"},
+		{InstructionIndex: 2, Change: ChangeSwapIn, Chain: "shard", ShardID: 1, PublicKey: "in2"},
+		{InstructionIndex: 2, Change: ChangeSwapOut, Chain: "shard", ShardID: 1, PublicKey: "out1"},
+	}, changes)
+	changes = getCommitteeChanges(0, []string{"swap", "in1", "", "beacon", "", "", "receiver"})
+	assert.Equal(t, []CommitteeChange{{Change: ChangeSwapIn, Chain: "beacon", ShardID: -1, PublicKey: "in1"}}, changes)
+	changes = getCommitteeChanges(0, []string{"assign", "key1", "shard", "3"})
+	assert.Equal(t, []CommitteeChange{{Change: ChangeAssign, Chain: "shard", ShardID: 3, PublicKey: "key1"}}, changes)
+	changes = getCommitteeChanges(0, []string{"stake", "key1,key2", "beacon", "tx1,tx2", "r1,r2", "true,false"})
+	assert.Equal(t, 2, len(changes))
+	assert.Equal(t, ChangeStake, changes[1].Change)
+	assert.Equal(t, "key2", changes[1].PublicKey)
+	assert.Equal(t, 0, len(getCommitteeChanges(0, []string{"random", "1", "2"})))
+}
+
+func TestGetActionKind(t *testing.T) {
+	assert.Equal(t, ActionPDETrade, getActionKind(metadata.PDECrossPoolTradeResponseMeta))
+	assert.Equal(t, ActionPDEContribution, getActionKind(metadata.PDEPRVRequiredContributionRequestMeta))
+	assert.Equal(t, ActionPortal, getActionKind(metadata.PortalRedeemRequestMeta))
+	assert.Equal(t, ActionPortal, getActionKind(metadata.PortalTopUpWaitingPortingRequestMeta))
+	assert.Equal(t, ActionStaking, getActionKind(metadata.DelegateMeta))
+	assert.Equal(t, ActionBridgeIssuance, getActionKind(metadata.IssuingETHRequestMeta))
+	assert.Equal(t, ActionBridgeBurn, getActionKind(metadata.BurningForDepositToSCRequestMeta))
+	assert.Equal(t, ActionHTLC, getActionKind(metadata.HTLCResponseMeta))
+	assert.Equal(t, ActionOther, getActionKind(metadata.InvalidMeta))
+}
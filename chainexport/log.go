@@ -0,0 +1,14 @@
+package chainexport
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type ChainExportLogger struct {
+	log common.Logger
+}
+
+func (chainExportLogger *ChainExportLogger) Init(inst common.Logger) {
+	chainExportLogger.log = inst
+}
+
+// Global instant to use
+var Logger = ChainExportLogger{}
@@ -0,0 +1,165 @@
+package chainexport
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcclient"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+)
+
+// RPCSource - read the blocks from a running node. The final height of a chain is the height of the last block the
+// node sent on its finalized block topic, a chain has no final height before its first notification
+type RPCSource struct {
+	client       *rpcclient.Client
+	finalHeights map[int]uint64
+	lock         sync.Mutex
+}
+
+func NewRPCSource(client *rpcclient.Client) *RPCSource {
+	return &RPCSource{
+		client:       client,
+		finalHeights: make(map[int]uint64),
+	}
+}
+
+// SetFinalHeight sets the final height of a chain from a notification of the node
+func (source *RPCSource) SetFinalHeight(chainID int, height uint64) {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+	source.finalHeights[chainID] = height
+}
+
+func (source *RPCSource) GetFinalHeight(chainID int) (uint64, error) {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+	height, ok := source.finalHeights[chainID]
+	if !ok {
+		return 0, fmt.Errorf("no block of chain %v is finalized yet", chainID)
+	}
+	return height, nil
+}
+
+// GetBlock reads the block of the best view at height, it is the block of the final chain up to the final height. A
+// shard block is read in its stored encoding, the node only gives some fields of the header of a beacon block
+func (source *RPCSource) GetBlock(chainID int, height uint64) (*Block, error) {
+	if chainID == common.BeaconChainDataBaseID {
+		results, err := source.client.RetrieveBeaconBlockByHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) == 0 || results[0] == nil {
+			return nil, fmt.Errorf("no beacon block at height %v", height)
+		}
+		return newBeaconBlockFromResult(results[0])
+	}
+	results, err := source.client.RetrieveBlockByHeight(height, chainID, "0")
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 || results[0] == nil {
+		return nil, fmt.Errorf("no block of shard %v at height %v", chainID, height)
+	}
+	data, err := hex.DecodeString(results[0].Data)
+	if err != nil {
+		return nil, err
+	}
+	shardBlock := blockchain.NewShardBlock()
+	if err := json.Unmarshal(data, shardBlock); err != nil {
+		return nil, err
+	}
+	return NewShardBlock(shardBlock)
+}
+
+func newBeaconBlockFromResult(result *jsonresult.GetBeaconBlockResult) (*Block, error) {
+	hash, err := common.Hash{}.NewHashFromStr(result.Hash)
+	if err != nil {
+		return nil, err
+	}
+	header := &blockchain.BeaconHeader{
+		Version:       result.Version,
+		Height:        result.Height,
+		Epoch:         result.Epoch,
+		Round:         result.Round,
+		Timestamp:     result.Time,
+		ConsensusType: result.ConsensusType,
+		Producer:      result.BlockProducer,
+	}
+	if result.PreviousBlockHash != "" {
+		previousHash, err := common.Hash{}.NewHashFromStr(result.PreviousBlockHash)
+		if err != nil {
+			return nil, err
+		}
+		header.PreviousBlockHash = *previousHash
+	}
+	return newBeaconBlock(hash, header, result.Instructions), nil
+}
+
+// Follow exports the blocks of the chains as the node finalizes them, until stop is closed. Every block the node
+// sends on the finalized block topic of a chain moves its final height and syncs the chain, the client subscribes
+// again after a reconnection and the next notification catches up the blocks missed meanwhile
+func Follow(exporter *Exporter, source *RPCSource, wsClient *rpcclient.WsClient, chainIDs []int, stop <-chan struct{}) error {
+	// a chain is synced once for the notifications received while it syncs
+	pending := make(map[int]chan struct{})
+	notify := func(chainID int, height uint64) {
+		source.SetFinalHeight(chainID, height)
+		select {
+		case pending[chainID] <- struct{}{}:
+		default:
+		}
+	}
+	for _, chainID := range chainIDs {
+		pending[chainID] = make(chan struct{}, 1)
+	}
+	for _, chainID := range chainIDs {
+		chainID := chainID
+		var err error
+		if chainID == common.BeaconChainDataBaseID {
+			_, err = wsClient.SubscribeFinalizedBeaconBlock(func(result *jsonresult.GetBeaconBlockResult, err error) {
+				if err != nil {
+					Logger.log.Errorf("Finalized beacon block notification error %v", err)
+					return
+				}
+				if result != nil && result.Hash != "" {
+					notify(chainID, result.Height)
+				}
+			})
+		} else {
+			_, err = wsClient.SubscribeFinalizedShardBlock(func(result *jsonresult.GetShardBlockResult, err error) {
+				if err != nil {
+					Logger.log.Errorf("Finalized shard %v block notification error %v", chainID, err)
+					return
+				}
+				if result != nil && result.Hash != "" {
+					notify(chainID, result.Height)
+				}
+			}, chainID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	wg := sync.WaitGroup{}
+	for _, chainID := range chainIDs {
+		wg.Add(1)
+		go func(chainID int) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-pending[chainID]:
+					if _, err := exporter.Sync(chainID, stop); err != nil {
+						Logger.log.Errorf("Export of chain %v failed, it is synced again on the next block, error %v", chainID, err)
+					}
+				}
+			}
+		}(chainID)
+	}
+	wg.Wait()
+	return nil
+}
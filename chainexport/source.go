@@ -0,0 +1,108 @@
+package chainexport
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// Source - where the finalized blocks of the chains are read
+type Source interface {
+	// GetFinalHeight returns the height of the final view of the chain
+	GetFinalHeight(chainID int) (uint64, error)
+	// GetBlock returns the block of the final chain at height, up to the final height
+	GetBlock(chainID int, height uint64) (*Block, error)
+}
+
+// DatabaseSource - read the blocks from the databases of a stopped node, or of a copy, with the finalized block index
+// of each chain up to the final view of its stored multiview
+type DatabaseSource struct {
+	db map[int]incdb.Database
+}
+
+func NewDatabaseSource(db map[int]incdb.Database) *DatabaseSource {
+	return &DatabaseSource{db: db}
+}
+
+func (source *DatabaseSource) getDatabase(chainID int) (incdb.Database, error) {
+	db, ok := source.db[chainID]
+	if !ok {
+		return nil, fmt.Errorf("no database for chain %v", chainID)
+	}
+	return db, nil
+}
+
+// GetFinalHeight returns the height of the first stored view, the views are stored from the final view
+func (source *DatabaseSource) GetFinalHeight(chainID int) (uint64, error) {
+	db, err := source.getDatabase(chainID)
+	if err != nil {
+		return 0, err
+	}
+	// only the height of the views is decoded
+	views := []struct {
+		BeaconHeight uint64
+		ShardHeight  uint64
+	}{}
+	var data []byte
+	if chainID == common.BeaconChainDataBaseID {
+		data, err = rawdbv2.GetBeaconViews(db)
+	} else {
+		data, err = rawdbv2.GetShardBestState(db, byte(chainID))
+	}
+	if err != nil {
+		return 0, fmt.Errorf("no stored views, error %v", err)
+	}
+	if err := json.Unmarshal(data, &views); err != nil {
+		return 0, fmt.Errorf("stored views can not be decoded, error %v", err)
+	}
+	if len(views) == 0 {
+		return 0, fmt.Errorf("no stored views")
+	}
+	if chainID == common.BeaconChainDataBaseID {
+		return views[0].BeaconHeight, nil
+	}
+	return views[0].ShardHeight, nil
+}
+
+func (source *DatabaseSource) GetBlock(chainID int, height uint64) (*Block, error) {
+	db, err := source.getDatabase(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if chainID == common.BeaconChainDataBaseID {
+		hash, err := rawdbv2.GetFinalizedBeaconBlockHashByIndex(db, height)
+		if err != nil {
+			return nil, err
+		}
+		data, err := rawdbv2.GetBeaconBlockByHash(db, *hash)
+		if err != nil {
+			return nil, err
+		}
+		beaconBlock := blockchain.NewBeaconBlock()
+		if err := json.Unmarshal(data, beaconBlock); err != nil {
+			return nil, err
+		}
+		return NewBeaconBlock(beaconBlock), nil
+	}
+	shardID := byte(chainID)
+	hash, err := rawdbv2.GetFinalizedShardBlockHashByIndex(db, shardID, height)
+	if err != nil {
+		return nil, err
+	}
+	data, err := rawdbv2.GetShardBlockByHash(db, *hash)
+	if err != nil {
+		if pruned, _ := rawdbv2.HasPrunedShardBlock(db, *hash); pruned {
+			return nil, fmt.Errorf("block %v of height %v is pruned, a pruned chain can not be exported", hash.String(), height)
+		}
+		return nil, err
+	}
+	shardBlock := blockchain.NewShardBlock()
+	if err := json.Unmarshal(data, shardBlock); err != nil {
+		return nil, err
+	}
+	return NewShardBlock(shardBlock)
+}
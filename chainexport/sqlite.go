@@ -0,0 +1,21 @@
+package chainexport
+
+import (
+	"database/sql"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const SQLiteDriver = "sqlite"
+
+// NewSQLiteStore opens or creates the SQLite database file at path. The amounts are integers, SQLite stores the ones
+// over the int64 range as reals
+func NewSQLiteStore(path string) (Store, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?_journal_mode=WAL&_synchronous=NORMAL&_busy_timeout=5000")
+	if err != nil {
+		return nil, err
+	}
+	// one writer, the analysts read the file from other processes
+	db.SetMaxOpenConns(1)
+	return newSQLStore(db, dialect{amountType: "INTEGER"})
+}
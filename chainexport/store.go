@@ -0,0 +1,314 @@
+package chainexport
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// Store - the database the chains are exported to. A block is written with the checkpoint of its chain in one
+// transaction, an export stopped at any time resumes from the checkpoints
+type Store interface {
+	// GetCheckpoint returns the last block exported for the chain, nil if none is
+	GetCheckpoint(chainID int) (*Checkpoint, error)
+	// GetBlockHash returns the hash of the block exported at height, false if none is
+	GetBlockHash(chainID int, height uint64) (common.Hash, bool, error)
+	// WriteBlock writes the rows of the block and moves the checkpoint of its chain to it
+	WriteBlock(block *Block) error
+	// Rollback deletes the rows of the blocks of the chain above height and moves its checkpoint back to height
+	Rollback(chainID int, height uint64) error
+	Close() error
+}
+
+type Checkpoint struct {
+	ChainID int
+	Height  uint64
+	Hash    common.Hash
+}
+
+// OpenStore opens the store of a driver, sqlite (dsn is the path of the file) or postgres (dsn is a connection string)
+func OpenStore(driver string, dsn string) (Store, error) {
+	switch driver {
+	case SQLiteDriver:
+		return NewSQLiteStore(dsn)
+	case PostgresDriver:
+		return NewPostgresStore(dsn)
+	}
+	return nil, fmt.Errorf("unknown export driver %v, it is %v or %v", driver, SQLiteDriver, PostgresDriver)
+}
+
+// dialect - what differs between the SQL databases
+type dialect struct {
+	numberedParams bool   // $1, $2... instead of ?
+	amountType     string // column type of the uint64 amounts, they are written as decimal strings
+}
+
+// sqlStore - a Store in a SQL database, one row of blocks for each block of a chain, the transactions and their
+// actions of a shard block, the instructions and the committee changes of a beacon block
+type sqlStore struct {
+	db      *sql.DB
+	dialect dialect
+}
+
+func newSQLStore(db *sql.DB, dialect dialect) (*sqlStore, error) {
+	store := &sqlStore{db: db, dialect: dialect}
+	for _, statement := range store.schema() {
+		if _, err := db.Exec(statement); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("create schema: %v", err)
+		}
+	}
+	return store, nil
+}
+
+func (store *sqlStore) schema() []string {
+	amount := store.dialect.amountType
+	return []string{
+		`CREATE TABLE IF NOT EXISTS blocks (
+			chain_id INTEGER NOT NULL,
+			height BIGINT NOT NULL,
+			hash TEXT NOT NULL,
+			previous_hash TEXT NOT NULL,
+			epoch BIGINT NOT NULL,
+			round INTEGER NOT NULL,
+			block_time BIGINT NOT NULL,
+			producer TEXT NOT NULL,
+			version INTEGER NOT NULL,
+			beacon_height BIGINT NOT NULL,
+			tx_count INTEGER NOT NULL,
+			PRIMARY KEY (chain_id, height))`,
+		`CREATE INDEX IF NOT EXISTS blocks_hash ON blocks (hash)`,
+		`CREATE TABLE IF NOT EXISTS txs (
+			shard_id INTEGER NOT NULL,
+			height BIGINT NOT NULL,
+			tx_index INTEGER NOT NULL,
+			hash TEXT NOT NULL,
+			type TEXT NOT NULL,
+			fee ` + amount + ` NOT NULL,
+			fee_token ` + amount + ` NOT NULL,
+			token_id TEXT NOT NULL,
+			metadata_type INTEGER NOT NULL,
+			privacy BOOLEAN NOT NULL,
+			size BIGINT NOT NULL,
+			lock_time BIGINT NOT NULL,
+			PRIMARY KEY (shard_id, height, tx_index))`,
+		`CREATE INDEX IF NOT EXISTS txs_hash ON txs (hash)`,
+		`CREATE TABLE IF NOT EXISTS actions (
+			shard_id INTEGER NOT NULL,
+			height BIGINT NOT NULL,
+			tx_index INTEGER NOT NULL,
+			tx_hash TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			metadata_type INTEGER NOT NULL,
+			token_id TEXT NOT NULL,
+			amount ` + amount + ` NOT NULL,
+			address TEXT NOT NULL,
+			data TEXT NOT NULL,
+			PRIMARY KEY (shard_id, height, tx_index))`,
+		`CREATE INDEX IF NOT EXISTS actions_kind ON actions (kind, metadata_type)`,
+		`CREATE TABLE IF NOT EXISTS beacon_instructions (
+			height BIGINT NOT NULL,
+			instruction_index INTEGER NOT NULL,
+			action TEXT NOT NULL,
+			data TEXT NOT NULL,
+			PRIMARY KEY (height, instruction_index))`,
+		`CREATE INDEX IF NOT EXISTS beacon_instructions_action ON beacon_instructions (action)`,
+		`CREATE TABLE IF NOT EXISTS committee_changes (
+			height BIGINT NOT NULL,
+			instruction_index INTEGER NOT NULL,
+			change_index INTEGER NOT NULL,
+			change TEXT NOT NULL,
+			chain TEXT NOT NULL,
+			shard_id INTEGER NOT NULL,
+			public_key TEXT NOT NULL,
+			PRIMARY KEY (height, instruction_index, change_index))`,
+		`CREATE INDEX IF NOT EXISTS committee_changes_public_key ON committee_changes (public_key)`,
+		`CREATE TABLE IF NOT EXISTS checkpoints (
+			chain_id INTEGER NOT NULL PRIMARY KEY,
+			height BIGINT NOT NULL,
+			hash TEXT NOT NULL)`,
+	}
+}
+
+// rebind - the query with the parameters of the dialect
+func (store *sqlStore) rebind(query string) string {
+	if !store.dialect.numberedParams {
+		return query
+	}
+	var builder strings.Builder
+	n := 0
+	for _, c := range query {
+		if c == '?' {
+			n++
+			builder.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		builder.WriteRune(c)
+	}
+	return builder.String()
+}
+
+func (store *sqlStore) GetCheckpoint(chainID int) (*Checkpoint, error) {
+	var height int64
+	var hash string
+	err := store.db.QueryRow(store.rebind(`SELECT height, hash FROM checkpoints WHERE chain_id = ?`), chainID).Scan(&height, &hash)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	checkpointHash, err := common.Hash{}.NewHashFromStr(hash)
+	if err != nil {
+		return nil, err
+	}
+	return &Checkpoint{ChainID: chainID, Height: uint64(height), Hash: *checkpointHash}, nil
+}
+
+func (store *sqlStore) GetBlockHash(chainID int, height uint64) (common.Hash, bool, error) {
+	var value string
+	err := store.db.QueryRow(store.rebind(`SELECT hash FROM blocks WHERE chain_id = ? AND height = ?`), chainID, int64(height)).Scan(&value)
+	if err == sql.ErrNoRows {
+		return common.Hash{}, false, nil
+	}
+	if err != nil {
+		return common.Hash{}, false, err
+	}
+	hash, err := common.Hash{}.NewHashFromStr(value)
+	if err != nil {
+		return common.Hash{}, false, err
+	}
+	return *hash, true, nil
+}
+
+func (store *sqlStore) WriteBlock(block *Block) error {
+	dbTx, err := store.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := store.writeBlock(dbTx, block); err != nil {
+		dbTx.Rollback()
+		return err
+	}
+	return dbTx.Commit()
+}
+
+func (store *sqlStore) writeBlock(dbTx *sql.Tx, block *Block) error {
+	height := int64(block.Height)
+	_, err := dbTx.Exec(store.rebind(`INSERT INTO blocks (chain_id, height, hash, previous_hash, epoch, round, block_time, producer, version, beacon_height, tx_count) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		block.ChainID, height, block.Hash.String(), block.PreviousHash.String(), int64(block.Epoch), block.Round, block.Timestamp, block.Producer, block.Version, int64(block.BeaconHeight), len(block.Txs))
+	if err != nil {
+		return fmt.Errorf("insert block: %v", err)
+	}
+	err = store.insertRows(dbTx, `INSERT INTO txs (shard_id, height, tx_index, hash, type, fee, fee_token, token_id, metadata_type, privacy, size, lock_time) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, len(block.Txs), func(i int) []interface{} {
+		tx := &block.Txs[i]
+		return []interface{}{block.ChainID, height, tx.Index, tx.Hash.String(), tx.Type, formatAmount(tx.Fee), formatAmount(tx.FeeToken), tx.TokenID, tx.MetadataType, tx.Privacy, int64(tx.Size), tx.LockTime}
+	})
+	if err != nil {
+		return fmt.Errorf("insert txs: %v", err)
+	}
+	err = store.insertRows(dbTx, `INSERT INTO actions (shard_id, height, tx_index, tx_hash, kind, metadata_type, token_id, amount, address, data) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, len(block.Actions), func(i int) []interface{} {
+		action := &block.Actions[i]
+		return []interface{}{block.ChainID, height, action.TxIndex, action.TxHash.String(), action.Kind, action.MetadataType, action.TokenID, formatAmount(action.Amount), action.Address, action.Data}
+	})
+	if err != nil {
+		return fmt.Errorf("insert actions: %v", err)
+	}
+	err = store.insertRows(dbTx, `INSERT INTO beacon_instructions (height, instruction_index, action, data) VALUES (?, ?, ?, ?)`, len(block.Instructions), func(i int) []interface{} {
+		instruction := &block.Instructions[i]
+		return []interface{}{height, instruction.Index, instruction.Action, instruction.Data}
+	})
+	if err != nil {
+		return fmt.Errorf("insert beacon instructions: %v", err)
+	}
+	err = store.insertRows(dbTx, `INSERT INTO committee_changes (height, instruction_index, change_index, change, chain, shard_id, public_key) VALUES (?, ?, ?, ?, ?, ?, ?)`, len(block.CommitteeChanges), func(i int) []interface{} {
+		change := &block.CommitteeChanges[i]
+		return []interface{}{height, change.InstructionIndex, i, change.Change, change.Chain, change.ShardID, change.PublicKey}
+	})
+	if err != nil {
+		return fmt.Errorf("insert committee changes: %v", err)
+	}
+	return store.setCheckpoint(dbTx, block.ChainID, block.Height, block.Hash)
+}
+
+// insertRows - execute the insert statement for the count rows of a table
+func (store *sqlStore) insertRows(dbTx *sql.Tx, query string, count int, row func(i int) []interface{}) error {
+	if count == 0 {
+		return nil
+	}
+	statement, err := dbTx.Prepare(store.rebind(query))
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+	for i := 0; i < count; i++ {
+		if _, err := statement.Exec(row(i)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (store *sqlStore) setCheckpoint(dbTx *sql.Tx, chainID int, height uint64, hash common.Hash) error {
+	_, err := dbTx.Exec(store.rebind(`INSERT INTO checkpoints (chain_id, height, hash) VALUES (?, ?, ?) ON CONFLICT (chain_id) DO UPDATE SET height = excluded.height, hash = excluded.hash`),
+		chainID, int64(height), hash.String())
+	if err != nil {
+		return fmt.Errorf("set checkpoint: %v", err)
+	}
+	return nil
+}
+
+func (store *sqlStore) Rollback(chainID int, height uint64) error {
+	hash, ok, err := store.GetBlockHash(chainID, height)
+	if err != nil {
+		return err
+	}
+	if !ok && height > 0 {
+		return fmt.Errorf("no block of chain %v exported at height %v", chainID, height)
+	}
+	dbTx, err := store.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := store.rollback(dbTx, chainID, height, hash); err != nil {
+		dbTx.Rollback()
+		return err
+	}
+	return dbTx.Commit()
+}
+
+func (store *sqlStore) rollback(dbTx *sql.Tx, chainID int, height uint64, hash common.Hash) error {
+	if _, err := dbTx.Exec(store.rebind(`DELETE FROM blocks WHERE chain_id = ? AND height > ?`), chainID, int64(height)); err != nil {
+		return err
+	}
+	if chainID == common.BeaconChainDataBaseID {
+		for _, table := range []string{"beacon_instructions", "committee_changes"} {
+			if _, err := dbTx.Exec(store.rebind(`DELETE FROM `+table+` WHERE height > ?`), int64(height)); err != nil {
+				return err
+			}
+		}
+	} else {
+		for _, table := range []string{"txs", "actions"} {
+			if _, err := dbTx.Exec(store.rebind(`DELETE FROM `+table+` WHERE shard_id = ? AND height > ?`), chainID, int64(height)); err != nil {
+				return err
+			}
+		}
+	}
+	if height == 0 {
+		_, err := dbTx.Exec(store.rebind(`DELETE FROM checkpoints WHERE chain_id = ?`), chainID)
+		return err
+	}
+	return store.setCheckpoint(dbTx, chainID, height, hash)
+}
+
+func (store *sqlStore) Close() error {
+	return store.db.Close()
+}
+
+// formatAmount - an uint64 amount as a decimal string, the SQL drivers only take int64 numbers
+func formatAmount(amount uint64) string {
+	return strconv.FormatUint(amount, 10)
+}
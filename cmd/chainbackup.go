@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// backupChainIDs - the chain IDs of the beacon and of the shards, the beacon first
+func backupChainIDs(beacon bool, shardIDs []byte) []int {
+	chainIDs := []int{}
+	if beacon {
+		chainIDs = append(chainIDs, common.BeaconChainDataBaseID)
+	}
+	for _, shardID := range shardIDs {
+		chainIDs = append(chainIDs, int(shardID))
+	}
+	return chainIDs
+}
+
+// backupEpoch - epoch, or the latest increment of the backup folder for 0
+func backupEpoch(backupFolder string, epoch uint64) (uint64, error) {
+	if epoch > 0 {
+		return epoch, nil
+	}
+	latestEpoch, err := incdb.LatestBackupEpoch(backupFolder)
+	if err != nil {
+		return 0, err
+	}
+	if latestEpoch == 0 {
+		return 0, fmt.Errorf("no backup in %v", backupFolder)
+	}
+	return latestEpoch, nil
+}
+
+// verifyBackups - check the backups of the chains can be restored, without restoring them. backupDir holds the backup
+// folder of each chain, like the backup directory of a node
+func verifyBackups(backupDir string, beacon bool, shardIDs []byte, epoch uint64) error {
+	failed := 0
+	for _, chainID := range backupChainIDs(beacon, shardIDs) {
+		backupFolder := filepath.Join(backupDir, chainDirectory(chainID))
+		chainEpoch, err := backupEpoch(backupFolder, epoch)
+		if err != nil {
+			return err
+		}
+		manifest, err := incdb.VerifyBackup(backupFolder, chainEpoch)
+		if err != nil {
+			log.Printf("%v: backup of epoch %v is broken, err %v", chainName(chainID), chainEpoch, err)
+			failed++
+			continue
+		}
+		printBackupManifest(backupFolder, manifest)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%v backups are broken", failed)
+	}
+	return nil
+}
+
+// restoreBackups - restore the chain databases in chainDataDir from their backup, the databases in place are moved
+// aside. The node must be stopped
+func restoreBackups(backupDir string, chainDataDir string, beacon bool, shardIDs []byte, epoch uint64) error {
+	suffix := ".replaced-" + time.Now().Format("20060102150405")
+	for _, chainID := range backupChainIDs(beacon, shardIDs) {
+		backupFolder := filepath.Join(backupDir, chainDirectory(chainID))
+		chainEpoch, err := backupEpoch(backupFolder, epoch)
+		if err != nil {
+			return err
+		}
+		dbPath := filepath.Join(chainDataDir, chainDirectory(chainID))
+		if err := os.RemoveAll(dbPath + ".restore"); err != nil {
+			return err
+		}
+		manifest, err := incdb.RestoreBackup(backupFolder, chainEpoch, dbPath+".restore")
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(dbPath); err == nil {
+			if err := os.Rename(dbPath, dbPath+suffix); err != nil {
+				return err
+			}
+			log.Printf("%v: the database in place is moved to %v", chainName(chainID), dbPath+suffix)
+		}
+		if err := os.Rename(dbPath+".restore", dbPath); err != nil {
+			return err
+		}
+		log.Printf("%v: restored epoch %v, height %v %v", chainName(chainID), manifest.Epoch, manifest.Height, manifest.BlockHash.String())
+	}
+	return nil
+}
+
+func printBackupManifest(backupFolder string, manifest *incdb.BackupManifest) {
+	chain, err := incdb.BackupChain(backupFolder, manifest.Epoch)
+	if err != nil {
+		return
+	}
+	var size, storedSize int64
+	for _, file := range manifest.Files {
+		size += file.Size
+	}
+	for _, file := range manifest.StoredFiles() {
+		storedSize += file.Size
+	}
+	log.Printf("%v: backup of epoch %v is good, height %v %v, %v increments from the full backup of epoch %v",
+		chainName(manifest.ChainID), manifest.Epoch, manifest.Height, manifest.BlockHash.String(), len(chain), chain[0].Epoch)
+	log.Printf("%v: %v files of %v bytes, the increment stores %v files of %v bytes",
+		chainName(manifest.ChainID), len(manifest.Files), size, len(manifest.StoredFiles()), storedSize)
+	for name, root := range manifest.Roots {
+		log.Printf("%v: %v %v", chainName(manifest.ChainID), name, root.String())
+	}
+}
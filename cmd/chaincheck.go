@@ -0,0 +1,343 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/devnet"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// checkChainOptions - what checkChains does besides checking the databases
+type checkChainOptions struct {
+	blockchain.ChainCheckOptions
+	Replay   bool               // recompute the state roots of the checked heights in a scratch database
+	Truncate bool               // fix the databases, rebuilding from the replay the chains damaged below their final view
+	Params   *blockchain.Params // network of the databases, needed by the replay
+}
+
+// checkChains - check the database of the beacon chain and of the shards, print the problems and the repair plan of
+// each chain, and truncate the chains to their last good height when asked
+func checkChains(chainDataDir string, beacon bool, shardIDs []byte, options checkChainOptions) error {
+	if _, err := os.Stat(chainDataDir); err != nil {
+		return err
+	}
+	db, err := incdb.OpenMultipleDB("leveldb", chainDataDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, chainDB := range db {
+			chainDB.Close()
+		}
+	}()
+	reports := []*blockchain.ChainReport{}
+	if beacon {
+		report, err := blockchain.CheckBeaconDatabase(db[common.BeaconChainDataBaseID], options.ChainCheckOptions)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+	for _, shardID := range shardIDs {
+		report, err := blockchain.CheckShardDatabase(db[int(shardID)], shardID, options.ChainCheckOptions)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+	var scratchDir string
+	var replayedHeights map[int]uint64
+	if options.Replay {
+		scratchDir, err = ioutil.TempDir("", "incognito-checkchain")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(scratchDir)
+		replayedHeights, err = replayChains(db, scratchDir, reports, options)
+		if err != nil {
+			return err
+		}
+	}
+	for _, report := range reports {
+		printChainReport(report)
+	}
+	if !options.Truncate {
+		return nil
+	}
+	rebuilt := []*blockchain.ChainReport{}
+	for _, report := range reports {
+		if report.NeedRebuild() {
+			if !options.Replay {
+				return fmt.Errorf("%v is damaged below its final view, it can only be rebuilt with --replay", chainName(report.ChainID))
+			}
+			rebuilt = append(rebuilt, report)
+		}
+	}
+	for _, report := range reports {
+		if len(report.Problems) == 0 || report.NeedRebuild() {
+			continue
+		}
+		if report.ChainID == common.BeaconChainDataBaseID {
+			err = blockchain.TruncateBeaconDatabase(db[report.ChainID], report)
+		} else {
+			err = blockchain.TruncateShardDatabase(db[report.ChainID], byte(report.ChainID), report)
+		}
+		if err != nil {
+			return err
+		}
+		log.Printf("%v is truncated", chainName(report.ChainID))
+	}
+	if len(rebuilt) == 0 {
+		return nil
+	}
+	// the databases of the chains are replaced by the ones of the replay, the damaged ones are kept aside
+	for _, chainDB := range db {
+		chainDB.Close()
+	}
+	db = nil
+	suffix := ".damaged-" + time.Now().Format("20060102150405")
+	for _, report := range rebuilt {
+		dir := chainDirectory(report.ChainID)
+		if err := os.Rename(filepath.Join(chainDataDir, dir), filepath.Join(chainDataDir, dir+suffix)); err != nil {
+			return err
+		}
+		if err := os.Rename(filepath.Join(scratchDir, dir), filepath.Join(chainDataDir, dir)); err != nil {
+			return err
+		}
+		log.Printf("%v is rebuilt up to height %v, the damaged database is moved to %v", chainName(report.ChainID), replayedHeights[report.ChainID], dir+suffix)
+	}
+	return nil
+}
+
+// replayChains - insert the good finalized blocks of the chains of reports in a scratch blockchain, from the genesis
+// block, and compare their state roots up to the first height with other roots. The states of the replay are the
+// right ones, it returns the height replayed of each chain
+func replayChains(db map[int]incdb.Database, scratchDir string, reports []*blockchain.ChainReport, options checkChainOptions) (map[int]uint64, error) {
+	if options.Params == nil {
+		return nil, fmt.Errorf("the network of the database is needed by the replay")
+	}
+	// the genesis blocks of the database, a devnet has its own
+	params := *options.Params
+	beaconDB := db[common.BeaconChainDataBaseID]
+	genesisBeaconBlock, err := getFinalizedBeaconBlock(beaconDB, 1)
+	if err != nil {
+		return nil, err
+	}
+	params.GenesisBeaconBlock = genesisBeaconBlock
+	genesisShardBlock, err := getFinalizedShardBlock(db[0], 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	params.GenesisShardBlock = genesisShardBlock
+	scratchDB, err := incdb.OpenMultipleDB("leveldb", scratchDir)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, chainDB := range scratchDB {
+			chainDB.Close()
+		}
+	}()
+	bc, err := newBlockChain(scratchDB, &params)
+	if err != nil {
+		return nil, err
+	}
+	// the beacon blocks inserted for a shard are not compared
+	replayedHeights := make(map[int]uint64)
+	beaconHeight := uint64(1)
+	insertBeaconBlocks := func(toHeight uint64, report *blockchain.ChainReport) error {
+		compare := report != nil
+		for beaconHeight < toHeight {
+			block, err := getFinalizedBeaconBlock(beaconDB, beaconHeight+1)
+			if err != nil {
+				return err
+			}
+			if err := bc.InsertBeaconBlock(block, false); err != nil {
+				return err
+			}
+			beaconHeight++
+			if !compare || beaconHeight < options.FromHeight {
+				continue
+			}
+			compare = compareRoots(report, beaconHeight, *block.Hash(), func(blockDB incdb.Database, hash common.Hash) ([]byte, error) {
+				return rawdbv2.GetBeaconRootsHash(blockDB, hash)
+			}, beaconDB, scratchDB[common.BeaconChainDataBaseID])
+		}
+		return nil
+	}
+	for _, report := range reports {
+		toHeight := report.LastGoodHeight
+		if options.ToHeight > 0 && options.ToHeight < toHeight {
+			toHeight = options.ToHeight
+		}
+		if report.ChainID == common.BeaconChainDataBaseID {
+			if err := insertBeaconBlocks(toHeight, report); err != nil {
+				return nil, err
+			}
+			replayedHeights[report.ChainID] = beaconHeight
+			log.Printf("%v is replayed up to height %v", chainName(report.ChainID), beaconHeight)
+			continue
+		}
+		shardID := byte(report.ChainID)
+		shardDB := db[report.ChainID]
+		compare := true
+		replayedHeight := uint64(1)
+		for height := uint64(2); height <= toHeight; height++ {
+			block, err := getFinalizedShardBlock(shardDB, shardID, height)
+			if err != nil {
+				return nil, err
+			}
+			if err := insertBeaconBlocks(block.Header.BeaconHeight, nil); err != nil {
+				return nil, err
+			}
+			if err := bc.InsertShardBlock(block, false); err != nil {
+				return nil, err
+			}
+			replayedHeight = height
+			if !compare || height < options.FromHeight {
+				continue
+			}
+			compare = compareRoots(report, height, *block.Hash(), func(blockDB incdb.Database, hash common.Hash) ([]byte, error) {
+				return rawdbv2.GetShardRootsHash(blockDB, shardID, hash)
+			}, shardDB, scratchDB[report.ChainID])
+		}
+		replayedHeights[report.ChainID] = replayedHeight
+		log.Printf("%v is replayed up to height %v", chainName(report.ChainID), replayedHeight)
+	}
+	return replayedHeights, nil
+}
+
+// compareRoots - whether the state roots of a block are the same in the database and in the replay. The committees
+// of the consensus state hold the local time the node stored them at, the replay can not give the same consensus root
+func compareRoots(report *blockchain.ChainReport, height uint64, hash common.Hash, getRoots func(incdb.Database, common.Hash) ([]byte, error), db incdb.Database, replayDB incdb.Database) bool {
+	stored, err := decodeRoots(getRoots(db, hash))
+	if err != nil {
+		report.AddProblem(blockchain.ChainReplayProblem, height, hash, "no state roots, error %v", err)
+		return false
+	}
+	replayed, err := decodeRoots(getRoots(replayDB, hash))
+	if err != nil {
+		report.AddProblem(blockchain.ChainReplayProblem, height, hash, "the replay has no state roots, error %v", err)
+		return false
+	}
+	for name, root := range stored {
+		if name == "ConsensusStateDBRootHash" {
+			continue
+		}
+		if replayedRoot := replayed[name]; !replayedRoot.IsEqual(&root) {
+			report.AddProblem(blockchain.ChainReplayProblem, height, hash, "%v %v, the replay gives %v", name, root.String(), replayedRoot.String())
+			return false
+		}
+	}
+	return true
+}
+
+func decodeRoots(data []byte, err error) (map[string]common.Hash, error) {
+	if err != nil {
+		return nil, err
+	}
+	roots := make(map[string]common.Hash)
+	if err := json.Unmarshal(data, &roots); err != nil {
+		return nil, err
+	}
+	return roots, nil
+}
+
+func getFinalizedBeaconBlock(db incdb.Database, height uint64) (*blockchain.BeaconBlock, error) {
+	hash, err := rawdbv2.GetFinalizedBeaconBlockHashByIndex(db, height)
+	if err != nil {
+		return nil, err
+	}
+	data, err := rawdbv2.GetBeaconBlockByHash(db, *hash)
+	if err != nil {
+		return nil, err
+	}
+	block := blockchain.NewBeaconBlock()
+	if err := json.Unmarshal(data, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func getFinalizedShardBlock(db incdb.Database, shardID byte, height uint64) (*blockchain.ShardBlock, error) {
+	hash, err := rawdbv2.GetFinalizedShardBlockHashByIndex(db, shardID, height)
+	if err != nil {
+		return nil, err
+	}
+	data, err := rawdbv2.GetShardBlockByHash(db, *hash)
+	if err != nil {
+		if pruned, _ := rawdbv2.HasPrunedShardBlock(db, *hash); pruned {
+			return nil, fmt.Errorf("block %v of height %v is pruned, a pruned chain can not be replayed", hash.String(), height)
+		}
+		return nil, err
+	}
+	block := blockchain.NewShardBlock()
+	if err := json.Unmarshal(data, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// getNetworkParams - params of the network of the database, a devnet is built from its number of shards and the size
+// of its committees, its genesis blocks are read from the database
+func getNetworkParams(testNet bool, devnetShards int, devnetCommitteeSize int) (*blockchain.Params, error) {
+	if devnetShards > 0 {
+		devnetObj, err := devnet.New(devnet.Config{
+			Shards:        devnetShards,
+			CommitteeSize: devnetCommitteeSize,
+			BlockTime:     devnet.DefaultBlockTime,
+			Seed:          devnet.DefaultSeed,
+		})
+		if err != nil {
+			return nil, err
+		}
+		params := &blockchain.Params{}
+		// without accounts, the genesis coins are not checked against a state
+		if err := devnetObj.SetupParams(params, nil); err != nil {
+			return nil, err
+		}
+		return params, nil
+	}
+	if testNet {
+		return &blockchain.ChainTestParam, nil
+	}
+	return &blockchain.ChainMainParam, nil
+}
+
+func printChainReport(report *blockchain.ChainReport) {
+	log.Printf("%v: final view %v, finalized block index up to %v, good up to %v", chainName(report.ChainID), report.FinalHeight, report.IndexedHeight, report.LastGoodHeight)
+	if len(report.Problems) == 0 {
+		log.Printf("%v: no problem", chainName(report.ChainID))
+		return
+	}
+	for _, problem := range report.Problems {
+		log.Printf("%v: [%v] height %v %v: %v", chainName(report.ChainID), problem.Kind, problem.Height, problem.Hash.String(), problem.Message)
+	}
+	for i, step := range report.RepairPlan() {
+		log.Printf("%v: repair %v. %v", chainName(report.ChainID), i+1, step)
+	}
+}
+
+func chainName(chainID int) string {
+	if chainID == common.BeaconChainDataBaseID {
+		return "beacon"
+	}
+	return "shard " + strconv.Itoa(chainID)
+}
+
+func chainDirectory(chainID int) string {
+	if chainID == common.BeaconChainDataBaseID {
+		return common.BeaconChainDatabaseDirectory
+	}
+	return common.ShardChainDatabaseDirectory + strconv.Itoa(chainID)
+}
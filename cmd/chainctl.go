@@ -22,23 +22,27 @@ import (
 )
 
 func makeBlockChain(databaseDir string, testNet bool) (*blockchain.BlockChain, error) {
-	blockchain.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
-	blockchain.BLogger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
-	mempool.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
-	dataaccessobject.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
-	trie.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
-	db, err := incdb.Open("leveldb", filepath.Join(databaseDir))
+	db, err := incdb.OpenMultipleDB("leveldb", filepath.Join(databaseDir))
 	if err != nil {
 		return nil, err
 	}
 	log.Printf("Open leveldb at %+v successfully", filepath.Join(databaseDir))
-	bc := blockchain.NewBlockChain(&blockchain.Config{}, false)
 	var bcParams *blockchain.Params
 	if testNet {
 		bcParams = &blockchain.ChainTestParam
 	} else {
 		bcParams = &blockchain.ChainMainParam
 	}
+	return newBlockChain(db, bcParams)
+}
+
+func newBlockChain(db map[int]incdb.Database, bcParams *blockchain.Params) (*blockchain.BlockChain, error) {
+	blockchain.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	blockchain.BLogger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	mempool.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	dataaccessobject.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	trie.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	bc := &blockchain.BlockChain{}
 	pb := pubsub.NewPubSubManager()
 	txPool := &mempool.TxPool{}
 	txPool.Init(&mempool.Config{
@@ -47,7 +51,7 @@ func makeBlockChain(databaseDir string, testNet bool) (*blockchain.BlockChain, e
 		BlockChain:    bc,
 		ChainParams:   bcParams,
 	})
-	err = bc.Init(&blockchain.Config{
+	err := bc.Init(&blockchain.Config{
 		ChainParams:     bcParams,
 		DataBase:        db,
 		PubSubManager:   pb,
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/incognitochain/incognito-chain/chainexport"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/rpcclient"
+)
+
+// exportChainOptions - where exportChains reads the blocks and writes the rows
+type exportChainOptions struct {
+	Driver       string // chainexport.SQLiteDriver or chainexport.PostgresDriver
+	DSN          string
+	ChainDataDir string // databases of a stopped node, when no node is followed
+	RPCURL       string // RPC and websocket of the node to follow
+	WsURL        string
+}
+
+// exportChains - export the finalized blocks of the chains from their checkpoints, from the databases of a stopped
+// node, or follow a running node until interrupted
+func exportChains(chainIDs []int, options exportChainOptions) error {
+	chainexport.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	store, err := chainexport.OpenStore(options.Driver, options.DSN)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	// stop at the next block when interrupted, the checkpoints are at the last block written
+	interrupt := make(chan os.Signal, 1)
+	stop := make(chan struct{})
+	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+	go func() {
+		<-interrupt
+		log.Println("Interrupted during export, stopping at next block")
+		close(stop)
+	}()
+
+	if options.WsURL != "" {
+		if options.RPCURL == "" {
+			return fmt.Errorf("following a node needs its RPC with --rpcurl")
+		}
+		wsClient, err := rpcclient.NewWsClient(options.WsURL)
+		if err != nil {
+			return err
+		}
+		defer wsClient.Close()
+		source := chainexport.NewRPCSource(rpcclient.NewClient(options.RPCURL))
+		log.Printf("Follow the finalized blocks of %v", options.WsURL)
+		return chainexport.Follow(chainexport.NewExporter(source, store), source, wsClient, chainIDs, stop)
+	}
+
+	if _, err := os.Stat(options.ChainDataDir); err != nil {
+		return err
+	}
+	db, err := incdb.OpenMultipleDB("leveldb", options.ChainDataDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, chainDB := range db {
+			chainDB.Close()
+		}
+	}()
+	exporter := chainexport.NewExporter(chainexport.NewDatabaseSource(db), store)
+	for _, chainID := range chainIDs {
+		exported, err := exporter.Sync(chainID, stop)
+		if err != nil {
+			return fmt.Errorf("%v: %v", chainName(chainID), err)
+		}
+		checkpoint, err := store.GetCheckpoint(chainID)
+		if err != nil {
+			return err
+		}
+		height := uint64(0)
+		if checkpoint != nil {
+			height = checkpoint.Height
+		}
+		log.Printf("%v: %v blocks exported, exported up to height %v", chainName(chainID), exported, height)
+	}
+	return nil
+}
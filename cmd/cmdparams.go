@@ -7,7 +7,9 @@ import (
 
 	"github.com/0xsirrush/color"
 	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/chainexport"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/devnet"
 	"github.com/jessevdk/go-flags"
 )
 
@@ -39,6 +41,21 @@ type params struct {
 	ChainDataDir string `long:"chaindatadir" description:"Directory of Stored Blockchain Database"`
 	OutDataDir   string `long:"outdatadir" description:"Directory of Export Blockchain Data"`
 	FileName     string `long:"filename" description:"Filename of Backup Blockchin Data"`
+	// check chain
+	FromHeight          uint64 `long:"fromheight" description:"First finalized height to check"`
+	ToHeight            uint64 `long:"toheight" description:"Last finalized height to check, default is the end of the chain"`
+	Replay              bool   `long:"replay" description:"Recompute the state roots by replaying the finalized blocks in a scratch database"`
+	Truncate            bool   `long:"truncate" description:"Truncate the chains to their last good height, chains damaged below their final view are rebuilt with --replay"`
+	DevnetShards        int    `long:"devnetshards" description:"Number of shards of the devnet of the database, for a devnet database"`
+	DevnetCommitteeSize int    `long:"devnetcommitteesize" description:"Number of validators of each devnet committee"`
+	// backup increments
+	BackupDir string `long:"backupdir" description:"Directory of the backup increments of the chains, like the backup directory of a node"`
+	Epoch     uint64 `long:"epoch" description:"Epoch of the backup increment, default is the latest"`
+	// export
+	ExportDriver string `long:"exportdriver" description:"Database the chains are exported to: sqlite or postgres"`
+	ExportDSN    string `long:"exportdsn" description:"Path of the SQLite database file, or connection string of the Postgres database"`
+	RPCURL       string `long:"rpcurl" description:"RPC of the running node to follow, like http://127.0.0.1:9334"`
+	WsURL        string `long:"wsurl" description:"Websocket of the running node to follow, like ws://127.0.0.1:19334"`
 	// wallet
 	WalletName        string `long:"wallet" description:"Wallet Database Name file, default is 'wallet'"`
 	WalletPassphrase  string `long:"walletpassphrase" description:"Wallet passphrase"`
@@ -58,8 +75,10 @@ func newConfigParser(cfg *params, options flags.Options) *flags.Parser {
 
 func loadParams() (*params, error) {
 	cfg := params{
-		DataDir: defaultDataDir,
-		TestNet: false,
+		DataDir:             defaultDataDir,
+		TestNet:             false,
+		DevnetCommitteeSize: devnet.DefaultCommitteeSize,
+		ExportDriver:        chainexport.SQLiteDriver,
 	}
 
 	preParser := newConfigParser(&cfg, flags.HelpFlag)
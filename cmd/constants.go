@@ -8,6 +8,10 @@ const (
 	getPrivacyTokenID      = "getprivacytokenid"
 	backupChain            = "backupchain"
 	restoreChain           = "restorechain"
+	checkChain             = "checkchain"
+	verifyBackup           = "verifybackup"
+	restoreBackup          = "restorebackup"
+	exportChain            = "exportchain"
 )
 
 var CmdList = []string{
@@ -18,4 +22,8 @@ var CmdList = []string{
 	getPrivacyTokenID,
 	backupChain,
 	restoreChain,
+	checkChain,
+	verifyBackup,
+	restoreBackup,
+	exportChain,
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"log"
 	"strconv"
@@ -20,6 +21,38 @@ func parseToJsonString(data interface{}) ([]byte, error) {
 	return result, nil
 }
 
+// parseShardIDs - shard IDs of "all" or of a list like "0,1,2"
+func parseShardIDs(value string, numberOfShards int) ([]byte, error) {
+	shardIDs := []byte{}
+	// all shard
+	if value == "all" {
+		for i := 0; i < numberOfShards; i++ {
+			shardIDs = append(shardIDs, byte(i))
+		}
+		return shardIDs, nil
+	}
+	// some particular shard
+	strs := strings.Split(value, ",")
+	if len(strs) > 256 {
+		return nil, errors.New("Number of shard id to process exceed limit")
+	}
+	for _, value := range strs {
+		temp, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.New("ShardID Params MUST contain number only in range 0-255")
+		}
+		if temp > 256 {
+			return nil, errors.New("ShardID exceed MAX value (> 255)")
+		}
+		shardID := byte(temp)
+		if common.IndexOfByte(shardID, shardIDs) > 0 {
+			continue
+		}
+		shardIDs = append(shardIDs, shardID)
+	}
+	return shardIDs, nil
+}
+
 func processCmd() {
 	switch cfg.Command {
 	case getPrivacyTokenID:
@@ -141,42 +174,17 @@ func processCmd() {
 					log.Printf("Beacon Beackup failed, err %+v", err)
 				}
 			}
-			var shardIDs = []byte{}
 			if cfg.ShardIDs != "" {
-				// all shard
-				if cfg.ShardIDs == "all" {
-					var numberOfShards int
-					if cfg.TestNet {
-						numberOfShards = blockchain.ChainTestParam.ActiveShards
-					} else {
-						numberOfShards = blockchain.ChainMainParam.ActiveShards
-					}
-					for i := 0; i < numberOfShards; i++ {
-						shardIDs = append(shardIDs, byte(i))
-					}
+				var numberOfShards int
+				if cfg.TestNet {
+					numberOfShards = blockchain.ChainTestParam.ActiveShards
 				} else {
-					// some particular shard
-					strs := strings.Split(cfg.ShardIDs, ",")
-					if len(strs) > 256 {
-						log.Println("Number of shard id to process exceed limit")
-						return
-					}
-					for _, value := range strs {
-						temp, err := strconv.Atoi(value)
-						if err != nil {
-							log.Println("ShardID Params MUST contain number only in range 0-255")
-							return
-						}
-						if temp > 256 {
-							log.Println("ShardID exceed MAX value (> 255)")
-							return
-						}
-						shardID := byte(temp)
-						if common.IndexOfByte(shardID, shardIDs) > 0 {
-							continue
-						}
-						shardIDs = append(shardIDs, shardID)
-					}
+					numberOfShards = blockchain.ChainMainParam.ActiveShards
+				}
+				shardIDs, err := parseShardIDs(cfg.ShardIDs, numberOfShards)
+				if err != nil {
+					log.Println(err)
+					return
 				}
 				//backup shard
 				for _, shardID := range shardIDs {
@@ -213,5 +221,109 @@ func processCmd() {
 				}
 			}
 		}
+	case checkChain:
+		{
+			if cfg.Beacon == false && cfg.ShardIDs == "" {
+				log.Println("No Expected Params")
+				return
+			}
+			bcParams, err := getNetworkParams(cfg.TestNet, cfg.DevnetShards, cfg.DevnetCommitteeSize)
+			if err != nil {
+				log.Println("Wrong param", err)
+				return
+			}
+			shardIDs := []byte{}
+			if cfg.ShardIDs != "" {
+				shardIDs, err = parseShardIDs(cfg.ShardIDs, bcParams.ActiveShards)
+				if err != nil {
+					log.Println(err)
+					return
+				}
+			}
+			err = checkChains(cfg.ChainDataDir, cfg.Beacon, shardIDs, checkChainOptions{
+				ChainCheckOptions: blockchain.ChainCheckOptions{
+					FromHeight: cfg.FromHeight,
+					ToHeight:   cfg.ToHeight,
+				},
+				Replay:   cfg.Replay,
+				Truncate: cfg.Truncate,
+				Params:   bcParams,
+			})
+			if err != nil {
+				log.Printf("Check chain failed, err %+v", err)
+			}
+		}
+	case verifyBackup, restoreBackup:
+		{
+			if cfg.Beacon == false && cfg.ShardIDs == "" {
+				log.Println("No Expected Params")
+				return
+			}
+			if cfg.BackupDir == "" {
+				log.Println("No Backup Directory to Process")
+				return
+			}
+			bcParams, err := getNetworkParams(cfg.TestNet, cfg.DevnetShards, cfg.DevnetCommitteeSize)
+			if err != nil {
+				log.Println("Wrong param", err)
+				return
+			}
+			shardIDs := []byte{}
+			if cfg.ShardIDs != "" {
+				shardIDs, err = parseShardIDs(cfg.ShardIDs, bcParams.ActiveShards)
+				if err != nil {
+					log.Println(err)
+					return
+				}
+			}
+			if cfg.Command == verifyBackup {
+				err = verifyBackups(cfg.BackupDir, cfg.Beacon, shardIDs, cfg.Epoch)
+			} else {
+				err = restoreBackups(cfg.BackupDir, cfg.ChainDataDir, cfg.Beacon, shardIDs, cfg.Epoch)
+			}
+			if err != nil {
+				log.Printf("Backup %v failed, err %+v", cfg.Command, err)
+			}
+		}
+	case exportChain:
+		{
+			if cfg.Beacon == false && cfg.ShardIDs == "" {
+				log.Println("No Expected Params")
+				return
+			}
+			if cfg.ExportDSN == "" {
+				log.Println("No Export Database to Process")
+				return
+			}
+			bcParams, err := getNetworkParams(cfg.TestNet, cfg.DevnetShards, cfg.DevnetCommitteeSize)
+			if err != nil {
+				log.Println("Wrong param", err)
+				return
+			}
+			chainIDs := []int{}
+			if cfg.Beacon {
+				chainIDs = append(chainIDs, common.BeaconChainDataBaseID)
+			}
+			if cfg.ShardIDs != "" {
+				shardIDs, err := parseShardIDs(cfg.ShardIDs, bcParams.ActiveShards)
+				if err != nil {
+					log.Println(err)
+					return
+				}
+				for _, shardID := range shardIDs {
+					chainIDs = append(chainIDs, int(shardID))
+				}
+			}
+			err = exportChains(chainIDs, exportChainOptions{
+				Driver:       cfg.ExportDriver,
+				DSN:          cfg.ExportDSN,
+				ChainDataDir: cfg.ChainDataDir,
+				RPCURL:       cfg.RPCURL,
+				WsURL:        cfg.WsURL,
+			})
+			if err != nil {
+				log.Printf("Export chain failed, err %+v", err)
+			}
+		}
 	}
 }
@@ -220,6 +220,21 @@ const (
 	PDECrossPoolTradeAcceptedChainStatus           = "xPoolTradeAccepted"
 )
 
+// Delegation statuses for chain
+const (
+	DelegationAcceptedChainStatus = "accepted"
+	DelegationRejectedChainStatus = "rejected"
+	DelegationUnbondedChainStatus = "unbonded"
+)
+
+// HTLC statuses for chain
+const (
+	HTLCLockedChainStatus   = "locked"
+	HTLCClaimedChainStatus  = "claimed"
+	HTLCRefundedChainStatus = "refunded"
+	HTLCRejectedChainStatus = "rejected"
+)
+
 // Portal status for chain
 const (
 	PortalCustodianDepositAcceptedChainStatus = "accepted"
@@ -2,6 +2,7 @@ package common
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -250,3 +251,20 @@ func TestHashHashArrayInterfaceWithInvalidInterface(t *testing.T) {
 		assert.Equal(t, errors.New("interface input is not an array"), err)
 	}
 }
+
+/*
+	Unit test for MarshalText and UnmarshalText functions
+ */
+
+func TestHashTextMapKey(t *testing.T) {
+	data := map[Hash]uint64{
+		PRVCoinID:     2,
+		{1, 2, 3, 4}: 5,
+	}
+	b, err := json.Marshal(data)
+	assert.Equal(t, nil, err)
+	result := map[Hash]uint64{}
+	err = json.Unmarshal(b, &result)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, data, result)
+}
@@ -36,6 +36,9 @@ type ChainInterface interface {
 
 const TIMESLOT = 10
 
+// TimeSlot is the length of a timeslot in seconds, TIMESLOT unless a devnet shortens it to its block time
+var TimeSlot int64 = TIMESLOT
+
 func CalculateTimeSlot(time int64) int64 {
-	return int64(math.Floor(float64(time / TIMESLOT)))
+	return int64(math.Floor(float64(time / TimeSlot)))
 }
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/rand"
 	"net"
 	"os"
@@ -13,9 +14,12 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/devnet"
+	"github.com/incognitochain/incognito-chain/txrelay"
 	"github.com/jessevdk/go-flags"
 )
 
@@ -49,10 +53,15 @@ const (
 	DefaultLimitFee                    = uint64(1) // 1 nano PRV = 10^-9 PRV
 	//DefaultLimitFee = uint64(100000) // 100000 nano PRV = 100000 * 10^-9 PRV
 	// For wallet
-	DefaultWalletName     = "wallet"
-	DefaultPersistMempool = false
-	DefaultBtcClient      = 0
-	DefaultBtcClientPort  = "8332"
+	DefaultWalletName               = "wallet"
+	DefaultPersistMempool           = false
+	DefaultMempoolRebroadcastBlocks = 20
+	DefaultTracingFile              = "traces.json"
+	DefaultTrieNodeCache            = 256 // MB
+	// For devnet
+	DefaultDevnetKeysFilename = "keys.json"
+	DefaultBtcClient          = 0
+	DefaultBtcClientPort      = "8332"
 )
 
 var (
@@ -121,6 +130,14 @@ type config struct {
 	// Net config
 	TestNet string `long:"testnet" description:"Use the test network"`
 
+	// Devnet
+	Devnet              bool          `long:"devnet" description:"Run a local network in this process, with a beacon and shard committees signing with generated keys (also 'incognito devnet')"`
+	DevnetShards        int           `long:"devnetshards" description:"Number of shards of the devnet"`
+	DevnetCommitteeSize int           `long:"devnetcommitteesize" description:"Number of validators of each devnet committee"`
+	DevnetBlockTime     time.Duration `long:"devnetblocktime" description:"Time between two blocks of a devnet chain, the timeslot of its consensus, whole seconds"`
+	DevnetAccounts      int           `long:"devnetaccounts" description:"Number of accounts funded in the devnet genesis block"`
+	DevnetSeed          string        `long:"devnetseed" description:"Seed of the devnet keys, the same seed gives the same keys"`
+
 	NodeMode    string `long:"nodemode" description:"Role of this node (beacon/shard/wallet/relay | default role is 'relay' (relayshards must be set to run), 'auto' mode will switch between 'beacon' and 'shard')"`
 	RelayShards string `long:"relayshards" description:"set relay shards of this node when in 'relay' mode if noderole is auto then it only sync shard data when user is a shard producer/validator"`
 	// For Wallet
@@ -130,31 +147,51 @@ type config struct {
 	WalletAutoInit   bool   `long:"walletautoinit" description:"Init wallet automatically if not exist"`
 	WalletShardID    int    `long:"walletshardid" description:"ShardID which wallet use to create account"`
 
-	FastStartup bool `long:"faststartup" description:"Load existed shard/chain dependencies instead of rebuild from block data"`
+	FastStartup bool   `long:"faststartup" description:"Load existed shard/chain dependencies instead of rebuild from block data"`
+	Archive     bool   `long:"archive" description:"Keep the state of every block and serve it to the RPCs taking a block height or hash as commitment"`
+	PruneEpochs uint64 `long:"pruneepochs" description:"Delete the body of the finalized shard blocks older than this number of epochs, the headers and the state are kept, default 0 keeps every block"`
+
+	TrieNodeCache int `long:"trienodecache" description:"Size in MB of the cache of the trie nodes read from disk, shared by the state databases of every chain, 0 disables it"`
 
 	TxPoolTTL   uint   `long:"txpoolttl" description:"Set Time To Live (TTL) Value for transaction that enter pool"`
 	TxPoolMaxTx uint64 `long:"txpoolmaxtx" description:"Set Maximum number of transaction in pool"`
 	LimitFee    uint64 `long:"limitfee" description:"Limited fee for tx(per Kb data), default is 0.00 PRV"`
 
-	LoadMempool       bool   `long:"loadmempool" description:"Load transactions from Mempool database"`
-	PersistMempool    bool   `long:"persistmempool" description:"Persistence transaction in memepool database"`
-	MetricUrl         string `long:"metricurl" description:"Metric URL"`
-	BtcClient         uint   `long:"btcclient" description:"Default 0: BlockCypherClient, 1: Self Host Bitcoin Client (Must pass in btcclientip, btcclientport, btcclientusername, btcclientpassword"`
-	BtcClientIP       string `long:"btcclientip" description:"Bitcoin Client IP (Static IP)"`
-	BtcClientPort     string `long:"btcclientport" description:"Bitcoin Client Port (default 8332)"`
-	BtcClientUsername string `long:"btcclientusername" description:"Bitcoin Client Username for RPC"`
-	BtcClientPassword string `long:"btcclientpassword" description:"Bitcoin Client Password for RPC"`
-	EnableMining      bool   `long:"mining" description:"enable mining"`
-	MiningKeys        string `long:"miningkeys" description:"keys used for different consensus algorigthm"`
-	PrivateKey        string `long:"privatekey" description:"your wallet privatekey"`
-	Accelerator       bool   `long:"accelerator" description:"Relay Node Configuration For Consensus"`
+	LoadMempool              bool   `long:"loadmempool" description:"Load transactions from Mempool database"`
+	PersistMempool           bool   `long:"persistmempool" description:"Persistence transaction in memepool database"`
+	MempoolRebroadcastBlocks uint64 `long:"mempoolrebroadcastblocks" description:"Relay again a transaction sent to the rpc of the node which is not in a block after this number of blocks of its shard, 0 to disable"`
+	MetricUrl                string `long:"metricurl" description:"Metric URL"`
+	BtcClient                uint   `long:"btcclient" description:"Default 0: BlockCypherClient, 1: Self Host Bitcoin Client (Must pass in btcclientip, btcclientport, btcclientusername, btcclientpassword"`
+	BtcClientIP              string `long:"btcclientip" description:"Bitcoin Client IP (Static IP)"`
+	BtcClientPort            string `long:"btcclientport" description:"Bitcoin Client Port (default 8332)"`
+	BtcClientUsername        string `long:"btcclientusername" description:"Bitcoin Client Username for RPC"`
+	BtcClientPassword        string `long:"btcclientpassword" description:"Bitcoin Client Password for RPC"`
+	EnableMining             bool   `long:"mining" description:"enable mining"`
+	MiningKeys               string `long:"miningkeys" description:"keys used for different consensus algorigthm"`
+	PrivateKey               string `long:"privatekey" description:"your wallet privatekey"`
+	RemoteSigner             string `long:"remotesigner" description:"Address of a signer daemon holding the mining keys, unix:<path> or tcp:<host:port>"`
+	Accelerator              bool   `long:"accelerator" description:"Relay Node Configuration For Consensus"`
 
 	// Highway
 	Libp2pPrivateKey string `long:"libp2pprivatekey" description:"Private key used to create node's PeerID, empty to generate random key each run"`
 
+	// Private relay of transactions
+	TxRelayMode          string        `long:"txrelaymode" description:"Relay mode of the transactions sent through RPC without a mode: public (broadcast) or private (stem phase to a single peer, then broadcast)"`
+	StemPeers            []string      `long:"stempeer" description:"Add a peer receiving the transactions relayed privately: /ip4/<ip>/tcp/<port>/p2p/<peer id>"`
+	StemFluffProbability float64       `long:"stemfluffprobability" description:"Probability of the node to broadcast the transactions it receives from the stem during an epoch instead of forwarding them"`
+	StemEpoch            time.Duration `long:"stemepoch" description:"Time before the node picks its stem peer and whether it broadcasts the transactions of the stem again"`
+	StemEmbargo          time.Duration `long:"stemembargo" description:"A transaction of the stem not seen broadcast after this time (plus a random time up to as much) is broadcast by the node"`
+	DisableStemFailSafe  bool          `long:"nostemfailsafe" description:"Reject a transaction sent through RPC in private mode when no stem peer can receive it instead of broadcasting it"`
+
 	//backup
 	PreloadAddress string `long:"preloadaddress" description:"Endpoint of fullnode to download backup database"`
 	ForceBackup    bool   `long:"forcebackup" description:"Force node to backup"`
+
+	//tracing
+	TracingExporter    string  `long:"tracingexporter" description:"Span exporter: otlp, file or stdout. Empty to disable tracing"`
+	TracingEndpoint    string  `long:"tracingendpoint" description:"OTLP/HTTP collector endpoint (default http://localhost:4318)"`
+	TracingFile        string  `long:"tracingfile" description:"Output file of file exporter (default <datadir>/traces.json)"`
+	TracingSampleRatio float64 `long:"tracingsampleratio" description:"Fraction of traces to keep, 0 or 1 keep all traces"`
 }
 
 func (cfg config) IsTestnet() bool {
@@ -338,11 +375,22 @@ func loadConfig() (*config, []string, error) {
 		TxPoolTTL:                   DefaultTxPoolTTL,
 		TxPoolMaxTx:                 DefaultTxPoolMaxTx,
 		PersistMempool:              DefaultPersistMempool,
+		MempoolRebroadcastBlocks:    DefaultMempoolRebroadcastBlocks,
+		TrieNodeCache:               DefaultTrieNodeCache,
 		LimitFee:                    DefaultLimitFee,
 		MetricUrl:                   DefaultMetricUrl,
 		BtcClient:                   DefaultBtcClient,
 		BtcClientPort:               DefaultBtcClientPort,
 		EnableMining:                DefaultEnableMining,
+		DevnetShards:                devnet.DefaultShards,
+		DevnetCommitteeSize:         devnet.DefaultCommitteeSize,
+		DevnetBlockTime:             devnet.DefaultBlockTime,
+		DevnetAccounts:              devnet.DefaultAccounts,
+		DevnetSeed:                  devnet.DefaultSeed,
+		TxRelayMode:                 txrelay.DefaultMode,
+		StemFluffProbability:        txrelay.DefaultFluffProbability,
+		StemEpoch:                   txrelay.DefaultEpoch,
+		StemEmbargo:                 txrelay.DefaultEmbargo,
 	}
 
 	// Service options which are only added on Windows.
@@ -445,6 +493,25 @@ func loadConfig() (*config, []string, error) {
 		os.Exit(common.ExitCodeUnknow)
 	}
 
+	// The devnet is a fresh network in a temporary data directory, it does not connect to peers and serves its RPC
+	// without authorization unless users are set
+	if cfg.Devnet {
+		activeNetParams = &devNetParams
+		cfg.DataDir, err = ioutil.TempDir("", "incognito-devnet")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
+		cfg.NodeMode = common.NodeModeRelay
+		cfg.RelayShards = "all"
+		cfg.DisableListen = true
+		cfg.Listener = "127.0.0.1:0"
+		cfg.DiscoverPeers = false
+		if cfg.RPCUser == "" && cfg.RPCLimitUser == "" {
+			cfg.RPCDisableAuth = true
+		}
+	}
+
 	// Append the network type to the data directory so it is "namespaced"
 	// per network.  In addition to the block database, there are other
 	// pieces of data that are saved to disk such as address manager state.
@@ -477,6 +544,23 @@ func loadConfig() (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	if !txrelay.IsValidMode(cfg.TxRelayMode) {
+		str := "%s: the --txrelaymode option must be %s or %s"
+		err := fmt.Errorf(str, funcName, txrelay.ModePublic, txrelay.ModePrivate)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// --archive keeps what --pruneepochs deletes.
+	if cfg.Archive && cfg.PruneEpochs > 0 {
+		str := "%s: the --archive and --pruneepochs options can not be mixed"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
 	// --addPeer and --connect do not mix.
 	if len(cfg.AddPeers) > 0 && len(cfg.ConnectPeers) > 0 {
 		str := "%s: the --addpeer and --connect options can not be mixed"
@@ -641,7 +725,7 @@ func loadConfig() (*config, []string, error) {
 		}
 	}
 
-	if cfg.MiningKeys == "" && cfg.PrivateKey == "" && cfg.NodeMode != common.NodeModeRelay {
+	if cfg.MiningKeys == "" && cfg.PrivateKey == "" && cfg.RemoteSigner == "" && cfg.NodeMode != common.NodeModeRelay {
 		return nil, nil, errors.New("MiningKeys can't be empty if nodemode isn't relay")
 	}
 
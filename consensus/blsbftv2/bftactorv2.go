@@ -11,8 +11,10 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/wire"
 )
 
@@ -118,6 +120,7 @@ func (e *BLSBFT_V2) Start() error {
 				}
 				block := blockIntf.(common.BlockInterface)
 				blkHash := block.Hash().String()
+				tracing.RememberBlock(blkHash, tracing.ContextWithRemoteParent(context.Background(), proposeMsg.TraceParent))
 
 				if _, ok := e.receiveBlockByHash[blkHash]; !ok {
 					e.receiveBlockByHash[blkHash] = &ProposeBlockInfo{
@@ -336,6 +339,9 @@ func (e *BLSBFT_V2) processIfBlockGetEnoughVote(blockHash string, v *ProposeBloc
 	v.hasNewVote = false
 	if validVote > 2*len(view.GetCommittee())/3 {
 		e.Logger.Infof("Commit block %v , height: %v", blockHash, v.block.GetHeight())
+		_, span := tracing.StartSpan(tracing.ContextForBlock(blockHash), "blsbftv2.commit",
+			tracing.String("chain", e.ChainKey), tracing.Uint64("height", v.block.GetHeight()), tracing.Int("votes", validVote))
+		defer span.End()
 		committeeBLSString, err := incognitokey.ExtractPublickeysFromCommitteeKeyList(view.GetCommittee(), common.BlsConsensus)
 		//fmt.Println(committeeBLSString)
 		if err != nil {
@@ -382,7 +388,11 @@ func (e *BLSBFT_V2) validateAndVote(v *ProposeBlockInfo) error {
 	_, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 
+	_, span := tracing.StartSpan(tracing.ContextForBlock(v.block.Hash().String()), "blsbftv2.vote",
+		tracing.String("chain", e.ChainKey), tracing.Uint64("height", v.block.GetHeight()))
+	defer span.End()
 	if err := e.Chain.ValidatePreSignBlock(v.block); err != nil {
+		span.SetError(err)
 		e.Logger.Error(err)
 		return err
 	}
@@ -399,14 +409,19 @@ func (e *BLSBFT_V2) validateAndVote(v *ProposeBlockInfo) error {
 		bytelist = append(bytelist, v.MiningPubKey[common.BlsConsensus])
 	}
 
-	blsSig, err := e.UserKeySet.BLSSignData(v.block.Hash().GetBytes(), selfIdx, bytelist)
+	blockInfo, err := signer.NewBlockInfo(e.ChainID, v.block)
+	if err != nil {
+		e.Logger.Error(err)
+		return NewConsensusError(UnExpectedError, err)
+	}
+	blsSig, err := e.UserKeySet.BLSSignData(blockInfo, selfIdx, bytelist)
 	if err != nil {
 		e.Logger.Error(err)
 		return NewConsensusError(UnExpectedError, err)
 	}
 	bridgeSig := []byte{}
 	if metadata.HasBridgeInstructions(v.block.GetInstructions()) {
-		bridgeSig, err = e.UserKeySet.BriSignData(v.block.Hash().GetBytes())
+		bridgeSig, err = e.UserKeySet.BriSignData(blockInfo, nil)
 		if err != nil {
 			e.Logger.Error(err)
 			return NewConsensusError(UnExpectedError, err)
@@ -419,7 +434,8 @@ func (e *BLSBFT_V2) validateAndVote(v *ProposeBlockInfo) error {
 	userPk := e.UserKeySet.GetPublicKey()
 	Vote.Validator = userPk.GetMiningKeyBase58(common.BlsConsensus)
 	Vote.PrevBlockHash = v.block.GetPrevHash().String()
-	err = Vote.signVote(e.UserKeySet)
+	Vote.TraceParent = span.Context().TraceParent()
+	err = Vote.signVote(e.UserKeySet, blockInfo)
 	if err != nil {
 		e.Logger.Error(err)
 		return NewConsensusError(UnExpectedError, err)
@@ -469,6 +485,9 @@ func (e *BLSBFT_V2) proposeBlock(proposerPk incognitokey.CommitteePublicKey, blo
 		return nil, NewConsensusError(BlockCreationError, errors.New("block is nil"))
 	}
 
+	_, span := tracing.StartSpan(tracing.ContextForBlock(block.Hash().String()), "blsbftv2.propose",
+		tracing.String("chain", e.ChainKey), tracing.Uint64("height", block.GetHeight()), tracing.String("blockHash", block.Hash().String()))
+	defer span.End()
 	validationData := e.CreateValidationData(block)
 	validationDataString, _ := EncodeValidationData(validationData)
 	block.(blockValidation).AddValidationField(validationDataString)
@@ -476,6 +495,7 @@ func (e *BLSBFT_V2) proposeBlock(proposerPk incognitokey.CommitteePublicKey, blo
 	var proposeCtn = new(BFTPropose)
 	proposeCtn.Block = blockData
 	proposeCtn.PeerID = e.Node.GetSelfPeerID().String()
+	proposeCtn.TraceParent = span.Context().TraceParent()
 	msg, _ := MakeBFTProposeMsg(proposeCtn, e.ChainKey, e.currentTimeSlot, block.GetHeight())
 	go e.ProcessBFTMsg(msg.(*wire.MessageBFT))
 	go e.Node.PushMessageToChain(msg, e.Chain)
@@ -518,14 +538,9 @@ func (e *BLSBFT_V2) preValidateVote(blockHash []byte, Vote *BFTVote, candidate [
 	return err
 }
 
-func (s *BFTVote) signVote(key *MiningKey) error {
-	data := []byte{}
-	data = append(data, s.BlockHash...)
-	data = append(data, s.BLS...)
-	data = append(data, s.BRI...)
-	data = common.HashB(data)
+func (s *BFTVote) signVote(key *MiningKey, block *signer.BlockInfo) error {
 	var err error
-	s.Confirmation, err = key.BriSignData(data)
+	s.Confirmation, err = key.BriSignData(block, &signer.VoteInfo{HashString: true, BLS: s.BLS, BRI: s.BRI})
 	return err
 }
 
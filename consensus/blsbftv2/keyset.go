@@ -2,10 +2,12 @@ package blsbftv2
 
 import (
 	"encoding/json"
+	"errors"
 	"sort"
 
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
@@ -16,6 +18,8 @@ import (
 type MiningKey struct {
 	PriKey map[string][]byte
 	PubKey map[string][]byte
+	// Signer signs with the private keys of a signer daemon, PriKey is empty when it is set
+	Signer signer.Signer
 }
 
 func (miningKey *MiningKey) GetPublicKey() incognitokey.CommitteePublicKey {
@@ -36,27 +40,69 @@ func (miningKey *MiningKey) GetPublicKeyBase58() string {
 }
 
 func (miningKey *MiningKey) BLSSignData(
-	data []byte,
+	block *signer.BlockInfo,
 	selfIdx int,
 	committee []blsmultisig.PublicKey,
 ) (
 	[]byte,
 	error,
 ) {
-	sigBytes, err := blsmultisig.Sign(data, miningKey.PriKey[common.BlsConsensus], selfIdx, committee)
+	var sigBytes []byte
+	var err error
+	if miningKey.Signer != nil {
+		committeeBytes := make([][]byte, len(committee))
+		for i, key := range committee {
+			committeeBytes[i] = key
+		}
+		sigBytes, err = miningKey.Signer.BLSSign(block, selfIdx, committeeBytes)
+	} else {
+		sigBytes, err = blsmultisig.Sign(block.Hash.GetBytes(), miningKey.PriKey[common.BlsConsensus], selfIdx, committee)
+	}
 	if err != nil {
 		return nil, NewConsensusError(SignDataError, err)
 	}
 	return sigBytes, nil
 }
 
+// BriSignData signs the hash of block with the bridge key, or the confirmation of a vote on block if vote is not nil
 func (miningKey *MiningKey) BriSignData(
+	block *signer.BlockInfo,
+	vote *signer.VoteInfo,
+) (
+	[]byte,
+	error,
+) {
+	var sig []byte
+	var err error
+	if miningKey.Signer != nil {
+		sig, err = miningKey.Signer.BriSign(block, vote)
+	} else {
+		data := block.Hash.GetBytes()
+		if vote != nil {
+			data = signer.ConfirmationData(block, vote)
+		}
+		sig, err = bridgesig.Sign(miningKey.PriKey[common.BridgeConsensus], data)
+	}
+	if err != nil {
+		return nil, NewConsensusError(SignDataError, err)
+	}
+	return sig, nil
+}
+
+// SignMessage signs data which is not a block with the bridge key
+func (miningKey *MiningKey) SignMessage(
 	data []byte,
 ) (
 	[]byte,
 	error,
 ) {
-	sig, err := bridgesig.Sign(miningKey.PriKey[common.BridgeConsensus], data)
+	var sig []byte
+	var err error
+	if miningKey.Signer != nil {
+		sig, err = miningKey.Signer.SignMessage(data)
+	} else {
+		sig, err = bridgesig.Sign(miningKey.PriKey[common.BridgeConsensus], data)
+	}
 	if err != nil {
 		return nil, NewConsensusError(SignDataError, err)
 	}
@@ -85,6 +131,23 @@ func (e *BLSBFT_V2) LoadUserKey(privateSeed string) error {
 	return nil
 }
 
+// LoadRemoteSigner uses the mining keys of a signer daemon instead of a key loaded in the node
+func (e *BLSBFT_V2) LoadRemoteSigner(remoteSigner signer.Signer) error {
+	pubKey, err := remoteSigner.MiningPublicKeys()
+	if err != nil {
+		return NewConsensusError(LoadKeyError, err)
+	}
+	if len(pubKey[common.BlsConsensus]) == 0 || len(pubKey[common.BridgeConsensus]) == 0 {
+		return NewConsensusError(LoadKeyError, errors.New("signer has no mining key"))
+	}
+	e.UserKeySet = &MiningKey{
+		PriKey: map[string][]byte{},
+		PubKey: pubKey,
+		Signer: remoteSigner,
+	}
+	return nil
+}
+
 func (e *BLSBFT_V2) LoadUserKeyFromIncPrivateKey(privateKey string) (string, error) {
 	wl, err := wallet.Base58CheckDeserialize(privateKey)
 	if err != nil {
@@ -107,7 +170,7 @@ func (e *BLSBFT_V2) GetUserPublicKey() *incognitokey.CommitteePublicKey {
 }
 
 func (e BLSBFT_V2) SignData(data []byte) (string, error) {
-	result, err := e.UserKeySet.BriSignData(data) //, 0, []blsmultisig.PublicKey{e.UserKeySet.PubKey[common.BlsConsensus]})
+	result, err := e.UserKeySet.SignMessage(data)
 	if err != nil {
 		return "", NewConsensusError(SignDataError, err)
 	}
@@ -13,9 +13,10 @@ const (
 )
 
 type BFTPropose struct {
-	PeerID   string
-	Block    json.RawMessage
-	TimeSlot uint64
+	PeerID      string
+	Block       json.RawMessage
+	TimeSlot    uint64
+	TraceParent string `json:",omitempty"` // W3C traceparent of proposer span, empty when tracing is disabled
 }
 
 type BFTVote struct {
@@ -28,6 +29,7 @@ type BFTVote struct {
 	Confirmation  []byte
 	isValid       int // 0 not process, 1 valid, -1 not valid
 	TimeSlot      uint64
+	TraceParent   string `json:",omitempty"`
 }
 
 type BFTRequestBlock struct {
@@ -8,6 +8,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 )
 
@@ -43,7 +44,15 @@ func EncodeValidationData(validationData ValidationData) (string, error) {
 
 func (e BLSBFT_V2) CreateValidationData(block common.BlockInterface) ValidationData {
 	var valData ValidationData
-	valData.ProducerBLSSig, _ = e.UserKeySet.BriSignData(block.Hash().GetBytes())
+	blockInfo, err := signer.NewBlockInfo(e.ChainID, block)
+	if err != nil {
+		e.Logger.Error(err)
+		return valData
+	}
+	valData.ProducerBLSSig, err = e.UserKeySet.BriSignData(blockInfo, nil)
+	if err != nil {
+		e.Logger.Error(err)
+	}
 	return valData
 }
 
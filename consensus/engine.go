@@ -8,6 +8,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/consensus/blsbft"
 	blsbft2 "github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/wire"
@@ -17,6 +18,7 @@ type Engine struct {
 	BFTProcess           map[int]ConsensusInterface //chainID -> consensus
 	userMiningPublicKeys map[string]*incognitokey.CommitteePublicKey
 	userKeyListString    string
+	remoteSigner         *signer.RemoteSigner
 	consensusName        string
 	currentMiningProcess ConsensusInterface
 	config               *EngineConfig
@@ -186,7 +188,13 @@ func (engine *Engine) Init(config *EngineConfig) {
 
 func (engine *Engine) Start() error {
 	defer Logger.Log.Infof("CONSENSUS: Start")
-	if engine.config.Node.GetPrivateKey() != "" {
+	if engine.config.Node.GetRemoteSigner() != "" {
+		remoteSigner, err := signer.Dial(engine.config.Node.GetRemoteSigner())
+		if err != nil {
+			panic(err)
+		}
+		engine.remoteSigner = remoteSigner
+	} else if engine.config.Node.GetPrivateKey() != "" {
 		keyList, err := engine.GenMiningKeyFromPrivateKey(engine.config.Node.GetPrivateKey())
 		if err != nil {
 			panic(err)
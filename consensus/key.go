@@ -14,6 +14,9 @@ import (
 )
 
 func (engine *Engine) LoadMiningKeys(keysString string) error {
+	if engine.remoteSigner != nil {
+		return engine.loadRemoteSigner()
+	}
 	if len(keysString) > 0 {
 		keys := strings.Split(keysString, "|")
 		if len(keys) > 0 {
@@ -48,6 +51,23 @@ func (engine *Engine) LoadMiningKeys(keysString string) error {
 	return nil
 }
 
+// loadRemoteSigner loads the mining keys of the signer daemon, a signer daemon only signs blocks of BFT v2
+func (engine *Engine) loadRemoteSigner() error {
+	f, ok := engine.currentMiningProcess.(*blsbftv2.BLSBFT_V2)
+	if engine.currentMiningProcess == nil {
+		f, ok = &blsbftv2.BLSBFT_V2{}, true
+	}
+	if !ok {
+		return errors.New("Remote signer requires consensus v2")
+	}
+	err := f.LoadRemoteSigner(engine.remoteSigner)
+	if err != nil {
+		return err
+	}
+	engine.SetMiningPublicKeys(common.BlsConsensus, f.GetUserPublicKey())
+	return nil
+}
+
 func (engine *Engine) GetCurrentMiningPublicKey() (publickey string, keyType string) {
 	if engine != nil && engine.GetMiningPublicKeys() != nil {
 		name := engine.consensusName
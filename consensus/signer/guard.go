@@ -0,0 +1,114 @@
+package signer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// signedBlock is the last block signed on a chain
+type signedBlock struct {
+	Height   uint64
+	TimeSlot int64
+	Hash     common.Hash
+}
+
+// Guard keeps the highest height and timeslot signed on each chain, a block is signed only if it is the last signed
+// block or comes after it. With a state file the guard survives restarts, the file is written before the signature
+// is returned
+type Guard struct {
+	stateFile string
+	lastBlock map[string]signedBlock // chain id -> last signed block
+	lock      sync.Mutex
+}
+
+// NewGuard loads the guard from stateFile, an empty stateFile keeps the guard in memory
+func NewGuard(stateFile string) (*Guard, error) {
+	guard := &Guard{
+		stateFile: stateFile,
+		lastBlock: make(map[string]signedBlock),
+	}
+	if stateFile == "" {
+		return guard, nil
+	}
+	data, err := ioutil.ReadFile(stateFile)
+	if os.IsNotExist(err) {
+		return guard, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &guard.lastBlock); err != nil {
+		return nil, err
+	}
+	return guard, nil
+}
+
+// Check verifies block and records it as the last signed block of its chain, sign is called while the chain is
+// locked and its signature is discarded if the record can not be saved
+func (g *Guard) Check(block *BlockInfo, sign func() ([]byte, error)) ([]byte, error) {
+	if err := block.Verify(); err != nil {
+		return nil, err
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	chainKey := strconv.Itoa(block.ChainID)
+	last, ok := g.lastBlock[chainKey]
+	if ok {
+		if block.Height < last.Height || (block.Height == last.Height && block.TimeSlot < last.TimeSlot) {
+			return nil, ErrOldBlock
+		}
+		if block.Height == last.Height && block.TimeSlot == last.TimeSlot && !block.Hash.IsEqual(&last.Hash) {
+			return nil, ErrDoubleSign
+		}
+	}
+	sig, err := sign()
+	if err != nil {
+		return nil, err
+	}
+	current := signedBlock{Height: block.Height, TimeSlot: block.TimeSlot, Hash: block.Hash}
+	if current != last {
+		g.lastBlock[chainKey] = current
+		if err := g.save(); err != nil {
+			if ok {
+				g.lastBlock[chainKey] = last
+			} else {
+				delete(g.lastBlock, chainKey)
+			}
+			return nil, err
+		}
+	}
+	return sig, nil
+}
+
+func (g *Guard) save() error {
+	if g.stateFile == "" {
+		return nil
+	}
+	data, err := json.Marshal(g.lastBlock)
+	if err != nil {
+		return err
+	}
+	// write then rename so that a crash leaves the old or the new state, never a partial file
+	tempFile := g.stateFile + ".tmp"
+	f, err := os.OpenFile(tempFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile, g.stateFile)
+}
@@ -0,0 +1,76 @@
+package signer
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+)
+
+// LocalSigner holds the mining keys in process, it is the signer served by the signer daemon and a stand-in for
+// tests
+type LocalSigner struct {
+	priKey map[string][]byte
+	pubKey map[string][]byte
+	guard  *Guard
+}
+
+// NewLocalSigner derives the mining keys from the base58 privateSeed of --miningkeys, guard is kept in stateFile
+func NewLocalSigner(privateSeed string, stateFile string) (*LocalSigner, error) {
+	privateSeedBytes, _, err := base58.Base58Check{}.Decode(privateSeed)
+	if err != nil {
+		return nil, err
+	}
+	guard, err := NewGuard(stateFile)
+	if err != nil {
+		return nil, err
+	}
+	blsPriKey, blsPubKey := blsmultisig.KeyGen(privateSeedBytes)
+	bridgePriKey, bridgePubKey := bridgesig.KeyGen(privateSeedBytes)
+	return &LocalSigner{
+		priKey: map[string][]byte{
+			common.BlsConsensus:    blsmultisig.SKBytes(blsPriKey),
+			common.BridgeConsensus: bridgesig.SKBytes(&bridgePriKey),
+		},
+		pubKey: map[string][]byte{
+			common.BlsConsensus:    blsmultisig.PKBytes(blsPubKey),
+			common.BridgeConsensus: bridgesig.PKBytes(&bridgePubKey),
+		},
+		guard: guard,
+	}, nil
+}
+
+func (s *LocalSigner) MiningPublicKeys() (map[string][]byte, error) {
+	pubKey := make(map[string][]byte)
+	for name, key := range s.pubKey {
+		pubKey[name] = key
+	}
+	return pubKey, nil
+}
+
+func (s *LocalSigner) BLSSign(block *BlockInfo, selfIdx int, committee [][]byte) ([]byte, error) {
+	return s.guard.Check(block, func() ([]byte, error) {
+		committeeKeys := make([]blsmultisig.PublicKey, len(committee))
+		for i, key := range committee {
+			committeeKeys[i] = key
+		}
+		return blsmultisig.Sign(block.Hash.GetBytes(), s.priKey[common.BlsConsensus], selfIdx, committeeKeys)
+	})
+}
+
+func (s *LocalSigner) BriSign(block *BlockInfo, vote *VoteInfo) ([]byte, error) {
+	return s.guard.Check(block, func() ([]byte, error) {
+		data := block.Hash.GetBytes()
+		if vote != nil {
+			data = ConfirmationData(block, vote)
+		}
+		return bridgesig.Sign(s.priKey[common.BridgeConsensus], data)
+	})
+}
+
+func (s *LocalSigner) SignMessage(data []byte) ([]byte, error) {
+	if len(data) == common.HashSize {
+		return nil, ErrBlockHashMessage
+	}
+	return bridgesig.Sign(s.priKey[common.BridgeConsensus], data)
+}
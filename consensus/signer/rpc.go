@@ -0,0 +1,187 @@
+package signer
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"os"
+	"strings"
+	"sync"
+)
+
+const serviceName = "Signer"
+
+// Listen listens on address, "unix:<path>" for a unix socket or "tcp:<host:port>". A tcp signer has no
+// authentication and should only be reached through a private network or a tunnel
+func Listen(address string) (net.Listener, error) {
+	network, addr, err := parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	if network == "unix" {
+		// remove the socket left by a previous run
+		if err := os.Remove(addr); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		listener, err := net.Listen(network, addr)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.Chmod(addr, 0600); err != nil {
+			listener.Close()
+			return nil, err
+		}
+		return listener, nil
+	}
+	return net.Listen(network, addr)
+}
+
+func parseAddress(address string) (string, string, error) {
+	parts := strings.SplitN(address, ":", 2)
+	if len(parts) != 2 || (parts[0] != "unix" && parts[0] != "tcp") || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid signer address %+v, expect unix:<path> or tcp:<host:port>", address)
+	}
+	return parts[0], parts[1], nil
+}
+
+// Serve serves signer on listener until the listener is closed
+func Serve(listener net.Listener, signer Signer) error {
+	server := rpc.NewServer()
+	if err := server.RegisterName(serviceName, &service{signer: signer}); err != nil {
+		return err
+	}
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go server.ServeConn(conn)
+	}
+}
+
+type BLSSignArgs struct {
+	Block     *BlockInfo
+	SelfIdx   int
+	Committee [][]byte
+}
+
+type BriSignArgs struct {
+	Block *BlockInfo
+	Vote  *VoteInfo
+}
+
+// service exposes a Signer to net/rpc
+type service struct {
+	signer Signer
+}
+
+func (s *service) MiningPublicKeys(_ struct{}, reply *map[string][]byte) error {
+	pubKey, err := s.signer.MiningPublicKeys()
+	if err != nil {
+		return err
+	}
+	*reply = pubKey
+	return nil
+}
+
+func (s *service) BLSSign(args BLSSignArgs, reply *[]byte) error {
+	sig, err := s.signer.BLSSign(args.Block, args.SelfIdx, args.Committee)
+	if err != nil {
+		return err
+	}
+	*reply = sig
+	return nil
+}
+
+func (s *service) BriSign(args BriSignArgs, reply *[]byte) error {
+	sig, err := s.signer.BriSign(args.Block, args.Vote)
+	if err != nil {
+		return err
+	}
+	*reply = sig
+	return nil
+}
+
+func (s *service) SignMessage(data []byte, reply *[]byte) error {
+	sig, err := s.signer.SignMessage(data)
+	if err != nil {
+		return err
+	}
+	*reply = sig
+	return nil
+}
+
+// RemoteSigner is a Signer served by a signer daemon, it reconnects when the daemon restarts
+type RemoteSigner struct {
+	network string
+	addr    string
+	client  *rpc.Client
+	lock    sync.Mutex
+}
+
+// Dial connects to the signer daemon listening on address, see Listen
+func Dial(address string) (*RemoteSigner, error) {
+	network, addr, err := parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	client, err := rpc.Dial(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteSigner{network: network, addr: addr, client: client}, nil
+}
+
+func (s *RemoteSigner) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.client.Close()
+}
+
+func (s *RemoteSigner) call(method string, args interface{}, reply interface{}) error {
+	s.lock.Lock()
+	client := s.client
+	s.lock.Unlock()
+	err := client.Call(serviceName+"."+method, args, reply)
+	// the connection is lost when the daemon restarts, signing the same block again is allowed by the guard
+	if err != rpc.ErrShutdown && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	s.lock.Lock()
+	if s.client == client {
+		newClient, err := rpc.Dial(s.network, s.addr)
+		if err != nil {
+			s.lock.Unlock()
+			return err
+		}
+		s.client = newClient
+	}
+	client = s.client
+	s.lock.Unlock()
+	return client.Call(serviceName+"."+method, args, reply)
+}
+
+func (s *RemoteSigner) MiningPublicKeys() (map[string][]byte, error) {
+	var pubKey map[string][]byte
+	err := s.call("MiningPublicKeys", struct{}{}, &pubKey)
+	return pubKey, err
+}
+
+func (s *RemoteSigner) BLSSign(block *BlockInfo, selfIdx int, committee [][]byte) ([]byte, error) {
+	var sig []byte
+	err := s.call("BLSSign", BLSSignArgs{Block: block, SelfIdx: selfIdx, Committee: committee}, &sig)
+	return sig, err
+}
+
+func (s *RemoteSigner) BriSign(block *BlockInfo, vote *VoteInfo) ([]byte, error) {
+	var sig []byte
+	err := s.call("BriSign", BriSignArgs{Block: block, Vote: vote}, &sig)
+	return sig, err
+}
+
+func (s *RemoteSigner) SignMessage(data []byte) ([]byte, error) {
+	var sig []byte
+	err := s.call("SignMessage", data, &sig)
+	return sig, err
+}
@@ -0,0 +1,123 @@
+// Package signer keeps consensus mining keys out of the node. The node sends what it wants signed to a Signer, a
+// signer daemon holding the keys checks the request against the block header and refuses to sign twice at the same
+// height and timeslot of a chain, so a compromised node can neither read the keys nor equivocate
+package signer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+var (
+	ErrInvalidBlockInfo = errors.New("block info does not match its header")
+	ErrDoubleSign       = errors.New("refuse to sign, a different block was signed at this height and timeslot")
+	ErrOldBlock         = errors.New("refuse to sign, a block at a higher height or timeslot was signed")
+	ErrBlockHashMessage = errors.New("refuse to sign a message of the size of a block hash")
+)
+
+// Signer signs consensus data with the BLS and bridge mining keys
+type Signer interface {
+	// MiningPublicKeys returns the public keys by consensus name, common.BlsConsensus and common.BridgeConsensus
+	MiningPublicKeys() (map[string][]byte, error)
+	// BLSSign signs the hash of block for the committee, selfIdx is the index of the signer in committee
+	BLSSign(block *BlockInfo, selfIdx int, committee [][]byte) ([]byte, error)
+	// BriSign signs the hash of block with the bridge key, or the confirmation of a vote on block if vote is not nil
+	BriSign(block *BlockInfo, vote *VoteInfo) ([]byte, error)
+	// SignMessage signs data unrelated to blocks with the bridge key, like the peer id of the node
+	SignMessage(data []byte) ([]byte, error)
+}
+
+// BlockInfo is a block to sign, Header is the json of its header so that a signer can check the other fields
+type BlockInfo struct {
+	ChainID  int // -1 for beacon
+	Height   uint64
+	TimeSlot int64
+	Hash     common.Hash
+	Header   []byte
+}
+
+// VoteInfo is a vote to confirm, the confirmation signs the block hash with the BLS and bridge signatures of the vote
+type VoteInfo struct {
+	// HashString is true when the block hash is added in hex as by BFT v2, false for the raw bytes of BFT v1
+	HashString bool
+	BLS        []byte
+	BRI        []byte
+}
+
+// NewBlockInfo returns the info to sign block of chainID
+func NewBlockInfo(chainID int, block common.BlockInterface) (*BlockInfo, error) {
+	var header interface{}
+	switch b := block.(type) {
+	case *blockchain.BeaconBlock:
+		header = b.Header
+	case *blockchain.ShardBlock:
+		header = b.Header
+	default:
+		return nil, fmt.Errorf("unknown block type %T", block)
+	}
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockInfo{
+		ChainID:  chainID,
+		Height:   block.GetHeight(),
+		TimeSlot: blockTimeSlot(block.GetVersion(), block.GetProposeTime(), block.GetRound()),
+		Hash:     *block.Hash(),
+		Header:   headerBytes,
+	}, nil
+}
+
+// blockTimeSlot returns the timeslot of a block proposal, the round is used for blocks of BFT v1
+func blockTimeSlot(version int, proposeTime int64, round int) int64 {
+	if version < 2 {
+		return int64(round)
+	}
+	return common.CalculateTimeSlot(proposeTime)
+}
+
+// Verify checks Height, TimeSlot and Hash against Header
+func (b *BlockInfo) Verify() error {
+	var height uint64
+	var hash common.Hash
+	var timeSlot int64
+	if b.ChainID == -1 {
+		var header blockchain.BeaconHeader
+		if err := json.Unmarshal(b.Header, &header); err != nil {
+			return err
+		}
+		height, hash = header.Height, header.Hash()
+		timeSlot = blockTimeSlot(header.Version, header.ProposeTime, header.Round)
+	} else {
+		var header blockchain.ShardHeader
+		if err := json.Unmarshal(b.Header, &header); err != nil {
+			return err
+		}
+		if int(header.ShardID) != b.ChainID {
+			return ErrInvalidBlockInfo
+		}
+		height, hash = header.Height, header.Hash()
+		timeSlot = blockTimeSlot(header.Version, header.ProposeTime, header.Round)
+	}
+	if height != b.Height || timeSlot != b.TimeSlot || !hash.IsEqual(&b.Hash) {
+		return ErrInvalidBlockInfo
+	}
+	return nil
+}
+
+// ConfirmationData returns the data signed to confirm vote on block
+func ConfirmationData(block *BlockInfo, vote *VoteInfo) []byte {
+	data := []byte{}
+	if vote.HashString {
+		data = append(data, block.Hash.String()...)
+	} else {
+		data = append(data, block.Hash.GetBytes()...)
+	}
+	data = append(data, vote.BLS...)
+	data = append(data, vote.BRI...)
+	return common.HashB(data)
+}
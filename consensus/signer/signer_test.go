@@ -0,0 +1,150 @@
+package signer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPrivateSeed = base58.Base58Check{}.Encode(common.HashB([]byte("signer test")), common.Base58Version)
+
+func newTestBlockInfo(t *testing.T, height uint64, timeSlot int64, proposer string) *BlockInfo {
+	block := &blockchain.ShardBlock{
+		Header: blockchain.ShardHeader{
+			ShardID:     1,
+			Version:     2,
+			Height:      height,
+			Proposer:    proposer,
+			ProposeTime: timeSlot * common.TIMESLOT,
+		},
+	}
+	blockInfo, err := NewBlockInfo(1, block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return blockInfo
+}
+
+func newTestLocalSigner(t *testing.T, stateFile string) *LocalSigner {
+	localSigner, err := NewLocalSigner(testPrivateSeed, stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return localSigner
+}
+
+func TestLocalSignerSignatures(t *testing.T) {
+	localSigner := newTestLocalSigner(t, "")
+	pubKey, err := localSigner.MiningPublicKeys()
+	assert.NoError(t, err)
+	block := newTestBlockInfo(t, 10, 100, "a")
+
+	committee := [][]byte{pubKey[common.BlsConsensus]}
+	blsSig, err := localSigner.BLSSign(block, 0, committee)
+	assert.NoError(t, err)
+	ok, err := blsmultisig.Verify(blsSig, block.Hash.GetBytes(), []int{0}, []blsmultisig.PublicKey{committee[0]})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	briSig, err := localSigner.BriSign(block, nil)
+	assert.NoError(t, err)
+	ok, err = bridgesig.Verify(pubKey[common.BridgeConsensus], block.Hash.GetBytes(), briSig)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	vote := &VoteInfo{HashString: true, BLS: blsSig, BRI: briSig}
+	confirmation, err := localSigner.BriSign(block, vote)
+	assert.NoError(t, err)
+	ok, err = bridgesig.Verify(pubKey[common.BridgeConsensus], ConfirmationData(block, vote), confirmation)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = localSigner.SignMessage(block.Hash.GetBytes())
+	assert.Equal(t, ErrBlockHashMessage, err)
+	_, err = localSigner.SignMessage([]byte("QmPeerIDOfTheNodeSigningThisMessage"))
+	assert.NoError(t, err)
+}
+
+func TestLocalSignerRefusesDoubleSign(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	stateFile := filepath.Join(dir, "state.json")
+	localSigner := newTestLocalSigner(t, stateFile)
+
+	_, err = localSigner.BriSign(newTestBlockInfo(t, 10, 100, "a"), nil)
+	assert.NoError(t, err)
+	// the same block again
+	_, err = localSigner.BriSign(newTestBlockInfo(t, 10, 100, "a"), nil)
+	assert.NoError(t, err)
+	// another block at the same height and timeslot
+	_, err = localSigner.BriSign(newTestBlockInfo(t, 10, 100, "b"), nil)
+	assert.Equal(t, ErrDoubleSign, err)
+	// lower timeslot and lower height
+	_, err = localSigner.BriSign(newTestBlockInfo(t, 10, 99, "a"), nil)
+	assert.Equal(t, ErrOldBlock, err)
+	_, err = localSigner.BriSign(newTestBlockInfo(t, 9, 101, "a"), nil)
+	assert.Equal(t, ErrOldBlock, err)
+	// the same height at a later timeslot
+	_, err = localSigner.BriSign(newTestBlockInfo(t, 10, 101, "b"), nil)
+	assert.NoError(t, err)
+
+	// info which does not match the header
+	block := newTestBlockInfo(t, 11, 102, "a")
+	block.Height = 12
+	_, err = localSigner.BriSign(block, nil)
+	assert.Equal(t, ErrInvalidBlockInfo, err)
+
+	// the guard is kept after a restart
+	localSigner = newTestLocalSigner(t, stateFile)
+	_, err = localSigner.BriSign(newTestBlockInfo(t, 10, 101, "c"), nil)
+	assert.Equal(t, ErrDoubleSign, err)
+	_, err = localSigner.BriSign(newTestBlockInfo(t, 11, 102, "c"), nil)
+	assert.NoError(t, err)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	address := "unix:" + filepath.Join(dir, "signer.sock")
+	listener, err := Listen(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go Serve(listener, newTestLocalSigner(t, ""))
+
+	remoteSigner, err := Dial(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer remoteSigner.Close()
+	pubKey, err := remoteSigner.MiningPublicKeys()
+	assert.NoError(t, err)
+
+	block := newTestBlockInfo(t, 10, 100, "a")
+	blsSig, err := remoteSigner.BLSSign(block, 0, [][]byte{pubKey[common.BlsConsensus]})
+	assert.NoError(t, err)
+	ok, err := blsmultisig.Verify(blsSig, block.Hash.GetBytes(), []int{0}, []blsmultisig.PublicKey{pubKey[common.BlsConsensus]})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = remoteSigner.BriSign(newTestBlockInfo(t, 10, 100, "b"), nil)
+	assert.EqualError(t, err, ErrDoubleSign.Error())
+
+	_, err = Dial("udp:localhost:9334")
+	assert.Error(t, err)
+}
@@ -80,6 +80,15 @@ func GetFinalizedBeaconBlockHashByIndex(db incdb.KeyValueReader, index uint64) (
 	return h, err
 }
 
+// DeleteFinalizedBeaconBlockHashByIndex remove the block hash of a finalized height of the beacon
+func DeleteFinalizedBeaconBlockHashByIndex(db incdb.KeyValueWriter, index uint64) error {
+	keyHash := GetBeaconIndexToBlockHashKey(index)
+	if err := db.Delete(keyHash); err != nil {
+		return NewRawdbError(DeleteBeaconBlockIndexError, err)
+	}
+	return nil
+}
+
 func StoreBeaconViews(db incdb.KeyValueWriter, val []byte) error {
 	key := GetBeaconViewsKey()
 	if err := db.Put(key, val); err != nil {
@@ -91,3 +91,40 @@ func RestoreCrossShardNextHeights(db incdb.Database, fromShard byte, toShard byt
 	}
 	return nil
 }
+
+// StoreCrossShardReceipt - store the shard block which processed the cross shard outputs of a block of another shard,
+// in the database of the shard receiving the outputs
+// key format: prefix + hash of the block sending the outputs
+// value: 8 bytes of the height then 32 bytes of the hash of the receiving block
+func StoreCrossShardReceipt(db incdb.KeyValueWriter, fromBlockHash common.Hash, height uint64, hash common.Hash) error {
+	key := GetCrossShardReceiptKey(fromBlockHash)
+	value := append(common.Uint64ToBytes(height), hash[:]...)
+	if err := db.Put(key, value); err != nil {
+		return NewRawdbError(StoreCrossShardReceiptError, err)
+	}
+	return nil
+}
+
+// GetCrossShardReceipt - return the height and hash of the shard block which processed the cross shard outputs of
+// the block fromBlockHash, nil if they are not processed yet
+func GetCrossShardReceipt(db incdb.KeyValueReader, fromBlockHash common.Hash) (uint64, *common.Hash, error) {
+	key := GetCrossShardReceiptKey(fromBlockHash)
+	if has, err := db.Has(key); err != nil {
+		return 0, nil, NewRawdbError(GetCrossShardReceiptError, err)
+	} else if !has {
+		return 0, nil, nil
+	}
+	value, err := db.Get(key)
+	if err != nil {
+		return 0, nil, NewRawdbError(GetCrossShardReceiptError, err)
+	}
+	if len(value) != common.Uint64Size+common.HashSize {
+		return 0, nil, NewRawdbError(GetCrossShardReceiptError, fmt.Errorf("invalid receipt of block %+v", fromBlockHash))
+	}
+	height, err := common.BytesToUint64(value[:common.Uint64Size])
+	if err != nil {
+		return 0, nil, NewRawdbError(GetCrossShardReceiptError, err)
+	}
+	hash := common.BytesToHash(value[common.Uint64Size:])
+	return height, &hash, nil
+}
@@ -0,0 +1,37 @@
+package rawdbv2_test
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+)
+
+func TestStoreCrossShardReceipt(t *testing.T) {
+	fromBlockHashes := generateTxHash(10)
+	blockHashes := generateTxHash(10)
+	for i, fromBlockHash := range fromBlockHashes {
+		err := rawdbv2.StoreCrossShardReceipt(dbTx, fromBlockHash, uint64(i+1), blockHashes[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, fromBlockHash := range fromBlockHashes {
+		height, hash, err := rawdbv2.GetCrossShardReceipt(dbTx, fromBlockHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash == nil {
+			t.Fatalf("want receipt of block %+v but got none", fromBlockHash)
+		}
+		if height != uint64(i+1) || *hash != blockHashes[i] {
+			t.Fatalf("want receipt %+v %+v but got %+v %+v", i+1, blockHashes[i], height, *hash)
+		}
+	}
+	_, hash, err := rawdbv2.GetCrossShardReceipt(dbTx, generateTxHash(1)[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != nil {
+		t.Fatalf("want no receipt but got %+v", *hash)
+	}
+}
@@ -43,6 +43,15 @@ func GetFinalizedShardBlockHashByIndex(db incdb.KeyValueReader, sid byte, index
 	return h, nil
 }
 
+// DeleteFinalizedShardBlockHashByIndex remove the block hash of a finalized height of the shard
+func DeleteFinalizedShardBlockHashByIndex(db incdb.KeyValueWriter, sid byte, index uint64) error {
+	keyHash := GetShardIndexToBlockHashPrefix(sid, index)
+	if err := db.Delete(keyHash); err != nil {
+		return NewRawdbError(DeleteShardBlockIndexError, err)
+	}
+	return nil
+}
+
 func HasShardBlock(db incdb.KeyValueReader, hash common.Hash) (bool, error) {
 	keyHash := GetShardHashToBlockKey(hash)
 	if ok, err := db.Has(keyHash); err != nil {
@@ -69,6 +78,76 @@ func GetShardBlockByHash(db incdb.KeyValueReader, hash common.Hash) ([]byte, err
 	return ret, nil
 }
 
+// DeleteShardBlock remove the block value of a hash
+func DeleteShardBlock(db incdb.KeyValueWriter, hash common.Hash) error {
+	keyHash := GetShardHashToBlockKey(hash)
+	if err := db.Delete(keyHash); err != nil {
+		return NewRawdbError(DeleteShardBlockError, err)
+	}
+	return nil
+}
+
+// StorePrunedShardBlock store block hash => the block without its body, once the block is pruned
+func StorePrunedShardBlock(db incdb.KeyValueWriter, hash common.Hash, v interface{}) error {
+	keyHash := GetPrunedShardBlockKey(hash)
+	val, err := json.Marshal(v)
+	if err != nil {
+		return NewRawdbError(StorePrunedShardBlockError, err)
+	}
+	if err := db.Put(keyHash, val); err != nil {
+		return NewRawdbError(StorePrunedShardBlockError, err)
+	}
+	return nil
+}
+
+func HasPrunedShardBlock(db incdb.KeyValueReader, hash common.Hash) (bool, error) {
+	keyHash := GetPrunedShardBlockKey(hash)
+	ok, err := db.Has(keyHash)
+	if err != nil {
+		return false, NewRawdbError(GetPrunedShardBlockError, fmt.Errorf("has key %+v failed", keyHash))
+	}
+	return ok, nil
+}
+
+func GetPrunedShardBlockByHash(db incdb.KeyValueReader, hash common.Hash) ([]byte, error) {
+	block, err := db.Get(GetPrunedShardBlockKey(hash))
+	if err != nil {
+		return nil, NewRawdbError(GetPrunedShardBlockError, err)
+	}
+	ret := make([]byte, len(block))
+	copy(ret, block)
+	return ret, nil
+}
+
+// StoreShardPrunedHeight store the height of the shard up to which the finalized blocks are pruned
+func StoreShardPrunedHeight(db incdb.KeyValueWriter, shardID byte, height uint64) error {
+	if err := db.Put(GetShardPrunedHeightKey(shardID), common.Uint64ToBytes(height)); err != nil {
+		return NewRawdbError(StoreShardPrunedHeightError, err)
+	}
+	return nil
+}
+
+// GetShardPrunedHeight return the height of the shard up to which the finalized blocks are pruned, 0 if none is
+func GetShardPrunedHeight(db incdb.KeyValueReader, shardID byte) (uint64, error) {
+	key := GetShardPrunedHeightKey(shardID)
+	ok, err := db.Has(key)
+	if err != nil {
+		return 0, NewRawdbError(GetShardPrunedHeightError, err)
+	}
+	if !ok {
+		return 0, nil
+	}
+	val, err := db.Get(key)
+	if err != nil {
+		return 0, NewRawdbError(GetShardPrunedHeightError, err)
+	}
+	height, err := common.BytesToUint64(val)
+	if err != nil {
+		return 0, NewRawdbError(GetShardPrunedHeightError, err)
+	}
+	return height, nil
+}
+
 func StoreShardBestState(db incdb.KeyValueWriter, shardID byte, v interface{}) error {
 	key := GetShardBestStateKey(shardID)
 	val, err := json.Marshal(v)
@@ -69,70 +69,54 @@ func resetDatabaseShard() {
 func storeShardBlock() error {
 	resetDatabaseShard()
 	for i := 0; i < maxS; i++ {
-		err := rawdbv2.StoreShardBlock(dbS, byte(0), uint64(i), shardBlocks[i].Header.Hash(), shardBlocks[i])
+		err := rawdbv2.StoreShardBlock(dbS, shardBlocks[i].Header.Hash(), shardBlocks[i])
 		if err != nil {
 			return err
 		}
 	}
-	err := rawdbv2.StoreShardBlock(dbS, byte(0), forkedShardBlock1.Header.Height, forkedShardBlock1.Header.Hash(), forkedShardBlock1)
+	err := rawdbv2.StoreShardBlock(dbS, forkedShardBlock1.Header.Hash(), forkedShardBlock1)
 	if err != nil {
 		return err
 	}
-	err1 := rawdbv2.StoreShardBlock(dbS, byte(0), forkedShardBlock2.Header.Height, forkedShardBlock2.Header.Hash(), forkedShardBlock2)
+	err1 := rawdbv2.StoreShardBlock(dbS, forkedShardBlock2.Header.Hash(), forkedShardBlock2)
 	if err1 != nil {
 		return err1
 	}
 	for i := 0; i < maxS; i++ {
-		err := rawdbv2.StoreShardBlockIndex(dbS, byte(0), uint64(i), shardBlocks[i].Header.Hash())
+		err := rawdbv2.StoreFinalizedShardBlockHashByIndex(dbS, byte(0), uint64(i), shardBlocks[i].Header.Hash())
 		if err != nil {
 			return err
 		}
 	}
-	err2 := rawdbv2.StoreShardBlockIndex(dbS, byte(0), forkedShardBlock1.Header.Height, forkedShardBlock1.Header.Hash())
-	if err2 != nil {
-		return err2
-	}
-	err3 := rawdbv2.StoreShardBlockIndex(dbS, byte(0), forkedShardBlock2.Header.Height, forkedShardBlock2.Header.Hash())
-	if err3 != nil {
-		return err3
-	}
 	return nil
 }
 
 func TestStoreShardBlock(t *testing.T) {
 	resetDatabaseShard()
 	for i := 0; i < maxS; i++ {
-		err := rawdbv2.StoreShardBlock(dbS, byte(0), uint64(i), shardBlocks[i].Header.Hash(), shardBlocks[i])
+		err := rawdbv2.StoreShardBlock(dbS, shardBlocks[i].Header.Hash(), shardBlocks[i])
 		if err != nil {
 			t.Fatal(err)
 		}
 	}
-	err := rawdbv2.StoreShardBlock(dbS, byte(0), forkedShardBlock1.Header.Height, forkedShardBlock1.Header.Hash(), forkedShardBlock1)
+	err := rawdbv2.StoreShardBlock(dbS, forkedShardBlock1.Header.Hash(), forkedShardBlock1)
 	if err != nil {
 		t.Fatal(err)
 	}
-	err1 := rawdbv2.StoreShardBlock(dbS, byte(0), forkedShardBlock2.Header.Height, forkedShardBlock2.Header.Hash(), forkedShardBlock2)
+	err1 := rawdbv2.StoreShardBlock(dbS, forkedShardBlock2.Header.Hash(), forkedShardBlock2)
 	if err1 != nil {
 		t.Fatal(err1)
 	}
 }
 
-func TestStoreShardBlockIndex(t *testing.T) {
+func TestStoreFinalizedShardBlockHashByIndex(t *testing.T) {
 	resetDatabaseShard()
 	for i := 0; i < maxS; i++ {
-		err := rawdbv2.StoreShardBlockIndex(dbS, byte(0), uint64(i), shardBlocks[i].Header.Hash())
+		err := rawdbv2.StoreFinalizedShardBlockHashByIndex(dbS, byte(0), uint64(i), shardBlocks[i].Header.Hash())
 		if err != nil {
 			t.Fatal(err)
 		}
 	}
-	err := rawdbv2.StoreShardBlockIndex(dbS, byte(0), forkedShardBlock1.Header.Height, forkedShardBlock1.Header.Hash())
-	if err != nil {
-		t.Fatal(err)
-	}
-	err1 := rawdbv2.StoreShardBlockIndex(dbS, byte(0), forkedShardBlock2.Header.Height, forkedShardBlock2.Header.Hash())
-	if err1 != nil {
-		t.Fatal(err1)
-	}
 }
 
 func TestHasShardBlock(t *testing.T) {
@@ -217,36 +201,29 @@ func TestGetShardBlockByHash(t *testing.T) {
 
 }
 
-func TestGetShardBlockByIndex(t *testing.T) {
+func TestGetFinalizedShardBlockHashByIndex(t *testing.T) {
 	err := storeShardBlock()
 	if err != nil {
 		t.Fatal(err)
 	}
-	tempShardBlockHeight1, err := rawdbv2.GetShardBlockByIndex(dbS, 0, 1)
-	if err != nil {
-		t.Fatal(err)
-	}
-	if len(tempShardBlockHeight1) != 2 {
-		t.Fatalf("want %+v but got %+v block", 2, len(tempShardBlockHeight1))
-	}
-
-	tempShardBlockHeight2, err := rawdbv2.GetShardBlockByIndex(dbS, 0, 2)
-	if err != nil {
-		t.Fatal(err)
-	}
-	if len(tempShardBlockHeight2) != 2 {
-		t.Fatalf("want %+v but got %+v block", 2, len(tempShardBlockHeight2))
-	}
-
-	for i := 3; i < maxS; i++ {
-		tempShardBlockHeight, err := rawdbv2.GetShardBlockByIndex(dbS, 0, uint64(i))
+	// the forked blocks at height 1 and 2 are stored but never finalized
+	for i := 0; i < maxS; i++ {
+		hash, err := rawdbv2.GetFinalizedShardBlockHashByIndex(dbS, 0, uint64(i))
 		if err != nil {
 			t.Fatal(err)
 		}
-		if len(tempShardBlockHeight) != 1 {
-			t.Fatalf("want %+v but got %+v block", 1, len(tempShardBlockHeight))
+		if hash.String() != shardBlocks[i].Header.Hash().String() {
+			t.Fatalf("want hash %+v but got %+v", shardBlocks[i].Header.Hash(), hash)
 		}
 	}
+	_, err = rawdbv2.GetFinalizedShardBlockHashByIndex(dbS, 0, randomShardBlock1.Header.Height)
+	if err == nil {
+		t.Fatalf("want no block at height %+v", randomShardBlock1.Header.Height)
+	}
+	_, err = rawdbv2.GetFinalizedShardBlockHashByIndex(dbS, 1, 1)
+	if err == nil {
+		t.Fatalf("want no block of shard %+v at height %+v", 1, 1)
+	}
 }
 
 func TestGetIndexOfShardBlock(t *testing.T) {
@@ -1,6 +1,7 @@
 package rawdbv2
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incdb"
@@ -8,7 +9,7 @@ import (
 	"strings"
 )
 
-func StoreTransactionIndex(db incdb.Database, txHash common.Hash, blockHash common.Hash, index int) error {
+func StoreTransactionIndex(db incdb.KeyValueWriter, txHash common.Hash, blockHash common.Hash, index int) error {
 	key := GetTransactionHashKey(txHash)
 	value := []byte(blockHash.String() + string(splitter) + strconv.Itoa(index))
 	if err := db.Put(key, value); err != nil {
@@ -41,7 +42,7 @@ func GetTransactionByHash(db incdb.Database, txHash common.Hash) (common.Hash, i
 	return *blockHash, index, nil
 }
 
-func DeleteTransactionIndex(db incdb.Database, txHash common.Hash) error {
+func DeleteTransactionIndex(db incdb.KeyValueWriter, txHash common.Hash) error {
 	key := GetTransactionHashKey(txHash)
 	err := db.Delete(key)
 	if err != nil {
@@ -50,6 +51,57 @@ func DeleteTransactionIndex(db incdb.Database, txHash common.Hash) error {
 	return nil
 }
 
+// IterateTransactionIndex - call f with every entry of the transaction index, until f returns an error
+func IterateTransactionIndex(db incdb.Database, f func(txHash common.Hash, blockHash common.Hash, index int) error) error {
+	iterator := db.NewIteratorWithPrefix(txHashPrefix)
+	defer iterator.Release()
+	for iterator.Next() {
+		key := iterator.Key()
+		txHash := common.Hash{}
+		if err := txHash.SetBytes(key[len(txHashPrefix):]); err != nil {
+			return NewRawdbError(IterateTransactionIndexError, err)
+		}
+		strs := strings.Split(string(iterator.Value()), string(splitter))
+		if len(strs) != 2 {
+			return NewRawdbError(IterateTransactionIndexError, fmt.Errorf("wrong index of transaction %+v", txHash))
+		}
+		blockHash, err := common.Hash{}.NewHashFromStr(strs[0])
+		if err != nil {
+			return NewRawdbError(IterateTransactionIndexError, err)
+		}
+		index, err := strconv.Atoi(strs[1])
+		if err != nil {
+			return NewRawdbError(IterateTransactionIndexError, err)
+		}
+		if err := f(txHash, *blockHash, index); err != nil {
+			return err
+		}
+	}
+	return iterator.Error()
+}
+
+// StoreKeptTransaction store tx hash => tx value, for the txs of a pruned block still read to validate blocks
+func StoreKeptTransaction(db incdb.KeyValueWriter, txHash common.Hash, v interface{}) error {
+	val, err := json.Marshal(v)
+	if err != nil {
+		return NewRawdbError(StoreKeptTransactionError, err)
+	}
+	if err := db.Put(GetKeptTransactionKey(txHash), val); err != nil {
+		return NewRawdbError(StoreKeptTransactionError, err)
+	}
+	return nil
+}
+
+func GetKeptTransaction(db incdb.KeyValueReader, txHash common.Hash) ([]byte, error) {
+	val, err := db.Get(GetKeptTransactionKey(txHash))
+	if err != nil {
+		return nil, NewRawdbError(GetKeptTransactionError, err)
+	}
+	ret := make([]byte, len(val))
+	copy(ret, val)
+	return ret, nil
+}
+
 // StoreTxByPublicKey - store txID by public key of receiver,
 // use this data to get tx which send to receiver
 // key format:
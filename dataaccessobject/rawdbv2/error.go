@@ -13,6 +13,7 @@ const (
 	UpdateBeaconBlockViewError
 	GetBeaconBlockByViewError
 	DeleteBeaconBlockByViewError
+	DeleteBeaconBlockIndexError
 	StoreBeaconBlockIndexError
 	GetIndexOfBeaconBlockError
 	HasBeaconBlockError
@@ -66,6 +67,7 @@ const (
 	GetShardBlockByHashError
 	GetShardBlockByIndexError
 	DeleteShardBlockError
+	DeleteShardBlockIndexError
 	StoreCrossShardNextHeightError
 	FetchCrossShardNextHeightError
 	GetIndexOfBlockError
@@ -77,12 +79,21 @@ const (
 	CleanUpPreviousShardBestStateError
 	RestoreCrossShardNextHeightsError
 	StoreShardPreCommitteeError
+	StoreCrossShardReceiptError
+	GetCrossShardReceiptError
+	StorePrunedShardBlockError
+	GetPrunedShardBlockError
+	StoreShardPrunedHeightError
+	GetShardPrunedHeightError
 	// tx
 	StoreTransactionIndexError
 	GetTransactionByHashError
 	DeleteTransactionByHashError
 	StoreTxByPublicKeyError
 	GetTxByPublicKeyError
+	IterateTransactionIndexError
+	StoreKeptTransactionError
+	GetKeptTransactionError
 
 	// relaying - portal
 	StoreRelayingBNBHeaderError
@@ -106,6 +117,7 @@ var ErrCodeMessage = map[int]struct {
 	UpdateBeaconBlockViewError:    {-1009, "Update Beacon Block View Error"},
 	GetBeaconBlockByViewError:     {-1010, "Get Beacon Block By View Error"},
 	DeleteBeaconBlockByViewError:  {-1011, "Delete Beacon Block By View"},
+	DeleteBeaconBlockIndexError:   {-1012, "Delete Beacon Block Index Error"},
 	FinalizedBeaconBlockError:     {-2016, "Finalized Beacon Block Error "},
 	GetFinalizedBeaconBlockError:  {-2017, "Get Finalized Beacon Block Error"},
 
@@ -127,12 +139,22 @@ var ErrCodeMessage = map[int]struct {
 	DeleteShardBlockByViewError:    {-2015, "Delete Shard Block By View"},
 	FinalizedShardBlockError:       {-2016, "Finalized Shard Block Error "},
 	GetFinalizedShardBlockError:    {-2017, "Get Finalized Shard Block Error"},
+	StoreCrossShardReceiptError:    {-2018, "Store Cross Shard Receipt Error"},
+	GetCrossShardReceiptError:      {-2019, "Get Cross Shard Receipt Error"},
+	DeleteShardBlockIndexError:     {-2020, "Delete Shard Block Index Error"},
+	StorePrunedShardBlockError:     {-2021, "Store Pruned Shard Block Error"},
+	GetPrunedShardBlockError:       {-2022, "Get Pruned Shard Block Error"},
+	StoreShardPrunedHeightError:    {-2023, "Store Shard Pruned Height Error"},
+	GetShardPrunedHeightError:      {-2024, "Get Shard Pruned Height Error"},
 
 	StoreTransactionIndexError:   {-3000, "Store Transaction Index Error"},
 	GetTransactionByHashError:    {-3001, "Get Transaction By Hash Error"},
 	StoreTxByPublicKeyError:      {-3002, "Store Tx By PublicKey Error"},
 	GetTxByPublicKeyError:        {-3003, "Get Tx By Public Key Error"},
 	DeleteTransactionByHashError: {-3004, "Delete Transaction By Hash Error"},
+	IterateTransactionIndexError: {-3005, "Iterate Transaction Index Error"},
+	StoreKeptTransactionError:    {-3006, "Store Kept Transaction Error"},
+	GetKeptTransactionError:      {-3007, "Get Kept Transaction Error"},
 
 	StoreBeaconConsensusRootHashError:       {-4000, "Store Beacon Consensus Root Hash Error"},
 	GetBeaconConsensusRootHashError:         {-4001, "Get Beacon Consensus Root Hash Error"},
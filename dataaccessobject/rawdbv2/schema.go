@@ -19,6 +19,10 @@ var (
 	beaconBlockHashToIndexPrefix       = []byte("b-b-H" + string(splitter))
 	txHashPrefix                       = []byte("tx-h" + string(splitter))
 	crossShardNextHeightPrefix         = []byte("c-s-n-h" + string(splitter))
+	crossShardReceiptPrefix            = []byte("c-s-r" + string(splitter))
+	prunedShardBlockPrefix             = []byte("p-s-b-h" + string(splitter))
+	shardPrunedHeightPrefix            = []byte("p-s-h" + string(splitter))
+	keptTxHashPrefix                   = []byte("p-tx-h" + string(splitter))
 	lastBeaconHeightConfirmCrossShard  = []byte("p-c-c-s" + string(splitter))
 	feeEstimatorPrefix                 = []byte("fee-est" + string(splitter))
 	txByPublicKeyPrefix                = []byte("tx-pb")
@@ -179,6 +183,31 @@ func GetCrossShardNextHeightKey(fromShard byte, toShard byte, height uint64) []b
 	return key
 }
 
+func GetCrossShardReceiptKey(fromBlockHash common.Hash) []byte {
+	temp := make([]byte, 0, len(crossShardReceiptPrefix))
+	temp = append(temp, crossShardReceiptPrefix...)
+	return append(temp, fromBlockHash[:]...)
+}
+
+// ============================= Pruning =======================================
+func GetPrunedShardBlockKey(hash common.Hash) []byte {
+	temp := make([]byte, 0, len(prunedShardBlockPrefix))
+	temp = append(temp, prunedShardBlockPrefix...)
+	return append(temp, hash[:]...)
+}
+
+func GetShardPrunedHeightKey(shardID byte) []byte {
+	temp := make([]byte, 0, len(shardPrunedHeightPrefix))
+	temp = append(temp, shardPrunedHeightPrefix...)
+	return append(temp, shardID)
+}
+
+func GetKeptTransactionKey(txHash common.Hash) []byte {
+	temp := make([]byte, 0, len(keptTxHashPrefix))
+	temp = append(temp, keptTxHashPrefix...)
+	return append(temp, txHash[:]...)
+}
+
 // ============================= State Root =======================================
 func GetRootHashPrefix() []byte {
 	temp := make([]byte, 0, len(rootHashPrefix))
@@ -311,6 +311,16 @@ func GetAllCommitteeStakeInfo(stateDB *StateDB, shardIDs []int) map[int][]*Stake
 	return stateDB.getShardsCommitteeInfo(shardIDs)
 }
 
+// GetAllCommitteeStakeInfoWithKey returns base58 committee public keys of shard committees with their staker info,
+// in the same order
+func GetAllCommitteeStakeInfoWithKey(stateDB *StateDB, shardIDs []int) (map[int][]string, map[int][]*StakerInfo, error) {
+	cKeys, cInfos, err := stateDB.getShardsCommitteeInfoWithKey(shardIDs)
+	if err != nil {
+		return nil, nil, NewStatedbError(GetShardCommitteeError, err)
+	}
+	return cKeys, cInfos, nil
+}
+
 func GetMapAutoStaking(bcDB *StateDB, shardIDs []int) map[string]bool {
 	res, err := bcDB.getMapAutoStaking(shardIDs)
 	if err != nil {
@@ -0,0 +1,90 @@
+package statedb
+
+import (
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// StoreDelegation sets amount delegated by delegatorAddress to committeePublicKey, a delegation of zero is removed
+func StoreDelegation(stateDB *StateDB, committeePublicKey string, delegatorAddress string, amount uint64) error {
+	key := GenerateDelegationObjectKey(committeePublicKey, delegatorAddress)
+	if amount == 0 {
+		stateDB.MarkDeleteStateObject(DelegationObjectType, key)
+		return nil
+	}
+	value := NewDelegationStateWithValue(committeePublicKey, delegatorAddress, amount)
+	err := stateDB.SetStateObject(DelegationObjectType, key, value)
+	if err != nil {
+		return NewStatedbError(StoreDelegationError, err)
+	}
+	return nil
+}
+
+func GetDelegation(stateDB *StateDB, committeePublicKey string, delegatorAddress string) (uint64, error) {
+	key := GenerateDelegationObjectKey(committeePublicKey, delegatorAddress)
+	delegationState, has, err := stateDB.getDelegationState(key)
+	if err != nil {
+		return 0, NewStatedbError(GetDelegationError, err)
+	}
+	if !has {
+		return 0, nil
+	}
+	return delegationState.Amount(), nil
+}
+
+// GetDelegationsByValidator returns amount delegated to committeePublicKey by each delegator address
+func GetDelegationsByValidator(stateDB *StateDB, committeePublicKey string) map[string]uint64 {
+	delegations := make(map[string]uint64)
+	for _, delegationState := range stateDB.getAllDelegationStateByValidator(committeePublicKey) {
+		delegations[delegationState.DelegatorAddress()] = delegationState.Amount()
+	}
+	return delegations
+}
+
+// StoreDelegationCommission sets the share of the reward of its delegators kept by committeePublicKey, in percent
+func StoreDelegationCommission(stateDB *StateDB, committeePublicKey string, commissionPercent uint64) error {
+	key := GenerateDelegationCommissionObjectKey(committeePublicKey)
+	value := NewDelegationCommissionStateWithValue(committeePublicKey, commissionPercent)
+	err := stateDB.SetStateObject(DelegationCommissionObjectType, key, value)
+	if err != nil {
+		return NewStatedbError(StoreDelegationCommissionError, err)
+	}
+	return nil
+}
+
+// GetDelegationCommission returns the commission set by committeePublicKey, false if it has not set one
+func GetDelegationCommission(stateDB *StateDB, committeePublicKey string) (uint64, bool, error) {
+	key := GenerateDelegationCommissionObjectKey(committeePublicKey)
+	delegationCommissionState, has, err := stateDB.getDelegationCommissionState(key)
+	if err != nil {
+		return 0, false, NewStatedbError(GetDelegationCommissionError, err)
+	}
+	if !has {
+		return 0, false, nil
+	}
+	return delegationCommissionState.CommissionPercent(), true, nil
+}
+
+func StoreUnbondingDelegation(stateDB *StateDB, unbondingDelegation *UnbondingDelegationState) error {
+	key := GenerateUnbondingDelegationObjectKey(unbondingDelegation.ReleaseEpoch(), unbondingDelegation.TxReqID())
+	err := stateDB.SetStateObject(UnbondingDelegationObjectType, key, unbondingDelegation)
+	if err != nil {
+		return NewStatedbError(StoreUnbondingDelegationError, err)
+	}
+	return nil
+}
+
+func DeleteUnbondingDelegation(stateDB *StateDB, releaseEpoch uint64, txReqID common.Hash) {
+	key := GenerateUnbondingDelegationObjectKey(releaseEpoch, txReqID)
+	stateDB.MarkDeleteStateObject(UnbondingDelegationObjectType, key)
+}
+
+// GetUnbondingDelegationsByReleaseEpoch returns unbonding delegations released at releaseEpoch ordered by request tx
+func GetUnbondingDelegationsByReleaseEpoch(stateDB *StateDB, releaseEpoch uint64) []*UnbondingDelegationState {
+	unbondingDelegations := stateDB.getAllUnbondingDelegationStateByReleaseEpoch(releaseEpoch)
+	sort.Slice(unbondingDelegations, func(i, j int) bool {
+		return unbondingDelegations[i].TxReqID().String() < unbondingDelegations[j].TxReqID().String()
+	})
+	return unbondingDelegations
+}
@@ -0,0 +1,141 @@
+package statedb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func TestStoreAndGetDelegation(t *testing.T) {
+	sDB, err := NewWithPrefixTrie(emptyRoot, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validator1, validator2 := committeePublicKeys[0], committeePublicKeys[1]
+	want := map[string]uint64{
+		incognitoPublicKeys[0]: 100,
+		incognitoPublicKeys[1]: 200,
+	}
+	for delegator, amount := range want {
+		if err := StoreDelegation(sDB, validator1, delegator, amount); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := StoreDelegation(sDB, validator2, incognitoPublicKeys[2], 300); err != nil {
+		t.Fatal(err)
+	}
+	rootHash, err := sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sDB, err = NewWithPrefixTrie(rootHash, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := GetDelegationsByValidator(sDB, validator1); !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %+v but got %+v", want, got)
+	}
+	amount, err := GetDelegation(sDB, validator2, incognitoPublicKeys[2])
+	if err != nil || amount != 300 {
+		t.Fatalf("want 300 but got %+v, error %+v", amount, err)
+	}
+
+	// a delegation of zero is removed
+	if err := StoreDelegation(sDB, validator1, incognitoPublicKeys[0], 0); err != nil {
+		t.Fatal(err)
+	}
+	rootHash, err = sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sDB, err = NewWithPrefixTrie(rootHash, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := GetDelegationsByValidator(sDB, validator1)
+	if len(got) != 1 || got[incognitoPublicKeys[1]] != 200 {
+		t.Fatalf("want only delegation of %+v but got %+v", incognitoPublicKeys[1], got)
+	}
+}
+
+func TestStoreAndDeleteUnbondingDelegation(t *testing.T) {
+	sDB, err := NewWithPrefixTrie(emptyRoot, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	late := NewUnbondingDelegationStateWithValue(common.HashH([]byte("late")), committeePublicKeys[0], incognitoPublicKeys[0], 100, 20)
+	early := NewUnbondingDelegationStateWithValue(common.HashH([]byte("early")), committeePublicKeys[0], incognitoPublicKeys[1], 200, 10)
+	early2 := NewUnbondingDelegationStateWithValue(common.HashH([]byte("early2")), committeePublicKeys[1], incognitoPublicKeys[2], 300, 10)
+	for _, u := range []*UnbondingDelegationState{late, early, early2} {
+		if err := StoreUnbondingDelegation(sDB, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rootHash, err := sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sDB, err = NewWithPrefixTrie(rootHash, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*UnbondingDelegationState{early, early2}
+	if early2.TxReqID().String() < early.TxReqID().String() {
+		want = []*UnbondingDelegationState{early2, early}
+	}
+	if got := GetUnbondingDelegationsByReleaseEpoch(sDB, 10); !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %+v but got %+v", want, got)
+	}
+	if got := GetUnbondingDelegationsByReleaseEpoch(sDB, 20); !reflect.DeepEqual([]*UnbondingDelegationState{late}, got) {
+		t.Fatalf("want %+v but got %+v", []*UnbondingDelegationState{late}, got)
+	}
+	if got := GetUnbondingDelegationsByReleaseEpoch(sDB, 15); len(got) != 0 {
+		t.Fatalf("want no unbonding delegation but got %+v", got)
+	}
+
+	DeleteUnbondingDelegation(sDB, early.ReleaseEpoch(), early.TxReqID())
+	rootHash, err = sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sDB, err = NewWithPrefixTrie(rootHash, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := GetUnbondingDelegationsByReleaseEpoch(sDB, 10)
+	if len(got) != 1 || got[0].TxReqID() != early2.TxReqID() {
+		t.Fatalf("want only %+v but got %+v", early2, got)
+	}
+}
+
+func TestStoreAndGetDelegationCommission(t *testing.T) {
+	sDB, err := NewWithPrefixTrie(emptyRoot, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := StoreDelegationCommission(sDB, committeePublicKeys[0], 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := StoreDelegationCommission(sDB, committeePublicKeys[1], 0); err != nil {
+		t.Fatal(err)
+	}
+	rootHash, err := sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sDB, err = NewWithPrefixTrie(rootHash, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []uint64{5, 0} {
+		commission, has, err := GetDelegationCommission(sDB, committeePublicKeys[i])
+		if err != nil || !has || commission != want {
+			t.Fatalf("want commission %+v but got %+v, %+v, error %+v", want, commission, has, err)
+		}
+	}
+	// a validator which has not set a commission
+	if _, has, err := GetDelegationCommission(sDB, committeePublicKeys[2]); err != nil || has {
+		t.Fatalf("want no commission but got %+v, error %+v", has, err)
+	}
+}
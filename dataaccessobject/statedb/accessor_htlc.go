@@ -0,0 +1,24 @@
+package statedb
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// StoreHTLC sets the htlc of its lock tx, a claimed or refunded htlc is kept with its status to look its preimage up
+func StoreHTLC(stateDB *StateDB, htlc *HTLCState) error {
+	key := GenerateHTLCObjectKey(htlc.LockTxID())
+	err := stateDB.SetStateObject(HTLCObjectType, key, htlc)
+	if err != nil {
+		return NewStatedbError(StoreHTLCError, err)
+	}
+	return nil
+}
+
+func GetHTLC(stateDB *StateDB, lockTxID common.Hash) (*HTLCState, bool, error) {
+	key := GenerateHTLCObjectKey(lockTxID)
+	htlcState, has, err := stateDB.getHTLCState(key)
+	if err != nil {
+		return nil, false, NewStatedbError(GetHTLCError, err)
+	}
+	return htlcState, has, nil
+}
@@ -0,0 +1,61 @@
+package statedb
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func TestStoreAndGetHTLC(t *testing.T) {
+	sDB, err := NewWithPrefixTrie(emptyRoot, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lockTxID := common.HashH([]byte("lock"))
+	hashLock := common.HashH([]byte("preimage")).String()
+	htlc := NewHTLCStateWithValue(lockTxID, incognitoPublicKeys[0], incognitoPublicKeys[1], common.PRVIDStr, 100, hashLock, 50, common.HTLCLockedChainStatus)
+	if err := StoreHTLC(sDB, htlc); err != nil {
+		t.Fatal(err)
+	}
+	rootHash, err := sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sDB, err = NewWithPrefixTrie(rootHash, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, has, err := GetHTLC(sDB, lockTxID)
+	if err != nil || !has {
+		t.Fatalf("want htlc of %+v but got has %+v, error %+v", lockTxID.String(), has, err)
+	}
+	if got.ReceiverAddress() != incognitoPublicKeys[1] || got.Amount() != 100 || got.HashLock() != hashLock ||
+		got.TimeoutBeaconHeight() != 50 || got.Status() != common.HTLCLockedChainStatus {
+		t.Fatalf("want %+v but got %+v", htlc, got)
+	}
+
+	// a claimed htlc keeps its preimage
+	settleTxID := common.HashH([]byte("claim"))
+	got.SetStatus(common.HTLCClaimedChainStatus)
+	got.SetPreimage("707265696d616765")
+	got.SetSettleTxID(settleTxID)
+	if err := StoreHTLC(sDB, got); err != nil {
+		t.Fatal(err)
+	}
+	rootHash, err = sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sDB, err = NewWithPrefixTrie(rootHash, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimed, has, err := GetHTLC(sDB, lockTxID)
+	if err != nil || !has || claimed.Status() != common.HTLCClaimedChainStatus || claimed.Preimage() != "707265696d616765" || claimed.SettleTxID() != settleTxID {
+		t.Fatalf("want claimed htlc but got %+v, has %+v, error %+v", claimed, has, err)
+	}
+
+	if _, has, err := GetHTLC(sDB, common.HashH([]byte("unknown"))); err != nil || has {
+		t.Fatalf("want no htlc but got has %+v, error %+v", has, err)
+	}
+}
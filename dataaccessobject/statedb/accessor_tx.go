@@ -3,6 +3,7 @@ package statedb
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -192,6 +193,55 @@ func GetOutcoinsByPubkey(stateDB *StateDB, tokenID common.Hash, publicKey []byte
 	return o, nil
 }
 
+// StoreStealthOutputCoins - store stealth output coins of shardID after the stored ones, publicKey is their one-time
+// public key
+func StoreStealthOutputCoins(stateDB *StateDB, tokenID common.Hash, publicKey []byte, outputCoins [][]byte, shardID byte) error {
+	if len(outputCoins) == 0 {
+		return nil
+	}
+	lengthKey := GenerateStealthOutputCoinLengthObjectKey(tokenID, shardID)
+	length, _, err := stateDB.getStealthOutputCoinLengthState(lengthKey)
+	if err != nil {
+		return NewStatedbError(GetOutputCoinError, err)
+	}
+	for _, outputCoin := range outputCoins {
+		key := GenerateStealthOutputCoinObjectKey(tokenID, shardID, length)
+		value := NewOutputCoinStateWithValue(tokenID, shardID, publicKey, outputCoin)
+		err := stateDB.SetStateObject(OutputCoinObjectType, key, value)
+		if err != nil {
+			return NewStatedbError(StoreOutputCoinError, err)
+		}
+		length++
+	}
+	err = stateDB.SetStateObject(CommitmentLengthObjectType, lengthKey, new(big.Int).SetUint64(length))
+	if err != nil {
+		return NewStatedbError(StoreOutputCoinError, err)
+	}
+	return nil
+}
+
+// GetStealthOutcoins - get the stealth output coins of shardID from index fromIndex on, and the number of stealth
+// output coins of shardID. A receiver finds its own ones with its receiving key, and scans only the coins stored since
+// its previous scan
+func GetStealthOutcoins(stateDB *StateDB, tokenID common.Hash, shardID byte, fromIndex uint64) ([][]byte, uint64, error) {
+	length, _, err := stateDB.getStealthOutputCoinLengthState(GenerateStealthOutputCoinLengthObjectKey(tokenID, shardID))
+	if err != nil {
+		return nil, 0, NewStatedbError(GetOutputCoinError, err)
+	}
+	o := [][]byte{}
+	for index := fromIndex; index < length; index++ {
+		outputCoinState, has, err := stateDB.getOutputCoinState(GenerateStealthOutputCoinObjectKey(tokenID, shardID, index))
+		if err != nil {
+			return nil, 0, NewStatedbError(GetOutputCoinError, err)
+		}
+		if !has {
+			return nil, 0, NewStatedbError(GetOutputCoinError, fmt.Errorf("no stealth output coin of index %+v", index))
+		}
+		o = append(o, outputCoinState.OutputCoin())
+	}
+	return o, length, nil
+}
+
 // StoreSNDerivators - store list serialNumbers by shardID
 func StoreSNDerivators(stateDB *StateDB, tokenID common.Hash, snds [][]byte) error {
 	for _, snd := range snds {
@@ -506,6 +506,59 @@ func TestStoreAndGetOutputCoin(t *testing.T) {
 	}
 }
 
+func TestStoreAndGetStealthOutputCoin(t *testing.T) {
+	stateDB, err := NewWithPrefixTrie(emptyRoot, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenID := testGenerateTokenIDs(1)[0]
+	publicKeys := testGeneratePublicKeyList(2)
+	outputCoins := testGenerateOutputCoinList(10)
+	// coins of different one-time public keys of a shard are stored under the same prefix
+	if err := StoreStealthOutputCoins(stateDB, tokenID, publicKeys[0], outputCoins[:5], 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := StoreStealthOutputCoins(stateDB, tokenID, publicKeys[1], outputCoins[5:], 0); err != nil {
+		t.Fatal(err)
+	}
+	rootHash, err := stateDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = stateDB.Database().TrieDB().Commit(rootHash, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gotOutputCoins, gotLength, err := GetStealthOutcoins(stateDB, tokenID, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gotOutputCoins) != len(outputCoins) || gotLength != uint64(len(outputCoins)) {
+		t.Fatalf("GetStealthOutcoins() want %v coins, got %v of %v", len(outputCoins), len(gotOutputCoins), gotLength)
+	}
+	// coins are returned in the order they are stored
+	for i, wantOutputCoin := range outputCoins {
+		if bytes.Compare(wantOutputCoin, gotOutputCoins[i]) != 0 {
+			t.Errorf("GetStealthOutcoins() want = %v, got %v", wantOutputCoin, gotOutputCoins[i])
+		}
+	}
+	// a receiver scans the coins stored since its previous scan only
+	gotOutputCoins, gotLength, err = GetStealthOutcoins(stateDB, tokenID, 0, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gotOutputCoins) != 3 || gotLength != uint64(len(outputCoins)) || bytes.Compare(gotOutputCoins[0], outputCoins[7]) != 0 {
+		t.Errorf("GetStealthOutcoins() from index 7 want %v coins, got %v of %v", 3, len(gotOutputCoins), gotLength)
+	}
+	if gotOutputCoins, gotLength, _ := GetStealthOutcoins(stateDB, tokenID, 1, 0); len(gotOutputCoins) != 0 || gotLength != 0 {
+		t.Errorf("GetStealthOutcoins() of shard 1 want nothing, got %v", len(gotOutputCoins))
+	}
+	if gotOutputCoins, _ := GetOutcoinsByPubkey(stateDB, tokenID, publicKeys[0], 0); len(gotOutputCoins) != 0 {
+		t.Errorf("GetOutcoinsByPubkey() want nothing, got %v", len(gotOutputCoins))
+	}
+}
+
 func TestStoreSNDerivators(t *testing.T) {
 	stateDB, err := NewWithPrefixTrie(emptyRoot, wrarperDB)
 	if err != nil {
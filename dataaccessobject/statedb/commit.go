@@ -0,0 +1,56 @@
+package statedb
+
+import (
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// CommitStateDBs - commit the state databases of a block and put their trie nodes in batch, the batch of the block.
+// The tries of the state databases are hashed in parallel, then their nodes are put in the batch one trie database
+// after the other, a trie database is not safe for concurrent commits. Once batch is written, UncacheStateDBs moves
+// the nodes out of the memory of the trie databases. The roots are in the order of stateDBs
+func CommitStateDBs(batch incdb.Batch, stateDBs ...*StateDB) ([]common.Hash, error) {
+	roots := make([]common.Hash, len(stateDBs))
+	errs := make([]error, len(stateDBs))
+	wg := sync.WaitGroup{}
+	for i, stateDB := range stateDBs {
+		wg.Add(1)
+		go func(i int, stateDB *StateDB) {
+			defer wg.Done()
+			roots[i], errs[i] = stateDB.Commit(true)
+		}(i, stateDB)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i, stateDB := range stateDBs {
+		if err := stateDB.Database().TrieDB().CommitToBatch(roots[i], batch); err != nil {
+			return nil, err
+		}
+	}
+	return roots, nil
+}
+
+// UncacheStateDBs - move the nodes of the state databases committed by CommitStateDBs out of the memory of their trie
+// databases, once batch is written, and clear their state objects
+func UncacheStateDBs(batch incdb.Batch, stateDBs ...*StateDB) error {
+	uncached := make(map[*trie.IntermediateWriter]struct{})
+	for _, stateDB := range stateDBs {
+		stateDB.ClearObjects()
+		trieDB := stateDB.Database().TrieDB()
+		if _, ok := uncached[trieDB]; ok {
+			continue
+		}
+		uncached[trieDB] = struct{}{}
+		if err := trieDB.Uncache(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package statedb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// setupCommitStateDBs returns five state databases sharing a trie database, each one with limit objects more than
+// the root they are opened at
+func setupCommitStateDBs(tb testing.TB, limit int, cache *trie.NodeCache) (incdb.Database, []*StateDB) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_commit_statedb_")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	diskDB, err := incdb.Open("leveldb", dbPath)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { os.RemoveAll(dbPath) })
+	SetNodeCache(cache)
+	defer SetNodeCache(nil)
+	warper := NewDatabaseAccessWarper(diskDB)
+	stateDBs := []*StateDB{}
+	for _, prefix := range []string{prefixA, prefixB, prefixC, prefixD, prefixE} {
+		stateDB, err := NewWithPrefixTrie(emptyRoot, warper)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		keys, values := generateKeyValuePairWithPrefix(limit, []byte(prefix))
+		for i := range keys {
+			stateDB.SetStateObject(TestObjectType, keys[i], values[i])
+		}
+		stateDBs = append(stateDBs, stateDB)
+	}
+	return diskDB, stateDBs
+}
+
+func TestCommitStateDBs(t *testing.T) {
+	diskDB, stateDBs := setupCommitStateDBs(t, limit1000, trie.NewNodeCache(16*1024*1024))
+	defer diskDB.Close()
+	wantRoots := []common.Hash{}
+	for _, stateDB := range stateDBs {
+		wantRoots = append(wantRoots, stateDB.IntermediateRoot(true))
+	}
+	batch := diskDB.NewBatch()
+	roots, err := CommitStateDBs(batch, stateDBs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range roots {
+		if roots[i] != wantRoots[i] {
+			t.Errorf("state database %v commits root %v, want %v", i, roots[i].String(), wantRoots[i].String())
+		}
+	}
+	// nothing is on disk before the batch is written
+	if has, _ := diskDB.Has(roots[0][:]); has {
+		t.Errorf("root %v is written before the batch", roots[0].String())
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+	if err := UncacheStateDBs(batch, stateDBs...); err != nil {
+		t.Fatal(err)
+	}
+	if nodes := stateDBs[0].Database().TrieDB().Nodes(); len(nodes) != 0 {
+		t.Errorf("%v nodes are still in memory", len(nodes))
+	}
+	// the state is read back from disk through the cache
+	for i, root := range roots {
+		stateDB, err := NewWithPrefixTrie(root, NewDatabaseAccessWarper(diskDB))
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys, _ := stateDB.getAllTestObjectList()
+		if len(keys) != limit1000 {
+			t.Errorf("state database %v has %v objects, want %v", i, len(keys), limit1000)
+		}
+	}
+}
+
+// benchmarkCommitStateDBs - commit blocks adding objects to a large state, the blocks of the old node commit the state
+// databases one after the other without cache, the blocks of the new node commit them in parallel with a node cache
+func benchmarkCommitStateDBs(b *testing.B, newNode bool) {
+	var cache *trie.NodeCache
+	if newNode {
+		cache = trie.NewNodeCache(64 * 1024 * 1024)
+	}
+	diskDB, stateDBs := setupCommitStateDBs(b, limit10000, cache)
+	defer diskDB.Close()
+	batch := diskDB.NewBatch()
+	if _, err := CommitStateDBs(batch, stateDBs...); err != nil {
+		b.Fatal(err)
+	}
+	if err := batch.Write(); err != nil {
+		b.Fatal(err)
+	}
+	if err := UncacheStateDBs(batch, stateDBs...); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		for i, prefix := range []string{prefixA, prefixB, prefixC, prefixD, prefixE} {
+			keys, values := generateKeyValuePairWithPrefix(limit100, []byte(prefix))
+			for j := range keys {
+				stateDBs[i].SetStateObject(TestObjectType, keys[j], values[j])
+			}
+		}
+		b.StartTimer()
+		batch := diskDB.NewBatch()
+		if newNode {
+			if _, err := CommitStateDBs(batch, stateDBs...); err != nil {
+				b.Fatal(err)
+			}
+			if err := batch.Write(); err != nil {
+				b.Fatal(err)
+			}
+			if err := UncacheStateDBs(batch, stateDBs...); err != nil {
+				b.Fatal(err)
+			}
+			continue
+		}
+		for _, stateDB := range stateDBs {
+			root, err := stateDB.Commit(true)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := stateDB.Database().TrieDB().Commit(root, false); err != nil {
+				b.Fatal(err)
+			}
+			stateDB.ClearObjects()
+		}
+	}
+}
+
+func BenchmarkCommitStateDBs_OneAfterTheOther(b *testing.B) {
+	benchmarkCommitStateDBs(b, false)
+}
+
+func BenchmarkCommitStateDBs_ParallelWithCache(b *testing.B) {
+	benchmarkCommitStateDBs(b, true)
+}
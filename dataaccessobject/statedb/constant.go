@@ -49,6 +49,14 @@ const (
 	PDETradingFeeObjectType
 
 	StakerObjectType
+
+	// delegation
+	DelegationObjectType
+	UnbondingDelegationObjectType
+	DelegationCommissionObjectType
+
+	// htlc
+	HTLCObjectType
 )
 
 // Prefix length
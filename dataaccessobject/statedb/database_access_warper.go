@@ -68,8 +68,16 @@ type accessorWarper struct {
 	iw *trie.IntermediateWriter
 }
 
+// nodeCache - the cache of the trie nodes shared by the state databases, nil for none
+var nodeCache *trie.NodeCache
+
+// SetNodeCache - share cache between the state databases opened from now on, it is set once when the node starts
+func SetNodeCache(cache *trie.NodeCache) {
+	nodeCache = cache
+}
+
 func NewDatabaseAccessWarper(database incdb.Database) DatabaseAccessWarper {
-	return &accessorWarper{iw: trie.NewIntermediateWriter(database)}
+	return &accessorWarper{iw: trie.NewIntermediateWriterWithCache(database, nodeCache)}
 }
 
 // OpenTrie opens the main account trie at a specific root hash.
@@ -44,6 +44,10 @@ const (
 	ErrInvalidRewardFeatureStateType          = "invalid feature reward state type"
 	ErrInvalidPDETradingFeeStateType          = "invalid pde trading fee state type"
 	ErrInvalidBlockHashType                   = "invalid block hash type"
+	ErrInvalidDelegationStateType             = "invalid delegation state type"
+	ErrInvalidUnbondingDelegationStateType    = "invalid unbonding delegation state type"
+	ErrInvalidDelegationCommissionStateType   = "invalid delegation commission state type"
+	ErrInvalidHTLCStateType                   = "invalid htlc state type"
 )
 const (
 	InvalidByteArrayTypeError = iota
@@ -210,6 +214,17 @@ const (
 	StorePDETradingFeeError
 	
 	InvalidStakerInfoTypeError
+
+	// delegation
+	StoreDelegationError
+	GetDelegationError
+	StoreUnbondingDelegationError
+	StoreDelegationCommissionError
+	GetDelegationCommissionError
+
+	// htlc
+	StoreHTLCError
+	GetHTLCError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -338,6 +353,15 @@ var ErrCodeMessage = map[int]struct {
 	ResetAllFeatureRewardByTokenIDError:  {-15003, "Reset all reward feature state by tokenID error"},
 	GetRewardFeatureAmountByTokenIDError: {-15004, "Get reward feature amount by tokenID error"},
 	InvalidStakerInfoTypeError:           {-15005, "Staker info invalid"},
+
+	StoreDelegationError:           {-16000, "Store delegation error"},
+	GetDelegationError:             {-16001, "Get delegation error"},
+	StoreUnbondingDelegationError:  {-16002, "Store unbonding delegation error"},
+	StoreDelegationCommissionError: {-16003, "Store delegation commission error"},
+	GetDelegationCommissionError:   {-16004, "Get delegation commission error"},
+
+	StoreHTLCError: {-17000, "Store htlc error"},
+	GetHTLCError:   {-17001, "Get htlc error"},
 }
 
 type StatedbError struct {
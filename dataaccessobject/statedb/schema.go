@@ -26,6 +26,8 @@ var (
 	commitmentLengthPrefix             = []byte("com-length-")
 	snDerivatorPrefix                  = []byte("sn-derivator-")
 	outputCoinPrefix                   = []byte("output-coin-")
+	stealthOutputCoinPrefix            = []byte("stealth-output-coin-")
+	stealthOutputCoinLengthPrefix      = []byte("stealth-output-coin-length-")
 	tokenPrefix                        = []byte("token-")
 	tokenTransactionPrefix             = []byte("token-transaction-")
 	waitingPDEContributionPrefix       = []byte("waitingpdecontribution-")
@@ -81,6 +83,14 @@ var (
 	rewardFeatureStatePrefix = []byte("rewardfeaturestate-")
 	// feature names
 	PortalRewardName = "portal"
+
+	// delegation
+	delegationPrefix           = []byte("delegation-")
+	unbondingDelegationPrefix  = []byte("unbonding-delegation-")
+	delegationCommissionPrefix = []byte("delegation-commission-")
+
+	// htlc
+	htlcPrefix = []byte("htlc-")
 )
 
 func GetCommitteePrefixWithRole(role int, shardID int) []byte {
@@ -176,6 +186,18 @@ func GetOutputCoinPrefix(tokenID common.Hash, shardID byte, publicKey []byte) []
 	return h[:][:prefixHashKeyLength]
 }
 
+// GetStealthOutputCoinPrefix - stealth output coins do not show the public key of their receiver, those of a token in a
+// shard are stored under the same prefix by their index
+func GetStealthOutputCoinPrefix(tokenID common.Hash, shardID byte) []byte {
+	h := common.HashH(append(stealthOutputCoinPrefix, append(tokenID[:], shardID)...))
+	return h[:][:prefixHashKeyLength]
+}
+
+func GetStealthOutputCoinLengthPrefix() []byte {
+	h := common.HashH(stealthOutputCoinLengthPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetTokenPrefix() []byte {
 	h := common.HashH(tokenPrefix)
 	return h[:][:prefixHashKeyLength]
@@ -359,6 +381,26 @@ func GetPortalRewardInfoStatePrefix(beaconHeight uint64) []byte {
 	return h[:][:prefixHashKeyLength]
 }
 
+func GetDelegationPrefix(committeePublicKey string) []byte {
+	h := common.HashH(append(delegationPrefix, []byte(committeePublicKey)...))
+	return h[:][:prefixHashKeyLength]
+}
+
+func GetUnbondingDelegationPrefix(releaseEpoch uint64) []byte {
+	h := common.HashH(append(unbondingDelegationPrefix, []byte(fmt.Sprintf("%d-", releaseEpoch))...))
+	return h[:][:prefixHashKeyLength]
+}
+
+func GetHTLCPrefix() []byte {
+	h := common.HashH(htlcPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
+func GetDelegationCommissionPrefix() []byte {
+	h := common.HashH(delegationCommissionPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetPortalStatusPrefix() []byte {
 	h := common.HashH(portalStatusPrefix)
 	return h[:][:prefixHashKeyLength]
@@ -719,7 +719,16 @@ func (stateDB *StateDB) getShardsCommitteeState(sIDs []int) (currentValidator ma
 }
 
 func (stateDB *StateDB) getShardsCommitteeInfo(sIDs []int) (curValidatorInfo map[int][]*StakerInfo) {
+	_, curValidatorInfo, err := stateDB.getShardsCommitteeInfoWithKey(sIDs)
+	if err != nil {
+		panic(err)
+	}
+	return curValidatorInfo
+}
+
+func (stateDB *StateDB) getShardsCommitteeInfoWithKey(sIDs []int) (curValidatorKey map[int][]string, curValidatorInfo map[int][]*StakerInfo, err error) {
 	currentValidator := make(map[int][]*CommitteeState)
+	curValidatorKey = make(map[int][]string)
 	curValidatorInfo = make(map[int][]*StakerInfo)
 	for _, shardID := range sIDs {
 		// Current Validator
@@ -730,21 +739,28 @@ func (stateDB *StateDB) getShardsCommitteeInfo(sIDs []int) (curValidatorInfo map
 			tempCurrentValidator = append(tempCurrentValidator, v)
 		}
 		currentValidator[shardID] = tempCurrentValidator
+		tempStakerKeys := []string{}
 		tempStakerInfos := []*StakerInfo{}
 		for _, c := range currentValidator[shardID] {
+			cPKStr, err := c.committeePublicKey.ToBase58()
+			if err != nil {
+				return nil, nil, err
+			}
 			cPKBytes, _ := c.committeePublicKey.RawBytes()
 			s, has, err := stateDB.getStakerInfo(GetStakerInfoKey(cPKBytes))
 			if err != nil {
-				panic(err)
+				return nil, nil, err
 			}
 			if !has || s == nil {
-				panic(errors.Errorf("Can not found staker info for this committee %v", c.committeePublicKey))
+				return nil, nil, errors.Errorf("Can not found staker info for this committee %v", c.committeePublicKey)
 			}
+			tempStakerKeys = append(tempStakerKeys, cPKStr)
 			tempStakerInfos = append(tempStakerInfos, s)
 		}
+		curValidatorKey[shardID] = tempStakerKeys
 		curValidatorInfo[shardID] = tempStakerInfos
 	}
-	return curValidatorInfo
+	return curValidatorKey, curValidatorInfo, nil
 }
 
 func (beaconConsensusStateDB *StateDB) GetAllStakingTX(ids []int) (map[string]string, error) {
@@ -1104,7 +1120,19 @@ func (stateDB *StateDB) getOutputCoinState(key common.Hash) (*OutputCoinState, b
 }
 
 func (stateDB *StateDB) getAllOutputCoinState(tokenID common.Hash, shardID byte, publicKey []byte) []*OutputCoinState {
-	temp := stateDB.trie.NodeIterator(GetOutputCoinPrefix(tokenID, shardID, publicKey))
+	return stateDB.getAllOutputCoinStateByPrefix(GetOutputCoinPrefix(tokenID, shardID, publicKey))
+}
+
+func (stateDB *StateDB) getStealthOutputCoinLengthState(key common.Hash) (uint64, bool, error) {
+	length, has, err := stateDB.getCommitmentLengthState(key)
+	if err != nil || !has {
+		return 0, has, err
+	}
+	return length.Uint64(), true, nil
+}
+
+func (stateDB *StateDB) getAllOutputCoinStateByPrefix(prefix []byte) []*OutputCoinState {
+	temp := stateDB.trie.NodeIterator(prefix)
 	it := trie.NewIterator(temp)
 	outputCoins := []*OutputCoinState{}
 	for it.Next() {
@@ -1624,3 +1652,74 @@ func (stateDB *StateDB) getAllFeatureRewards(epoch uint64) (*RewardFeatureState,
 	}
 	return result, true, nil
 }
+
+// ================================= Delegation OBJECT =======================================
+func (stateDB *StateDB) getDelegationState(key common.Hash) (*DelegationState, bool, error) {
+	delegationState, err := stateDB.getStateObject(DelegationObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if delegationState != nil {
+		return delegationState.GetValue().(*DelegationState), true, nil
+	}
+	return NewDelegationState(), false, nil
+}
+
+func (stateDB *StateDB) getDelegationCommissionState(key common.Hash) (*DelegationCommissionState, bool, error) {
+	delegationCommissionState, err := stateDB.getStateObject(DelegationCommissionObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if delegationCommissionState != nil {
+		return delegationCommissionState.GetValue().(*DelegationCommissionState), true, nil
+	}
+	return NewDelegationCommissionState(), false, nil
+}
+
+func (stateDB *StateDB) getAllDelegationStateByValidator(committeePublicKey string) []*DelegationState {
+	delegationStates := []*DelegationState{}
+	temp := stateDB.trie.NodeIterator(GetDelegationPrefix(committeePublicKey))
+	it := trie.NewIterator(temp)
+	for it.Next() {
+		value := it.Value
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		d := NewDelegationState()
+		err := json.Unmarshal(newValue, d)
+		if err != nil {
+			panic("wrong expect type")
+		}
+		delegationStates = append(delegationStates, d)
+	}
+	return delegationStates
+}
+
+func (stateDB *StateDB) getAllUnbondingDelegationStateByReleaseEpoch(releaseEpoch uint64) []*UnbondingDelegationState {
+	unbondingDelegationStates := []*UnbondingDelegationState{}
+	temp := stateDB.trie.NodeIterator(GetUnbondingDelegationPrefix(releaseEpoch))
+	it := trie.NewIterator(temp)
+	for it.Next() {
+		value := it.Value
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		u := NewUnbondingDelegationState()
+		err := json.Unmarshal(newValue, u)
+		if err != nil {
+			panic("wrong expect type")
+		}
+		unbondingDelegationStates = append(unbondingDelegationStates, u)
+	}
+	return unbondingDelegationStates
+}
+
+// ================================= HTLC OBJECT =======================================
+func (stateDB *StateDB) getHTLCState(key common.Hash) (*HTLCState, bool, error) {
+	htlcState, err := stateDB.getStateObject(HTLCObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if htlcState != nil {
+		return htlcState.GetValue().(*HTLCState), true, nil
+	}
+	return NewHTLCState(), false, nil
+}
@@ -85,6 +85,14 @@ func newStateObjectWithValue(db *StateDB, objectType int, hash common.Hash, valu
 		return newRewardFeatureStateObjectWithValue(db, hash, value)
 	case StakerObjectType:
 		return newStakerObjectWithValue(db, hash, value)
+	case DelegationObjectType:
+		return newDelegationObjectWithValue(db, hash, value)
+	case UnbondingDelegationObjectType:
+		return newUnbondingDelegationObjectWithValue(db, hash, value)
+	case DelegationCommissionObjectType:
+		return newDelegationCommissionObjectWithValue(db, hash, value)
+	case HTLCObjectType:
+		return newHTLCObjectWithValue(db, hash, value)
 	default:
 		panic("state object type not exist")
 	}
@@ -150,6 +158,14 @@ func newStateObject(db *StateDB, objectType int, hash common.Hash) StateObject {
 		return newRewardFeatureStateObject(db, hash)
 	case StakerObjectType:
 		return newStakerObject(db, hash)
+	case DelegationObjectType:
+		return newDelegationObject(db, hash)
+	case UnbondingDelegationObjectType:
+		return newUnbondingDelegationObject(db, hash)
+	case DelegationCommissionObjectType:
+		return newDelegationCommissionObject(db, hash)
+	case HTLCObjectType:
+		return newHTLCObject(db, hash)
 	default:
 		panic("state object type not exist")
 	}
@@ -0,0 +1,211 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+type DelegationState struct {
+	committeePublicKey string
+	delegatorAddress   string
+	amount             uint64
+}
+
+func (s DelegationState) CommitteePublicKey() string {
+	return s.committeePublicKey
+}
+
+func (s *DelegationState) SetCommitteePublicKey(committeePublicKey string) {
+	s.committeePublicKey = committeePublicKey
+}
+
+func (s DelegationState) DelegatorAddress() string {
+	return s.delegatorAddress
+}
+
+func (s *DelegationState) SetDelegatorAddress(delegatorAddress string) {
+	s.delegatorAddress = delegatorAddress
+}
+
+func (s DelegationState) Amount() uint64 {
+	return s.amount
+}
+
+func (s *DelegationState) SetAmount(amount uint64) {
+	s.amount = amount
+}
+
+func (s DelegationState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		CommitteePublicKey string
+		DelegatorAddress   string
+		Amount             uint64
+	}{
+		CommitteePublicKey: s.committeePublicKey,
+		DelegatorAddress:   s.delegatorAddress,
+		Amount:             s.amount,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (s *DelegationState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		CommitteePublicKey string
+		DelegatorAddress   string
+		Amount             uint64
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	s.committeePublicKey = temp.CommitteePublicKey
+	s.delegatorAddress = temp.DelegatorAddress
+	s.amount = temp.Amount
+	return nil
+}
+
+func NewDelegationState() *DelegationState {
+	return &DelegationState{}
+}
+
+func NewDelegationStateWithValue(committeePublicKey string, delegatorAddress string, amount uint64) *DelegationState {
+	return &DelegationState{committeePublicKey: committeePublicKey, delegatorAddress: delegatorAddress, amount: amount}
+}
+
+type DelegationObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version         int
+	delegationHash  common.Hash
+	delegationState *DelegationState
+	objectType      int
+	deleted         bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newDelegationObject(db *StateDB, hash common.Hash) *DelegationObject {
+	return &DelegationObject{
+		version:         defaultVersion,
+		db:              db,
+		delegationHash:  hash,
+		delegationState: NewDelegationState(),
+		objectType:      DelegationObjectType,
+		deleted:         false,
+	}
+}
+
+func newDelegationObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*DelegationObject, error) {
+	var newDelegationState = NewDelegationState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newDelegationState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newDelegationState, ok = data.(*DelegationState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidDelegationStateType, reflect.TypeOf(data))
+		}
+	}
+	return &DelegationObject{
+		version:         defaultVersion,
+		delegationHash:  key,
+		delegationState: newDelegationState,
+		db:              db,
+		objectType:      DelegationObjectType,
+		deleted:         false,
+	}, nil
+}
+
+// GenerateDelegationObjectKey keys delegations under a prefix of their validator, so delegations of one validator
+// can be iterated without reading the others
+func GenerateDelegationObjectKey(committeePublicKey string, delegatorAddress string) common.Hash {
+	prefixHash := GetDelegationPrefix(committeePublicKey)
+	valueHash := common.HashH([]byte(delegatorAddress))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t DelegationObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *DelegationObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t DelegationObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *DelegationObject) SetValue(data interface{}) error {
+	newDelegationState, ok := data.(*DelegationState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidDelegationStateType, reflect.TypeOf(data))
+	}
+	t.delegationState = newDelegationState
+	return nil
+}
+
+func (t DelegationObject) GetValue() interface{} {
+	return t.delegationState
+}
+
+func (t DelegationObject) GetValueBytes() []byte {
+	delegationState, ok := t.GetValue().(*DelegationState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(delegationState)
+	if err != nil {
+		panic("failed to marshal delegation state")
+	}
+	return value
+}
+
+func (t DelegationObject) GetHash() common.Hash {
+	return t.delegationHash
+}
+
+func (t DelegationObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *DelegationObject) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all delegation value into default value
+func (t *DelegationObject) Reset() bool {
+	t.delegationState = NewDelegationState()
+	return true
+}
+
+func (t DelegationObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t DelegationObject) IsEmpty() bool {
+	temp := NewDelegationState()
+	return reflect.DeepEqual(temp, t.delegationState) || t.delegationState == nil
+}
@@ -0,0 +1,197 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// DelegationCommissionState is the share of the reward of its delegators kept by a validator, in percent
+type DelegationCommissionState struct {
+	committeePublicKey string
+	commissionPercent  uint64
+}
+
+func (s DelegationCommissionState) CommitteePublicKey() string {
+	return s.committeePublicKey
+}
+
+func (s *DelegationCommissionState) SetCommitteePublicKey(committeePublicKey string) {
+	s.committeePublicKey = committeePublicKey
+}
+
+func (s DelegationCommissionState) CommissionPercent() uint64 {
+	return s.commissionPercent
+}
+
+func (s *DelegationCommissionState) SetCommissionPercent(commissionPercent uint64) {
+	s.commissionPercent = commissionPercent
+}
+
+func (s DelegationCommissionState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		CommitteePublicKey string
+		CommissionPercent  uint64
+	}{
+		CommitteePublicKey: s.committeePublicKey,
+		CommissionPercent:  s.commissionPercent,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (s *DelegationCommissionState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		CommitteePublicKey string
+		CommissionPercent  uint64
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	s.committeePublicKey = temp.CommitteePublicKey
+	s.commissionPercent = temp.CommissionPercent
+	return nil
+}
+
+func NewDelegationCommissionState() *DelegationCommissionState {
+	return &DelegationCommissionState{}
+}
+
+func NewDelegationCommissionStateWithValue(committeePublicKey string, commissionPercent uint64) *DelegationCommissionState {
+	return &DelegationCommissionState{committeePublicKey: committeePublicKey, commissionPercent: commissionPercent}
+}
+
+type DelegationCommissionObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version                   int
+	delegationCommissionHash  common.Hash
+	delegationCommissionState *DelegationCommissionState
+	objectType                int
+	deleted                   bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newDelegationCommissionObject(db *StateDB, hash common.Hash) *DelegationCommissionObject {
+	return &DelegationCommissionObject{
+		version:                   defaultVersion,
+		db:                        db,
+		delegationCommissionHash:  hash,
+		delegationCommissionState: NewDelegationCommissionState(),
+		objectType:                DelegationCommissionObjectType,
+		deleted:                   false,
+	}
+}
+
+func newDelegationCommissionObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*DelegationCommissionObject, error) {
+	var newDelegationCommissionState = NewDelegationCommissionState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newDelegationCommissionState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newDelegationCommissionState, ok = data.(*DelegationCommissionState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidDelegationCommissionStateType, reflect.TypeOf(data))
+		}
+	}
+	return &DelegationCommissionObject{
+		version:                   defaultVersion,
+		delegationCommissionHash:  key,
+		delegationCommissionState: newDelegationCommissionState,
+		db:                        db,
+		objectType:                DelegationCommissionObjectType,
+		deleted:                   false,
+	}, nil
+}
+
+func GenerateDelegationCommissionObjectKey(committeePublicKey string) common.Hash {
+	prefixHash := GetDelegationCommissionPrefix()
+	valueHash := common.HashH([]byte(committeePublicKey))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t DelegationCommissionObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *DelegationCommissionObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t DelegationCommissionObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *DelegationCommissionObject) SetValue(data interface{}) error {
+	newDelegationCommissionState, ok := data.(*DelegationCommissionState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidDelegationCommissionStateType, reflect.TypeOf(data))
+	}
+	t.delegationCommissionState = newDelegationCommissionState
+	return nil
+}
+
+func (t DelegationCommissionObject) GetValue() interface{} {
+	return t.delegationCommissionState
+}
+
+func (t DelegationCommissionObject) GetValueBytes() []byte {
+	delegationCommissionState, ok := t.GetValue().(*DelegationCommissionState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(delegationCommissionState)
+	if err != nil {
+		panic("failed to marshal delegation commission state")
+	}
+	return value
+}
+
+func (t DelegationCommissionObject) GetHash() common.Hash {
+	return t.delegationCommissionHash
+}
+
+func (t DelegationCommissionObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *DelegationCommissionObject) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all delegation commission value into default value
+func (t *DelegationCommissionObject) Reset() bool {
+	t.delegationCommissionState = NewDelegationCommissionState()
+	return true
+}
+
+func (t DelegationCommissionObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t DelegationCommissionObject) IsEmpty() bool {
+	temp := NewDelegationCommissionState()
+	return reflect.DeepEqual(temp, t.delegationCommissionState) || t.delegationCommissionState == nil
+}
@@ -0,0 +1,320 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// HTLCState - amount of PRV or a pToken locked by a lock request tx until its receiver claims it with the preimage
+// of hashLock or its sender refunds it from timeoutBeaconHeight on
+type HTLCState struct {
+	lockTxID            common.Hash
+	senderAddress       string
+	receiverAddress     string
+	tokenID             string
+	amount              uint64
+	hashLock            string
+	timeoutBeaconHeight uint64
+	status              string
+	preimage            string
+	settleTxID          common.Hash
+}
+
+func (s HTLCState) LockTxID() common.Hash {
+	return s.lockTxID
+}
+
+func (s *HTLCState) SetLockTxID(lockTxID common.Hash) {
+	s.lockTxID = lockTxID
+}
+
+func (s HTLCState) SenderAddress() string {
+	return s.senderAddress
+}
+
+func (s *HTLCState) SetSenderAddress(senderAddress string) {
+	s.senderAddress = senderAddress
+}
+
+func (s HTLCState) ReceiverAddress() string {
+	return s.receiverAddress
+}
+
+func (s *HTLCState) SetReceiverAddress(receiverAddress string) {
+	s.receiverAddress = receiverAddress
+}
+
+func (s HTLCState) TokenID() string {
+	return s.tokenID
+}
+
+func (s *HTLCState) SetTokenID(tokenID string) {
+	s.tokenID = tokenID
+}
+
+func (s HTLCState) Amount() uint64 {
+	return s.amount
+}
+
+func (s *HTLCState) SetAmount(amount uint64) {
+	s.amount = amount
+}
+
+func (s HTLCState) HashLock() string {
+	return s.hashLock
+}
+
+func (s *HTLCState) SetHashLock(hashLock string) {
+	s.hashLock = hashLock
+}
+
+func (s HTLCState) TimeoutBeaconHeight() uint64 {
+	return s.timeoutBeaconHeight
+}
+
+func (s *HTLCState) SetTimeoutBeaconHeight(timeoutBeaconHeight uint64) {
+	s.timeoutBeaconHeight = timeoutBeaconHeight
+}
+
+func (s HTLCState) Status() string {
+	return s.status
+}
+
+func (s *HTLCState) SetStatus(status string) {
+	s.status = status
+}
+
+func (s HTLCState) Preimage() string {
+	return s.preimage
+}
+
+func (s *HTLCState) SetPreimage(preimage string) {
+	s.preimage = preimage
+}
+
+func (s HTLCState) SettleTxID() common.Hash {
+	return s.settleTxID
+}
+
+func (s *HTLCState) SetSettleTxID(settleTxID common.Hash) {
+	s.settleTxID = settleTxID
+}
+
+func (s HTLCState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		LockTxID            common.Hash
+		SenderAddress       string
+		ReceiverAddress     string
+		TokenID             string
+		Amount              uint64
+		HashLock            string
+		TimeoutBeaconHeight uint64
+		Status              string
+		Preimage            string
+		SettleTxID          common.Hash
+	}{
+		LockTxID:            s.lockTxID,
+		SenderAddress:       s.senderAddress,
+		ReceiverAddress:     s.receiverAddress,
+		TokenID:             s.tokenID,
+		Amount:              s.amount,
+		HashLock:            s.hashLock,
+		TimeoutBeaconHeight: s.timeoutBeaconHeight,
+		Status:              s.status,
+		Preimage:            s.preimage,
+		SettleTxID:          s.settleTxID,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (s *HTLCState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		LockTxID            common.Hash
+		SenderAddress       string
+		ReceiverAddress     string
+		TokenID             string
+		Amount              uint64
+		HashLock            string
+		TimeoutBeaconHeight uint64
+		Status              string
+		Preimage            string
+		SettleTxID          common.Hash
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	s.lockTxID = temp.LockTxID
+	s.senderAddress = temp.SenderAddress
+	s.receiverAddress = temp.ReceiverAddress
+	s.tokenID = temp.TokenID
+	s.amount = temp.Amount
+	s.hashLock = temp.HashLock
+	s.timeoutBeaconHeight = temp.TimeoutBeaconHeight
+	s.status = temp.Status
+	s.preimage = temp.Preimage
+	s.settleTxID = temp.SettleTxID
+	return nil
+}
+
+func NewHTLCState() *HTLCState {
+	return &HTLCState{}
+}
+
+func NewHTLCStateWithValue(
+	lockTxID common.Hash,
+	senderAddress string,
+	receiverAddress string,
+	tokenID string,
+	amount uint64,
+	hashLock string,
+	timeoutBeaconHeight uint64,
+	status string,
+) *HTLCState {
+	return &HTLCState{
+		lockTxID:            lockTxID,
+		senderAddress:       senderAddress,
+		receiverAddress:     receiverAddress,
+		tokenID:             tokenID,
+		amount:              amount,
+		hashLock:            hashLock,
+		timeoutBeaconHeight: timeoutBeaconHeight,
+		status:              status,
+	}
+}
+
+type HTLCObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version    int
+	htlcHash   common.Hash
+	htlcState  *HTLCState
+	objectType int
+	deleted    bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newHTLCObject(db *StateDB, hash common.Hash) *HTLCObject {
+	return &HTLCObject{
+		version:    defaultVersion,
+		db:         db,
+		htlcHash:   hash,
+		htlcState:  NewHTLCState(),
+		objectType: HTLCObjectType,
+		deleted:    false,
+	}
+}
+
+func newHTLCObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*HTLCObject, error) {
+	var newHTLCState = NewHTLCState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newHTLCState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newHTLCState, ok = data.(*HTLCState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidHTLCStateType, reflect.TypeOf(data))
+		}
+	}
+	return &HTLCObject{
+		version:    defaultVersion,
+		htlcHash:   key,
+		htlcState:  newHTLCState,
+		db:         db,
+		objectType: HTLCObjectType,
+		deleted:    false,
+	}, nil
+}
+
+func GenerateHTLCObjectKey(lockTxID common.Hash) common.Hash {
+	prefixHash := GetHTLCPrefix()
+	valueHash := common.HashH(lockTxID[:])
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t HTLCObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *HTLCObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t HTLCObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *HTLCObject) SetValue(data interface{}) error {
+	newHTLCState, ok := data.(*HTLCState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidHTLCStateType, reflect.TypeOf(data))
+	}
+	t.htlcState = newHTLCState
+	return nil
+}
+
+func (t HTLCObject) GetValue() interface{} {
+	return t.htlcState
+}
+
+func (t HTLCObject) GetValueBytes() []byte {
+	htlcState, ok := t.GetValue().(*HTLCState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(htlcState)
+	if err != nil {
+		panic("failed to marshal htlc state")
+	}
+	return value
+}
+
+func (t HTLCObject) GetHash() common.Hash {
+	return t.htlcHash
+}
+
+func (t HTLCObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *HTLCObject) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all htlc value into default value
+func (t *HTLCObject) Reset() bool {
+	t.htlcState = NewHTLCState()
+	return true
+}
+
+func (t HTLCObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t HTLCObject) IsEmpty() bool {
+	temp := NewHTLCState()
+	return reflect.DeepEqual(temp, t.htlcState) || t.htlcState == nil
+}
@@ -151,6 +151,22 @@ func GenerateOutputCoinObjectKey(tokenID common.Hash, shardID byte, publicKey []
 	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
 }
 
+// GenerateStealthOutputCoinObjectKey - key of the stealth output coin of index in the order stealth output coins of
+// tokenID in shardID are stored
+func GenerateStealthOutputCoinObjectKey(tokenID common.Hash, shardID byte, index uint64) common.Hash {
+	prefixHash := GetStealthOutputCoinPrefix(tokenID, shardID)
+	valueHash := common.HashH(common.Uint64ToBytes(index))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+// GenerateStealthOutputCoinLengthObjectKey - key of the number of stealth output coins of tokenID in shardID, stored as
+// a length object like the number of commitments
+func GenerateStealthOutputCoinLengthObjectKey(tokenID common.Hash, shardID byte) common.Hash {
+	prefixHash := GetStealthOutputCoinLengthPrefix()
+	valueHash := common.HashH(append(tokenID[:], shardID))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
 func (s OutputCoinObject) GetVersion() int {
 	return s.version
 }
@@ -0,0 +1,249 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+type UnbondingDelegationState struct {
+	txReqID            common.Hash
+	committeePublicKey string
+	delegatorAddress   string
+	amount             uint64
+	releaseEpoch       uint64
+}
+
+func (s UnbondingDelegationState) TxReqID() common.Hash {
+	return s.txReqID
+}
+
+func (s *UnbondingDelegationState) SetTxReqID(txReqID common.Hash) {
+	s.txReqID = txReqID
+}
+
+func (s UnbondingDelegationState) CommitteePublicKey() string {
+	return s.committeePublicKey
+}
+
+func (s *UnbondingDelegationState) SetCommitteePublicKey(committeePublicKey string) {
+	s.committeePublicKey = committeePublicKey
+}
+
+func (s UnbondingDelegationState) DelegatorAddress() string {
+	return s.delegatorAddress
+}
+
+func (s *UnbondingDelegationState) SetDelegatorAddress(delegatorAddress string) {
+	s.delegatorAddress = delegatorAddress
+}
+
+func (s UnbondingDelegationState) Amount() uint64 {
+	return s.amount
+}
+
+func (s *UnbondingDelegationState) SetAmount(amount uint64) {
+	s.amount = amount
+}
+
+func (s UnbondingDelegationState) ReleaseEpoch() uint64 {
+	return s.releaseEpoch
+}
+
+func (s *UnbondingDelegationState) SetReleaseEpoch(releaseEpoch uint64) {
+	s.releaseEpoch = releaseEpoch
+}
+
+func (s UnbondingDelegationState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		TxReqID            common.Hash
+		CommitteePublicKey string
+		DelegatorAddress   string
+		Amount             uint64
+		ReleaseEpoch       uint64
+	}{
+		TxReqID:            s.txReqID,
+		CommitteePublicKey: s.committeePublicKey,
+		DelegatorAddress:   s.delegatorAddress,
+		Amount:             s.amount,
+		ReleaseEpoch:       s.releaseEpoch,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (s *UnbondingDelegationState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		TxReqID            common.Hash
+		CommitteePublicKey string
+		DelegatorAddress   string
+		Amount             uint64
+		ReleaseEpoch       uint64
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	s.txReqID = temp.TxReqID
+	s.committeePublicKey = temp.CommitteePublicKey
+	s.delegatorAddress = temp.DelegatorAddress
+	s.amount = temp.Amount
+	s.releaseEpoch = temp.ReleaseEpoch
+	return nil
+}
+
+func NewUnbondingDelegationState() *UnbondingDelegationState {
+	return &UnbondingDelegationState{}
+}
+
+func NewUnbondingDelegationStateWithValue(
+	txReqID common.Hash,
+	committeePublicKey string,
+	delegatorAddress string,
+	amount uint64,
+	releaseEpoch uint64,
+) *UnbondingDelegationState {
+	return &UnbondingDelegationState{
+		txReqID:            txReqID,
+		committeePublicKey: committeePublicKey,
+		delegatorAddress:   delegatorAddress,
+		amount:             amount,
+		releaseEpoch:       releaseEpoch,
+	}
+}
+
+type UnbondingDelegationObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version                  int
+	unbondingDelegationHash  common.Hash
+	unbondingDelegationState *UnbondingDelegationState
+	objectType               int
+	deleted                  bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newUnbondingDelegationObject(db *StateDB, hash common.Hash) *UnbondingDelegationObject {
+	return &UnbondingDelegationObject{
+		version:                  defaultVersion,
+		db:                       db,
+		unbondingDelegationHash:  hash,
+		unbondingDelegationState: NewUnbondingDelegationState(),
+		objectType:               UnbondingDelegationObjectType,
+		deleted:                  false,
+	}
+}
+
+func newUnbondingDelegationObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*UnbondingDelegationObject, error) {
+	var newUnbondingDelegationState = NewUnbondingDelegationState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newUnbondingDelegationState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newUnbondingDelegationState, ok = data.(*UnbondingDelegationState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidUnbondingDelegationStateType, reflect.TypeOf(data))
+		}
+	}
+	return &UnbondingDelegationObject{
+		version:                  defaultVersion,
+		unbondingDelegationHash:  key,
+		unbondingDelegationState: newUnbondingDelegationState,
+		db:                       db,
+		objectType:               UnbondingDelegationObjectType,
+		deleted:                  false,
+	}, nil
+}
+
+// GenerateUnbondingDelegationObjectKey indexes unbonding delegations by release epoch, beacon reads only the ones
+// released in its epoch
+func GenerateUnbondingDelegationObjectKey(releaseEpoch uint64, txReqID common.Hash) common.Hash {
+	prefixHash := GetUnbondingDelegationPrefix(releaseEpoch)
+	valueHash := common.HashH(txReqID[:])
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t UnbondingDelegationObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *UnbondingDelegationObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t UnbondingDelegationObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *UnbondingDelegationObject) SetValue(data interface{}) error {
+	newUnbondingDelegationState, ok := data.(*UnbondingDelegationState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidUnbondingDelegationStateType, reflect.TypeOf(data))
+	}
+	t.unbondingDelegationState = newUnbondingDelegationState
+	return nil
+}
+
+func (t UnbondingDelegationObject) GetValue() interface{} {
+	return t.unbondingDelegationState
+}
+
+func (t UnbondingDelegationObject) GetValueBytes() []byte {
+	unbondingDelegationState, ok := t.GetValue().(*UnbondingDelegationState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(unbondingDelegationState)
+	if err != nil {
+		panic("failed to marshal unbonding delegation state")
+	}
+	return value
+}
+
+func (t UnbondingDelegationObject) GetHash() common.Hash {
+	return t.unbondingDelegationHash
+}
+
+func (t UnbondingDelegationObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *UnbondingDelegationObject) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all unbonding delegation value into default value
+func (t *UnbondingDelegationObject) Reset() bool {
+	t.unbondingDelegationState = NewUnbondingDelegationState()
+	return true
+}
+
+func (t UnbondingDelegationObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t UnbondingDelegationObject) IsEmpty() bool {
+	temp := NewUnbondingDelegationState()
+	return reflect.DeepEqual(temp, t.unbondingDelegationState) || t.unbondingDelegationState == nil
+}
@@ -2,37 +2,39 @@ package statedb
 
 import (
 	"testing"
+
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
 )
 
 func TestValidation_ValidatePaymentAddressSanity(t *testing.T) {
-	str1 := receiverPaymentAddress[0]
-	str2 := str1[1:]
-	str3 := str1[2:]
-	str4 := str1[:len(str1)-1]
-	str5 := str1[:len(str1)-2]
-	err := SoValidation.ValidatePaymentAddressSanity(str1)
-	if err != nil {
-		t.Fatal(err)
-	}
-	for _, v := range receiverPaymentAddress[1:] {
-		err := SoValidation.ValidatePaymentAddressSanity(v)
+	for _, v := range receiverPaymentAddress {
+		wl, err := wallet.Base58CheckDeserialize(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = SoValidation.ValidatePaymentAddressSanity(wl.KeySet.PaymentAddress)
 		if err != nil {
 			t.Fatal(err)
 		}
 	}
-	err = SoValidation.ValidatePaymentAddressSanity(str2)
-	if err == nil {
+	wl, err := wallet.Base58CheckDeserialize(receiverPaymentAddress[0])
+	if err != nil {
 		t.Fatal(err)
 	}
-	err = SoValidation.ValidatePaymentAddressSanity(str3)
+	noPublicKey := wl.KeySet.PaymentAddress
+	noPublicKey.Pk = nil
+	err = SoValidation.ValidatePaymentAddressSanity(noPublicKey)
 	if err == nil {
 		t.Fatal(err)
 	}
-	err = SoValidation.ValidatePaymentAddressSanity(str4)
+	noTransmissionKey := wl.KeySet.PaymentAddress
+	noTransmissionKey.Tk = nil
+	err = SoValidation.ValidatePaymentAddressSanity(noTransmissionKey)
 	if err == nil {
 		t.Fatal(err)
 	}
-	err = SoValidation.ValidatePaymentAddressSanity(str5)
+	err = SoValidation.ValidatePaymentAddressSanity(privacy.PaymentAddress{})
 	if err == nil {
 		t.Fatal(err)
 	}
@@ -10,10 +10,12 @@ const (
 	DriverExistErr = iota
 	DriverNotRegisterErr
 	
-	// LevelDB
+	// LevelDB, journal
 	OpenDbErr
 	NotExistValue
 	LvDbNotFound
+	JournalNotFound
+	JournalErr
 	
 	// BlockChain err
 	NotImplHashMethod
@@ -30,10 +32,12 @@ var ErrCodeMessage = map[int]struct {
 	DriverExistErr:       {-1000, "Driver is already registered"},
 	DriverNotRegisterErr: {-1001, "Driver is not registered"},
 	
-	// -2xxx levelDb
-	OpenDbErr:     {-2000, "Open database error"},
-	NotExistValue: {-2001, "H is not existed"},
-	LvDbNotFound:  {-2002, "lvdb not found"},
+	// -2xxx levelDb, journal
+	OpenDbErr:       {-2000, "Open database error"},
+	NotExistValue:   {-2001, "H is not existed"},
+	LvDbNotFound:    {-2002, "lvdb not found"},
+	JournalNotFound: {-2003, "Journal value not found"},
+	JournalErr:      {-2004, "Journal error"},
 	
 	// -3xxx blockchain
 	NotImplHashMethod: {-3000, "Data does not implement this method"},
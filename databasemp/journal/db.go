@@ -0,0 +1,54 @@
+package journal
+
+import (
+	"github.com/incognitochain/incognito-chain/databasemp"
+	"github.com/pkg/errors"
+)
+
+func (db *db) Close() error {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	return errors.Wrap(db.file.Close(), "db.file.Close")
+}
+
+func (db *db) HasValue(key []byte) (bool, error) {
+	db.mtx.RLock()
+	defer db.mtx.RUnlock()
+	_, ok := db.values[string(key)]
+	return ok, nil
+}
+
+func (db *db) Put(key, value []byte) error {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	newValue := make([]byte, len(value))
+	copy(newValue, value)
+	if err := db.write(opPut, string(key), newValue); err != nil {
+		return databasemp.NewDatabaseMempoolError(databasemp.JournalErr, errors.Wrap(err, "db.write"))
+	}
+	return nil
+}
+
+func (db *db) Delete(key []byte) error {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	if _, ok := db.values[string(key)]; !ok {
+		return nil
+	}
+	if err := db.write(opDelete, string(key), nil); err != nil {
+		return databasemp.NewDatabaseMempoolError(databasemp.JournalErr, errors.Wrap(err, "db.write"))
+	}
+	return nil
+}
+
+func (db *db) Get(key []byte) ([]byte, error) {
+	db.mtx.RLock()
+	defer db.mtx.RUnlock()
+	value, ok := db.values[string(key)]
+	if !ok {
+		return nil, databasemp.NewDatabaseMempoolError(databasemp.JournalNotFound, errors.Errorf("key %x not found", key))
+	}
+	result := make([]byte, len(value))
+	copy(result, value)
+	return result, nil
+}
@@ -0,0 +1,260 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/databasemp"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// The journal is an append only file of records, each record puts or deletes one key:
+//
+//	length of payload (4 bytes) | crc32 of payload (4 bytes) | payload
+//	payload: op (1 byte) | length of key (uvarint) | key | value
+//
+// A write returns once its record is synced to disk. A record torn by a crash fails its length or its checksum, it
+// is dropped with everything after it when the journal is opened again, so the journal always replays to the state
+// of a completed write.
+const (
+	journalFileName  = "journal"
+	leveldbFileName  = "CURRENT"
+	recordHeaderSize = 8
+	maxRecordSize    = 64 << 20
+	// the journal is rewritten with the live keys only once dead records take more than half of it
+	minCompactSize = 1 << 20
+)
+
+const (
+	opPut byte = iota + 1
+	opDelete
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+type db struct {
+	mtx      sync.RWMutex
+	dir      string
+	file     *os.File
+	values   map[string][]byte
+	size     int64 // size of the journal file
+	liveSize int64 // size of the records of the live keys
+}
+
+func open(dir string) (databasemp.DatabaseInterface, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, databasemp.NewDatabaseMempoolError(databasemp.OpenDbErr, errors.Wrapf(err, "os.MkdirAll %s", dir))
+	}
+	db := &db{
+		dir:    dir,
+		values: make(map[string][]byte),
+	}
+	path := filepath.Join(dir, journalFileName)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		// the mempool database of previous versions is a leveldb in the same directory
+		if _, err := os.Stat(filepath.Join(dir, leveldbFileName)); err == nil {
+			if err := db.migrateLevelDB(); err != nil {
+				return nil, databasemp.NewDatabaseMempoolError(databasemp.OpenDbErr, err)
+			}
+		}
+	} else if err := db.replay(path); err != nil {
+		return nil, databasemp.NewDatabaseMempoolError(databasemp.OpenDbErr, err)
+	}
+	// a torn record at the end is not kept
+	if err := db.compact(); err != nil {
+		return nil, databasemp.NewDatabaseMempoolError(databasemp.OpenDbErr, err)
+	}
+	return db, nil
+}
+
+// replay - apply the records of the journal until the end of the file or the first torn record
+func (db *db) replay(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "os.Open %s", path)
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	offset := int64(0)
+	header := make([]byte, recordHeaderSize)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if err != io.EOF {
+				Logger.log.Warnf("Mempool journal %s: drop torn record at offset %d", path, offset)
+			}
+			return nil
+		}
+		length := binary.BigEndian.Uint32(header[:4])
+		if length == 0 || length > maxRecordSize {
+			Logger.log.Warnf("Mempool journal %s: drop record of length %d at offset %d", path, length, offset)
+			return nil
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			Logger.log.Warnf("Mempool journal %s: drop torn record at offset %d", path, offset)
+			return nil
+		}
+		if crc32.Checksum(payload, crcTable) != binary.BigEndian.Uint32(header[4:]) {
+			Logger.log.Warnf("Mempool journal %s: drop record with wrong checksum at offset %d", path, offset)
+			return nil
+		}
+		op, key, value, err := decodePayload(payload)
+		if err != nil {
+			Logger.log.Warnf("Mempool journal %s: drop record at offset %d: %v", path, offset, err)
+			return nil
+		}
+		db.apply(op, key, value)
+		offset += recordHeaderSize + int64(length)
+	}
+}
+
+// migrateLevelDB - load the keys of the leveldb of previous versions, its files are left in the directory
+func (db *db) migrateLevelDB() error {
+	lvdb, err := leveldb.OpenFile(db.dir, &opt.Options{ReadOnly: true, ErrorIfMissing: true})
+	if err != nil {
+		return errors.Wrapf(err, "leveldb.OpenFile %s", db.dir)
+	}
+	defer lvdb.Close()
+	iter := lvdb.NewIterator(nil, nil)
+	defer iter.Release()
+	for iter.Next() {
+		value := make([]byte, len(iter.Value()))
+		copy(value, iter.Value())
+		db.apply(opPut, string(iter.Key()), value)
+	}
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "iter.Error")
+	}
+	Logger.log.Infof("Mempool journal: migrated %d keys of the leveldb in %s", len(db.values), db.dir)
+	return nil
+}
+
+// compact - rewrite the journal with one record by live key, the new journal replaces the old one atomically
+func (db *db) compact() error {
+	path := filepath.Join(db.dir, journalFileName)
+	tmpPath := path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "os.OpenFile %s", tmpPath)
+	}
+	keys := make([]string, 0, len(db.values))
+	for key := range db.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	writer := bufio.NewWriter(tmp)
+	size := int64(0)
+	for _, key := range keys {
+		record := encodeRecord(opPut, key, db.values[key])
+		if _, err := writer.Write(record); err != nil {
+			tmp.Close()
+			return errors.Wrap(err, "write journal")
+		}
+		size += int64(len(record))
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "write journal")
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "sync journal")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "close journal")
+	}
+	if db.file != nil {
+		db.file.Close()
+		db.file = nil
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.Wrapf(err, "os.Rename %s", tmpPath)
+	}
+	syncDir(db.dir)
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "os.OpenFile %s", path)
+	}
+	db.file = file
+	db.size = size
+	db.liveSize = size
+	return nil
+}
+
+// write - append a record and sync it, then apply it
+func (db *db) write(op byte, key string, value []byte) error {
+	record := encodeRecord(op, key, value)
+	if _, err := db.file.Write(record); err != nil {
+		return errors.Wrap(err, "write journal")
+	}
+	if err := db.file.Sync(); err != nil {
+		return errors.Wrap(err, "sync journal")
+	}
+	db.size += int64(len(record))
+	db.apply(op, key, value)
+	if db.size > minCompactSize && db.size > 2*db.liveSize {
+		return db.compact()
+	}
+	return nil
+}
+
+func (db *db) apply(op byte, key string, value []byte) {
+	if old, ok := db.values[key]; ok {
+		db.liveSize -= recordSize(key, old)
+		delete(db.values, key)
+	}
+	if op == opPut {
+		db.values[key] = value
+		db.liveSize += recordSize(key, value)
+	}
+}
+
+func encodeRecord(op byte, key string, value []byte) []byte {
+	record := make([]byte, recordHeaderSize, recordSize(key, value))
+	record = append(record, op)
+	var keyLength [binary.MaxVarintLen64]byte
+	record = append(record, keyLength[:binary.PutUvarint(keyLength[:], uint64(len(key)))]...)
+	record = append(record, key...)
+	record = append(record, value...)
+	payload := record[recordHeaderSize:]
+	binary.BigEndian.PutUint32(record[:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.Checksum(payload, crcTable))
+	return record
+}
+
+func decodePayload(payload []byte) (byte, string, []byte, error) {
+	op := payload[0]
+	if op != opPut && op != opDelete {
+		return 0, "", nil, errors.Errorf("unknown op %d", op)
+	}
+	keyLength, n := binary.Uvarint(payload[1:])
+	if n <= 0 || uint64(len(payload)-1-n) < keyLength {
+		return 0, "", nil, errors.New("wrong key length")
+	}
+	key := string(payload[1+n : 1+n+int(keyLength)])
+	value := payload[1+n+int(keyLength):]
+	return op, key, value, nil
+}
+
+func recordSize(key string, value []byte) int64 {
+	var keyLength [binary.MaxVarintLen64]byte
+	return int64(recordHeaderSize + 1 + binary.PutUvarint(keyLength[:], uint64(len(key))) + len(key) + len(value))
+}
+
+// syncDir - sync the directory so a rename in it survives a crash, not supported on every platform
+func syncDir(dir string) {
+	file, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	file.Sync()
+	file.Close()
+}
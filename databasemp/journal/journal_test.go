@@ -0,0 +1,203 @@
+package journal
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func init() {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+func openTestJournal(t *testing.T, dir string) *db {
+	journal, err := open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return journal.(*db)
+}
+
+func TestJournalReopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	journal := openTestJournal(t, dir)
+	txHash1 := common.HashH([]byte{1})
+	txHash2 := common.HashH([]byte{2})
+	if err := journal.AddTransaction(&txHash1, common.TxNormalType, []byte("tx1"), []byte("desc1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := journal.AddTransaction(&txHash2, common.TxNormalType, []byte("tx2"), []byte("desc2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := journal.RemoveTransaction(&txHash1); err != nil {
+		t.Fatal(err)
+	}
+	journal.Close()
+
+	journal = openTestJournal(t, dir)
+	defer journal.Close()
+	if has, _ := journal.HasTransaction(&txHash1); has {
+		t.Fatal("Expect removed tx not in journal")
+	}
+	value, err := journal.GetTransaction(&txHash2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(value, []byte("tx2")) || !bytes.Contains(value, []byte("desc2")) {
+		t.Fatalf("Wrong value %s", value)
+	}
+	txHashes, txs, err := journal.Load()
+	if err != nil || len(txHashes) != 1 || len(txs) != 1 {
+		t.Fatalf("Expect 1 tx but get %d, error %v", len(txs), err)
+	}
+	if !bytes.Equal(txHashes[0][len(txKeyPrefix):], txHash2[:]) {
+		t.Fatal("Wrong tx hash")
+	}
+}
+
+func TestJournalTornRecord(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	journal := openTestJournal(t, dir)
+	if err := journal.Put([]byte("a"), []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	journal.Close()
+	path := filepath.Join(dir, journalFileName)
+	// a crash in the middle of the write of the next record
+	record := encodeRecord(opPut, "b", []byte("2"))
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Write(record[:len(record)-1])
+	file.Close()
+
+	journal = openTestJournal(t, dir)
+	if has, _ := journal.HasValue([]byte("b")); has {
+		t.Fatal("Expect torn record dropped")
+	}
+	if value, err := journal.Get([]byte("a")); err != nil || string(value) != "1" {
+		t.Fatalf("Expect a=1 but get %s, error %v", value, err)
+	}
+	// the torn record is not in the way of the next writes
+	if err := journal.Put([]byte("c"), []byte("3")); err != nil {
+		t.Fatal(err)
+	}
+	journal.Close()
+	journal = openTestJournal(t, dir)
+	defer journal.Close()
+	if value, err := journal.Get([]byte("c")); err != nil || string(value) != "3" {
+		t.Fatalf("Expect c=3 but get %s, error %v", value, err)
+	}
+}
+
+func TestJournalChecksum(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	journal := openTestJournal(t, dir)
+	journal.Put([]byte("a"), []byte("1"))
+	journal.Put([]byte("b"), []byte("2"))
+	journal.Close()
+	path := filepath.Join(dir, journalFileName)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)-1] ^= 0xff
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	journal = openTestJournal(t, dir)
+	defer journal.Close()
+	if has, _ := journal.HasValue([]byte("b")); has {
+		t.Fatal("Expect record with wrong checksum dropped")
+	}
+	if has, _ := journal.HasValue([]byte("a")); !has {
+		t.Fatal("Expect a in journal")
+	}
+}
+
+func TestJournalCompact(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	journal := openTestJournal(t, dir)
+	defer journal.Close()
+	value := make([]byte, 1024)
+	for i := 0; i < 4*minCompactSize/len(value); i++ {
+		if err := journal.Put([]byte("a"), value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	info, err := os.Stat(filepath.Join(dir, journalFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() > minCompactSize+recordSize("a", value) {
+		t.Fatalf("Expect compacted journal but its size is %d", info.Size())
+	}
+	if info.Size() != journal.size {
+		t.Fatalf("Expect journal size %d but get %d", info.Size(), journal.size)
+	}
+}
+
+func TestJournalReset(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	journal := openTestJournal(t, dir)
+	txHash := common.HashH([]byte{1})
+	journal.AddTransaction(&txHash, common.TxNormalType, []byte("tx"), []byte("desc"))
+	if err := journal.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	journal.Close()
+	journal = openTestJournal(t, dir)
+	defer journal.Close()
+	if txHashes, _, _ := journal.Load(); len(txHashes) != 0 {
+		t.Fatalf("Expect no tx but get %d", len(txHashes))
+	}
+}
+
+func TestJournalMigrateLevelDB(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	lvdb, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txHash := common.HashH([]byte{1})
+	lvdb.Put(getKey(&txHash), []byte("value"), nil)
+	lvdb.Close()
+
+	journal := openTestJournal(t, dir)
+	defer journal.Close()
+	value, err := journal.GetTransaction(&txHash)
+	if err != nil || string(value) != "value" {
+		t.Fatalf("Expect migrated tx but get %s, error %v", value, err)
+	}
+}
@@ -0,0 +1,14 @@
+package journal
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type JournalLogger struct {
+	log common.Logger
+}
+
+func (journalLogger *JournalLogger) Init(inst common.Logger) {
+	journalLogger.log = inst
+}
+
+// Global instant to use
+var Logger = JournalLogger{}
@@ -0,0 +1,80 @@
+package journal
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/databasemp"
+	"github.com/incognitochain/incognito-chain/databasemp/lvdb"
+	"github.com/pkg/errors"
+)
+
+// keys and values are the ones of the leveldb mempool database
+var txKeyPrefix = "tx-"
+
+func getKey(txHash *common.Hash) []byte {
+	return append([]byte(txKeyPrefix), txHash[:]...)
+}
+
+// Key: tx-{txHash}
+// Value: {type}-transaction(byte value)-Splitter-otherDescValue(byte Value)
+func (db *db) AddTransaction(txHash *common.Hash, txType string, valueTx []byte, valueDesc []byte) error {
+	value := append([]byte(txType), lvdb.Splitter...)
+	value = append(value, valueTx...)
+	value = append(value, lvdb.Splitter...)
+	value = append(value, valueDesc...)
+	return db.Put(getKey(txHash), value)
+}
+
+func (db *db) RemoveTransaction(txHash *common.Hash) error {
+	return db.Delete(getKey(txHash))
+}
+
+func (db *db) GetTransaction(txHash *common.Hash) ([]byte, error) {
+	value, err := db.Get(getKey(txHash))
+	if err != nil {
+		return []byte{}, err
+	}
+	return value, nil
+}
+
+func (db *db) HasTransaction(txHash *common.Hash) (bool, error) {
+	return db.HasValue(getKey(txHash))
+}
+
+// Reset - remove every transaction, the journal is rewritten at once
+func (db *db) Reset() error {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	for key := range db.values {
+		if strings.HasPrefix(key, txKeyPrefix) {
+			db.apply(opDelete, key, nil)
+		}
+	}
+	if err := db.compact(); err != nil {
+		return databasemp.NewDatabaseMempoolError(databasemp.JournalErr, errors.Wrap(err, "db.compact"))
+	}
+	return nil
+}
+
+func (db *db) Load() ([][]byte, [][]byte, error) {
+	db.mtx.RLock()
+	defer db.mtx.RUnlock()
+	keys := []string{}
+	for key := range db.values {
+		if strings.HasPrefix(key, txKeyPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	txHashes := [][]byte{}
+	txs := [][]byte{}
+	for _, key := range keys {
+		value := make([]byte, len(db.values[key]))
+		copy(value, db.values[key])
+		txHashes = append(txHashes, []byte(key))
+		txs = append(txs, value)
+	}
+	return txHashes, txs, nil
+}
@@ -0,0 +1,245 @@
+// Package devnet runs a whole network in one process for local development: a beacon committee and the committees of
+// the shards, with keys generated from a seed and a genesis block funding a few accounts. Each validator is a node
+// running its own consensus engine, the nodes exchange their BFT messages and blocks through a loopback instead of
+// highway
+package devnet
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/peerv2"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wire"
+)
+
+const (
+	Name                 = "devnet"
+	DefaultShards        = 2
+	DefaultCommitteeSize = 4
+	DefaultBlockTime     = 2 * time.Second
+	DefaultAccounts      = 4
+	DefaultSeed          = "incognito devnet"
+	DefaultBalance       = 1000000 * 1e9 // 1M PRV
+	Epoch                = 20
+	RandomTime           = 10
+	genesisTimeLayout    = "2006-01-02T15:04:05.000Z"
+)
+
+type Config struct {
+	Shards        int
+	CommitteeSize int
+	BlockTime     time.Duration // whole seconds, block timestamps are in seconds
+	Accounts      int           // accounts funded with Balance in the genesis block, spread over the shards
+	Balance       uint64
+	Seed          string
+}
+
+// Devnet holds the generated keys and the nodes of the validators
+type Devnet struct {
+	config   Config
+	beacon   []*Key
+	shards   [][]*Key
+	accounts []*Key
+	nodes    []*node
+	loopback *loopback
+}
+
+// New generates the keys of the committees and the accounts of config
+func New(config Config) (*Devnet, error) {
+	if config.Shards < 1 || config.Shards > common.MaxShardNumber {
+		return nil, errors.New("the devnet needs between 1 and 8 shards")
+	}
+	if config.CommitteeSize < 1 {
+		return nil, errors.New("the devnet needs at least one validator by committee")
+	}
+	if config.BlockTime < time.Second || config.BlockTime%time.Second != 0 {
+		return nil, errors.New("the block time of the devnet must be a whole number of seconds")
+	}
+	generator, err := newKeyGenerator(config.Seed, config.Shards)
+	if err != nil {
+		return nil, err
+	}
+	d := &Devnet{
+		config: config,
+		shards: make([][]*Key, config.Shards),
+	}
+	for i := 0; i < config.CommitteeSize; i++ {
+		key, err := generator.next(-1)
+		if err != nil {
+			return nil, err
+		}
+		d.beacon = append(d.beacon, key)
+	}
+	for shardID := 0; shardID < config.Shards; shardID++ {
+		for i := 0; i < config.CommitteeSize; i++ {
+			key, err := generator.next(shardID)
+			if err != nil {
+				return nil, err
+			}
+			d.shards[shardID] = append(d.shards[shardID], key)
+		}
+	}
+	for i := 0; i < config.Accounts; i++ {
+		key, err := generator.next(i % config.Shards)
+		if err != nil {
+			return nil, err
+		}
+		d.accounts = append(d.accounts, key)
+	}
+	for _, key := range d.committeeKeys() {
+		if _, err := blsbftv2.GetMiningKeyFromPrivateSeed(key.MiningKey); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func (d *Devnet) committeeKeys() []*Key {
+	keys := append([]*Key{}, d.beacon...)
+	for _, shardKeys := range d.shards {
+		keys = append(keys, shardKeys...)
+	}
+	return keys
+}
+
+// Accounts returns the accounts funded in the genesis block
+func (d *Devnet) Accounts() []*Key {
+	return d.accounts
+}
+
+// SetupParams fills params with the devnet network, based on the testnet, and shortens the timeslot to the block time.
+// stateDB is an empty state, the coins of the genesis block are checked against it
+func (d *Devnet) SetupParams(params *blockchain.Params, stateDB *statedb.StateDB) error {
+	genesisTime := time.Now().UTC().Add(-d.config.BlockTime).Truncate(time.Second)
+	genesisParams := &blockchain.GenesisParams{
+		SelectBeaconNodeSerializedPubkeyV2:         make(map[uint64][]string),
+		SelectBeaconNodeSerializedPaymentAddressV2: make(map[uint64][]string),
+		SelectShardNodeSerializedPubkeyV2:          make(map[uint64][]string),
+		SelectShardNodeSerializedPaymentAddressV2:  make(map[uint64][]string),
+		ConsensusAlgorithm:                         common.BlsConsensus,
+	}
+	for _, key := range d.beacon {
+		genesisParams.PreSelectBeaconNodeSerializedPubkey = append(genesisParams.PreSelectBeaconNodeSerializedPubkey, key.CommitteePublicKey)
+		genesisParams.PreSelectBeaconNodeSerializedPaymentAddress = append(genesisParams.PreSelectBeaconNodeSerializedPaymentAddress, key.PaymentAddress)
+	}
+	for _, shardKeys := range d.shards {
+		for _, key := range shardKeys {
+			genesisParams.PreSelectShardNodeSerializedPubkey = append(genesisParams.PreSelectShardNodeSerializedPubkey, key.CommitteePublicKey)
+			genesisParams.PreSelectShardNodeSerializedPaymentAddress = append(genesisParams.PreSelectShardNodeSerializedPaymentAddress, key.PaymentAddress)
+		}
+	}
+	for _, account := range d.accounts {
+		tx := transaction.Tx{LockTime: genesisTime.Unix()}
+		keySet := account.wallet.KeySet
+		if err := tx.InitTxSalary(d.config.Balance, &keySet.PaymentAddress, &keySet.PrivateKey, stateDB, nil); err != nil {
+			return err
+		}
+		txBytes, err := json.Marshal(&tx)
+		if err != nil {
+			return err
+		}
+		genesisParams.InitialIncognito = append(genesisParams.InitialIncognito, string(txBytes))
+	}
+
+	*params = blockchain.ChainTestParam
+	params.Name = Name
+	params.GenesisParams = genesisParams
+	params.ActiveShards = d.config.Shards
+	params.MinShardCommitteeSize = d.config.CommitteeSize
+	// the first validators of a shard committee are fixed, without free seats a staker could not be swapped in
+	params.MaxShardCommitteeSize = d.config.CommitteeSize + stakedValidators(d.config.CommitteeSize)
+	params.MinBeaconCommitteeSize = d.config.CommitteeSize
+	params.MaxBeaconCommitteeSize = d.config.CommitteeSize + stakedValidators(d.config.CommitteeSize)
+	params.GenesisBeaconBlock = blockchain.CreateBeaconGenesisBlock(1, blockchain.Testnet, genesisTime.Format(genesisTimeLayout), genesisParams)
+	params.GenesisShardBlock = blockchain.CreateShardGenesisBlock(1, blockchain.Testnet, genesisTime.Format(genesisTimeLayout), genesisParams)
+	params.MinShardBlockInterval = d.config.BlockTime
+	params.MinBeaconBlockInterval = d.config.BlockTime
+	// a proposer makes one block by timeslot
+	common.TimeSlot = int64(d.config.BlockTime / time.Second)
+	params.Epoch = Epoch
+	params.RandomTime = RandomTime
+	params.ConsensusV2Epoch = 1
+	params.EpochBreakPointSwapNewKey = nil
+	// a devnet starts a new chain, features scheduled on testnet are active from its genesis
+	params.BeaconHeightBreakPointRingSize = 1
+	params.BeaconHeightBreakPointStealthAddress = 1
+	params.BeaconHeightBreakPointHTLC = 1
+	params.BeaconHeightBreakPointDelegation = 1
+	params.PreloadAddress = ""
+	params.CheckForce = false
+	if len(d.accounts) > 0 {
+		// the first account issues and contracts centralized bridge tokens
+		params.CentralizedWebsitePaymentAddress = d.accounts[0].PaymentAddress
+	}
+	return nil
+}
+
+// stakedValidators returns the seats of a committee left for validators staked with transactions. The devnet does not
+// know their keys, its validators must keep more than 2/3 of the votes of the committee
+func stakedValidators(committeeSize int) int {
+	return (committeeSize - 1) / 2
+}
+
+// WriteKeys writes the keys of the committees and the accounts to file as json
+func (d *Devnet) WriteKeys(file string) error {
+	keys := struct {
+		Accounts []*Key
+		Beacon   []*Key
+		Shard    map[int][]*Key
+	}{
+		Accounts: d.accounts,
+		Beacon:   d.beacon,
+		Shard:    make(map[int][]*Key),
+	}
+	for shardID, shardKeys := range d.shards {
+		keys.Shard[shardID] = shardKeys
+	}
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0600)
+}
+
+// Init makes a node for each validator of the committees, the messages of the nodes reach the node of this process
+// through dispatcher
+func (d *Devnet) Init(bc *blockchain.BlockChain, dispatcher *peerv2.Dispatcher) {
+	d.loopback = newLoopback(dispatcher)
+	// the nodes notify their roles, which are not the role of this process
+	pubSubManager := pubsub.NewPubSubManager()
+	go pubSubManager.Start()
+	for i, key := range d.committeeKeys() {
+		d.nodes = append(d.nodes, newNode(key, i, bc, d.loopback, pubSubManager))
+	}
+	d.loopback.nodes = d.nodes
+}
+
+// Start starts the consensus engines of the nodes
+func (d *Devnet) Start() error {
+	for _, n := range d.nodes {
+		if err := n.start(); err != nil {
+			return err
+		}
+	}
+	Logger.log.Infof("Devnet started with %v shards, %v validators by committee and a block every %v", d.config.Shards, d.config.CommitteeSize, d.config.BlockTime)
+	return nil
+}
+
+func (d *Devnet) Stop() {
+	for _, n := range d.nodes {
+		n.stop()
+	}
+}
+
+// PublishMessage passes msg to the nodes of the devnet, as highway would
+func (d *Devnet) PublishMessage(msg wire.Message) error {
+	return d.loopback.publish(msg)
+}
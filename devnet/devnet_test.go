@@ -0,0 +1,161 @@
+package devnet
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestConfig() Config {
+	return Config{
+		Shards:        DefaultShards,
+		CommitteeSize: DefaultCommitteeSize,
+		BlockTime:     DefaultBlockTime,
+		Accounts:      DefaultAccounts,
+		Balance:       DefaultBalance,
+		Seed:          DefaultSeed,
+	}
+}
+
+func TestNewIsDeterministic(t *testing.T) {
+	d1, err := New(newTestConfig())
+	assert.Nil(t, err)
+	d2, err := New(newTestConfig())
+	assert.Nil(t, err)
+	assert.Equal(t, d1.committeeKeys()[0].PrivateKey, d2.committeeKeys()[0].PrivateKey)
+	assert.Equal(t, d1.accounts[0].PrivateKey, d2.accounts[0].PrivateKey)
+
+	config := newTestConfig()
+	config.Seed = "another seed"
+	d3, err := New(config)
+	assert.Nil(t, err)
+	assert.NotEqual(t, d1.accounts[0].PrivateKey, d3.accounts[0].PrivateKey)
+}
+
+func TestNewKeys(t *testing.T) {
+	d, err := New(newTestConfig())
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultCommitteeSize, len(d.beacon))
+	assert.Equal(t, DefaultShards, len(d.shards))
+	for shardID, shardKeys := range d.shards {
+		assert.Equal(t, DefaultCommitteeSize, len(shardKeys))
+		for _, key := range shardKeys {
+			assert.Equal(t, byte(shardID), key.ShardID)
+		}
+	}
+	assert.Equal(t, DefaultAccounts, len(d.accounts))
+	for i, account := range d.accounts {
+		assert.Equal(t, byte(i%DefaultShards), account.ShardID)
+	}
+	assert.Equal(t, DefaultCommitteeSize*(DefaultShards+1), len(d.committeeKeys()))
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	config := newTestConfig()
+	config.Shards = common.MaxShardNumber + 1
+	_, err := New(config)
+	assert.NotNil(t, err)
+
+	config = newTestConfig()
+	config.CommitteeSize = 0
+	_, err = New(config)
+	assert.NotNil(t, err)
+
+	config = newTestConfig()
+	config.BlockTime = 0
+	_, err = New(config)
+	assert.NotNil(t, err)
+
+	// timeslots are whole seconds
+	config = newTestConfig()
+	config.BlockTime = 1500 * time.Millisecond
+	_, err = New(config)
+	assert.NotNil(t, err)
+}
+
+func TestSetupParams(t *testing.T) {
+	dir, err := ioutil.TempDir("", "devnet")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	db, err := incdb.Open("leveldb", dir)
+	assert.Nil(t, err)
+	defer db.Close()
+	stateDB, err := statedb.NewWithPrefixTrie(common.EmptyRoot, statedb.NewDatabaseAccessWarper(db))
+	assert.Nil(t, err)
+
+	d, err := New(newTestConfig())
+	assert.Nil(t, err)
+	params := &blockchain.Params{}
+	defer func() { common.TimeSlot = common.TIMESLOT }()
+	assert.Nil(t, d.SetupParams(params, stateDB))
+	assert.Equal(t, int64(DefaultBlockTime/time.Second), common.TimeSlot)
+	assert.Equal(t, Name, params.Name)
+	assert.Equal(t, DefaultShards, params.ActiveShards)
+	assert.Equal(t, DefaultCommitteeSize, params.MinBeaconCommitteeSize)
+	assert.Equal(t, DefaultCommitteeSize+1, params.MaxShardCommitteeSize)
+	assert.Equal(t, DefaultCommitteeSize, len(params.GenesisParams.PreSelectBeaconNodeSerializedPubkey))
+	assert.Equal(t, DefaultCommitteeSize*DefaultShards, len(params.GenesisParams.PreSelectShardNodeSerializedPubkey))
+	assert.Equal(t, DefaultAccounts, len(params.GenesisParams.InitialIncognito))
+	assert.NotNil(t, params.GenesisBeaconBlock)
+	assert.NotNil(t, params.GenesisShardBlock)
+	assert.Equal(t, d.accounts[0].PaymentAddress, params.CentralizedWebsitePaymentAddress)
+}
+
+func TestStakedValidators(t *testing.T) {
+	for committeeSize := 1; committeeSize <= 10; committeeSize++ {
+		// the committee commits a block with more than 2/3 of its votes
+		size := committeeSize + stakedValidators(committeeSize)
+		assert.True(t, committeeSize > 2*size/3, "committee size %v", committeeSize)
+		assert.False(t, committeeSize > 2*(size+1)/3, "committee size %v", committeeSize)
+	}
+}
+
+func TestGenerateKeys(t *testing.T) {
+	keys, err := GenerateKeys("scenario", DefaultShards, 1, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(keys))
+	for _, key := range keys {
+		assert.Equal(t, byte(1), key.ShardID)
+	}
+	again, err := GenerateKeys("scenario", DefaultShards, 1, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, keys[2].PrivateKey, again[2].PrivateKey)
+
+	d, err := New(newTestConfig())
+	assert.Nil(t, err)
+	keys, err = GenerateKeys(DefaultSeed, DefaultShards, -1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, d.beacon[0].PrivateKey, keys[0].PrivateKey)
+}
+
+func TestWriteKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "devnet")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	d, err := New(newTestConfig())
+	assert.Nil(t, err)
+	file := filepath.Join(dir, "keys.json")
+	assert.Nil(t, d.WriteKeys(file))
+	data, err := ioutil.ReadFile(file)
+	assert.Nil(t, err)
+	keys := struct {
+		Accounts []*Key
+		Beacon   []*Key
+		Shard    map[int][]*Key
+	}{}
+	assert.Nil(t, json.Unmarshal(data, &keys))
+	assert.Equal(t, d.accounts[0].PrivateKey, keys.Accounts[0].PrivateKey)
+	assert.Equal(t, d.beacon[0].MiningKey, keys.Beacon[0].MiningKey)
+	assert.Equal(t, DefaultShards, len(keys.Shard))
+}
@@ -0,0 +1,93 @@
+package devnet
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// Key is a generated account, the keys of committee members also sign the blocks of their chain
+type Key struct {
+	PrivateKey         string
+	PaymentAddress     string
+	ReadOnlyKey        string
+	MiningKey          string // value of --miningkeys for the account
+	CommitteePublicKey string
+	ShardID            byte
+
+	wallet *wallet.KeyWallet
+}
+
+// keyGenerator derives keys from a seed in order, a devnet started with the same seed and config has the same keys
+type keyGenerator struct {
+	master       *wallet.KeyWallet
+	activeShards int
+	nextChild    uint32
+}
+
+func newKeyGenerator(seed string, activeShards int) (*keyGenerator, error) {
+	master, err := wallet.NewMasterKey(common.HashB([]byte(seed)))
+	if err != nil {
+		return nil, err
+	}
+	return &keyGenerator{master: master, activeShards: activeShards}, nil
+}
+
+// next returns the next key of shardID, or of any active shard if shardID is -1. Keys of inactive shards are skipped,
+// their accounts could not send transactions
+func (g *keyGenerator) next(shardID int) (*Key, error) {
+	for {
+		child, err := g.master.NewChildKey(g.nextChild)
+		if err != nil {
+			return nil, err
+		}
+		g.nextChild++
+		pubKey := child.KeySet.PaymentAddress.Pk
+		childShardID := common.GetShardIDFromLastByte(pubKey[len(pubKey)-1])
+		if int(childShardID) >= g.activeShards || (shardID != -1 && int(childShardID) != shardID) {
+			continue
+		}
+		return newKey(child, childShardID)
+	}
+}
+
+// GenerateKeys derives count keys of shardID, or of any of the active shards if shardID is -1, from seed. Scenarios use
+// it for fresh accounts, a devnet would give the same keys from the same seed
+func GenerateKeys(seed string, activeShards int, shardID int, count int) ([]*Key, error) {
+	generator, err := newKeyGenerator(seed, activeShards)
+	if err != nil {
+		return nil, err
+	}
+	keys := []*Key{}
+	for i := 0; i < count; i++ {
+		key, err := generator.next(shardID)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func newKey(keyWallet *wallet.KeyWallet, shardID byte) (*Key, error) {
+	// same derivation as the mining key of --privatekey
+	miningSeed := common.HashB(common.HashB(keyWallet.KeySet.PrivateKey))
+	committeeKey, err := incognitokey.NewCommitteeKeyFromSeed(miningSeed, keyWallet.KeySet.PaymentAddress.Pk)
+	if err != nil {
+		return nil, err
+	}
+	committeeKeyStr, err := committeeKey.ToBase58()
+	if err != nil {
+		return nil, err
+	}
+	return &Key{
+		PrivateKey:         keyWallet.Base58CheckSerialize(wallet.PriKeyType),
+		PaymentAddress:     keyWallet.Base58CheckSerialize(wallet.PaymentAddressType),
+		ReadOnlyKey:        keyWallet.Base58CheckSerialize(wallet.ReadonlyKeyType),
+		MiningKey:          base58.Base58Check{}.Encode(miningSeed, common.Base58Version),
+		CommitteePublicKey: committeeKeyStr,
+		ShardID:            shardID,
+		wallet:             keyWallet,
+	}, nil
+}
@@ -0,0 +1,14 @@
+package devnet
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type DevnetLogger struct {
+	log common.Logger
+}
+
+func (devnetLogger *DevnetLogger) Init(inst common.Logger) {
+	devnetLogger.log = inst
+}
+
+// Global instant to use
+var Logger = DevnetLogger{}
@@ -0,0 +1,55 @@
+package devnet
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/peerv2"
+	"github.com/incognitochain/incognito-chain/wire"
+)
+
+// loopback passes the messages of the devnet in this process, as highway passes them between the nodes of a network.
+// A BFT message reaches the consensus engine of every devnet node, an engine only processes the messages of its
+// chain. Blocks, cross shard blocks, shard to beacon blocks and peer states reach the node serving the RPC through its
+// dispatcher, as if they came from highway. Each receiver decodes its own copy of a message
+type loopback struct {
+	nodes      []*node
+	dispatcher *peerv2.Dispatcher
+}
+
+func newLoopback(dispatcher *peerv2.Dispatcher) *loopback {
+	return &loopback{dispatcher: dispatcher}
+}
+
+// publish delivers msg to its receivers without waiting for them to process it
+func (l *loopback) publish(msg wire.Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	switch msg.MessageType() {
+	case wire.CmdBFT:
+		for _, n := range l.nodes {
+			msgBFT := new(wire.MessageBFT)
+			if err := json.Unmarshal(data, msgBFT); err != nil {
+				return err
+			}
+			go n.engine.OnBFTMsg(msgBFT)
+		}
+	case wire.CmdTx, wire.CmdPrivacyCustomToken:
+		// the devnet nodes share the mempool of this process, a tx is in it before it is published
+	default:
+		received, err := wire.MakeEmptyMessage(msg.MessageType())
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, received); err != nil {
+			return err
+		}
+		go func() {
+			if err := l.dispatcher.ProcessMessage(received); err != nil {
+				Logger.log.Error(err)
+			}
+		}()
+	}
+	return nil
+}
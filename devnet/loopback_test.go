@@ -0,0 +1,48 @@
+package devnet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/peer"
+	"github.com/incognitochain/incognito-chain/peerv2"
+	"github.com/incognitochain/incognito-chain/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoopbackPublish(t *testing.T) {
+	peerv2.Logger.Init(common.NewBackend(nil).Logger("test", true))
+	peerStates := make(chan *wire.MessagePeerState, 1)
+	txs := make(chan *wire.MessageTx, 1)
+	l := newLoopback(&peerv2.Dispatcher{MessageListeners: &peerv2.MessageListeners{
+		OnPeerState: func(_ *peer.PeerConn, msg *wire.MessagePeerState) { peerStates <- msg },
+		OnTx:        func(_ *peer.PeerConn, msg *wire.MessageTx) { txs <- msg },
+	}})
+
+	msg, err := wire.MakeEmptyMessage(wire.CmdPeerState)
+	assert.Nil(t, err)
+	sent := msg.(*wire.MessagePeerState)
+	sent.Beacon.Height = 5
+	sent.SenderID = "sender"
+	assert.Nil(t, l.publish(sent))
+	select {
+	case received := <-peerStates:
+		// the receiver gets its own copy
+		assert.False(t, sent == received)
+		assert.Equal(t, uint64(5), received.Beacon.Height)
+		assert.Equal(t, "sender", received.SenderID)
+	case <-time.After(time.Second):
+		t.Fatal("the peer state is not delivered")
+	}
+
+	// the mempool is shared, a tx does not go back to it
+	msg, err = wire.MakeEmptyMessage(wire.CmdTx)
+	assert.Nil(t, err)
+	assert.Nil(t, l.publish(msg))
+	select {
+	case <-txs:
+		t.Fatal("the tx is delivered")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,161 @@
+package devnet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/wire"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+const peerStateInterval = 3 * time.Second
+
+// node is a validator of the devnet. It runs the consensus engine of a node with the mining key of the validator and
+// reaches the other validators through the loopback. The nodes share the chains, the mempool and the syncker of this
+// process, they only exchange the messages of the network
+type node struct {
+	key      *Key
+	peerID   peer.ID
+	bc       *blockchain.BlockChain
+	loopback *loopback
+	engine   *consensus.Engine
+	stopCh   chan struct{}
+}
+
+func newNode(key *Key, index int, bc *blockchain.BlockChain, loopback *loopback, pubSubManager *pubsub.PubSubManager) *node {
+	n := &node{
+		key:      key,
+		peerID:   peer.ID(fmt.Sprintf("%v-%v", Name, index)),
+		bc:       bc,
+		loopback: loopback,
+		engine:   consensus.NewConsensusEngine(),
+		stopCh:   make(chan struct{}),
+	}
+	n.engine.Init(&consensus.EngineConfig{Node: n, Blockchain: bc, PubSubManager: pubSubManager})
+	return n
+}
+
+func (n *node) start() error {
+	if err := n.engine.Start(); err != nil {
+		return err
+	}
+	go n.publishPeerStates()
+	return nil
+}
+
+func (n *node) stop() {
+	close(n.stopCh)
+	if err := n.engine.Stop(); err != nil {
+		Logger.log.Error(err)
+	}
+}
+
+// publishPeerStates publishes the chains of the validator as the syncker of a node does, the node serving the RPC
+// gets its chains ready once it knows a peer of them
+func (n *node) publishPeerStates() {
+	ticker := time.NewTicker(peerStateInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.stopCh:
+			return
+		case <-ticker.C:
+			if err := n.publishPeerState(); err != nil {
+				Logger.log.Error(err)
+			}
+		}
+	}
+}
+
+func (n *node) publishPeerState() error {
+	role, chainID := n.GetUserMiningState()
+	if role != common.CommitteeRole {
+		return nil
+	}
+	msg, err := wire.MakeEmptyMessage(wire.CmdPeerState)
+	if err != nil {
+		return err
+	}
+	peerState := msg.(*wire.MessagePeerState)
+	beaconBestState := n.bc.GetBeaconBestState()
+	peerState.Beacon = wire.ChainState{
+		Timestamp:     beaconBestState.BestBlock.Header.Timestamp,
+		Height:        beaconBestState.BeaconHeight,
+		BlockHash:     beaconBestState.BestBlockHash,
+		BestStateHash: beaconBestState.Hash(),
+	}
+	if chainID >= 0 {
+		shardBestState := n.bc.GetBestStateShard(byte(chainID))
+		peerState.Shards[byte(chainID)] = wire.ChainState{
+			Timestamp:     shardBestState.BestBlock.Header.Timestamp,
+			Height:        shardBestState.ShardHeight,
+			BlockHash:     shardBestState.BestBlockHash,
+			BestStateHash: shardBestState.Hash(),
+			PrunedHeight:  n.bc.GetShardPrunedHeight(byte(chainID)),
+		}
+	}
+	peerState.SenderMiningPublicKey, err = n.engine.GetMiningPublicKeys().ToBase58()
+	if err != nil {
+		return err
+	}
+	if err := peerState.SetSenderID(n.peerID); err != nil {
+		return err
+	}
+	return n.loopback.publish(peerState)
+}
+
+func (n *node) PushMessageToChain(msg wire.Message, chain common.ChainInterface) error {
+	return n.loopback.publish(msg)
+}
+
+func (n *node) IsEnableMining() bool {
+	return true
+}
+
+func (n *node) GetMiningKeys() string {
+	return n.key.MiningKey
+}
+
+func (n *node) GetPrivateKey() string {
+	return ""
+}
+
+func (n *node) GetRemoteSigner() string {
+	return ""
+}
+
+// GetUserMiningState returns the chain whose committee has the validator. The validators of the devnet are not
+// swapped out of their committee, there is no pending role
+func (n *node) GetUserMiningState() (role string, chainID int) {
+	userPk := n.engine.GetMiningPublicKeys()
+	if userPk == nil {
+		return "", -2
+	}
+	for _, v := range n.bc.BeaconChain.GetCommittee() {
+		if v.IsEqualMiningPubKey(common.BlsConsensus, userPk) {
+			return common.CommitteeRole, -1
+		}
+	}
+	for _, chain := range n.bc.ShardChain {
+		for _, v := range chain.GetCommittee() {
+			if v.IsEqualMiningPubKey(common.BlsConsensus, userPk) {
+				return common.CommitteeRole, chain.GetShardID()
+			}
+		}
+	}
+	return "", -2
+}
+
+// RequestMissingViewViaStream does nothing, the chains are shared by the nodes: a view known to a proposer is known to
+// every node
+func (n *node) RequestMissingViewViaStream(peerID string, hashes [][]byte, fromCID int, chainName string) error {
+	return nil
+}
+
+func (n *node) GetSelfPeerID() peer.ID {
+	return n.peerID
+}
@@ -0,0 +1,380 @@
+package incdb
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/pkg/errors"
+)
+
+// A backup of a database is a chain of increments in a backup folder, one sub folder per epoch. The first increment
+// of a chain is a full copy of the database files, the next ones only hold the files added or changed since the
+// previous increment: the SST files holding the blocks and the trie nodes never change once written, so an increment
+// mostly holds the SST files written during its epoch. The manifest of an increment lists all the files of the
+// database with their content hash and the increment storing them, and the chain height and state roots it covers.
+const (
+	BackupManifestFile   = "manifest.json"
+	MaxBackupIncrements  = 10 // increments of a chain, the next backup is a full one
+	backupManifestFormat = 1
+)
+
+// BackupInfo - the chain state covered by a backup
+type BackupInfo struct {
+	ChainID   int
+	Epoch     uint64
+	Height    uint64
+	BlockHash common.Hash
+	Roots     map[string]common.Hash
+}
+
+// BackupFile - a file of the database, stored in the increment of epoch Epoch
+type BackupFile struct {
+	Name  string
+	Size  int64
+	Hash  common.Hash // sha256 of the content
+	Epoch uint64
+}
+
+// BackupManifest - the content of an increment of a backup
+type BackupManifest struct {
+	Format int
+	BackupInfo
+	PreviousEpoch        uint64      // 0 for a full backup
+	PreviousManifestHash common.Hash // sha256 of the manifest file of the previous increment
+	Files                []BackupFile
+	Timestamp            int64
+}
+
+// IsFull - whether the increment is the first of its chain
+func (manifest *BackupManifest) IsFull() bool {
+	return manifest.PreviousEpoch == 0
+}
+
+// StoredFiles - the files stored in the increment itself
+func (manifest *BackupManifest) StoredFiles() []BackupFile {
+	files := []BackupFile{}
+	for _, file := range manifest.Files {
+		if file.Epoch == manifest.Epoch {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// BackupIncrementPath - the folder of the increment of epoch
+func BackupIncrementPath(backupFolder string, epoch uint64) string {
+	return filepath.Join(backupFolder, strconv.FormatUint(epoch, 10))
+}
+
+// ReadBackupManifest - the manifest of the increment of epoch, and the sha256 of its file
+func ReadBackupManifest(backupFolder string, epoch uint64) (*BackupManifest, common.Hash, error) {
+	data, err := ioutil.ReadFile(filepath.Join(BackupIncrementPath(backupFolder, epoch), BackupManifestFile))
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	manifest := &BackupManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, common.Hash{}, errors.Wrapf(err, "manifest of epoch %v", epoch)
+	}
+	if manifest.Format != backupManifestFormat {
+		return nil, common.Hash{}, fmt.Errorf("manifest of epoch %v has unknown format %v", epoch, manifest.Format)
+	}
+	if manifest.Epoch != epoch {
+		return nil, common.Hash{}, fmt.Errorf("manifest of epoch %v is for epoch %v", epoch, manifest.Epoch)
+	}
+	return manifest, sha256.Sum256(data), nil
+}
+
+// BackupEpochs - the epochs of the complete increments of the backup folder, lowest first. An increment is complete
+// once its manifest is written
+func BackupEpochs(backupFolder string) ([]uint64, error) {
+	files, err := ioutil.ReadDir(backupFolder)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	epochs := []uint64{}
+	for _, file := range files {
+		epoch, err := strconv.ParseUint(file.Name(), 10, 64)
+		if err != nil || !file.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(backupFolder, file.Name(), BackupManifestFile)); err != nil {
+			continue
+		}
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+	return epochs, nil
+}
+
+// LatestBackupEpoch - the epoch of the latest complete increment, 0 without any
+func LatestBackupEpoch(backupFolder string) (uint64, error) {
+	epochs, err := BackupEpochs(backupFolder)
+	if err != nil || len(epochs) == 0 {
+		return 0, err
+	}
+	return epochs[len(epochs)-1], nil
+}
+
+// BackupChain - the manifests of the increments restoring the increment of epoch, the full backup first
+func BackupChain(backupFolder string, epoch uint64) ([]*BackupManifest, error) {
+	chain := []*BackupManifest{}
+	var nextManifest *BackupManifest
+	for {
+		manifest, manifestHash, err := ReadBackupManifest(backupFolder, epoch)
+		if err != nil {
+			return nil, err
+		}
+		if nextManifest != nil && !nextManifest.PreviousManifestHash.IsEqual(&manifestHash) {
+			return nil, fmt.Errorf("manifest of epoch %v is not the one increment %v is based on", epoch, nextManifest.Epoch)
+		}
+		chain = append([]*BackupManifest{manifest}, chain...)
+		if manifest.IsFull() {
+			return chain, nil
+		}
+		if manifest.PreviousEpoch >= epoch {
+			return nil, fmt.Errorf("increment %v is based on the later increment %v", epoch, manifest.PreviousEpoch)
+		}
+		nextManifest = manifest
+		epoch = manifest.PreviousEpoch
+	}
+}
+
+// CreateBackupIncrement - back up the files of the database at dbPath in a new increment of the backup folder. The
+// database must not be written meanwhile. The increment is built in a temporary folder and renamed once complete, a
+// failed backup leaves the previous increments as they are
+func CreateBackupIncrement(dbPath string, backupFolder string, info BackupInfo) (*BackupManifest, error) {
+	latestEpoch, err := LatestBackupEpoch(backupFolder)
+	if err != nil {
+		return nil, err
+	}
+	if latestEpoch >= info.Epoch {
+		return nil, fmt.Errorf("epoch %v is already backed up, latest backup is at epoch %v", info.Epoch, latestEpoch)
+	}
+	manifest := &BackupManifest{
+		Format:     backupManifestFormat,
+		BackupInfo: info,
+		Timestamp:  time.Now().Unix(),
+	}
+	// the files of the previous increment, unless the chain is long enough for a full backup
+	previousFiles := make(map[string]BackupFile)
+	if latestEpoch > 0 {
+		chain, err := BackupChain(backupFolder, latestEpoch)
+		if err == nil && len(chain) < MaxBackupIncrements {
+			_, previousManifestHash, err := ReadBackupManifest(backupFolder, latestEpoch)
+			if err != nil {
+				return nil, err
+			}
+			manifest.PreviousEpoch = latestEpoch
+			manifest.PreviousManifestHash = previousManifestHash
+			for _, file := range chain[len(chain)-1].Files {
+				previousFiles[file.Name] = file
+			}
+		}
+	}
+	incrementPath := BackupIncrementPath(backupFolder, info.Epoch)
+	tempPath := incrementPath + ".tmp"
+	if err := os.RemoveAll(tempPath); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(tempPath, 0700); err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if file.IsDir() || !isBackupDatabaseFile(file.Name()) {
+			continue
+		}
+		hash, err := hashFile(filepath.Join(dbPath, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		backupFile := BackupFile{Name: file.Name(), Size: file.Size(), Hash: hash, Epoch: info.Epoch}
+		if previousFile, ok := previousFiles[file.Name()]; ok && previousFile.Hash.IsEqual(&hash) && previousFile.Size == file.Size() {
+			backupFile.Epoch = previousFile.Epoch
+		} else if _, err := copyFile(filepath.Join(dbPath, file.Name()), filepath.Join(tempPath, file.Name())); err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, backupFile)
+	}
+	data, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tempPath, BackupManifestFile), data, 0600); err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(incrementPath); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tempPath, incrementPath); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// RemoveUnusedBackups - remove the increments of the chains before the previous full backup, the latest chain and
+// the one before are kept
+func RemoveUnusedBackups(backupFolder string) error {
+	epochs, err := BackupEpochs(backupFolder)
+	if err != nil {
+		return err
+	}
+	fullEpochs := []uint64{}
+	for _, epoch := range epochs {
+		manifest, _, err := ReadBackupManifest(backupFolder, epoch)
+		if err != nil {
+			continue
+		}
+		if manifest.IsFull() {
+			fullEpochs = append(fullEpochs, epoch)
+		}
+	}
+	if len(fullEpochs) < 2 {
+		return nil
+	}
+	keptEpoch := fullEpochs[len(fullEpochs)-2]
+	for _, epoch := range epochs {
+		if epoch < keptEpoch {
+			if err := os.RemoveAll(BackupIncrementPath(backupFolder, epoch)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// VerifyBackup - check the increment of epoch can be restored, without restoring it: the chain of manifests is
+// complete and every file of the database is stored with its size and content hash
+func VerifyBackup(backupFolder string, epoch uint64) (*BackupManifest, error) {
+	chain, err := BackupChain(backupFolder, epoch)
+	if err != nil {
+		return nil, err
+	}
+	manifest := chain[len(chain)-1]
+	chainEpochs := make(map[uint64]bool)
+	for _, increment := range chain {
+		chainEpochs[increment.Epoch] = true
+	}
+	hasCurrent := false
+	for _, file := range manifest.Files {
+		if !chainEpochs[file.Epoch] {
+			return nil, fmt.Errorf("file %v is stored by increment %v, not in the chain of increment %v", file.Name, file.Epoch, epoch)
+		}
+		if err := VerifyBackupFile(backupFolder, file); err != nil {
+			return nil, err
+		}
+		if file.Name == "CURRENT" {
+			hasCurrent = true
+		}
+	}
+	if !hasCurrent {
+		return nil, fmt.Errorf("increment %v has no CURRENT file", epoch)
+	}
+	return manifest, nil
+}
+
+// RestoreBackup - restore the database files of the increment of epoch in dbPath, which must not exist. The files
+// are checked against the manifest while copied
+func RestoreBackup(backupFolder string, epoch uint64, dbPath string) (*BackupManifest, error) {
+	manifest, err := VerifyBackup(backupFolder, epoch)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(dbPath); err == nil {
+		return nil, fmt.Errorf("%v already exists", dbPath)
+	}
+	if err := os.MkdirAll(dbPath, 0700); err != nil {
+		return nil, err
+	}
+	for _, file := range manifest.Files {
+		hash, err := copyFile(filepath.Join(BackupIncrementPath(backupFolder, file.Epoch), file.Name), filepath.Join(dbPath, file.Name))
+		if err != nil {
+			return nil, err
+		}
+		if !hash.IsEqual(&file.Hash) {
+			return nil, fmt.Errorf("file %v of increment %v changed while restored", file.Name, file.Epoch)
+		}
+	}
+	return manifest, nil
+}
+
+// VerifyBackupFile - check a file is stored in its increment with its size and content hash
+func VerifyBackupFile(backupFolder string, file BackupFile) error {
+	path := filepath.Join(BackupIncrementPath(backupFolder, file.Epoch), file.Name)
+	stat, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if stat.Size() != file.Size {
+		return fmt.Errorf("file %v of increment %v has size %v, expected %v", file.Name, file.Epoch, stat.Size(), file.Size)
+	}
+	hash, err := hashFile(path)
+	if err != nil {
+		return err
+	}
+	if !hash.IsEqual(&file.Hash) {
+		return fmt.Errorf("file %v of increment %v has hash %v, expected %v", file.Name, file.Epoch, hash.String(), file.Hash.String())
+	}
+	return nil
+}
+
+// isBackupDatabaseFile - the files of a leveldb database needed to open it, the lock and the logs are not
+func isBackupDatabaseFile(name string) bool {
+	return name != "LOCK" && !strings.HasPrefix(name, "LOG") && !strings.HasSuffix(name, ".tmp")
+}
+
+func hashFile(path string) (common.Hash, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer fd.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, fd); err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(hasher.Sum(nil)), nil
+}
+
+// copyFile - copy src to dst, and return the sha256 of the content
+func copyFile(src string, dst string) (common.Hash, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(out, hasher), in); err != nil {
+		out.Close()
+		return common.Hash{}, err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return common.Hash{}, err
+	}
+	if err := out.Close(); err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(hasher.Sum(nil)), nil
+}
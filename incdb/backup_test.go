@@ -0,0 +1,120 @@
+package incdb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func Test_BackupIncrements(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_backup_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dbPath := filepath.Join(dir, "db")
+	backupFolder := filepath.Join(dir, "backup")
+	if err := os.MkdirAll(dbPath, 0700); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFiles(t, dbPath, map[string]string{
+		"CURRENT":         "MANIFEST-000002\n",
+		"MANIFEST-000002": "manifest 1",
+		"000001.ldb":      "blocks",
+		"000002.log":      "journal 1",
+		"LOCK":            "",
+		"LOG":             "log",
+	})
+	info := BackupInfo{ChainID: -1, Epoch: 1, Height: 10, BlockHash: common.HashH([]byte("10")), Roots: map[string]common.Hash{"ConsensusStateDBRootHash": common.HashH([]byte("root"))}}
+	full, err := CreateBackupIncrement(dbPath, backupFolder, info)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, full.IsFull())
+	assert.Equal(t, 4, len(full.StoredFiles()))
+
+	// the next epoch writes a table and changes the manifest and the journal
+	writeTestFiles(t, dbPath, map[string]string{
+		"MANIFEST-000002": "manifest 2",
+		"000003.ldb":      "more blocks",
+		"000002.log":      "journal 2",
+	})
+	info.Epoch, info.Height = 2, 20
+	increment, err := CreateBackupIncrement(dbPath, backupFolder, info)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, increment.IsFull())
+	assert.Equal(t, uint64(1), increment.PreviousEpoch)
+	assert.Equal(t, 5, len(increment.Files))
+	assert.Equal(t, 3, len(increment.StoredFiles()))
+	_, err = CreateBackupIncrement(dbPath, backupFolder, info)
+	assert.NotEqual(t, nil, err)
+
+	manifest, err := VerifyBackup(backupFolder, 2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(20), manifest.Height)
+	assert.Equal(t, info.Roots, manifest.Roots)
+
+	restorePath := filepath.Join(dir, "restore")
+	_, err = RestoreBackup(backupFolder, 2, restorePath)
+	assert.Equal(t, nil, err)
+	for _, name := range []string{"CURRENT", "MANIFEST-000002", "000001.ldb", "000002.log", "000003.ldb"} {
+		want, _ := ioutil.ReadFile(filepath.Join(dbPath, name))
+		got, err := ioutil.ReadFile(filepath.Join(restorePath, name))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, want, got)
+	}
+	_, err = os.Stat(filepath.Join(restorePath, "LOCK"))
+	assert.Equal(t, true, os.IsNotExist(err))
+
+	// a damaged file of the full backup breaks the increments based on it
+	writeTestFiles(t, BackupIncrementPath(backupFolder, 1), map[string]string{"000001.ldb": "bl0cks"})
+	_, err = VerifyBackup(backupFolder, 2)
+	assert.NotEqual(t, nil, err)
+	_, err = RestoreBackup(backupFolder, 2, filepath.Join(dir, "restore2"))
+	assert.NotEqual(t, nil, err)
+
+	// so does a rewritten manifest
+	writeTestFiles(t, BackupIncrementPath(backupFolder, 1), map[string]string{"000001.ldb": "blocks"})
+	_, err = VerifyBackup(backupFolder, 2)
+	assert.Equal(t, nil, err)
+	data, _ := ioutil.ReadFile(filepath.Join(BackupIncrementPath(backupFolder, 1), BackupManifestFile))
+	writeTestFiles(t, BackupIncrementPath(backupFolder, 1), map[string]string{BackupManifestFile: string(data) + "\n"})
+	_, err = VerifyBackup(backupFolder, 2)
+	assert.NotEqual(t, nil, err)
+}
+
+func Test_RemoveUnusedBackups(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_backup_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dbPath := filepath.Join(dir, "db")
+	backupFolder := filepath.Join(dir, "backup")
+	if err := os.MkdirAll(dbPath, 0700); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFiles(t, dbPath, map[string]string{"CURRENT": "MANIFEST-000001\n"})
+	for epoch := uint64(1); epoch <= 3*MaxBackupIncrements; epoch++ {
+		_, err := CreateBackupIncrement(dbPath, backupFolder, BackupInfo{Epoch: epoch})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, nil, RemoveUnusedBackups(backupFolder))
+	}
+	// the latest chain and the one before
+	epochs, err := BackupEpochs(backupFolder)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2*MaxBackupIncrements, len(epochs))
+	assert.Equal(t, uint64(MaxBackupIncrements+1), epochs[0])
+	_, err = VerifyBackup(backupFolder, 3*MaxBackupIncrements)
+	assert.Equal(t, nil, err)
+}
@@ -55,10 +55,14 @@ type Database interface {
 	Stater
 	Compacter
 	io.Closer
+	// RemoveBackup removes the increment of a backup, a path relative to the database
 	RemoveBackup(string)
-	Backup(backupFolder string) error
+	// Backup adds an increment of the epoch of info to the backup folder, a path relative to the database
+	Backup(backupFolder string, info BackupInfo) error
+	// LatestBackup returns the epoch and the path of the latest increment of the backup folder
 	LatestBackup(backupFolder string) (int, string)
-	PreloadBackup(backupFile string) error
+	// PreloadBackup replaces the database by the one restored from the increment at backupPath
+	PreloadBackup(backupPath string) error
 	ReOpen() error
 	Clear() error
 }
@@ -3,15 +3,11 @@ package lvdb
 import (
 	"fmt"
 	"io/ioutil"
-	"log"
 	"os"
 	"path/filepath"
 	"strconv"
-	"strings"
 	"sync"
 
-	"github.com/incognitochain/incognito-chain/common"
-
 	"github.com/pkg/errors"
 
 	"github.com/incognitochain/incognito-chain/incdb"
@@ -251,9 +247,17 @@ func (db *db) Batch(data []incdb.BatchData) leveldb.Batch {
 	return *batch
 }
 
-func (db *db) PreloadBackup(backupFile string) error {
-	err := uncompress(backupFile, db.dbPath+"_")
+func (db *db) PreloadBackup(backupPath string) error {
+	backupFolder, epochName := filepath.Split(filepath.Clean(backupPath))
+	epoch, err := strconv.ParseUint(epochName, 10, 64)
 	if err != nil {
+		return errors.Wrapf(err, "backup increment %s", backupPath)
+	}
+	if err := os.RemoveAll(db.dbPath + "_"); err != nil {
+		return err
+	}
+	incdb.Logger.Log.Infof("Start restoring backup increment %v", backupPath)
+	if _, err := incdb.RestoreBackup(backupFolder, epoch, db.dbPath+"_"); err != nil {
 		return err
 	}
 
@@ -272,61 +276,71 @@ func (db *db) PreloadBackup(backupFile string) error {
 
 func (db *db) LatestBackup(path string) (int, string) {
 	backupFolder := filepath.Join(db.dbPath, path)
-	//fmt.Println("backupFolder", backupFolder)
-	files, err := ioutil.ReadDir(backupFolder)
+	latestIncrementEpoch, err := incdb.LatestBackupEpoch(backupFolder)
 	if err != nil {
 		return 0, ""
 	}
-	//fmt.Println("files", files)
-	if len(files) == 0 {
+	if latestIncrementEpoch > 0 {
+		return int(latestIncrementEpoch), incdb.BackupIncrementPath(backupFolder, latestIncrementEpoch)
+	}
+	// the other backups, like the one of the btc relaying chain, are compressed files named by epoch
+	files, err := ioutil.ReadDir(backupFolder)
+	if err != nil {
 		return 0, ""
 	}
 	latestBackupEpoch := 0
-	//Get max epoch
 	for _, file := range files {
-		//fmt.Println("file", file.Name())
 		epoch, err := strconv.Atoi(file.Name())
-		if err != nil {
-			return 0, ""
+		if err != nil || file.IsDir() {
+			continue
 		}
 		if epoch > latestBackupEpoch {
 			latestBackupEpoch = epoch
 		}
 	}
-
+	if latestBackupEpoch == 0 {
+		return 0, ""
+	}
 	return latestBackupEpoch, fmt.Sprintf("%v/%v", backupFolder, latestBackupEpoch)
 }
 
-func (db *db) RemoveBackup(backupFile string) {
-	backupFile = filepath.Join(db.dbPath, backupFile)
-	os.Remove(backupFile)
+func (db *db) RemoveBackup(backupPath string) {
+	backupPath = filepath.Join(db.dbPath, backupPath)
+	os.RemoveAll(backupPath)
+	os.RemoveAll(backupPath + ".tmp")
 }
 
-func (db *db) Backup(backupFile string) error {
+func (db *db) Backup(backupFolder string, info incdb.BackupInfo) error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
 
-	backupFile = filepath.Join(db.dbPath, backupFile)
-	fmt.Println("backupFile", backupFile)
+	backupFolder = filepath.Join(db.dbPath, backupFolder)
+	// a chain can reach the backup point of an epoch more than once
+	if latestEpoch, err := incdb.LatestBackupEpoch(backupFolder); err == nil && latestEpoch >= info.Epoch {
+		return nil
+	}
+	incdb.Logger.Log.Infof("Backup database to %v at epoch %v", backupFolder, info.Epoch)
 
-	if err := os.MkdirAll(filepath.Dir(backupFile), 0700); err != nil {
+	if err := os.MkdirAll(backupFolder, 0700); err != nil {
 		panic(err)
 	}
 
+	// the files of the database do not change while closed
 	if err := db.Close(); err != nil {
 		return err
 	}
 
-	err := common.CompressDatabase(db.dbPath, backupFile)
-	if err != nil {
-		return err
-	}
+	manifest, err := incdb.CreateBackupIncrement(db.dbPath, backupFolder, info)
 
 	if err := db.ReOpen(); err != nil {
 		panic(err)
 	}
+	if err != nil {
+		return err
+	}
+	incdb.Logger.Log.Infof("Backup database to %v at epoch %v done, stored %v of %v files", backupFolder, info.Epoch, len(manifest.StoredFiles()), len(manifest.Files))
 
-	if err := removeUnusedBackupDatabase(backupFile); err != nil {
+	if err := incdb.RemoveUnusedBackups(backupFolder); err != nil {
 		panic(err)
 	}
 
@@ -346,80 +360,3 @@ func (db *db) Clear() error {
 	}
 	return nil
 }
-
-//removeUnusedBackupDatabase ...
-// for remove unused databases in backup folder
-func removeUnusedBackupDatabase(filePath string) error {
-	strs := strings.Split(filePath, "/")
-
-	//Get latest epoch
-	latestEpoch, err := strconv.Atoi(strs[len(strs)-1])
-	if err != nil {
-		return err
-	}
-
-	//Get path directory of this file
-	path := filePath
-	for i := len(path) - 1; i > -1; i-- {
-		if path[i] != '/' {
-			path = path[:len(path)-1]
-		} else {
-			log.Println(1)
-			break
-		}
-	}
-
-	//Get needed epoch to download
-	files, err := ioutil.ReadDir(path)
-	if err != nil {
-		return err
-	}
-	if len(files) == 0 {
-		return err
-	}
-
-	//Get file name and compare with latest epoch
-	for _, file := range files {
-
-		epoch, err := strconv.Atoi(file.Name())
-		if err != nil {
-			return err
-		}
-
-		if epoch != latestEpoch && epoch != latestEpoch-1 {
-			name := path + "/" + file.Name()
-			err = os.Remove(name)
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-//Uncompress file from zip file
-func uncompress(srcPath, desPath string) error {
-
-	//uncompress write
-	//Remove all old data
-	// if err := os.RemoveAll(srcPath); err != nil {
-	// 	panic(err)
-	// }
-	fmt.Println("start decompress", srcPath)
-	if err := os.RemoveAll(desPath); err != nil {
-		panic(err)
-	}
-	//Create new data
-	if err := os.MkdirAll(desPath, 0700); err != nil {
-		panic(err)
-	}
-
-	err := common.DecompressDatabaseBackup(srcPath, desPath)
-	if err != nil {
-		return err
-	}
-
-	fmt.Println("done decompress", desPath)
-	return nil
-}
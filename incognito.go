@@ -18,12 +18,16 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	_ "github.com/incognitochain/incognito-chain/consensus/blsbft"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/databasemp"
-	_ "github.com/incognitochain/incognito-chain/databasemp/lvdb"
+	_ "github.com/incognitochain/incognito-chain/databasemp/journal"
+	"github.com/incognitochain/incognito-chain/devnet"
 	"github.com/incognitochain/incognito-chain/incdb"
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
 	"github.com/incognitochain/incognito-chain/limits"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	"github.com/incognitochain/incognito-chain/tracing"
+	"github.com/incognitochain/incognito-chain/trie"
 	"github.com/incognitochain/incognito-chain/wallet"
 )
 
@@ -90,6 +94,26 @@ func mainMaster(serverChan chan<- *Server) error {
 	if interruptRequested(interrupt) {
 		return nil
 	}
+	if cfg.TracingExporter != common.EmptyString {
+		tracingTarget := cfg.TracingEndpoint
+		if cfg.TracingExporter == tracing.ExporterFile {
+			tracingTarget = cfg.TracingFile
+			if tracingTarget == common.EmptyString {
+				tracingTarget = filepath.Join(cfg.DataDir, DefaultTracingFile)
+			}
+		}
+		exporter, err := tracing.NewExporter(cfg.TracingExporter, tracingTarget)
+		if err != nil {
+			Logger.log.Error(err)
+			return err
+		}
+		if err := tracing.Init(tracing.Config{Exporter: exporter, SampleRatio: cfg.TracingSampleRatio}); err != nil {
+			Logger.log.Error(err)
+			return err
+		}
+		defer tracing.Shutdown()
+		Logger.log.Infof("Tracing is enabled with %s exporter", cfg.TracingExporter)
+	}
 	db, err := incdb.OpenMultipleDB("leveldb", filepath.Join(cfg.DataDir, cfg.DatabaseDir))
 	// Create db and use it.
 	if err != nil {
@@ -97,13 +121,25 @@ func mainMaster(serverChan chan<- *Server) error {
 		Logger.log.Error(err)
 		panic(err)
 	}
+	// the state databases of every chain read the trie nodes through one cache
+	statedb.SetNodeCache(trie.NewNodeCache(cfg.TrieNodeCache * 1024 * 1024))
 	// Create db for mempool and use it
-	dbmp, err := databasemp.Open("leveldbmempool", filepath.Join(cfg.DataDir, cfg.DatabaseMempoolDir))
+	dbmp, err := databasemp.Open("journalmempool", filepath.Join(cfg.DataDir, cfg.DatabaseMempoolDir))
 	if err != nil {
 		Logger.log.Error("could not open connection to leveldb")
 		Logger.log.Error(err)
 		panic(err)
 	}
+	var devnetObj *devnet.Devnet
+	if cfg.Devnet {
+		devnetObj, err = setupDevnet(db)
+		if err != nil {
+			Logger.log.Error(err)
+			return err
+		}
+		// the devnet is not kept between runs
+		defer os.RemoveAll(filepath.Dir(cfg.DataDir))
+	}
 	// Check wallet and start it
 	var walletObj *wallet.Wallet
 	if cfg.Wallet {
@@ -166,6 +202,7 @@ func mainMaster(serverChan chan<- *Server) error {
 	// Create server and start it.
 	server := Server{}
 	server.wallet = walletObj
+	server.devnet = devnetObj
 	activeNetParams.Params.IsBackup = cfg.ForceBackup
 	err = server.NewServer(cfg.Listener, db, dbmp, activeNetParams.Params, version, btcChain, bnbChainState, interrupt)
 	if err != nil {
@@ -196,6 +233,40 @@ func mainMaster(serverChan chan<- *Server) error {
 	return nil
 
 }
+
+// setupDevnet generates the keys of the devnet and its network params, the genesis coins are checked against the empty
+// state of db
+func setupDevnet(db map[int]incdb.Database) (*devnet.Devnet, error) {
+	devnetObj, err := devnet.New(devnet.Config{
+		Shards:        cfg.DevnetShards,
+		CommitteeSize: cfg.DevnetCommitteeSize,
+		BlockTime:     cfg.DevnetBlockTime,
+		Accounts:      cfg.DevnetAccounts,
+		Balance:       devnet.DefaultBalance,
+		Seed:          cfg.DevnetSeed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	stateDB, err := statedb.NewWithPrefixTrie(common.EmptyRoot, statedb.NewDatabaseAccessWarper(db[common.BeaconChainDataBaseID]))
+	if err != nil {
+		return nil, err
+	}
+	if err := devnetObj.SetupParams(activeNetParams.Params, stateDB); err != nil {
+		return nil, err
+	}
+	blockchain.GenesisParam = activeNetParams.Params.GenesisParams
+	keysFile := filepath.Join(cfg.DataDir, DefaultDevnetKeysFilename)
+	if err := devnetObj.WriteKeys(keysFile); err != nil {
+		return nil, err
+	}
+	Logger.log.Infof("Devnet keys are written to %v", keysFile)
+	for _, account := range devnetObj.Accounts() {
+		Logger.log.Infof("Devnet account of shard %v: %v", account.ShardID, account.PrivateKey)
+	}
+	return devnetObj, nil
+}
+
 func main() {
 	limitThreads := os.Getenv("CPU")
 	if limitThreads == "" {
@@ -237,6 +308,10 @@ func main() {
 			os.Exit(common.ExitCodeUnknow)
 		}
 	}
+	// "incognito devnet [flags]" is "incognito --devnet [flags]"
+	if len(os.Args) > 1 && os.Args[1] == devnet.Name {
+		os.Args = append([]string{os.Args[0], "--devnet"}, os.Args[2:]...)
+	}
 	// Work around defer not working after os.Exit()
 	if err := mainMaster(nil); err != nil {
 		os.Exit(common.ExitByOs)
@@ -8,7 +8,9 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/incognitochain/incognito-chain/devnet"
 	"github.com/incognitochain/incognito-chain/syncker"
+	"github.com/incognitochain/incognito-chain/tracing"
 
 	"github.com/incognitochain/incognito-chain/addrmanager"
 	"github.com/incognitochain/incognito-chain/blockchain"
@@ -17,6 +19,7 @@ import (
 	"github.com/incognitochain/incognito-chain/connmanager"
 	"github.com/incognitochain/incognito-chain/consensus"
 	"github.com/incognitochain/incognito-chain/databasemp"
+	"github.com/incognitochain/incognito-chain/databasemp/journal"
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -29,6 +32,7 @@ import (
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/incognitochain/incognito-chain/trie"
+	"github.com/incognitochain/incognito-chain/txrelay"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/jrick/logrotate/rotator"
 )
@@ -65,6 +69,9 @@ var (
 	daov2Logger            = backendLog.Logger("DAO log", false)
 	btcRelayingLogger      = backendLog.Logger("BTC relaying log", false)
 	synckerLogger          = backendLog.Logger("Syncker log ", false)
+	tracingLogger          = backendLog.Logger("Tracing log", false)
+	devnetLogger           = backendLog.Logger("Devnet log", false)
+	txRelayLogger          = backendLog.Logger("Tx relay log", false)
 )
 
 // logWriter implements an io.Writer that outputs to both standard output and
@@ -98,6 +105,7 @@ func init() {
 	transaction.Logger.Init(transactionLogger)
 	privacy.Logger.Init(privacyLogger)
 	databasemp.Logger.Init(dbmpLogger)
+	journal.Logger.Init(dbmpLogger)
 	blockchain.BLogger.Init(bridgeLogger)
 	rpcserver.BLogger.Init(bridgeLogger)
 	metadata.Logger.Init(metadataLogger)
@@ -108,6 +116,9 @@ func init() {
 	dataaccessobject.Logger.Init(daov2Logger)
 	btcRelaying.Logger.Init(btcRelayingLogger)
 	syncker.Logger.Init(synckerLogger)
+	tracing.Logger.Init(tracingLogger)
+	devnet.Logger.Init(devnetLogger)
+	txrelay.Logger.Init(txRelayLogger)
 }
 
 // subsystemLoggers maps each subsystem identifier to its associated logger.
@@ -137,6 +148,9 @@ var subsystemLoggers = map[string]common.Logger{
 	"DAO":               daov2Logger,
 	"BTCRELAYING":       btcRelayingLogger,
 	"SYNCKER":           synckerLogger,
+	"TRACING":           tracingLogger,
+	"DEVNET":            devnetLogger,
+	"TXRELAY":           txRelayLogger,
 }
 
 // initLogRotator initializes the logging rotater to write logs to logFile and
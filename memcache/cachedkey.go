@@ -15,6 +15,20 @@ func GetListOutputcoinCachedKey(publicKey []byte, tokenID *common.Hash, shardID
 	return key
 }
 
+// GetListStealthOutputcoinCachedKey - build key on memcache for the stealth output coins of the receiver of
+// receivingKey and publicKey, the key holds a hash of the receiving key only
+func GetListStealthOutputcoinCachedKey(receivingKey []byte, publicKey []byte, tokenID *common.Hash, shardID byte) []byte {
+	key := make([]byte, 0)
+	key = append(key, []byte(splitChar)...)
+	key = append(key, []byte(stealthOutputCoinCacheKey)...)
+	key = append(key, []byte(splitChar)...)
+	key = append(key, common.HashB(append(append([]byte{}, receivingKey...), publicKey...))...)
+	key = append(key, []byte(splitChar)...)
+	key = append(key, tokenID.GetBytes()...)
+	key = append(key, shardID)
+	return key
+}
+
 func GetShardBestStateCachedKey() []byte {
 	key := make([]byte, 0)
 	key = append(key, []byte(shardBestStateCacheKey)...)
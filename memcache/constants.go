@@ -3,6 +3,7 @@ package memcache
 const (
 	splitChar                             = "-"
 	outputCoinCacheKey                    = "listoutputcoin"
+	stealthOutputCoinCacheKey             = "liststealthoutputcoin"
 	shardBestStateCacheKey                = "shardbeststate"
 	beaconBestStateCacheKey               = "beaconbeststate"
 	getBlocksCacheKey                     = "getblocks"
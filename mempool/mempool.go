@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/incognitochain/incognito-chain/incdb"
@@ -41,6 +42,7 @@ type Config struct {
 	MaxTx             uint64                 //Max transaction pool may have
 	IsLoadFromMempool bool                   //Reset mempool database when run node
 	PersistMempool    bool
+	RebroadcastBlocks uint64 // local transactions not in a block are relayed again after this number of blocks of their shard, 0 to disable
 	RelayShards       []byte
 	// UserKeyset            *incognitokey.KeySet
 	PubSubManager         *pubsub.PubSubManager
@@ -52,6 +54,9 @@ type TxDesc struct {
 	Desc            metadata.TxDesc // transaction details
 	StartTime       time.Time       //Unix Time that transaction enter mempool
 	IsFowardMessage bool
+	IsLocal         bool   // transaction is sent to the rpc server of this node
+	RelayHeight     uint64 // best height of the shard of the sender when the transaction was last relayed
+	IsStem          bool   // transaction relayed privately, it is not broadcast until its stem phase is over
 }
 
 type TxPool struct {
@@ -208,15 +213,39 @@ func (tp *TxPool) MonitorPool() {
 // #1: tx
 // #2: default nil, contain input coins hash, which are used for creating this tx
 func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction, beaconHeight int64) (*common.Hash, *TxDesc, error) {
+	return tp.maybeAcceptTransactionFrom(tx, beaconHeight, false, false)
+}
+
+// MaybeAcceptLocalTransaction - MaybeAcceptTransaction for a transaction sent to the rpc server of this node,
+// it is relayed again while it is not in a block
+func (tp *TxPool) MaybeAcceptLocalTransaction(tx metadata.Transaction, beaconHeight int64) (*common.Hash, *TxDesc, error) {
+	return tp.maybeAcceptTransactionFrom(tx, beaconHeight, true, false)
+}
+
+// MaybeAcceptStemTransaction - MaybeAcceptTransaction for a transaction relayed privately, sent to the rpc server of
+// this node or received from the stem, it is not broadcast by the mempool until it is marked forwarded
+func (tp *TxPool) MaybeAcceptStemTransaction(tx metadata.Transaction, beaconHeight int64, isLocal bool) (*common.Hash, *TxDesc, error) {
+	return tp.maybeAcceptTransactionFrom(tx, beaconHeight, isLocal, true)
+}
+
+func (tp *TxPool) maybeAcceptTransactionFrom(tx metadata.Transaction, beaconHeight int64, isLocal bool, isStem bool) (*common.Hash, *TxDesc, error) {
 	//beaconView.BeaconHeight
 	tp.mtx.Lock()
 	defer tp.mtx.Unlock()
 	if tp.IsTest {
 		return &common.Hash{}, &TxDesc{}, nil
 	}
-	go func(txHash common.Hash) {
-		tp.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.TransactionHashEnterNodeTopic, txHash))
-	}(*tx.Hash())
+	if !isStem {
+		// the stem phase of the transaction is over once it is broadcast by another node
+		if txDesc, ok := tp.pool[*tx.Hash()]; ok && txDesc.IsStem {
+			txDesc.IsStem = false
+			txDesc.IsFowardMessage = true
+		}
+		// the hash of a stem transaction is not cached by netsync so that its broadcast still reaches the mempool
+		go func(txHash common.Hash) {
+			tp.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.TransactionHashEnterNodeTopic, txHash))
+		}(*tx.Hash())
+	}
 	senderShardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
 	if !tp.checkRelayShard(tx) && !tp.checkPublicKeyRole(tx) {
 		err := NewMempoolTxError(UnexpectedTransactionError, errors.New("Unexpected Transaction From Shard "+fmt.Sprintf("%d", senderShardID)))
@@ -229,11 +258,12 @@ func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction, beaconHeight i
 	if uint64(len(tp.pool)) >= tp.config.MaxTx {
 		return nil, nil, NewMempoolTxError(MaxPoolSizeError, errors.New("Pool reach max number of transaction"))
 	}
-	hash, txDesc, err := tp.maybeAcceptTransaction(shardView, beaconView, tx, tp.config.PersistMempool, true, beaconHeight)
+	hash, txDesc, err := tp.maybeAcceptTransaction(shardView, beaconView, tx, tp.config.PersistMempool, true, isLocal, beaconHeight)
 	//==========
 	if err != nil {
 		Logger.log.Error(err)
 	} else {
+		txDesc.IsStem = isStem
 		if tp.IsBlockGenStarted {
 			if tp.IsUnlockMempool {
 				go func(tx metadata.Transaction) {
@@ -258,7 +288,7 @@ func (tp *TxPool) MaybeAcceptTransactionForBlockProducing(tx metadata.Transactio
 		Logger.log.Error(err)
 		return nil, err
 	}
-	_, txDesc, err := tp.maybeAcceptTransaction(shardView, beaconView, tx, false, false, int64(bHeight))
+	_, txDesc, err := tp.maybeAcceptTransaction(shardView, beaconView, tx, false, false, false, int64(bHeight))
 	if err != nil {
 		Logger.log.Error(err)
 		return nil, err
@@ -267,7 +297,7 @@ func (tp *TxPool) MaybeAcceptTransactionForBlockProducing(tx metadata.Transactio
 	return tempTxDesc, err
 }
 
-func (tp *TxPool) MaybeAcceptBatchTransactionForBlockProducing(shardID byte, txs []metadata.Transaction, beaconHeight int64, shardView *blockchain.ShardBestState) ([]*metadata.TxDesc, error) {
+func (tp *TxPool) MaybeAcceptBatchTransactionForBlockProducing(ctx context.Context, shardID byte, txs []metadata.Transaction, beaconHeight int64, shardView *blockchain.ShardBestState) ([]*metadata.TxDesc, error) {
 	tp.mtx.Lock()
 	defer tp.mtx.Unlock()
 	bHeight := shardView.BestBlock.Header.BeaconHeight
@@ -277,15 +307,15 @@ func (tp *TxPool) MaybeAcceptBatchTransactionForBlockProducing(shardID byte, txs
 		Logger.log.Error(err)
 		return nil, err
 	}
-	_, txDesc, err := tp.maybeAcceptBatchTransaction(shardView, beaconView, shardID, txs, int64(bHeight))
+	_, txDesc, err := tp.maybeAcceptBatchTransaction(ctx, shardView, beaconView, shardID, txs, int64(bHeight))
 	return txDesc, err
 }
 
-func (tp *TxPool) maybeAcceptBatchTransaction(shardView *blockchain.ShardBestState, beaconView *blockchain.BeaconBestState, shardID byte, txs []metadata.Transaction, beaconHeight int64) ([]common.Hash, []*metadata.TxDesc, error) {
+func (tp *TxPool) maybeAcceptBatchTransaction(ctx context.Context, shardView *blockchain.ShardBestState, beaconView *blockchain.BeaconBestState, shardID byte, txs []metadata.Transaction, beaconHeight int64) ([]common.Hash, []*metadata.TxDesc, error) {
 	txDescs := []*metadata.TxDesc{}
 	txHashes := []common.Hash{}
 	batch := transaction.NewBatchTransaction(txs)
-	ok, err, _ := batch.Validate(shardView.GetCopiedTransactionStateDB(), beaconView.GetBeaconFeatureStateDB())
+	ok, err, _ := batch.ValidateWithContext(ctx, shardView.GetCopiedTransactionStateDB(), beaconView.GetBeaconFeatureStateDB())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -298,8 +328,7 @@ func (tp *TxPool) maybeAcceptBatchTransaction(shardView *blockchain.ShardBestSta
 		if err != nil {
 			return nil, nil, err
 		}
-		shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
-		bestHeight := tp.config.BlockChain.GetBestStateShard(byte(shardID)).BestBlock.Header.Height
+		bestHeight := shardView.BestBlock.Header.Height
 		txFee := tx.GetTxFee()
 		txFeeToken := tx.GetTxFeeToken()
 		txD := createTxDescMempool(tx, bestHeight, txFee, txFeeToken)
@@ -319,7 +348,7 @@ func (tp *TxPool) maybeAcceptBatchTransaction(shardView *blockchain.ShardBestSta
 // #2: store into db
 // #3: default nil, contain input coins hash, which are used for creating this tx
 */
-func (tp *TxPool) maybeAcceptTransaction(shardView *blockchain.ShardBestState, beaconView *blockchain.BeaconBestState, tx metadata.Transaction, isStore bool, isNewTransaction bool, beaconHeight int64) (*common.Hash, *TxDesc, error) {
+func (tp *TxPool) maybeAcceptTransaction(shardView *blockchain.ShardBestState, beaconView *blockchain.BeaconBestState, tx metadata.Transaction, isStore bool, isNewTransaction bool, isLocal bool, beaconHeight int64) (*common.Hash, *TxDesc, error) {
 	// validate tx
 	err := tp.validateTransaction(shardView, beaconView, tx, beaconHeight, false, isNewTransaction)
 	if err != nil {
@@ -330,6 +359,7 @@ func (tp *TxPool) maybeAcceptTransaction(shardView *blockchain.ShardBestState, b
 	txFee := tx.GetTxFee()
 	txFeeToken := tx.GetTxFeeToken()
 	txD := createTxDescMempool(tx, bestHeight, txFee, txFeeToken)
+	txD.IsLocal = isLocal
 	err = tp.addTx(txD, isStore)
 	if err != nil {
 		return nil, nil, err
@@ -494,8 +524,9 @@ func (tp *TxPool) validateTransaction(shardView *blockchain.ShardBestState, beac
 	if isSalaryTx {
 		return NewMempoolTxError(RejectSalaryTx, fmt.Errorf("%+v is salary tx", txHash.String()))
 	}
-	// Condition 4: check fee PRV of tx
-	validFee := tp.checkFees(beaconView, tx, shardID, beaconHeight)
+	// Condition 4: check fee PRV of tx, with the fee estimator of the shard validating it: the response txs of a
+	// block are sent by a temporary key, which may belong to any shard
+	validFee := tp.checkFees(beaconView, tx, shardView.ShardID, beaconHeight)
 	if !validFee {
 		return NewMempoolTxError(RejectInvalidFee,
 			fmt.Errorf("Transaction %+v has invalid fees.",
@@ -879,16 +910,58 @@ func (tp *TxPool) SendTransactionToBlockGen() {
 	tp.IsUnlockMempool = true
 }
 
-// MarkForwardedTransaction - mart a transaction is forward message
+// MarkForwardedTransaction - mart a transaction is forward message, it is out of its stem phase
 func (tp *TxPool) MarkForwardedTransaction(txHash common.Hash) {
-	tp.mtx.RLock()
-	defer tp.mtx.RUnlock()
+	tp.mtx.Lock()
+	defer tp.mtx.Unlock()
 	if tp.IsTest {
 		return
 	}
-	if _, ok := tp.pool[txHash]; ok {
-		tp.pool[txHash].IsFowardMessage = true
+	txDesc, ok := tp.pool[txHash]
+	if !ok {
+		return
+	}
+	txDesc.IsFowardMessage = true
+	txDesc.IsStem = false
+	senderShardID := common.GetShardIDFromLastByte(txDesc.Desc.Tx.GetSenderAddrLastByte())
+	txDesc.RelayHeight = tp.config.BlockChain.ShardChain[senderShardID].GetBestViewHeight()
+	// the relay status is kept for the rebroadcast of local transactions
+	if tp.config.PersistMempool {
+		if err := tp.addTransactionToDatabaseMempool(&txHash, *txDesc); err != nil {
+			Logger.log.Errorf("Fail to update tx %+v in mempool database %+v \n", txHash, err)
+		}
+	}
+}
+
+// IsStemTransaction - transaction is in the pool and still in its stem phase
+func (tp *TxPool) IsStemTransaction(txHash common.Hash) bool {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+	txDesc, ok := tp.pool[txHash]
+	return ok && txDesc.IsStem
+}
+
+// GetTxsToRebroadcast - local transactions of a shard relayed RebroadcastBlocks blocks ago or more and still not in
+// a block
+func (tp *TxPool) GetTxsToRebroadcast(shardID byte, bestHeight uint64) []metadata.Transaction {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+	txs := []metadata.Transaction{}
+	if tp.config.RebroadcastBlocks == 0 {
+		return txs
+	}
+	for _, txDesc := range tp.pool {
+		if !txDesc.IsLocal || !txDesc.IsFowardMessage {
+			continue
+		}
+		if common.GetShardIDFromLastByte(txDesc.Desc.Tx.GetSenderAddrLastByte()) != shardID {
+			continue
+		}
+		if bestHeight >= txDesc.RelayHeight+tp.config.RebroadcastBlocks {
+			txs = append(txs, txDesc.Desc.Tx)
+		}
 	}
+	return txs
 }
 
 // GetTx get transaction info by hash
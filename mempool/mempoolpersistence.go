@@ -2,6 +2,7 @@ package mempool
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"strings"
 	"time"
@@ -12,11 +13,13 @@ import (
 )
 
 type TempDesc struct {
-	StartTime     time.Time
+	StartTime     time.Time // first time the transaction was seen
 	IsPushMessage bool
 	Height        uint64
 	Fee           uint64
 	FeePerKB      int32
+	IsLocal       bool
+	RelayHeight   uint64
 }
 
 // addTransactionToDatabaseMempool - Add a transaction data into mempool database
@@ -28,6 +31,8 @@ func (tp *TxPool) addTransactionToDatabaseMempool(txHash *common.Hash, txDesc Tx
 		Height:        txDesc.Desc.Height,
 		Fee:           txDesc.Desc.Fee,
 		FeePerKB:      txDesc.Desc.FeePerKB,
+		IsLocal:       txDesc.IsLocal,
+		RelayHeight:   txDesc.RelayHeight,
 	}
 	switch tx.GetType() {
 	//==================For PRV Transfer Only
@@ -96,7 +101,10 @@ func (tp *TxPool) loadDatabaseMP() ([]TxDesc, error) {
 	}
 	for index, tx := range allTxs {
 		values := strings.Split(string(tx), string(lvdb.Splitter))
-		txDesc, err := unMarshallTxDescFromDatabase(values[0], []byte(values[1]), []byte(values[2]))
+		txDesc, err := &TxDesc{}, fmt.Errorf("tx %x has %d fields in mempool database", allTxHashes[index], len(values))
+		if len(values) == 3 {
+			txDesc, err = unMarshallTxDescFromDatabase(values[0], []byte(values[1]), []byte(values[2]))
+		}
 		if err != nil {
 			Logger.log.Error(err)
 			txHash, err := common.Hash{}.NewHash(allTxHashes[index][3:])
@@ -112,11 +120,12 @@ func (tp *TxPool) loadDatabaseMP() ([]TxDesc, error) {
 			continue
 		}
 		//if transaction is timeout then remove
-		if time.Since(txDesc.StartTime) > ttl {
+		if ttl > 0 && time.Since(txDesc.StartTime) > ttl {
 			err1 := tp.removeTransactionFromDatabaseMP(txDesc.Desc.Tx.Hash())
 			if err1 != nil {
 				Logger.log.Error(err1)
 			}
+			continue
 		}
 		//if not validated by current blockchain db then remove
 		senderShardID := common.GetShardIDFromLastByte(txDesc.Desc.Tx.GetSenderAddrLastByte())
@@ -132,6 +141,8 @@ func (tp *TxPool) loadDatabaseMP() ([]TxDesc, error) {
 			continue
 		}
 
+		// peers may have dropped the transaction meanwhile, relay it again
+		txDesc.IsFowardMessage = false
 		err = tp.addTx(txDesc, false)
 		if err != nil {
 			Logger.log.Error(err)
@@ -184,5 +195,7 @@ func unMarshallTxDescFromDatabase(txType string, valueTx []byte, valueDesc []byt
 	txDesc.Desc.Height = tempDesc.Height
 	txDesc.Desc.Fee = tempDesc.Fee
 	txDesc.Desc.FeePerKB = tempDesc.FeePerKB
+	txDesc.IsLocal = tempDesc.IsLocal
+	txDesc.RelayHeight = tempDesc.RelayHeight
 	return &txDesc, nil
 }
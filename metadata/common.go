@@ -57,6 +57,16 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &WithDrawRewardResponse{}
 	case StopAutoStakingMeta:
 		md = &StopAutoStakingMetadata{}
+	case DelegateMeta, UndelegateMeta, RedelegateMeta:
+		md = &DelegationRequest{}
+	case DelegationCommissionMeta:
+		md = &DelegationCommissionRequest{}
+	case HTLCLockRequestMeta:
+		md = &HTLCLockRequest{}
+	case HTLCClaimRequestMeta, HTLCRefundRequestMeta:
+		md = &HTLCSettleRequest{}
+	case HTLCResponseMeta:
+		md = &HTLCResponse{}
 	case PDEContributionMeta:
 		md = &PDEContribution{}
 	case PDEPRVRequiredContributionRequestMeta:
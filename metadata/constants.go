@@ -47,6 +47,19 @@ const (
 	PDEFeeWithdrawalResponseMeta          = 208
 	PDETradingFeesDistributionMeta        = 209
 
+	// delegation
+	DelegateMeta   = 210
+	UndelegateMeta = 211
+	RedelegateMeta = 212
+	// validators set their own commission
+	DelegationCommissionMeta = 217
+
+	// htlc
+	HTLCLockRequestMeta   = 213
+	HTLCClaimRequestMeta  = 214
+	HTLCRefundRequestMeta = 215
+	HTLCResponseMeta      = 216
+
 	// portal
 	PortalCustodianDepositMeta                      = 100
 	PortalUserRegisterMeta                          = 101
@@ -115,6 +128,7 @@ var minerCreatedMetaTypes = []int{
 	PortalLiquidationCustodianDepositResponseMetaV2,
 	PortalPortingResponseMeta,
 	PortalTopUpWaitingPortingResponseMeta,
+	HTLCResponseMeta,
 }
 
 // Special rules for shardID: stored as 2nd param of instruction of BeaconBlock
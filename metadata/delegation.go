@@ -0,0 +1,334 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// DelegationRequest - delegate PRV to a validator committee key, undelegate it or move it to another validator.
+// Delegate burns Amount, undelegate and redelegate burn nothing. Undelegated PRV is paid back to the reward balance
+// of the delegator after the unbonding period and withdrawn like a committee reward
+type DelegationRequest struct {
+	MetadataBase
+	DelegatorAddressStr   string
+	CommitteePublicKey    string
+	NewCommitteePublicKey string // redelegate only
+	Amount                uint64
+}
+
+type DelegationRequestAction struct {
+	Meta    DelegationRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+type DelegationAcceptedContent struct {
+	DelegatorAddressStr   string
+	CommitteePublicKey    string
+	NewCommitteePublicKey string
+	Amount                uint64
+	ReleaseEpoch          uint64 // undelegate only
+	TxReqID               common.Hash
+	ShardID               byte
+}
+
+func NewDelegationRequest(
+	metaType int,
+	delegatorAddressStr string,
+	committeePublicKey string,
+	newCommitteePublicKey string,
+	amount uint64,
+) (*DelegationRequest, error) {
+	if metaType != DelegateMeta && metaType != UndelegateMeta && metaType != RedelegateMeta {
+		return nil, errors.New("invalid delegation type")
+	}
+	metadataBase := NewMetadataBase(metaType)
+	return &DelegationRequest{
+		MetadataBase:          *metadataBase,
+		DelegatorAddressStr:   delegatorAddressStr,
+		CommitteePublicKey:    committeePublicKey,
+		NewCommitteePublicKey: newCommitteePublicKey,
+		Amount:                amount,
+	}, nil
+}
+
+// validateDelegationActivation rejects delegation txs before the beacon height BeaconHeightBreakPointDelegation, the
+// beacon height of a new tx is the one of the shard view
+func validateDelegationActivation(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconHeight uint64) error {
+	if beaconHeight == 0 && shardViewRetriever != nil {
+		beaconHeight = shardViewRetriever.GetBeaconHeight()
+	}
+	if breakPoint := chainRetriever.GetBeaconHeightBreakPointDelegation(); beaconHeight < breakPoint {
+		return NewMetadataTxError(DelegationRequestSanityError, fmt.Errorf("delegation is not accepted before beacon height %+v, beacon height is %+v", breakPoint, beaconHeight))
+	}
+	return nil
+}
+
+// targetCommitteePublicKey returns the validator which receives the delegation
+func (dr DelegationRequest) targetCommitteePublicKey() string {
+	if dr.Type == RedelegateMeta {
+		return dr.NewCommitteePublicKey
+	}
+	return dr.CommitteePublicKey
+}
+
+func isValidCommitteePublicKey(committeePublicKeyStr string) bool {
+	if !incognitokey.IsInBase58ShortFormat([]string{committeePublicKeyStr}) {
+		return false
+	}
+	committeePublicKey := new(incognitokey.CommitteePublicKey)
+	if err := committeePublicKey.FromString(committeePublicKeyStr); err != nil {
+		return false
+	}
+	return committeePublicKey.CheckSanityData()
+}
+
+// ValidateTxWithBlockChain checks the validator receiving the delegation is a candidate, a substitute or in a committee,
+// the delegated amount of an undelegation or a redelegation is checked by beacon
+func (dr DelegationRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	if dr.Type == UndelegateMeta {
+		return true, nil
+	}
+	committees, err := beaconViewRetriever.GetAllCommitteeValidatorCandidateFlattenListFromDatabase()
+	if err != nil {
+		return false, NewMetadataTxError(DelegationRequestValidatorNotFoundError, err)
+	}
+	if common.IndexOfStr(dr.targetCommitteePublicKey(), committees) < 0 {
+		return false, NewMetadataTxError(DelegationRequestValidatorNotFoundError, fmt.Errorf("Committee Publickey %+v not found in any committee list of current beacon beststate", dr.targetCommitteePublicKey()))
+	}
+	return true, nil
+}
+
+/*
+	// Have only one receiver
+	// Receiver Is Burning Address
+	// Burn Amount to delegate, nothing to undelegate or redelegate
+	// Sender is the delegator
+*/
+func (dr DelegationRequest) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	if err := validateDelegationActivation(chainRetriever, shardViewRetriever, beaconHeight); err != nil {
+		return false, false, err
+	}
+	if tx.IsPrivacy() {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("Delegation Request Transaction Is No Privacy Transaction"))
+	}
+	onlyOne, pubkey, amount := tx.GetUniqueReceiver()
+	if !onlyOne {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("Delegation Request Transaction Should Have 1 Output Amount crossponding to 1 Receiver"))
+	}
+	burningAddress := chainRetriever.GetBurningAddress(beaconHeight)
+	keyWalletBurningAdd, err := wallet.Base58CheckDeserialize(burningAddress)
+	if err != nil {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, err)
+	}
+	if !bytes.Equal(pubkey, keyWalletBurningAdd.KeySet.PaymentAddress.Pk) {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("receiver Should be Burning Address"))
+	}
+	if dr.Amount == 0 {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("Amount should be large than 0"))
+	}
+	if dr.Type == DelegateMeta && amount != dr.Amount {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, fmt.Errorf("burning amount %+v should be delegation amount %+v", amount, dr.Amount))
+	}
+	if dr.Type != DelegateMeta && amount != 0 {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("receiver amount should be zero"))
+	}
+
+	keyWallet, err := wallet.Base58CheckDeserialize(dr.DelegatorAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("DelegatorAddressStr incorrect"))
+	}
+	delegatorAddr := keyWallet.KeySet.PaymentAddress
+	if len(delegatorAddr.Pk) != common.PublicKeySize {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("Wrong request info's delegator address"))
+	}
+	if !bytes.Equal(tx.GetSigPubKey()[:], delegatorAddr.Pk[:]) {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("DelegatorAddr incorrect"))
+	}
+
+	if !isValidCommitteePublicKey(dr.CommitteePublicKey) {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("Invalid Commitee Public Key of validator"))
+	}
+	if dr.Type == RedelegateMeta {
+		if !isValidCommitteePublicKey(dr.NewCommitteePublicKey) {
+			return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("Invalid New Commitee Public Key of validator"))
+		}
+		if dr.NewCommitteePublicKey == dr.CommitteePublicKey {
+			return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("New Commitee Public Key should be different"))
+		}
+	} else if dr.NewCommitteePublicKey != "" {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("New Commitee Public Key is for redelegation only"))
+	}
+	return true, true, nil
+}
+
+func (dr DelegationRequest) ValidateMetadataByItself() bool {
+	return dr.Type == DelegateMeta || dr.Type == UndelegateMeta || dr.Type == RedelegateMeta
+}
+
+func (dr DelegationRequest) Hash() *common.Hash {
+	record := dr.MetadataBase.Hash().String()
+	record += dr.DelegatorAddressStr
+	record += dr.CommitteePublicKey
+	record += dr.NewCommitteePublicKey
+	record += strconv.FormatUint(dr.Amount, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (dr *DelegationRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := DelegationRequestAction{
+		Meta:    *dr,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(dr.Type), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (dr *DelegationRequest) CalculateSize() uint64 {
+	return calculateSize(dr)
+}
+
+// DelegationCommissionRequest - a validator sets the share of the reward of its delegators it keeps, in percent.
+// It is sent by the sender of the staking tx of the validator and burns nothing. Validators which have not set one
+// keep DelegationCommissionPercent of the chain params
+type DelegationCommissionRequest struct {
+	MetadataBase
+	CommitteePublicKey string
+	CommissionPercent  uint64
+}
+
+type DelegationCommissionRequestAction struct {
+	Meta    DelegationCommissionRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+type DelegationCommissionAcceptedContent struct {
+	CommitteePublicKey string
+	CommissionPercent  uint64
+	TxReqID            common.Hash
+	ShardID            byte
+}
+
+func NewDelegationCommissionRequest(committeePublicKey string, commissionPercent uint64) *DelegationCommissionRequest {
+	metadataBase := NewMetadataBase(DelegationCommissionMeta)
+	return &DelegationCommissionRequest{
+		MetadataBase:       *metadataBase,
+		CommitteePublicKey: committeePublicKey,
+		CommissionPercent:  commissionPercent,
+	}
+}
+
+// ValidateTxWithBlockChain checks the validator is a candidate, a substitute or in a committee and the tx is sent by
+// the sender of its staking tx, like a stop auto staking request
+func (cr DelegationCommissionRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	committees, err := beaconViewRetriever.GetAllCommitteeValidatorCandidateFlattenListFromDatabase()
+	if err != nil {
+		return false, NewMetadataTxError(DelegationRequestValidatorNotFoundError, err)
+	}
+	if common.IndexOfStr(cr.CommitteePublicKey, committees) < 0 {
+		return false, NewMetadataTxError(DelegationRequestValidatorNotFoundError, fmt.Errorf("Committee Publickey %+v not found in any committee list of current beacon beststate", cr.CommitteePublicKey))
+	}
+	stakingTxHashStr, ok := shardViewRetriever.GetStakingTx()[cr.CommitteePublicKey]
+	if !ok {
+		return false, NewMetadataTxError(DelegationCommissionRequestSenderError, fmt.Errorf("No Committe Publickey %+v found in StakingTx of Shard %+v", cr.CommitteePublicKey, shardID))
+	}
+	stakingTxHash, err := common.Hash{}.NewHashFromStr(stakingTxHashStr)
+	if err != nil {
+		return false, NewMetadataTxError(DelegationCommissionRequestSenderError, err)
+	}
+	_, _, _, _, stakingTx, err := chainRetriever.GetTransactionByHash(*stakingTxHash)
+	if err != nil {
+		return false, NewMetadataTxError(DelegationCommissionRequestSenderError, err)
+	}
+	if !bytes.Equal(stakingTx.GetSender(), tx.GetSender()) {
+		return false, NewMetadataTxError(DelegationCommissionRequestSenderError, fmt.Errorf("Expect %+v to send delegation commission request but get %+v", stakingTx.GetSender(), tx.GetSender()))
+	}
+	return true, nil
+}
+
+/*
+	// Have only one receiver
+	// Receiver Is Burning Address
+	// Burn nothing
+	// Commission is a percent
+*/
+func (cr DelegationCommissionRequest) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	if err := validateDelegationActivation(chainRetriever, shardViewRetriever, beaconHeight); err != nil {
+		return false, false, err
+	}
+	if tx.IsPrivacy() {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("Delegation Commission Request Transaction Is No Privacy Transaction"))
+	}
+	onlyOne, pubkey, amount := tx.GetUniqueReceiver()
+	if !onlyOne {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("Delegation Commission Request Transaction Should Have 1 Output Amount crossponding to 1 Receiver"))
+	}
+	burningAddress := chainRetriever.GetBurningAddress(beaconHeight)
+	keyWalletBurningAdd, err := wallet.Base58CheckDeserialize(burningAddress)
+	if err != nil {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, err)
+	}
+	if !bytes.Equal(pubkey, keyWalletBurningAdd.KeySet.PaymentAddress.Pk) {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("receiver Should be Burning Address"))
+	}
+	if amount != 0 {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("receiver amount should be zero"))
+	}
+	if cr.CommissionPercent > 100 {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, fmt.Errorf("commission %+v should be at most 100 percent", cr.CommissionPercent))
+	}
+	if !isValidCommitteePublicKey(cr.CommitteePublicKey) {
+		return false, false, NewMetadataTxError(DelegationRequestSanityError, errors.New("Invalid Commitee Public Key of validator"))
+	}
+	return true, true, nil
+}
+
+func (cr DelegationCommissionRequest) ValidateMetadataByItself() bool {
+	return cr.Type == DelegationCommissionMeta
+}
+
+func (cr DelegationCommissionRequest) Hash() *common.Hash {
+	record := cr.MetadataBase.Hash().String()
+	record += cr.CommitteePublicKey
+	record += strconv.FormatUint(cr.CommissionPercent, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (cr *DelegationCommissionRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := DelegationCommissionRequestAction{
+		Meta:    *cr,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(cr.Type), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (cr *DelegationCommissionRequest) CalculateSize() uint64 {
+	return calculateSize(cr)
+}
@@ -47,6 +47,15 @@ const (
 	PortalRequestPTokenParamError
 	PortalRedeemRequestParamError
 	PortalRedeemLiquidateExchangeRatesParamError
+
+	// delegation
+	DelegationRequestSanityError
+	DelegationRequestValidatorNotFoundError
+	DelegationCommissionRequestSenderError
+
+	// htlc
+	HTLCRequestSanityError
+	HTLCRequestNotSettleableError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -97,6 +106,15 @@ var ErrCodeMessage = map[int]struct {
 	PortalRequestPTokenParamError:                {-7001, "Portal request ptoken param error"},
 	PortalRedeemRequestParamError:                {-7002, "Portal redeem request param error"},
 	PortalRedeemLiquidateExchangeRatesParamError: {-7003, "Portal redeem liquidate exchange rates param error"},
+
+	// delegation
+	DelegationRequestSanityError:            {-8001, "Delegation request sanity error"},
+	DelegationRequestValidatorNotFoundError: {-8002, "Delegation request validator not found error"},
+	DelegationCommissionRequestSenderError:  {-8003, "Delegation commission request sender is not the staker error"},
+
+	// htlc
+	HTLCRequestSanityError:        {-9001, "HTLC request sanity error"},
+	HTLCRequestNotSettleableError: {-9002, "HTLC request not settleable error"},
 }
 
 type MetadataTxError struct {
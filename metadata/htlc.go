@@ -0,0 +1,317 @@
+package metadata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// HTLCPreimageSize is the size of the preimage of a hashlock, the size other chains check in their htlc scripts
+const HTLCPreimageSize = 32
+
+// HTLCLockRequest - lock Amount of PRV or a pToken to the sha256 HashLock of a secret preimage until
+// TimeoutBeaconHeight, the tx burns Amount. The receiver claims it with the preimage before the timeout
+// by an HTLCSettleRequest of type HTLCClaimRequestMeta, the sender refunds it from the timeout on by one
+// of type HTLCRefundRequestMeta. Both are paid out by an HTLCResponse tx
+type HTLCLockRequest struct {
+	MetadataBase
+	SenderAddressStr    string
+	ReceiverAddressStr  string
+	TokenIDStr          string
+	Amount              uint64
+	HashLock            string // hex encoded sha256 of the preimage
+	TimeoutBeaconHeight uint64
+}
+
+type HTLCLockRequestAction struct {
+	Meta    HTLCLockRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+// HTLCSettleRequest - claim the htlc locked by LockTxID with its Preimage or refund it, the payout goes to the
+// receiver or the sender of the htlc whoever sends the request
+type HTLCSettleRequest struct {
+	MetadataBase
+	LockTxID common.Hash
+	Preimage string // hex encoded, claim only
+}
+
+type HTLCSettleRequestAction struct {
+	Meta    HTLCSettleRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+// HTLCAcceptedContent is the content of htlc instructions, ShardID is the shard of the payee of instructions
+// paid out by a response tx and the shard of the request otherwise
+type HTLCAcceptedContent struct {
+	LockTxID            common.Hash
+	SenderAddressStr    string
+	ReceiverAddressStr  string
+	TokenIDStr          string
+	Amount              uint64
+	HashLock            string
+	TimeoutBeaconHeight uint64
+	Preimage            string
+	TxReqID             common.Hash
+	ShardID             byte
+}
+
+// validateHTLCActivation rejects htlc txs before the beacon height BeaconHeightBreakPointHTLC, the beacon height of
+// a new tx is the one of the shard view
+func validateHTLCActivation(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconHeight uint64) error {
+	if beaconHeight == 0 && shardViewRetriever != nil {
+		beaconHeight = shardViewRetriever.GetBeaconHeight()
+	}
+	if breakPoint := chainRetriever.GetBeaconHeightBreakPointHTLC(); beaconHeight < breakPoint {
+		return NewMetadataTxError(HTLCRequestSanityError, fmt.Errorf("htlc is not accepted before beacon height %+v, beacon height is %+v", breakPoint, beaconHeight))
+	}
+	return nil
+}
+
+// GetHTLCPayeeAddress returns the address paid out by an htlc instruction of status, an empty string when the
+// instruction pays nothing out
+func GetHTLCPayeeAddress(metaType int, status string, content HTLCAcceptedContent) string {
+	switch {
+	case metaType == HTLCLockRequestMeta && status == common.HTLCRejectedChainStatus:
+		return content.SenderAddressStr
+	case metaType == HTLCClaimRequestMeta && status == common.HTLCClaimedChainStatus:
+		return content.ReceiverAddressStr
+	case metaType == HTLCRefundRequestMeta && status == common.HTLCRefundedChainStatus:
+		return content.SenderAddressStr
+	}
+	return ""
+}
+
+// IsHTLCPreimage returns whether preimageStr is the hex encoded preimage of hashLockStr
+func IsHTLCPreimage(preimageStr string, hashLockStr string) bool {
+	preimage, err := hex.DecodeString(preimageStr)
+	if err != nil || len(preimage) != HTLCPreimageSize {
+		return false
+	}
+	hashLock, err := hex.DecodeString(hashLockStr)
+	if err != nil {
+		return false
+	}
+	hash := sha256.Sum256(preimage)
+	return bytes.Equal(hash[:], hashLock)
+}
+
+func NewHTLCLockRequest(
+	senderAddressStr string,
+	receiverAddressStr string,
+	tokenIDStr string,
+	amount uint64,
+	hashLock string,
+	timeoutBeaconHeight uint64,
+) *HTLCLockRequest {
+	metadataBase := NewMetadataBase(HTLCLockRequestMeta)
+	return &HTLCLockRequest{
+		MetadataBase:        *metadataBase,
+		SenderAddressStr:    senderAddressStr,
+		ReceiverAddressStr:  receiverAddressStr,
+		TokenIDStr:          tokenIDStr,
+		Amount:              amount,
+		HashLock:            hashLock,
+		TimeoutBeaconHeight: timeoutBeaconHeight,
+	}
+}
+
+// ValidateTxWithBlockChain checks the timeout is after the beacon height seen by the shard, beacon refunds locks
+// whose timeout has passed when it gets them
+func (lr HTLCLockRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	if lr.TimeoutBeaconHeight <= shardViewRetriever.GetBeaconHeight() {
+		return false, NewMetadataTxError(HTLCRequestSanityError, fmt.Errorf("timeout beacon height %+v should be after beacon height %+v", lr.TimeoutBeaconHeight, shardViewRetriever.GetBeaconHeight()))
+	}
+	return true, nil
+}
+
+/*
+// Burn Amount of TokenIDStr
+// Sender is the signer
+// HashLock is a sha256 hash
+*/
+func (lr HTLCLockRequest) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	if err := validateHTLCActivation(chainRetriever, shardViewRetriever, beaconHeight); err != nil {
+		return false, false, err
+	}
+	// Note: the metadata was already verified with *transaction.TxCustomToken level so no need to verify with *transaction.Tx level again as *transaction.Tx is embedding property of *transaction.TxCustomToken
+	if tx.GetType() == common.TxCustomTokenPrivacyType && reflect.TypeOf(tx).String() == "*transaction.Tx" {
+		return true, true, nil
+	}
+	senderKeyWallet, err := wallet.Base58CheckDeserialize(lr.SenderAddressStr)
+	if err != nil || len(senderKeyWallet.KeySet.PaymentAddress.Pk) != common.PublicKeySize {
+		return false, false, NewMetadataTxError(HTLCRequestSanityError, errors.New("SenderAddressStr incorrect"))
+	}
+	if !bytes.Equal(tx.GetSigPubKey()[:], senderKeyWallet.KeySet.PaymentAddress.Pk[:]) {
+		return false, false, NewMetadataTxError(HTLCRequestSanityError, errors.New("SenderAddress should be the signer"))
+	}
+	receiverKeyWallet, err := wallet.Base58CheckDeserialize(lr.ReceiverAddressStr)
+	if err != nil || len(receiverKeyWallet.KeySet.PaymentAddress.Pk) != common.PublicKeySize {
+		return false, false, NewMetadataTxError(HTLCRequestSanityError, errors.New("ReceiverAddressStr incorrect"))
+	}
+	if !tx.IsCoinsBurning(chainRetriever, shardViewRetriever, beaconViewRetriever, beaconHeight) {
+		return false, false, NewMetadataTxError(HTLCRequestSanityError, errors.New("Must send coin to burning address"))
+	}
+	if lr.Amount == 0 {
+		return false, false, NewMetadataTxError(HTLCRequestSanityError, errors.New("Amount should be larger than 0"))
+	}
+	if lr.Amount != tx.CalculateTxValue() {
+		return false, false, NewMetadataTxError(HTLCRequestSanityError, errors.New("Amount should be equal to the tx value"))
+	}
+
+	tokenID, err := common.Hash{}.NewHashFromStr(lr.TokenIDStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(HTLCRequestSanityError, errors.New("TokenIDStr incorrect"))
+	}
+	if !bytes.Equal(tx.GetTokenID()[:], tokenID[:]) {
+		return false, false, NewMetadataTxError(HTLCRequestSanityError, errors.New("Wrong request info's token id, it should be equal to tx's token id"))
+	}
+	if tx.GetType() == common.TxNormalType && lr.TokenIDStr != common.PRVCoinID.String() {
+		return false, false, NewMetadataTxError(HTLCRequestSanityError, errors.New("With tx normal privacy, the tokenIDStr should be PRV, not custom token"))
+	}
+	if tx.GetType() == common.TxCustomTokenPrivacyType && lr.TokenIDStr == common.PRVCoinID.String() {
+		return false, false, NewMetadataTxError(HTLCRequestSanityError, errors.New("With tx custom token privacy, the tokenIDStr should not be PRV, but custom token"))
+	}
+
+	hashLock, err := hex.DecodeString(lr.HashLock)
+	if err != nil || len(hashLock) != sha256.Size {
+		return false, false, NewMetadataTxError(HTLCRequestSanityError, errors.New("HashLock should be a hex encoded sha256 hash"))
+	}
+	return true, true, nil
+}
+
+func (lr HTLCLockRequest) ValidateMetadataByItself() bool {
+	return lr.Type == HTLCLockRequestMeta
+}
+
+func (lr HTLCLockRequest) Hash() *common.Hash {
+	record := lr.MetadataBase.Hash().String()
+	record += lr.SenderAddressStr
+	record += lr.ReceiverAddressStr
+	record += lr.TokenIDStr
+	record += strconv.FormatUint(lr.Amount, 10)
+	record += lr.HashLock
+	record += strconv.FormatUint(lr.TimeoutBeaconHeight, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (lr *HTLCLockRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := HTLCLockRequestAction{
+		Meta:    *lr,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(lr.Type), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (lr *HTLCLockRequest) CalculateSize() uint64 {
+	return calculateSize(lr)
+}
+
+func NewHTLCSettleRequest(metaType int, lockTxID common.Hash, preimage string) (*HTLCSettleRequest, error) {
+	if metaType != HTLCClaimRequestMeta && metaType != HTLCRefundRequestMeta {
+		return nil, errors.New("invalid htlc settle type")
+	}
+	metadataBase := NewMetadataBase(metaType)
+	return &HTLCSettleRequest{
+		MetadataBase: *metadataBase,
+		LockTxID:     lockTxID,
+		Preimage:     preimage,
+	}, nil
+}
+
+// ValidateTxWithBlockChain checks the htlc is locked at the beacon view of the shard, with the preimage of a claim
+// and past the timeout of a refund, beacon checks it again when it gets the request
+func (sr HTLCSettleRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	htlc, has, err := statedb.GetHTLC(beaconViewRetriever.GetBeaconFeatureStateDB(), sr.LockTxID)
+	if err != nil {
+		return false, NewMetadataTxError(HTLCRequestNotSettleableError, err)
+	}
+	if !has || htlc.Status() != common.HTLCLockedChainStatus {
+		return false, NewMetadataTxError(HTLCRequestNotSettleableError, fmt.Errorf("no locked htlc of lock tx %+v", sr.LockTxID.String()))
+	}
+	if sr.Type == HTLCClaimRequestMeta && !IsHTLCPreimage(sr.Preimage, htlc.HashLock()) {
+		return false, NewMetadataTxError(HTLCRequestNotSettleableError, fmt.Errorf("preimage does not match hashlock %+v", htlc.HashLock()))
+	}
+	if sr.Type == HTLCRefundRequestMeta && shardViewRetriever.GetBeaconHeight() < htlc.TimeoutBeaconHeight() {
+		return false, NewMetadataTxError(HTLCRequestNotSettleableError, fmt.Errorf("htlc times out at beacon height %+v", htlc.TimeoutBeaconHeight()))
+	}
+	return true, nil
+}
+
+/*
+// Tx is a PRV tx
+// Preimage of a claim has HTLCPreimageSize bytes, a refund has none
+*/
+func (sr HTLCSettleRequest) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	if err := validateHTLCActivation(chainRetriever, shardViewRetriever, beaconHeight); err != nil {
+		return false, false, err
+	}
+	if tx.GetType() != common.TxNormalType {
+		return false, false, NewMetadataTxError(HTLCRequestSanityError, errors.New("tx htlc settle request must be TxNormalType"))
+	}
+	if sr.LockTxID.IsEqual(&common.Hash{}) {
+		return false, false, NewMetadataTxError(HTLCRequestSanityError, errors.New("LockTxID should not be empty"))
+	}
+	if sr.Type == HTLCClaimRequestMeta {
+		preimage, err := hex.DecodeString(sr.Preimage)
+		if err != nil || len(preimage) != HTLCPreimageSize {
+			return false, false, NewMetadataTxError(HTLCRequestSanityError, fmt.Errorf("Preimage should be %+v hex encoded bytes", HTLCPreimageSize))
+		}
+	} else if sr.Preimage != "" {
+		return false, false, NewMetadataTxError(HTLCRequestSanityError, errors.New("Preimage is for claim only"))
+	}
+	return true, true, nil
+}
+
+func (sr HTLCSettleRequest) ValidateMetadataByItself() bool {
+	return sr.Type == HTLCClaimRequestMeta || sr.Type == HTLCRefundRequestMeta
+}
+
+func (sr HTLCSettleRequest) Hash() *common.Hash {
+	record := sr.MetadataBase.Hash().String()
+	record += sr.LockTxID.String()
+	record += sr.Preimage
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (sr *HTLCSettleRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := HTLCSettleRequestAction{
+		Meta:    *sr,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(sr.Type), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (sr *HTLCSettleRequest) CalculateSize() uint64 {
+	return calculateSize(sr)
+}
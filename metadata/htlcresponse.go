@@ -0,0 +1,120 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/pkg/errors"
+)
+
+// HTLCResponse - pays out an htlc to its receiver on claim, to its sender on refund or on a rejected lock
+type HTLCResponse struct {
+	MetadataBase
+	RequestStatus string
+	ReqTxID       common.Hash
+	LockTxID      common.Hash
+}
+
+func NewHTLCResponse(requestStatus string, reqTxID common.Hash, lockTxID common.Hash) *HTLCResponse {
+	metadataBase := MetadataBase{
+		Type: HTLCResponseMeta,
+	}
+	return &HTLCResponse{
+		RequestStatus: requestStatus,
+		ReqTxID:       reqTxID,
+		LockTxID:      lockTxID,
+		MetadataBase:  metadataBase,
+	}
+}
+
+func (iRes HTLCResponse) CheckTransactionFee(tr Transaction, minFee uint64, beaconHeight int64, db *statedb.StateDB) bool {
+	// no need to have fee for this tx
+	return true
+}
+
+func (iRes HTLCResponse) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// no need to validate tx with blockchain, just need to validate with requested tx (via ReqTxID)
+	return false, nil
+}
+
+func (iRes HTLCResponse) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	if err := validateHTLCActivation(chainRetriever, shardViewRetriever, beaconHeight); err != nil {
+		return false, false, err
+	}
+	return false, true, nil
+}
+
+func (iRes HTLCResponse) ValidateMetadataByItself() bool {
+	// The validation just need to check at tx level, so returning true here
+	return iRes.Type == HTLCResponseMeta
+}
+
+func (iRes HTLCResponse) Hash() *common.Hash {
+	record := iRes.RequestStatus
+	record += iRes.ReqTxID.String()
+	record += iRes.LockTxID.String()
+	record += iRes.MetadataBase.Hash().String()
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (iRes *HTLCResponse) CalculateSize() uint64 {
+	return calculateSize(iRes)
+}
+
+func (iRes HTLCResponse) VerifyMinerCreatedTxBeforeGettingInBlock(txsInBlock []Transaction, txsUsed []int, insts [][]string, instUsed []int, shardID byte, tx Transaction, chainRetriever ChainRetriever, ac *AccumulatedValues, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever) (bool, error) {
+	idx := -1
+	for i, inst := range insts {
+		if len(inst) < 4 { // this is not htlc instruction
+			continue
+		}
+		instMetaType, err := strconv.Atoi(inst[0])
+		if err != nil || instUsed[i] > 0 || iRes.RequestStatus != inst[2] ||
+			(instMetaType != HTLCLockRequestMeta && instMetaType != HTLCClaimRequestMeta && instMetaType != HTLCRefundRequestMeta) {
+			continue
+		}
+
+		contentBytes := []byte(inst[3])
+		var htlcContent HTLCAcceptedContent
+		err = json.Unmarshal(contentBytes, &htlcContent)
+		if err != nil {
+			Logger.log.Error("WARNING - VALIDATION: an error occured while parsing instruction content: ", err)
+			continue
+		}
+
+		if !bytes.Equal(iRes.ReqTxID[:], htlcContent.TxReqID[:]) ||
+			!bytes.Equal(iRes.LockTxID[:], htlcContent.LockTxID[:]) ||
+			shardID != htlcContent.ShardID {
+			continue
+		}
+		payeeAddressStr := GetHTLCPayeeAddress(instMetaType, inst[2], htlcContent)
+		if payeeAddressStr == "" {
+			continue
+		}
+		key, err := wallet.Base58CheckDeserialize(payeeAddressStr)
+		if err != nil {
+			Logger.log.Info("WARNING - VALIDATION: an error occured while deserializing payee address string: ", err)
+			continue
+		}
+
+		_, pk, amount, assetID := tx.GetTransferData()
+		if !bytes.Equal(key.KeySet.PaymentAddress.Pk[:], pk[:]) ||
+			htlcContent.Amount != amount ||
+			htlcContent.TokenIDStr != assetID.String() {
+			continue
+		}
+		idx = i
+		break
+	}
+	if idx == -1 { // not found the htlc request tx for this response
+		return false, errors.Errorf("no htlc request tx found for the HTLCResponse tx %s", tx.Hash().String())
+	}
+	instUsed[idx] = 1
+	return true, nil
+}
@@ -57,6 +57,10 @@ type ChainRetriever interface {
 	GetStakingAmountShard() uint64
 	GetCentralizedWebsitePaymentAddress(uint64) string
 	GetBeaconHeightBreakPointBurnAddr() uint64
+	GetBeaconHeightBreakPointRingSize() uint64
+	GetBeaconHeightBreakPointStealthAddress() uint64
+	GetBeaconHeightBreakPointHTLC() uint64
+	GetBeaconHeightBreakPointDelegation() uint64
 	GetBurningAddress(blockHeight uint64) string
 	GetTransactionByHash(common.Hash) (byte, common.Hash, uint64, int, Transaction, error)
 	ListPrivacyTokenAndBridgeTokenAndPRVByShardID(byte) ([]common.Hash, error)
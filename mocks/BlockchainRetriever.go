@@ -182,6 +182,62 @@ func (_m *BlockchainRetriever) GetBeaconHeightBreakPointBurnAddr() uint64 {
 	return r0
 }
 
+// GetBeaconHeightBreakPointRingSize provides a mock function with given fields:
+func (_m *BlockchainRetriever) GetBeaconHeightBreakPointRingSize() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// GetBeaconHeightBreakPointStealthAddress provides a mock function with given fields:
+func (_m *BlockchainRetriever) GetBeaconHeightBreakPointStealthAddress() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// GetBeaconHeightBreakPointHTLC provides a mock function with given fields:
+func (_m *BlockchainRetriever) GetBeaconHeightBreakPointHTLC() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// GetBeaconHeightBreakPointDelegation provides a mock function with given fields:
+func (_m *BlockchainRetriever) GetBeaconHeightBreakPointDelegation() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
 // GetBeaconRewardStateDB provides a mock function with given fields:
 func (_m *BlockchainRetriever) GetBeaconRewardStateDB() *statedb.StateDB {
 	ret := _m.Called()
@@ -2,6 +2,7 @@ package main
 
 import (
 	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/devnet"
 )
 
 // activeNetParams is a pointer to the parameters specific to the
@@ -28,6 +29,13 @@ var testNetParams = params{
 	wsPort:  TestnetWsServerPort,
 }
 
+// devNetParams is filled by the devnet when the node starts, its committees and genesis blocks are generated
+var devNetParams = params{
+	Params:  &blockchain.Params{Name: devnet.Name},
+	rpcPort: TestnetRpcServerPort,
+	wsPort:  TestnetWsServerPort,
+}
+
 // netName returns the name used when referring to a coin network.
 func netName(chainParams *params) string {
 	return chainParams.Name
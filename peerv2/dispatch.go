@@ -72,11 +72,46 @@ func (d *Dispatcher) processStreamBlk(blktype byte, data []byte) error {
 // after receiving a good message from stream,
 // we need analyze it and process with corresponding message type
 func (d *Dispatcher) processInMessageString(msgStr string) error {
+	message, err := decodeMessage(msgStr)
+	if err != nil {
+		return err
+	}
+	realType := reflect.TypeOf(message)
+	// fmt.Printf("Cmd message type of struct %s", realType.String())
+
+	// // cache message hash
+	// if peerConn.listenerPeer != nil {
+	// 	hashMsg := message.Hash()
+	// 	if err := peerConn.listenerPeer.HashToPool(hashMsg); err != nil {
+	// 		Logger.Error(err)
+	// 		return NewPeerError(CacheMessageHashError, err, nil)
+	// 	}
+	// }
+
+	// process message for each of message type
+	errProcessMessage := d.processMessageForEachType(realType, message)
+	if errProcessMessage != nil {
+		return errors.WithStack(errProcessMessage)
+	}
+
+	// MONITOR INBOUND MESSAGE
+	//storeInboundPeerMessage(message, time.Now().Unix(), peerConn.remotePeer.GetPeerID())
+	return nil
+}
+
+// ProcessMessage processes a message which is already decoded, as the devnet passes the messages of its nodes in
+// process
+func (d *Dispatcher) ProcessMessage(message wire.Message) error {
+	return d.processMessageForEachType(reflect.TypeOf(message), message)
+}
+
+// decodeMessage - parse a message encoded by encodeMessage
+func decodeMessage(msgStr string) (wire.Message, error) {
 	// NOTE: copy from peerConn.processInMessageString
 	// Parse Message header from last 24 bytes header message
 	jsonDecodeBytesRaw, err := hex.DecodeString(msgStr)
 	if err != nil {
-		return errors.Wrapf(err, "msgStr: %v", msgStr)
+		return nil, errors.Wrapf(err, "msgStr: %v", msgStr)
 	}
 
 	// TODO(0xbunyip): separate caching from peerConn
@@ -91,7 +126,7 @@ func (d *Dispatcher) processInMessageString(msgStr string) error {
 	// unzip data before process
 	jsonDecodeBytes, err := common.GZipToBytes(jsonDecodeBytesRaw)
 	if err != nil {
-		return errors.WithStack(err)
+		return nil, errors.WithStack(err)
 	}
 
 	// fmt.Printf("In message content : %s", string(jsonDecodeBytes))
@@ -107,11 +142,11 @@ func (d *Dispatcher) processInMessageString(msgStr string) error {
 	// convert to particular message from message cmd type
 	message, err := wire.MakeEmptyMessage(string(commandType))
 	if err != nil {
-		return errors.WithStack(err)
+		return nil, errors.WithStack(err)
 	}
 
 	if len(jsonDecodeBytes) > message.MaxPayloadLength(wire.Version) {
-		return errors.Errorf("Message size too lagre %v, it must be less than %v", len(jsonDecodeBytes), message.MaxPayloadLength(wire.Version))
+		return nil, errors.Errorf("Message size too lagre %v, it must be less than %v", len(jsonDecodeBytes), message.MaxPayloadLength(wire.Version))
 	}
 	// check forward TODO
 	/*if peerConn.config.MessageListeners.GetCurrentRoleShard != nil {
@@ -147,29 +182,9 @@ func (d *Dispatcher) processInMessageString(msgStr string) error {
 
 	err = json.Unmarshal(messageBody, &message)
 	if err != nil {
-		return errors.WithStack(err)
-	}
-	realType := reflect.TypeOf(message)
-	// fmt.Printf("Cmd message type of struct %s", realType.String())
-
-	// // cache message hash
-	// if peerConn.listenerPeer != nil {
-	// 	hashMsg := message.Hash()
-	// 	if err := peerConn.listenerPeer.HashToPool(hashMsg); err != nil {
-	// 		Logger.Error(err)
-	// 		return NewPeerError(CacheMessageHashError, err, nil)
-	// 	}
-	// }
-
-	// process message for each of message type
-	errProcessMessage := d.processMessageForEachType(realType, message)
-	if errProcessMessage != nil {
-		return errors.WithStack(errProcessMessage)
+		return nil, errors.WithStack(err)
 	}
-
-	// MONITOR INBOUND MESSAGE
-	//storeInboundPeerMessage(message, time.Now().Unix(), peerConn.remotePeer.GetPeerID())
-	return nil
+	return message, nil
 }
 
 // process message for each of message type
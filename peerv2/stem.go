@@ -0,0 +1,139 @@
+package peerv2
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/wire"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/pkg/errors"
+)
+
+// StemProtocol carries the transactions relayed privately from a node to a single stem peer, they are not published
+// to the topics of the highway
+const StemProtocol = protocol.ID("/incognito/stemtx/1.0.0")
+
+// maxStemMessageSize bounds a stem message, the hex of the largest transaction message and its header and the
+// newline ending it
+const maxStemMessageSize = 2*(wire.MaxTxPrivacyTokenPayload+wire.MessageHeaderSize) + 1
+
+// StemReadTimeout - time for a stem peer to send its message after opening a stream
+var StemReadTimeout = 10 * time.Second
+
+// StemRelay sends the transactions of the stem phase to the stem peers of the node through direct libp2p streams
+// and passes the transactions received from the stem to OnStem
+type StemRelay struct {
+	host   *Host
+	peers  []peer.AddrInfo
+	onStem func(msg wire.Message)
+}
+
+// NewStemRelay parses the libp2p addresses of the stem peers (/ip4/<ip>/tcp/<port>/p2p/<peer id>) and handles
+// the stem streams of the host
+func NewStemRelay(host *Host, stemPeers []string, onStem func(msg wire.Message)) (*StemRelay, error) {
+	relay := &StemRelay{
+		host:   host,
+		onStem: onStem,
+	}
+	for _, stemPeer := range stemPeers {
+		addrInfo, err := getAddressInfo(stemPeer)
+		if err != nil {
+			return nil, err
+		}
+		relay.peers = append(relay.peers, *addrInfo)
+	}
+	host.Host.SetStreamHandler(StemProtocol, relay.handleStream)
+	return relay, nil
+}
+
+// Peers - IDs of the stem peers which are connected or can be dialed
+func (relay *StemRelay) Peers() []string {
+	peerIDs := []string{}
+	for _, addrInfo := range relay.peers {
+		if relay.host.Host.Network().Connectedness(addrInfo.ID) == network.CannotConnect {
+			continue
+		}
+		peerIDs = append(peerIDs, addrInfo.ID.Pretty())
+	}
+	return peerIDs
+}
+
+// SendStem - send a transaction message to a stem peer
+func (relay *StemRelay) SendStem(msg wire.Message, peerID string) error {
+	var addrInfo *peer.AddrInfo
+	for i := range relay.peers {
+		if relay.peers[i].ID.Pretty() == peerID {
+			addrInfo = &relay.peers[i]
+		}
+	}
+	if addrInfo == nil {
+		return errors.Errorf("%s is not a stem peer", peerID)
+	}
+	messageHex, err := encodeMessage(msg)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DialTimeout)
+	defer cancel()
+	if err := relay.host.Host.Connect(ctx, *addrInfo); err != nil {
+		return errors.Wrapf(err, "connect stem peer %s", peerID)
+	}
+	stream, err := relay.host.Host.NewStream(ctx, addrInfo.ID, StemProtocol)
+	if err != nil {
+		return errors.Wrapf(err, "open stem stream to %s", peerID)
+	}
+	defer stream.Close()
+	writer := bufio.NewWriter(stream)
+	if _, err := writer.WriteString(messageHex + "\n"); err != nil {
+		stream.Reset()
+		return errors.Wrapf(err, "write stem stream to %s", peerID)
+	}
+	if err := writer.Flush(); err != nil {
+		stream.Reset()
+		return errors.Wrapf(err, "write stem stream to %s", peerID)
+	}
+	return nil
+}
+
+func (relay *StemRelay) handleStream(stream network.Stream) {
+	defer stream.Close()
+	if err := stream.SetReadDeadline(time.Now().Add(StemReadTimeout)); err != nil {
+		Logger.Warnf("Set read deadline of stem stream from %s: %v", stream.Conn().RemotePeer().Pretty(), err)
+		stream.Reset()
+		return
+	}
+	messageHex, err := readStemMessage(stream)
+	if err != nil {
+		stream.Reset()
+		Logger.Warnf("Read stem stream from %s: %v", stream.Conn().RemotePeer().Pretty(), err)
+		return
+	}
+	msg, err := decodeMessage(messageHex)
+	if err != nil {
+		Logger.Warnf("Decode stem message from %s: %v", stream.Conn().RemotePeer().Pretty(), err)
+		return
+	}
+	switch msg.MessageType() {
+	case wire.CmdTx, wire.CmdPrivacyCustomToken:
+		relay.onStem(msg)
+	default:
+		Logger.Warnf("Drop stem message of type %s from %s", msg.MessageType(), stream.Conn().RemotePeer().Pretty())
+	}
+}
+
+// readStemMessage reads the hex of a message ended by a newline, a message over maxStemMessageSize is an error
+func readStemMessage(reader io.Reader) (string, error) {
+	messageHex, err := bufio.NewReader(io.LimitReader(reader, maxStemMessageSize)).ReadString('\n')
+	if err == io.EOF && len(messageHex) == maxStemMessageSize {
+		return "", errors.Errorf("stem message is over %v bytes", maxStemMessageSize)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(messageHex, "\n"), nil
+}
@@ -0,0 +1,24 @@
+package peerv2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadStemMessage(t *testing.T) {
+	messageHex, err := readStemMessage(strings.NewReader("abcd\nef"))
+	assert.Nil(t, err)
+	assert.Equal(t, "abcd", messageHex)
+
+	_, err = readStemMessage(strings.NewReader("abcd"))
+	assert.NotNil(t, err)
+
+	_, err = readStemMessage(strings.NewReader(strings.Repeat("a", maxStemMessageSize+10) + "\n"))
+	assert.NotNil(t, err)
+
+	messageHex, err = readStemMessage(strings.NewReader(strings.Repeat("a", maxStemMessageSize-1) + "\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, maxStemMessageSize-1, len(messageHex))
+}
@@ -19,6 +19,10 @@ type Coin struct {
 	randomness     *Scalar
 	value          uint64
 	info           []byte //256 bytes
+
+	// txRandom and viewTag are set in a stealth coin only, see stealth.go
+	txRandom *Point
+	viewTag  byte
 }
 
 // Start GET/SET
@@ -79,6 +83,30 @@ func (coin *Coin) SetInfo(v []byte) {
 	copy(coin.info, v)
 }
 
+func (coin Coin) GetTxRandom() *Point {
+	return coin.txRandom
+}
+
+func (coin *Coin) SetTxRandom(v *Point) {
+	coin.txRandom = v
+}
+
+func (coin Coin) GetViewTag() byte {
+	return coin.viewTag
+}
+
+func (coin *Coin) SetViewTag(v byte) {
+	coin.viewTag = v
+}
+
+// GetVersion returns CoinVersionStealth for a coin with a one-time public key, CoinVersion otherwise
+func (coin Coin) GetVersion() int {
+	if coin.txRandom != nil {
+		return CoinVersionStealth
+	}
+	return CoinVersion
+}
+
 // Init (Coin) initializes a coin
 func (coin *Coin) Init() *Coin {
 	coin.publicKey = new(Point).Identity()
@@ -214,6 +242,13 @@ func (coin *Coin) Bytes() []byte {
 		coinBytes = append(coinBytes, byte(0))
 	}
 
+	// a coin of version 1 ends with its info, so its bytes do not change
+	if coin.txRandom != nil {
+		coinBytes = append(coinBytes, byte(Ed25519KeySize))
+		coinBytes = append(coinBytes, coin.txRandom.ToBytesS()...)
+		coinBytes = append(coinBytes, byte(1), coin.viewTag)
+	}
+
 	return coinBytes
 }
 
@@ -348,7 +383,27 @@ func (coin *Coin) SetBytes(coinBytes []byte) error {
 		}
 		coin.info = make([]byte, lenField)
 		copy(coin.info, coinBytes[offset:offset+int(lenField)])
+		offset += int(lenField)
+	}
+
+	// Parse TxRandom and ViewTag of a stealth coin
+	if offset >= len(coinBytes) {
+		return nil
 	}
+	lenField = coinBytes[offset]
+	offset++
+	if lenField != Ed25519KeySize || offset+int(lenField)+2 > len(coinBytes) {
+		return errors.New("out of range Parse TxRandom")
+	}
+	coin.txRandom, err = new(Point).FromBytesS(coinBytes[offset : offset+int(lenField)])
+	if err != nil {
+		return err
+	}
+	offset += int(lenField)
+	if coinBytes[offset] != 1 {
+		return errors.New("out of range Parse ViewTag")
+	}
+	coin.viewTag = coinBytes[offset+1]
 	return nil
 }
 
@@ -386,6 +441,8 @@ type CoinObject struct {
 	Randomness     string `json:"Randomness"`
 	Value          string `json:"Value"`
 	Info           string `json:"Info"`
+	TxRandom       string `json:"TxRandom"`
+	ViewTag        string `json:"ViewTag"`
 }
 
 // SetBytes (InputCoin) receives a coinBytes (in bytes array), and
@@ -473,6 +530,27 @@ func (inputCoin *InputCoin) ParseCoinObjectToInputCoin(coinObj CoinObject) error
 		}
 		inputCoin.CoinDetails.SetInfo(infoBytes)
 	}
+
+	if coinObj.TxRandom != "" {
+		txRandom, _, err := base58.Base58Check{}.Decode(coinObj.TxRandom)
+		if err != nil {
+			return err
+		}
+
+		txRandomPoint, err := new(Point).FromBytesS(txRandom)
+		if err != nil {
+			return err
+		}
+		inputCoin.CoinDetails.SetTxRandom(txRandomPoint)
+	}
+
+	if coinObj.ViewTag != "" {
+		viewTag, err := strconv.ParseUint(coinObj.ViewTag, 10, 8)
+		if err != nil {
+			return err
+		}
+		inputCoin.CoinDetails.SetViewTag(byte(viewTag))
+	}
 	return nil
 }
 
@@ -67,7 +67,7 @@ func TestCoinMarshalJSON(t *testing.T) {
 		coin.snDerivator = RandomScalar()
 		coin.randomness = RandomScalar()
 		coin.value = uint64(100)
-		coin.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
+		coin.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
 		coin.CommitAll()
 		coin.info = []byte("Incognito chain")
 
@@ -98,7 +98,7 @@ func TestCoinBytesSetBytes(t *testing.T) {
 		coin.snDerivator = RandomScalar()
 		coin.randomness = RandomScalar()
 		coin.value = uint64(100)
-		coin.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
+		coin.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
 		coin.CommitAll()
 		coin.info = []byte("Incognito chain")
 
@@ -128,7 +128,7 @@ func TestCoinBytesSetBytesWithMissingFields(t *testing.T) {
 		coin.snDerivator = RandomScalar()
 		coin.randomness = RandomScalar()
 		coin.value = uint64(100)
-		coin.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
+		coin.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
 		//coin.CommitAll()
 		coin.info = []byte("Incognito chain")
 
@@ -159,7 +159,7 @@ func TestCoinBytesSetBytesWithInvalidBytes(t *testing.T) {
 	coin.snDerivator = RandomScalar()
 	coin.randomness = RandomScalar()
 	coin.value = uint64(100)
-	coin.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
+	coin.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.snDerivator)
 	coin.CommitAll()
 	coin.info = []byte("Incognito chain")
 
@@ -203,7 +203,7 @@ func TestInputCoinBytesSetBytes(t *testing.T) {
 		coin.CoinDetails.snDerivator = RandomScalar()
 		coin.CoinDetails.randomness = RandomScalar()
 		coin.CoinDetails.value = uint64(100)
-		coin.CoinDetails.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
+		coin.CoinDetails.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
 		coin.CoinDetails.CommitAll()
 		coin.CoinDetails.info = []byte("Incognito chain")
 
@@ -232,7 +232,7 @@ func TestInputCoinBytesSetBytesWithMissingFields(t *testing.T) {
 	coin.CoinDetails.snDerivator = RandomScalar()
 	coin.CoinDetails.randomness = RandomScalar()
 	coin.CoinDetails.value = uint64(100)
-	coin.CoinDetails.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
+	coin.CoinDetails.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
 	//coin.CoinDetails.CommitAll()
 	coin.CoinDetails.info = []byte("Incognito chain")
 
@@ -259,7 +259,7 @@ func TestInputCoinBytesSetBytesWithInvalidBytes(t *testing.T) {
 	coin.CoinDetails.snDerivator = RandomScalar()
 	coin.CoinDetails.randomness = RandomScalar()
 	coin.CoinDetails.value = uint64(100)
-	coin.CoinDetails.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
+	coin.CoinDetails.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
 	//coin.CoinDetails.CommitAll()
 	coin.CoinDetails.info = []byte("Incognito chain")
 
@@ -302,7 +302,7 @@ func TestOutputCoinBytesSetBytes(t *testing.T) {
 	coin.CoinDetails.snDerivator = RandomScalar()
 	coin.CoinDetails.randomness = RandomScalar()
 	coin.CoinDetails.value = uint64(100)
-	coin.CoinDetails.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
+	coin.CoinDetails.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytesS(privateKey), coin.CoinDetails.snDerivator)
 	//coin.CoinDetails.CommitAll()
 	coin.CoinDetails.info = []byte("Incognito chain")
 	coin.Encrypt(paymentAddr.Tk)
@@ -332,7 +332,7 @@ func TestOutputCoinBytesSetBytesWithMissingFields(t *testing.T) {
 	coin.CoinDetails.snDerivator = RandomScalar()
 	coin.CoinDetails.randomness = RandomScalar()
 	coin.CoinDetails.value = uint64(100)
-	//coin.CoinDetails.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytes(SliceToArray(privateKey)), coin.CoinDetails.snDerivator)
+	//coin.CoinDetails.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytes(SliceToArray(privateKey)), coin.CoinDetails.snDerivator)
 	//coin.CoinDetails.CommitAll()
 	coin.CoinDetails.info = []byte("Incognito chain")
 	coin.Encrypt(paymentAddr.Tk)
@@ -361,7 +361,7 @@ func TestOutputCoinBytesSetBytesWithInvalidBytes(t *testing.T) {
 	coin.CoinDetails.snDerivator = RandomScalar()
 	coin.CoinDetails.randomness = RandomScalar()
 	coin.CoinDetails.value = uint64(100)
-	//coin.CoinDetails.serialNumber = PedCom.G[0].Derive(PedCom.G[0], new(Scalar).FromBytes(SliceToArray(privateKey)), coin.CoinDetails.snDerivator)
+	//coin.CoinDetails.serialNumber = new(Point).Derive(PedCom.G[0], new(Scalar).FromBytes(SliceToArray(privateKey)), coin.CoinDetails.snDerivator)
 	//coin.CoinDetails.CommitAll()
 	coin.CoinDetails.info = []byte("Incognito chain")
 	coin.Encrypt(paymentAddr.Tk)
@@ -458,3 +458,50 @@ func TestOutputCoinEncryptDecryptWithUnmatchedKey(t *testing.T) {
 	assert.NotEqual(t, coin.CoinDetails.randomness, coin2.CoinDetails.randomness)
 	assert.NotEqual(t, coin.CoinDetails.value, coin2.CoinDetails.value)
 }
+
+func TestCoinStealthPublicKey(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		privateKey := GeneratePrivateKey(RandomScalar().ToBytesS())
+		paymentAddr := GeneratePaymentAddress(privateKey)
+		viewingKey := GenerateViewingKey(privateKey)
+
+		coin := new(Coin).Init()
+		err := coin.SetStealthPublicKey(paymentAddr)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, CoinVersionStealth, coin.GetVersion())
+		assert.NotEqual(t, []byte(paymentAddr.Pk), coin.GetPublicKey().ToBytesS())
+		assert.Equal(t, common.GetShardIDFromLastByte(paymentAddr.Pk[Ed25519KeySize-1]), common.GetShardIDFromLastByte(coin.GetPubKeyLastByte()))
+
+		coin.snDerivator = RandomScalar()
+		coin.randomness = RandomScalar()
+		coin.value = uint64(100)
+		coin.info = []byte("Incognito chain")
+		err = coin.CommitAll()
+		assert.Equal(t, nil, err)
+
+		// the receiver finds the coin and opens its commitment as a coin of its public key
+		offset, ok := coin.GetStealthOffset(viewingKey.Rk, paymentAddr.Pk)
+		assert.Equal(t, true, ok)
+		coinOfReceiver := new(Coin).Init()
+		coinOfReceiver.publicKey, _ = new(Point).FromBytesS(paymentAddr.Pk)
+		coinOfReceiver.snDerivator = coin.snDerivator
+		coinOfReceiver.randomness = new(Scalar).Add(coin.randomness, offset)
+		coinOfReceiver.value = coin.value
+		err = coinOfReceiver.CommitAll()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, IsPointEqual(coin.GetCoinCommitment(), coinOfReceiver.GetCoinCommitment()))
+
+		// another receiver does not
+		otherPrivateKey := GeneratePrivateKey(RandomScalar().ToBytesS())
+		_, ok = coin.GetStealthOffset(GenerateReceivingKey(otherPrivateKey), GeneratePublicKey(otherPrivateKey))
+		assert.Equal(t, false, ok)
+
+		coin2 := new(Coin)
+		err = coin2.SetBytes(coin.Bytes())
+		assert.Equal(t, nil, err)
+		assert.Equal(t, coin, coin2)
+
+		coin2.SetTxRandom(nil)
+		assert.Equal(t, len(coin.Bytes()), len(coin2.Bytes())+StealthCoinExtraSize)
+	}
+}
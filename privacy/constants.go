@@ -9,16 +9,30 @@ const (
 )
 
 const (
-	Ed25519KeySize        = 32
-	AESKeySize            = 32
-	CommitmentRingSize    = 8
-	CommitmentRingSizeExp = 3
-	CStringBulletProof    = "bulletproof"
-	CStringBurnAddress    = "burningaddress"
+	Ed25519KeySize           = 32
+	AESKeySize               = 32
+	CommitmentRingSize       = 8 // ring size of the one-out-of-many proofs of a tx version 1
+	CommitmentRingSizeExp    = 3
+	MaxCommitmentRingSize    = 64
+	MaxCommitmentRingSizeExp = 6
+	CStringBulletProof       = "bulletproof"
+	CStringBurnAddress       = "burningaddress"
 )
 
 const (
-	MaxSizeInfoCoin = 255 // byte
+	MaxSizeInfoCoin  = 255  // byte
+	MemoInfoPrefix   = 0xfe // first byte of the info of a coin carrying an encrypted memo
+	MemoChecksumSize = 4
+	// MaxSizeMemo is the largest memo a coin carries encrypted in its info: prefix, encrypted AES key, IV, checksum
+	MaxSizeMemo = MaxSizeInfoCoin - 1 - elGamalCiphertextSize - 16 - MemoChecksumSize
+)
+
+const (
+	CoinVersion           = 1
+	CoinVersionStealth    = 2  // coin with a one-time public key, see stealth.go
+	StealthCoinExtraSize  = 35 // bytes of the tx random and the view tag of a stealth coin
+	CStringStealthOffset  = "stealthoffset"
+	CStringStealthViewTag = "stealthviewtag"
 )
 
 var LInt = new(big.Int).SetBytes([]byte{0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14, 0xde, 0xf9, 0xde, 0xa2, 0xf7, 0x9c, 0xd6, 0x58, 0x12, 0x63, 0x1a, 0x5c, 0xf5, 0xd3, 0xed})
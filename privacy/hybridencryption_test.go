@@ -50,3 +50,37 @@ func randomMessage() []byte {
 	rand.Read(msg)
 	return msg
 }
+
+func TestEncryptDecryptMemo(t *testing.T) {
+	privateKey := GeneratePrivateKey(RandomScalar().ToBytesS())
+	paymentAddr := GeneratePaymentAddress(privateKey)
+	viewingKey := GenerateViewingKey(privateKey)
+	otherViewingKey := GenerateViewingKey(GeneratePrivateKey(RandomScalar().ToBytesS()))
+
+	for _, size := range []int{1, 32, MaxSizeMemo} {
+		memo := make([]byte, size)
+		rand.Read(memo)
+		info, err := EncryptMemo(memo, paymentAddr.Tk)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, len(info) <= MaxSizeInfoCoin)
+		assert.Equal(t, true, IsEncryptedMemo(info))
+
+		memo2, err := DecryptMemo(info, viewingKey.Rk)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, memo, memo2)
+
+		// another receiver does not read the memo
+		_, err = DecryptMemo(info, otherViewingKey.Rk)
+		assert.NotEqual(t, nil, err)
+	}
+
+	// a memo is not empty and fits in the info of a coin
+	_, err := EncryptMemo([]byte{}, paymentAddr.Tk)
+	assert.NotEqual(t, nil, err)
+	_, err = EncryptMemo(make([]byte, MaxSizeMemo+1), paymentAddr.Tk)
+	assert.NotEqual(t, nil, err)
+
+	// a plain info is not a memo
+	_, err = DecryptMemo([]byte("Incognito chain"), viewingKey.Rk)
+	assert.NotEqual(t, nil, err)
+}
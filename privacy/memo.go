@@ -0,0 +1,69 @@
+package privacy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// An encrypted memo is a message of the sender to the receiver of an output coin, stored in the info of the coin:
+//   info = MemoInfoPrefix || HybridEncrypt(memo || checksum, Tk)
+// where Tk is the transmission key of the receiver and checksum the first MemoChecksumSize bytes of the hash of the
+// memo. The receiver decrypts it with its receiving key, the checksum tells it apart from a plain info starting with
+// MemoInfoPrefix. The info of a coin is not in its commitment, a memo does not change how the coin is spent.
+
+// memoChecksum returns the checksum appended to a memo before its encryption
+func memoChecksum(memo []byte) []byte {
+	return common.HashB(memo)[:MemoChecksumSize]
+}
+
+// EncryptMemo returns the info of an output coin carrying memo encrypted to transmissionKey
+func EncryptMemo(memo []byte, transmissionKey TransmissionKey) ([]byte, error) {
+	if len(memo) == 0 {
+		return nil, errors.New("memo is empty")
+	}
+	if len(memo) > MaxSizeMemo {
+		return nil, fmt.Errorf("memo has %v bytes, it is at most %v bytes", len(memo), MaxSizeMemo)
+	}
+	transmissionKeyPoint, err := new(Point).FromBytesS(transmissionKey)
+	if err != nil {
+		return nil, errors.New("transmission key of the receiver is invalid")
+	}
+	plaintext := append(append([]byte{}, memo...), memoChecksum(memo)...)
+	ciphertext, err := HybridEncrypt(plaintext, transmissionKeyPoint)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{MemoInfoPrefix}, ciphertext.Bytes()...), nil
+}
+
+// IsEncryptedMemo returns whether info may carry an encrypted memo, only its receiver knows it for sure
+func IsEncryptedMemo(info []byte) bool {
+	return len(info) > 1+elGamalCiphertextSize+16+MemoChecksumSize && info[0] == MemoInfoPrefix
+}
+
+// DecryptMemo returns the memo of info encrypted to the receiver of receivingKey, it fails when info is not a memo
+// or is a memo to another receiver
+func DecryptMemo(info []byte, receivingKey ReceivingKey) ([]byte, error) {
+	if !IsEncryptedMemo(info) {
+		return nil, errors.New("info is not an encrypted memo")
+	}
+	if len(receivingKey) != Ed25519KeySize {
+		return nil, errors.New("receiving key is invalid")
+	}
+	ciphertext := new(HybridCipherText)
+	if err := ciphertext.SetBytes(info[1:]); err != nil {
+		return nil, err
+	}
+	plaintext, err := HybridDecrypt(ciphertext, new(Scalar).FromBytesS(receivingKey))
+	if err != nil {
+		return nil, err
+	}
+	memo := plaintext[:len(plaintext)-MemoChecksumSize]
+	if !bytes.Equal(plaintext[len(memo):], memoChecksum(memo)) {
+		return nil, errors.New("memo is not encrypted to the receiving key")
+	}
+	return memo, nil
+}
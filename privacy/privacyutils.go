@@ -12,6 +12,17 @@ func RandBytes(length int) []byte {
 	return rbytes
 }
 
+// GetCommitmentRingSizeExp returns n of a ring size 2^n of the one-out-of-many proofs, ok is false when the ring
+// size is not 8, 16, 32 or 64
+func GetCommitmentRingSizeExp(ringSize int) (int, bool) {
+	for n := CommitmentRingSizeExp; n <= MaxCommitmentRingSizeExp; n++ {
+		if ringSize == 1<<uint(n) {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
 // ConvertIntToBinary represents a integer number in binary array with little endian with size n
 func ConvertIntToBinary(inum int, n int) []byte {
 	binary := make([]byte, n)
@@ -47,6 +47,18 @@ func TestUtilsConvertIntToBinary(t *testing.T) {
 	}
 }
 
+func TestUtilsGetCommitmentRingSizeExp(t *testing.T) {
+	for ringSize, exp := range map[int]int{8: 3, 16: 4, 32: 5, 64: 6} {
+		n, ok := GetCommitmentRingSizeExp(ringSize)
+		assert.True(t, ok)
+		assert.Equal(t, exp, n)
+	}
+	for _, ringSize := range []int{0, 4, 7, 24, 128} {
+		_, ok := GetCommitmentRingSizeExp(ringSize)
+		assert.False(t, ok)
+	}
+}
+
 //func TestUtilsConvertBigIntToBinary(t *testing.T) {
 //	data := []struct {
 //		number *big.Int
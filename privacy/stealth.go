@@ -0,0 +1,79 @@
+package privacy
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// A stealth coin (CoinVersionStealth) does not carry the public key of its receiver. The sender picks a random r and
+// publishes the tx random R = r*G in the coin, the sender and the receiver share the secret S = r*Tk = rk*R where Tk
+// is the transmission key and rk the receiving key of the receiver:
+//   - the one-time public key of the coin is P = Pk + H(S)*G[PedersenRandomnessIndex]
+//   - the view tag of the coin is the first byte of another hash of S, a receiver skips most coins of others with
+//     one scalar multiplication
+// The coin commitment P + v*G[1] + snd*G[2] + shardID*G[3] + rand*G[4] is the commitment of a coin of Pk with the
+// randomness rand + H(S), so the receiver spends it with its private key like a coin of version 1.
+
+// maxStealthKeyAttempts bounds the draws of r looking for a one-time public key in the shard of the receiver
+const maxStealthKeyAttempts = 1000
+
+// hashStealthSecret returns the offset of the one-time public key and the view tag of the shared secret
+func hashStealthSecret(sharedSecret *Point) (*Scalar, byte) {
+	sharedSecretBytes := sharedSecret.ToBytesS()
+	offset := HashToScalar(append([]byte(CStringStealthOffset), sharedSecretBytes...))
+	viewTag := common.HashB(append([]byte(CStringStealthViewTag), sharedSecretBytes...))[0]
+	return offset, viewTag
+}
+
+// SetStealthPublicKey sets to the coin a one-time public key of the receiver of paymentAddress with its tx random and
+// view tag, the last byte of the one-time public key maps to the shard of the receiver
+func (coin *Coin) SetStealthPublicKey(paymentAddress PaymentAddress) error {
+	if len(paymentAddress.Pk) != Ed25519KeySize || len(paymentAddress.Tk) != Ed25519KeySize {
+		return errors.New("payment address of the receiver is invalid")
+	}
+	publicKey, err := new(Point).FromBytesS(paymentAddress.Pk)
+	if err != nil {
+		return err
+	}
+	transmissionKey, err := new(Point).FromBytesS(paymentAddress.Tk)
+	if err != nil {
+		return err
+	}
+	receiverShardID := common.GetShardIDFromLastByte(paymentAddress.Pk[Ed25519KeySize-1])
+
+	for i := 0; i < maxStealthKeyAttempts; i++ {
+		r := RandomScalar()
+		offset, viewTag := hashStealthSecret(new(Point).ScalarMult(transmissionKey, r))
+		oneTimePublicKey := new(Point).Add(publicKey, new(Point).ScalarMult(PedCom.G[PedersenRandomnessIndex], offset))
+		if common.GetShardIDFromLastByte(oneTimePublicKey.ToBytesS()[Ed25519KeySize-1]) != receiverShardID {
+			continue
+		}
+		coin.publicKey = oneTimePublicKey
+		coin.txRandom = new(Point).ScalarMultBase(r)
+		coin.viewTag = viewTag
+		return nil
+	}
+	return errors.New("can not find a one-time public key in the shard of the receiver")
+}
+
+// GetStealthOffset returns H(S) and true when the stealth coin belongs to the receiver of receivingKey and publicKey,
+// the randomness of the coin for its receiver is its randomness plus H(S)
+func (coin Coin) GetStealthOffset(receivingKey ReceivingKey, publicKey PublicKey) (*Scalar, bool) {
+	if coin.txRandom == nil || coin.publicKey == nil || len(receivingKey) != Ed25519KeySize {
+		return nil, false
+	}
+	offset, viewTag := hashStealthSecret(new(Point).ScalarMult(coin.txRandom, new(Scalar).FromBytesS(receivingKey)))
+	if viewTag != coin.viewTag {
+		return nil, false
+	}
+	receiverPublicKey, err := new(Point).FromBytesS(publicKey)
+	if err != nil {
+		return nil, false
+	}
+	oneTimePublicKey := new(Point).Add(receiverPublicKey, new(Point).ScalarMult(PedCom.G[PedersenRandomnessIndex], offset))
+	if !IsPointEqual(oneTimePublicKey, coin.publicKey) {
+		return nil, false
+	}
+	return offset, true
+}
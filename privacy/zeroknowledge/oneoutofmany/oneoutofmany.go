@@ -8,6 +8,8 @@ import (
 )
 
 // This protocol proves in zero-knowledge that one-out-of-N commitments contains 0
+// N = 2^n is the ring size, 8 for a tx version 1, up to privacy.MaxCommitmentRingSize. The proof has n elements in
+// each of its arrays, so n is read from the proof and its bytes
 
 // Statement to be proved
 type OneOutOfManyStatement struct {
@@ -29,11 +31,18 @@ type OneOutOfManyProof struct {
 	zd             *privacy.Scalar
 }
 
+// GetRingSize returns the number of commitments the proof is for
+func (proof OneOutOfManyProof) GetRingSize() int {
+	return 1 << uint(len(proof.cl))
+}
+
 func (proof OneOutOfManyProof) ValidateSanity() bool {
-	if len(proof.cl) != privacy.CommitmentRingSizeExp || len(proof.ca) != privacy.CommitmentRingSizeExp ||
-		len(proof.cb) != privacy.CommitmentRingSizeExp || len(proof.cd) != privacy.CommitmentRingSizeExp ||
-		len(proof.f) != privacy.CommitmentRingSizeExp || len(proof.za) != privacy.CommitmentRingSizeExp ||
-		len(proof.zb) != privacy.CommitmentRingSizeExp {
+	n := len(proof.cl)
+	if n < privacy.CommitmentRingSizeExp || n > privacy.MaxCommitmentRingSizeExp {
+		return false
+	}
+	if len(proof.ca) != n || len(proof.cb) != n || len(proof.cd) != n ||
+		len(proof.f) != n || len(proof.za) != n || len(proof.zb) != n {
 		return false
 	}
 
@@ -134,7 +143,7 @@ func (proof OneOutOfManyProof) Bytes() []byte {
 	}
 
 	// N = 2^n
-	n := privacy.CommitmentRingSizeExp
+	n := len(proof.cl)
 
 	var bytes []byte
 
@@ -186,7 +195,11 @@ func (proof *OneOutOfManyProof) SetBytes(bytes []byte) error {
 		return nil
 	}
 
-	n := privacy.CommitmentRingSizeExp
+	// the bytes are 7 arrays of n elements and zd
+	n := (len(bytes)/privacy.Ed25519KeySize - 1) / 7
+	if n < privacy.CommitmentRingSizeExp || n > privacy.MaxCommitmentRingSizeExp || len(bytes) != utils.GetOneOfManyProofSize(n) {
+		return errors.New("invalid length of one out of many proof bytes")
+	}
 
 	offset := 0
 	var err error
@@ -262,12 +275,11 @@ func (proof *OneOutOfManyProof) SetBytes(bytes []byte) error {
 func (wit OneOutOfManyWitness) Prove() (*OneOutOfManyProof, error) {
 	// Check the number of Commitment list's elements
 	N := len(wit.stmt.Commitments)
-	if N != privacy.CommitmentRingSize {
-		return nil, errors.New("the number of Commitment list's elements must be equal to CMRingSize")
+	n, ok := privacy.GetCommitmentRingSizeExp(N)
+	if !ok {
+		return nil, errors.New("the number of Commitment list's elements must be a supported ring size")
 	}
 
-	n := privacy.CommitmentRingSizeExp
-
 	// Check indexIsZero
 	if wit.indexIsZero > uint64(N) {
 		return nil, errors.New("Index is zero must be Index in list of commitments")
@@ -380,11 +392,11 @@ func (wit OneOutOfManyWitness) Prove() (*OneOutOfManyProof, error) {
 func (proof OneOutOfManyProof) Verify() (bool, error) {
 	N := len(proof.Statement.Commitments)
 
-	// the number of Commitment list's elements must be equal to CMRingSize
-	if N != privacy.CommitmentRingSize {
+	// the number of Commitment list's elements must be equal to the ring size of the proof
+	n := len(proof.cl)
+	if _, ok := privacy.GetCommitmentRingSizeExp(N); !ok || N != proof.GetRingSize() {
 		return false, errors.New("Invalid length of commitments list in one out of many proof")
 	}
-	n := privacy.CommitmentRingSizeExp
 
 	//Calculate x
 	x := new(privacy.Scalar).FromUint64(0)
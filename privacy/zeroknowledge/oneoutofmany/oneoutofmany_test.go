@@ -89,3 +89,82 @@ func TestPKOneOfMany(t *testing.T) {
 
 	}
 }
+
+// newRingWitness returns a witness for a ring of ringSize random commitments with a commitment to zero at indexIsZero
+func newRingWitness(ringSize int, indexIsZero int) (*OneOutOfManyWitness, []*privacy.Point) {
+	commitments := make([]*privacy.Point, ringSize)
+	randoms := make([]*privacy.Scalar, ringSize)
+	for i := 0; i < ringSize; i++ {
+		randoms[i] = privacy.RandomScalar()
+		commitments[i] = privacy.PedCom.CommitAtIndex(privacy.RandomScalar(), randoms[i], privacy.PedersenSndIndex)
+	}
+	commitments[indexIsZero] = privacy.PedCom.CommitAtIndex(new(privacy.Scalar).FromUint64(0), randoms[indexIsZero], privacy.PedersenSndIndex)
+	witness := new(OneOutOfManyWitness)
+	witness.Set(commitments, randoms[indexIsZero], uint64(indexIsZero))
+	return witness, commitments
+}
+
+func TestPKOneOfManyRingSizes(t *testing.T) {
+	for _, ringSize := range []int{8, 16, 32, 64} {
+		n, _ := privacy.GetCommitmentRingSizeExp(ringSize)
+		witness, commitments := newRingWitness(ringSize, common.RandInt()%ringSize)
+		proof, err := witness.Prove()
+		assert.Equal(t, nil, err)
+		assert.True(t, proof.ValidateSanity())
+		assert.Equal(t, ringSize, proof.GetRingSize())
+
+		proofBytes := proof.Bytes()
+		assert.Equal(t, utils.GetOneOfManyProofSize(n), len(proofBytes))
+		proof2 := new(OneOutOfManyProof).Init()
+		assert.Equal(t, nil, proof2.SetBytes(proofBytes))
+		proof2.Statement.Commitments = commitments
+		res, err := proof2.Verify()
+		assert.Equal(t, true, res)
+		assert.Equal(t, nil, err)
+
+		// the commitments of a ring of another size do not verify
+		proof2.Statement.Commitments = commitments[:ringSize/2]
+		res, err = proof2.Verify()
+		assert.Equal(t, false, res)
+		assert.NotEqual(t, nil, err)
+
+		// a proof truncated to another length is rejected
+		assert.NotEqual(t, nil, new(OneOutOfManyProof).Init().SetBytes(proofBytes[:len(proofBytes)-privacy.Ed25519KeySize]))
+	}
+
+	// ring sizes out of 8..64 are not supported
+	for _, ringSize := range []int{4, 128} {
+		witness, _ := newRingWitness(ringSize, 0)
+		_, err := witness.Prove()
+		assert.NotEqual(t, nil, err)
+	}
+}
+
+func benchmarkOneOfMany(b *testing.B, ringSize int, verify bool) {
+	witness, commitments := newRingWitness(ringSize, 0)
+	proof, err := witness.Prove()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if verify {
+			proof.Statement.Commitments = commitments
+			if ok, err := proof.Verify(); !ok {
+				b.Fatal(err)
+			}
+		} else if _, err := witness.Prove(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(len(proof.Bytes())), "proofbytes")
+}
+
+func BenchmarkOneOfManyProve8(b *testing.B)   { benchmarkOneOfMany(b, 8, false) }
+func BenchmarkOneOfManyProve16(b *testing.B)  { benchmarkOneOfMany(b, 16, false) }
+func BenchmarkOneOfManyProve32(b *testing.B)  { benchmarkOneOfMany(b, 32, false) }
+func BenchmarkOneOfManyProve64(b *testing.B)  { benchmarkOneOfMany(b, 64, false) }
+func BenchmarkOneOfManyVerify8(b *testing.B)  { benchmarkOneOfMany(b, 8, true) }
+func BenchmarkOneOfManyVerify16(b *testing.B) { benchmarkOneOfMany(b, 16, true) }
+func BenchmarkOneOfManyVerify32(b *testing.B) { benchmarkOneOfMany(b, 32, true) }
+func BenchmarkOneOfManyVerify64(b *testing.B) { benchmarkOneOfMany(b, 64, true) }
@@ -49,6 +49,19 @@ func (paymentProof PaymentProof) GetOneOfManyProof() []*oneoutofmany.OneOutOfMan
 	return paymentProof.oneOfManyProof
 }
 
+// GetCommitmentRingSize returns the ring size of the one out of many proofs, 0 when there is no proof or when the
+// proofs have different ring sizes
+func (paymentProof PaymentProof) GetCommitmentRingSize() int {
+	ringSize := 0
+	for i, oneOfManyProof := range paymentProof.oneOfManyProof {
+		if i > 0 && oneOfManyProof.GetRingSize() != ringSize {
+			return 0
+		}
+		ringSize = oneOfManyProof.GetRingSize()
+	}
+	return ringSize
+}
+
 func (paymentProof PaymentProof) GetSerialNumberProof() []*serialnumberprivacy.SNPrivacyProof {
 	return paymentProof.serialNumberProof
 }
@@ -168,7 +181,7 @@ func (proof *PaymentProof) Bytes() []byte {
 	bytes = append(bytes, byte(len(proof.oneOfManyProof)))
 	for i := 0; i < len(proof.oneOfManyProof); i++ {
 		oneOfManyProof := proof.oneOfManyProof[i].Bytes()
-		bytes = append(bytes, common.IntToBytes(utils.OneOfManyProofSize)...)
+		bytes = append(bytes, common.IntToBytes(len(oneOfManyProof))...)
 		bytes = append(bytes, oneOfManyProof...)
 	}
 
@@ -612,9 +625,13 @@ func (proof *PaymentProof) SetBytes(proofbytes []byte) *privacy.PrivacyError {
 		offset += lenComInputShardID
 	}
 
-	// get commitments list
-	proof.commitmentIndices = make([]uint64, len(proof.oneOfManyProof)*privacy.CommitmentRingSize)
-	for i := 0; i < len(proof.oneOfManyProof)*privacy.CommitmentRingSize; i++ {
+	// get commitments list, a ring of commitments for each one out of many proof
+	lenCommitmentIndices := 0
+	for i := 0; i < len(proof.oneOfManyProof); i++ {
+		lenCommitmentIndices += proof.oneOfManyProof[i].GetRingSize()
+	}
+	proof.commitmentIndices = make([]uint64, lenCommitmentIndices)
+	for i := 0; i < lenCommitmentIndices; i++ {
 		if offset+common.Uint64Size > len(proofbytes) {
 			return privacy.NewPrivacyErr(privacy.SetBytesProofErr, errors.New("Out of range commitment indices"))
 		}
@@ -719,9 +736,13 @@ func (proof PaymentProof) verifyNoPrivacy(pubKey privacy.PublicKey, fee uint64,
 func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64, stateDB *statedb.StateDB, shardID byte, tokenID *common.Hash, isBatch bool) (bool, error) {
 	// verify for input coins
 	cmInputSum := make([]*privacy.Point, len(proof.oneOfManyProof))
+	ringSize := proof.GetCommitmentRingSize()
+	if ringSize == 0 || len(proof.commitmentIndices) != len(proof.oneOfManyProof)*ringSize {
+		return false, privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, errors.New("one out of many proofs must have the same ring size"))
+	}
 	for i := 0; i < len(proof.oneOfManyProof); i++ {
 		privacy.Logger.Log.Debugf("[TEST] input coins %v\n ShardID %v fee %v", i, shardID, fee)
-		privacy.Logger.Log.Debugf("[TEST] commitments indices %v\n", proof.commitmentIndices[i*privacy.CommitmentRingSize:i*privacy.CommitmentRingSize+8])
+		privacy.Logger.Log.Debugf("[TEST] commitments indices %v\n", proof.commitmentIndices[i*ringSize:(i+1)*ringSize])
 		// Verify for the proof one-out-of-N commitments is a commitment to the coins being spent
 		// Calculate cm input sum
 		cmInputSum[i] = new(privacy.Point).Add(proof.commitmentInputSecretKey, proof.commitmentInputValue[i])
@@ -729,9 +750,9 @@ func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64,
 		cmInputSum[i].Add(cmInputSum[i], proof.commitmentInputShardID)
 
 		// get commitments list from CommitmentIndices
-		commitments := make([]*privacy.Point, privacy.CommitmentRingSize)
-		for j := 0; j < privacy.CommitmentRingSize; j++ {
-			index := proof.commitmentIndices[i*privacy.CommitmentRingSize+j]
+		commitments := make([]*privacy.Point, ringSize)
+		for j := 0; j < ringSize; j++ {
+			index := proof.commitmentIndices[i*ringSize+j]
 			commitmentBytes, err := statedb.GetCommitmentByIndex(stateDB, *tokenID, index, shardID)
 			privacy.Logger.Log.Debugf("[TEST] commitment at index %v: %v\n", index, commitmentBytes)
 			if err != nil {
@@ -2,6 +2,7 @@ package zkp
 
 import (
 	"errors"
+	"fmt"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/aggregaterange"
@@ -104,6 +105,18 @@ func (wit *PaymentWitness) Init(PaymentWitnessParam PaymentWitnessParam) *privac
 	numInputCoin := len(wit.inputCoins)
 	numOutputCoin := len(wit.outputCoins)
 
+	// each input coin has a ring of commitments, all rings have the same size
+	ringSize := privacy.CommitmentRingSize
+	if numInputCoin > 0 {
+		if len(commitmentIndices)%numInputCoin != 0 || len(commitments) != len(commitmentIndices) {
+			return privacy.NewPrivacyErr(privacy.ProveOneOutOfManyErr, errors.New("commitments of input coins are invalid"))
+		}
+		ringSize = len(commitmentIndices) / numInputCoin
+		if _, ok := privacy.GetCommitmentRingSizeExp(ringSize); !ok {
+			return privacy.NewPrivacyErr(privacy.ProveOneOutOfManyErr, fmt.Errorf("ring size %v of commitments is not supported", ringSize))
+		}
+	}
+
 	randInputSK := privacy.RandomScalar()
 	// set rand sk for Schnorr signature
 	wit.randSecretKey = new(privacy.Scalar).Set(randInputSK)
@@ -171,22 +184,22 @@ func (wit *PaymentWitness) Init(PaymentWitnessParam PaymentWitnessParam) *privac
 		randInputSumAll.Add(randInputSumAll, randInputSum[i])
 
 		// commitmentTemps is a list of commitments for protocol one-out-of-N
-		commitmentTemps[i] = make([]*privacy.Point, privacy.CommitmentRingSize)
+		commitmentTemps[i] = make([]*privacy.Point, ringSize)
 
 		randInputIsZero[i] = new(privacy.Scalar).FromUint64(0)
 		randInputIsZero[i].Sub(inputCoin.CoinDetails.GetRandomness(), randInputSum[i])
 
-		for j := 0; j < privacy.CommitmentRingSize; j++ {
+		for j := 0; j < ringSize; j++ {
 			commitmentTemps[i][j] = new(privacy.Point).Sub(commitments[preIndex+j], cmInputSum[i])
 		}
 
 		if wit.oneOfManyWitness[i] == nil {
 			wit.oneOfManyWitness[i] = new(oneoutofmany.OneOutOfManyWitness)
 		}
-		indexIsZero := myCommitmentIndices[i] % privacy.CommitmentRingSize
+		indexIsZero := myCommitmentIndices[i] % uint64(ringSize)
 
 		wit.oneOfManyWitness[i].Set(commitmentTemps[i], randInputIsZero[i], indexIsZero)
-		preIndex = privacy.CommitmentRingSize * (i + 1)
+		preIndex = ringSize * (i + 1)
 		// ---------------------------------------------------
 
 		/***** Build witness for proving that serial number is derived from the committed derivator *****/
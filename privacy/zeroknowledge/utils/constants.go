@@ -2,7 +2,7 @@ package utils
 
 const (
 	// size of zero knowledge proof corresponding one input
-	OneOfManyProofSize   = 704
+	OneOfManyProofSize   = 704 // ring size 8, see GetOneOfManyProofSize
 	SnPrivacyProofSize   = 320
 	SnNoPrivacyProofSize = 192
 
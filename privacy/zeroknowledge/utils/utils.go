@@ -25,8 +25,15 @@ func GenerateChallenge(values [][]byte) *privacy.Scalar {
 	return hash
 }
 
-// EstimateProofSize returns the estimated size of the proof in bytes
-func EstimateProofSize(nInput int, nOutput int, hasPrivacy bool) uint64 {
+// GetOneOfManyProofSize returns the size in bytes of a one-out-of-many proof for a ring size 2^n: cl, ca, cb, cd,
+// f, za and zb of n elements and zd
+func GetOneOfManyProofSize(n int) int {
+	return (7*n + 1) * privacy.Ed25519KeySize
+}
+
+// EstimateProofSize returns the estimated size of the proof in bytes, ringSize is the number of commitments of the
+// one-out-of-many proof of each input, 0 is privacy.CommitmentRingSize
+func EstimateProofSize(nInput int, nOutput int, hasPrivacy bool, ringSize int) uint64 {
 	if !hasPrivacy {
 		FlagSize := 14 + 2*nInput + nOutput
 		sizeSNNoPrivacyProof := nInput * SnNoPrivacyProofSize
@@ -39,7 +46,11 @@ func EstimateProofSize(nInput int, nOutput int, hasPrivacy bool) uint64 {
 
 	FlagSize := 14 + 7*nInput + 4*nOutput
 
-	sizeOneOfManyProof := nInput * OneOfManyProofSize
+	ringSizeExp, ok := privacy.GetCommitmentRingSizeExp(ringSize)
+	if !ok {
+		ringSize, ringSizeExp = privacy.CommitmentRingSize, privacy.CommitmentRingSizeExp
+	}
+	sizeOneOfManyProof := nInput * GetOneOfManyProofSize(ringSizeExp)
 	sizeSNPrivacyProof := nInput * SnPrivacyProofSize
 	sizeComOutputMultiRangeProof := int(aggregaterange.EstimateMultiRangeProofSize(nOutput))
 
@@ -55,7 +66,7 @@ func EstimateProofSize(nInput int, nOutput int, hasPrivacy bool) uint64 {
 	sizeComInputSND := nInput * privacy.Ed25519KeySize
 	sizeComInputShardID := privacy.Ed25519KeySize
 
-	sizeCommitmentIndices := nInput * privacy.CommitmentRingSize * common.Uint64Size
+	sizeCommitmentIndices := nInput * ringSize * common.Uint64Size
 
 	sizeProof := sizeOneOfManyProof + sizeSNPrivacyProof +
 		sizeComOutputMultiRangeProof + sizeInputCoins + sizeOutputCoins +
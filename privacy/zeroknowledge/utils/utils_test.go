@@ -3,9 +3,21 @@ package utils
 import (
 	"fmt"
 	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestEstimateProofSize(t *testing.T) {
-	testcase1 := EstimateProofSize(4, 1, true)
+	testcase1 := EstimateProofSize(4, 1, true, 0)
 	fmt.Printf("testcase 1: %v\n", testcase1)
+	assert.Equal(t, testcase1, EstimateProofSize(4, 1, true, privacy.CommitmentRingSize))
+	assert.Equal(t, OneOfManyProofSize, GetOneOfManyProofSize(privacy.CommitmentRingSizeExp))
+
+	// each input has a larger one-out-of-many proof and more commitment indices
+	size64 := EstimateProofSize(4, 1, true, privacy.MaxCommitmentRingSize)
+	extra := 4 * (GetOneOfManyProofSize(privacy.MaxCommitmentRingSizeExp) - OneOfManyProofSize +
+		(privacy.MaxCommitmentRingSize-privacy.CommitmentRingSize)*common.Uint64Size)
+	assert.Equal(t, testcase1+uint64(extra), size64)
 }
@@ -6,6 +6,8 @@ const ChanWorkLoad = 100
 const (
 	NewShardblockTopic              = "newshardblocktopic"
 	NewBeaconBlockTopic             = "newbeaconblocktopic"
+	FinalizedShardBlockTopic        = "finalizedshardblocktopic"
+	FinalizedBeaconBlockTopic       = "finalizedbeaconblocktopic"
 	TransactionHashEnterNodeTopic   = "transactionhashenternodetopic"
 	ShardRoleTopic                  = "shardroletopic"
 	BeaconRoleTopic                 = "beaconroletopic"
@@ -22,6 +24,8 @@ const (
 var Topics = []string{
 	NewShardblockTopic,
 	NewBeaconBlockTopic,
+	FinalizedShardBlockTopic,
+	FinalizedBeaconBlockTopic,
 	MempoolInfoTopic,
 	TestTopic,
 	TransactionHashEnterNodeTopic,
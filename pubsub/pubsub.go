@@ -18,6 +18,7 @@ type PubSubManager struct {
 	topicList      []string                         // only allow registered Topic
 	subscriberList map[string]map[uint]EventChannel // List of Subscriber
 	messageBroker  map[string][]*Message            // Message pool
+	deliveries     map[uint]chan struct{}           // last delivery to each subscriber, closed when done
 	idGenerator    uint                             // id generator for event
 	cond           *sync.Cond
 }
@@ -27,6 +28,7 @@ func NewPubSubManager() *PubSubManager {
 		topicList:      Topics,
 		subscriberList: make(map[string]map[uint]EventChannel),
 		messageBroker:  make(map[string][]*Message),
+		deliveries:     make(map[uint]chan struct{}),
 		idGenerator:    0,
 		cond:           sync.NewCond(&sync.Mutex{}),
 	}
@@ -41,11 +43,21 @@ func (pubSubManager *PubSubManager) Start() {
 	for {
 		pubSubManager.cond.L.Lock()
 		for topic, messages := range pubSubManager.messageBroker {
-			for _, message := range messages {
-				if subMap, ok := pubSubManager.subscriberList[topic]; ok {
-					for _, event := range subMap {
-						go event.NotifyMessage(message)
-					}
+			if subMap, ok := pubSubManager.subscriberList[topic]; ok && len(messages) > 0 {
+				for id, event := range subMap {
+					// a subscriber gets messages in the order they are published, a delivery waits for the previous one
+					previous := pubSubManager.deliveries[id]
+					done := make(chan struct{})
+					pubSubManager.deliveries[id] = done
+					go func(event EventChannel, messages []*Message, previous chan struct{}, done chan struct{}) {
+						if previous != nil {
+							<-previous
+						}
+						for _, message := range messages {
+							event.NotifyMessage(message)
+						}
+						close(done)
+					}(event, messages, previous, done)
 				}
 			}
 			// delete message (if no thing subscribe for it then delete msg too)
@@ -90,6 +102,7 @@ func (pubSubManager *PubSubManager) Unsubscribe(topic string, subId uint) {
 	if subMap, ok := pubSubManager.subscriberList[topic]; ok {
 		if _, ok := subMap[subId]; ok {
 			delete(subMap, subId)
+			delete(pubSubManager.deliveries, subId)
 		}
 	}
 }
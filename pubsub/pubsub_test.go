@@ -117,6 +117,25 @@ func TestMessageBroken(t *testing.T) {
 	pubsubManager.Unsubscribe(TestTopic, id)
 	return
 }
+func TestMessageOrder(t *testing.T) {
+	var pubsubManager = NewPubSubManager()
+	go pubsubManager.Start()
+	id, event, err := pubsubManager.RegisterNewSubscriber(TestTopic)
+	if err != nil {
+		t.Error("Error when subcription")
+	}
+	numMessages := 10 * ChanWorkLoad
+	for i := 0; i < numMessages; i++ {
+		pubsubManager.PublishMessage(NewMessage(TestTopic, i))
+	}
+	for i := 0; i < numMessages; i++ {
+		msg := <-event
+		if value, ok := msg.Value.(int); !ok || value != i {
+			t.Fatalf("Message %v out of order, got %+v", i, msg.Value)
+		}
+	}
+	pubsubManager.Unsubscribe(TestTopic, id)
+}
 func TestHasTopic(t *testing.T) {
 	var pubsubManager = NewPubSubManager()
 	if !pubsubManager.HasTopic(NewBeaconBlockTopic) {
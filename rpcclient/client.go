@@ -0,0 +1,170 @@
+package rpcclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+//go:generate go run ./internal/gen
+
+const (
+	DefaultTimeout = 60 * time.Second
+	jsonRPCVersion = "1.0"
+)
+
+// Request is the JSON-RPC 1.0 envelope understood by rpcserver
+type Request struct {
+	Jsonrpc string      `json:"Jsonrpc"`
+	Method  string      `json:"Method"`
+	Params  interface{} `json:"Params"`
+	Id      interface{} `json:"Id"`
+}
+
+// Response is the JSON-RPC envelope written by rpcserver, Result is decoded by the typed methods
+type Response struct {
+	Id      *interface{}         `json:"Id"`
+	Result  json.RawMessage      `json:"Result"`
+	Error   *rpcservice.RPCError `json:"Error"`
+	Params  interface{}          `json:"Params"`
+	Method  string               `json:"Method"`
+	Jsonrpc string               `json:"Jsonrpc"`
+}
+
+// Client call JSON-RPC methods of a node over http.
+// Every handler registered in rpcserver has a typed method in methods.go.
+type Client struct {
+	url        string
+	user       string
+	pass       string
+	httpClient *http.Client
+	requestID  uint64
+}
+
+// NewClient create client for node listening at url, ex: http://127.0.0.1:9334
+func NewClient(url string) *Client {
+	return &Client{
+		url:        url,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// NewClientWithAuth create client sending basic auth on every request,
+// use the limited user credential to call limited methods
+func NewClientWithAuth(url string, user string, pass string) *Client {
+	client := NewClient(url)
+	client.user = user
+	client.pass = pass
+	return client
+}
+
+// SetHTTPClient replace the underlying http client, ex: to change timeout or transport
+func (client *Client) SetHTTPClient(httpClient *http.Client) {
+	client.httpClient = httpClient
+}
+
+// Call send method with params and decode the result into result (a pointer), result can be nil to discard it.
+// An error reported by the node is returned as *rpcservice.RPCError.
+func (client *Client) Call(method string, params []interface{}, result interface{}) error {
+	response, err := client.CallRaw(method, params)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	return decodeResult(response.Result, result)
+}
+
+// CallRaw send method with params and return the undecoded response
+func (client *Client) CallRaw(method string, params []interface{}) (*Response, error) {
+	httpResponse, err := client.post(method, params)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	return readResponse(method, httpResponse)
+}
+
+// DownloadBackup call "downloadbackup" and copy the streamed file of the backup into w,
+// an error reported by the node is returned as *rpcservice.RPCError
+func (client *Client) DownloadBackup(w io.Writer, params ...interface{}) error {
+	httpResponse, err := client.post("downloadbackup", params)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.Header.Get("Content-Type") != "application/octet-stream" {
+		_, err := readResponse("downloadbackup", httpResponse)
+		return err
+	}
+	if _, err := io.Copy(w, httpResponse.Body); err != nil {
+		return NewRPCClientError(NetworkError, err)
+	}
+	return nil
+}
+
+func (client *Client) post(method string, params []interface{}) (*http.Response, error) {
+	if params == nil {
+		params = []interface{}{}
+	}
+	request := Request{
+		Jsonrpc: jsonRPCVersion,
+		Method:  method,
+		Params:  params,
+		Id:      atomic.AddUint64(&client.requestID, 1),
+	}
+	requestBytes, err := json.Marshal(&request)
+	if err != nil {
+		return nil, NewRPCClientError(MarshalRequestError, err)
+	}
+	httpRequest, err := http.NewRequest(http.MethodPost, client.url, bytes.NewReader(requestBytes))
+	if err != nil {
+		return nil, NewRPCClientError(NetworkError, err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	if client.user != "" {
+		httpRequest.SetBasicAuth(client.user, client.pass)
+	}
+	httpResponse, err := client.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, NewRPCClientError(NetworkError, err)
+	}
+	return httpResponse, nil
+}
+
+func readResponse(method string, httpResponse *http.Response) (*Response, error) {
+	responseBytes, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, NewRPCClientError(NetworkError, err)
+	}
+	response := &Response{}
+	if err := json.Unmarshal(responseBytes, response); err != nil {
+		if httpResponse.StatusCode != http.StatusOK {
+			return nil, NewRPCClientError(HTTPStatusError, fmt.Errorf("method %+v status %+v", method, httpResponse.Status))
+		}
+		return nil, NewRPCClientError(UnmarshalResponseError, err)
+	}
+	if response.Error != nil {
+		return response, response.Error
+	}
+	return response, nil
+}
+
+func decodeResult(raw json.RawMessage, result interface{}) error {
+	if len(raw) == 0 {
+		return NewRPCClientError(UnmarshalResultError, errors.New("empty result"))
+	}
+	if err := json.Unmarshal(raw, result); err != nil {
+		return NewRPCClientError(UnmarshalResultError, err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package rpcclient
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	UnexpectedError = iota
+	MarshalRequestError
+	NetworkError
+	HTTPStatusError
+	UnmarshalResponseError
+	UnmarshalResultError
+	WebsocketDialError
+	WebsocketClosedError
+)
+
+var ErrCodeMessage = map[int]struct {
+	Code    int
+	Message string
+}{
+	UnexpectedError:        {-1000, "Unexpected Error"},
+	MarshalRequestError:    {-1001, "Marshal Request Error"},
+	NetworkError:           {-1002, "Network Error"},
+	HTTPStatusError:        {-1003, "Http Status Error"},
+	UnmarshalResponseError: {-1004, "Unmarshal Response Error"},
+	UnmarshalResultError:   {-1005, "Unmarshal Result Error"},
+	WebsocketDialError:     {-1006, "Websocket Dial Error"},
+	WebsocketClosedError:   {-1007, "Websocket Client Closed Error"},
+}
+
+type RPCClientError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e RPCClientError) Error() string {
+	return fmt.Sprintf("%d: %s %+v", e.Code, e.Message, e.Err)
+}
+
+func NewRPCClientError(key int, err error) *RPCClientError {
+	return &RPCClientError{
+		Code:    ErrCodeMessage[key].Code,
+		Message: ErrCodeMessage[key].Message,
+		Err:     errors.Wrap(err, ErrCodeMessage[key].Message),
+	}
+}
@@ -0,0 +1,410 @@
+// gen build rpcclient/methods.go from the handler tables of rpcserver.
+// The result type of each typed method is the static type returned by its handler,
+// handlers returning types that cannot be decoded from JSON fall back to json.RawMessage.
+//
+// Usage (from rpcclient directory): go run ./internal/gen
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	rpcServerDir  = "../rpcserver"
+	rpcServerPath = "github.com/incognitochain/incognito-chain/rpcserver"
+	outputFile    = "methods.go"
+)
+
+type handler struct {
+	Name       string // rpc method name
+	Const      string // constant name in rpcserver
+	Func       string // handler func name
+	Limited    bool
+	Websocket  bool
+	HasParams  bool
+	ResultType types.Type
+}
+
+type generator struct {
+	fset    *token.FileSet
+	pkg     *types.Package
+	info    *types.Info
+	files   []*ast.File
+	funcs   map[string]*ast.FuncDecl
+	imports map[string]string // path -> name
+}
+
+func main() {
+	g := &generator{fset: token.NewFileSet(), funcs: make(map[string]*ast.FuncDecl), imports: make(map[string]string)}
+	if err := g.load(); err != nil {
+		log.Fatal(err)
+	}
+	handlers := g.collectHandlers()
+	src, err := g.render(handlers)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(outputFile, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func (g *generator) load() error {
+	pkgs, err := parser.ParseDir(g.fset, rpcServerDir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return err
+	}
+	pkg, ok := pkgs["rpcserver"]
+	if !ok {
+		return fmt.Errorf("package rpcserver not found in %s", rpcServerDir)
+	}
+	names := make([]string, 0, len(pkg.Files))
+	for name := range pkg.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		g.files = append(g.files, pkg.Files[name])
+	}
+	g.info = &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(g.fset, "source", nil)}
+	g.pkg, err = conf.Check(rpcServerPath, g.fset, g.files, g.info)
+	if err != nil {
+		return err
+	}
+	for _, file := range g.files {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil {
+				g.funcs[fn.Name.Name] = fn
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) collectHandlers() []*handler {
+	handlers := []*handler{}
+	for _, table := range []struct {
+		name      string
+		limited   bool
+		websocket bool
+	}{{"HttpHandler", false, false}, {"LimitedHttpHandler", true, false}, {"WsHandler", false, true}} {
+		lit := g.findTable(table.name)
+		for _, elt := range lit.Elts {
+			kv := elt.(*ast.KeyValueExpr)
+			key := kv.Key.(*ast.Ident)
+			value := g.info.Types[kv.Key].Value
+			if value == nil || value.Kind() != constant.String {
+				log.Fatalf("key %s of %s is not a string constant", key.Name, table.name)
+			}
+			funcName := kv.Value.(*ast.SelectorExpr).Sel.Name
+			fn, ok := g.funcs[funcName]
+			if !ok {
+				log.Fatalf("handler %s not found", funcName)
+			}
+			h := &handler{
+				Name:      constant.StringVal(value),
+				Const:     key.Name,
+				Func:      funcName,
+				Limited:   table.limited,
+				Websocket: table.websocket,
+				HasParams: g.usesParams(fn),
+			}
+			if table.websocket {
+				h.ResultType = g.wsResultType(fn)
+			} else {
+				h.ResultType = g.httpResultType(fn)
+			}
+			handlers = append(handlers, h)
+		}
+	}
+	sort.SliceStable(handlers, func(i, j int) bool {
+		if handlers[i].Websocket != handlers[j].Websocket {
+			return !handlers[i].Websocket
+		}
+		return handlers[i].Name < handlers[j].Name
+	})
+	return handlers
+}
+
+func (g *generator) findTable(name string) *ast.CompositeLit {
+	for _, file := range g.files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, n := range vs.Names {
+					if n.Name == name {
+						return vs.Values[i].(*ast.CompositeLit)
+					}
+				}
+			}
+		}
+	}
+	log.Fatalf("table %s not found", name)
+	return nil
+}
+
+func (g *generator) usesParams(fn *ast.FuncDecl) bool {
+	paramsObj := g.info.Defs[fn.Type.Params.List[0].Names[0]]
+	used := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && g.info.Uses[ident] == paramsObj {
+			used = true
+		}
+		return !used
+	})
+	return used
+}
+
+// httpResultType return the single concrete type returned as first result of handler, nil if undecidable
+func (g *generator) httpResultType(fn *ast.FuncDecl) types.Type {
+	candidates := []types.Type{}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) == 2 {
+				candidates = append(candidates, g.info.Types[node.Results[0]].Type)
+			}
+		}
+		return true
+	})
+	return g.pickType(candidates)
+}
+
+// wsResultType return the type of results pushed to the subscription channel, ignoring unsubscribe notices
+func (g *generator) wsResultType(fn *ast.FuncDecl) types.Type {
+	candidates := []types.Type{}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		send, ok := n.(*ast.SendStmt)
+		if !ok {
+			return true
+		}
+		lit, ok := send.Value.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok || kv.Key.(*ast.Ident).Name != "Result" {
+				continue
+			}
+			t := g.info.Types[kv.Value].Type
+			if named, ok := t.(*types.Named); ok && named.Obj().Name() == "UnsubcribeResult" {
+				continue
+			}
+			candidates = append(candidates, t)
+		}
+		return true
+	})
+	return g.pickType(candidates)
+}
+
+func (g *generator) pickType(candidates []types.Type) types.Type {
+	var result types.Type
+	for _, t := range candidates {
+		if t == nil {
+			continue
+		}
+		if basic, ok := t.(*types.Basic); ok && basic.Kind() == types.UntypedNil {
+			continue
+		}
+		if b, ok := t.(*types.Basic); ok && b.Info()&types.IsUntyped != 0 {
+			t = types.Default(t)
+		}
+		if result == nil {
+			result = t
+		} else if !types.Identical(result, t) {
+			return nil
+		}
+	}
+	if result == nil || !g.decodable(result, make(map[types.Type]bool)) {
+		return nil
+	}
+	return result
+}
+
+// decodable report whether encoding/json can decode into t and t can be named from another package
+func (g *generator) decodable(t types.Type, seen map[types.Type]bool) bool {
+	if seen[t] {
+		return true
+	}
+	seen[t] = true
+	switch tt := t.(type) {
+	case *types.Basic:
+		return tt.Kind() != types.UnsafePointer && tt.Info()&types.IsComplex == 0
+	case *types.Pointer:
+		return g.decodable(tt.Elem(), seen)
+	case *types.Slice:
+		return g.decodable(tt.Elem(), seen)
+	case *types.Array:
+		return g.decodable(tt.Elem(), seen)
+	case *types.Map:
+		return g.decodable(tt.Key(), seen) && g.decodable(tt.Elem(), seen)
+	case *types.Interface:
+		return tt.Empty()
+	case *types.Named:
+		obj := tt.Obj()
+		if obj.Pkg() == nil {
+			return obj.Name() != "error"
+		}
+		// types declared inside a function body are not reachable from other packages
+		if !obj.Exported() || obj.Parent() != obj.Pkg().Scope() || obj.Pkg().Name() == "main" || strings.Contains(obj.Pkg().Path(), "/internal/") {
+			return false
+		}
+		if _, ok := tt.Underlying().(*types.Interface); ok {
+			return tt.Underlying().(*types.Interface).Empty()
+		}
+		if implementsUnmarshaler(tt) {
+			return true
+		}
+		return g.decodable(tt.Underlying(), seen)
+	case *types.Struct:
+		for i := 0; i < tt.NumFields(); i++ {
+			field := tt.Field(i)
+			if !field.Exported() && !field.Embedded() {
+				continue
+			}
+			if !g.decodable(field.Type(), seen) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func implementsUnmarshaler(t *types.Named) bool {
+	methods := types.NewMethodSet(types.NewPointer(t))
+	return methods.Lookup(nil, "UnmarshalJSON") != nil || methods.Lookup(nil, "UnmarshalText") != nil
+}
+
+func (g *generator) qualifier(pkg *types.Package) string {
+	name := pkg.Name()
+	if pkg.Path() == rpcServerPath {
+		name = "rpcserver"
+	}
+	g.imports[pkg.Path()] = name
+	return name
+}
+
+func exportedName(name string) string {
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func (g *generator) render(handlers []*handler) ([]byte, error) {
+	body := &bytes.Buffer{}
+	for _, h := range handlers {
+		if h.Websocket {
+			g.renderSubscribe(body, h)
+		} else {
+			g.renderCall(body, h)
+		}
+	}
+	out := &bytes.Buffer{}
+	fmt.Fprintln(out, "// Code generated by rpcclient/internal/gen. DO NOT EDIT.")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "package rpcclient")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "import (")
+	g.imports["encoding/json"] = "json"
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	// standard library first, then a blank line and the others, as goimports does
+	for _, std := range []bool{true, false} {
+		if !std {
+			fmt.Fprintln(out)
+		}
+		for _, path := range paths {
+			if isStd := !strings.Contains(strings.Split(path, "/")[0], "."); isStd != std {
+				continue
+			}
+			if filepath.Base(path) == g.imports[path] {
+				fmt.Fprintf(out, "\t%q\n", path)
+			} else {
+				fmt.Fprintf(out, "\t%s %q\n", g.imports[path], path)
+			}
+		}
+	}
+	fmt.Fprintln(out, ")")
+	fmt.Fprintln(out)
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+func (g *generator) typeString(t types.Type) string {
+	if t == nil {
+		return "json.RawMessage"
+	}
+	return types.TypeString(t, g.qualifier)
+}
+
+func (g *generator) renderCall(w *bytes.Buffer, h *handler) {
+	resultType := g.typeString(h.ResultType)
+	method := exportedName(h.Const)
+	params := "nil"
+	signature := ""
+	if h.HasParams {
+		params = "params"
+		signature = "params ...interface{}"
+	}
+	fmt.Fprintln(w)
+	if h.Limited {
+		fmt.Fprintf(w, "// %s call %q, available to limited users\n", method, h.Name)
+	} else {
+		fmt.Fprintf(w, "// %s call %q\n", method, h.Name)
+	}
+	fmt.Fprintf(w, "func (client *Client) %s(%s) (%s, error) {\n", method, signature, resultType)
+	fmt.Fprintf(w, "\tvar result %s\n", resultType)
+	fmt.Fprintf(w, "\terr := client.Call(%q, %s, &result)\n", h.Name, params)
+	fmt.Fprintln(w, "\treturn result, err")
+	fmt.Fprintln(w, "}")
+}
+
+func (g *generator) renderSubscribe(w *bytes.Buffer, h *handler) {
+	resultType := g.typeString(h.ResultType)
+	method := exportedName(strings.Replace(h.Const, "subcribe", "subscribe", 1))
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "// %s subscribe %q, every notification is decoded into %s and passed to handler\n", method, h.Name, resultType)
+	fmt.Fprintf(w, "func (client *WsClient) %s(handler func(%s, error), params ...interface{}) (*Subscription, error) {\n", method, resultType)
+	fmt.Fprintf(w, "\treturn client.Subscribe(%q, params, func(raw json.RawMessage, err error) {\n", h.Name)
+	fmt.Fprintf(w, "\t\tvar result %s\n", resultType)
+	fmt.Fprintln(w, "\t\tif err == nil {")
+	fmt.Fprintln(w, "\t\t\terr = decodeResult(raw, &result)")
+	fmt.Fprintln(w, "\t\t}")
+	fmt.Fprintln(w, "\t\thandler(result, err)")
+	fmt.Fprintln(w, "\t})")
+	fmt.Fprintln(w, "}")
+}
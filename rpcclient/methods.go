@@ -0,0 +1,1699 @@
+// Code generated by rpcclient/internal/gen. DO NOT EDIT.
+
+package rpcclient
+
+import (
+	"encoding/json"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	"github.com/incognitochain/incognito-chain/rpcserver"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// CanPubkeyStake call "canpubkeystake"
+func (client *Client) CanPubkeyStake(params ...interface{}) (*jsonresult.StakeResult, error) {
+	var result *jsonresult.StakeResult
+	err := client.Call("canpubkeystake", params, &result)
+	return result, err
+}
+
+// CheckETHHashIssued call "checkethhashissued"
+func (client *Client) CheckETHHashIssued(params ...interface{}) (bool, error) {
+	var result bool
+	err := client.Call("checkethhashissued", params, &result)
+	return result, err
+}
+
+// CheckHashValue call "checkhashvalue"
+func (client *Client) CheckHashValue(params ...interface{}) (jsonresult.HashValueDetail, error) {
+	var result jsonresult.HashValueDetail
+	err := client.Call("checkhashvalue", params, &result)
+	return result, err
+}
+
+// ConvertExchangeRates call "convertexchangerates"
+func (client *Client) ConvertExchangeRates(params ...interface{}) (map[string]uint64, error) {
+	var result map[string]uint64
+	err := client.Call("convertexchangerates", params, &result)
+	return result, err
+}
+
+// ConvertNativeTokenToPrivacyToken call "convertnativetokentoprivacytoken", available to limited users
+func (client *Client) ConvertNativeTokenToPrivacyToken(params ...interface{}) (float64, error) {
+	var result float64
+	err := client.Call("convertnativetokentoprivacytoken", params, &result)
+	return result, err
+}
+
+// ConvertPDEPrices call "convertpdeprices"
+func (client *Client) ConvertPDEPrices(params ...interface{}) ([]*rpcserver.ConvertedPrice, error) {
+	var result []*rpcserver.ConvertedPrice
+	err := client.Call("convertpdeprices", params, &result)
+	return result, err
+}
+
+// ConvertPrivacyTokenToNativeToken call "convertprivacytokentonativetoken", available to limited users
+func (client *Client) ConvertPrivacyTokenToNativeToken(params ...interface{}) (float64, error) {
+	var result float64
+	err := client.Call("convertprivacytokentonativetoken", params, &result)
+	return result, err
+}
+
+// CreateAndSendBurningForDepositToSCRequest call "createandsendburningfordeposittoscrequest"
+func (client *Client) CreateAndSendBurningForDepositToSCRequest(params ...interface{}) (interface{}, error) {
+	var result interface{}
+	err := client.Call("createandsendburningfordeposittoscrequest", params, &result)
+	return result, err
+}
+
+// CreateAndSendBurningRequest call "createandsendburningrequest"
+func (client *Client) CreateAndSendBurningRequest(params ...interface{}) (interface{}, error) {
+	var result interface{}
+	err := client.Call("createandsendburningrequest", params, &result)
+	return result, err
+}
+
+// CreateAndSendContractingRequest call "createandsendcontractingrequest"
+func (client *Client) CreateAndSendContractingRequest(params ...interface{}) (interface{}, error) {
+	var result interface{}
+	err := client.Call("createandsendcontractingrequest", params, &result)
+	return result, err
+}
+
+// CreateAndSendCustodianWithdrawRequest call "createandsendcustodianwithdrawrequest"
+func (client *Client) CreateAndSendCustodianWithdrawRequest(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendcustodianwithdrawrequest", params, &result)
+	return result, err
+}
+
+// CreateAndSendDelegationCommissionTx call "createandsenddelegationcommissiontransaction"
+func (client *Client) CreateAndSendDelegationCommissionTx(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsenddelegationcommissiontransaction", params, &result)
+	return result, err
+}
+
+// CreateAndSendDelegationTransaction call "createandsenddelegationtransaction"
+func (client *Client) CreateAndSendDelegationTransaction(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsenddelegationtransaction", params, &result)
+	return result, err
+}
+
+// CreateAndSendIssuingRequest call "createandsendissuingrequest"
+func (client *Client) CreateAndSendIssuingRequest(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("createandsendissuingrequest", params, &result)
+	return result, err
+}
+
+// CreateAndSendLiquidationCustodianDeposit call "createandsendliquidationcustodiandeposit"
+func (client *Client) CreateAndSendLiquidationCustodianDeposit(params ...interface{}) (interface{}, error) {
+	var result interface{}
+	err := client.Call("createandsendliquidationcustodiandeposit", params, &result)
+	return result, err
+}
+
+// CreateAndSendPortalExchangeRates call "createandsendportalexchangerates"
+func (client *Client) CreateAndSendPortalExchangeRates(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendportalexchangerates", params, &result)
+	return result, err
+}
+
+// CreateAndSendPrivacyCustomTokenTransaction call "createandsendprivacycustomtokentransaction"
+func (client *Client) CreateAndSendPrivacyCustomTokenTransaction(params ...interface{}) (jsonresult.CreateTransactionTokenResult, error) {
+	var result jsonresult.CreateTransactionTokenResult
+	err := client.Call("createandsendprivacycustomtokentransaction", params, &result)
+	return result, err
+}
+
+// CreateAndSendRedeemLiquidationExchangeRates call "createandsendredeemliquidationexchangerates"
+func (client *Client) CreateAndSendRedeemLiquidationExchangeRates(params ...interface{}) (interface{}, error) {
+	var result interface{}
+	err := client.Call("createandsendredeemliquidationexchangerates", params, &result)
+	return result, err
+}
+
+// CreateAndSendRegisterPortingPublicTokens call "createandsendregisterportingpublictokens"
+func (client *Client) CreateAndSendRegisterPortingPublicTokens(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendregisterportingpublictokens", params, &result)
+	return result, err
+}
+
+// CreateAndSendStakingTransaction call "createandsendstakingtransaction"
+func (client *Client) CreateAndSendStakingTransaction(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendstakingtransaction", params, &result)
+	return result, err
+}
+
+// CreateAndSendStopAutoStakingTransaction call "createandsendstopautostakingtransaction"
+func (client *Client) CreateAndSendStopAutoStakingTransaction(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendstopautostakingtransaction", params, &result)
+	return result, err
+}
+
+// CreateAndSendTopUpWaitingPorting call "createandsendtopupwaitingporting"
+func (client *Client) CreateAndSendTopUpWaitingPorting(params ...interface{}) (interface{}, error) {
+	var result interface{}
+	err := client.Call("createandsendtopupwaitingporting", params, &result)
+	return result, err
+}
+
+// CreateAndSendTransaction call "createandsendtransaction"
+func (client *Client) CreateAndSendTransaction(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtransaction", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithCustodianDeposit call "createandsendtxwithcustodiandeposit"
+func (client *Client) CreateAndSendTxWithCustodianDeposit(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithcustodiandeposit", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithHTLCClaim call "createandsendtxwithhtlcclaim"
+func (client *Client) CreateAndSendTxWithHTLCClaim(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithhtlcclaim", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithHTLCLock call "createandsendtxwithhtlclock"
+func (client *Client) CreateAndSendTxWithHTLCLock(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithhtlclock", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithHTLCRefund call "createandsendtxwithhtlcrefund"
+func (client *Client) CreateAndSendTxWithHTLCRefund(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithhtlcrefund", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithIssuingETHReq call "createandsendtxwithissuingethreq"
+func (client *Client) CreateAndSendTxWithIssuingETHReq(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithissuingethreq", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithPDEFeeWithdrawalReq call "createandsendtxwithpdefeewithdrawalreq"
+func (client *Client) CreateAndSendTxWithPDEFeeWithdrawalReq(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithpdefeewithdrawalreq", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithPRVContribution call "createandsendtxwithprvcontribution"
+func (client *Client) CreateAndSendTxWithPRVContribution(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithprvcontribution", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithPRVContributionV2 call "createandsendtxwithprvcontributionv2"
+func (client *Client) CreateAndSendTxWithPRVContributionV2(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithprvcontributionv2", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithPRVCrossPoolTradeReq call "createandsendtxwithprvcrosspooltradereq"
+func (client *Client) CreateAndSendTxWithPRVCrossPoolTradeReq(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithprvcrosspooltradereq", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithPRVTradeReq call "createandsendtxwithprvtradereq"
+func (client *Client) CreateAndSendTxWithPRVTradeReq(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithprvtradereq", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithPTokenContribution call "createandsendtxwithptokencontribution"
+func (client *Client) CreateAndSendTxWithPTokenContribution(params ...interface{}) (interface{}, error) {
+	var result interface{}
+	err := client.Call("createandsendtxwithptokencontribution", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithPTokenContributionV2 call "createandsendtxwithptokencontributionv2"
+func (client *Client) CreateAndSendTxWithPTokenContributionV2(params ...interface{}) (interface{}, error) {
+	var result interface{}
+	err := client.Call("createandsendtxwithptokencontributionv2", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithPTokenCrossPoolTradeReq call "createandsendtxwithptokencrosspooltradereq"
+func (client *Client) CreateAndSendTxWithPTokenCrossPoolTradeReq(params ...interface{}) (interface{}, error) {
+	var result interface{}
+	err := client.Call("createandsendtxwithptokencrosspooltradereq", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithPTokenHTLCLock call "createandsendtxwithptokenhtlclock"
+func (client *Client) CreateAndSendTxWithPTokenHTLCLock(params ...interface{}) (interface{}, error) {
+	var result interface{}
+	err := client.Call("createandsendtxwithptokenhtlclock", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithPTokenTradeReq call "createandsendtxwithptokentradereq"
+func (client *Client) CreateAndSendTxWithPTokenTradeReq(params ...interface{}) (interface{}, error) {
+	var result interface{}
+	err := client.Call("createandsendtxwithptokentradereq", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithRedeemReq call "createandsendtxwithredeemreq"
+func (client *Client) CreateAndSendTxWithRedeemReq(params ...interface{}) (interface{}, error) {
+	var result interface{}
+	err := client.Call("createandsendtxwithredeemreq", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithRelayingBNBHeader call "createandsendtxwithrelayingbnbheader"
+func (client *Client) CreateAndSendTxWithRelayingBNBHeader(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithrelayingbnbheader", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithRelayingBTCHeader call "createandsendtxwithrelayingbtcheader"
+func (client *Client) CreateAndSendTxWithRelayingBTCHeader(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithrelayingbtcheader", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithReqMatchingRedeem call "createandsendtxwithreqmatchingredeem"
+func (client *Client) CreateAndSendTxWithReqMatchingRedeem(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithreqmatchingredeem", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithReqPToken call "createandsendtxwithreqptoken"
+func (client *Client) CreateAndSendTxWithReqPToken(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithreqptoken", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithReqUnlockCollateral call "createandsendtxwithrequnlockcollateral"
+func (client *Client) CreateAndSendTxWithReqUnlockCollateral(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithrequnlockcollateral", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithReqWithdrawRewardPortal call "createandsendtxwithreqwithdrawrewardportal"
+func (client *Client) CreateAndSendTxWithReqWithdrawRewardPortal(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithreqwithdrawrewardportal", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithWithdrawalReq call "createandsendtxwithwithdrawalreq"
+func (client *Client) CreateAndSendTxWithWithdrawalReq(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithwithdrawalreq", params, &result)
+	return result, err
+}
+
+// CreateAndSendTxWithWithdrawalReqV2 call "createandsendtxwithwithdrawalreqv2"
+func (client *Client) CreateAndSendTxWithWithdrawalReqV2(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createandsendtxwithwithdrawalreqv2", params, &result)
+	return result, err
+}
+
+// CreateIssuingRequest call "createissuingrequest"
+func (client *Client) CreateIssuingRequest(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("createissuingrequest", params, &result)
+	return result, err
+}
+
+// CreateRawPrivacyCustomTokenTransaction call "createrawprivacycustomtokentransaction"
+func (client *Client) CreateRawPrivacyCustomTokenTransaction(params ...interface{}) (jsonresult.CreateTransactionTokenResult, error) {
+	var result jsonresult.CreateTransactionTokenResult
+	err := client.Call("createrawprivacycustomtokentransaction", params, &result)
+	return result, err
+}
+
+// CreateRawTransaction call "createtransaction"
+func (client *Client) CreateRawTransaction(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("createtransaction", params, &result)
+	return result, err
+}
+
+// CreateUnsignedPrivacyTokenTx call "createunsignedprivacycustomtokentransaction"
+func (client *Client) CreateUnsignedPrivacyTokenTx(params ...interface{}) (jsonresult.CreateUnsignedPrivacyTokenTransactionResult, error) {
+	var result jsonresult.CreateUnsignedPrivacyTokenTransactionResult
+	err := client.Call("createunsignedprivacycustomtokentransaction", params, &result)
+	return result, err
+}
+
+// CreateUnsignedTransaction call "createunsignedtransaction"
+func (client *Client) CreateUnsignedTransaction(params ...interface{}) (jsonresult.CreateUnsignedTransactionResult, error) {
+	var result jsonresult.CreateUnsignedTransactionResult
+	err := client.Call("createunsignedtransaction", params, &result)
+	return result, err
+}
+
+// Decryptoutputcoinbykeyoftransaction call "decryptoutputcoinbykeyoftransaction"
+func (client *Client) Decryptoutputcoinbykeyoftransaction(params ...interface{}) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := client.Call("decryptoutputcoinbykeyoftransaction", params, &result)
+	return result, err
+}
+
+// DefragmentAccount call "defragmentaccount"
+func (client *Client) DefragmentAccount(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("defragmentaccount", params, &result)
+	return result, err
+}
+
+// DefragmentAccountToken call "defragmentaccounttoken"
+func (client *Client) DefragmentAccountToken(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("defragmentaccounttoken", params, &result)
+	return result, err
+}
+
+// DumpPrivkey call "dumpprivkey", available to limited users
+func (client *Client) DumpPrivkey(params ...interface{}) (wallet.KeySerializedData, error) {
+	var result wallet.KeySerializedData
+	err := client.Call("dumpprivkey", params, &result)
+	return result, err
+}
+
+// EnableMining call "enablemining"
+func (client *Client) EnableMining(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("enablemining", params, &result)
+	return result, err
+}
+
+// EstimateFee call "estimatefee"
+func (client *Client) EstimateFee(params ...interface{}) (*jsonresult.EstimateFeeResult, error) {
+	var result *jsonresult.EstimateFeeResult
+	err := client.Call("estimatefee", params, &result)
+	return result, err
+}
+
+// EstimateFeeWithEstimator call "estimatefeewithestimator"
+func (client *Client) EstimateFeeWithEstimator(params ...interface{}) (*jsonresult.EstimateFeeResult, error) {
+	var result *jsonresult.EstimateFeeResult
+	err := client.Call("estimatefeewithestimator", params, &result)
+	return result, err
+}
+
+// ExportMetrics call "exportmetrics"
+func (client *Client) ExportMetrics() (string, error) {
+	var result string
+	err := client.Call("exportmetrics", nil, &result)
+	return result, err
+}
+
+// ExtractPDEInstsFromBeaconBlock call "extractpdeinstsfrombeaconblock"
+func (client *Client) ExtractPDEInstsFromBeaconBlock(params ...interface{}) (rpcserver.PDEInfoFromBeaconBlock, error) {
+	var result rpcserver.PDEInfoFromBeaconBlock
+	err := client.Call("extractpdeinstsfrombeaconblock", params, &result)
+	return result, err
+}
+
+// GenerateTokenID call "generatetokenid"
+func (client *Client) GenerateTokenID(params ...interface{}) (string, error) {
+	var result string
+	err := client.Call("generatetokenid", params, &result)
+	return result, err
+}
+
+// GetAccount call "getaccount", available to limited users
+func (client *Client) GetAccount(params ...interface{}) (string, error) {
+	var result string
+	err := client.Call("getaccount", params, &result)
+	return result, err
+}
+
+// GetAccountAddress call "getaccountaddress", available to limited users
+func (client *Client) GetAccountAddress(params ...interface{}) (wallet.KeySerializedData, error) {
+	var result wallet.KeySerializedData
+	err := client.Call("getaccountaddress", params, &result)
+	return result, err
+}
+
+// GetActiveShards call "getactiveshards"
+func (client *Client) GetActiveShards() (int, error) {
+	var result int
+	err := client.Call("getactiveshards", nil, &result)
+	return result, err
+}
+
+// GetAddressesByAccount call "getaddressesbyaccount", available to limited users
+func (client *Client) GetAddressesByAccount(params ...interface{}) (jsonresult.GetAddressesByAccount, error) {
+	var result jsonresult.GetAddressesByAccount
+	err := client.Call("getaddressesbyaccount", params, &result)
+	return result, err
+}
+
+// GetAllBridgeTokens call "getallbridgetokens"
+func (client *Client) GetAllBridgeTokens() (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getallbridgetokens", nil, &result)
+	return result, err
+}
+
+// GetAllConnectedPeers call "getallconnectedpeers"
+func (client *Client) GetAllConnectedPeers() (*jsonresult.GetAllConnectedPeersResult, error) {
+	var result *jsonresult.GetAllConnectedPeersResult
+	err := client.Call("getallconnectedpeers", nil, &result)
+	return result, err
+}
+
+// GetAllPeers call "getallpeers"
+func (client *Client) GetAllPeers() (*jsonresult.GetAllPeersResult, error) {
+	var result *jsonresult.GetAllPeersResult
+	err := client.Call("getallpeers", nil, &result)
+	return result, err
+}
+
+// GetAllView call "getallview"
+func (client *Client) GetAllView(params ...interface{}) ([]jsonresult.GetViewResult, error) {
+	var result []jsonresult.GetViewResult
+	err := client.Call("getallview", params, &result)
+	return result, err
+}
+
+// GetAllViewDetail call "getallviewdetail"
+func (client *Client) GetAllViewDetail(params ...interface{}) ([]jsonresult.GetViewResult, error) {
+	var result []jsonresult.GetViewResult
+	err := client.Call("getallviewdetail", params, &result)
+	return result, err
+}
+
+// GetAmountNeededForCustodianDepositLiquidation call "getamountneededforcustodiandepositliquidation"
+func (client *Client) GetAmountNeededForCustodianDepositLiquidation(params ...interface{}) (jsonresult.GetLiquidateAmountNeededCustodianDeposit, error) {
+	var result jsonresult.GetLiquidateAmountNeededCustodianDeposit
+	err := client.Call("getamountneededforcustodiandepositliquidation", params, &result)
+	return result, err
+}
+
+// GetAmountTopUpWaitingPorting call "getamounttopupwaitingporting"
+func (client *Client) GetAmountTopUpWaitingPorting(params ...interface{}) (map[string]uint64, error) {
+	var result map[string]uint64
+	err := client.Call("getamounttopupwaitingporting", params, &result)
+	return result, err
+}
+
+// GetAndSendTxsFromFile call "getandsendtxsfromfile"
+func (client *Client) GetAndSendTxsFromFile(params ...interface{}) (rpcserver.CountResult, error) {
+	var result rpcserver.CountResult
+	err := client.Call("getandsendtxsfromfile", params, &result)
+	return result, err
+}
+
+// GetAndSendTxsFromFileV2 call "getandsendtxsfromfilev2"
+func (client *Client) GetAndSendTxsFromFileV2(params ...interface{}) (rpcserver.CountResult, error) {
+	var result rpcserver.CountResult
+	err := client.Call("getandsendtxsfromfilev2", params, &result)
+	return result, err
+}
+
+// GetAutoStakingByHeight call "getautostakingbyheight"
+func (client *Client) GetAutoStakingByHeight(params ...interface{}) ([]interface{}, error) {
+	var result []interface{}
+	err := client.Call("getautostakingbyheight", params, &result)
+	return result, err
+}
+
+// GetBalance call "getbalance", available to limited users
+func (client *Client) GetBalance(params ...interface{}) (uint64, error) {
+	var result uint64
+	err := client.Call("getbalance", params, &result)
+	return result, err
+}
+
+// GetBalanceByPaymentAddress call "getbalancebypaymentaddress", available to limited users
+func (client *Client) GetBalanceByPaymentAddress(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getbalancebypaymentaddress", params, &result)
+	return result, err
+}
+
+// GetBalanceByPrivatekey call "getbalancebyprivatekey", available to limited users
+func (client *Client) GetBalanceByPrivatekey(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getbalancebyprivatekey", params, &result)
+	return result, err
+}
+
+// GetBalancePrivacyCustomToken call "getbalanceprivacycustomtoken"
+func (client *Client) GetBalancePrivacyCustomToken(params ...interface{}) (uint64, error) {
+	var result uint64
+	err := client.Call("getbalanceprivacycustomtoken", params, &result)
+	return result, err
+}
+
+// GetBeaconBestState call "getbeaconbeststate"
+func (client *Client) GetBeaconBestState() (*jsonresult.GetBeaconBestState, error) {
+	var result *jsonresult.GetBeaconBestState
+	err := client.Call("getbeaconbeststate", nil, &result)
+	return result, err
+}
+
+// GetBeaconBestStateDetail call "getbeaconbeststatedetail"
+func (client *Client) GetBeaconBestStateDetail() (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getbeaconbeststatedetail", nil, &result)
+	return result, err
+}
+
+// GetBeaconPoolInfo call "getbeaconpoolinfo"
+func (client *Client) GetBeaconPoolInfo(params ...interface{}) (*jsonresult.PoolInfo, error) {
+	var result *jsonresult.PoolInfo
+	err := client.Call("getbeaconpoolinfo", params, &result)
+	return result, err
+}
+
+// GetBeaconSwapProof call "getbeaconswapproof"
+func (client *Client) GetBeaconSwapProof(params ...interface{}) (jsonresult.GetInstructionProof, error) {
+	var result jsonresult.GetInstructionProof
+	err := client.Call("getbeaconswapproof", params, &result)
+	return result, err
+}
+
+// GetBestBlock call "getbestblock"
+func (client *Client) GetBestBlock() (jsonresult.GetBestBlockResult, error) {
+	var result jsonresult.GetBestBlockResult
+	err := client.Call("getbestblock", nil, &result)
+	return result, err
+}
+
+// GetBestBlockHash call "getbestblockhash"
+func (client *Client) GetBestBlockHash() (jsonresult.GetBestBlockHashResult, error) {
+	var result jsonresult.GetBestBlockHashResult
+	err := client.Call("getbestblockhash", nil, &result)
+	return result, err
+}
+
+// GetBlockChainInfo call "getblockchaininfo"
+func (client *Client) GetBlockChainInfo() (jsonresult.GetBlockChainInfoResult, error) {
+	var result jsonresult.GetBlockChainInfoResult
+	err := client.Call("getblockchaininfo", nil, &result)
+	return result, err
+}
+
+// GetBlockCount call "getblockcount"
+func (client *Client) GetBlockCount(params ...interface{}) (uint64, error) {
+	var result uint64
+	err := client.Call("getblockcount", params, &result)
+	return result, err
+}
+
+// GetBlockHash call "getblockhash"
+func (client *Client) GetBlockHash(params ...interface{}) ([]common.Hash, error) {
+	var result []common.Hash
+	err := client.Call("getblockhash", params, &result)
+	return result, err
+}
+
+// GetBlocks call "getblocks"
+func (client *Client) GetBlocks(params ...interface{}) (interface{}, error) {
+	var result interface{}
+	err := client.Call("getblocks", params, &result)
+	return result, err
+}
+
+// GetBlockTemplate call "getblocktemplate"
+func (client *Client) GetBlockTemplate(params ...interface{}) (*blockchain.BlockTemplate, error) {
+	var result *blockchain.BlockTemplate
+	err := client.Call("getblocktemplate", params, &result)
+	return result, err
+}
+
+// GetBridgeReqWithStatus call "getbridgereqwithstatus"
+func (client *Client) GetBridgeReqWithStatus(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getbridgereqwithstatus", params, &result)
+	return result, err
+}
+
+// GetBridgeSwapProof call "getbridgeswapproof"
+func (client *Client) GetBridgeSwapProof(params ...interface{}) (jsonresult.GetInstructionProof, error) {
+	var result jsonresult.GetInstructionProof
+	err := client.Call("getbridgeswapproof", params, &result)
+	return result, err
+}
+
+// GetBTCBlockByHash call "getbtcblockbyhash"
+func (client *Client) GetBTCBlockByHash(params ...interface{}) (*wire.MsgBlock, error) {
+	var result *wire.MsgBlock
+	err := client.Call("getbtcblockbyhash", params, &result)
+	return result, err
+}
+
+// GetBTCRelayingBestState call "getbtcrelayingbeststate"
+func (client *Client) GetBTCRelayingBestState() (*btcrelaying.BestState, error) {
+	var result *btcrelaying.BestState
+	err := client.Call("getbtcrelayingbeststate", nil, &result)
+	return result, err
+}
+
+// GetBurningAddress call "getburningaddress"
+func (client *Client) GetBurningAddress(params ...interface{}) (string, error) {
+	var result string
+	err := client.Call("getburningaddress", params, &result)
+	return result, err
+}
+
+// GetBurnProof call "getburnproof"
+func (client *Client) GetBurnProof(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getburnproof", params, &result)
+	return result, err
+}
+
+// GetBurnProofForDepositToSC call "getburnprooffordeposittosc"
+func (client *Client) GetBurnProofForDepositToSC(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getburnprooffordeposittosc", params, &result)
+	return result, err
+}
+
+// GetCandidateList call "getcandidatelist"
+func (client *Client) GetCandidateList() (jsonresult.CandidateListsResult, error) {
+	var result jsonresult.CandidateListsResult
+	err := client.Call("getcandidatelist", nil, &result)
+	return result, err
+}
+
+// GetChainMiningStatus call "getchainminingstatus"
+func (client *Client) GetChainMiningStatus(params ...interface{}) (string, error) {
+	var result string
+	err := client.Call("getchainminingstatus", params, &result)
+	return result, err
+}
+
+// GetCommitteeList call "getcommitteelist"
+func (client *Client) GetCommitteeList(params ...interface{}) (*jsonresult.CommitteeListsResult, error) {
+	var result *jsonresult.CommitteeListsResult
+	err := client.Call("getcommitteelist", params, &result)
+	return result, err
+}
+
+// GetConnectionCount call "getconnectioncount"
+func (client *Client) GetConnectionCount() (int, error) {
+	var result int
+	err := client.Call("getconnectioncount", nil, &result)
+	return result, err
+}
+
+// GetCrossShardBlock call "getcrossshardblock"
+func (client *Client) GetCrossShardBlock(params ...interface{}) (map[common.Hash]jsonresult.CrossShardDataResult, error) {
+	var result map[common.Hash]jsonresult.CrossShardDataResult
+	err := client.Call("getcrossshardblock", params, &result)
+	return result, err
+}
+
+// GetCrossShardPoolInfo call "getcrossshardpoolinfo"
+func (client *Client) GetCrossShardPoolInfo(params ...interface{}) (*jsonresult.PoolInfo, error) {
+	var result *jsonresult.PoolInfo
+	err := client.Call("getcrossshardpoolinfo", params, &result)
+	return result, err
+}
+
+// GetCrossShardTxStatus call "getcrossshardtxstatus"
+func (client *Client) GetCrossShardTxStatus(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getcrossshardtxstatus", params, &result)
+	return result, err
+}
+
+// GetCustodianLiquidationStatus call "getcustodianliquidationstatus"
+func (client *Client) GetCustodianLiquidationStatus(params ...interface{}) (*metadata.PortalLiquidateCustodianStatus, error) {
+	var result *metadata.PortalLiquidateCustodianStatus
+	err := client.Call("getcustodianliquidationstatus", params, &result)
+	return result, err
+}
+
+// GetPortalCustodianTopupStatus call "getcustodiantopupstatus"
+func (client *Client) GetPortalCustodianTopupStatus(params ...interface{}) (*metadata.LiquidationCustodianDepositStatusV2, error) {
+	var result *metadata.LiquidationCustodianDepositStatusV2
+	err := client.Call("getcustodiantopupstatus", params, &result)
+	return result, err
+}
+
+// GetPortalCustodianTopupWaitingPortingStatus call "getcustodiantopupwaitingportingstatus"
+func (client *Client) GetPortalCustodianTopupWaitingPortingStatus(params ...interface{}) (*metadata.PortalTopUpWaitingPortingRequestStatus, error) {
+	var result *metadata.PortalTopUpWaitingPortingRequestStatus
+	err := client.Call("getcustodiantopupwaitingportingstatus", params, &result)
+	return result, err
+}
+
+// GetCustodianWithdrawByTxId call "getcustodianwithdrawbytxid"
+func (client *Client) GetCustodianWithdrawByTxId(params ...interface{}) (jsonresult.PortalCustodianWithdrawRequest, error) {
+	var result jsonresult.PortalCustodianWithdrawRequest
+	err := client.Call("getcustodianwithdrawbytxid", params, &result)
+	return result, err
+}
+
+// GetDelegations call "getdelegations"
+func (client *Client) GetDelegations(params ...interface{}) (*jsonresult.GetDelegationsResult, error) {
+	var result *jsonresult.GetDelegationsResult
+	err := client.Call("getdelegations", params, &result)
+	return result, err
+}
+
+// GetETHHeaderByHash call "getethheaderbyhash"
+func (client *Client) GetETHHeaderByHash(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getethheaderbyhash", params, &result)
+	return result, err
+}
+
+// GetBlockHeader call "getheader"
+func (client *Client) GetBlockHeader(params ...interface{}) ([]jsonresult.GetHeaderResult, error) {
+	var result []jsonresult.GetHeaderResult
+	err := client.Call("getheader", params, &result)
+	return result, err
+}
+
+// GetHTLC call "gethtlc"
+func (client *Client) GetHTLC(params ...interface{}) (*jsonresult.GetHTLCResult, error) {
+	var result *jsonresult.GetHTLCResult
+	err := client.Call("gethtlc", params, &result)
+	return result, err
+}
+
+// GetIncognitoPublicKeyRole call "getincognitopublickeyrole"
+func (client *Client) GetIncognitoPublicKeyRole(params ...interface{}) (*struct {
+	Role     int
+	IsBeacon bool
+	ShardID  int
+}, error) {
+	var result *struct {
+		Role     int
+		IsBeacon bool
+		ShardID  int
+	}
+	err := client.Call("getincognitopublickeyrole", params, &result)
+	return result, err
+}
+
+// GetInOutMessageCount call "getinoutmessagecount"
+func (client *Client) GetInOutMessageCount(params ...interface{}) (*jsonresult.GetInOutMessageCountResult, error) {
+	var result *jsonresult.GetInOutMessageCountResult
+	err := client.Call("getinoutmessagecount", params, &result)
+	return result, err
+}
+
+// GetInOutMessages call "getinoutmessages"
+func (client *Client) GetInOutMessages(params ...interface{}) (*jsonresult.GetInOutMessageResult, error) {
+	var result *jsonresult.GetInOutMessageResult
+	err := client.Call("getinoutmessages", params, &result)
+	return result, err
+}
+
+// GetLatestBackup call "getlatestbackup"
+func (client *Client) GetLatestBackup(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getlatestbackup", params, &result)
+	return result, err
+}
+
+// GetLatestBeaconSwapProof call "getlatestbeaconswapproof"
+func (client *Client) GetLatestBeaconSwapProof() (interface{}, error) {
+	var result interface{}
+	err := client.Call("getlatestbeaconswapproof", nil, &result)
+	return result, err
+}
+
+// GetLatestBNBHeaderBlockHeight call "getlatestbnbheaderblockheight"
+func (client *Client) GetLatestBNBHeaderBlockHeight() (int64, error) {
+	var result int64
+	err := client.Call("getlatestbnbheaderblockheight", nil, &result)
+	return result, err
+}
+
+// GetLatestBridgeSwapProof call "getlatestbridgeswapproof"
+func (client *Client) GetLatestBridgeSwapProof() (interface{}, error) {
+	var result interface{}
+	err := client.Call("getlatestbridgeswapproof", nil, &result)
+	return result, err
+}
+
+// GetLiquidationExchangeRatesPool call "getliquidationtpexchangeratespool"
+func (client *Client) GetLiquidationExchangeRatesPool(params ...interface{}) (jsonresult.GetLiquidateExchangeRates, error) {
+	var result jsonresult.GetLiquidateExchangeRates
+	err := client.Call("getliquidationtpexchangeratespool", params, &result)
+	return result, err
+}
+
+// GetListPrivacyCustomTokenBalance call "getlistprivacycustomtokenbalance"
+func (client *Client) GetListPrivacyCustomTokenBalance(params ...interface{}) (jsonresult.ListCustomTokenBalance, error) {
+	var result jsonresult.ListCustomTokenBalance
+	err := client.Call("getlistprivacycustomtokenbalance", params, &result)
+	return result, err
+}
+
+// GetMaxShardsNumber call "getmaxshardsnumber"
+func (client *Client) GetMaxShardsNumber() (int, error) {
+	var result int
+	err := client.Call("getmaxshardsnumber", nil, &result)
+	return result, err
+}
+
+// GetMempoolEntry call "getmempoolentry"
+func (client *Client) GetMempoolEntry(params ...interface{}) (*jsonresult.TransactionDetail, error) {
+	var result *jsonresult.TransactionDetail
+	err := client.Call("getmempoolentry", params, &result)
+	return result, err
+}
+
+// GetMempoolInfo call "getmempoolinfo"
+func (client *Client) GetMempoolInfo() (*jsonresult.GetMempoolInfo, error) {
+	var result *jsonresult.GetMempoolInfo
+	err := client.Call("getmempoolinfo", nil, &result)
+	return result, err
+}
+
+// GetMinerRewardFromMiningKey call "getminerrewardfromminingkey"
+func (client *Client) GetMinerRewardFromMiningKey(params ...interface{}) (map[string]uint64, error) {
+	var result map[string]uint64
+	err := client.Call("getminerrewardfromminingkey", params, &result)
+	return result, err
+}
+
+// GetMiningInfo call "getmininginfo"
+func (client *Client) GetMiningInfo() (*jsonresult.GetMiningInfoResult, error) {
+	var result *jsonresult.GetMiningInfoResult
+	err := client.Call("getmininginfo", nil, &result)
+	return result, err
+}
+
+// GetNetworkInfo call "getnetworkinfo"
+func (client *Client) GetNetworkInfo() (*jsonresult.GetNetworkInfoResult, error) {
+	var result *jsonresult.GetNetworkInfoResult
+	err := client.Call("getnetworkinfo", nil, &result)
+	return result, err
+}
+
+// GetNodeRole call "getnoderole"
+func (client *Client) GetNodeRole() (string, error) {
+	var result string
+	err := client.Call("getnoderole", nil, &result)
+	return result, err
+}
+
+// GetNumberOfTxsInMempool call "getnumberoftxsinmempool"
+func (client *Client) GetNumberOfTxsInMempool() (int, error) {
+	var result int
+	err := client.Call("getnumberoftxsinmempool", nil, &result)
+	return result, err
+}
+
+// GetPDEContributionStatus call "getpdecontributionstatus"
+func (client *Client) GetPDEContributionStatus(params ...interface{}) (byte, error) {
+	var result byte
+	err := client.Call("getpdecontributionstatus", params, &result)
+	return result, err
+}
+
+// GetPDEContributionStatusV2 call "getpdecontributionstatusv2"
+func (client *Client) GetPDEContributionStatusV2(params ...interface{}) (*metadata.PDEContributionStatus, error) {
+	var result *metadata.PDEContributionStatus
+	err := client.Call("getpdecontributionstatusv2", params, &result)
+	return result, err
+}
+
+// GetPDEFeeWithdrawalStatus call "getpdefeewithdrawalstatus"
+func (client *Client) GetPDEFeeWithdrawalStatus(params ...interface{}) (byte, error) {
+	var result byte
+	err := client.Call("getpdefeewithdrawalstatus", params, &result)
+	return result, err
+}
+
+// GetPDEState call "getpdestate"
+func (client *Client) GetPDEState(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getpdestate", params, &result)
+	return result, err
+}
+
+// GetPDETradeStatus call "getpdetradestatus"
+func (client *Client) GetPDETradeStatus(params ...interface{}) (byte, error) {
+	var result byte
+	err := client.Call("getpdetradestatus", params, &result)
+	return result, err
+}
+
+// GetPDEWithdrawalStatus call "getpdewithdrawalstatus"
+func (client *Client) GetPDEWithdrawalStatus(params ...interface{}) (byte, error) {
+	var result byte
+	err := client.Call("getpdewithdrawalstatus", params, &result)
+	return result, err
+}
+
+// GetPendingTxsInBlockgen call "getpendingtxsinblockgen"
+func (client *Client) GetPendingTxsInBlockgen() (jsonresult.GetPendingTxsInBlockgenResult, error) {
+	var result jsonresult.GetPendingTxsInBlockgenResult
+	err := client.Call("getpendingtxsinblockgen", nil, &result)
+	return result, err
+}
+
+// GetPortalCustodianDepositStatus call "getportalcustodiandepositstatus"
+func (client *Client) GetPortalCustodianDepositStatus(params ...interface{}) (*metadata.PortalCustodianDepositStatus, error) {
+	var result *metadata.PortalCustodianDepositStatus
+	err := client.Call("getportalcustodiandepositstatus", params, &result)
+	return result, err
+}
+
+// GetPortalFinalExchangeRates call "getportalfinalexchangerates"
+func (client *Client) GetPortalFinalExchangeRates(params ...interface{}) (jsonresult.FinalExchangeRatesResult, error) {
+	var result jsonresult.FinalExchangeRatesResult
+	err := client.Call("getportalfinalexchangerates", params, &result)
+	return result, err
+}
+
+// GetPortalPortingRequestByKey call "getportalportingrequestbykey"
+func (client *Client) GetPortalPortingRequestByKey(params ...interface{}) (jsonresult.PortalPortingRequest, error) {
+	var result jsonresult.PortalPortingRequest
+	err := client.Call("getportalportingrequestbykey", params, &result)
+	return result, err
+}
+
+// GetPortalPortingRequestByPortingId call "getportalportingrequestbyportingid"
+func (client *Client) GetPortalPortingRequestByPortingId(params ...interface{}) (jsonresult.PortalPortingRequest, error) {
+	var result jsonresult.PortalPortingRequest
+	err := client.Call("getportalportingrequestbyportingid", params, &result)
+	return result, err
+}
+
+// GetPortalReqPTokenStatus call "getportalreqptokenstatus"
+func (client *Client) GetPortalReqPTokenStatus(params ...interface{}) (*metadata.PortalRequestPTokensStatus, error) {
+	var result *metadata.PortalRequestPTokensStatus
+	err := client.Call("getportalreqptokenstatus", params, &result)
+	return result, err
+}
+
+// GetPortalReqRedeemStatus call "getportalreqredeemstatus"
+func (client *Client) GetPortalReqRedeemStatus(params ...interface{}) (*metadata.PortalRedeemRequestStatus, error) {
+	var result *metadata.PortalRedeemRequestStatus
+	err := client.Call("getportalreqredeemstatus", params, &result)
+	return result, err
+}
+
+// GetPortalReqUnlockCollateralStatus call "getportalrequnlockcollateralstatus"
+func (client *Client) GetPortalReqUnlockCollateralStatus(params ...interface{}) (*metadata.PortalRequestUnlockCollateralStatus, error) {
+	var result *metadata.PortalRequestUnlockCollateralStatus
+	err := client.Call("getportalrequnlockcollateralstatus", params, &result)
+	return result, err
+}
+
+// GetPortalReward call "getportalreward"
+func (client *Client) GetPortalReward(params ...interface{}) (map[string]uint64, error) {
+	var result map[string]uint64
+	err := client.Call("getportalreward", params, &result)
+	return result, err
+}
+
+// GetPortalState call "getportalstate"
+func (client *Client) GetPortalState(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getportalstate", params, &result)
+	return result, err
+}
+
+// GetPortingRequestFees call "getportingrequestfees"
+func (client *Client) GetPortingRequestFees(params ...interface{}) (map[string]uint64, error) {
+	var result map[string]uint64
+	err := client.Call("getportingrequestfees", params, &result)
+	return result, err
+}
+
+// GetPrivacyCustomToken call "getprivacycustomtoken"
+func (client *Client) GetPrivacyCustomToken(params ...interface{}) (*jsonresult.GetCustomToken, error) {
+	var result *jsonresult.GetCustomToken
+	err := client.Call("getprivacycustomtoken", params, &result)
+	return result, err
+}
+
+// GetProducersBlackList call "getproducersblacklist"
+func (client *Client) GetProducersBlackList() (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getproducersblacklist", nil, &result)
+	return result, err
+}
+
+// GetProducersBlackListDetail call "getproducersblacklistdetail"
+func (client *Client) GetProducersBlackListDetail() (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getproducersblacklistdetail", nil, &result)
+	return result, err
+}
+
+// GetPublicKeyFromPaymentAddress call "getpublickeyfrompaymentaddress"
+func (client *Client) GetPublicKeyFromPaymentAddress(params ...interface{}) (*jsonresult.GetPublicKeyFromPaymentAddressResult, error) {
+	var result *jsonresult.GetPublicKeyFromPaymentAddressResult
+	err := client.Call("getpublickeyfrompaymentaddress", params, &result)
+	return result, err
+}
+
+// GetPublickeyMining call "getpublickeymining"
+func (client *Client) GetPublickeyMining() ([]string, error) {
+	var result []string
+	err := client.Call("getpublickeymining", nil, &result)
+	return result, err
+}
+
+// GetPublicKeyRole call "getpublickeyrole"
+func (client *Client) GetPublicKeyRole(params ...interface{}) (*struct {
+	Role    int
+	ShardID int
+}, error) {
+	var result *struct {
+		Role    int
+		ShardID int
+	}
+	err := client.Call("getpublickeyrole", params, &result)
+	return result, err
+}
+
+// GetRawMempool call "getrawmempool"
+func (client *Client) GetRawMempool() (*jsonresult.GetRawMempoolResult, error) {
+	var result *jsonresult.GetRawMempoolResult
+	err := client.Call("getrawmempool", nil, &result)
+	return result, err
+}
+
+// GetReceivedByAccount call "getreceivedbyaccount", available to limited users
+func (client *Client) GetReceivedByAccount(params ...interface{}) (uint64, error) {
+	var result uint64
+	err := client.Call("getreceivedbyaccount", params, &result)
+	return result, err
+}
+
+// GetRelayingBNBHeaderByBlockHeight call "getrelayingbnbheaderbyblockheight"
+func (client *Client) GetRelayingBNBHeaderByBlockHeight(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getrelayingbnbheaderbyblockheight", params, &result)
+	return result, err
+}
+
+// GetRelayingBNBHeaderState call "getrelayingbnbheaderstate"
+func (client *Client) GetRelayingBNBHeaderState() (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getrelayingbnbheaderstate", nil, &result)
+	return result, err
+}
+
+// GetReqMatchingRedeemStatus call "getreqmatchingredeemstatus"
+func (client *Client) GetReqMatchingRedeemStatus(params ...interface{}) (*metadata.PortalReqMatchingRedeemStatus, error) {
+	var result *metadata.PortalReqMatchingRedeemStatus
+	err := client.Call("getreqmatchingredeemstatus", params, &result)
+	return result, err
+}
+
+// GetReqRedeemFromLiquidationPoolByTxIDStatus call "getreqredeemfromliquidationpoolbytxidstatus"
+func (client *Client) GetReqRedeemFromLiquidationPoolByTxIDStatus(params ...interface{}) (*metadata.RedeemLiquidateExchangeRatesStatus, error) {
+	var result *metadata.RedeemLiquidateExchangeRatesStatus
+	err := client.Call("getreqredeemfromliquidationpoolbytxidstatus", params, &result)
+	return result, err
+}
+
+// GetPortalReqRedeemByTxIDStatus call "getreqredeemstatusbytxid"
+func (client *Client) GetPortalReqRedeemByTxIDStatus(params ...interface{}) (*metadata.PortalRedeemRequestStatus, error) {
+	var result *metadata.PortalRedeemRequestStatus
+	err := client.Call("getreqredeemstatusbytxid", params, &result)
+	return result, err
+}
+
+// GetRequestWithdrawPortalRewardStatus call "getrequestwithdrawportalrewardstatus"
+func (client *Client) GetRequestWithdrawPortalRewardStatus(params ...interface{}) (*metadata.PortalRequestWithdrawRewardStatus, error) {
+	var result *metadata.PortalRequestWithdrawRewardStatus
+	err := client.Call("getrequestwithdrawportalrewardstatus", params, &result)
+	return result, err
+}
+
+// GetRewardAmount call "getrewardamount"
+func (client *Client) GetRewardAmount(params ...interface{}) (map[string]uint64, error) {
+	var result map[string]uint64
+	err := client.Call("getrewardamount", params, &result)
+	return result, err
+}
+
+// GetRewardAmountByEpoch call "getrewardamountbyepoch"
+func (client *Client) GetRewardAmountByEpoch(params ...interface{}) (uint64, error) {
+	var result uint64
+	err := client.Call("getrewardamountbyepoch", params, &result)
+	return result, err
+}
+
+// GetRewardAmountByPublicKey call "getrewardamountbypublickey"
+func (client *Client) GetRewardAmountByPublicKey(params ...interface{}) (map[string]uint64, error) {
+	var result map[string]uint64
+	err := client.Call("getrewardamountbypublickey", params, &result)
+	return result, err
+}
+
+// GetRewardFeature call "getrewardfeature"
+func (client *Client) GetRewardFeature(params ...interface{}) (map[string]uint64, error) {
+	var result map[string]uint64
+	err := client.Call("getrewardfeature", params, &result)
+	return result, err
+}
+
+// GetRoleByValidatorKey call "getrolebyvalidatorkey"
+func (client *Client) GetRoleByValidatorKey(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getrolebyvalidatorkey", params, &result)
+	return result, err
+}
+
+// GetRPCSchema call "getrpcschema"
+func (client *Client) GetRPCSchema(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("getrpcschema", params, &result)
+	return result, err
+}
+
+// GetShardBestState call "getshardbeststate"
+func (client *Client) GetShardBestState(params ...interface{}) (*jsonresult.GetShardBestState, error) {
+	var result *jsonresult.GetShardBestState
+	err := client.Call("getshardbeststate", params, &result)
+	return result, err
+}
+
+// GetShardBestStateDetail call "getshardbeststatedetail"
+func (client *Client) GetShardBestStateDetail(params ...interface{}) (*jsonresult.GetShardBestStateDetail, error) {
+	var result *jsonresult.GetShardBestStateDetail
+	err := client.Call("getshardbeststatedetail", params, &result)
+	return result, err
+}
+
+// GetShardPoolInfo call "getshardpoolinfo"
+func (client *Client) GetShardPoolInfo(params ...interface{}) (*jsonresult.PoolInfo, error) {
+	var result *jsonresult.PoolInfo
+	err := client.Call("getshardpoolinfo", params, &result)
+	return result, err
+}
+
+// GetShardToBeaconPoolInfo call "getshardtobeaconpoolinfo"
+func (client *Client) GetShardToBeaconPoolInfo(params ...interface{}) (*jsonresult.PoolInfo, error) {
+	var result *jsonresult.PoolInfo
+	err := client.Call("getshardtobeaconpoolinfo", params, &result)
+	return result, err
+}
+
+// GetStackingAmount call "getstackingamount"
+func (client *Client) GetStackingAmount(params ...interface{}) (uint64, error) {
+	var result uint64
+	err := client.Call("getstackingamount", params, &result)
+	return result, err
+}
+
+// GetTotalTransaction call "gettotaltransaction"
+func (client *Client) GetTotalTransaction(params ...interface{}) (*jsonresult.TotalTransactionInShard, error) {
+	var result *jsonresult.TotalTransactionInShard
+	err := client.Call("gettotaltransaction", params, &result)
+	return result, err
+}
+
+// GetTransactionByHash call "gettransactionbyhash"
+func (client *Client) GetTransactionByHash(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("gettransactionbyhash", params, &result)
+	return result, err
+}
+
+// Gettransactionbyreceiver call "gettransactionbyreceiver"
+func (client *Client) Gettransactionbyreceiver(params ...interface{}) (*jsonresult.ListReceivedTransaction, error) {
+	var result *jsonresult.ListReceivedTransaction
+	err := client.Call("gettransactionbyreceiver", params, &result)
+	return result, err
+}
+
+// Gettransactionhashbyreceiver call "gettransactionhashbyreceiver"
+func (client *Client) Gettransactionhashbyreceiver(params ...interface{}) (map[byte][]common.Hash, error) {
+	var result map[byte][]common.Hash
+	err := client.Call("gettransactionhashbyreceiver", params, &result)
+	return result, err
+}
+
+// HashToIdenticon call "hashtoidenticon"
+func (client *Client) HashToIdenticon(params ...interface{}) ([]string, error) {
+	var result []string
+	err := client.Call("hashtoidenticon", params, &result)
+	return result, err
+}
+
+// HasSerialNumbers call "hasserialnumbers"
+func (client *Client) HasSerialNumbers(params ...interface{}) ([]bool, error) {
+	var result []bool
+	err := client.Call("hasserialnumbers", params, &result)
+	return result, err
+}
+
+// HasSnDerivators call "hassnderivators"
+func (client *Client) HasSnDerivators(params ...interface{}) ([]bool, error) {
+	var result []bool
+	err := client.Call("hassnderivators", params, &result)
+	return result, err
+}
+
+// ImportAccount call "importaccount", available to limited users
+func (client *Client) ImportAccount(params ...interface{}) (wallet.KeySerializedData, error) {
+	var result wallet.KeySerializedData
+	err := client.Call("importaccount", params, &result)
+	return result, err
+}
+
+// ListAccounts call "listaccounts", available to limited users
+func (client *Client) ListAccounts() (jsonresult.ListAccounts, error) {
+	var result jsonresult.ListAccounts
+	err := client.Call("listaccounts", nil, &result)
+	return result, err
+}
+
+// ListCommitmentIndices call "listcommitmentindices"
+func (client *Client) ListCommitmentIndices(params ...interface{}) (map[uint64]string, error) {
+	var result map[uint64]string
+	err := client.Call("listcommitmentindices", params, &result)
+	return result, err
+}
+
+// ListCommitments call "listcommitments"
+func (client *Client) ListCommitments(params ...interface{}) (map[string]uint64, error) {
+	var result map[string]uint64
+	err := client.Call("listcommitments", params, &result)
+	return result, err
+}
+
+// ListOutputCoins call "listoutputcoins"
+func (client *Client) ListOutputCoins(params ...interface{}) (*jsonresult.ListOutputCoins, error) {
+	var result *jsonresult.ListOutputCoins
+	err := client.Call("listoutputcoins", params, &result)
+	return result, err
+}
+
+// ListPrivacyCustomToken call "listprivacycustomtoken"
+func (client *Client) ListPrivacyCustomToken(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("listprivacycustomtoken", params, &result)
+	return result, err
+}
+
+// ListPrivacyCustomTokenByShard call "listprivacycustomtokenbyshard"
+func (client *Client) ListPrivacyCustomTokenByShard(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("listprivacycustomtokenbyshard", params, &result)
+	return result, err
+}
+
+// ListRewardAmount call "listrewardamount"
+func (client *Client) ListRewardAmount() (map[string]map[common.Hash]uint64, error) {
+	var result map[string]map[common.Hash]uint64
+	err := client.Call("listrewardamount", nil, &result)
+	return result, err
+}
+
+// ListSerialNumbers call "listserialnumbers"
+func (client *Client) ListSerialNumbers(params ...interface{}) (map[string]struct{}, error) {
+	var result map[string]struct{}
+	err := client.Call("listserialnumbers", params, &result)
+	return result, err
+}
+
+// ListUnspentOutputCoins call "listunspentoutputcoins", available to limited users
+func (client *Client) ListUnspentOutputCoins(params ...interface{}) (*jsonresult.ListOutputCoins, error) {
+	var result *jsonresult.ListOutputCoins
+	err := client.Call("listunspentoutputcoins", params, &result)
+	return result, err
+}
+
+// PrivacyCustomTokenTxs call "privacycustomtoken"
+func (client *Client) PrivacyCustomTokenTxs(params ...interface{}) (jsonresult.CustomToken, error) {
+	var result jsonresult.CustomToken
+	err := client.Call("privacycustomtoken", params, &result)
+	return result, err
+}
+
+// RandomCommitments call "randomcommitments"
+func (client *Client) RandomCommitments(params ...interface{}) (*jsonresult.RandomCommitmentResult, error) {
+	var result *jsonresult.RandomCommitmentResult
+	err := client.Call("randomcommitments", params, &result)
+	return result, err
+}
+
+// RemoveAccount call "removeaccount", available to limited users
+func (client *Client) RemoveAccount(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("removeaccount", params, &result)
+	return result, err
+}
+
+// RemoveTxInMempool call "removetxinmempool"
+func (client *Client) RemoveTxInMempool(params ...interface{}) ([]bool, error) {
+	var result []bool
+	err := client.Call("removetxinmempool", params, &result)
+	return result, err
+}
+
+// RetrieveBeaconBlock call "retrievebeaconblock"
+func (client *Client) RetrieveBeaconBlock(params ...interface{}) (*jsonresult.GetBeaconBlockResult, error) {
+	var result *jsonresult.GetBeaconBlockResult
+	err := client.Call("retrievebeaconblock", params, &result)
+	return result, err
+}
+
+// RetrieveBeaconBlockByHeight call "retrievebeaconblockbyheight"
+func (client *Client) RetrieveBeaconBlockByHeight(params ...interface{}) ([]*jsonresult.GetBeaconBlockResult, error) {
+	var result []*jsonresult.GetBeaconBlockResult
+	err := client.Call("retrievebeaconblockbyheight", params, &result)
+	return result, err
+}
+
+// RetrieveBlock call "retrieveblock"
+func (client *Client) RetrieveBlock(params ...interface{}) (*jsonresult.GetShardBlockResult, error) {
+	var result *jsonresult.GetShardBlockResult
+	err := client.Call("retrieveblock", params, &result)
+	return result, err
+}
+
+// RetrieveBlockByHeight call "retrieveblockbyheight"
+func (client *Client) RetrieveBlockByHeight(params ...interface{}) ([]*jsonresult.GetShardBlockResult, error) {
+	var result []*jsonresult.GetShardBlockResult
+	err := client.Call("retrieveblockbyheight", params, &result)
+	return result, err
+}
+
+// SendIssuingRequest call "sendissuingrequest"
+func (client *Client) SendIssuingRequest(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("sendissuingrequest", params, &result)
+	return result, err
+}
+
+// SendRawPrivacyCustomTokenTransaction call "sendrawprivacycustomtokentransaction"
+func (client *Client) SendRawPrivacyCustomTokenTransaction(params ...interface{}) (jsonresult.CreateTransactionTokenResult, error) {
+	var result jsonresult.CreateTransactionTokenResult
+	err := client.Call("sendrawprivacycustomtokentransaction", params, &result)
+	return result, err
+}
+
+// SendRawTransaction call "sendtransaction"
+func (client *Client) SendRawTransaction(params ...interface{}) (jsonresult.CreateTransactionResult, error) {
+	var result jsonresult.CreateTransactionResult
+	err := client.Call("sendtransaction", params, &result)
+	return result, err
+}
+
+// SetBackup call "setbackup"
+func (client *Client) SetBackup(params ...interface{}) (bool, error) {
+	var result bool
+	err := client.Call("setbackup", params, &result)
+	return result, err
+}
+
+// SetTxFee call "settxfee", available to limited users
+func (client *Client) SetTxFee(params ...interface{}) (bool, error) {
+	var result bool
+	err := client.Call("settxfee", params, &result)
+	return result, err
+}
+
+// StartProfiling call "startprofiling"
+func (client *Client) StartProfiling() (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("startprofiling", nil, &result)
+	return result, err
+}
+
+// StopProfiling call "stopprofiling"
+func (client *Client) StopProfiling() (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("stopprofiling", nil, &result)
+	return result, err
+}
+
+// TestHttpServer call "testrpcserver"
+func (client *Client) TestHttpServer() (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("testrpcserver", nil, &result)
+	return result, err
+}
+
+// UnlockMempool call "unlockmempool"
+func (client *Client) UnlockMempool() (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("unlockmempool", nil, &result)
+	return result, err
+}
+
+// CreateRawWithDrawTransaction call "withdrawreward"
+func (client *Client) CreateRawWithDrawTransaction(params ...interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := client.Call("withdrawreward", params, &result)
+	return result, err
+}
+
+// SubscribeBeaconBestState subscribe "subcribebeaconbeststate", every notification is decoded into *jsonresult.GetBeaconBestState and passed to handler
+func (client *WsClient) SubscribeBeaconBestState(handler func(*jsonresult.GetBeaconBestState, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribebeaconbeststate", params, func(raw json.RawMessage, err error) {
+		var result *jsonresult.GetBeaconBestState
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeBeaconCandidateByPublickey subscribe "subcribebeaconcandidatebypublickey", every notification is decoded into bool and passed to handler
+func (client *WsClient) SubscribeBeaconCandidateByPublickey(handler func(bool, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribebeaconcandidatebypublickey", params, func(raw json.RawMessage, err error) {
+		var result bool
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeBeaconCommitteeByPublickey subscribe "subcribebeaconcommitteebypublickey", every notification is decoded into bool and passed to handler
+func (client *WsClient) SubscribeBeaconCommitteeByPublickey(handler func(bool, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribebeaconcommitteebypublickey", params, func(raw json.RawMessage, err error) {
+		var result bool
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeBeaconPendingValidatorByPublickey subscribe "subcribebeaconpendingvalidatorbypublickey", every notification is decoded into bool and passed to handler
+func (client *WsClient) SubscribeBeaconPendingValidatorByPublickey(handler func(bool, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribebeaconpendingvalidatorbypublickey", params, func(raw json.RawMessage, err error) {
+		var result bool
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeBeaconPoolBeststate subscribe "subcribebeaconpoolbeststate", every notification is decoded into json.RawMessage and passed to handler
+func (client *WsClient) SubscribeBeaconPoolBeststate(handler func(json.RawMessage, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribebeaconpoolbeststate", params, func(raw json.RawMessage, err error) {
+		var result json.RawMessage
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeCrossCustomTokenPrivacyByPrivateKey subscribe "subcribecrosscustomtokenprivacybyprivatekey", every notification is decoded into jsonresult.CrossCustomTokenPrivacyResult and passed to handler
+func (client *WsClient) SubscribeCrossCustomTokenPrivacyByPrivateKey(handler func(jsonresult.CrossCustomTokenPrivacyResult, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribecrosscustomtokenprivacybyprivatekey", params, func(raw json.RawMessage, err error) {
+		var result jsonresult.CrossCustomTokenPrivacyResult
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeCrossOutputCoinByPrivateKey subscribe "subcribecrossoutputcoinbyprivatekey", every notification is decoded into jsonresult.CrossOutputCoinResult and passed to handler
+func (client *WsClient) SubscribeCrossOutputCoinByPrivateKey(handler func(jsonresult.CrossOutputCoinResult, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribecrossoutputcoinbyprivatekey", params, func(raw json.RawMessage, err error) {
+		var result jsonresult.CrossOutputCoinResult
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeCrossShardTxStatus subscribe "subcribecrossshardtxstatus", every notification is decoded into *jsonresult.CrossShardTxStatus and passed to handler
+func (client *WsClient) SubscribeCrossShardTxStatus(handler func(*jsonresult.CrossShardTxStatus, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribecrossshardtxstatus", params, func(raw json.RawMessage, err error) {
+		var result *jsonresult.CrossShardTxStatus
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeMempoolInfo subscribe "subcribemempoolinfo", every notification is decoded into json.RawMessage and passed to handler
+func (client *WsClient) SubscribeMempoolInfo(handler func(json.RawMessage, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribemempoolinfo", params, func(raw json.RawMessage, err error) {
+		var result json.RawMessage
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeNewBeaconBlock subscribe "subcribenewbeaconblock", every notification is decoded into *jsonresult.GetBeaconBlockResult and passed to handler
+func (client *WsClient) SubscribeNewBeaconBlock(handler func(*jsonresult.GetBeaconBlockResult, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribenewbeaconblock", params, func(raw json.RawMessage, err error) {
+		var result *jsonresult.GetBeaconBlockResult
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeNewShardBlock subscribe "subcribenewshardblock", every notification is decoded into *jsonresult.GetShardBlockResult and passed to handler
+func (client *WsClient) SubscribeNewShardBlock(handler func(*jsonresult.GetShardBlockResult, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribenewshardblock", params, func(raw json.RawMessage, err error) {
+		var result *jsonresult.GetShardBlockResult
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribePendingTransaction subscribe "subcribependingtransaction", every notification is decoded into *jsonresult.TransactionDetail and passed to handler
+func (client *WsClient) SubscribePendingTransaction(handler func(*jsonresult.TransactionDetail, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribependingtransaction", params, func(raw json.RawMessage, err error) {
+		var result *jsonresult.TransactionDetail
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeShardBestState subscribe "subcribeshardbeststate", every notification is decoded into *blockchain.ShardBestState and passed to handler
+func (client *WsClient) SubscribeShardBestState(handler func(*blockchain.ShardBestState, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribeshardbeststate", params, func(raw json.RawMessage, err error) {
+		var result *blockchain.ShardBestState
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeShardCandidateByPublickey subscribe "subcribeshardcandidatebypublickey", every notification is decoded into bool and passed to handler
+func (client *WsClient) SubscribeShardCandidateByPublickey(handler func(bool, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribeshardcandidatebypublickey", params, func(raw json.RawMessage, err error) {
+		var result bool
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeShardCommitteeByPublickey subscribe "subcribeshardcommitteebypublickey", every notification is decoded into bool and passed to handler
+func (client *WsClient) SubscribeShardCommitteeByPublickey(handler func(bool, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribeshardcommitteebypublickey", params, func(raw json.RawMessage, err error) {
+		var result bool
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeShardPendingValidatorByPublickey subscribe "subcribeshardpendingvalidatorbypublickey", every notification is decoded into bool and passed to handler
+func (client *WsClient) SubscribeShardPendingValidatorByPublickey(handler func(bool, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribeshardpendingvalidatorbypublickey", params, func(raw json.RawMessage, err error) {
+		var result bool
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeShardPoolBeststate subscribe "subcribeshardpoolbeststate", every notification is decoded into json.RawMessage and passed to handler
+func (client *WsClient) SubscribeShardPoolBeststate(handler func(json.RawMessage, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subcribeshardpoolbeststate", params, func(raw json.RawMessage, err error) {
+		var result json.RawMessage
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeFinalizedBeaconBlock subscribe "subscribefinalizedbeaconblock", every notification is decoded into *jsonresult.GetBeaconBlockResult and passed to handler
+func (client *WsClient) SubscribeFinalizedBeaconBlock(handler func(*jsonresult.GetBeaconBlockResult, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subscribefinalizedbeaconblock", params, func(raw json.RawMessage, err error) {
+		var result *jsonresult.GetBeaconBlockResult
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// SubscribeFinalizedShardBlock subscribe "subscribefinalizedshardblock", every notification is decoded into *jsonresult.GetShardBlockResult and passed to handler
+func (client *WsClient) SubscribeFinalizedShardBlock(handler func(*jsonresult.GetShardBlockResult, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("subscribefinalizedshardblock", params, func(raw json.RawMessage, err error) {
+		var result *jsonresult.GetShardBlockResult
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
+
+// TestSubcrice subscribe "testsubcribe", every notification is decoded into int and passed to handler
+func (client *WsClient) TestSubcrice(handler func(int, error), params ...interface{}) (*Subscription, error) {
+	return client.Subscribe("testsubcribe", params, func(raw json.RawMessage, err error) {
+		var result int
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		handler(result, err)
+	})
+}
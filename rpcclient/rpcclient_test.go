@@ -0,0 +1,286 @@
+package rpcclient
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/wire"
+	peer2 "github.com/libp2p/go-libp2p-peer"
+)
+
+func init() {
+	rpcserver.Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+type fakeServer struct{}
+
+func (fakeServer) PushMessageToAll(message wire.Message) error               { return nil }
+func (fakeServer) PushMessageToPeer(message wire.Message, id peer2.ID) error { return nil }
+func (fakeServer) GetNodeRole() string                                       { return common.ShardRole }
+func (fakeServer) EnableMining(enable bool) error                            { return nil }
+func (fakeServer) IsEnableMining() bool                                      { return true }
+func (fakeServer) GetChainMiningStatus(chain int) string                     { return "" }
+func (fakeServer) GetPublicKeyRole(publicKey string, keyType string) (int, int) {
+	return -1, -1
+}
+func (fakeServer) GetIncognitoPublicKeyRole(publicKey string) (int, bool, int) {
+	return -1, false, -1
+}
+func (fakeServer) GetMinerIncognitoPublickey(publicKey string, keyType string) []byte {
+	return nil
+}
+
+// startServers run an in-process HttpServer and WsServer on random local ports
+func startServers(t *testing.T) (string, string, func()) {
+	httpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wsListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &rpcserver.RpcServerConfig{
+		HttpListenters:  []net.Listener{httpListener},
+		WsListenters:    []net.Listener{wsListener},
+		Server:          fakeServer{},
+		RPCMaxClients:   10,
+		RPCMaxWSClients: 10,
+		DisableAuth:     true,
+	}
+	httpServer := &rpcserver.HttpServer{}
+	httpServer.Init(config)
+	if err := httpServer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	wsServer := &rpcserver.WsServer{}
+	wsServer.Init(config)
+	if err := wsServer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	return "http://" + httpListener.Addr().String(), "ws://" + wsListener.Addr().String(), func() {
+		httpServer.Stop()
+		wsServer.Stop()
+		wsListener.Close()
+	}
+}
+
+func TestClientAgainstHttpServer(t *testing.T) {
+	httpURL, _, stop := startServers(t)
+	defer stop()
+	client := NewClient(httpURL)
+
+	maxShards, err := client.GetMaxShardsNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maxShards != common.MaxShardNumber {
+		t.Errorf("expect %+v got %+v", common.MaxShardNumber, maxShards)
+	}
+	role, err := client.GetNodeRole()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role != common.ShardRole {
+		t.Errorf("expect %+v got %+v", common.ShardRole, role)
+	}
+
+	_, err = client.RetrieveBlock(1, "1")
+	rpcErr, ok := err.(*rpcservice.RPCError)
+	if !ok {
+		t.Fatalf("expect rpc error got %+v", err)
+	}
+	if rpcErr.Code != rpcservice.ErrCodeMessage[rpcservice.RPCInvalidParamsError].Code {
+		t.Errorf("unexpected error code %+v", rpcErr.Code)
+	}
+
+	err = client.Call("nosuchmethod", nil, nil)
+	if rpcErr, ok := err.(*rpcservice.RPCError); !ok || rpcErr.Code != rpcservice.ErrCodeMessage[rpcservice.RPCMethodNotFoundError].Code {
+		t.Errorf("expect method not found got %+v", err)
+	}
+}
+
+func TestRPCSchemaAgainstHttpServer(t *testing.T) {
+	httpURL, _, stop := startServers(t)
+	defer stop()
+	client := NewClient(httpURL)
+
+	document := struct {
+		Method string
+		Typed  bool
+		Params struct {
+			PrefixItems []map[string]interface{} `json:"prefixItems"`
+			MinItems    int                      `json:"minItems"`
+		}
+	}{}
+	method := "createandsendtxwithptokencrosspooltradereq"
+	if err := client.Call("getrpcschema", []interface{}{method}, &document); err != nil {
+		t.Fatal(err)
+	}
+	if !document.Typed || len(document.Params.PrefixItems) != 7 || document.Params.MinItems != 5 {
+		t.Fatalf("unexpected schema %+v", document)
+	}
+	if document.Params.PrefixItems[4]["title"] != "tokenParams" {
+		t.Errorf("unexpected param #5 %+v", document.Params.PrefixItems[4])
+	}
+
+	// params are rejected before reaching the handler
+	err := client.Call(method, []interface{}{"key", map[string]interface{}{}, -1, -1, map[string]interface{}{"TokenIDToBuyStr": "a"}}, nil)
+	rpcErr, ok := err.(*rpcservice.RPCError)
+	if !ok || rpcErr.Code != rpcservice.ErrCodeMessage[rpcservice.RPCInvalidParamsError].Code {
+		t.Fatalf("expect invalid params got %+v", err)
+	}
+	if !strings.Contains(rpcErr.StackTrace, "param #5 (tokenParams): key TokenIDToSellStr is required") {
+		t.Errorf("unexpected error detail %+v", rpcErr.StackTrace)
+	}
+	err = client.Call("getblockcount", []interface{}{"0"}, nil)
+	if rpcErr, ok := err.(*rpcservice.RPCError); !ok || !strings.Contains(rpcErr.StackTrace, "param #1 (shardID): expect integer") {
+		t.Errorf("expect invalid shardID got %+v", err)
+	}
+
+	response, err := http.Get(httpURL + "/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	openAPI := struct {
+		OpenAPI string                 `json:"openapi"`
+		Paths   map[string]interface{} `json:"paths"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&openAPI); err != nil {
+		t.Fatal(err)
+	}
+	if openAPI.OpenAPI == "" || openAPI.Paths["/#"+method] == nil || openAPI.Paths["/#getnoderole"] == nil {
+		t.Errorf("unexpected openapi document %+v", openAPI.OpenAPI)
+	}
+}
+
+func TestDownloadBackupAgainstHttpServer(t *testing.T) {
+	httpURL, _, stop := startServers(t)
+	defer stop()
+	client := NewClient(httpURL)
+
+	document := struct {
+		Typed  bool
+		Params struct {
+			MinItems int `json:"minItems"`
+		}
+	}{}
+	if err := client.Call("getrpcschema", []interface{}{"downloadbackup"}, &document); err != nil {
+		t.Fatal(err)
+	}
+	if !document.Typed || document.Params.MinItems != 1 {
+		t.Fatalf("unexpected schema %+v", document)
+	}
+
+	// invalid params are answered in json instead of the stream
+	for _, params := range [][]interface{}{{}, {"beacon", 0, "MANIFEST"}, {"beacon", 3, "../MANIFEST"}, {"beacon", true}, {"beacon", ""}} {
+		err := client.DownloadBackup(ioutil.Discard, params...)
+		if rpcErr, ok := err.(*rpcservice.RPCError); !ok || rpcErr.Code != rpcservice.ErrCodeMessage[rpcservice.RPCInvalidParamsError].Code {
+			t.Errorf("params %+v expect invalid params got %+v", params, err)
+		}
+	}
+}
+
+func TestWsClientAgainstWsServer(t *testing.T) {
+	_, wsURL, stop := startServers(t)
+	defer stop()
+	client, err := NewWsClient(wsURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	received := make(chan int, 10)
+	_, err = client.TestSubcrice(func(result int, err error) {
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		received <- result
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for expect := 0; expect < 2; expect++ {
+		select {
+		case result := <-received:
+			if result != expect {
+				t.Errorf("expect %+v got %+v", expect, result)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for notification")
+		}
+	}
+}
+
+// TestWsClientReconnect drop the first connection server side and expect the subscription to be sent again
+func TestWsClientReconnect(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	var mtx sync.Mutex
+	connections := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		mtx.Lock()
+		connections++
+		current := connections
+		mtx.Unlock()
+		request := subscriptionRequest{}
+		if err := conn.ReadJSON(&request); err != nil {
+			return
+		}
+		if current == 1 {
+			return
+		}
+		result, _ := json.Marshal(subscriptionResult{Subscription: request.Subcription, Result: json.RawMessage(`"reconnected"`)})
+		conn.WriteJSON(Response{Result: result, Method: request.Request.Method})
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client, err := NewWsClient("ws" + strings.TrimPrefix(server.URL, "http"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.MinReconnectDelay = 10 * time.Millisecond
+	defer client.Close()
+
+	received := make(chan string, 1)
+	_, err = client.Subscribe("subcribenewbeaconblock", nil, func(raw json.RawMessage, err error) {
+		var result string
+		if err == nil {
+			err = decodeResult(raw, &result)
+		}
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		received <- result
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case result := <-received:
+		if result != "reconnected" {
+			t.Errorf("unexpected result %+v", result)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for notification after reconnect")
+	}
+}
@@ -0,0 +1,248 @@
+package rpcclient
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	subscribeType   = 0
+	unsubscribeType = 1
+
+	DefaultMinReconnectDelay = time.Second
+	DefaultMaxReconnectDelay = 30 * time.Second
+)
+
+// subscriptionRequest is the websocket envelope understood by rpcserver (0: subscribe, 1: unsubscribe)
+type subscriptionRequest struct {
+	Request     Request `json:"Request"`
+	Subcription string  `json:"Subcription"`
+	Type        int     `json:"Type"`
+}
+
+type subscriptionResult struct {
+	Subscription string          `json:"Subscription"`
+	Result       json.RawMessage `json:"Result"`
+}
+
+// Subscription is one active websocket subscription, it is re-sent automatically after reconnect
+type Subscription struct {
+	ID      string
+	Method  string
+	Params  []interface{}
+	handler func(json.RawMessage, error)
+	client  *WsClient
+}
+
+// Unsubscribe stop receiving notifications of this subscription
+func (subscription *Subscription) Unsubscribe() error {
+	return subscription.client.unsubscribe(subscription)
+}
+
+// WsClient manage subscriptions to the node websocket API over a single connection.
+// When the connection drops it redials with exponential backoff and subscribes again.
+type WsClient struct {
+	url               string
+	header            http.Header
+	MinReconnectDelay time.Duration
+	MaxReconnectDelay time.Duration
+
+	mtx           sync.Mutex
+	writeMtx      sync.Mutex
+	conn          *websocket.Conn
+	subscriptions map[string]*Subscription
+	nextID        uint64
+	closed        bool
+	stopCh        chan struct{}
+	doneCh        chan struct{}
+}
+
+// NewWsClient dial node websocket endpoint at url, ex: ws://127.0.0.1:19334
+func NewWsClient(url string) (*WsClient, error) {
+	return NewWsClientWithHeader(url, nil)
+}
+
+// NewWsClientWithHeader dial url sending header on every (re)connection, ex: to carry basic auth
+func NewWsClientWithHeader(url string, header http.Header) (*WsClient, error) {
+	client := &WsClient{
+		url:               url,
+		header:            header,
+		MinReconnectDelay: DefaultMinReconnectDelay,
+		MaxReconnectDelay: DefaultMaxReconnectDelay,
+		subscriptions:     make(map[string]*Subscription),
+		stopCh:            make(chan struct{}),
+		doneCh:            make(chan struct{}),
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(url, header)
+	if err != nil {
+		return nil, NewRPCClientError(WebsocketDialError, err)
+	}
+	client.conn = conn
+	go client.readLoop()
+	return client, nil
+}
+
+// Subscribe send method with params, handler is called sequentially with the raw result of every notification
+func (client *WsClient) Subscribe(method string, params []interface{}, handler func(json.RawMessage, error)) (*Subscription, error) {
+	if params == nil {
+		params = []interface{}{}
+	}
+	client.mtx.Lock()
+	if client.closed {
+		client.mtx.Unlock()
+		return nil, NewRPCClientError(WebsocketClosedError, errors.New("subscribe on closed client"))
+	}
+	client.nextID++
+	subscription := &Subscription{
+		ID:      strconv.FormatUint(client.nextID, 10),
+		Method:  method,
+		Params:  params,
+		handler: handler,
+		client:  client,
+	}
+	client.subscriptions[subscription.ID] = subscription
+	conn := client.conn
+	client.mtx.Unlock()
+
+	if err := client.send(conn, subscription, subscribeType); err != nil {
+		// the subscription stays registered and is sent again once reconnected
+		return subscription, err
+	}
+	return subscription, nil
+}
+
+func (client *WsClient) unsubscribe(subscription *Subscription) error {
+	client.mtx.Lock()
+	if _, ok := client.subscriptions[subscription.ID]; !ok {
+		client.mtx.Unlock()
+		return nil
+	}
+	delete(client.subscriptions, subscription.ID)
+	conn := client.conn
+	closed := client.closed
+	client.mtx.Unlock()
+	if closed {
+		return nil
+	}
+	return client.send(conn, subscription, unsubscribeType)
+}
+
+func (client *WsClient) send(conn *websocket.Conn, subscription *Subscription, requestType int) error {
+	request := subscriptionRequest{
+		Request: Request{
+			Jsonrpc: jsonRPCVersion,
+			Method:  subscription.Method,
+			Params:  subscription.Params,
+			Id:      subscription.ID,
+		},
+		Subcription: subscription.ID,
+		Type:        requestType,
+	}
+	client.writeMtx.Lock()
+	defer client.writeMtx.Unlock()
+	if err := conn.WriteJSON(request); err != nil {
+		return NewRPCClientError(NetworkError, err)
+	}
+	return nil
+}
+
+// Close unsubscribe everything and close the connection, handlers are not called after Close returns
+func (client *WsClient) Close() error {
+	client.mtx.Lock()
+	if client.closed {
+		client.mtx.Unlock()
+		return nil
+	}
+	client.closed = true
+	conn := client.conn
+	client.mtx.Unlock()
+	close(client.stopCh)
+	err := conn.Close()
+	<-client.doneCh
+	return err
+}
+
+func (client *WsClient) readLoop() {
+	defer close(client.doneCh)
+	for {
+		client.mtx.Lock()
+		conn := client.conn
+		client.mtx.Unlock()
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				break
+			}
+			client.dispatch(message)
+		}
+		if !client.reconnect() {
+			return
+		}
+	}
+}
+
+func (client *WsClient) dispatch(message []byte) {
+	response := Response{}
+	if err := json.Unmarshal(message, &response); err != nil {
+		return
+	}
+	result := subscriptionResult{}
+	if err := json.Unmarshal(response.Result, &result); err != nil {
+		return
+	}
+	client.mtx.Lock()
+	subscription, ok := client.subscriptions[result.Subscription]
+	client.mtx.Unlock()
+	if !ok {
+		return
+	}
+	if response.Error != nil {
+		subscription.handler(nil, response.Error)
+		return
+	}
+	subscription.handler(result.Result, nil)
+}
+
+// reconnect redial until success or Close, then subscribe again every active subscription
+func (client *WsClient) reconnect() bool {
+	delay := client.MinReconnectDelay
+	for {
+		select {
+		case <-client.stopCh:
+			return false
+		case <-time.After(delay):
+		}
+		conn, _, err := websocket.DefaultDialer.Dial(client.url, client.header)
+		if err != nil {
+			delay *= 2
+			if delay > client.MaxReconnectDelay {
+				delay = client.MaxReconnectDelay
+			}
+			continue
+		}
+		client.mtx.Lock()
+		if client.closed {
+			client.mtx.Unlock()
+			conn.Close()
+			return false
+		}
+		client.conn = conn
+		subscriptions := make([]*Subscription, 0, len(client.subscriptions))
+		for _, subscription := range client.subscriptions {
+			subscriptions = append(subscriptions, subscription)
+		}
+		client.mtx.Unlock()
+		for _, subscription := range subscriptions {
+			if err := client.send(conn, subscription, subscribeType); err != nil {
+				break
+			}
+		}
+		return true
+	}
+}
@@ -19,6 +19,25 @@ type CreateRawPrivacyTokenTxParam struct {
 	Info                 []byte
 	IsGetPTokenFee       bool
 	UnitPTokenFee        int64
+	Memos                map[string]string
+}
+
+// SetMemos reads the optional memos at index in params like CreateRawTxParam.SetMemos, they are encrypted by
+// SetPaymentMemos once the token receivers are parsed
+func (param *CreateRawPrivacyTokenTxParam) SetMemos(params interface{}, index int) error {
+	memos, err := readMemos(params, index)
+	if err != nil {
+		return err
+	}
+	param.Memos = memos
+	return nil
+}
+
+// SetPaymentMemos encrypts the memos to the PRV receivers and to tokenReceivers, a receiver of both PRV and token gets
+// the memo in each of its output coins
+func (param *CreateRawPrivacyTokenTxParam) SetPaymentMemos(tokenReceivers []*privacy.PaymentInfo) error {
+	paymentInfos := append(append([]*privacy.PaymentInfo{}, param.PaymentInfos...), tokenReceivers...)
+	return setPaymentMemos(paymentInfos, param.Memos)
 }
 
 func NewCreateRawPrivacyTokenTxParam(params interface{}) (*CreateRawPrivacyTokenTxParam, error) {
@@ -1,6 +1,7 @@
 package bean
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
@@ -17,6 +18,8 @@ type CreateRawTxParam struct {
 	EstimateFeeCoinPerKb int64
 	HasPrivacyCoin       bool
 	Info                 []byte
+	CommitmentRingSize   int  // ring size of the one-out-of-many proofs, 0 is privacy.CommitmentRingSize
+	Stealth              bool // output coins have one-time public keys
 }
 
 func GetKeySetFromPrivateKeyParams(privateKeyWalletStr string) (*incognitokey.KeySet, byte, error) {
@@ -43,6 +46,102 @@ func GetKeySetFromPrivateKeyParams(privateKeyWalletStr string) (*incognitokey.Ke
 	return &keyWallet.KeySet, shardID, nil
 }
 
+// SetCommitmentRingSize reads the optional ring size of the one-out-of-many proofs at index in params, the methods
+// sending PRV read it after info
+func (param *CreateRawTxParam) SetCommitmentRingSize(params interface{}, index int) error {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) <= index || arrayParams[index] == nil {
+		return nil
+	}
+	ringSize, ok := arrayParams[index].(float64)
+	if !ok {
+		return errors.New("ring size is invalid")
+	}
+	if _, ok := privacy.GetCommitmentRingSizeExp(int(ringSize)); !ok {
+		return fmt.Errorf("ring size %v is not supported, it is 8, 16, 32 or 64", ringSize)
+	}
+	param.CommitmentRingSize = int(ringSize)
+	return nil
+}
+
+// SetStealth reads the optional flag at index in params making the output coins stealth coins, the methods sending
+// PRV read it after the ring size
+func (param *CreateRawTxParam) SetStealth(params interface{}, index int) error {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) <= index || arrayParams[index] == nil {
+		return nil
+	}
+	stealth, ok := arrayParams[index].(bool)
+	if !ok {
+		return errors.New("stealth is invalid")
+	}
+	if stealth && !param.HasPrivacyCoin {
+		return errors.New("stealth output coins need a tx with privacy")
+	}
+	param.Stealth = stealth
+	return nil
+}
+
+// SetMemos reads the optional memos at index in params, a memo string by payment address of a receiver, each memo is
+// encrypted in the info of the output coin to its receiver, the methods sending PRV read it after stealth
+func (param *CreateRawTxParam) SetMemos(params interface{}, index int) error {
+	memos, err := readMemos(params, index)
+	if err != nil {
+		return err
+	}
+	return setPaymentMemos(param.PaymentInfos, memos)
+}
+
+// readMemos reads the optional memos at index in params, a memo string by payment address of a receiver
+func readMemos(params interface{}, index int) (map[string]string, error) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) <= index || arrayParams[index] == nil {
+		return nil, nil
+	}
+	memosParam, ok := arrayParams[index].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("memos param is invalid")
+	}
+	memos := make(map[string]string, len(memosParam))
+	for paymentAddressStr, memo := range memosParam {
+		memos[paymentAddressStr], ok = memo.(string)
+		if !ok {
+			return nil, fmt.Errorf("memo of %+v is invalid", paymentAddressStr)
+		}
+	}
+	return memos, nil
+}
+
+// setPaymentMemos encrypts the memo of each payment address in memos to the message of its payment infos, a receiver
+// listed several times gets the memo in each of its output coins
+func setPaymentMemos(paymentInfos []*privacy.PaymentInfo, memos map[string]string) error {
+	for paymentAddressStr, memo := range memos {
+		keyWalletReceiver, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+		if err != nil {
+			return err
+		}
+		hasReceiver := false
+		for _, paymentInfo := range paymentInfos {
+			if !bytes.Equal(paymentInfo.PaymentAddress.Bytes(), keyWalletReceiver.KeySet.PaymentAddress.Bytes()) {
+				continue
+			}
+			if paymentInfo.Message != nil {
+				return fmt.Errorf("memo of %+v is given twice", paymentAddressStr)
+			}
+			// each output coin has its own encryption of the memo
+			paymentInfo.Message, err = privacy.EncryptMemo([]byte(memo), paymentInfo.PaymentAddress.Tk)
+			if err != nil {
+				return fmt.Errorf("memo of %+v is invalid: %v", paymentAddressStr, err)
+			}
+			hasReceiver = true
+		}
+		if !hasReceiver {
+			return fmt.Errorf("memo of %+v is not for a receiver", paymentAddressStr)
+		}
+	}
+	return nil
+}
+
 func NewCreateRawTxParam(params interface{}) (*CreateRawTxParam, error) {
 	arrayParams := common.InterfaceSlice(params)
 	if len(arrayParams) < 3 {
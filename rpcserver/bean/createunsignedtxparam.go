@@ -0,0 +1,210 @@
+package bean
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// CreateUnsignedTxParam is the param of an unsigned PRV tx, sender is given by its payment address and readonly key
+// so the private key never reaches the node
+type CreateUnsignedTxParam struct {
+	SenderKeySet         *incognitokey.KeySet
+	ShardIDSender        byte
+	PaymentInfos         []*privacy.PaymentInfo
+	EstimateFeeCoinPerKb int64
+	HasPrivacyCoin       bool
+	Info                 []byte
+	// SerialNumbers maps SNDerivator of coins to their serial number, both base58 check encoded,
+	// nil when the sender does not restrict the coins to spend
+	SerialNumbers map[string][]byte
+	// CommitmentRingSize is the ring size of the one-out-of-many proofs, 0 is privacy.CommitmentRingSize
+	CommitmentRingSize int
+	// Stealth makes the output coins stealth coins with one-time public keys
+	Stealth bool
+}
+
+func NewCreateUnsignedTxParam(
+	paymentAddressStr string,
+	readonlyKeyStr string,
+	receivers map[string]uint64,
+	estimateFeeCoinPerKb int64,
+	hasPrivacyCoin bool,
+	info string,
+	serialNumbers map[string]string,
+	ringSize int,
+	stealth bool,
+	memos map[string]string,
+) (*CreateUnsignedTxParam, error) {
+	// param #1: payment address of sender
+	senderWallet, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(senderWallet.KeySet.PaymentAddress.Pk) == 0 {
+		return nil, errors.New("sender payment address is invalid")
+	}
+
+	// param #2: readonly key of sender
+	readonlyKeyWallet, err := wallet.Base58CheckDeserialize(readonlyKeyStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(readonlyKeyWallet.KeySet.ReadonlyKey.Rk) == 0 {
+		return nil, errors.New("sender readonly key is invalid")
+	}
+	if !bytes.Equal(readonlyKeyWallet.KeySet.ReadonlyKey.Pk, senderWallet.KeySet.PaymentAddress.Pk) {
+		return nil, errors.New("readonly key does not belong to sender payment address")
+	}
+	senderKeySet := &incognitokey.KeySet{
+		PaymentAddress: senderWallet.KeySet.PaymentAddress,
+		ReadonlyKey:    readonlyKeyWallet.KeySet.ReadonlyKey,
+	}
+	lastByte := senderKeySet.PaymentAddress.Pk[len(senderKeySet.PaymentAddress.Pk)-1]
+	shardIDSender := common.GetShardIDFromLastByte(lastByte)
+
+	// param #3: list receivers
+	paymentInfos, err := newUnsignedPaymentInfos(receivers)
+	if err != nil {
+		return nil, err
+	}
+
+	// param #7: serial numbers of coins to spend (optional)
+	serialNumberBytes, err := decodeSerialNumbers(serialNumbers)
+	if err != nil {
+		return nil, err
+	}
+
+	// param #8: ring size of the one-out-of-many proofs (optional)
+	if ringSize != 0 {
+		if _, ok := privacy.GetCommitmentRingSizeExp(ringSize); !ok {
+			return nil, fmt.Errorf("ring size %v is not supported, it is 8, 16, 32 or 64", ringSize)
+		}
+	}
+
+	// param #9: output coins with one-time public keys (optional)
+	if stealth && !hasPrivacyCoin {
+		return nil, errors.New("stealth output coins need a tx with privacy")
+	}
+
+	// param #10: memos encrypted to receivers (optional)
+	if err := setPaymentMemos(paymentInfos, memos); err != nil {
+		return nil, err
+	}
+
+	return &CreateUnsignedTxParam{
+		SenderKeySet:         senderKeySet,
+		ShardIDSender:        shardIDSender,
+		PaymentInfos:         paymentInfos,
+		EstimateFeeCoinPerKb: estimateFeeCoinPerKb,
+		HasPrivacyCoin:       hasPrivacyCoin,
+		Info:                 []byte(info),
+		SerialNumbers:        serialNumberBytes,
+		CommitmentRingSize:   ringSize,
+		Stealth:              stealth,
+	}, nil
+}
+
+// CreateUnsignedPrivacyTokenTxParam is the param of an unsigned privacy token transfer, the PRV part pays the fee
+type CreateUnsignedPrivacyTokenTxParam struct {
+	*CreateUnsignedTxParam
+	TokenID         *common.Hash
+	TokenReceivers  []*privacy.PaymentInfo
+	TokenFee        uint64
+	HasPrivacyToken bool
+	// TokenSerialNumbers is SerialNumbers of the token coins to spend
+	TokenSerialNumbers map[string][]byte
+}
+
+func NewCreateUnsignedPrivacyTokenTxParam(
+	paymentAddressStr string,
+	readonlyKeyStr string,
+	receivers map[string]uint64,
+	estimateFeeCoinPerKb int64,
+	hasPrivacyCoin bool,
+	info string,
+	serialNumbers map[string]string,
+	tokenIDStr string,
+	tokenReceivers map[string]uint64,
+	tokenFee uint64,
+	hasPrivacyToken bool,
+	tokenSerialNumbers map[string]string,
+	memos map[string]string,
+) (*CreateUnsignedPrivacyTokenTxParam, error) {
+	txParam, err := NewCreateUnsignedTxParam(paymentAddressStr, readonlyKeyStr, receivers, estimateFeeCoinPerKb,
+		hasPrivacyCoin, info, serialNumbers, 0, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("token id %+v is invalid", tokenIDStr)
+	}
+	if tokenID.IsEqual(&common.PRVCoinID) {
+		return nil, errors.New("token id is PRV, use createunsignedtransaction")
+	}
+	tokenPaymentInfos, err := newUnsignedPaymentInfos(tokenReceivers)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokenPaymentInfos) == 0 {
+		return nil, errors.New("token receivers are empty")
+	}
+	tokenSerialNumberBytes, err := decodeSerialNumbers(tokenSerialNumbers)
+	if err != nil {
+		return nil, err
+	}
+	// memos encrypted to the receivers of PRV and token
+	paymentInfos := append(append([]*privacy.PaymentInfo{}, txParam.PaymentInfos...), tokenPaymentInfos...)
+	if err := setPaymentMemos(paymentInfos, memos); err != nil {
+		return nil, err
+	}
+	return &CreateUnsignedPrivacyTokenTxParam{
+		CreateUnsignedTxParam: txParam,
+		TokenID:               tokenID,
+		TokenReceivers:        tokenPaymentInfos,
+		TokenFee:              tokenFee,
+		HasPrivacyToken:       hasPrivacyToken,
+		TokenSerialNumbers:    tokenSerialNumberBytes,
+	}, nil
+}
+
+func newUnsignedPaymentInfos(receivers map[string]uint64) ([]*privacy.PaymentInfo, error) {
+	paymentInfos := make([]*privacy.PaymentInfo, 0)
+	for paymentAddressStr, amount := range receivers {
+		keyWalletReceiver, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+		if err != nil {
+			return nil, err
+		}
+		if len(keyWalletReceiver.KeySet.PaymentAddress.Pk) == 0 {
+			return nil, fmt.Errorf("payment info %+v is invalid", paymentAddressStr)
+		}
+		paymentInfos = append(paymentInfos, &privacy.PaymentInfo{
+			Amount:         amount,
+			PaymentAddress: keyWalletReceiver.KeySet.PaymentAddress,
+		})
+	}
+	return paymentInfos, nil
+}
+
+// decodeSerialNumbers decodes the SNDerivator to serial number map, nil stays nil
+func decodeSerialNumbers(serialNumbers map[string]string) (map[string][]byte, error) {
+	if serialNumbers == nil {
+		return nil, nil
+	}
+	serialNumberBytes := make(map[string][]byte, len(serialNumbers))
+	for sndStr, serialNumberStr := range serialNumbers {
+		serialNumber, _, err := base58.Base58Check{}.Decode(serialNumberStr)
+		if err != nil {
+			return nil, fmt.Errorf("serial number of SND %+v is invalid", sndStr)
+		}
+		serialNumberBytes[sndStr] = serialNumber
+	}
+	return serialNumberBytes, nil
+}
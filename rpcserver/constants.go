@@ -22,6 +22,8 @@ const (
 	getActiveShards    = "getactiveshards"
 	getMaxShardsNumber = "getmaxshardsnumber"
 
+	getRPCSchema = "getrpcschema"
+
 	getMiningInfo                 = "getmininginfo"
 	getRawMempool                 = "getrawmempool"
 	getNumberOfTxsInMempool       = "getnumberoftxsinmempool"
@@ -40,6 +42,7 @@ const (
 	//getFeeEstimator             = "getfeeestimator"
 	setBackup                   = "setbackup"
 	getLatestBackup             = "getlatestbackup"
+	downloadBackup              = "downloadbackup"
 	getBestBlock                = "getbestblock"
 	getBestBlockHash            = "getbestblockhash"
 	getBlocks                   = "getblocks"
@@ -54,6 +57,7 @@ const (
 	listOutputCoins                            = "listoutputcoins"
 	createRawTransaction                       = "createtransaction"
 	sendRawTransaction                         = "sendtransaction"
+	createUnsignedTransaction                  = "createunsignedtransaction"
 	createAndSendTransaction                   = "createandsendtransaction"
 	createAndSendCustomTokenTransaction        = "createandsendcustomtokentransaction"
 	sendRawCustomTokenTransaction              = "sendrawcustomtokentransaction"
@@ -61,8 +65,10 @@ const (
 	createRawPrivacyCustomTokenTransaction     = "createrawprivacycustomtokentransaction"
 	sendRawPrivacyCustomTokenTransaction       = "sendrawprivacycustomtokentransaction"
 	createAndSendPrivacyCustomTokenTransaction = "createandsendprivacycustomtokentransaction"
+	createUnsignedPrivacyTokenTx               = "createunsignedprivacycustomtokentransaction"
 	getMempoolInfo                             = "getmempoolinfo"
 	getPendingTxsInBlockgen                    = "getpendingtxsinblockgen"
+	getBlockTemplate                           = "getblocktemplate"
 	getCandidateList                           = "getcandidatelist"
 	getCommitteeList                           = "getcommitteelist"
 	canPubkeyStake                             = "canpubkeystake"
@@ -70,6 +76,7 @@ const (
 	listUnspentCustomToken                     = "listunspentcustomtoken"
 	getBalanceCustomToken                      = "getbalancecustomtoken"
 	getTransactionByHash                       = "gettransactionbyhash"
+	getCrossShardTxStatus                      = "getcrossshardtxstatus"
 	gettransactionhashbyreceiver               = "gettransactionhashbyreceiver"
 	gettransactionbyreceiver                   = "gettransactionbyreceiver"
 	listCustomToken                            = "listcustomtoken"
@@ -94,6 +101,14 @@ const (
 	listCommitmentIndices                      = "listcommitmentindices"
 	createAndSendStakingTransaction            = "createandsendstakingtransaction"
 	createAndSendStopAutoStakingTransaction    = "createandsendstopautostakingtransaction"
+	createAndSendDelegationTransaction         = "createandsenddelegationtransaction"
+	createAndSendDelegationCommissionTx        = "createandsenddelegationcommissiontransaction"
+	getDelegations                             = "getdelegations"
+	createAndSendTxWithHTLCLock                = "createandsendtxwithhtlclock"
+	createAndSendTxWithPTokenHTLCLock          = "createandsendtxwithptokenhtlclock"
+	createAndSendTxWithHTLCClaim               = "createandsendtxwithhtlcclaim"
+	createAndSendTxWithHTLCRefund              = "createandsendtxwithhtlcrefund"
+	getHTLC                                    = "gethtlc"
 	decryptoutputcoinbykeyoftransaction        = "decryptoutputcoinbykeyoftransaction"
 
 	//===========For Testing and Benchmark==============
@@ -264,7 +279,10 @@ const (
 	testSubcrice                                = "testsubcribe"
 	subcribeNewShardBlock                       = "subcribenewshardblock"
 	subcribeNewBeaconBlock                      = "subcribenewbeaconblock"
+	subscribeFinalizedShardBlock                = "subscribefinalizedshardblock"
+	subscribeFinalizedBeaconBlock               = "subscribefinalizedbeaconblock"
 	subcribePendingTransaction                  = "subcribependingtransaction"
+	subcribeCrossShardTxStatus                  = "subcribecrossshardtxstatus"
 	subcribeShardCandidateByPublickey           = "subcribeshardcandidatebypublickey"
 	subcribeShardPendingValidatorByPublickey    = "subcribeshardpendingvalidatorbypublickey"
 	subcribeShardCommitteeByPublickey           = "subcribeshardcommitteebypublickey"
@@ -1,6 +1,7 @@
 package rpcserver
 
 import (
+	"context"
 	"crypto/subtle"
 	"encoding/base64"
 	"errors"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/tracing"
 )
 
 type HttpServer struct {
@@ -111,6 +113,7 @@ func (httpServer *HttpServer) Start() error {
 	httpServeMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		httpServer.handleRequest(w, r)
 	})
+	httpServeMux.HandleFunc("/openapi.json", httpServer.handleOpenAPI)
 	for _, listen := range httpServer.config.HttpListenters {
 		go func(listen net.Listener) {
 			Logger.log.Infof("RPC Http server listening on %s", listen.Addr())
@@ -282,27 +285,41 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 			}
 		}
 		if jsonErr == nil {
-			if request.Method == "downloadbackup" {
-				httpServer.handleDownloadBackup(conn, request.Params)
-				return
-			}
-
-			// Attempt to parse the JSON-RPC request into a known concrete
-			// command.
-			command := HttpHandler[request.Method]
-			if command == nil {
-				if isLimitedUser {
-					command = LimitedHttpHandler[request.Method]
+			var command httpHandler
+			if request.Method == downloadBackup {
+				// the backup is streamed on the connection, only invalid params are answered in json
+				rpcErr := httpServer.handleDownloadBackup(conn, request.Params)
+				if rpcErr == nil {
+					return
+				}
+				jsonErr = rpcErr
+			} else {
+				// Attempt to parse the JSON-RPC request into a known concrete
+				// command.
+				command = HttpHandler[request.Method]
+				if command == nil {
+					if isLimitedUser {
+						command = LimitedHttpHandler[request.Method]
+					} else {
+						result = nil
+						jsonErr = rpcservice.NewRPCError(rpcservice.RPCMethodNotFoundError, errors.New("Method not found: "+request.Method))
+					}
+				}
+				if command != nil {
+					// params of typed methods are checked against the schema registry before the handler is called
+					jsonErr = validateRPCParams(request.Method, request.Params)
+					if jsonErr.(*rpcservice.RPCError) == nil {
+						_, span := tracing.StartSpan(tracing.ContextWithRemoteParent(context.Background(), r.Header.Get("traceparent")), "rpc."+request.Method)
+						result, jsonErr = command(httpServer, request.Params, closeChan)
+						if rpcErr, ok := jsonErr.(*rpcservice.RPCError); ok && rpcErr != nil {
+							span.SetError(rpcErr)
+						}
+						span.End()
+					}
 				} else {
-					result = nil
 					jsonErr = rpcservice.NewRPCError(rpcservice.RPCMethodNotFoundError, errors.New("Method not found: "+request.Method))
 				}
 			}
-			if command != nil {
-				result, jsonErr = command(httpServer, request.Params, closeChan)
-			} else {
-				jsonErr = rpcservice.NewRPCError(rpcservice.RPCMethodNotFoundError, errors.New("Method not found: "+request.Method))
-			}
 		}
 	}
 
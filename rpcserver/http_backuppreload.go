@@ -1,85 +1,121 @@
 package rpcserver
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
-	"github.com/pkg/errors"
 	"io"
 	"net"
 	"os"
+	"path/filepath"
 )
 
 func (httpServer *HttpServer) handleSetBackup(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	paramArray, ok := params.([]interface{})
-	if ok && len(paramArray) == 1 {
-		setBackup, ok := paramArray[0].(bool)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("set backup is invalid"))
-		}
-		httpServer.config.ChainParams.IsBackup = setBackup
-		return setBackup, nil
+	backupParams := setBackupParams{}
+	if err := decodeRPCParams(params, &backupParams); err != nil {
+		return nil, err
 	}
-	return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("no param"))
+	httpServer.config.ChainParams.IsBackup = backupParams.Backup
+	return backupParams.Backup, nil
 }
 
 func (httpServer *HttpServer) handleGetLatestBackup(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	paramArray, ok := params.([]interface{})
-	//fmt.Println("handleGetLatestBackup", paramArray)
-	if ok && len(paramArray) == 1 {
+	backupParams := getLatestBackupParams{}
+	if err := decodeRPCParams(params, &backupParams); err != nil {
+		return nil, err
+	}
+	if backupParams.ChainName == "" {
+		return 0, nil
+	}
+	epoch, _ := httpServer.config.BlockChain.GetBeaconChainDatabase().LatestBackup(fmt.Sprintf("../../backup/%v", backupParams.ChainName))
+	return struct {
+		LatestEpoch int
+	}{
+		epoch,
+	}, nil
+}
 
-		chainName, ok := paramArray[0].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("chainName is invalid"))
+// backupSource is the second param of downloadbackup, the epoch of an increment of the backup or the chain name of
+// another backup
+type backupSource struct {
+	Epoch      uint64
+	OtherChain string
+}
+
+func (source *backupSource) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &source.OtherChain); err != nil {
+		err = json.Unmarshal(data, &source.Epoch)
+		if err == nil && source.Epoch >= 1 {
+			return nil
 		}
-		epoch, _ := httpServer.config.BlockChain.GetBeaconChainDatabase().LatestBackup(fmt.Sprintf("../../backup/%v", chainName))
-		return struct {
-			LatestEpoch int
-		}{
-			epoch,
-		}, nil
+	} else if source.OtherChain != "" {
+		return nil
 	}
+	return errors.New("expect an epoch from 1 or a chain name")
+}
 
-	return 0, nil
+func (source backupSource) MarshalJSON() ([]byte, error) {
+	if source.OtherChain != "" {
+		return json.Marshal(source.OtherChain)
+	}
+	return json.Marshal(source.Epoch)
 }
 
-func (httpServer *HttpServer) handleDownloadBackup(conn net.Conn, params interface{}) {
-	paramArray, ok := params.([]interface{})
-	if ok && len(paramArray) >= 1 {
-		chainName, ok := paramArray[0].(string)
-		if !ok {
-			return
+// handleDownloadBackup stream a file of a backup on conn, nothing is written when the file can not be read
+func (httpServer *HttpServer) handleDownloadBackup(conn net.Conn, params interface{}) *rpcservice.RPCError {
+	backupParams := downloadBackupParams{}
+	if err := decodeRPCParams(params, &backupParams); err != nil {
+		return err
+	}
+	backupFolder := func(chainName string) string {
+		return fmt.Sprintf("../../backup/%v", chainName)
+	}
+	var fd *os.File
+	var err error
+	switch {
+	case backupParams.Source == nil:
+		_, filepath := httpServer.config.BlockChain.GetBeaconChainDatabase().LatestBackup(backupFolder(backupParams.ChainName))
+		fd, err = os.Open(filepath)
+		if err != nil {
+			fmt.Println(err)
+			return nil
 		}
-		var fd *os.File
-		var err error
-		if len(paramArray) == 1 {
-			_, filepath := httpServer.config.BlockChain.GetBeaconChainDatabase().LatestBackup(fmt.Sprintf("../../backup/%v", chainName))
-			fd, err = os.Open(filepath)
-			if err != nil {
-				fmt.Println(err)
-				return
-			}
-			defer fd.Close()
-		} else if len(paramArray) == 2 {
-			otherChain, ok := paramArray[1].(string)
-			if !ok {
-				return
-			}
-			_, filepath := httpServer.config.BlockChain.GetBeaconChainDatabase().LatestBackup(fmt.Sprintf("../../backup/%v", otherChain))
-			fd, err = os.Open(filepath)
-			if err != nil {
-				fmt.Println(err)
-				return
-			}
-			defer fd.Close()
+		defer fd.Close()
+		// a backup of increments is downloaded file by file
+		if stat, err := fd.Stat(); err != nil || stat.IsDir() {
+			return nil
 		}
-		_, err = conn.Write([]byte("HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\n\r\n"))
+	case backupParams.Source.OtherChain != "":
+		_, filepath := httpServer.config.BlockChain.GetBeaconChainDatabase().LatestBackup(backupFolder(backupParams.Source.OtherChain))
+		fd, err = os.Open(filepath)
 		if err != nil {
-			return
+			fmt.Println(err)
+			return nil
 		}
-		_, err = io.Copy(conn, fd)
+		defer fd.Close()
+	default:
+		// a file of an increment of the backup, the manifest or a database file it lists
+		fileName := backupParams.FileName
+		if fileName == "" || fileName != filepath.Base(fileName) || fileName == "." || fileName == ".." {
+			return rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("file name %+v is invalid", fileName))
+		}
+		latestEpoch, latestPath := httpServer.config.BlockChain.GetBeaconChainDatabase().LatestBackup(backupFolder(backupParams.ChainName))
+		if latestEpoch == 0 {
+			return nil
+		}
+		fd, err = os.Open(filepath.Join(incdb.BackupIncrementPath(filepath.Dir(latestPath), backupParams.Source.Epoch), fileName))
 		if err != nil {
-			return
+			fmt.Println(err)
+			return nil
 		}
+		defer fd.Close()
+	}
+	_, err = conn.Write([]byte("HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\n\r\n"))
+	if err != nil {
+		return nil
 	}
-	return
+	io.Copy(conn, fd)
+	return nil
 }
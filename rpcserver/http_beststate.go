@@ -2,13 +2,11 @@ package rpcserver
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 
 	"github.com/incognitochain/incognito-chain/blockchain"
-	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 )
@@ -66,15 +64,11 @@ func (httpServer *HttpServer) handleGetBeaconBestState(params interface{}, close
 handleGetShardBestState - RPC get shard best state
 */
 func (httpServer *HttpServer) handleGetShardBestState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Shard ID empty"))
+	shardParams := shardIDParams{}
+	if err := decodeRPCParams(params, &shardParams); err != nil {
+		return nil, err
 	}
-	shardIdParam, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Shard ID component invalid"))
-	}
-	shardID := byte(shardIdParam)
+	shardID := byte(shardParams.ShardID)
 
 	shardBestState, err := httpServer.blockService.GetShardBestStateByShardID(shardID)
 	if err != nil {
@@ -142,10 +136,19 @@ func (httpServer *HttpServer) handleGetCandidateList(params interface{}, closeCh
 }
 
 // handleGetCommitteeList - return current committee in network
+// param #1: optional commitment, best, finalized, a beacon block height or a beacon block hash
 func (httpServer *HttpServer) handleGetCommitteeList(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	clonedBeaconBestState, err := httpServer.blockService.GetBeaconBestState()
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.GetClonedBeaconBestStateError, err)
+	committeeParams := getCommitteeListParams{}
+	if err := decodeRPCParams(params, &committeeParams); err != nil {
+		return nil, err
+	}
+	commitment, errC := httpServer.getCommitmentParam(committeeParams.Commitment)
+	if errC != nil {
+		return nil, errC
+	}
+	clonedBeaconBestState, errC := httpServer.blockService.GetBeaconCommittees(commitment)
+	if errC != nil {
+		return nil, errC
 	}
 
 	beaconCommittee := clonedBeaconBestState.BeaconCommittee
@@ -165,36 +168,26 @@ func (httpServer *HttpServer) handleGetCommitteeList(params interface{}, closeCh
 	return #2: error
 */
 func (httpServer *HttpServer) handleCanPubkeyStake(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Shard ID empty"))
+	stakeParams := canPubkeyStakeParams{}
+	if err := decodeRPCParams(params, &stakeParams); err != nil {
+		return nil, err
 	}
 
-	publicKey, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Pub key is invalid"))
-	}
-
-	canStake, err := httpServer.blockService.CanPubkeyStake(publicKey)
+	canStake, err := httpServer.blockService.CanPubkeyStake(stakeParams.PublicKey)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
 
-	result := jsonresult.NewStakeResult(publicKey, canStake)
+	result := jsonresult.NewStakeResult(stakeParams.PublicKey, canStake)
 	return result, nil
 }
 
 func (httpServer *HttpServer) handleGetTotalTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Shard ID empty"))
+	shardParams := shardIDParams{}
+	if err := decodeRPCParams(params, &shardParams); err != nil {
+		return nil, err
 	}
-
-	shardIdParam, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Shard ID invalid"))
-	}
-	shardID := byte(shardIdParam)
+	shardID := byte(shardParams.ShardID)
 
 	clonedShardBestState, err := httpServer.blockService.GetShardBestStateByShardID(shardID)
 	if err != nil {
@@ -220,15 +213,11 @@ func (httpServer *HttpServer) handleGetBeaconBestStateDetail(params interface{},
 handleGetShardBestState - RPC get shard best state
 */
 func (httpServer *HttpServer) handleGetShardBestStateDetail(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Shard ID empty"))
-	}
-	shardIdParam, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Shard ID component invalid"))
+	shardParams := shardIDParams{}
+	if err := decodeRPCParams(params, &shardParams); err != nil {
+		return nil, err
 	}
-	shardID := byte(shardIdParam)
+	shardID := byte(shardParams.ShardID)
 
 	shardBestState, err := httpServer.blockService.GetShardBestStateByShardID(shardID)
 	if err != nil {
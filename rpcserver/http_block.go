@@ -66,111 +66,72 @@ func (httpServer *HttpServer) handleGetBestBlockHash(params interface{}, closeCh
 handleRetrieveBlock RPC return information for block
 */
 func (httpServer *HttpServer) handleRetrieveBlock(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	paramArray, ok := params.([]interface{})
-	if ok && len(paramArray) >= 2 {
-		hashString, ok := paramArray[0].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("hashString is invalid"))
-		}
-		verbosity, ok := paramArray[1].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("verbosity is invalid"))
-		}
+	blockParams := retrieveBlockParams{}
+	if err := decodeRPCParams(params, &blockParams); err != nil {
+		return nil, err
+	}
 
-		result, err := httpServer.blockService.RetrieveShardBlock(hashString, verbosity)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+	result, err := httpServer.blockService.RetrieveShardBlock(blockParams.Hash, blockParams.Verbosity)
+	if err != nil {
+		return nil, err
 	}
-	return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	return result, nil
 }
 
 func (httpServer *HttpServer) handleRetrieveBlockByHeight(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	paramArray, ok := params.([]interface{})
-	if ok && len(paramArray) >= 3 {
-		blockHeight, ok := paramArray[0].(float64)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("hashString is invalid"))
-		}
-		shardID, ok := paramArray[1].(float64)
-		if !ok || shardID < 0 {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("shardID is invalid"))
-		}
-		verbosity, ok := paramArray[2].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("verbosity is invalid"))
-		}
+	blockParams := retrieveBlockByHeightParams{}
+	if err := decodeRPCParams(params, &blockParams); err != nil {
+		return nil, err
+	}
+	if blockParams.ShardID < 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("shardID is invalid"))
+	}
 
-		result, err := httpServer.blockService.RetrieveShardBlockByHeight(uint64(blockHeight), int(shardID), verbosity)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+	result, err := httpServer.blockService.RetrieveShardBlockByHeight(blockParams.Height, blockParams.ShardID, blockParams.Verbosity)
+	if err != nil {
+		return nil, err
 	}
-	return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	return result, nil
 }
 
 /*
 handleRetrieveBlock RPC return information for block
 */
 func (httpServer *HttpServer) handleRetrieveBeaconBlock(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	paramArray, ok := params.([]interface{})
-	if ok && len(paramArray) >= 1 {
-		hashString, ok := paramArray[0].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("hashString is invalid"))
-		}
-		result, err := httpServer.blockService.RetrieveBeaconBlock(hashString)
-		if err != nil {
-			return result, err
-		}
-		return result, nil
+	blockParams := retrieveBeaconBlockParams{}
+	if err := decodeRPCParams(params, &blockParams); err != nil {
+		return nil, err
 	}
-	return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	result, err := httpServer.blockService.RetrieveBeaconBlock(blockParams.Hash)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
 }
 
 /*
 handleRetrieveBlock RPC return information for block
 */
 func (httpServer *HttpServer) handleRetrieveBeaconBlockByHeight(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	paramArray, ok := params.([]interface{})
-	if ok && len(paramArray) >= 1 {
-		beaconHeight, ok := paramArray[0].(float64)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("hashString is invalid"))
-		}
-		result, err := httpServer.blockService.RetrieveBeaconBlockByHeight(uint64(beaconHeight))
-		if err != nil {
-			return result, err
-		}
-		return result, nil
+	blockParams := retrieveBeaconBlockByHeightParams{}
+	if err := decodeRPCParams(params, &blockParams); err != nil {
+		return nil, err
+	}
+	result, err := httpServer.blockService.RetrieveBeaconBlockByHeight(blockParams.Height)
+	if err != nil {
+		return result, err
 	}
-	return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	return result, nil
 }
 
 // handleGetBlocks - get n top blocks from chain ID
 func (httpServer *HttpServer) handleGetBlocks(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 2 {
-		arrayParams = []interface{}{
-			0.0,
-			0.0,
-		}
-	}
-	numBlockTemp, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("numblock is invalid"))
-	}
-	numBlock := int(numBlockTemp)
-
-	shardIDParam, ok := arrayParams[1].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("shardIDParam is invalid"))
+	blocksParams := getBlocksParams{}
+	if err := decodeRPCParams(params, &blocksParams); err != nil {
+		return nil, err
 	}
-	shardID := int(shardIDParam)
 
-	result, err := httpServer.blockService.GetBlocks(shardID, numBlock)
+	result, err := httpServer.blockService.GetBlocks(blocksParams.ShardID, blocksParams.NumBlock)
 	if err != nil {
 		return nil, err
 	}
@@ -206,16 +167,12 @@ func (httpServer *HttpServer) handleGetBlockChainInfo(params interface{}, closeC
 getblockcount RPC return information fo blockchain node
 */
 func (httpServer *HttpServer) handleGetBlockCount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("component empty"))
-	}
-	paramNumberFloat, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Expected get float number component"))
+	countParams := getBlockCountParams{}
+	if err := decodeRPCParams(params, &countParams); err != nil {
+		return nil, err
 	}
-	shardID := byte(int(paramNumberFloat))
-	isGetBeacon := int(paramNumberFloat) == -1
+	shardID := byte(countParams.ShardID)
+	isGetBeacon := countParams.ShardID == -1
 	if isGetBeacon {
 		beacon, err := httpServer.blockService.GetBeaconBestState()
 		if err != nil {
@@ -237,26 +194,12 @@ func (httpServer *HttpServer) handleGetBlockCount(params interface{}, closeChan
 getblockhash RPC return information fo blockchain node
 */
 func (httpServer *HttpServer) handleGetBlockHash(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 2 {
-		arrayParams = []interface{}{
-			0.0,
-			1.0,
-		}
-	}
-	shardIDParam, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("shardID is invalid"))
-	}
-	shardID := int(shardIDParam)
-
-	heightParam, ok := arrayParams[1].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("height is invalid"))
+	hashParams := getBlockHashParams{Height: 1}
+	if err := decodeRPCParams(params, &hashParams); err != nil {
+		return nil, err
 	}
-	height := uint64(heightParam)
 
-	result, err := httpServer.blockService.GetBlockHashByHeightV2(shardID, height)
+	result, err := httpServer.blockService.GetBlockHashByHeightV2(hashParams.ShardID, hashParams.Height)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetShardBlockByHeightError, err)
 	}
@@ -265,31 +208,18 @@ func (httpServer *HttpServer) handleGetBlockHash(params interface{}, closeChan <
 
 // handleGetBlockHeader - return block header data
 func (httpServer *HttpServer) handleGetBlockHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 3 {
-		arrayParams = []interface{}{"", "", 0.0}
-	}
-	getBy, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("getBy is invalid"))
-	}
-	block, ok := arrayParams[1].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("block is invalid"))
-	}
-	shardID, ok := arrayParams[2].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("shardID is invalid"))
+	headerParams := getBlockHeaderParams{}
+	if err := decodeRPCParams(params, &headerParams); err != nil {
+		return nil, err
 	}
 
-	blockHeaders, blockNumber, blockHashes, err := httpServer.blockService.GetShardBlockHeader(getBy, block, shardID)
+	blockHeaders, blockNumber, blockHashes, err := httpServer.blockService.GetShardBlockHeader(headerParams.GetBy, headerParams.Block, float64(headerParams.ShardID))
 	if err != nil {
 		return nil, err
 	}
 	result := []jsonresult.GetHeaderResult{}
 	for i, blockHeader := range blockHeaders {
-		res := jsonresult.NewHeaderResult(*blockHeader, blockNumber, blockHashes[i], byte(shardID))
+		res := jsonresult.NewHeaderResult(*blockHeader, blockNumber, blockHashes[i], byte(headerParams.ShardID))
 		result = append(result, res)
 	}
 	return result, nil
@@ -297,24 +227,11 @@ func (httpServer *HttpServer) handleGetBlockHeader(params interface{}, closeChan
 
 //This function return the result of cross shard block of a specific block in shard
 func (httpServer *HttpServer) handleGetCrossShardBlock(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	// Logger.log.Info(arrayParams)
-	if arrayParams == nil || len(arrayParams) != 2 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("wrong request format"))
-	}
-	// #param1: shardID
-	shardIDParam, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("shardID is invalid"))
-	}
-	shardID := int(shardIDParam)
-	// #param2: shard block height
-	blockHeightParam, ok := arrayParams[1].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("blockHeight is invalid"))
+	crossShardParams := getCrossShardBlockParams{}
+	if err := decodeRPCParams(params, &crossShardParams); err != nil {
+		return nil, err
 	}
-	blockHeight := uint64(blockHeightParam)
-	shardBlocks, err := httpServer.config.BlockChain.GetShardBlockByHeight(blockHeight, byte(shardID))
+	shardBlocks, err := httpServer.config.BlockChain.GetShardBlockByHeight(crossShardParams.Height, byte(crossShardParams.ShardID))
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetShardBlockByHeightError, err)
 	}
@@ -3,7 +3,6 @@ package rpcserver
 import (
 	"encoding/json"
 
-	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/rpcserver/bean"
@@ -32,44 +31,16 @@ func (httpServer *HttpServer) handleCreateAndSendIssuingRequest(params interface
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithContractingReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	contractingParams := createContractingTxParams{}
+	if err := decodeRPCParams(params, &contractingParams); err != nil {
+		return nil, err
 	}
-
 	// check privacy mode param
-	if len(arrayParams) > 6 {
-		privacyTemp, ok := arrayParams[6].(float64)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be valid"))
-		}
-		hasPrivacyToken := int(privacyTemp) > 0
-		if hasPrivacyToken {
-			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
-		}
-	}
-
-	senderPrivateKeyParam, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("private key is invalid"))
-	}
-
-	tokenParamsRaw, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token param is invalid"))
-	}
-
-	tokenReceivers, ok := tokenParamsRaw["TokenReceivers"].(interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token receivers is invalid"))
+	if contractingParams.HasPrivacyToken > 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
 	}
 
-	tokenID, ok := tokenParamsRaw["TokenID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token ID is invalid"))
-	}
-
-	meta, err := rpcservice.NewContractingRequestMetadata(senderPrivateKeyParam, tokenReceivers, tokenID)
+	meta, err := rpcservice.NewContractingRequestMetadata(contractingParams.PrivateKey, contractingParams.TokenParams.TokenReceivers, contractingParams.TokenParams.TokenID)
 	if err != nil {
 		return nil, err
 	}
@@ -140,17 +111,11 @@ func (httpServer *HttpServer) handleCreateAndSendBurningRequest(params interface
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithIssuingETHReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
-	}
-
-	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	issuingParams := createTxWithMetadataParams{}
+	if err := decodeRPCParams(params, &issuingParams); err != nil {
+		return nil, err
 	}
-	meta, err := metadata.NewIssuingETHRequestFromMap(data)
+	meta, err := metadata.NewIssuingETHRequestFromMap(issuingParams.Metadata)
 	if err != nil {
 		rpcErr := rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 		Logger.log.Error(rpcErr)
@@ -199,13 +164,12 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithIssuingETHReq(params inte
 }
 
 func (httpServer *HttpServer) handleCheckETHHashIssued(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	issuedParams := checkETHHashIssuedParams{}
+	if err := decodeRPCParams(params, &issuedParams); err != nil {
+		return nil, err
 	}
-	data := arrayParams[0].(map[string]interface{})
 
-	issued, err := httpServer.blockService.CheckETHHashIssued(data)
+	issued, err := httpServer.blockService.CheckETHHashIssued(issuedParams.Data.BlockHash, issuedParams.Data.TxIndex)
 	if err != nil {
 		return false, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -221,13 +185,12 @@ func (httpServer *HttpServer) handleGetAllBridgeTokens(params interface{}, close
 }
 
 func (httpServer *HttpServer) handleGetETHHeaderByHash(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	headerParams := ethBlockHashParams{}
+	if err := decodeRPCParams(params, &headerParams); err != nil {
+		return nil, err
 	}
-	ethBlockHash := arrayParams[0].(string)
 
-	ethHeader, err := rpcservice.GetETHHeaderByHash(ethBlockHash)
+	ethHeader, err := rpcservice.GetETHHeaderByHash(headerParams.ETHBlockHash)
 	if err != nil {
 		return false, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -235,13 +198,12 @@ func (httpServer *HttpServer) handleGetETHHeaderByHash(params interface{}, close
 }
 
 func (httpServer *HttpServer) handleGetBridgeReqWithStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	statusParams := getBridgeReqWithStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
-	data := arrayParams[0].(map[string]interface{})
 
-	status, err := httpServer.blockService.GetBridgeReqWithStatus(data["TxReqID"].(string))
+	status, err := httpServer.blockService.GetBridgeReqWithStatus(statusParams.Data.TxReqID)
 	if err != nil {
 		return false, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -254,53 +216,16 @@ func processBurningReq(
 	closeChan <-chan struct{},
 	httpServer *HttpServer,
 ) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
-	}
-
-	if len(arrayParams) > 6 {
-		privacyTemp, ok := arrayParams[6].(float64)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("The privacy mode must be valid "))
-		}
-		hasPrivacyToken := int(privacyTemp) > 0
-		if hasPrivacyToken {
-			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
-		}
-	}
-
-	senderPrivateKeyParam, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("private key is invalid"))
-	}
-
-	tokenParamsRaw, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token param is invalid"))
-	}
-
-	tokenReceivers, ok := tokenParamsRaw["TokenReceivers"].(interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token receivers is invalid"))
-	}
-
-	tokenID, ok := tokenParamsRaw["TokenID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token ID is invalid"))
-	}
-
-	tokenName, ok := tokenParamsRaw["TokenName"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token name is invalid"))
+	burningParams := createBurningTxParams{}
+	if err := decodeRPCParams(params, &burningParams); err != nil {
+		return nil, err
 	}
-
-	remoteAddress, ok := tokenParamsRaw["RemoteAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("remote address is invalid"))
+	if burningParams.HasPrivacyToken > 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
 	}
 
-	meta, err := rpcservice.NewBurningRequestMetadata(senderPrivateKeyParam, tokenReceivers, tokenID, tokenName, remoteAddress, burningMetaType, httpServer.GetBlockchain(), httpServer.GetBlockchain().BeaconChain.CurrentHeight())
+	tokenParams := burningParams.TokenParams
+	meta, err := rpcservice.NewBurningRequestMetadata(burningParams.PrivateKey, tokenParams.TokenReceivers, tokenParams.TokenID, tokenParams.TokenName, tokenParams.RemoteAddress, burningMetaType, httpServer.GetBlockchain(), httpServer.GetBlockchain().BeaconChain.CurrentHeight())
 	if err != nil {
 		return nil, err
 	}
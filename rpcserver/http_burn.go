@@ -9,7 +9,6 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
-	"github.com/pkg/errors"
 )
 
 // handleGetBurnProof returns a proof of a tx burning pETH
@@ -34,17 +33,12 @@ func retrieveBurnProof(
 	burningMetaType int,
 	httpServer *HttpServer,
 ) (interface{}, *rpcservice.RPCError) {
-	listParams, ok := params.([]interface{})
-	if !ok || len(listParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
-	}
-
-	txIDParam, ok := listParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Tx id invalid"))
+	proofParams := txHashParams{}
+	if err := decodeRPCParams(params, &proofParams); err != nil {
+		return nil, err
 	}
 
-	txID, err := common.Hash{}.NewHashFromStr(txIDParam)
+	txID, err := common.Hash{}.NewHashFromStr(proofParams.TxHash)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
@@ -188,20 +182,12 @@ func splitAndDecodeInst(bridgeInst, beaconInst []string) (string, string, string
 
 // handleGetBurnProof returns a proof of a tx burning pETH
 func (httpServer *HttpServer) handleGetBurningAddress(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	listParams, ok := params.([]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array"))
-	}
-
-	beaconHeightParam := float64(0)
-	if len(listParams) >= 1 {
-		beaconHeightParam, ok = listParams[0].(float64)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("beacon height is invalid"))
-		}
+	addressParams := getBurningAddressParams{}
+	if err := decodeRPCParams(params, &addressParams); err != nil {
+		return nil, err
 	}
 
-	burningAddress := httpServer.blockService.GetBurningAddress(uint64(beaconHeightParam))
+	burningAddress := httpServer.blockService.GetBurningAddress(addressParams.BeaconHeight)
 
 	return burningAddress, nil
 }
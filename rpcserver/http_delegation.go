@@ -0,0 +1,147 @@
+package rpcserver
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/bean"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// handleCreateRawDelegationTransaction - RPC create delegate, undelegate or redelegate tx, the sender is the delegator
+func (httpServer *HttpServer) handleCreateRawDelegationTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	delegationParams := createDelegationTxParams{}
+	if err := decodeRPCParams(params, &delegationParams); err != nil {
+		return nil, err
+	}
+
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	keyWallet := new(wallet.KeyWallet)
+	keyWallet.KeySet = *createRawTxParam.SenderKeySet
+	delegatorPaymentAddress := keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+
+	data := delegationParams.Metadata
+	if data.Amount == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid Amount %+v", data.Amount))
+	}
+
+	delegationMetadata, err := metadata.NewDelegationRequest(data.DelegationType, delegatorPaymentAddress, data.CommitteePublicKey, data.NewCommitteePublicKey, data.Amount)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	txID, txBytes, txShardID, err := httpServer.txService.CreateRawTransaction(createRawTxParam, delegationMetadata)
+	if err.(*rpcservice.RPCError) != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.CreateTxDataError, err)
+	}
+
+	result := jsonresult.CreateTransactionResult{
+		TxID:            txID.String(),
+		Base58CheckData: base58.Base58Check{}.Encode(txBytes, common.ZeroByte),
+		ShardID:         txShardID,
+	}
+	return result, nil
+}
+
+// handleCreateAndSendDelegationTransaction - RPC create and send delegate, undelegate or redelegate tx to network
+func (httpServer *HttpServer) handleCreateAndSendDelegationTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	var err error
+	data, err := httpServer.handleCreateRawDelegationTransaction(params, closeChan)
+	if err.(*rpcservice.RPCError) != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.CreateTxDataError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err.(*rpcservice.RPCError) != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.SendTxDataError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, tx.ShardID)
+	return result, nil
+}
+
+// handleCreateRawDelegationCommissionTransaction - RPC create a tx setting the commission of a validator, the sender
+// is the sender of its staking tx
+func (httpServer *HttpServer) handleCreateRawDelegationCommissionTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	commissionParams := createDelegationCommissionTxParams{}
+	if err := decodeRPCParams(params, &commissionParams); err != nil {
+		return nil, err
+	}
+
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	data := commissionParams.Metadata
+	if data.CommissionPercent > 100 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid Commission Percent %+v", data.CommissionPercent))
+	}
+
+	commissionMetadata := metadata.NewDelegationCommissionRequest(data.CommitteePublicKey, data.CommissionPercent)
+	txID, txBytes, txShardID, err := httpServer.txService.CreateRawTransaction(createRawTxParam, commissionMetadata)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.CreateTxDataError, err)
+	}
+
+	result := jsonresult.CreateTransactionResult{
+		TxID:            txID.String(),
+		Base58CheckData: base58.Base58Check{}.Encode(txBytes, common.ZeroByte),
+		ShardID:         txShardID,
+	}
+	return result, nil
+}
+
+// handleCreateAndSendDelegationCommissionTransaction - RPC create and send a tx setting the commission of a validator
+func (httpServer *HttpServer) handleCreateAndSendDelegationCommissionTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	var err error
+	data, err := httpServer.handleCreateRawDelegationCommissionTransaction(params, closeChan)
+	if err.(*rpcservice.RPCError) != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.CreateTxDataError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err.(*rpcservice.RPCError) != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.SendTxDataError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, tx.ShardID)
+	return result, nil
+}
+
+// handleGetDelegations - RPC get delegations to a validator committee public key at the beacon best state
+func (httpServer *HttpServer) handleGetDelegations(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	delegationsParams := getDelegationsParams{}
+	if err := decodeRPCParams(params, &delegationsParams); err != nil {
+		return nil, err
+	}
+	committeePublicKey := delegationsParams.CommitteePublicKey
+	if committeePublicKey == "" {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Committee Public Key invalid"))
+	}
+	featureStateDB := httpServer.config.BlockChain.GetBeaconBestState().GetBeaconFeatureStateDB()
+	delegations := statedb.GetDelegationsByValidator(featureStateDB, committeePublicKey)
+	commissionPercent, has, err := statedb.GetDelegationCommission(featureStateDB, committeePublicKey)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	if !has {
+		commissionPercent = httpServer.config.ChainParams.DelegationCommissionPercent
+	}
+	return jsonresult.NewGetDelegationsResult(committeePublicKey, delegations, commissionPercent), nil
+}
@@ -1,8 +1,6 @@
 package rpcserver
 
 import (
-	"errors"
-
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
@@ -13,31 +11,13 @@ import (
 handleEstimateFee - RPC estimates the transaction fee per kilobyte that needs to be paid for a transaction to be included within a certain number of blocks.
 */
 func (httpServer *HttpServer) handleEstimateFee(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	/******* START Fetch all component to ******/
-	// all component
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 4 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Not enough params"))
-	}
-	// param #1: private key of sender
-	senderKeyParam, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Sender private key is invalid"))
-	}
-	// param #3: estimation fee coin per kb
-	defaultFeeCoinPerKbtemp, ok := arrayParams[2].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Default FeeCoinPerKbtemp is invalid"))
-	}
-	defaultFeeCoinPerKb := int64(defaultFeeCoinPerKbtemp)
-	// param #4: hasPrivacy flag for PRV
-	hashPrivacyTemp, ok := arrayParams[3].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("hasPrivacy is invalid"))
+	feeParams := estimateFeeParams{}
+	if err := decodeRPCParams(params, &feeParams); err != nil {
+		return nil, err
 	}
-	hasPrivacy := int(hashPrivacyTemp) > 0
+	hasPrivacy := feeParams.Privacy > 0
 
-	senderKeySet, shardIDSender, err := rpcservice.GetKeySetFromPrivateKeyParams(senderKeyParam)
+	senderKeySet, shardIDSender, err := rpcservice.GetKeySetFromPrivateKeyParams(feeParams.PrivateKey)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.InvalidSenderPrivateKeyError, err)
 	}
@@ -56,13 +36,9 @@ func (httpServer *HttpServer) handleEstimateFee(params interface{}, closeChan <-
 	estimateFeeCoinPerKb := uint64(0)
 	estimateTxSizeInKb := uint64(0)
 	if len(outCoins) > 0 {
-		// param #2: list receiver
 		receiversPaymentAddressStrParam := make(map[string]interface{})
-		if arrayParams[1] != nil {
-			receiversPaymentAddressStrParam, ok = arrayParams[1].(map[string]interface{})
-			if !ok {
-				return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("receivers payment address is invalid"))
-			}
+		if feeParams.Receivers != nil {
+			receiversPaymentAddressStrParam = feeParams.Receivers
 		}
 
 		paymentInfos, err := rpcservice.NewPaymentInfosFromReceiversParam(receiversPaymentAddressStrParam)
@@ -73,14 +49,8 @@ func (httpServer *HttpServer) handleEstimateFee(params interface{}, closeChan <-
 		// Check custom token param
 		var customPrivacyTokenParam *transaction.CustomTokenPrivacyParamTx
 		isGetPTokenFee := false
-		if len(arrayParams) > 4 {
-			// param #5: token params
-			tokenParamsRaw, ok := arrayParams[4].(map[string]interface{})
-			if !ok {
-				return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token param is invalid"))
-			}
-
-			customPrivacyTokenParam, err = httpServer.txService.BuildTokenParam(tokenParamsRaw, senderKeySet, shardIDSender)
+		if feeParams.TokenParams != nil {
+			customPrivacyTokenParam, err = httpServer.txService.BuildTokenParam(feeParams.TokenParams, senderKeySet, shardIDSender)
 			if err.(*rpcservice.RPCError) != nil {
 				return nil, err.(*rpcservice.RPCError)
 			}
@@ -90,7 +60,7 @@ func (httpServer *HttpServer) handleEstimateFee(params interface{}, closeChan <-
 
 		var err2 error
 		_, estimateFeeCoinPerKb, estimateTxSizeInKb, err2 = httpServer.txService.EstimateFee(
-			defaultFeeCoinPerKb, isGetPTokenFee, outCoins, paymentInfos, shardIDSender, 8, hasPrivacy, nil, customPrivacyTokenParam, int64(beaconHeight))
+			feeParams.Fee, isGetPTokenFee, outCoins, paymentInfos, shardIDSender, 8, hasPrivacy, nil, customPrivacyTokenParam, int64(beaconHeight), 0, false)
 		if err2 != nil {
 			return nil, rpcservice.NewRPCError(rpcservice.RejectInvalidTxFeeError, err2)
 		}
@@ -101,48 +71,20 @@ func (httpServer *HttpServer) handleEstimateFee(params interface{}, closeChan <-
 
 // handleEstimateFeeWithEstimator -- get fee from estimator
 func (httpServer *HttpServer) handleEstimateFeeWithEstimator(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	// all params
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 2 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Not enough params"))
+	estimatorParams := estimateFeeWithEstimatorParams{NumBlock: 8}
+	if err := decodeRPCParams(params, &estimatorParams); err != nil {
+		return nil, err
 	}
-	// param #1: estimation fee coin per kb from client
-	defaultFeeCoinPerKbTemp, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("defaultFeeCoinPerKbTemp is invalid"))
-	}
-	defaultFeeCoinPerKb := int64(defaultFeeCoinPerKbTemp)
-
-	// param #2: payment address
-	paymentAddressParam, ok := arrayParams[1].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("sender key param is invalid"))
-	}
-	_, shardIDSender, err := rpcservice.GetKeySetFromPaymentAddressParam(paymentAddressParam)
+	_, shardIDSender, err := rpcservice.GetKeySetFromPaymentAddressParam(estimatorParams.PaymentAddress)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.InvalidSenderPrivateKeyError, err)
 	}
 
-	// param #2: numbloc
-	numblock := uint64(8)
-	if len(arrayParams) >= 3 {
-		numBlockParam, ok := arrayParams[2].(float64)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("num block param is invalid"))
-		}
-		numblock = uint64(numBlockParam)
-	}
-
-	// param #3: tokenId
 	// if tokenID != nil, return fee for privacy token
-	// if tokenID != nil, return fee for native token
+	// if tokenID == nil, return fee for native token
 	var tokenId *common.Hash
-	if len(arrayParams) >= 4 && arrayParams[3] != nil {
-		tokenIdParam, ok := arrayParams[3].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token id param is invalid"))
-		}
-		tokenId, err = common.Hash{}.NewHashFromStr(tokenIdParam)
+	if estimatorParams.TokenID != "" {
+		tokenId, err = common.Hash{}.NewHashFromStr(estimatorParams.TokenID)
 		if err != nil {
 			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 		}
@@ -150,7 +92,7 @@ func (httpServer *HttpServer) handleEstimateFeeWithEstimator(params interface{},
 
 	beaconHeight := httpServer.blockService.BlockChain.GetBeaconBestState().BestBlock.GetHeight()
 
-	estimateFeeCoinPerKb, err := httpServer.txService.EstimateFeeWithEstimator(defaultFeeCoinPerKb, shardIDSender, numblock, tokenId, int64(beaconHeight))
+	estimateFeeCoinPerKb, err := httpServer.txService.EstimateFeeWithEstimator(estimatorParams.Fee, shardIDSender, estimatorParams.NumBlock, tokenId, int64(beaconHeight))
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
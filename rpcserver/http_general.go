@@ -1,7 +1,6 @@
 package rpcserver
 
 import (
-	"errors"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
@@ -11,12 +10,12 @@ import (
 handleGetInOutPeerMessageCount - return all inbound/outbound message count by peer which this node connected
 */
 func (httpServer *HttpServer) handleGetInOutMessageCount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	paramsArray := common.InterfaceSlice(params)
-	if paramsArray == nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, nil)
+	peerParams := peerIDParams{}
+	if err := decodeRPCParams(params, &peerParams); err != nil {
+		return nil, err
 	}
 
-	result, err := jsonresult.NewGetInOutMessageCountResult(paramsArray)
+	result, err := jsonresult.NewGetInOutMessageCountResult(peerParams.PeerID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -27,12 +26,12 @@ func (httpServer *HttpServer) handleGetInOutMessageCount(params interface{}, clo
 handleGetInOutPeerMessages - return all inbound/outbound messages peer which this node connected
 */
 func (httpServer *HttpServer) handleGetInOutMessages(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	paramsArray := common.InterfaceSlice(params)
-	if paramsArray == nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, nil)
+	peerParams := peerIDParams{}
+	if err := decodeRPCParams(params, &peerParams); err != nil {
+		return nil, err
 	}
 
-	result, err := jsonresult.NewGetInOutMessageResult(paramsArray)
+	result, err := jsonresult.NewGetInOutMessageResult(peerParams.PeerID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -68,17 +67,13 @@ func (httpServer *HttpServer) handleGetNetWorkInfo(params interface{}, closeChan
 }
 
 func (httpServer *HttpServer) handleCheckHashValue(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) == 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Expected array component"))
-	}
-	hashParams, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Expected hash string value"))
-	}
 	// param #1: transaction Hash
+	hashParams := checkHashValueParams{}
+	if err := decodeRPCParams(params, &hashParams); err != nil {
+		return nil, err
+	}
 
-	isTransaction, isShardBlock, isBeaconBlock, err := httpServer.blockService.CheckHashValue(hashParams)
+	isTransaction, isShardBlock, isBeaconBlock, err := httpServer.blockService.CheckHashValue(hashParams.Hash)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
@@ -112,28 +107,22 @@ func (httpServer *HttpServer) handleGetMaxShardsNumber(params interface{}, close
 }
 
 func (httpServer *HttpServer) handleGetStakingAmount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) <= 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ErrRPCInvalidParams"))
-	}
-
-	stakingTypeParam, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("staking type is invalid"))
+	stakingParams := getStakingAmountParams{}
+	if err := decodeRPCParams(params, &stakingParams); err != nil {
+		return nil, err
 	}
-	stakingType := int(stakingTypeParam)
 
-	amount := rpcservice.GetStakingAmount(stakingType, httpServer.config.ChainParams.StakingAmountShard)
+	amount := rpcservice.GetStakingAmount(stakingParams.StakingType, httpServer.config.ChainParams.StakingAmountShard)
 	return amount, nil
 }
 
 func (httpServer *HttpServer) handleHashToIdenticon(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ErrRPCInvalidParams"))
+	identiconParams := hashToIdenticonParams{}
+	if err := decodeRPCParams(params, &identiconParams); err != nil {
+		return nil, err
 	}
 
-	result, err := rpcservice.HashToIdenticon(arrayParams)
+	result, err := rpcservice.HashToIdenticon(identiconParams.Hashes)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
@@ -147,22 +136,12 @@ func (httpServer *HttpServer) handleGetPublicKeyMining(params interface{}, close
 }
 
 func (httpServer *HttpServer) handleGenerateTokenID(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 2 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
-	}
-
-	network, ok := arrayParams[0].(string)
-	if !ok {
-		rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("network invalid"))
-	}
-
-	tokenName, ok := arrayParams[1].(string)
-	if !ok {
-		rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token name invalid"))
+	tokenParams := generateTokenIDParams{}
+	if err := decodeRPCParams(params, &tokenParams); err != nil {
+		return nil, err
 	}
 
-	tokenID, err := rpcservice.GenerateTokenID(network, tokenName)
+	tokenID, err := rpcservice.GenerateTokenID(tokenParams.Network, tokenParams.TokenName)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	} else {
@@ -0,0 +1,229 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/bean"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// newHTLCLockRequestFromParams builds an htlc lock request of the sender to the receiver, the hashlock and the timeout
+func newHTLCLockRequestFromParams(senderKeySet *incognitokey.KeySet, receiverAddressStr string, hashLock string, timeoutBeaconHeight uint64, tokenIDStr string, amount uint64) (*metadata.HTLCLockRequest, error) {
+	if timeoutBeaconHeight == 0 {
+		return nil, fmt.Errorf("Invalid Timeout Beacon Height %+v", timeoutBeaconHeight)
+	}
+	if amount == 0 {
+		return nil, errors.New("Amount should be larger than 0")
+	}
+	keyWallet := new(wallet.KeyWallet)
+	keyWallet.KeySet = *senderKeySet
+	senderAddressStr := keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+	return metadata.NewHTLCLockRequest(senderAddressStr, receiverAddressStr, tokenIDStr, amount, hashLock, timeoutBeaconHeight), nil
+}
+
+// handleCreateRawTxWithHTLCLock - RPC create a tx locking PRV to a hashlock, the sender is the signer
+func (httpServer *HttpServer) handleCreateRawTxWithHTLCLock(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	lockParams := createHTLCLockTxParams{}
+	if err := decodeRPCParams(params, &lockParams); err != nil {
+		return nil, err
+	}
+
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	data := lockParams.Metadata
+	lockMetadata, err := newHTLCLockRequestFromParams(createRawTxParam.SenderKeySet, data.ReceiverAddressStr, data.HashLock, data.TimeoutBeaconHeight, common.PRVCoinID.String(), data.Amount)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	txID, txBytes, txShardID, err := httpServer.txService.CreateRawTransaction(createRawTxParam, lockMetadata)
+	if err.(*rpcservice.RPCError) != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.CreateTxDataError, err)
+	}
+
+	result := jsonresult.CreateTransactionResult{
+		TxID:            txID.String(),
+		Base58CheckData: base58.Base58Check{}.Encode(txBytes, common.ZeroByte),
+		ShardID:         txShardID,
+	}
+	return result, nil
+}
+
+// handleCreateAndSendTxWithHTLCLock - RPC create and send a tx locking PRV to a hashlock to network
+func (httpServer *HttpServer) handleCreateAndSendTxWithHTLCLock(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithHTLCLock(params, closeChan)
+	if err != nil {
+		return nil, err
+	}
+	result, err := httpServer.sendHTLCTransaction(data.(jsonresult.CreateTransactionResult), closeChan)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// handleCreateRawTxWithPTokenHTLCLock - RPC create a tx locking TokenAmount of the token of tokenParams to a hashlock,
+// the htlc fields are read in tokenParams too
+func (httpServer *HttpServer) handleCreateRawTxWithPTokenHTLCLock(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	lockParams := createPTokenHTLCLockTxParams{}
+	if err := decodeRPCParams(params, &lockParams); err != nil {
+		return nil, err
+	}
+	txParam, errParam := bean.NewCreateRawPrivacyTokenTxParam(params)
+	if errParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errParam)
+	}
+	tokenParams := lockParams.TokenParams
+	if tokenParams.TokenID == "" {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Token ID"))
+	}
+	lockMetadata, err := newHTLCLockRequestFromParams(txParam.SenderKeySet, tokenParams.ReceiverAddressStr, tokenParams.HashLock, tokenParams.TimeoutBeaconHeight, tokenParams.TokenID, tokenParams.TokenAmount)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	customTokenTx, rpcErr := httpServer.txService.BuildRawPrivacyCustomTokenTransaction(params, lockMetadata)
+	if rpcErr != nil {
+		Logger.log.Error(rpcErr)
+		return nil, rpcErr
+	}
+
+	byteArrays, err := json.Marshal(customTokenTx)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            customTokenTx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
+}
+
+// handleCreateAndSendTxWithPTokenHTLCLock - RPC create and send a tx locking a token to a hashlock to network
+func (httpServer *HttpServer) handleCreateAndSendTxWithPTokenHTLCLock(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithPTokenHTLCLock(params, closeChan)
+	if err != nil {
+		return nil, err
+	}
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, data.(jsonresult.CreateTransactionResult).Base58CheckData)
+	sendResult, err := httpServer.handleSendRawPrivacyCustomTokenTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.SendTxDataError, err)
+	}
+	return sendResult, nil
+}
+
+// createRawTxWithHTLCSettle - create a claim or refund tx of the htlc of metadata LockTxID, anyone may send it
+func (httpServer *HttpServer) createRawTxWithHTLCSettle(metaType int, params interface{}) (interface{}, *rpcservice.RPCError) {
+	settleParams := createHTLCSettleTxParams{}
+	if err := decodeRPCParams(params, &settleParams); err != nil {
+		return nil, err
+	}
+
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	data := settleParams.Metadata
+	lockTxID, err := common.Hash{}.NewHashFromStr(data.LockTxID)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	preimage := ""
+	if metaType == metadata.HTLCClaimRequestMeta {
+		if data.Preimage == "" {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Preimage"))
+		}
+		preimage = data.Preimage
+	}
+	settleMetadata, err := metadata.NewHTLCSettleRequest(metaType, *lockTxID, preimage)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	txID, txBytes, txShardID, err := httpServer.txService.CreateRawTransaction(createRawTxParam, settleMetadata)
+	if err.(*rpcservice.RPCError) != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.CreateTxDataError, err)
+	}
+
+	result := jsonresult.CreateTransactionResult{
+		TxID:            txID.String(),
+		Base58CheckData: base58.Base58Check{}.Encode(txBytes, common.ZeroByte),
+		ShardID:         txShardID,
+	}
+	return result, nil
+}
+
+// handleCreateAndSendTxWithHTLCClaim - RPC create and send a tx claiming an htlc with the preimage of its hashlock,
+// the htlc is paid out to its receiver
+func (httpServer *HttpServer) handleCreateAndSendTxWithHTLCClaim(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.createRawTxWithHTLCSettle(metadata.HTLCClaimRequestMeta, params)
+	if err != nil {
+		return nil, err
+	}
+	result, err := httpServer.sendHTLCTransaction(data.(jsonresult.CreateTransactionResult), closeChan)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// handleCreateAndSendTxWithHTLCRefund - RPC create and send a tx refunding an htlc past its timeout, the htlc is paid
+// out to its sender
+func (httpServer *HttpServer) handleCreateAndSendTxWithHTLCRefund(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.createRawTxWithHTLCSettle(metadata.HTLCRefundRequestMeta, params)
+	if err != nil {
+		return nil, err
+	}
+	result, err := httpServer.sendHTLCTransaction(data.(jsonresult.CreateTransactionResult), closeChan)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) sendHTLCTransaction(tx jsonresult.CreateTransactionResult, closeChan <-chan struct{}) (jsonresult.CreateTransactionResult, *rpcservice.RPCError) {
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, tx.Base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return jsonresult.CreateTransactionResult{}, rpcservice.NewRPCError(rpcservice.SendTxDataError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
+
+// handleGetHTLC - RPC get the htlc of a lock tx at the beacon best state, with its preimage once claimed
+func (httpServer *HttpServer) handleGetHTLC(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	htlcParams := getHTLCParams{}
+	if err := decodeRPCParams(params, &htlcParams); err != nil {
+		return nil, err
+	}
+	lockTxIDStr := htlcParams.LockTxID
+	lockTxID, err := common.Hash{}.NewHashFromStr(lockTxIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	featureStateDB := httpServer.config.BlockChain.GetBeaconBestState().GetBeaconFeatureStateDB()
+	htlc, has, err := statedb.GetHTLC(featureStateDB, *lockTxID)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetHTLCError, err)
+	}
+	if !has {
+		return nil, rpcservice.NewRPCError(rpcservice.GetHTLCError, fmt.Errorf("no htlc of lock tx %+v", lockTxIDStr))
+	}
+	return jsonresult.NewGetHTLCResult(htlc), nil
+}
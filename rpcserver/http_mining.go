@@ -22,22 +22,13 @@ func (httpServer *HttpServer) handleGetMiningInfo(params interface{}, closeChan
 }
 
 func (httpServer *HttpServer) handleEnableMining(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 2 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 element"))
-	}
-
-	enableParam, ok := arrayParams[0].(bool)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("EnableParam component invalid"))
+	miningParams := enableMiningParams{}
+	if err := decodeRPCParams(params, &miningParams); err != nil {
+		return nil, err
 	}
 
 	// validator by validator key
-	validatorKeyParam, ok := arrayParams[1].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Validator key component invalid"))
-	}
-	seed, _, err := base58.Base58Check{}.Decode(validatorKeyParam)
+	seed, _, err := base58.Base58Check{}.Decode(miningParams.ValidatorKey)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Validator key component invalid"))
 	}
@@ -51,33 +42,24 @@ func (httpServer *HttpServer) handleEnableMining(params interface{}, closeChan <
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Validator key component invalid"))
 	}
 
-	return httpServer.config.Server.EnableMining(enableParam), nil
+	return httpServer.config.Server.EnableMining(miningParams.Enable), nil
 }
 
 func (httpServer *HttpServer) handleGetChainMiningStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Chain ID empty"))
+	chainParams := getChainMiningStatusParams{}
+	if err := decodeRPCParams(params, &chainParams); err != nil {
+		return nil, err
 	}
-
-	chainIDParam, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Chain ID component invalid"))
-	}
-	return httpServer.config.Server.GetChainMiningStatus(int(chainIDParam)), nil
+	return httpServer.config.Server.GetChainMiningStatus(chainParams.ChainID), nil
 }
 
 // handleGetPublicKeyRole - from bls consensus public key and get role in network
 func (httpServer *HttpServer) handleGetPublicKeyRole(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
-	}
-
-	keyParam, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("key param is invalid"))
+	roleParams := miningKeyParams{}
+	if err := decodeRPCParams(params, &roleParams); err != nil {
+		return nil, err
 	}
+	keyParam := roleParams.MiningKey
 
 	keyParts := strings.Split(keyParam, ":")
 	if len(keyParts) != 2 {
@@ -104,15 +86,11 @@ func (httpServer *HttpServer) handleGetPublicKeyRole(params interface{}, closeCh
 
 // handleGetValidatorKeyRole - get validator key, convert to bls consensus public key and get role in network
 func (httpServer *HttpServer) handleGetValidatorKeyRole(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
-	}
-
-	keyParam, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("key param is invalid"))
+	roleParams := validatorKeyParams{}
+	if err := decodeRPCParams(params, &roleParams); err != nil {
+		return nil, err
 	}
+	keyParam := roleParams.ValidatorKey
 
 	privateSeedBytes, _, err := base58.Base58Check{}.Decode(keyParam)
 	if err != nil {
@@ -130,15 +108,11 @@ func (httpServer *HttpServer) handleGetValidatorKeyRole(params interface{}, clos
 }
 
 func (httpServer *HttpServer) handleGetIncognitoPublicKeyRole(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
-	}
-
-	keyParam, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("key param is invalid"))
+	roleParams := incognitoPublicKeyParams{}
+	if err := decodeRPCParams(params, &roleParams); err != nil {
+		return nil, err
 	}
+	keyParam := roleParams.PublicKey
 
 	role, isBeacon, shardID := httpServer.config.Server.GetIncognitoPublicKeyRole(keyParam)
 	if role == -2 {
@@ -158,15 +132,11 @@ func (httpServer *HttpServer) handleGetIncognitoPublicKeyRole(params interface{}
 }
 
 func (httpServer *HttpServer) handleGetMinerRewardFromMiningKey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param empty"))
-	}
-
-	keyParam, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("key param is invalid"))
+	rewardParams := miningKeyParams{}
+	if err := decodeRPCParams(params, &rewardParams); err != nil {
+		return nil, err
 	}
+	keyParam := rewardParams.MiningKey
 
 	keyParts := strings.Split(keyParam, ":")
 	if len(keyParts) != 2 {
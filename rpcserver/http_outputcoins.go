@@ -3,7 +3,6 @@ package rpcserver
 import (
 	"errors"
 
-	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 )
 
@@ -16,57 +15,19 @@ import (
 //
 func (httpServer *HttpServer) handleListUnspentOutputCoins(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 
-	// get component
-	paramsArray := common.InterfaceSlice(params)
-	if paramsArray == nil || len(paramsArray) < 3 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 3 elements"))
-	}
-
-	var min int
-	var max int
-	if paramsArray[0] != nil {
-		minParam, ok := paramsArray[0].(float64)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("min param is invalid"))
-		}
-		min = int(minParam)
-	}
-
-	if paramsArray[1] != nil {
-		maxParam, ok := paramsArray[1].(float64)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("max param is invalid"))
-		}
-		max = int(maxParam)
+	unspentParams := listUnspentOutputCoinsParams{}
+	if err := decodeRPCParams(params, &unspentParams); err != nil {
+		return nil, err
 	}
-	_ = min
-	_ = max
-
-	listKeyParams := common.InterfaceSlice(paramsArray[2])
-	if listKeyParams == nil {
+	if unspentParams.Keys == nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("list key is invalid"))
 	}
-
-	tokenID := &common.Hash{}
-	err1 := tokenID.SetBytes(common.PRVCoinID[:])
-	if err1 != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err1)
-	}
-	if len(paramsArray) == 4 {
-		tokenIDStr, ok := paramsArray[3].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token id param is invalid"))
-		}
-		if tokenIDStr != "" {
-			tokenIDHash, err2 := common.Hash{}.NewHashFromStr(tokenIDStr)
-			if err2 != nil {
-				return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token id param is invalid"))
-			}
-			tokenID = tokenIDHash
-		}
+	tokenID, errT := getTokenIDParam(unspentParams.TokenID)
+	if errT != nil {
+		return nil, errT
 	}
 
-	result, err := httpServer.outputCoinService.ListUnspentOutputCoinsByKey(listKeyParams, tokenID)
+	result, err := httpServer.outputCoinService.ListUnspentOutputCoinsByKey(unspentParams.Keys, tokenID)
 	if err != nil {
 		return nil, err
 	}
@@ -81,54 +42,25 @@ func (httpServer *HttpServer) handleListUnspentOutputCoins(params interface{}, c
 //Parameter #2—the maximum number of confirmations an output may have
 //Parameter #3—the list paymentaddress-readonlykey which be used to view list outputcoin
 //Parameter #4 - optional - token id - default prv coin
+//Parameter #5 - optional - commitment - best, finalized, a block height or a block hash - default best
 func (httpServer *HttpServer) handleListOutputCoins(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 
-	// get component
-	paramsArray := common.InterfaceSlice(params)
-	if paramsArray == nil || len(paramsArray) < 3 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 3 elements"))
-	}
-
-	minTemp, ok := paramsArray[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("min param is invalid"))
-	}
-	min := int(minTemp)
-
-	maxTemp, ok := paramsArray[1].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("max param is invalid"))
+	outputCoinsParams := listOutputCoinsParams{}
+	if err := decodeRPCParams(params, &outputCoinsParams); err != nil {
+		return nil, err
 	}
-	max := int(maxTemp)
-
-	_ = min
-	_ = max
-
-	//#3: list key component
-	listKeyParams := common.InterfaceSlice(paramsArray[2])
-	if listKeyParams == nil {
+	if outputCoinsParams.Keys == nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("list key is invalid"))
 	}
-
-	//#4: optional token type - default prv coin
-	tokenID := &common.Hash{}
-	err := tokenID.SetBytes(common.PRVCoinID[:])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.TokenIsInvalidError, err)
+	tokenID, errT := getTokenIDParam(outputCoinsParams.TokenID)
+	if errT != nil {
+		return nil, errT
 	}
-	if len(paramsArray) > 3 {
-		var err1 error
-		tokenIdParam, ok := paramsArray[3].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token id param is invalid"))
-		}
-
-		tokenID, err1 = common.Hash{}.NewHashFromStr(tokenIdParam)
-		if err1 != nil {
-			return nil, rpcservice.NewRPCError(rpcservice.ListTokenNotFoundError, err1)
-		}
+	commitment, errC := httpServer.getCommitmentParam(outputCoinsParams.Commitment)
+	if errC != nil {
+		return nil, errC
 	}
-	result, err1 := httpServer.outputCoinService.ListOutputCoinsByKey(listKeyParams, *tokenID)
+	result, err1 := httpServer.outputCoinService.ListOutputCoinsByKey(outputCoinsParams.Keys, *tokenID, commitment)
 	if err1 != nil {
 		return nil, err1
 	}
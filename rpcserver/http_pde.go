@@ -72,35 +72,16 @@ type ConvertedPrice struct {
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithPRVContribution(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	pdeContributionPairID, ok := data["PDEContributionPairID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	contributorAddressStr, ok := data["ContributorAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	contributedAmountData, ok := data["ContributedAmount"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	contributedAmount := uint64(contributedAmountData)
-	tokenIDStr, ok := data["TokenIDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	contributionParams := createPRVContributionTxParams{}
+	if err := decodeRPCParams(params, &contributionParams); err != nil {
+		return nil, err
 	}
 	meta, _ := metadata.NewPDEContribution(
-		pdeContributionPairID,
-		contributorAddressStr,
-		contributedAmount,
-		tokenIDStr,
+		contributionParams.Metadata.PDEContributionPairID,
+		contributionParams.Metadata.ContributorAddressStr,
+		contributionParams.Metadata.ContributedAmount,
+		contributionParams.Metadata.TokenIDStr,
 		metadata.PDEContributionMeta,
 	)
 
@@ -146,38 +127,20 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithPRVContribution(params in
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithPTokenContribution(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	if len(arrayParams) >= 7 {
-		hasPrivacyToken := int(arrayParams[6].(float64)) > 0
-		if hasPrivacyToken {
-			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
-		}
-	}
-	tokenParamsRaw := arrayParams[4].(map[string]interface{})
-
-	pdeContributionPairID, ok := tokenParamsRaw["PDEContributionPairID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	contributorAddressStr, ok := tokenParamsRaw["ContributorAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	contributedAmountData, ok := tokenParamsRaw["ContributedAmount"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	contributionParams := createPTokenContributionTxParams{}
+	if err := decodeRPCParams(params, &contributionParams); err != nil {
+		return nil, err
 	}
-	contributedAmount := uint64(contributedAmountData)
-	tokenIDStr := tokenParamsRaw["TokenIDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	if contributionParams.HasPrivacyToken > 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
 	}
+	tokenParams := contributionParams.TokenParams
+
 	meta, _ := metadata.NewPDEContribution(
-		pdeContributionPairID,
-		contributorAddressStr,
-		contributedAmount,
-		tokenIDStr,
+		tokenParams.PDEContributionPairID,
+		tokenParams.ContributorAddressStr,
+		tokenParams.ContributedAmount,
+		tokenParams.TokenIDStr,
 		metadata.PDEContributionMeta,
 	)
 
@@ -219,43 +182,18 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithPTokenContribution(params
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithPRVTradeReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	tokenIDToBuyStr, ok := data["TokenIDToBuyStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	tokenIDToSellStr, ok := data["TokenIDToSellStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	sellAmount := uint64(data["SellAmount"].(float64))
-	traderAddressStr, ok := data["TraderAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	minAcceptableAmountData, ok := data["MinAcceptableAmount"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	minAcceptableAmount := uint64(minAcceptableAmountData)
-	tradingFeeData, ok := data["TradingFee"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	tradeParams := createPRVTradeTxParams{}
+	if err := decodeRPCParams(params, &tradeParams); err != nil {
+		return nil, err
 	}
-	tradingFee := uint64(tradingFeeData)
 	meta, _ := metadata.NewPDETradeRequest(
-		tokenIDToBuyStr,
-		tokenIDToSellStr,
-		sellAmount,
-		minAcceptableAmount,
-		tradingFee,
-		traderAddressStr,
+		tradeParams.Metadata.TokenIDToBuyStr,
+		tradeParams.Metadata.TokenIDToSellStr,
+		tradeParams.Metadata.SellAmount,
+		tradeParams.Metadata.MinAcceptableAmount,
+		tradeParams.Metadata.TradingFee,
+		tradeParams.Metadata.TraderAddressStr,
 		metadata.PDETradeRequestMeta,
 	)
 
@@ -301,56 +239,22 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithPRVTradeReq(params interf
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithPTokenTradeReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	if len(arrayParams) >= 7 {
-		hasPrivacyToken := int(arrayParams[6].(float64)) > 0
-		if hasPrivacyToken {
-			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
-		}
-	}
-	tokenParamsRaw := arrayParams[4].(map[string]interface{})
-
-	tokenIDToBuyStr, ok := tokenParamsRaw["TokenIDToBuyStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	tokenIDToSellStr, ok := tokenParamsRaw["TokenIDToSellStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	sellAmountData, ok := tokenParamsRaw["SellAmount"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	sellAmount := uint64(sellAmountData)
-
-	traderAddressStr, ok := tokenParamsRaw["TraderAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	minAcceptableAmountData, ok := tokenParamsRaw["MinAcceptableAmount"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	tradeParams := createPTokenTradeTxParams{}
+	if err := decodeRPCParams(params, &tradeParams); err != nil {
+		return nil, err
 	}
-	minAcceptableAmount := uint64(minAcceptableAmountData)
-
-	tradingFeeData, ok := tokenParamsRaw["TradingFee"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	if tradeParams.HasPrivacyToken > 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
 	}
-	tradingFee := uint64(tradingFeeData)
+	tokenParams := tradeParams.TokenParams
 
 	meta, _ := metadata.NewPDETradeRequest(
-		tokenIDToBuyStr,
-		tokenIDToSellStr,
-		sellAmount,
-		minAcceptableAmount,
-		tradingFee,
-		traderAddressStr,
+		tokenParams.TokenIDToBuyStr,
+		tokenParams.TokenIDToSellStr,
+		tokenParams.SellAmount,
+		tokenParams.MinAcceptableAmount,
+		tokenParams.TradingFee,
+		tokenParams.TraderAddressStr,
 		metadata.PDETradeRequestMeta,
 	)
 
@@ -392,40 +296,16 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithPTokenTradeReq(params int
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithWithdrawalReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	withdrawerAddressStr, ok := data["WithdrawerAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	withdrawalToken1IDStr, ok := data["WithdrawalToken1IDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	withdrawalToken2IDStr, ok := data["WithdrawalToken2IDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	withdrawalShareAmtData, ok := data["WithdrawalShareAmt"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	withdrawalParams := createPDEWithdrawalTxParams{}
+	if err := decodeRPCParams(params, &withdrawalParams); err != nil {
+		return nil, err
 	}
-	withdrawalShareAmt := uint64(withdrawalShareAmtData)
-
 	meta, _ := metadata.NewPDEWithdrawalRequest(
-		withdrawerAddressStr,
-		withdrawalToken1IDStr,
-		withdrawalToken2IDStr,
-		withdrawalShareAmt,
+		withdrawalParams.Metadata.WithdrawerAddressStr,
+		withdrawalParams.Metadata.WithdrawalToken1IDStr,
+		withdrawalParams.Metadata.WithdrawalToken2IDStr,
+		withdrawalParams.Metadata.WithdrawalShareAmt,
 		metadata.PDEWithdrawalRequestMeta,
 	)
 
@@ -471,35 +351,27 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithWithdrawalReq(params inte
 }
 
 func (httpServer *HttpServer) handleGetPDEState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) == 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	stateParams := getPDEStateParams{}
+	if err := decodeRPCParams(params, &stateParams); err != nil {
+		return nil, err
 	}
-	beaconHeight, ok := data["BeaconHeight"].(float64)
-	if !ok {
+	// the state at the beacon block BeaconHash, or else at the height BeaconHeight
+	data := stateParams.Data
+	if data.BeaconHash == "" && data.BeaconHeight == nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Beacon height is invalid"))
 	}
-	beaconFeatureStateRootHash, err := httpServer.config.BlockChain.GetBeaconFeatureRootHash(httpServer.config.BlockChain.GetBeaconBestState(), uint64(beaconHeight))
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, fmt.Errorf("Can't found ConsensusStateRootHash of beacon height %+v, error %+v", beaconHeight, err))
+	var beaconHeight uint64
+	if data.BeaconHeight != nil {
+		beaconHeight = *data.BeaconHeight
 	}
-	beaconFeatureStateDB, err := statedb.NewWithPrefixTrie(beaconFeatureStateRootHash, statedb.NewDatabaseAccessWarper(httpServer.GetBeaconChainDatabase()))
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	beaconBlock, beaconFeatureStateDB, errR := httpServer.getBeaconBlockFeatureState(data.BeaconHash, beaconHeight, rpcservice.GetPDEStateError)
+	if errR != nil {
+		return nil, errR
 	}
-	pdeState, err := blockchain.InitCurrentPDEStateFromDB(beaconFeatureStateDB, uint64(beaconHeight))
+	pdeState, err := blockchain.InitCurrentPDEStateFromDB(beaconFeatureStateDB, beaconBlock.Header.Height)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
 	}
-	beaconBlocks, err := httpServer.config.BlockChain.GetBeaconBlockByHeight(uint64(beaconHeight))
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
-	}
-	beaconBlock := beaconBlocks[0]
 	type CurrentPDEState struct {
 		WaitingPDEContributions map[string]*rawdbv2.PDEContribution `json:"WaitingPDEContributions"`
 		PDEPoolPairs            map[string]*rawdbv2.PDEPoolForPair  `json:"PDEPoolPairs"`
@@ -518,32 +390,23 @@ func (httpServer *HttpServer) handleGetPDEState(params interface{}, closeChan <-
 }
 
 func (httpServer *HttpServer) handleConvertNativeTokenToPrivacyToken(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	data := arrayParams[0].(map[string]interface{})
-	beaconHeight, ok := data["BeaconHeight"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
-	}
-	nativeTokenAmount, ok := data["NativeTokenAmount"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
-	}
-	tokenIDStr, ok := data["TokenID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	convertParams := convertNativeTokenToPrivacyTokenParams{}
+	if err := decodeRPCParams(params, &convertParams); err != nil {
+		return nil, err
 	}
-	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+	data := convertParams.Data
+	tokenID, err := common.Hash{}.NewHashFromStr(data.TokenID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
 	}
-	beaconPdexStateDB, err := httpServer.config.BlockChain.GetBestStateBeaconFeatureStateDBByHeight(uint64(beaconHeight), httpServer.GetBeaconChainDatabase())
+	beaconPdexStateDB, err := httpServer.config.BlockChain.GetBestStateBeaconFeatureStateDBByHeight(data.BeaconHeight, httpServer.GetBeaconChainDatabase())
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
 	res, err := metadata.ConvertNativeTokenToPrivacyToken(
-		uint64(nativeTokenAmount),
+		data.NativeTokenAmount,
 		tokenID,
-		int64(beaconHeight),
+		int64(data.BeaconHeight),
 		beaconPdexStateDB,
 	)
 	if err != nil {
@@ -553,32 +416,23 @@ func (httpServer *HttpServer) handleConvertNativeTokenToPrivacyToken(params inte
 }
 
 func (httpServer *HttpServer) handleConvertPrivacyTokenToNativeToken(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	data := arrayParams[0].(map[string]interface{})
-	beaconHeight, ok := data["BeaconHeight"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
-	}
-	privacyTokenAmount, ok := data["PrivacyTokenAmount"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
-	}
-	tokenIDStr, ok := data["TokenID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	convertParams := convertPrivacyTokenToNativeTokenParams{}
+	if err := decodeRPCParams(params, &convertParams); err != nil {
+		return nil, err
 	}
-	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+	data := convertParams.Data
+	tokenID, err := common.Hash{}.NewHashFromStr(data.TokenID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
 	}
-	beaconPdexStateDB, err := httpServer.config.BlockChain.GetBestStateBeaconFeatureStateDBByHeight(uint64(beaconHeight), httpServer.GetBeaconChainDatabase())
+	beaconPdexStateDB, err := httpServer.config.BlockChain.GetBestStateBeaconFeatureStateDBByHeight(data.BeaconHeight, httpServer.GetBeaconChainDatabase())
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
 	res, err := metadata.ConvertPrivacyTokenToNativeToken(
-		uint64(privacyTokenAmount),
+		data.PrivacyTokenAmount,
 		tokenID,
-		int64(beaconHeight),
+		int64(data.BeaconHeight),
 		beaconPdexStateDB,
 	)
 	if err != nil {
@@ -588,12 +442,11 @@ func (httpServer *HttpServer) handleConvertPrivacyTokenToNativeToken(params inte
 }
 
 func (httpServer *HttpServer) handleGetPDEContributionStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	data := arrayParams[0].(map[string]interface{})
-	contributionPairID, ok := data["ContributionPairID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	statusParams := getPDEContributionStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	contributionPairID := statusParams.Data.ContributionPairID
 	status, err := httpServer.blockService.GetPDEStatus(rawdbv2.PDEContributionStatusPrefix, []byte(contributionPairID))
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
@@ -602,12 +455,11 @@ func (httpServer *HttpServer) handleGetPDEContributionStatus(params interface{},
 }
 
 func (httpServer *HttpServer) handleGetPDEContributionStatusV2(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	data := arrayParams[0].(map[string]interface{})
-	contributionPairID, ok := data["ContributionPairID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	statusParams := getPDEContributionStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	contributionPairID := statusParams.Data.ContributionPairID
 	contributionStatus, err := httpServer.blockService.GetPDEContributionStatus(rawdbv2.PDEContributionStatusPrefix, []byte(contributionPairID))
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
@@ -616,15 +468,11 @@ func (httpServer *HttpServer) handleGetPDEContributionStatusV2(params interface{
 }
 
 func (httpServer *HttpServer) handleGetPDETradeStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
-	}
-	txRequestIDStr, ok := data["TxRequestIDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	statusParams := getPDERequestStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	txRequestIDStr := statusParams.Data.TxRequestIDStr
 	txIDHash, err := common.Hash{}.NewHashFromStr(txRequestIDStr)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
@@ -637,12 +485,11 @@ func (httpServer *HttpServer) handleGetPDETradeStatus(params interface{}, closeC
 }
 
 func (httpServer *HttpServer) handleGetPDEWithdrawalStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	data := arrayParams[0].(map[string]interface{})
-	txRequestIDStr, ok := data["TxRequestIDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	statusParams := getPDERequestStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	txRequestIDStr := statusParams.Data.TxRequestIDStr
 	txIDHash, err := common.Hash{}.NewHashFromStr(txRequestIDStr)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
@@ -655,12 +502,11 @@ func (httpServer *HttpServer) handleGetPDEWithdrawalStatus(params interface{}, c
 }
 
 func (httpServer *HttpServer) handleGetPDEFeeWithdrawalStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	data := arrayParams[0].(map[string]interface{})
-	txRequestIDStr, ok := data["TxRequestIDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	statusParams := getPDERequestStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	txRequestIDStr := statusParams.Data.TxRequestIDStr
 	txIDHash, err := common.Hash{}.NewHashFromStr(txRequestIDStr)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
@@ -811,17 +657,12 @@ func parsePDEWithdrawalInst(inst []string, beaconHeight uint64) (*PDEWithdrawal,
 func (httpServer *HttpServer) handleExtractPDEInstsFromBeaconBlock(
 	params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError,
 ) {
-	arrayParams := common.InterfaceSlice(params)
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	beaconHeight, ok := data["BeaconHeight"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Beacon height is invalid"))
+	extractParams := extractPDEInstsFromBeaconBlockParams{}
+	if err := decodeRPCParams(params, &extractParams); err != nil {
+		return nil, err
 	}
 
-	bcHeight := uint64(beaconHeight)
+	bcHeight := extractParams.Data.BeaconHeight
 	beaconBlocks, err := blockchain.FetchBeaconBlockFromHeight(
 		httpServer.config.BlockChain,
 		bcHeight,
@@ -921,24 +762,13 @@ func convertPrice(
 func (httpServer *HttpServer) handleConvertPDEPrices(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	latestBeaconHeight := httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight
 
-	arrayParams := common.InterfaceSlice(params)
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	fromTokenIDStr, ok := data["FromTokenIDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("FromTokenIDStr is invalid"))
-	}
-	toTokenIDStr, ok := data["ToTokenIDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ToTokenIDStr is invalid"))
-	}
-	amount, ok := data["Amount"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Amount is invalid"))
+	priceParams := convertPDEPricesParams{}
+	if err := decodeRPCParams(params, &priceParams); err != nil {
+		return nil, err
 	}
-	convertingAmt := uint64(amount)
+	fromTokenIDStr := priceParams.Data.FromTokenIDStr
+	toTokenIDStr := priceParams.Data.ToTokenIDStr
+	convertingAmt := priceParams.Data.Amount
 	if convertingAmt == 0 {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Amount is invalid"))
 	}
@@ -1001,34 +831,16 @@ func (httpServer *HttpServer) handleConvertPDEPrices(params interface{}, closeCh
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithPRVContributionV2(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	pdeContributionPairID, ok := data["PDEContributionPairID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	contributorAddressStr, ok := data["ContributorAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	contributedAmount, err := common.AssertAndConvertStrToNumber(data["ContributedAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-	tokenIDStr, ok := data["TokenIDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	contributionParams := createPRVContributionTxParamsV2{}
+	if err := decodeRPCParams(params, &contributionParams); err != nil {
+		return nil, err
 	}
 	meta, _ := metadata.NewPDEContribution(
-		pdeContributionPairID,
-		contributorAddressStr,
-		contributedAmount,
-		tokenIDStr,
+		contributionParams.Metadata.PDEContributionPairID,
+		contributionParams.Metadata.ContributorAddressStr,
+		uint64(contributionParams.Metadata.ContributedAmount),
+		contributionParams.Metadata.TokenIDStr,
 		metadata.PDEPRVRequiredContributionRequestMeta,
 	)
 
@@ -1074,37 +886,20 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithPRVContributionV2(params
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithPTokenContributionV2(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	if len(arrayParams) >= 7 {
-		hasPrivacyToken := int(arrayParams[6].(float64)) > 0
-		if hasPrivacyToken {
-			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
-		}
-	}
-	tokenParamsRaw := arrayParams[4].(map[string]interface{})
-
-	pdeContributionPairID, ok := tokenParamsRaw["PDEContributionPairID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	contributorAddressStr, ok := tokenParamsRaw["ContributorAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	contributedAmount, err := common.AssertAndConvertStrToNumber(tokenParamsRaw["ContributedAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	contributionParams := createPTokenContributionTxParamsV2{}
+	if err := decodeRPCParams(params, &contributionParams); err != nil {
+		return nil, err
 	}
-	tokenIDStr := tokenParamsRaw["TokenIDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	if contributionParams.HasPrivacyToken > 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
 	}
+	tokenParams := contributionParams.TokenParams
+
 	meta, _ := metadata.NewPDEContribution(
-		pdeContributionPairID,
-		contributorAddressStr,
-		contributedAmount,
-		tokenIDStr,
+		tokenParams.PDEContributionPairID,
+		tokenParams.ContributorAddressStr,
+		uint64(tokenParams.ContributedAmount),
+		tokenParams.TokenIDStr,
 		metadata.PDEPRVRequiredContributionRequestMeta,
 	)
 
@@ -1146,44 +941,18 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithPTokenContributionV2(para
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithPRVCrossPoolTradeReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	tokenIDToBuyStr, ok := data["TokenIDToBuyStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	tokenIDToSellStr, ok := data["TokenIDToSellStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	sellAmount, err := common.AssertAndConvertStrToNumber(data["SellAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-	traderAddressStr, ok := data["TraderAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-	minAcceptableAmount, err := common.AssertAndConvertStrToNumber(data["MinAcceptableAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-	tradingFee, err := common.AssertAndConvertStrToNumber(data["TradingFee"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	tradeParams := createAndSendTxWithPRVCrossPoolTradeReqParams{}
+	if err := decodeRPCParams(params, &tradeParams); err != nil {
+		return nil, err
 	}
 	meta, _ := metadata.NewPDECrossPoolTradeRequest(
-		tokenIDToBuyStr,
-		tokenIDToSellStr,
-		sellAmount,
-		minAcceptableAmount,
-		tradingFee,
-		traderAddressStr,
+		tradeParams.Metadata.TokenIDToBuyStr,
+		tradeParams.Metadata.TokenIDToSellStr,
+		uint64(tradeParams.Metadata.SellAmount),
+		uint64(tradeParams.Metadata.MinAcceptableAmount),
+		uint64(tradeParams.Metadata.TradingFee),
+		tradeParams.Metadata.TraderAddressStr,
 		metadata.PDECrossPoolTradeRequestMeta,
 	)
 
@@ -1229,53 +998,22 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithPRVCrossPoolTradeReq(para
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithPTokenCrossPoolTradeReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	if len(arrayParams) >= 7 {
-		hasPrivacyToken := int(arrayParams[6].(float64)) > 0
-		if hasPrivacyToken {
-			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
-		}
-	}
-	tokenParamsRaw := arrayParams[4].(map[string]interface{})
-
-	tokenIDToBuyStr, ok := tokenParamsRaw["TokenIDToBuyStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	tokenIDToSellStr, ok := tokenParamsRaw["TokenIDToSellStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	sellAmount, err := common.AssertAndConvertStrToNumber(tokenParamsRaw["SellAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
-	traderAddressStr, ok := tokenParamsRaw["TraderAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	minAcceptableAmount, err := common.AssertAndConvertStrToNumber(tokenParamsRaw["MinAcceptableAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	tradeParams := createAndSendTxWithPTokenCrossPoolTradeReqParams{}
+	if err := decodeRPCParams(params, &tradeParams); err != nil {
+		return nil, err
 	}
-
-	tradingFee, err := common.AssertAndConvertStrToNumber(tokenParamsRaw["TradingFee"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	if tradeParams.HasPrivacyToken > 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
 	}
+	tokenParams := tradeParams.TokenParams
 
 	meta, _ := metadata.NewPDECrossPoolTradeRequest(
-		tokenIDToBuyStr,
-		tokenIDToSellStr,
-		sellAmount,
-		minAcceptableAmount,
-		tradingFee,
-		traderAddressStr,
+		tokenParams.TokenIDToBuyStr,
+		tokenParams.TokenIDToSellStr,
+		uint64(tokenParams.SellAmount),
+		uint64(tokenParams.MinAcceptableAmount),
+		uint64(tokenParams.TradingFee),
+		tokenParams.TraderAddressStr,
 		metadata.PDECrossPoolTradeRequestMeta,
 	)
 
@@ -1317,39 +1055,16 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithPTokenCrossPoolTradeReq(p
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithWithdrawalReqV2(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	withdrawerAddressStr, ok := data["WithdrawerAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	withdrawalToken1IDStr, ok := data["WithdrawalToken1IDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	withdrawalToken2IDStr, ok := data["WithdrawalToken2IDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	withdrawalShareAmt, err := common.AssertAndConvertStrToNumber(data["WithdrawalShareAmt"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	withdrawalParams := createPDEWithdrawalTxParamsV2{}
+	if err := decodeRPCParams(params, &withdrawalParams); err != nil {
+		return nil, err
 	}
-
 	meta, _ := metadata.NewPDEWithdrawalRequest(
-		withdrawerAddressStr,
-		withdrawalToken1IDStr,
-		withdrawalToken2IDStr,
-		withdrawalShareAmt,
+		withdrawalParams.Metadata.WithdrawerAddressStr,
+		withdrawalParams.Metadata.WithdrawalToken1IDStr,
+		withdrawalParams.Metadata.WithdrawalToken2IDStr,
+		uint64(withdrawalParams.Metadata.WithdrawalShareAmt),
 		metadata.PDEWithdrawalRequestMeta,
 	)
 
@@ -1395,39 +1110,16 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithWithdrawalReqV2(params in
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithPDEFeeWithdrawalReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	withdrawerAddressStr, ok := data["WithdrawerAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	withdrawalToken1IDStr, ok := data["WithdrawalToken1IDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	withdrawalToken2IDStr, ok := data["WithdrawalToken2IDStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	withdrawalFeeAmt, err := common.AssertAndConvertStrToNumber(data["WithdrawalFeeAmt"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	withdrawalParams := createPDEFeeWithdrawalTxParams{}
+	if err := decodeRPCParams(params, &withdrawalParams); err != nil {
+		return nil, err
 	}
-
 	meta, _ := metadata.NewPDEFeeWithdrawalRequest(
-		withdrawerAddressStr,
-		withdrawalToken1IDStr,
-		withdrawalToken2IDStr,
-		withdrawalFeeAmt,
+		withdrawalParams.Metadata.WithdrawerAddressStr,
+		withdrawalParams.Metadata.WithdrawalToken1IDStr,
+		withdrawalParams.Metadata.WithdrawalToken2IDStr,
+		uint64(withdrawalParams.Metadata.WithdrawalFeeAmt),
 		metadata.PDEFeeWithdrawalRequestMeta,
 	)
 
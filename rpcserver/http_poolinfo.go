@@ -1,8 +1,6 @@
 package rpcserver
 
 import (
-	"errors"
-
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/multiview"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
@@ -25,55 +23,37 @@ func (httpServer *HttpServer) handleGetShardToBeaconPoolInfo(params interface{},
 	return result, nil
 }
 func (httpServer *HttpServer) hanldeGetShardPoolInfo(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ShardID component invalid"))
-	}
-
-	shardID, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ShardID component invalid"))
+	shardParams := shardIDParams{}
+	if err := decodeRPCParams(params, &shardParams); err != nil {
+		return nil, err
 	}
 	Logger.log.Debugf("hanldeGetShardPoolInfo params: %+v", params)
-	blks := httpServer.synkerService.GetShardPoolInfo(int(shardID))
+	blks := httpServer.synkerService.GetShardPoolInfo(shardParams.ShardID)
 	result := jsonresult.NewPoolInfo(blks)
 	Logger.log.Debugf("handleGetShardPoolInfo result: %+v", result)
 	return result, nil
 }
 
 func (httpServer *HttpServer) hanldeGetCrossShardPoolInfo(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ShardID invalid"))
-	}
-
-	shardID, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ShardID component invalid"))
+	shardParams := shardIDParams{}
+	if err := decodeRPCParams(params, &shardParams); err != nil {
+		return nil, err
 	}
 	Logger.log.Debugf("hanldeGetCrossShardPoolInfo params: %+v", params)
-	blks := httpServer.synkerService.GetCrossShardPoolInfo(int(shardID))
+	blks := httpServer.synkerService.GetCrossShardPoolInfo(shardParams.ShardID)
 	result := jsonresult.NewPoolInfo(blks)
 	Logger.log.Debugf("hanldeGetCrossShardPoolInfo result: %+v", result)
 	return result, nil
 }
 
 func (httpServer *HttpServer) hanldeGetAllView(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 2 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid param, param 0 must be shardid, 1 is number of blk estimate"))
-	}
-
-	shardID, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ShardID component invalid"))
-	}
-	numOfBlks, ok := arrayParams[1].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Block height component invalid"))
+	viewParams := getAllViewParams{}
+	if err := decodeRPCParams(params, &viewParams); err != nil {
+		return nil, err
 	}
+	shardID := viewParams.ShardID
 	Logger.log.Debugf("hanldeGetCrossShardPoolInfo params: %+v", params)
-	blkOnChain, err := httpServer.blockService.GetBlocks(int(shardID), int(numOfBlks))
+	blkOnChain, err := httpServer.blockService.GetBlocks(shardID, viewParams.NumBlock)
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +78,7 @@ func (httpServer *HttpServer) hanldeGetAllView(params interface{}, closeChan <-c
 		if len(blks) == 0 {
 			return nil, nil
 		}
-		blksPool = httpServer.synkerService.GetAllViewShardByHash(blks[len(blks)-1].Hash, int(shardID))
+		blksPool = httpServer.synkerService.GetAllViewShardByHash(blks[len(blks)-1].Hash, shardID)
 		for _, blk := range blks {
 			res = append(res, jsonresult.GetViewResult{
 				Hash:              blk.Hash,
@@ -120,26 +100,18 @@ func (httpServer *HttpServer) hanldeGetAllView(params interface{}, closeChan <-c
 }
 
 func (httpServer *HttpServer) hanldeGetAllViewDetail(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid param, param 0 must be shardid"))
-	}
-
-	shardID, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ShardID component invalid"))
+	shardParams := shardIDParams{}
+	if err := decodeRPCParams(params, &shardParams); err != nil {
+		return nil, err
 	}
-	// numOfBlks, ok := arrayParams[1].(float64)
-	// if !ok {
-	// 	return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Block height component invalid"))
-	// }
+	shardID := shardParams.ShardID
 
 	res := []jsonresult.GetViewResult{}
 	var views []multiview.View
 	if shardID == -1 {
 		views = httpServer.config.BlockChain.BeaconChain.GetAllView()
 	} else {
-		sChain := httpServer.config.BlockChain.ShardChain[int(shardID)]
+		sChain := httpServer.config.BlockChain.ShardChain[shardID]
 		if sChain != nil {
 			views = sChain.GetAllView()
 		}
@@ -12,58 +12,29 @@ import (
 	"github.com/incognitochain/incognito-chain/rpcserver/bean"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
-	"sort"
 )
 
 func (httpServer *HttpServer) handleCreateRawTxWithCustodianDeposit(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-	incognitoAddress, ok := data["IncognitoAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata IncognitoAddress is invalid"))
-	}
-	remoteAddressesMap, ok := data["RemoteAddresses"].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata RemoteAddresses param is invalid"))
+	depositParams := createCustodianDepositTxParams{}
+	if err := decodeRPCParams(params, &depositParams); err != nil {
+		return nil, err
 	}
-	if len(remoteAddressesMap) < 1 {
+	data := depositParams.Metadata
+	if len(data.RemoteAddresses) < 1 {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata RemoteAddresses must be at least one"))
 	}
-	remoteAddresses := make(map[string]string, 0)
-	tokenIDKeys := make([]string, 0)
-	for pTokenID, remoteAddress := range remoteAddressesMap {
+	for pTokenID := range data.RemoteAddresses {
 		if !common.IsPortalToken(pTokenID) {
 			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata public token is not supported currently"))
 		}
-		_, ok := remoteAddress.(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata RemoteAddresses is invalid"))
-		}
-		tokenIDKeys = append(tokenIDKeys, pTokenID)
-	}
-	sort.Strings(tokenIDKeys)
-	for _, pTokenID := range tokenIDKeys {
-		remoteAddresses[pTokenID] = remoteAddressesMap[pTokenID].(string)
-	}
-
-	depositedAmount, err := common.AssertAndConvertStrToNumber(data["DepositedAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
 
 	meta, _ := metadata.NewPortalCustodianDeposit(
 		metadata.PortalCustodianDepositMeta,
-		incognitoAddress,
-		remoteAddresses,
-		depositedAmount,
+		data.IncognitoAddress,
+		data.RemoteAddresses,
+		uint64(data.DepositedAmount),
 	)
 
 	// create new param to build raw tx from param interface
@@ -110,49 +81,20 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithCustodianDeposit(params i
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithReqPToken(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-	uniquePortingID, ok := data["UniquePortingID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata UniquePortingID is invalid"))
-	}
-	tokenID, ok := data["TokenID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is invalid"))
-	}
-	//tokenIDHash, err := new(common.Hash).NewHashFromStr(tokenID)
-	//if err != nil {
-	//	return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata Can not new TokenIDHash from TokenID"))
-	//}
-	incognitoAddress, ok := data["IncogAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata IncogAddressStr is invalid"))
-	}
-	portingAmount, err := common.AssertAndConvertStrToNumber(data["PortingAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
-	portingProof, ok := data["PortingProof"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PortingProof param is invalid"))
+	requestParams := createPortalReqPTokenTxParams{}
+	if err := decodeRPCParams(params, &requestParams); err != nil {
+		return nil, err
 	}
+	data := requestParams.Metadata
 
 	meta, _ := metadata.NewPortalRequestPTokens(
 		metadata.PortalUserRequestPTokenMeta,
-		uniquePortingID,
-		tokenID,
-		incognitoAddress,
-		portingAmount,
-		portingProof,
+		data.UniquePortingID,
+		data.TokenID,
+		data.IncogAddressStr,
+		uint64(data.PortingAmount),
+		data.PortingProof,
 	)
 
 	// create new param to build raw tx from param interface
@@ -199,37 +141,29 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithReqPToken(params interfac
 }
 
 func (httpServer *HttpServer) handleGetPortalState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
+	stateParams := getPortalStateParams{}
+	if err := decodeRPCParams(params, &stateParams); err != nil {
+		return nil, err
 	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	// the state at the beacon block BeaconHash, or else at the height BeaconHeight
+	data := stateParams.Data
+	if data.BeaconHash == "" && data.BeaconHeight == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("beacon height is invalid"))
 	}
-	beaconHeight, err := common.AssertAndConvertStrToNumber(data["BeaconHeight"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	var beaconHeight uint64
+	if data.BeaconHeight != nil {
+		beaconHeight = uint64(*data.BeaconHeight)
 	}
-
-	//beaconFeatureStateDB := httpServer.config.BlockChain.GetBeaconBestState().GetCopiedFeatureStateDB()
-	beaconFeatureStateRootHash, err := httpServer.config.BlockChain.GetBeaconFeatureRootHash(httpServer.config.BlockChain.GetBeaconBestState(), uint64(beaconHeight))
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.GetPortalStateError, fmt.Errorf("Can't found FeatureStateRootHash of beacon height %+v, error %+v", beaconHeight, err))
+	beaconBlock, beaconFeatureStateDB, errR := httpServer.getBeaconBlockFeatureState(data.BeaconHash, beaconHeight, rpcservice.GetPortalStateError)
+	if errR != nil {
+		return nil, errR
 	}
-	beaconFeatureStateDB, err := statedb.NewWithPrefixTrie(beaconFeatureStateRootHash, statedb.NewDatabaseAccessWarper(httpServer.config.BlockChain.GetBeaconChainDatabase()))
 
 	portalState, err := blockchain.InitCurrentPortalStateFromDB(beaconFeatureStateDB)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetPortalStateError, err)
 	}
 
-	beaconBlocks, err := httpServer.config.BlockChain.GetBeaconBlockByHeight(uint64(beaconHeight))
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.GetPortalStateError, err)
-	}
-	beaconBlock := beaconBlocks[0]
-
 	type CurrentPortalState struct {
 		WaitingPortingRequests     map[string]*statedb.WaitingPortingRequest `json:"WaitingPortingRequests"`
 		WaitingRedeemRequests      map[string]*statedb.RedeemRequest         `json:"WaitingRedeemRequests"`
@@ -255,18 +189,11 @@ func (httpServer *HttpServer) handleGetPortalState(params interface{}, closeChan
 }
 
 func (httpServer *HttpServer) handleGetPortalCustodianDepositStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	depositTxID, ok := data["DepositTxID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param DepositTxID is invalid"))
+	statusParams := getPortalCustodianDepositStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	depositTxID := statusParams.Data.DepositTxID
 
 	status, err := httpServer.blockService.GetCustodianDepositStatus(depositTxID)
 	if err != nil {
@@ -276,18 +203,11 @@ func (httpServer *HttpServer) handleGetPortalCustodianDepositStatus(params inter
 }
 
 func (httpServer *HttpServer) handleGetPortalReqPTokenStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	reqTxID, ok := data["ReqTxID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param ReqTxID is invalid"))
+	statusParams := getPortalReqStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	reqTxID := statusParams.Data.ReqTxID
 	status, err := httpServer.blockService.GetPortalReqPTokenStatus(reqTxID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetReqPTokenStatusError, err)
@@ -296,55 +216,18 @@ func (httpServer *HttpServer) handleGetPortalReqPTokenStatus(params interface{},
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithRedeemReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	if len(arrayParams) >= 7 {
-		hasPrivacyTokenParam, ok := arrayParams[6].(float64)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("HasPrivacyToken is invalid"))
-		}
-		hasPrivacyToken := int(hasPrivacyTokenParam) > 0
-		if hasPrivacyToken {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("The privacy mode must be disabled"))
-		}
-	}
-	tokenParamsRaw, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param metadata is invalid"))
-	}
-
-	uniqueRedeemID, ok := tokenParamsRaw["UniqueRedeemID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("UniqueRedeemID is invalid"))
-	}
-
-	redeemTokenID, ok := tokenParamsRaw["RedeemTokenID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("RedeemTokenID is invalid"))
-	}
-
-	redeemAmount, err := common.AssertAndConvertStrToNumber(tokenParamsRaw["RedeemAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
-	redeemFee, err := common.AssertAndConvertStrToNumber(tokenParamsRaw["RedeemFee"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
-	redeemerIncAddressStr, ok := tokenParamsRaw["RedeemerIncAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("RedeemerIncAddressStr is invalid"))
+	redeemParams := createPortalRedeemTxParams{}
+	if err := decodeRPCParams(params, &redeemParams); err != nil {
+		return nil, err
 	}
-
-	remoteAddress, ok := tokenParamsRaw["RemoteAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("RemoteAddress is invalid"))
+	if redeemParams.HasPrivacyToken > 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("The privacy mode must be disabled"))
 	}
+	tokenParams := redeemParams.TokenParams
 
-	meta, _ := metadata.NewPortalRedeemRequest(metadata.PortalRedeemRequestMeta, uniqueRedeemID,
-		redeemTokenID, redeemAmount, redeemerIncAddressStr, remoteAddress, redeemFee)
+	meta, _ := metadata.NewPortalRedeemRequest(metadata.PortalRedeemRequestMeta, tokenParams.UniqueRedeemID,
+		tokenParams.RedeemTokenID, uint64(tokenParams.RedeemAmount), tokenParams.RedeemerIncAddressStr,
+		tokenParams.RemoteAddress, uint64(tokenParams.RedeemFee))
 
 	customTokenTx, rpcErr := httpServer.txService.BuildRawPrivacyCustomTokenTransactionV2(params, meta)
 	if rpcErr != nil {
@@ -383,28 +266,16 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithRedeemReq(params interfac
 }
 
 func (httpServer *HttpServer) handleCustodianWithdrawRequest(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	paymentAddress, ok := data["PaymentAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PaymentAddress is invalid"))
-	}
-
-	amount, err := common.AssertAndConvertStrToNumber(data["Amount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	withdrawParams := createCustodianWithdrawTxParams{}
+	if err := decodeRPCParams(params, &withdrawParams); err != nil {
+		return nil, err
 	}
 
 	meta, _ := metadata.NewPortalCustodianWithdrawRequest(
 		metadata.PortalCustodianWithdrawRequestMeta,
-		paymentAddress,
-		amount,
+		withdrawParams.Metadata.PaymentAddress,
+		uint64(withdrawParams.Metadata.Amount),
 	)
 
 	// create new param to build raw tx from param interface
@@ -450,49 +321,24 @@ func (httpServer *HttpServer) handleCreateAndSendCustodianWithdrawRequest(params
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithReqUnlockCollateral(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
+	unlockParams := createPortalReqUnlockCollateralTxParams{}
+	if err := decodeRPCParams(params, &unlockParams); err != nil {
+		return nil, err
 	}
-	uniqueRedeemID, ok := data["UniqueRedeemID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata UniqueRedeemID is invalid"))
-	}
-	tokenID, ok := data["TokenID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is invalid"))
-	}
-	_, err := new(common.Hash).NewHashFromStr(tokenID)
+	data := unlockParams.Metadata
+	_, err := new(common.Hash).NewHashFromStr(data.TokenID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata Can not new TokenIDHash from TokenID"))
 	}
-	incognitoAddress, ok := data["CustodianAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata CustodianAddressStr is invalid"))
-	}
-	redeemAmount, err := common.AssertAndConvertStrToNumber(data["RedeemAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
-	redeemProof, ok := data["RedeemProof"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata RedeemProof param is invalid"))
-	}
 
 	meta, _ := metadata.NewPortalRequestUnlockCollateral(
 		metadata.PortalRequestUnlockCollateralMeta,
-		uniqueRedeemID,
-		tokenID,
-		incognitoAddress,
-		redeemAmount,
-		redeemProof,
+		data.UniqueRedeemID,
+		data.TokenID,
+		data.CustodianAddressStr,
+		uint64(data.RedeemAmount),
+		data.RedeemProof,
 	)
 
 	// create new param to build raw tx from param interface
@@ -539,18 +385,11 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithReqUnlockCollateral(param
 }
 
 func (httpServer *HttpServer) handleGetCustodianWithdrawByTxId(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	// get meta data from params
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-
-	txId, ok := data["TxId"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata txId is invalid"))
+	withdrawParams := getCustodianWithdrawByTxIdParams{}
+	if err := decodeRPCParams(params, &withdrawParams); err != nil {
+		return nil, err
 	}
+	txId := withdrawParams.Data.TxId
 
 	result, err := httpServer.portal.GetCustodianWithdrawByTxId(txId)
 
@@ -562,18 +401,11 @@ func (httpServer *HttpServer) handleGetCustodianWithdrawByTxId(params interface{
 }
 
 func (httpServer *HttpServer) handleGetPortalReqUnlockCollateralStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	reqTxID, ok := data["ReqTxID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param ReqTxID is invalid"))
+	statusParams := getPortalReqStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	reqTxID := statusParams.Data.ReqTxID
 	status, err := httpServer.blockService.GetPortalReqUnlockCollateralStatus(reqTxID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetReqUnlockCollateralStatusError, err)
@@ -582,18 +414,11 @@ func (httpServer *HttpServer) handleGetPortalReqUnlockCollateralStatus(params in
 }
 
 func (httpServer *HttpServer) handleGetPortalReqRedeemStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	redeemID, ok := data["RedeemID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param RedeemID is invalid"))
+	statusParams := getPortalReqRedeemStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	redeemID := statusParams.Data.RedeemID
 	status, err := httpServer.blockService.GetPortalRedeemReqStatus(redeemID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetReqRedeemStatusError, err)
@@ -602,18 +427,11 @@ func (httpServer *HttpServer) handleGetPortalReqRedeemStatus(params interface{},
 }
 
 func (httpServer *HttpServer) handleGetPortalReqRedeemByTxIDStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	reqTxID, ok := data["ReqTxID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param ReqTxID is invalid"))
+	statusParams := getPortalReqStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	reqTxID := statusParams.Data.ReqTxID
 	status, err := httpServer.blockService.GetPortalRedeemReqByTxIDStatus(reqTxID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetReqRedeemStatusError, err)
@@ -622,23 +440,12 @@ func (httpServer *HttpServer) handleGetPortalReqRedeemByTxIDStatus(params interf
 }
 
 func (httpServer *HttpServer) handleGetCustodianLiquidationStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	redeemID, ok := data["RedeemID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param RedeemID is invalid"))
-	}
-
-	custodianAddress, ok := data["CustodianIncAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param CustodianIncAddress is invalid"))
+	statusParams := getCustodianLiquidationStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	redeemID := statusParams.Data.RedeemID
+	custodianAddress := statusParams.Data.CustodianIncAddress
 	status, err := httpServer.blockService.GetPortalLiquidationCustodianStatus(redeemID, custodianAddress)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetReqRedeemStatusError, err)
@@ -647,35 +454,19 @@ func (httpServer *HttpServer) handleGetCustodianLiquidationStatus(params interfa
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithReqWithdrawRewardPortal(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
+	withdrawParams := createPortalReqWithdrawRewardTxParams{}
+	if err := decodeRPCParams(params, &withdrawParams); err != nil {
+		return nil, err
 	}
-	incognitoAddress, ok := data["CustodianAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata CustodianAddressStr is invalid"))
-	}
-	tokenID := new(common.Hash)
-	tokenIDStr, ok := data["TokenID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is invalid"))
-
-	}
-	var err error
-	tokenID, err = tokenID.NewHashFromStr(tokenIDStr)
+	tokenID, err := new(common.Hash).NewHashFromStr(withdrawParams.Metadata.TokenID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is invalid"))
 	}
 
 	meta, _ := metadata.NewPortalRequestWithdrawReward(
 		metadata.PortalRequestWithdrawRewardMeta,
-		incognitoAddress,
+		withdrawParams.Metadata.CustodianAddressStr,
 		*tokenID)
 
 	// create new param to build raw tx from param interface
@@ -722,18 +513,11 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithReqWithdrawRewardPortal(p
 }
 
 func (httpServer *HttpServer) handleGetPortalReward(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	incognitoAddress, ok := data["IncognitoAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("IncognitoAddress is invalid"))
+	rewardParams := getPortalRewardParams{}
+	if err := decodeRPCParams(params, &rewardParams); err != nil {
+		return nil, err
 	}
+	incognitoAddress := rewardParams.Data.IncognitoAddress
 
 	latestBeaconHeight := httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight
 	beaconFeatureStateRootHash, err := httpServer.config.BlockChain.GetBeaconFeatureRootHash(httpServer.config.BlockChain.GetBeaconBestState(), latestBeaconHeight)
@@ -758,18 +542,11 @@ func (httpServer *HttpServer) handleGetPortalReward(params interface{}, closeCha
 }
 
 func (httpServer *HttpServer) handleGetRequestWithdrawPortalRewardStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	reqTxID, ok := data["ReqTxID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param ReqTxID is invalid"))
+	statusParams := getPortalReqStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	reqTxID := statusParams.Data.ReqTxID
 
 	status, err := httpServer.blockService.GetPortalRequestWithdrawRewardStatus(reqTxID)
 	if err != nil {
@@ -779,22 +556,12 @@ func (httpServer *HttpServer) handleGetRequestWithdrawPortalRewardStatus(params
 }
 
 func (httpServer *HttpServer) handleGetRewardFeature(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	featureName, ok := data["FeatureName"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param FeatureName is invalid"))
-	}
-	epoch, err := common.AssertAndConvertStrToNumber(data["Epoch"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	featureParams := getRewardFeatureParams{}
+	if err := decodeRPCParams(params, &featureParams); err != nil {
+		return nil, err
 	}
+	featureName := featureParams.Data.FeatureName
+	epoch := uint64(featureParams.Data.Epoch)
 	if epoch < 1 {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param Epoch must be greater or than 1"))
 	}
@@ -807,29 +574,16 @@ func (httpServer *HttpServer) handleGetRewardFeature(params interface{}, closeCh
 }
 
 func (httpServer *HttpServer) handleCreateRawTxWithReqMatchingRedeem(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-	incognitoAddress, ok := data["CustodianAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata IncognitoAddress is invalid"))
-	}
-	redeemID, ok := data["RedeemID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata RemoteAddresses param is invalid"))
+	matchingParams := createPortalReqMatchingRedeemTxParams{}
+	if err := decodeRPCParams(params, &matchingParams); err != nil {
+		return nil, err
 	}
 
 	meta, _ := metadata.NewPortalReqMatchingRedeem(
 		metadata.PortalReqMatchingRedeemMeta,
-		incognitoAddress,
-		redeemID,
+		matchingParams.Metadata.CustodianAddressStr,
+		matchingParams.Metadata.RedeemID,
 	)
 
 	// create new param to build raw tx from param interface
@@ -876,18 +630,11 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithReqMatchingRedeem(params
 }
 
 func (httpServer *HttpServer) handleGetReqMatchingRedeemByTxIDStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	reqTxID, ok := data["ReqTxID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param ReqTxID is invalid"))
+	statusParams := getPortalReqStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	reqTxID := statusParams.Data.ReqTxID
 
 	status, err := httpServer.blockService.GetReqMatchingRedeemByTxIDStatus(reqTxID)
 	if err != nil {
@@ -14,48 +14,23 @@ import (
 )
 
 func (httpServer *HttpServer) createPortalExchangeRate(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	if len(arrayParams) == 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params should be not empty"))
-	}
-
-	if len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-
-	senderAddress, ok := data["SenderAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata SenderAddress is invalid"))
+	ratesParams := createPortalExchangeRatesTxParams{}
+	if err := decodeRPCParams(params, &ratesParams); err != nil {
+		return nil, err
 	}
+	data := ratesParams.Metadata
 
 	var exchangeRate = make([]*metadata.ExchangeRateInfo, 0)
-
-	exchangeRateMap, ok := data["Rates"].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata Rates is invalid"))
-	}
-
-	if len(exchangeRateMap) <= 0 {
+	if len(data.Rates) <= 0 {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata Rates is invalid"))
 	}
 
-	for pTokenID, value := range exchangeRateMap {
+	for pTokenID, amount := range data.Rates {
 		if !common.IsPortalExchangeRateToken(pTokenID) {
 			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("TokenID is not portal exchange rate token"))
 		}
 
-		amount, err := common.AssertAndConvertStrToNumber(value)
-		if err != nil {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-		}
-
 		if amount <= 0 {
 			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Exchange rates should be larger than 0"))
 		}
@@ -70,7 +45,7 @@ func (httpServer *HttpServer) createPortalExchangeRate(params interface{}, close
 
 	meta, _ := metadata.NewPortalExchangeRates(
 		metadata.PortalExchangeRatesMeta,
-		senderAddress,
+		data.SenderAddress,
 		exchangeRate,
 	)
 
@@ -116,26 +91,12 @@ func (httpServer *HttpServer) handleCreateAndSendPortalExchangeRates(params inte
 }
 
 func (httpServer *HttpServer) handleGetPortalFinalExchangeRates(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	if len(arrayParams) == 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params should be not empty"))
-	}
-
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 1"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-
-	beaconHeight, err := common.AssertAndConvertStrToNumber(data["BeaconHeight"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	ratesParams := getPortalFinalExchangeRatesParams{}
+	if err := decodeRPCParams(params, &ratesParams); err != nil {
+		return nil, err
 	}
+	beaconHeight := uint64(ratesParams.Data.BeaconHeight)
 
 	featureStateRootHash, err := httpServer.config.BlockChain.GetBeaconFeatureRootHash(httpServer.config.BlockChain.GetBeaconBestState(), uint64(beaconHeight))
 	if err != nil {
@@ -156,38 +117,16 @@ func (httpServer *HttpServer) handleGetPortalFinalExchangeRates(params interface
 }
 
 func (httpServer *HttpServer) handleConvertExchangeRates(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	if len(arrayParams) == 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params should be not empty"))
-	}
-
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 1"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-
-	valuePToken, err := common.AssertAndConvertStrToNumber(data["ValuePToken"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
-	tokenID, ok := data["TokenID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is invalid"))
-	}
-
-	beaconHeight, err := common.AssertAndConvertStrToNumber(data["BeaconHeight"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	convertParams := convertExchangeRatesParams{}
+	if err := decodeRPCParams(params, &convertParams); err != nil {
+		return nil, err
 	}
+	valuePToken := uint64(convertParams.Data.ValuePToken)
+	tokenID := convertParams.Data.TokenID
+	beaconHeight := uint64(convertParams.Data.BeaconHeight)
 
-	_, err = httpServer.config.BlockChain.GetBeaconBlockByHeight(uint64(beaconHeight))
+	_, err := httpServer.config.BlockChain.GetBeaconBlockByHeight(uint64(beaconHeight))
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.ConvertExchangeRatesError, err)
 	}
@@ -212,36 +151,14 @@ func (httpServer *HttpServer) handleConvertExchangeRates(params interface{}, clo
 }
 
 func (httpServer *HttpServer) handleGetPortingRequestFees(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	if len(arrayParams) == 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params should be not empty"))
-	}
-
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 1"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-
-	valuePToken, err := common.AssertAndConvertStrToNumber(data["ValuePToken"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
-	tokenID, ok := data["TokenID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is invalid"))
-	}
-
-	beaconHeight, err := common.AssertAndConvertStrToNumber(data["BeaconHeight"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	convertParams := getPortingRequestFeesParams{}
+	if err := decodeRPCParams(params, &convertParams); err != nil {
+		return nil, err
 	}
+	valuePToken := uint64(convertParams.Data.ValuePToken)
+	tokenID := convertParams.Data.TokenID
+	beaconHeight := uint64(convertParams.Data.BeaconHeight)
 
 	if !common.IsPortalToken(tokenID) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is not support"))
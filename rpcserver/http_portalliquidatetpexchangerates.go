@@ -14,31 +14,13 @@ import (
 )
 
 func (httpServer *HttpServer) handleGetLiquidationExchangeRatesPool(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	if len(arrayParams) == 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params should be not empty"))
-	}
-
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 1"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-
-	beaconHeight, err := common.AssertAndConvertStrToNumber(data["BeaconHeight"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
-	pTokenID, ok := data["TokenID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is invalid"))
+	poolParams := getLiquidationExchangeRatesPoolParams{}
+	if err := decodeRPCParams(params, &poolParams); err != nil {
+		return nil, err
 	}
+	beaconHeight := uint64(poolParams.Data.BeaconHeight)
+	pTokenID := poolParams.Data.TokenID
 
 	if !common.IsPortalExchangeRateToken(pTokenID) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is not support"))
@@ -62,34 +44,14 @@ func (httpServer *HttpServer) handleGetLiquidationExchangeRatesPool(params inter
 }
 
 func (httpServer *HttpServer) handleGetAmountNeededForCustodianDepositLiquidation(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) == 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params should be not empty"))
-	}
-
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 1"))
-	}
 	// get meta data from params
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-
-	beaconHeight, err := common.AssertAndConvertStrToNumber(data["BeaconHeight"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
-	custodianAddress, ok := data["CustodianAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata CustodianAddress is invalid"))
-	}
-
-	pTokenID, ok := data["TokenID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is invalid"))
+	amountParams := getAmountNeededForCustodianDepositLiquidationParams{}
+	if err := decodeRPCParams(params, &amountParams); err != nil {
+		return nil, err
 	}
+	beaconHeight := uint64(amountParams.Data.BeaconHeight)
+	custodianAddress := amountParams.Data.CustodianAddress
+	pTokenID := amountParams.Data.TokenID
 
 	if !common.IsPortalToken(pTokenID) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is not support"))
@@ -115,48 +77,16 @@ func (httpServer *HttpServer) handleGetAmountNeededForCustodianDepositLiquidatio
 }
 
 func (httpServer *HttpServer) createRawRedeemLiquidationExchangeRates(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	if len(arrayParams) == 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params should be not empty"))
-	}
-
-	if len(arrayParams) >= 7 {
-		hasPrivacyTokenParam, ok := arrayParams[6].(float64)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("HasPrivacyToken is invalid"))
-		}
-		hasPrivacyToken := int(hasPrivacyTokenParam) > 0
-		if hasPrivacyToken {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("The privacy mode must be disabled"))
-		}
-	}
-
-	if len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
-	}
-
-	tokenParamsRaw, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param metadata is invalid"))
+	redeemParams := createRedeemLiquidationExchangeRatesTxParams{}
+	if err := decodeRPCParams(params, &redeemParams); err != nil {
+		return nil, err
 	}
-
-	redeemTokenID, ok := tokenParamsRaw["RedeemTokenID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("RedeemTokenID is invalid"))
+	if redeemParams.HasPrivacyToken > 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("The privacy mode must be disabled"))
 	}
+	tokenParams := redeemParams.TokenParams
 
-	redeemAmount, err := common.AssertAndConvertStrToNumber(tokenParamsRaw["RedeemAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
-	redeemerIncAddressStr, ok := tokenParamsRaw["RedeemerIncAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("RedeemerIncAddressStr is invalid"))
-	}
-
-	meta, _ := metadata.NewPortalRedeemLiquidateExchangeRates(metadata.PortalRedeemLiquidateExchangeRatesMeta, redeemTokenID, redeemAmount, redeemerIncAddressStr)
+	meta, _ := metadata.NewPortalRedeemLiquidateExchangeRates(metadata.PortalRedeemLiquidateExchangeRatesMeta, tokenParams.RedeemTokenID, uint64(tokenParams.RedeemAmount), tokenParams.RedeemerIncAddressStr)
 
 	customTokenTx, rpcErr := httpServer.txService.BuildRawPrivacyCustomTokenTransactionV2(params, meta)
 	if rpcErr != nil {
@@ -195,50 +125,22 @@ func (httpServer *HttpServer) handleCreateAndSendRedeemLiquidationExchangeRates(
 }
 
 func (httpServer *HttpServer) createLiquidationCustodianDeposit(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) == 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params should be not empty"))
-	}
-
-	if len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-	incognitoAddress, ok := data["IncognitoAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata IncognitoAddress is invalid"))
+	depositParams := createLiquidationCustodianDepositTxParams{}
+	if err := decodeRPCParams(params, &depositParams); err != nil {
+		return nil, err
 	}
-
-	pTokenId, ok := data["PTokenId"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PTokenId param is invalid"))
-	}
-
-	if !common.IsPortalToken(pTokenId) {
+	data := depositParams.Metadata
+	if !common.IsPortalToken(data.PTokenId) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata public token is not supported currently"))
 	}
 
-	freeCollateralAmount, err := common.AssertAndConvertStrToNumber(data["FreeCollateralAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
-	depositedAmount, err := common.AssertAndConvertStrToNumber(data["DepositedAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
 	meta, _ := metadata.NewPortalLiquidationCustodianDepositV2(
 		metadata.PortalLiquidationCustodianDepositMetaV2,
-		incognitoAddress,
-		pTokenId,
-		depositedAmount,
-		freeCollateralAmount,
+		data.IncognitoAddress,
+		data.PTokenId,
+		uint64(data.DepositedAmount),
+		uint64(data.FreeCollateralAmount),
 	)
 
 	// create new param to build raw tx from param interface
@@ -286,56 +188,23 @@ func (httpServer *HttpServer) handleCreateAndSendLiquidationCustodianDeposit(par
 }
 
 func (httpServer *HttpServer) createTopUpWaitingPorting(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) == 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params should be not empty"))
-	}
-
-	if len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-	portingID, ok := data["PortingID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PortingID is invalid"))
-	}
-
-	incognitoAddress, ok := data["IncognitoAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata IncognitoAddress is invalid"))
+	topUpParams := createTopUpWaitingPortingTxParams{}
+	if err := decodeRPCParams(params, &topUpParams); err != nil {
+		return nil, err
 	}
-
-	pTokenId, ok := data["PTokenId"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PTokenId param is invalid"))
-	}
-
-	if !common.IsPortalToken(pTokenId) {
+	data := topUpParams.Metadata
+	if !common.IsPortalToken(data.PTokenId) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata public token is not supported currently"))
 	}
 
-	freeCollateralAmount, err := common.AssertAndConvertStrToNumber(data["FreeCollateralAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
-	depositedAmount, err := common.AssertAndConvertStrToNumber(data["DepositedAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
 	meta, _ := metadata.NewPortalTopUpWaitingPortingRequest(
 		metadata.PortalTopUpWaitingPortingRequestMeta,
-		portingID,
-		incognitoAddress,
-		pTokenId,
-		depositedAmount,
-		freeCollateralAmount,
+		data.PortingID,
+		data.IncognitoAddress,
+		data.PTokenId,
+		uint64(data.DepositedAmount),
+		uint64(data.FreeCollateralAmount),
 	)
 
 	// create new param to build raw tx from param interface
@@ -383,18 +252,11 @@ func (httpServer *HttpServer) handleCreateAndSendTopUpWaitingPorting(params inte
 }
 
 func (httpServer *HttpServer) handleGetPortalCustodianTopupStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	txID, ok := data["TxID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param TxID is invalid"))
+	statusParams := getPortalCustodianTopupStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	txID := statusParams.Data.TxID
 
 	status, err := httpServer.blockService.GetCustodianTopupStatus(txID)
 	if err != nil {
@@ -404,18 +266,11 @@ func (httpServer *HttpServer) handleGetPortalCustodianTopupStatus(params interfa
 }
 
 func (httpServer *HttpServer) handleGetPortalCustodianTopupWaitingPortingStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	txID, ok := data["TxID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param TxID is invalid"))
+	statusParams := getPortalCustodianTopupStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	txID := statusParams.Data.TxID
 
 	status, err := httpServer.blockService.GetCustodianTopupWaitingPortingStatus(txID)
 	if err != nil {
@@ -425,18 +280,11 @@ func (httpServer *HttpServer) handleGetPortalCustodianTopupWaitingPortingStatus(
 }
 
 func (httpServer *HttpServer) handleGetAmountTopUpWaitingPorting(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	custodianAddr, ok := data["CustodianAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param CustodianAddress is invalid"))
+	amountParams := getAmountTopUpWaitingPortingParams{}
+	if err := decodeRPCParams(params, &amountParams); err != nil {
+		return nil, err
 	}
+	custodianAddr := amountParams.Data.CustodianAddress
 
 	result, err := httpServer.blockService.GetAmountTopUpWaitingPorting(custodianAddr)
 	if err != nil {
@@ -446,18 +294,11 @@ func (httpServer *HttpServer) handleGetAmountTopUpWaitingPorting(params interfac
 }
 
 func (httpServer *HttpServer) handleGetReqRedeemFromLiquidationPoolByTxIDStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	reqTxID, ok := data["ReqTxID"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param ReqTxID is invalid"))
+	statusParams := getPortalReqStatusParams{}
+	if err := decodeRPCParams(params, &statusParams); err != nil {
+		return nil, err
 	}
+	reqTxID := statusParams.Data.ReqTxID
 	status, err := httpServer.blockService.GetRedeemReqFromLiquidationPoolByTxIDStatus(reqTxID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetReqRedeemFromLiquidationPoolStatusError, err)
@@ -12,58 +12,23 @@ import (
 )
 
 func (httpServer *HttpServer) createRegisterPortingPublicTokens(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	if len(arrayParams) == 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params should be not empty"))
-	}
-
-	if len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
-	}
-
-	uniqueRegisterId, ok := data["UniqueRegisterId"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata UniqueRegisterId is invalid"))
-	}
-
-	incogAddressStr, ok := data["IncogAddressStr"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata IncogAddressStr is invalid"))
-	}
-
-	pTokenId, ok := data["PTokenId"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PTokenId is invalid"))
+	registerParams := createRegisterPortingPublicTokensTxParams{}
+	if err := decodeRPCParams(params, &registerParams); err != nil {
+		return nil, err
 	}
-
-	if !common.IsPortalToken(pTokenId) {
+	data := registerParams.Metadata
+	if !common.IsPortalToken(data.PTokenId) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata public token is not supported currently"))
 	}
 
-	registerAmount, err := common.AssertAndConvertStrToNumber(data["RegisterAmount"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
-	portingFee, err := common.AssertAndConvertStrToNumber(data["PortingFee"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
-	}
-
 	//check exchange rates
 	meta, _ := metadata.NewPortalUserRegister(
-		uniqueRegisterId,
-		incogAddressStr,
-		pTokenId,
-		registerAmount,
-		portingFee,
+		data.UniqueRegisterId,
+		data.IncogAddressStr,
+		data.PTokenId,
+		uint64(data.RegisterAmount),
+		uint64(data.PortingFee),
 		metadata.PortalUserRegisterMeta,
 	)
 
@@ -110,26 +75,12 @@ func (httpServer *HttpServer) handleCreateAndSendRegisterPortingPublicTokens(par
 }
 
 func (httpServer *HttpServer) handleGetPortingRequestByKey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	if len(arrayParams) == 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params should be not empty"))
-	}
-
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 1"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-
-	txHash, ok := data["TxHash"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PortingRequestId is invalid"))
+	portingParams := getPortingRequestByKeyParams{}
+	if err := decodeRPCParams(params, &portingParams); err != nil {
+		return nil, err
 	}
+	txHash := portingParams.Data.TxHash
 
 	result, err := httpServer.portal.GetPortingRequestByByKey(txHash)
 
@@ -141,26 +92,12 @@ func (httpServer *HttpServer) handleGetPortingRequestByKey(params interface{}, c
 }
 
 func (httpServer *HttpServer) handleGetPortingRequestByPortingId(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-
-	if len(arrayParams) == 0 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params should be not empty"))
-	}
-
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 1"))
-	}
-
 	// get meta data from params
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-
-	portingId, ok := data["PortingId"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata Porting Id is invalid"))
+	portingParams := getPortingRequestByPortingIdParams{}
+	if err := decodeRPCParams(params, &portingParams); err != nil {
+		return nil, err
 	}
+	portingId := portingParams.Data.PortingId
 
 	result, err := httpServer.portal.GetPortingRequestByByPortingId(portingId)
 
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
-	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/metadata"
 	bnbrelaying "github.com/incognitochain/incognito-chain/relaying/bnb"
@@ -35,36 +34,17 @@ func (httpServer *HttpServer) handleCreateRawTxWithRelayingHeader(
 	params interface{},
 	closeChan <-chan struct{},
 ) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
-	}
-
-	// get meta data from params
-	data, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-	senderAddress, ok := data["SenderAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata SenderAddress is invalid"))
-	}
-	// base64encode(marshalbytes), header + lastcommit
-	header, ok := data["Header"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata Header param is invalid"))
-	}
-
-	blockHeight, err := common.AssertAndConvertStrToNumber(data["BlockHeight"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	relayingParams := createRelayingHeaderTxParams{}
+	if err := decodeRPCParams(params, &relayingParams); err != nil {
+		return nil, err
 	}
+	data := relayingParams.Metadata
 
 	meta, _ := metadata.NewRelayingHeader(
 		metaType,
-		senderAddress,
-		header,
-		blockHeight,
+		data.SenderAddress,
+		data.Header,
+		uint64(data.BlockHeight),
 	)
 
 	// create new param to build raw tx from param interface
@@ -149,20 +129,12 @@ func (httpServer *HttpServer) handleGetRelayingBNBHeaderState(params interface{}
 }
 
 func (httpServer *HttpServer) handleGetRelayingBNBHeaderByBlockHeight(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
-	}
-	data, ok := arrayParams[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
-	}
-	blockHeight, err := common.AssertAndConvertStrToNumber(data["BlockHeight"])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	headerParams := getRelayingBNBHeaderByBlockHeightParams{}
+	if err := decodeRPCParams(params, &headerParams); err != nil {
+		return nil, err
 	}
 
-	block, err := httpServer.config.BlockChain.GetBNBBlockByHeight(int64(blockHeight))
+	block, err := httpServer.config.BlockChain.GetBNBBlockByHeight(int64(headerParams.Data.BlockHeight))
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetRelayingBNBHeaderByBlockHeightError, err)
 	}
@@ -194,18 +166,12 @@ func (httpServer *HttpServer) handleGetBTCBlockByHash(params interface{}, closeC
 	if btcChain == nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetBTCBlockByHash, errors.New("BTC relaying chain should not be null"))
 	}
-	arrayParams := common.InterfaceSlice(params)
-	if len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 1"))
-	}
-
-	// get meta data from params
-	btcBlockHashStr, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("BTC block hash param is invalid"))
+	blockParams := btcBlockHashParams{}
+	if err := decodeRPCParams(params, &blockParams); err != nil {
+		return nil, err
 	}
 
-	blkHash, err := chainhash.NewHashFromStr(btcBlockHashStr)
+	blkHash, err := chainhash.NewHashFromStr(blockParams.BTCBlockHash)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetBTCBlockByHash, err)
 	}
@@ -47,15 +47,11 @@ func (httpServer *HttpServer) handleGetLatestBeaconSwapProof(params interface{},
 // handleGetBeaconSwapProof returns a proof of a new beacon committee (for a given bridge block height)
 func (httpServer *HttpServer) handleGetBeaconSwapProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	Logger.log.Infof("handleGetBeaconSwapProof params: %+v", params)
-	listParams, ok := params.([]interface{})
-	if !ok || len(listParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
-	}
-	heightParam, ok := listParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("height param is invalid"))
+	proofParams := beaconHeightParams{}
+	if err := decodeRPCParams(params, &proofParams); err != nil {
+		return nil, err
 	}
-	beaconHeigh := uint64(heightParam)
+	beaconHeigh := proofParams.BeaconHeight
 	// Get proof of instruction on beacon
 	beaconInstProof, _, errProof := getSwapProofOnBeacon(beaconHeigh, httpServer.config.BlockChain, httpServer.config.ConsensusEngine, metadata.BeaconSwapConfirmMeta)
 	if errProof != nil {
@@ -29,16 +29,11 @@ func (httpServer *HttpServer) handleGetLatestBridgeSwapProof(params interface{},
 // handleGetBridgeSwapProof returns a proof of a new bridge committee (for a given beacon block height)
 func (httpServer *HttpServer) handleGetBridgeSwapProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	Logger.log.Infof("handleGetBridgeSwapProof params: %+v", params)
-	listParams, ok := params.([]interface{})
-	if !ok || len(listParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	proofParams := beaconHeightParams{}
+	if err := decodeRPCParams(params, &proofParams); err != nil {
+		return nil, err
 	}
-
-	heightParam, ok := listParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("height param is invalid"))
-	}
-	beaconHeigh := uint64(heightParam)
+	beaconHeigh := proofParams.BeaconHeight
 	// Get proof of instruction on beacon
 	beaconInstProof, beaconBlock, errProof := getSwapProofOnBeacon(beaconHeigh, httpServer.config.BlockChain, httpServer.config.ConsensusEngine, metadata.BridgeSwapConfirmMeta)
 	if errProof != nil {
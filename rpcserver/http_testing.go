@@ -37,9 +37,11 @@ func (httpServer *HttpServer) handleUnlockMempool(params interface{}, closeChan
 }
 
 func (httpServer *HttpServer) handleGetAutoStakingByHeight(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	height := int(arrayParams[0].(float64))
-	beaconConsensusStateRootHash, err := httpServer.blockService.BlockChain.GetBeaconConsensusRootHash(httpServer.blockService.BlockChain.GetBeaconBestState(), uint64(height))
+	heightParams := beaconHeightParams{}
+	if err := decodeRPCParams(params, &heightParams); err != nil {
+		return nil, err
+	}
+	beaconConsensusStateRootHash, err := httpServer.blockService.BlockChain.GetBeaconConsensusRootHash(httpServer.blockService.BlockChain.GetBeaconBestState(), heightParams.BeaconHeight)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -53,21 +55,25 @@ func (httpServer *HttpServer) handleGetAutoStakingByHeight(params interface{}, c
 }
 
 func (httpServer *HttpServer) handleGetRewardAmountByEpoch(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	shardID := byte(arrayParams[0].(float64))
-	epoch := uint64(arrayParams[1].(float64))
+	rewardParams := getRewardAmountByEpochParams{}
+	if err := decodeRPCParams(params, &rewardParams); err != nil {
+		return nil, err
+	}
 	rewardStateDB := httpServer.config.BlockChain.GetBeaconBestState().GetBeaconRewardStateDB()
-	amount, err := statedb.GetRewardOfShardByEpoch(rewardStateDB, epoch, shardID, common.PRVCoinID)
+	amount, err := statedb.GetRewardOfShardByEpoch(rewardStateDB, rewardParams.Epoch, byte(rewardParams.ShardID), common.PRVCoinID)
 	return amount, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 }
 
 func (httpServer *HttpServer) handleGetAndSendTxsFromFile(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	shardIDParam := int(arrayParams[0].(float64))
-	Logger.log.Critical(arrayParams)
-	txType := arrayParams[1].(string)
-	isSent := arrayParams[2].(bool)
-	interval := int64(arrayParams[3].(float64))
+	fileParams := getAndSendTxsFromFileParams{}
+	if err := decodeRPCParams(params, &fileParams); err != nil {
+		return nil, err
+	}
+	Logger.log.Critical(params)
+	shardIDParam := fileParams.ShardID
+	txType := fileParams.TxType
+	isSent := fileParams.IsSent
+	interval := fileParams.Interval
 	Logger.log.Criticalf("Interval between transactions %+v \n", interval)
 	datadir := "./bin/"
 	filename := ""
@@ -206,12 +212,15 @@ func (httpServer *HttpServer) handleGetAndSendTxsFromFile(params interface{}, cl
 }
 
 func (httpServer *HttpServer) handleGetAndSendTxsFromFileV2(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	Logger.log.Critical(arrayParams)
-	shardIDParam := int(arrayParams[0].(float64))
-	txType := arrayParams[1].(string)
-	isSent := arrayParams[2].(bool)
-	interval := int64(arrayParams[3].(float64))
+	fileParams := getAndSendTxsFromFileParams{}
+	if err := decodeRPCParams(params, &fileParams); err != nil {
+		return nil, err
+	}
+	Logger.log.Critical(params)
+	shardIDParam := fileParams.ShardID
+	txType := fileParams.TxType
+	isSent := fileParams.IsSent
+	interval := fileParams.Interval
 	Logger.log.Criticalf("Interval between transactions %+v \n", interval)
 	datadir := "./utility/"
 	Txs := []string{}
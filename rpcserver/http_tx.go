@@ -12,7 +12,9 @@ import (
 	"github.com/incognitochain/incognito-chain/rpcserver/bean"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/txrelay"
 	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/incognitochain/incognito-chain/wire"
 )
 
 // handleCreateTransaction handles createtransaction commands.
@@ -23,6 +25,18 @@ func (httpServer *HttpServer) handleCreateRawTransaction(params interface{}, clo
 	if errNewParam != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
 	}
+	// param #7: ring size of the one-out-of-many proofs (optional)
+	if errNewParam = createRawTxParam.SetCommitmentRingSize(params, 6); errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+	// param #8: output coins with one-time public keys (optional)
+	if errNewParam = createRawTxParam.SetStealth(params, 7); errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+	// param #9: memos encrypted to receivers (optional)
+	if errNewParam = createRawTxParam.SetMemos(params, 8); errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
 
 	txHash, txBytes, txShardID, err := httpServer.txService.CreateRawTransaction(createRawTxParam, nil)
 	if err != nil {
@@ -34,38 +48,144 @@ func (httpServer *HttpServer) handleCreateRawTransaction(params interface{}, clo
 	return result, nil
 }
 
+// handleCreateUnsignedTransaction - build a PRV tx from payment address and readonly key of sender,
+// the returned template is signed offline with the private key and sent by sendtransaction
+func (httpServer *HttpServer) handleCreateUnsignedTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	unsignedTxParams := createUnsignedTxParams{}
+	if err := decodeRPCParams(params, &unsignedTxParams); err != nil {
+		return nil, err
+	}
+	createUnsignedTxParam, errNewParam := bean.NewCreateUnsignedTxParam(
+		unsignedTxParams.PaymentAddress,
+		unsignedTxParams.ReadonlyKey,
+		unsignedTxParams.Receivers,
+		unsignedTxParams.Fee,
+		unsignedTxParams.Privacy > 0,
+		unsignedTxParams.Info,
+		unsignedTxParams.SerialNumbers,
+		unsignedTxParams.RingSize,
+		unsignedTxParams.Stealth,
+		unsignedTxParams.Memos,
+	)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	template, err := httpServer.txService.BuildUnsignedTransaction(createUnsignedTxParam)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil, err
+	}
+	result := jsonresult.CreateUnsignedTransactionResult{
+		Template: template,
+		Fee:      template.Fee,
+		ShardID:  createUnsignedTxParam.ShardIDSender,
+	}
+	return result, nil
+}
+
+// handleCreateUnsignedPrivacyCustomTokenTransaction - build a privacy token transfer from payment address and
+// readonly key of sender, the returned template is signed offline and sent by sendrawprivacycustomtokentransaction
+func (httpServer *HttpServer) handleCreateUnsignedPrivacyCustomTokenTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	unsignedTxParams := createUnsignedPrivacyTokenTxParams{HasPrivacyToken: 1}
+	if err := decodeRPCParams(params, &unsignedTxParams); err != nil {
+		return nil, err
+	}
+	createUnsignedTxParam, errNewParam := bean.NewCreateUnsignedPrivacyTokenTxParam(
+		unsignedTxParams.PaymentAddress,
+		unsignedTxParams.ReadonlyKey,
+		unsignedTxParams.Receivers,
+		unsignedTxParams.Fee,
+		unsignedTxParams.Privacy > 0,
+		unsignedTxParams.Info,
+		unsignedTxParams.SerialNumbers,
+		unsignedTxParams.TokenID,
+		unsignedTxParams.TokenReceivers,
+		unsignedTxParams.TokenFee,
+		unsignedTxParams.HasPrivacyToken > 0,
+		unsignedTxParams.TokenSerialNumbers,
+		unsignedTxParams.Memos,
+	)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	template, err := httpServer.txService.BuildUnsignedPrivacyTokenTransaction(createUnsignedTxParam)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil, err
+	}
+	result := jsonresult.CreateUnsignedPrivacyTokenTransactionResult{
+		Template: template,
+		Fee:      template.Template.Fee,
+		TokenFee: template.TokenTemplate.Fee,
+		ShardID:  createUnsignedTxParam.ShardIDSender,
+	}
+	return result, nil
+}
+
 // handleSendTransaction implements the sendtransaction command.
 // Parameter #1—a serialized transaction to broadcast
-// Parameter #2–whether to allow high fees
+// Parameter #2—optional relay mode: public to broadcast the transaction, private to relay it along a stem of single
+// peers before it is broadcast, the relay mode of the node by default
 // Result—a TXID or error Message
 func (httpServer *HttpServer) handleSendRawTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	sendParams := sendRawTransactionParams{}
+	if err := decodeRPCParams(params, &sendParams); err != nil {
+		return nil, err
 	}
-
-	base58CheckData, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("base58 check data is invalid"))
+	relayMode, err := httpServer.getTxRelayMode(sendParams.RelayMode)
+	if err != nil {
+		return nil, err
 	}
 
-	txMsg, txHash, LastBytePubKeySender, err := httpServer.txService.SendRawTransaction(base58CheckData)
+	txMsg, txHash, LastBytePubKeySender, err := httpServer.txService.SendRawTransaction(sendParams.Base58CheckData, relayMode == txrelay.ModePrivate)
 	if err != nil {
 		return nil, err
 	}
 
-	err2 := httpServer.config.Server.PushMessageToAll(txMsg)
-	if err2 == nil {
-		Logger.log.Info("handleSendRawTransaction broadcast message to all successfully")
-		httpServer.config.TxMemPool.MarkForwardedTransaction(*txHash)
+	if relayMode == txrelay.ModePrivate {
+		if err := httpServer.relayPrivateTransaction(txMsg.(*wire.MessageTx).Transaction); err != nil {
+			return nil, err
+		}
 	} else {
-		Logger.log.Errorf("handleSendRawTransaction broadcast message to all with error %+v", err2)
+		err2 := httpServer.config.Server.PushMessageToAll(txMsg)
+		if err2 == nil {
+			Logger.log.Info("handleSendRawTransaction broadcast message to all successfully")
+			httpServer.config.TxMemPool.MarkForwardedTransaction(*txHash)
+		} else {
+			Logger.log.Errorf("handleSendRawTransaction broadcast message to all with error %+v", err2)
+		}
 	}
 
 	result := jsonresult.NewCreateTransactionResult(txHash, common.EmptyString, nil, common.GetShardIDFromLastByte(LastBytePubKeySender))
 	return result, nil
 }
 
+// getTxRelayMode - relay mode of a sent transaction from its optional param, the relay mode of the node when it is
+// missing
+func (httpServer *HttpServer) getTxRelayMode(relayMode string) (string, *rpcservice.RPCError) {
+	if relayMode == "" {
+		return httpServer.config.TxRelayMode, nil
+	}
+	if !txrelay.IsValidMode(relayMode) {
+		return "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("relay mode must be %s or %s", txrelay.ModePublic, txrelay.ModePrivate))
+	}
+	return relayMode, nil
+}
+
+// relayPrivateTransaction - forward a transaction of the mempool to the stem peer of the node, it leaves the mempool
+// when it can not be relayed
+func (httpServer *HttpServer) relayPrivateTransaction(tx metadata.Transaction) *rpcservice.RPCError {
+	err := httpServer.config.TxRelay.RelayLocal(tx)
+	if err != nil {
+		Logger.log.Errorf("Relay tx %+v privately with error %+v", tx.Hash().String(), err)
+		httpServer.config.TxMemPool.RemoveTx([]metadata.Transaction{tx}, false)
+		return rpcservice.NewRPCError(rpcservice.SendTxDataError, err)
+	}
+	return nil
+}
+
 // handleCreateAndSendTx - RPC creates transaction and send to network
 func (httpServer *HttpServer) handleCreateAndSendTx(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	var err error
@@ -86,17 +206,12 @@ func (httpServer *HttpServer) handleCreateAndSendTx(params interface{}, closeCha
 }
 
 func (httpServer *HttpServer) handleGetTransactionHashByReceiver(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
-	}
-
-	paymentAddress, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payment address"))
+	receiverParams := paymentAddressParams{}
+	if err := decodeRPCParams(params, &receiverParams); err != nil {
+		return nil, err
 	}
 
-	result, err := httpServer.txService.GetTransactionHashByReceiver(paymentAddress)
+	result, err := httpServer.txService.GetTransactionHashByReceiver(receiverParams.PaymentAddress)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -105,19 +220,17 @@ func (httpServer *HttpServer) handleGetTransactionHashByReceiver(params interfac
 }
 
 func (httpServer *HttpServer) handleGetTransactionByReceiver(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	paramsArray := common.InterfaceSlice(params)
-	keys, ok := paramsArray[0].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("key param is invalid"))
+	receiverParams := getTransactionByReceiverParams{}
+	if err := decodeRPCParams(params, &receiverParams); err != nil {
+		return nil, err
 	}
 
 	// create a key set
 	keySet := incognitokey.KeySet{}
 
 	// get keyset only contain readonly-key by deserializing
-	readonlyKeyStr, ok := keys["ReadonlyKey"].(string)
-	if ok {
-		readonlyKey, err := wallet.Base58CheckDeserialize(readonlyKeyStr)
+	if receiverParams.Keys.ReadonlyKey != "" {
+		readonlyKey, err := wallet.Base58CheckDeserialize(receiverParams.Keys.ReadonlyKey)
 		if err != nil {
 			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 		}
@@ -125,9 +238,8 @@ func (httpServer *HttpServer) handleGetTransactionByReceiver(params interface{},
 	}
 
 	// get keyset only contain payment address by deserializing
-	paymentAddressStr, ok := keys["PaymentAddress"].(string)
-	if ok {
-		paymentAddress, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	if receiverParams.Keys.PaymentAddress != "" {
+		paymentAddress, err := wallet.Base58CheckDeserialize(receiverParams.Keys.PaymentAddress)
 		if err != nil {
 			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 		}
@@ -141,33 +253,35 @@ func (httpServer *HttpServer) handleGetTransactionByReceiver(params interface{},
 
 // Get transaction by Hash
 func (httpServer *HttpServer) handleGetTransactionByHash(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	// param #1: transaction Hash
+	txParams := txHashParams{}
+	if err := decodeRPCParams(params, &txParams); err != nil {
+		return nil, err
 	}
+	return httpServer.txService.GetTransactionByHash(txParams.TxHash)
+}
 
+// handleGetCrossShardTxStatus - return the status of the outputs of a transaction sent to other shards
+func (httpServer *HttpServer) handleGetCrossShardTxStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	// param #1: transaction Hash
-	txHashStr, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Tx hash is invalid"))
+	txParams := txHashParams{}
+	if err := decodeRPCParams(params, &txParams); err != nil {
+		return nil, err
 	}
-	return httpServer.txService.GetTransactionByHash(txHashStr)
+	return httpServer.txService.GetCrossShardTxStatus(txParams.TxHash)
 }
 
 // handleGetListPrivacyCustomTokenBalance - return list privacy token + balance for one account payment address
 func (httpServer *HttpServer) handleGetListPrivacyCustomTokenBalance(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	keyParams := privateKeyParams{}
+	if err := decodeRPCParams(params, &keyParams); err != nil {
+		return nil, err
 	}
-
-	privateKey, ok := arrayParams[0].(string)
-	if len(privateKey) == 0 || !ok {
+	if len(keyParams.PrivateKey) == 0 {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param is invalid"))
 	}
 
-	result, err := httpServer.txService.GetListPrivacyCustomTokenBalance(privateKey)
+	result, err := httpServer.txService.GetListPrivacyCustomTokenBalance(keyParams.PrivateKey)
 	if err != nil {
 		return nil, err
 	}
@@ -176,22 +290,24 @@ func (httpServer *HttpServer) handleGetListPrivacyCustomTokenBalance(params inte
 
 // handleGetListPrivacyCustomTokenBalance - return list privacy token + balance for one account payment address
 func (httpServer *HttpServer) handleGetBalancePrivacyCustomToken(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 2 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	balanceParams := getBalancePrivacyCustomTokenParams{}
+	if err := decodeRPCParams(params, &balanceParams); err != nil {
+		return nil, err
 	}
-
-	privateKey, ok := arrayParams[0].(string)
-	if len(privateKey) == 0 || !ok {
+	if len(balanceParams.PrivateKey) == 0 {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("private key is invalid"))
 	}
-
-	tokenID, ok := arrayParams[1].(string)
-	if len(tokenID) == 0 || !ok {
+	if len(balanceParams.TokenID) == 0 {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("tokenID is invalid"))
 	}
 
-	totalValue, err2 := httpServer.txService.GetBalancePrivacyCustomToken(privateKey, tokenID)
+	// param #3: optional commitment, best, finalized, a block height or a block hash
+	commitment, errC := httpServer.getCommitmentParam(balanceParams.Commitment)
+	if errC != nil {
+		return nil, errC
+	}
+
+	totalValue, err2 := httpServer.txService.GetBalancePrivacyCustomToken(balanceParams.PrivateKey, balanceParams.TokenID, commitment)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -201,15 +317,11 @@ func (httpServer *HttpServer) handleGetBalancePrivacyCustomToken(params interfac
 
 // handlePrivacyCustomTokenDetail - return list tx which relate to privacy custom token by token id
 func (httpServer *HttpServer) handlePrivacyCustomTokenDetail(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("param must be an array at least 1 element"))
-	}
-
-	tokenIDTemp, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("tokenID is invalid"))
+	tokenParams := tokenIDParams{}
+	if err := decodeRPCParams(params, &tokenParams); err != nil {
+		return nil, err
 	}
+	tokenIDTemp := tokenParams.TokenID
 
 	txs, tokenData, err := httpServer.txService.PrivacyCustomTokenDetail(tokenIDTemp)
 	if err != nil {
@@ -245,44 +357,21 @@ func (httpServer *HttpServer) handlePrivacyCustomTokenDetail(params interface{},
 
 // handleRandomCommitments - from input of outputcoin, random to create data for create new tx
 func (httpServer *HttpServer) handleRandomCommitments(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 2 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 element"))
-	}
-
-	// #1: payment address
-	paymentAddressStr, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("PaymentAddress is invalid"))
-	}
-
-	// #2: available inputCoin from old outputcoin
-	outputs, ok := arrayParams[1].([]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("outputs is invalid"))
+	randomParams := randomCommitmentsParams{}
+	if err := decodeRPCParams(params, &randomParams); err != nil {
+		return nil, err
 	}
-	if len(outputs) == 0 {
+	if len(randomParams.Outputs) == 0 {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("len of outputs must be greater than zero"))
 	}
 
 	//#3 - tokenID - default PRV
-	tokenID := &common.Hash{}
-	err := tokenID.SetBytes(common.PRVCoinID[:])
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.TokenIsInvalidError, err)
-	}
-	if len(arrayParams) > 2 {
-		tokenIDTemp, ok := arrayParams[2].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("tokenID is invalid"))
-		}
-		tokenID, err = common.Hash{}.NewHashFromStr(tokenIDTemp)
-		if err != nil {
-			return nil, rpcservice.NewRPCError(rpcservice.ListTokenNotFoundError, err)
-		}
+	tokenID, errT := getTokenIDParam(randomParams.TokenID)
+	if errT != nil {
+		return nil, errT
 	}
 
-	commitmentIndexs, myCommitmentIndexs, commitments, err2 := httpServer.txService.RandomCommitments(paymentAddressStr, outputs, tokenID)
+	commitmentIndexs, myCommitmentIndexs, commitments, err2 := httpServer.txService.RandomCommitments(randomParams.PaymentAddress, randomParams.Outputs, tokenID)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -293,33 +382,15 @@ func (httpServer *HttpServer) handleRandomCommitments(params interface{}, closeC
 
 // handleListSerialNumbers - return list all serialnumber in shard for token ID
 func (httpServer *HttpServer) handleListSerialNumbers(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	var err error
-	tokenID := &common.Hash{}
-	err = tokenID.SetBytes(common.PRVCoinID[:]) // default is PRV coin
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.TokenIsInvalidError, err)
-	}
-	if len(arrayParams) > 0 {
-		tokenIDTemp, ok := arrayParams[0].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("serialNumbers is invalid"))
-		}
-		if len(tokenIDTemp) > 0 {
-			tokenID, err = (common.Hash{}).NewHashFromStr(tokenIDTemp)
-			if err != nil {
-				return nil, rpcservice.NewRPCError(rpcservice.ListTokenNotFoundError, err)
-			}
-		}
+	listParams := listByTokenParams{}
+	if err := decodeRPCParams(params, &listParams); err != nil {
+		return nil, err
 	}
-	shardID := 0
-	if len(arrayParams) > 1 {
-		shardIDParam, ok := arrayParams[1].(float64)
-		if ok {
-			shardID = int(shardIDParam)
-		}
+	tokenID, errT := getTokenIDParam(listParams.TokenID)
+	if errT != nil {
+		return nil, errT
 	}
-	result, err := httpServer.txService.ListSerialNumbers(*tokenID, byte(shardID))
+	result, err := httpServer.txService.ListSerialNumbers(*tokenID, byte(listParams.ShardID))
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.ListTokenNotFoundError, err)
 	}
@@ -328,33 +399,15 @@ func (httpServer *HttpServer) handleListSerialNumbers(params interface{}, closeC
 
 // handleListSerialNumbers - return list all serialnumber in shard for token ID
 func (httpServer *HttpServer) handleListSNDerivator(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	var err error
-	tokenID := &common.Hash{}
-	err = tokenID.SetBytes(common.PRVCoinID[:]) // default is PRV coin
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.TokenIsInvalidError, err)
-	}
-	if len(arrayParams) > 0 {
-		tokenIDTemp, ok := arrayParams[0].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("serialNumbers is invalid"))
-		}
-		if len(tokenIDTemp) > 0 {
-			tokenID, err = (common.Hash{}).NewHashFromStr(tokenIDTemp)
-			if err != nil {
-				return nil, rpcservice.NewRPCError(rpcservice.ListTokenNotFoundError, err)
-			}
-		}
+	listParams := listByTokenParams{}
+	if err := decodeRPCParams(params, &listParams); err != nil {
+		return nil, err
 	}
-	shardID := 0
-	if len(arrayParams) > 1 {
-		shardIDParam, ok := arrayParams[1].(float64)
-		if ok {
-			shardID = int(shardIDParam)
-		}
+	tokenID, errT := getTokenIDParam(listParams.TokenID)
+	if errT != nil {
+		return nil, errT
 	}
-	result, err := httpServer.txService.ListSNDerivator(*tokenID, byte(shardID))
+	result, err := httpServer.txService.ListSNDerivator(*tokenID, byte(listParams.ShardID))
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.ListTokenNotFoundError, err)
 	}
@@ -363,33 +416,15 @@ func (httpServer *HttpServer) handleListSNDerivator(params interface{}, closeCha
 
 // handleListCommitments - return list all commitments in shard for token ID
 func (httpServer *HttpServer) handleListCommitments(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	var err error
-	tokenID := &common.Hash{}
-	err = tokenID.SetBytes(common.PRVCoinID[:]) // default is PRV coin
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.TokenIsInvalidError, err)
-	}
-	if len(arrayParams) > 0 {
-		tokenIDTemp, ok := arrayParams[0].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("serialNumbers is invalid"))
-		}
-		if len(tokenIDTemp) > 0 {
-			tokenID, err = (common.Hash{}).NewHashFromStr(tokenIDTemp)
-			if err != nil {
-				return nil, rpcservice.NewRPCError(rpcservice.ListTokenNotFoundError, err)
-			}
-		}
+	listParams := listByTokenParams{}
+	if err := decodeRPCParams(params, &listParams); err != nil {
+		return nil, err
 	}
-	shardID := byte(0)
-	if len(arrayParams) > 1 {
-		shardIDParam, ok := arrayParams[1].(float64)
-		if ok {
-			shardID = byte(shardIDParam)
-		}
+	tokenID, errT := getTokenIDParam(listParams.TokenID)
+	if errT != nil {
+		return nil, errT
 	}
-	result, err := httpServer.txService.ListCommitments(*tokenID, shardID)
+	result, err := httpServer.txService.ListCommitments(*tokenID, byte(listParams.ShardID))
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.ListTokenNotFoundError, err)
 	}
@@ -398,34 +433,15 @@ func (httpServer *HttpServer) handleListCommitments(params interface{}, closeCha
 
 // handleListCommitmentIndices - return list all commitment indices in shard for token ID
 func (httpServer *HttpServer) handleListCommitmentIndices(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	var err error
-	tokenID := &common.Hash{}
-	err = tokenID.SetBytes(common.PRVCoinID[:]) // default is PRV coin
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.TokenIsInvalidError, err)
-	}
-	if len(arrayParams) > 0 {
-		tokenIDTemp, ok := arrayParams[0].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("serialNumbers is invalid"))
-		}
-		if len(tokenIDTemp) > 0 {
-			tokenID, err = (common.Hash{}).NewHashFromStr(tokenIDTemp)
-			if err != nil {
-				return nil, rpcservice.NewRPCError(rpcservice.ListTokenNotFoundError, err)
-			}
-		}
+	listParams := listByTokenParams{}
+	if err := decodeRPCParams(params, &listParams); err != nil {
+		return nil, err
 	}
-	shardID := byte(0)
-	if len(arrayParams) > 1 {
-		shardIDParam, ok := arrayParams[1].(float64)
-		if ok {
-			shardID = byte(shardIDParam)
-		}
+	tokenID, errT := getTokenIDParam(listParams.TokenID)
+	if errT != nil {
+		return nil, errT
 	}
-
-	result, err := httpServer.txService.ListCommitmentIndices(*tokenID, shardID)
+	result, err := httpServer.txService.ListCommitmentIndices(*tokenID, byte(listParams.ShardID))
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.ListTokenNotFoundError, err)
 	}
@@ -434,41 +450,16 @@ func (httpServer *HttpServer) handleListCommitmentIndices(params interface{}, cl
 
 // handleHasSerialNumbers - check list serial numbers existed in db of node
 func (httpServer *HttpServer) handleHasSerialNumbers(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 2 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
-	}
-
-	// #1: payment address
-	paymentAddressStr, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("PaymentAddress is invalid"))
-	}
-
-	//#2: list serialnumbers in base58check encode string
-	serialNumbersStr, ok := arrayParams[1].([]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("serialNumbers is invalid"))
-	}
-
-	// #3: optional - token ID - default is prv coin
-	tokenID := &common.Hash{}
-	err := tokenID.SetBytes(common.PRVCoinID[:]) // default is PRV coin
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.TokenIsInvalidError, err)
+	hasParams := hasSerialNumbersParams{}
+	if err := decodeRPCParams(params, &hasParams); err != nil {
+		return nil, err
 	}
-	if len(arrayParams) > 2 {
-		tokenIDTemp, ok := arrayParams[2].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("serialNumbers is invalid"))
-		}
-		tokenID, err = (common.Hash{}).NewHashFromStr(tokenIDTemp)
-		if err != nil {
-			return nil, rpcservice.NewRPCError(rpcservice.ListTokenNotFoundError, err)
-		}
+	tokenID, errT := getTokenIDParam(hasParams.TokenID)
+	if errT != nil {
+		return nil, errT
 	}
 
-	result, err := httpServer.txService.HasSerialNumbers(paymentAddressStr, serialNumbersStr, *tokenID)
+	result, err := httpServer.txService.HasSerialNumbers(hasParams.PaymentAddress, hasParams.SerialNumbers, *tokenID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.ListTokenNotFoundError, err)
 	}
@@ -478,40 +469,16 @@ func (httpServer *HttpServer) handleHasSerialNumbers(params interface{}, closeCh
 
 // handleHasSerialNumbers - check list serial numbers existed in db of node
 func (httpServer *HttpServer) handleHasSnDerivators(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 2 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
-	}
-
-	// #1: payment address
-	paymentAddressStr, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("paymentAddress is invalid"))
+	hasParams := hasSNDerivatorsParams{}
+	if err := decodeRPCParams(params, &hasParams); err != nil {
+		return nil, err
 	}
-
-	//#2: list serialnumbers in base58check encode string
-	snDerivatorStr, ok := arrayParams[1].([]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("snDerivatorStr is invalid"))
+	tokenID, errT := getTokenIDParam(hasParams.TokenID)
+	if errT != nil {
+		return nil, errT
 	}
 
-	// #3: optional - token ID - default is prv coin
-	tokenID := &common.Hash{}
-	err := tokenID.SetBytes(common.PRVCoinID[:]) // default is PRV coin
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.TokenIsInvalidError, err)
-	}
-	if len(arrayParams) > 2 {
-		tokenIDTemp, ok := arrayParams[1].(string)
-		if !ok {
-			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("tokenID is invalid"))
-		}
-		tokenID, err = (common.Hash{}).NewHashFromStr(tokenIDTemp)
-		if err != nil {
-			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
-		}
-	}
-	result, err := httpServer.txService.HasSnDerivators(paymentAddressStr, snDerivatorStr, *tokenID)
+	result, err := httpServer.txService.HasSnDerivators(hasParams.PaymentAddress, hasParams.SNDerivators, *tokenID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -522,7 +489,15 @@ func (httpServer *HttpServer) handleHasSnDerivators(params interface{}, closeCha
 // handleCreateRawCustomTokenTransaction - handle create a custom token command and return in hex string format.
 func (httpServer *HttpServer) handleCreateRawPrivacyCustomTokenTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	var err error
-	tx, err := httpServer.txService.BuildRawPrivacyCustomTokenTransaction(params, nil)
+	txParam, errParam := bean.NewCreateRawPrivacyTokenTxParam(params)
+	if errParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errParam)
+	}
+	// param #8: memos encrypted to receivers of PRV or token (optional)
+	if errParam = txParam.SetMemos(params, 7); errParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errParam)
+	}
+	tx, err := httpServer.txService.BuildRawPrivacyCustomTokenTransactionFromParam(txParam, nil)
 	if err.(*rpcservice.RPCError) != nil {
 		Logger.log.Error(err)
 		return nil, rpcservice.NewRPCError(rpcservice.CreateTxDataError, err)
@@ -546,25 +521,30 @@ func (httpServer *HttpServer) handleCreateRawPrivacyCustomTokenTransaction(param
 
 // handleSendRawTransaction...
 func (httpServer *HttpServer) handleSendRawPrivacyCustomTokenTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	sendParams := sendRawTransactionParams{}
+	if err := decodeRPCParams(params, &sendParams); err != nil {
+		return nil, err
 	}
-
-	base58CheckData, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param is invalid"))
+	relayMode, err1 := httpServer.getTxRelayMode(sendParams.RelayMode)
+	if err1 != nil {
+		return nil, err1
 	}
 
-	txMsg, tx, err1 := httpServer.txService.SendRawPrivacyCustomTokenTransaction(base58CheckData)
+	txMsg, tx, err1 := httpServer.txService.SendRawPrivacyCustomTokenTransaction(sendParams.Base58CheckData, relayMode == txrelay.ModePrivate)
 	if err1 != nil {
 		return nil, err1
 	}
 
-	err := httpServer.config.Server.PushMessageToAll(txMsg)
-	//Mark forwarded message
-	if err == nil {
-		httpServer.config.TxMemPool.MarkForwardedTransaction(*tx.Hash())
+	if relayMode == txrelay.ModePrivate {
+		if err1 := httpServer.relayPrivateTransaction(tx); err1 != nil {
+			return nil, err1
+		}
+	} else {
+		err := httpServer.config.Server.PushMessageToAll(txMsg)
+		//Mark forwarded message
+		if err == nil {
+			httpServer.config.TxMemPool.MarkForwardedTransaction(*tx.Hash())
+		}
 	}
 	result := jsonresult.CreateTransactionTokenResult{
 		TxID:        tx.Hash().String(),
@@ -598,10 +578,9 @@ func (httpServer *HttpServer) handleCreateAndSendPrivacyCustomTokenTransaction(p
 // handleCreateRawStakingTransaction handles create staking
 func (httpServer *HttpServer) handleCreateRawStakingTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	// get component
-
-	paramsArray := common.InterfaceSlice(params)
-	if paramsArray == nil || len(paramsArray) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 element"))
+	stakingParams := createStakingTxParams{}
+	if err := decodeRPCParams(params, &stakingParams); err != nil {
+		return nil, err
 	}
 
 	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
@@ -614,45 +593,16 @@ func (httpServer *HttpServer) handleCreateRawStakingTransaction(params interface
 	funderPaymentAddress := keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
 
 	// prepare meta data
-	data, ok := paramsArray[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid Data For Staking Transaction %+v", paramsArray[4]))
-	}
-
-	stakingType, ok := data["StakingType"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid Staking Type For Staking Transaction %+v", data["StakingType"]))
-	}
-
-	candidatePaymentAddress, ok := data["CandidatePaymentAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid Producer Payment Address for Staking Transaction %+v", data["CandidatePaymentAddress"]))
-	}
+	data := stakingParams.Metadata
 
 	// Get private seed, a.k.a mining key
-	privateSeed, ok := data["PrivateSeed"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid Private Seed For Staking Transaction %+v", data["PrivateSeed"]))
-	}
-	privateSeedBytes, ver, errDecode := base58.Base58Check{}.Decode(privateSeed)
+	privateSeedBytes, ver, errDecode := base58.Base58Check{}.Decode(data.PrivateSeed)
 	if (errDecode != nil) || (ver != common.ZeroByte) {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("Decode privateseed failed!"))
 	}
 
-	//Get RewardReceiver Payment Address
-	rewardReceiverPaymentAddress, ok := data["RewardReceiverPaymentAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid Reward Receiver Payment Address For Staking Transaction %+v", data["RewardReceiverPaymentAddress"]))
-	}
-
-	//Get auto staking flag
-	autoReStaking, ok := data["AutoReStaking"].(bool)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid auto restaking flag %+v", data["AutoReStaking"]))
-	}
-
 	// Get candidate publickey
-	candidateWallet, err := wallet.Base58CheckDeserialize(candidatePaymentAddress)
+	candidateWallet, err := wallet.Base58CheckDeserialize(data.CandidatePaymentAddress)
 	if err != nil || candidateWallet == nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Base58CheckDeserialize candidate Payment Address failed"))
 	}
@@ -670,9 +620,9 @@ func (httpServer *HttpServer) handleCreateRawStakingTransaction(params interface
 	}
 
 	stakingMetadata, err := metadata.NewStakingMetadata(
-		int(stakingType), funderPaymentAddress, rewardReceiverPaymentAddress,
+		data.StakingType, funderPaymentAddress, data.RewardReceiverPaymentAddress,
 		httpServer.config.ChainParams.StakingAmountShard,
-		base58.Base58Check{}.Encode(committeePKBytes, common.ZeroByte), autoReStaking)
+		base58.Base58Check{}.Encode(committeePKBytes, common.ZeroByte), data.AutoReStaking)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
@@ -713,9 +663,9 @@ func (httpServer *HttpServer) handleCreateAndSendStakingTx(params interface{}, c
 // handleCreateRawStopAutoStakingTransaction - RPC create stop auto stake tx
 func (httpServer *HttpServer) handleCreateRawStopAutoStakingTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	// get component
-	paramsArray := common.InterfaceSlice(params)
-	if paramsArray == nil || len(paramsArray) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 element"))
+	stopParams := createStopAutoStakingTxParams{}
+	if err := decodeRPCParams(params, &stopParams); err != nil {
+		return nil, err
 	}
 
 	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
@@ -729,34 +679,16 @@ func (httpServer *HttpServer) handleCreateRawStopAutoStakingTransaction(params i
 	_ = funderPaymentAddress
 
 	//Get data to create meta data
-	data, ok := paramsArray[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid Staking Type For Staking Transaction %+v", paramsArray[4]))
-	}
-
-	//Get staking type
-	stopAutoStakingType, ok := data["StopAutoStakingType"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid Staking Type For Staking Transaction %+v", data["StopAutoStakingType"]))
-	}
+	data := stopParams.Metadata
 
-	//Get Candidate Payment Address
-	candidatePaymentAddress, ok := data["CandidatePaymentAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid Producer Payment Address for Staking Transaction %+v", data["CandidatePaymentAddress"]))
-	}
 	// Get private seed, a.k.a mining key
-	privateSeed, ok := data["PrivateSeed"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid Private Seed for Staking Transaction %+v", data["PrivateSeed"]))
-	}
-	privateSeedBytes, ver, err := base58.Base58Check{}.Decode(privateSeed)
+	privateSeedBytes, ver, err := base58.Base58Check{}.Decode(data.PrivateSeed)
 	if (err != nil) || (ver != common.ZeroByte) {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("Decode privateseed failed!"))
 	}
 
 	// Get candidate publickey
-	candidateWallet, err := wallet.Base58CheckDeserialize(candidatePaymentAddress)
+	candidateWallet, err := wallet.Base58CheckDeserialize(data.CandidatePaymentAddress)
 	if err != nil || candidateWallet == nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Base58CheckDeserialize candidate Payment Address failed"))
 	}
@@ -772,7 +704,7 @@ func (httpServer *HttpServer) handleCreateRawStopAutoStakingTransaction(params i
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
 
-	stakingMetadata, err := metadata.NewStopAutoStakingMetadata(int(stopAutoStakingType), base58.Base58Check{}.Encode(committeePKBytes, common.ZeroByte))
+	stakingMetadata, err := metadata.NewStopAutoStakingMetadata(data.StopAutoStakingType, base58.Base58Check{}.Encode(committeePKBytes, common.ZeroByte))
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
@@ -810,41 +742,29 @@ func (httpServer *HttpServer) handleCreateAndSendStopAutoStakingTransaction(para
 }
 
 func (httpServer *HttpServer) handleDecryptOutputCoinByKeyOfTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	paramsArray := common.InterfaceSlice(params)
-
-	txIdParam, ok := paramsArray[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("tx id param is invalid"))
+	decryptParams := decryptOutputCoinParams{}
+	if err := decodeRPCParams(params, &decryptParams); err != nil {
+		return nil, err
 	}
-	txId, err1 := common.Hash{}.NewHashFromStr(txIdParam)
+	txId, err1 := common.Hash{}.NewHashFromStr(decryptParams.TxID)
 	if err1 != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("tx id param is invalid"))
 	}
 
-	keys, ok := paramsArray[1].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("key param is invalid"))
-	}
 	// get keyset only contain readonly-key by deserializing(optional)
 	var readonlyKey *wallet.KeyWallet
 	var err error
-	readonlyKeyStr, ok := keys["ReadonlyKey"].(string)
-	if !ok || readonlyKeyStr == "" {
-		//return nil, NewRPCError(RPCInvalidParamsError, errors.New("invalid readonly key"))
+	if decryptParams.Keys.ReadonlyKey == "" {
 		Logger.log.Info("ReadonlyKey is optional")
 	} else {
-		readonlyKey, err = wallet.Base58CheckDeserialize(readonlyKeyStr)
+		readonlyKey, err = wallet.Base58CheckDeserialize(decryptParams.Keys.ReadonlyKey)
 		if err != nil {
 			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 		}
 	}
 
 	// get keyset only contain pub-key by deserializing(required)
-	paymentAddressStr, ok := keys["PaymentAddress"].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("invalid payment address"))
-	}
-	paymentAddressKey, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	paymentAddressKey, err := wallet.Base58CheckDeserialize(decryptParams.Keys.PaymentAddress)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -32,6 +32,23 @@ func (httpServer *HttpServer) handleGetPendingTxsInBlockgen(params interface{},
 	return result, nil
 }
 
+/*
+handleGetBlockTemplate - RPC returns the pending txs in blockgen a producer of the shard would put in its next block,
+by fee per KB and within the quota of each metadata type
+*/
+func (httpServer *HttpServer) handleGetBlockTemplate(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	shardParams := shardIDParams{}
+	if err := decodeRPCParams(params, &shardParams); err != nil {
+		return nil, err
+	}
+	if shardParams.ShardID < 0 || shardParams.ShardID >= common.MaxShardNumber {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Shard ID component invalid"))
+	}
+	beaconHeight := httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight
+	result, _ := httpServer.config.Blockgen.NewBlockTemplate(byte(shardParams.ShardID), beaconHeight)
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleGetNumberOfTxsInMempool(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	result := httpServer.txMemPoolService.GetNumberOfTxsInMempool()
 	return result, nil
@@ -41,13 +58,16 @@ func (httpServer *HttpServer) handleGetNumberOfTxsInMempool(params interface{},
 handleMempoolEntry - RPC fetch a specific transaction from the mempool
 */
 func (httpServer *HttpServer) handleMempoolEntry(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	// Param #1: hash string of tx(tx id)
-	txIDParam, ok := params.(string)
-	if !ok || txIDParam == "" {
+	// Param #1: hash string of tx(tx id), also accepted bare
+	txParams := txHashParams{}
+	if err := decodeRPCParams(params, &txParams); err != nil {
+		return nil, err
+	}
+	if txParams.TxHash == "" {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("transaction id is invalid"))
 	}
 
-	txInPool, shardID, err := httpServer.txMemPoolService.MempoolEntry(txIDParam)
+	txInPool, shardID, err := httpServer.txMemPoolService.MempoolEntry(txParams.TxHash)
 	if err != nil {
 		return nil, err
 	}
@@ -63,20 +83,15 @@ func (httpServer *HttpServer) handleMempoolEntry(params interface{}, closeChan <
 
 // handleRemoveTxInMempool - try to remove tx from tx mempool
 func (httpServer *HttpServer) handleRemoveTxInMempool(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrays := common.InterfaceSlice(params)
-	if arrays == nil {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param is invalid"))
+	removeParams := removeTxInMempoolParams{}
+	if err := decodeRPCParams(params, &removeParams); err != nil {
+		return nil, err
 	}
 
 	result := []bool{}
-	for _, txHashString := range arrays {
-		txHash, ok := txHashString.(string)
-		if ok {
-			t, _ := httpServer.txMemPoolService.RemoveTxInMempool(txHash)
-			result = append(result, t)
-		} else {
-			result = append(result, false)
-		}
+	for _, txHash := range removeParams.TxHashes {
+		t, _ := httpServer.txMemPoolService.RemoveTxInMempool(txHash)
+		result = append(result, t)
 	}
 	return result, nil
 }
@@ -4,10 +4,11 @@ import (
 	"encoding/json"
 	"github.com/incognitochain/incognito-chain/rpcserver/bean"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
-	"github.com/pkg/errors"
 
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 )
@@ -20,24 +21,13 @@ var metaConstructors = map[string]metaConstructorType{
 }
 
 func (httpServer *HttpServer) createRawTxWithMetadata(params interface{}, closeChan <-chan struct{}, metaConstructorType metaConstructorType) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
-	}
-
-	// param #5 get meta data param
-	metaRaw, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
-	}
-
-	privateKeyParam, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("private key is invalid"))
+	txParams := createTxWithMetadataParams{}
+	if err := decodeRPCParams(params, &txParams); err != nil {
+		return nil, err
 	}
 
-	meta, errCons := metaConstructorType(metaRaw)
-	_, _, errParseKey := rpcservice.GetKeySetFromPrivateKeyParams(privateKeyParam)
+	meta, errCons := metaConstructorType(txParams.Metadata)
+	_, _, errParseKey := rpcservice.GetKeySetFromPrivateKeyParams(txParams.PrivateKey)
 	if err := common.CheckError(errCons, errParseKey); err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
@@ -77,3 +67,59 @@ func (httpServer *HttpServer) createAndSendTxWithMetadata(params interface{}, cl
 	newParam = append(newParam, base58CheckData)
 	return sendHandler(httpServer, newParam, closeChan)
 }
+
+// getCommitmentParam - return the block whose state is read from the optional commitment param, the best view when
+// it is omitted
+func (httpServer *HttpServer) getCommitmentParam(commitmentParam interface{}) (rpcservice.Commitment, *rpcservice.RPCError) {
+	if commitmentParam == nil {
+		return rpcservice.Commitment{}, nil
+	}
+	commitment, err := rpcservice.ParseCommitment(commitmentParam, httpServer.config.BlockChain.IsArchive())
+	if err != nil {
+		return rpcservice.Commitment{}, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	return *commitment, nil
+}
+
+// getBeaconBlockFeatureState - return the beacon block with the hash param when it is not empty, or else at the height in the chain of the
+// best view, and the feature state (pde, portal) stored by this block. The state of these rpcs has always been read at
+// a given beacon block, so it is served by non archive nodes too
+func (httpServer *HttpServer) getBeaconBlockFeatureState(beaconHashParam string, beaconHeight uint64, errCode int) (*blockchain.BeaconBlock, *statedb.StateDB, *rpcservice.RPCError) {
+	bc := httpServer.config.BlockChain
+	var beaconHash *common.Hash
+	if beaconHashParam != "" {
+		var err error
+		beaconHash, err = common.Hash{}.NewHashFromStr(beaconHashParam)
+		if err != nil {
+			return nil, nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+	} else {
+		var err error
+		beaconHash, err = bc.GetBeaconBlockHashByHeight(bc.BeaconChain.GetFinalView(), bc.BeaconChain.GetBestView(), beaconHeight)
+		if err != nil {
+			return nil, nil, rpcservice.NewRPCError(errCode, err)
+		}
+	}
+	beaconBlock, _, err := bc.GetBeaconBlockByHash(*beaconHash)
+	if err != nil {
+		return nil, nil, rpcservice.NewRPCError(errCode, err)
+	}
+	beaconFeatureStateDB, err := bc.GetBeaconFeatureStateDBByBlockHash(*beaconHash)
+	if err != nil {
+		return nil, nil, rpcservice.NewRPCError(errCode, err)
+	}
+	return beaconBlock, beaconFeatureStateDB, nil
+}
+
+// getTokenIDParam - return the token id of an optional token id param, PRV when it is omitted or empty
+func getTokenIDParam(tokenIDParam string) (*common.Hash, *rpcservice.RPCError) {
+	if tokenIDParam == "" {
+		tokenID := common.PRVCoinID
+		return &tokenID, nil
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDParam)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.ListTokenNotFoundError, err)
+	}
+	return tokenID, nil
+}
@@ -33,12 +33,12 @@ getaccount RPC returns the name of the account associated with the given address
 - Param #1: address
 */
 func (httpServer *HttpServer) handleGetAccount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	paramTemp, ok := params.(string)
-	if !ok {
-		return nil, nil
+	accountParams := paymentAddressParams{}
+	if err := decodeRPCParams(params, &accountParams); err != nil {
+		return nil, err
 	}
 
-	accountName, _ := httpServer.walletService.GetAccount(paramTemp)
+	accountName, _ := httpServer.walletService.GetAccount(accountParams.PaymentAddress)
 	if accountName != "" {
 		return accountName, nil
 	}
@@ -53,12 +53,12 @@ Parameter #1—the account name
 Result—a list of addresses
 */
 func (httpServer *HttpServer) handleGetAddressesByAccount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	paramTemp, ok := params.(string)
-	if !ok {
-		return nil, nil
+	accountParams := accountNameParams{}
+	if err := decodeRPCParams(params, &accountParams); err != nil {
+		return nil, err
 	}
 
-	addresses, err := httpServer.walletService.GetAddressesByAccount(paramTemp)
+	addresses, err := httpServer.walletService.GetAddressesByAccount(accountParams.AccountName)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -75,12 +75,12 @@ Parameter #1—an account name
 Result—a incognito address
 */
 func (httpServer *HttpServer) handleGetAccountAddress(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	accountName, ok := params.(string)
-	if !ok {
-		return nil, nil
+	accountParams := accountNameParams{}
+	if err := decodeRPCParams(params, &accountParams); err != nil {
+		return nil, err
 	}
 
-	result, err := httpServer.walletService.GetAccountAddress(accountName)
+	result, err := httpServer.walletService.GetAccountAddress(accountParams.AccountName)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -94,11 +94,11 @@ Parameter #1—the address corresponding to the private key to get
 Result—the private key
 */
 func (httpServer *HttpServer) handleDumpPrivkey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	paramTemp, ok := params.(string)
-	if !ok {
-		return nil, nil
+	dumpParams := paymentAddressParams{}
+	if err := decodeRPCParams(params, &dumpParams); err != nil {
+		return nil, err
 	}
-	result := httpServer.walletService.DumpPrivkey(paramTemp)
+	result := httpServer.walletService.DumpPrivkey(dumpParams.PaymentAddress)
 	return result, nil
 }
 
@@ -109,27 +109,12 @@ handleImportAccount - import a new account by private-key
 - Param #3: passPhrase of wallet
 */
 func (httpServer *HttpServer) handleImportAccount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 3 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 3 elements"))
-	}
-
-	privateKey, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("privateKey is invalid"))
-	}
-
-	accountName, ok := arrayParams[1].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("accountName is invalid"))
-	}
-
-	passPhrase, ok := arrayParams[2].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
+	importParams := importAccountParams{}
+	if err := decodeRPCParams(params, &importParams); err != nil {
+		return nil, err
 	}
 
-	result, err := httpServer.walletService.ImportAccount(privateKey, accountName, passPhrase)
+	result, err := httpServer.walletService.ImportAccount(importParams.PrivateKey, importParams.AccountName, importParams.PassPhrase)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -138,55 +123,41 @@ func (httpServer *HttpServer) handleImportAccount(params interface{}, closeChan
 }
 
 func (httpServer *HttpServer) handleRemoveAccount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 2 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
-	}
-
-	privateKey, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("privateKey is invalid"))
-	}
-
-	passPhrase, ok := arrayParams[1].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
+	removeParams := removeAccountParams{}
+	if err := decodeRPCParams(params, &removeParams); err != nil {
+		return nil, err
 	}
 
-	return httpServer.walletService.RemoveAccount(privateKey, passPhrase)
+	return httpServer.walletService.RemoveAccount(removeParams.PrivateKey, removeParams.PassPhrase)
 }
 
 // handleGetBalanceByPrivatekey -  return balance of private key
 func (httpServer *HttpServer) handleGetBalanceByPrivatekey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	// all component
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	balanceParams := getBalanceParams{}
+	if err := decodeRPCParams(params, &balanceParams); err != nil {
+		return nil, err
 	}
-	// param #1: private key of sender
-	senderKeyParam, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("invalid private key"))
+	commitment, errC := httpServer.getCommitmentParam(balanceParams.Commitment)
+	if errC != nil {
+		return nil, errC
 	}
 
-	return httpServer.walletService.GetBalanceByPrivateKey(senderKeyParam)
+	return httpServer.walletService.GetBalanceByPrivateKey(balanceParams.PrivateKey, commitment)
 }
 
 // handleGetBalanceByPaymentAddress -  return balance of paymentaddress
 func (httpServer *HttpServer) handleGetBalanceByPaymentAddress(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 
-	// all component
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	balanceParams := getBalanceByPaymentAddressParams{}
+	if err := decodeRPCParams(params, &balanceParams); err != nil {
+		return nil, err
 	}
-	// param #1: private key of sender
-	paymentAddressParam, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
+	commitment, errC := httpServer.getCommitmentParam(balanceParams.Commitment)
+	if errC != nil {
+		return nil, errC
 	}
 
-	return httpServer.walletService.GetBalanceByPaymentAddress(paymentAddressParam)
+	return httpServer.walletService.GetBalanceByPaymentAddress(balanceParams.PaymentAddress, commitment)
 }
 
 /*
@@ -200,36 +171,16 @@ func (httpServer *HttpServer) handleGetBalance(params interface{}, closeChan <-c
 		return uint64(0), rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("no account is existed"))
 	}
 
-	// convert component to array
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 3 {
-		return uint64(0), rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 3 elements"))
-	}
-	// Param #1: account "*" for all or a particular account
-	accountName, ok := arrayParams[0].(string)
-	if !ok {
-		return uint64(0), rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("accountName is invalid"))
-	}
-
-	// Param #2: the minimum number of confirmations an output must have
-	minTemp, ok := arrayParams[1].(float64)
-	if !ok {
-		return uint64(0), rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("min is invalid"))
-	}
-	min := int(minTemp)
-	_ = min
-
-	// Param #3: passphrase to access local wallet of node
-	passPhrase, ok := arrayParams[2].(string)
-	if !ok {
-		return uint64(0), rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
+	balanceParams := walletAccountParams{}
+	if err := decodeRPCParams(params, &balanceParams); err != nil {
+		return uint64(0), err
 	}
 
-	if passPhrase != httpServer.config.Wallet.PassPhrase {
+	if balanceParams.PassPhrase != httpServer.config.Wallet.PassPhrase {
 		return uint64(0), rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("password phrase is wrong for local wallet"))
 	}
 
-	return httpServer.walletService.GetBalance(accountName)
+	return httpServer.walletService.GetBalance(balanceParams.AccountName)
 }
 
 /*
@@ -246,58 +197,39 @@ func (httpServer *HttpServer) handleGetReceivedByAccount(params interface{}, clo
 		return balance, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("no account is existed"))
 	}
 
-	// convert component to array
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 3 {
-		return balance, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 3 elements"))
+	receivedParams := walletAccountParams{}
+	if err := decodeRPCParams(params, &receivedParams); err != nil {
+		return balance, err
 	}
-	// Param #1: account "*" for all or a particular account
-	accountName, ok := arrayParams[0].(string)
-	if !ok {
-		return balance, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("accountName is invalid"))
-	}
-
-	// Param #2: the minimum number of confirmations an output must have
-	minTemp, ok := arrayParams[1].(float64)
-	if !ok {
-		return balance, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("min is invalid"))
-	}
-	min := int(minTemp)
-	_ = min
 
-	// Param #3: passphrase to access local wallet of node
-	passPhrase, ok := arrayParams[2].(string)
-	if !ok {
-		return balance, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
-	}
-
-	if passPhrase != httpServer.config.Wallet.PassPhrase {
+	if receivedParams.PassPhrase != httpServer.config.Wallet.PassPhrase {
 		return balance, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("password phrase is wrong for local wallet"))
 	}
 
-	return httpServer.walletService.GetReceivedByAccount(accountName)
+	return httpServer.walletService.GetReceivedByAccount(receivedParams.AccountName)
 }
 
 /*
 handleSetTxFee - RPC sets the transaction fee per kilobyte paid more by transactions created by this wallet. default is 1 coin per 1 kb
 */
 func (httpServer *HttpServer) handleSetTxFee(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	paramTmp, ok := params.(float64)
-	if !ok {
-		return false, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param is invalid"))
+	feeParams := setTxFeeParams{}
+	if err := decodeRPCParams(params, &feeParams); err != nil {
+		return false, err
 	}
 
-	httpServer.config.Wallet.GetConfig().IncrementalFee = uint64(paramTmp)
+	httpServer.config.Wallet.GetConfig().IncrementalFee = feeParams.Fee
 	err := httpServer.config.Wallet.Save(httpServer.config.Wallet.PassPhrase)
 	return err == nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 }
 
 func (httpServer *HttpServer) handleListPrivacyCustomToken(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	getCountTxs := false
-	if len(arrayParams) == 1 {
-		getCountTxs = true
+	listParams := listPrivacyCustomTokenParams{}
+	if err := decodeRPCParams(params, &listParams); err != nil {
+		return nil, err
 	}
+	// "" is the empty params of older clients
+	getCountTxs := listParams.WithTxs != nil && listParams.WithTxs != ""
 	listPrivacyToken := make(map[common.Hash]*statedb.TokenState)
 	var err error
 	if getCountTxs {
@@ -382,15 +314,11 @@ func (httpServer *HttpServer) handleListPrivacyCustomToken(params interface{}, c
 }
 
 func (httpServer *HttpServer) handleGetPrivacyCustomToken(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("params is invalid"))
-	}
-	tokenIDStr, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("params type is invalid"))
+	tokenParams := tokenIDParams{}
+	if err := decodeRPCParams(params, &tokenParams); err != nil {
+		return nil, err
 	}
-	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+	tokenID, err := common.Hash{}.NewHashFromStr(tokenParams.TokenID)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("tokenID to hash failed %+v", err))
 	}
@@ -409,15 +337,11 @@ func (httpServer *HttpServer) handleGetPrivacyCustomToken(params interface{}, cl
 }
 
 func (httpServer *HttpServer) handleListPrivacyCustomTokenByShard(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("params is invalid"))
-	}
-	shardID, ok := arrayParams[0].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("params type is invalid"))
+	shardParams := shardIDParams{}
+	if err := decodeRPCParams(params, &shardParams); err != nil {
+		return nil, err
 	}
-	listPrivacyToken, err := httpServer.blockService.ListPrivacyCustomTokenWithPRVByShardID(byte(shardID))
+	listPrivacyToken, err := httpServer.blockService.ListPrivacyCustomTokenWithPRVByShardID(byte(shardParams.ShardID))
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.ListTokenNotFoundError, err)
 	}
@@ -446,16 +370,12 @@ func (httpServer *HttpServer) handleListPrivacyCustomTokenByShard(params interfa
 
 // handleGetPublicKeyFromPaymentAddress - return base58check encode of public key which is got from payment address
 func (httpServer *HttpServer) handleGetPublicKeyFromPaymentAddress(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("params is invalid"))
-	}
-	paymentAddress, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("paymentAddress is invalid"))
+	addressParams := paymentAddressParams{}
+	if err := decodeRPCParams(params, &addressParams); err != nil {
+		return nil, err
 	}
 
-	keySet, _, err := rpcservice.GetKeySetFromPaymentAddressParam(paymentAddress)
+	keySet, _, err := rpcservice.GetKeySetFromPaymentAddressParam(addressParams.PaymentAddress)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
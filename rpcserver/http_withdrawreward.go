@@ -1,53 +1,64 @@
 package rpcserver
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/rpcserver/bean"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
-	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/pkg/errors"
 )
 
 func (httpServer *HttpServer) handleCreateRawWithDrawTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) < 5 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	withdrawParams := createWithdrawRewardTxParams{}
+	if err := decodeRPCParams(params, &withdrawParams); err != nil {
+		return nil, err
 	}
-	arrayParams[1] = nil
-
-	privateKeyParam, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("private key is invalid"))
+	senderKeySet, shardIDSender, err := rpcservice.GetKeySetFromPrivateKeyParams(withdrawParams.PrivateKey)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New(fmt.Sprintf("Wrong privatekey %+v", err)))
 	}
-	keyWallet, err := wallet.Base58CheckDeserialize(privateKeyParam)
+	tokenID, err := common.Hash{}.NewHashFromStr(withdrawParams.Metadata.TokenID)
 	if err != nil {
-		return []byte{}, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New(fmt.Sprintf("Wrong privatekey %+v", err)))
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid token Id"))
 	}
-	keyWallet.KeySet.InitFromPrivateKeyByte(keyWallet.KeySet.PrivateKey)
 
-	metaParam, ok := arrayParams[4].(map[string]interface{})
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	// the reward is withdrawn to the payment address of the sender
+	meta := &metadata.WithDrawRewardRequest{
+		MetadataBase:   metadata.MetadataBase{Type: metadata.WithDrawRewardRequestMeta},
+		PaymentAddress: senderKeySet.PaymentAddress,
+		TokenID:        *tokenID,
 	}
-	tokenIDParam, ok := metaParam["TokenID"]
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token ID is invalid"))
+	if withdrawParams.Metadata.Version != nil {
+		meta.Version = *withdrawParams.Metadata.Version
+	}
+	createRawTxParam := &bean.CreateRawTxParam{
+		SenderKeySet:         senderKeySet,
+		ShardIDSender:        shardIDSender,
+		PaymentInfos:         []*privacy.PaymentInfo{},
+		EstimateFeeCoinPerKb: withdrawParams.Fee,
+		HasPrivacyCoin:       withdrawParams.Privacy > 0,
+		Info:                 []byte{},
 	}
 
-	param := map[string]interface{}{}
-	param["PaymentAddress"] = keyWallet.Base58CheckSerialize(1)
-	param["TokenID"] = tokenIDParam
-	param["Version"] = 1
-	if version, ok := metaParam["Version"]; ok {
-		param["Version"] = version
+	tx, rpcErr := httpServer.txService.BuildRawTransaction(createRawTxParam, meta)
+	if rpcErr != nil {
+		Logger.log.Error(rpcErr)
+		return nil, rpcErr
 	}
-	arrayParams[4] = interface{}(param)
-	return httpServer.createRawTxWithMetadata(
-		arrayParams,
-		closeChan,
-		metadata.NewWithDrawRewardRequestFromRPC,
-	)
+	byteArrays, err := json.Marshal(tx)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.JsonError, err)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
 }
 
 func (httpServer *HttpServer) handleCreateAndSendWithDrawTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
@@ -61,16 +72,11 @@ func (httpServer *HttpServer) handleCreateAndSendWithDrawTransaction(params inte
 
 // handleGetRewardAmount - Get the reward amount of a payment address with all existed token
 func (httpServer *HttpServer) handleGetRewardAmount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
-	}
-
-	paymentAddress, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
+	rewardParams := paymentAddressParams{}
+	if err := decodeRPCParams(params, &rewardParams); err != nil {
+		return nil, err
 	}
-	rewardAmount, err := httpServer.blockService.GetRewardAmount(paymentAddress)
+	rewardAmount, err := httpServer.blockService.GetRewardAmount(rewardParams.PaymentAddress)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetRewardAmountError, err)
 	}
@@ -79,16 +85,11 @@ func (httpServer *HttpServer) handleGetRewardAmount(params interface{}, closeCha
 
 // handleGetRewardAmount - Get the reward amount of a payment address with all existed token
 func (httpServer *HttpServer) handleGetRewardAmountByPublicKey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 1 {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
-	}
-
-	paymentAddress, ok := arrayParams[0].(string)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
+	rewardParams := incognitoPublicKeyParams{}
+	if err := decodeRPCParams(params, &rewardParams); err != nil {
+		return nil, err
 	}
-	rewardAmount, err := httpServer.blockService.GetRewardAmountByPublicKey(paymentAddress)
+	rewardAmount, err := httpServer.blockService.GetRewardAmountByPublicKey(rewardParams.PublicKey)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetRewardAmountError, err)
 	}
@@ -3,6 +3,7 @@ package jsonresult
 import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/transaction"
 )
 
 type CreateTransactionResult struct {
@@ -27,6 +28,21 @@ func NewCreateTransactionResult(txID *common.Hash, txIDString string, byteArrays
 	return result
 }
 
+// CreateUnsignedTransactionResult - Template is signed offline then sent by sendtransaction
+type CreateUnsignedTransactionResult struct {
+	Template *transaction.TxTemplate
+	Fee      uint64
+	ShardID  byte
+}
+
+// CreateUnsignedPrivacyTokenTransactionResult - Template is signed offline then sent by sendrawprivacycustomtokentransaction
+type CreateUnsignedPrivacyTokenTransactionResult struct {
+	Template *transaction.TxPrivacyTokenTemplate
+	Fee      uint64
+	TokenFee uint64
+	ShardID  byte
+}
+
 type CreateTransactionTokenResult struct {
 	Base58CheckData string
 	ShardID         byte   `json:"ShardID"`
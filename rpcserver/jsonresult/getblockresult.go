@@ -20,6 +20,8 @@ type GetBeaconBlockResult struct {
 	Instructions      [][]string  `json:"Instructions"`
 	Size              uint64      `json:"Size"`
 	ShardStates       interface{} `json:"ShardStates"`
+	Confirmations     int64       `json:"Confirmations"`
+	IsFinalized       bool        `json:"IsFinalized"`
 }
 
 type GetShardBlockResult struct {
@@ -27,6 +29,7 @@ type GetShardBlockResult struct {
 	ShardID           byte               `json:"ShardID"`
 	Height            uint64             `json:"Height"`
 	Confirmations     int64              `json:"Confirmations"`
+	IsFinalized       bool               `json:"IsFinalized"`
 	Version           int                `json:"Version"`
 	TxRoot            string             `json:"TxRoot"`
 	Time              int64              `json:"Time"`
@@ -7,6 +7,7 @@ type CrossOutputCoinResult struct {
 	BlockHash string `json:"BlockHash"`
 	PaymentAddress string `json:"PaymentAddress"`
 	Value uint64 `json:"Value"`
+	Memos []string `json:"Memos,omitempty"` // memos of the output coins encrypted to the receiver
 }
 type CrossCustomTokenPrivacyResult struct {
 	SenderShardID byte `json:"SenderShardID"`
@@ -16,6 +17,7 @@ type CrossCustomTokenPrivacyResult struct {
 	PaymentAddress string `json:"PaymentAddress"`
 	TokenID string `json:"TokenID"`
 	Value uint64 `json:"Value"`
+	Memos []string `json:"Memos,omitempty"` // memos of the output coins encrypted to the receiver
 }
 type CrossCustomTokenResult struct {
 	SenderShardID byte `json:"SenderShardID"`
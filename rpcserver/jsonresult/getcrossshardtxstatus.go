@@ -0,0 +1,46 @@
+package jsonresult
+
+import "github.com/incognitochain/incognito-chain/blockchain"
+
+type CrossShardTxReceipt struct {
+	ToShardID   byte   `json:"ToShardID"`
+	Status      string `json:"Status"`
+	BlockHeight uint64 `json:"BlockHeight"`
+	BlockHash   string `json:"BlockHash"`
+}
+
+type CrossShardTxStatus struct {
+	TxID           string                `json:"TxID"`
+	Status         string                `json:"Status"` // pending, included or finalized
+	FromShardID    byte                  `json:"FromShardID"`
+	BlockHeight    uint64                `json:"BlockHeight"`
+	BlockHash      string                `json:"BlockHash"`
+	BlockFinalized bool                  `json:"BlockFinalized"`
+	IsInMempool    bool                  `json:"IsInMempool"`
+	Receipts       []CrossShardTxReceipt `json:"Receipts"` // by receiving shard
+}
+
+func NewCrossShardTxStatus(status *blockchain.CrossShardTxStatus) *CrossShardTxStatus {
+	result := &CrossShardTxStatus{
+		TxID:           status.TxHash.String(),
+		Status:         status.Status(),
+		FromShardID:    status.FromShardID,
+		BlockHeight:    status.BlockHeight,
+		BlockHash:      status.BlockHash.String(),
+		BlockFinalized: status.BlockFinalized,
+		Receipts:       []CrossShardTxReceipt{},
+	}
+	for _, receipt := range status.Receipts {
+		blockHash := ""
+		if receipt.Status != blockchain.CrossShardTxPending {
+			blockHash = receipt.BlockHash.String()
+		}
+		result.Receipts = append(result.Receipts, CrossShardTxReceipt{
+			ToShardID:   receipt.ToShardID,
+			Status:      receipt.Status,
+			BlockHeight: receipt.BlockHeight,
+			BlockHash:   blockHash,
+		})
+	}
+	return result
+}
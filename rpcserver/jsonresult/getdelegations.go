@@ -0,0 +1,20 @@
+package jsonresult
+
+type GetDelegationsResult struct {
+	CommitteePublicKey string
+	Delegations        map[string]uint64 // delegator payment address to amount in nano PRV
+	TotalDelegated     uint64
+	CommissionPercent  uint64 // share of the reward of the delegators kept by the validator
+}
+
+func NewGetDelegationsResult(committeePublicKey string, delegations map[string]uint64, commissionPercent uint64) *GetDelegationsResult {
+	obj := &GetDelegationsResult{
+		CommitteePublicKey: committeePublicKey,
+		Delegations:        delegations,
+		CommissionPercent:  commissionPercent,
+	}
+	for _, amount := range delegations {
+		obj.TotalDelegated += amount
+	}
+	return obj
+}
@@ -0,0 +1,37 @@
+package jsonresult
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+)
+
+type GetHTLCResult struct {
+	LockTxID            string
+	SenderAddress       string
+	ReceiverAddress     string
+	TokenID             string
+	Amount              uint64
+	HashLock            string
+	TimeoutBeaconHeight uint64
+	Status              string // locked, claimed or refunded
+	Preimage            string `json:",omitempty"` // hex encoded, once claimed
+	SettleTxID          string `json:",omitempty"`
+}
+
+func NewGetHTLCResult(htlc *statedb.HTLCState) *GetHTLCResult {
+	obj := &GetHTLCResult{
+		LockTxID:            htlc.LockTxID().String(),
+		SenderAddress:       htlc.SenderAddress(),
+		ReceiverAddress:     htlc.ReceiverAddress(),
+		TokenID:             htlc.TokenID(),
+		Amount:              htlc.Amount(),
+		HashLock:            htlc.HashLock(),
+		TimeoutBeaconHeight: htlc.TimeoutBeaconHeight(),
+		Status:              htlc.Status(),
+		Preimage:            htlc.Preimage(),
+	}
+	if htlc.Status() != common.HTLCLockedChainStatus {
+		obj.SettleTxID = htlc.SettleTxID().String()
+	}
+	return obj
+}
@@ -11,14 +11,15 @@ type GetInOutMessageResult struct {
 	OutboundMessages map[string]interface{} `json:"Outbounds"`
 }
 
-func NewGetInOutMessageResult(paramsArray []interface{}) (*GetInOutMessageResult, error) {
+// NewGetInOutMessageResult - messages of peerID by type, the number of messages by type when peerID is empty
+func NewGetInOutMessageResult(peerID string) (*GetInOutMessageResult, error) {
 	inboundMessages := peer.GetInboundPeerMessages()
 	outboundMessages := peer.GetOutboundPeerMessages()
 	result := &GetInOutMessageResult{
 		InboundMessages:  map[string]interface{}{},
 		OutboundMessages: map[string]interface{}{},
 	}
-	if len(paramsArray) == 0 {
+	if peerID == common.EmptyString {
 		for messageType, messagePeers := range inboundMessages {
 			result.InboundMessages[messageType] = len(messagePeers)
 		}
@@ -27,11 +28,6 @@ func NewGetInOutMessageResult(paramsArray []interface{}) (*GetInOutMessageResult
 		}
 		return result, nil
 	}
-	peerID, ok := paramsArray[0].(string)
-	if !ok {
-		peerID = common.EmptyString
-	}
-
 	for messageType, messagePeers := range inboundMessages {
 		messages := []wire.Message{}
 		for _, m := range messagePeers {
@@ -60,21 +56,17 @@ type GetInOutMessageCountResult struct {
 	OutboundMessages interface{} `json:"Outbounds"`
 }
 
-func NewGetInOutMessageCountResult(paramsArray []interface{}) (*GetInOutMessageCountResult, error) {
+// NewGetInOutMessageCountResult - number of messages of peerID by type, of every peer when peerID is empty
+func NewGetInOutMessageCountResult(peerID string) (*GetInOutMessageCountResult, error) {
 	result := &GetInOutMessageCountResult{}
 	inboundMessageByPeers := peer.GetInboundMessagesByPeer()
 	outboundMessageByPeers := peer.GetOutboundMessagesByPeer()
 
-	if len(paramsArray) == 0 {
+	if peerID == common.EmptyString {
 		result.InboundMessages = inboundMessageByPeers
 		result.OutboundMessages = outboundMessageByPeers
 		return result, nil
 	}
-
-	peerID, ok := paramsArray[0].(string)
-	if !ok {
-		peerID = ""
-	}
 	result.InboundMessages = inboundMessageByPeers[peerID]
 	result.OutboundMessages = outboundMessageByPeers[peerID]
 	return result, nil
@@ -42,8 +42,10 @@ type TransactionDetail struct {
 	PrivacyCustomTokenIsPrivacy   bool        `json:"PrivacyCustomTokenIsPrivacy"`
 	PrivacyCustomTokenFee         uint64      `json:"PrivacyCustomTokenFee"`
 
-	IsInMempool bool `json:"IsInMempool"`
-	IsInBlock   bool `json:"IsInBlock"`
+	IsInMempool   bool   `json:"IsInMempool"`
+	IsInBlock     bool   `json:"IsInBlock"`
+	Confirmations uint64 `json:"Confirmations"`
+	IsFinalized   bool   `json:"IsFinalized"`
 
 	Info string `json:"Info"`
 }
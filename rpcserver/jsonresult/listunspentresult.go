@@ -23,6 +23,9 @@ type OutCoin struct {
 	Value                string `json:"Value"`
 	Info                 string `json:"Info"`
 	CoinDetailsEncrypted string `json:"CoinDetailsEncrypted"`
+	TxRandom             string `json:"TxRandom,omitempty"`
+	ViewTag              string `json:"ViewTag,omitempty"`
+	Memo                 string `json:"Memo,omitempty"`
 }
 
 func NewOutcoinFromInterface(data interface{}) (*OutCoin, error) {
@@ -60,6 +63,11 @@ func NewOutCoin(outCoin *privacy.OutputCoin) OutCoin {
 	if outCoin.CoinDetails.GetRandomness() != nil {
 		result.Randomness = base58.Base58Check{}.Encode(outCoin.CoinDetails.GetRandomness().ToBytesS(), common.ZeroByte)
 	}
+	// a stealth coin returns its tx random and view tag
+	if outCoin.CoinDetails.GetTxRandom() != nil {
+		result.TxRandom = base58.Base58Check{}.Encode(outCoin.CoinDetails.GetTxRandom().ToBytesS(), common.ZeroByte)
+		result.ViewTag = strconv.Itoa(int(outCoin.CoinDetails.GetViewTag()))
+	}
 	// return more data of CoinDetailsEncrypted
 	if outCoin.CoinDetailsEncrypted != nil {
 		result.CoinDetailsEncrypted = base58.Base58Check{}.Encode(outCoin.CoinDetailsEncrypted.Bytes(), common.ZeroByte)
@@ -67,3 +75,14 @@ func NewOutCoin(outCoin *privacy.OutputCoin) OutCoin {
 
 	return result
 }
+
+// NewOutCoinWithMemo returns NewOutCoin with the memo of the coin when its info is a memo encrypted to receivingKey
+func NewOutCoinWithMemo(outCoin *privacy.OutputCoin, receivingKey privacy.ReceivingKey) OutCoin {
+	result := NewOutCoin(outCoin)
+	if len(receivingKey) > 0 && privacy.IsEncryptedMemo(outCoin.CoinDetails.GetInfo()) {
+		if memo, err := privacy.DecryptMemo(outCoin.CoinDetails.GetInfo(), receivingKey); err == nil {
+			result.Memo = string(memo)
+		}
+	}
+	return result
+}
@@ -27,6 +27,7 @@ var HttpHandler = map[string]httpHandler{
 	estimateFeeWithEstimator: (*HttpServer).handleEstimateFeeWithEstimator,
 	getActiveShards:          (*HttpServer).handleGetActiveShards,
 	getMaxShardsNumber:       (*HttpServer).handleGetMaxShardsNumber,
+	getRPCSchema:             (*HttpServer).handleGetRPCSchema,
 
 	//tx pool
 	getRawMempool:           (*HttpServer).handleGetRawMempool,
@@ -35,6 +36,7 @@ var HttpHandler = map[string]httpHandler{
 	removeTxInMempool:       (*HttpServer).handleRemoveTxInMempool,
 	getMempoolInfo:          (*HttpServer).handleGetMempoolInfo,
 	getPendingTxsInBlockgen: (*HttpServer).handleGetPendingTxsInBlockgen,
+	getBlockTemplate:        (*HttpServer).handleGetBlockTemplate,
 
 	// block pool ver.2
 	// getShardToBeaconPoolStateV2: (*HttpServer).handleGetShardToBeaconPoolStateV2,
@@ -68,12 +70,22 @@ var HttpHandler = map[string]httpHandler{
 	listOutputCoins:                         (*HttpServer).handleListOutputCoins,
 	createRawTransaction:                    (*HttpServer).handleCreateRawTransaction,
 	sendRawTransaction:                      (*HttpServer).handleSendRawTransaction,
+	createUnsignedTransaction:               (*HttpServer).handleCreateUnsignedTransaction,
 	createAndSendTransaction:                (*HttpServer).handleCreateAndSendTx,
 	getTransactionByHash:                    (*HttpServer).handleGetTransactionByHash,
+	getCrossShardTxStatus:                   (*HttpServer).handleGetCrossShardTxStatus,
 	gettransactionhashbyreceiver:            (*HttpServer).handleGetTransactionHashByReceiver,
 	gettransactionbyreceiver:                (*HttpServer).handleGetTransactionByReceiver,
 	createAndSendStakingTransaction:         (*HttpServer).handleCreateAndSendStakingTx,
 	createAndSendStopAutoStakingTransaction: (*HttpServer).handleCreateAndSendStopAutoStakingTransaction,
+	createAndSendDelegationTransaction:      (*HttpServer).handleCreateAndSendDelegationTransaction,
+	createAndSendDelegationCommissionTx:     (*HttpServer).handleCreateAndSendDelegationCommissionTransaction,
+	getDelegations:                          (*HttpServer).handleGetDelegations,
+	createAndSendTxWithHTLCLock:             (*HttpServer).handleCreateAndSendTxWithHTLCLock,
+	createAndSendTxWithPTokenHTLCLock:       (*HttpServer).handleCreateAndSendTxWithPTokenHTLCLock,
+	createAndSendTxWithHTLCClaim:            (*HttpServer).handleCreateAndSendTxWithHTLCClaim,
+	createAndSendTxWithHTLCRefund:           (*HttpServer).handleCreateAndSendTxWithHTLCRefund,
+	getHTLC:                                 (*HttpServer).handleGetHTLC,
 	randomCommitments:                       (*HttpServer).handleRandomCommitments,
 	hasSerialNumbers:                        (*HttpServer).handleHasSerialNumbers,
 	hasSnDerivators:                         (*HttpServer).handleHasSnDerivators,
@@ -107,6 +119,7 @@ var HttpHandler = map[string]httpHandler{
 	createRawPrivacyCustomTokenTransaction:     (*HttpServer).handleCreateRawPrivacyCustomTokenTransaction,
 	sendRawPrivacyCustomTokenTransaction:       (*HttpServer).handleSendRawPrivacyCustomTokenTransaction,
 	createAndSendPrivacyCustomTokenTransaction: (*HttpServer).handleCreateAndSendPrivacyCustomTokenTransaction,
+	createUnsignedPrivacyTokenTx:               (*HttpServer).handleCreateUnsignedPrivacyCustomTokenTransaction,
 	listPrivacyCustomToken:                     (*HttpServer).handleListPrivacyCustomToken,
 	getPrivacyCustomToken:                      (*HttpServer).handleGetPrivacyCustomToken,
 	listPrivacyCustomTokenByShard:              (*HttpServer).handleListPrivacyCustomTokenByShard,
@@ -269,7 +282,10 @@ var WsHandler = map[string]wsHandler{
 	testSubcrice:                                (*WsServer).handleTestSubcribe,
 	subcribeNewShardBlock:                       (*WsServer).handleSubscribeNewShardBlock,
 	subcribeNewBeaconBlock:                      (*WsServer).handleSubscribeNewBeaconBlock,
+	subscribeFinalizedShardBlock:                (*WsServer).handleSubscribeFinalizedShardBlock,
+	subscribeFinalizedBeaconBlock:               (*WsServer).handleSubscribeFinalizedBeaconBlock,
 	subcribePendingTransaction:                  (*WsServer).handleSubscribePendingTransaction,
+	subcribeCrossShardTxStatus:                  (*WsServer).handleSubscribeCrossShardTxStatus,
 	subcribeShardCandidateByPublickey:           (*WsServer).handleSubcribeShardCandidateByPublickey,
 	subcribeShardCommitteeByPublickey:           (*WsServer).handleSubcribeShardCommitteeByPublickey,
 	subcribeShardPendingValidatorByPublickey:    (*WsServer).handleSubcribeShardPendingValidatorByPublickey,
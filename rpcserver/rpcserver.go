@@ -16,6 +16,7 @@ import (
 	"github.com/incognitochain/incognito-chain/netsync"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/syncker"
+	"github.com/incognitochain/incognito-chain/txrelay"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/incognitochain/incognito-chain/wire"
 	peer2 "github.com/libp2p/go-libp2p-peer"
@@ -110,6 +111,9 @@ type RpcServerConfig struct {
 	// IsMiningNode    bool   // flag mining node. True: mining, False: not mining
 	MiningKeys    string // encode of mining key
 	PubSubManager *pubsub.PubSubManager
+	// relay of the transactions sent in private mode, TxRelayMode is the mode of the transactions sent without one
+	TxRelay     *txrelay.TxRelay
+	TxRelayMode string
 }
 
 func (rpcServer *RpcServer) Init(config *RpcServerConfig) {
@@ -57,6 +57,30 @@ func (blockService BlockService) GetBeaconBestState() (*blockchain.BeaconBestSta
 	return blockService.BlockChain.GetClonedBeaconBestState()
 }
 
+// GetBeaconCommittees - beacon state holding the committees at the beacon block of the commitment, only its epoch,
+// committees and substitute validators are set for a block of the past
+func (blockService BlockService) GetBeaconCommittees(commitment Commitment) (*blockchain.BeaconBestState, *RPCError) {
+	if commitment.IsHistorical() {
+		blockHash, err := getBeaconBlockHash(blockService.BlockChain, commitment)
+		if err != nil {
+			return nil, NewRPCError(GetHistoricalStateError, err)
+		}
+		beaconState, err := blockService.BlockChain.GetBeaconCommitteesByBlockHash(*blockHash)
+		if err != nil {
+			return nil, NewRPCError(GetHistoricalStateError, err)
+		}
+		return beaconState, nil
+	}
+	if commitment.Finalized {
+		return blockService.BlockChain.BeaconChain.GetFinalViewState(), nil
+	}
+	beaconState, err := blockService.GetBeaconBestState()
+	if err != nil {
+		return nil, NewRPCError(GetClonedBeaconBestStateError, err)
+	}
+	return beaconState, nil
+}
+
 func (blockService BlockService) GetBeaconBestBlockHash() (*common.Hash, error) {
 	clonedBeaconBestState, err := blockService.BlockChain.GetClonedBeaconBestState()
 	if err != nil {
@@ -74,6 +98,9 @@ func (blockService BlockService) RetrieveShardBlock(hashString string, verbosity
 	shardBlock, _, errD := blockService.BlockChain.GetShardBlockByHash(*hash)
 	if errD != nil {
 		Logger.log.Debugf("handleRetrieveBlock result: %+v, err: %+v", nil, errD)
+		if blockchain.IsBlockPrunedError(errD) {
+			return nil, NewRPCError(BlockPrunedError, errD)
+		}
 		return nil, NewRPCError(GetShardBlockByHashError, errD)
 	}
 	result := jsonresult.GetShardBlockResult{}
@@ -106,7 +133,6 @@ func (blockService BlockService) RetrieveShardBlock(hashString string, verbosity
 			}
 		}
 		result.Hash = shardBlock.Hash().String()
-		result.Confirmations = int64(1 + best.Header.Height - blockHeight)
 		result.Height = shardBlock.Header.Height
 		result.Version = shardBlock.Header.Version
 		result.TxRoot = shardBlock.Header.TxRoot.String()
@@ -150,7 +176,6 @@ func (blockService BlockService) RetrieveShardBlock(hashString string, verbosity
 			}
 		}
 		result.Hash = shardBlock.Hash().String()
-		result.Confirmations = int64(1 + best.Header.Height - blockHeight)
 		result.Height = shardBlock.Header.Height
 		result.Version = shardBlock.Header.Version
 		result.TxRoot = shardBlock.Header.TxRoot.String()
@@ -191,6 +216,7 @@ func (blockService BlockService) RetrieveShardBlock(hashString string, verbosity
 			result.Txs = append(result.Txs, transactionResult)
 		}
 	}
+	blockService.setShardBlockFinality(&result, shardBlock)
 	return &result, nil
 }
 
@@ -198,6 +224,9 @@ func (blockService BlockService) RetrieveShardBlockByHeight(blockHeight uint64,
 	shardBlocks, errD := blockService.BlockChain.GetShardBlockByHeight(blockHeight, byte(shardId))
 	if errD != nil {
 		Logger.log.Debugf("handleRetrieveBlock result: %+v, err: %+v", nil, errD)
+		if blockchain.IsBlockPrunedError(errD) {
+			return nil, NewRPCError(BlockPrunedError, errD)
+		}
 		return nil, NewRPCError(GetShardBlockByHashError, errD)
 	}
 	result := []*jsonresult.GetShardBlockResult{}
@@ -228,7 +257,6 @@ func (blockService BlockService) RetrieveShardBlockByHeight(blockHeight uint64,
 				}
 			}
 			res.Hash = shardBlock.Hash().String()
-			res.Confirmations = int64(1 + best.Header.Height - blockHeight)
 			res.Height = shardBlock.Header.Height
 			res.Version = shardBlock.Header.Version
 			res.TxRoot = shardBlock.Header.TxRoot.String()
@@ -271,7 +299,6 @@ func (blockService BlockService) RetrieveShardBlockByHeight(blockHeight uint64,
 			}
 
 			res.Hash = shardBlock.Hash().String()
-			res.Confirmations = int64(1 + best.Header.Height - blockHeight)
 			res.Height = shardBlock.Header.Height
 			res.Version = shardBlock.Header.Version
 			res.TxRoot = shardBlock.Header.TxRoot.String()
@@ -312,6 +339,7 @@ func (blockService BlockService) RetrieveShardBlockByHeight(blockHeight uint64,
 				res.Txs = append(res.Txs, transactionT)
 			}
 		}
+		blockService.setShardBlockFinality(&res, shardBlock)
 		result = append(result, &res)
 	}
 	return result, nil
@@ -347,6 +375,7 @@ func (blockService BlockService) RetrieveBeaconBlock(hashString string) (*jsonre
 		return nil, NewRPCError(UnexpectedError, errS)
 	}
 	result := jsonresult.NewGetBlocksBeaconResult(block, uint64(len(blockBytes)), nextHashString)
+	blockService.setBeaconBlockFinality(result, block)
 	return result, nil
 }
 
@@ -381,6 +410,7 @@ func (blockService BlockService) RetrieveBeaconBlockByHeight(blockHeight uint64)
 			}
 		}
 		res := jsonresult.NewGetBlocksBeaconResult(beaconBlock, uint64(len(beaconBlockBytes)), nextHashString)
+		blockService.setBeaconBlockFinality(res, beaconBlock)
 		result = append(result, res)
 	}
 	return result, nil
@@ -431,6 +461,7 @@ func (blockService BlockService) GetBlocks(shardIDParam int, numBlock int) (inte
 					return nil, NewRPCError(GetShardBlockByHashError, errD)
 				}
 				blockResult := jsonresult.NewGetBlockResult(block, size, common.EmptyString)
+				blockService.setShardBlockFinality(blockResult, block)
 				resultShard = append(resultShard, *blockResult)
 				previousHash = &block.Header.PreviousBlockHash
 				if previousHash.String() == (common.Hash{}).String() {
@@ -464,6 +495,7 @@ func (blockService BlockService) GetBlocks(shardIDParam int, numBlock int) (inte
 					return nil, NewRPCError(GetBeaconBlockByHashError, errD)
 				}
 				blockResult := jsonresult.NewGetBlocksBeaconResult(block, size, common.EmptyString)
+				blockService.setBeaconBlockFinality(blockResult, block)
 				resultBeacon = append(resultBeacon, *blockResult)
 				previousHash = &block.Header.PreviousBlockHash
 				if previousHash.String() == (common.Hash{}).String() {
@@ -485,6 +517,20 @@ func (blockService BlockService) GetBlocks(shardIDParam int, numBlock int) (inte
 	}
 }
 
+// setShardBlockFinality - set the confirmations of the block at the best view of its shard and whether it is final
+func (blockService BlockService) setShardBlockFinality(result *jsonresult.GetShardBlockResult, block *blockchain.ShardBlock) {
+	confirmations, isFinalized := blockService.BlockChain.GetShardBlockFinality(block.Header.ShardID, block.Header.Height, *block.Hash())
+	result.Confirmations = int64(confirmations)
+	result.IsFinalized = isFinalized
+}
+
+// setBeaconBlockFinality - set the confirmations of the block at the best beacon view and whether it is final
+func (blockService BlockService) setBeaconBlockFinality(result *jsonresult.GetBeaconBlockResult, block *blockchain.BeaconBlock) {
+	confirmations, isFinalized := blockService.BlockChain.GetBeaconBlockFinality(block.Header.Height, *block.Hash())
+	result.Confirmations = int64(confirmations)
+	result.IsFinalized = isFinalized
+}
+
 func (blockService BlockService) IsBeaconBestStateNil() bool {
 	return blockService.BlockChain.GetBeaconBestState() == nil
 }
@@ -858,18 +904,8 @@ func (blockService BlockService) GetAllBridgeTokens() ([]*rawdbv2.BridgeTokenInf
 	return bridgeTokenInfos, err
 }
 
-func (blockService BlockService) CheckETHHashIssued(data map[string]interface{}) (bool, error) {
-	blockHashParam, ok := data["BlockHash"].(string)
-	if !ok {
-		return false, errors.New("Block hash param is invalid")
-	}
+func (blockService BlockService) CheckETHHashIssued(blockHashParam string, txIdx uint) (bool, error) {
 	blockHash := rCommon.HexToHash(blockHashParam)
-
-	txIdxParam, ok := data["TxIndex"].(float64)
-	if !ok {
-		return false, errors.New("Tx index param is invalid")
-	}
-	txIdx := uint(txIdxParam)
 	uniqETHTx := append(blockHash[:], []byte(strconv.Itoa(int(txIdx)))...)
 	bridgeStateDB := blockService.BlockChain.GetBeaconBestState().GetBeaconFeatureStateDB()
 	issued, err := statedb.IsETHTxHashIssued(bridgeStateDB, uniqETHTx)
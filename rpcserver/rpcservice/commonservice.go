@@ -2,8 +2,10 @@ package rpcservice
 
 import (
 	"errors"
+	"fmt"
 	rCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -170,10 +172,10 @@ func GetStakingAmount(stakingType int, stakingShardAmountParam uint64) uint64 {
 	return amount
 }
 
-func HashToIdenticon(hashStrs []interface{}) ([]string, error) {
+func HashToIdenticon(hashStrs []string) ([]string, error) {
 	result := make([]string, 0)
 	for _, hash := range hashStrs {
-		temp, err := common.Hash{}.NewHashFromStr(hash.(string))
+		temp, err := common.Hash{}.NewHashFromStr(hash)
 		if err != nil {
 			return nil, errors.New("Hash string is invalid")
 		}
@@ -193,3 +195,87 @@ func GenerateTokenID(network string, name string) (common.Hash, error) {
 	}
 	return *hash, nil
 }
+
+// Commitment levels of the state rpcs
+const (
+	CommitmentBest      = "best"      // state at the best view of the chain, the default
+	CommitmentFinalized = "finalized" // state at the final view of the chain, blocks after it may still be reverted
+)
+
+// Commitment - block whose state an rpc reads: the best view, the final view, or a block of the past given by its
+// height in the chain of the best view or by its hash, which only archive nodes serve
+type Commitment struct {
+	Finalized bool
+	Height    uint64
+	BlockHash *common.Hash
+}
+
+// IsHistorical - whether the commitment is a block of the past
+func (commitment Commitment) IsHistorical() bool {
+	return commitment.Height > 0 || commitment.BlockHash != nil
+}
+
+// ParseCommitment - parse the commitment param of a state rpc: "best" or an empty string for the best view,
+// "finalized" for the final view, a block height or a block hash. Blocks of the past are rejected unless isArchive
+func ParseCommitment(param interface{}, isArchive bool) (*Commitment, error) {
+	commitment := &Commitment{}
+	switch value := param.(type) {
+	case string:
+		switch value {
+		case "", CommitmentBest:
+		case CommitmentFinalized:
+			commitment.Finalized = true
+		default:
+			if len(value) != common.HashSize*2 {
+				return nil, fmt.Errorf("commitment %+v is invalid, want %+v, %+v, a block height or a block hash", value, CommitmentBest, CommitmentFinalized)
+			}
+			blockHash, err := common.Hash{}.NewHashFromStr(value)
+			if err != nil {
+				return nil, fmt.Errorf("commitment %+v is not a block hash, error %+v", value, err)
+			}
+			commitment.BlockHash = blockHash
+		}
+	case float64:
+		if value < 1 || value != float64(uint64(value)) {
+			return nil, fmt.Errorf("commitment %+v is not a block height", value)
+		}
+		commitment.Height = uint64(value)
+	default:
+		return nil, fmt.Errorf("commitment %+v is invalid, want a string or a block height", param)
+	}
+	if commitment.IsHistorical() && !isArchive {
+		return nil, errors.New("the state at a block of the past is only served by archive nodes (--archive)")
+	}
+	return commitment, nil
+}
+
+// getShardBlockHash - hash of the shard block of a historical commitment
+func getShardBlockHash(bc *blockchain.BlockChain, shardID byte, commitment Commitment) (*common.Hash, error) {
+	if commitment.BlockHash != nil {
+		return commitment.BlockHash, nil
+	}
+	return bc.GetShardBlockHashByHeight(bc.ShardChain[shardID].GetFinalView(), bc.ShardChain[shardID].GetBestView(), commitment.Height)
+}
+
+// getBeaconBlockHash - hash of the beacon block of a historical commitment
+func getBeaconBlockHash(bc *blockchain.BlockChain, commitment Commitment) (*common.Hash, error) {
+	if commitment.BlockHash != nil {
+		return commitment.BlockHash, nil
+	}
+	return bc.GetBeaconBlockHashByHeight(bc.BeaconChain.GetFinalView(), bc.BeaconChain.GetBestView(), commitment.Height)
+}
+
+// GetListOutputCoinsByKeyset - return the unspent output coins of the keyset at the block of the commitment
+func GetListOutputCoinsByKeyset(bc *blockchain.BlockChain, keySet *incognitokey.KeySet, shardID byte, tokenID *common.Hash, commitment Commitment) ([]*privacy.OutputCoin, error) {
+	if commitment.IsHistorical() {
+		blockHash, err := getShardBlockHash(bc, shardID, commitment)
+		if err != nil {
+			return nil, err
+		}
+		return bc.GetListOutputCoinsByKeysetAtBlock(keySet, shardID, tokenID, *blockHash)
+	}
+	if commitment.Finalized {
+		return bc.GetListFinalizedOutputCoinsByKeyset(keySet, shardID, tokenID)
+	}
+	return bc.GetListOutputCoinsByKeyset(keySet, shardID, tokenID)
+}
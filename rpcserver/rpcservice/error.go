@@ -124,6 +124,15 @@ const (
 	RestoreCandidateBeaconWaitingForNextRandom
 	RestoreCandidateShardWaitingForCurrentRandom
 	RestoreCandidateShardWaitingForNextRandom
+
+	// archive
+	GetHistoricalStateError
+
+	// pruning
+	BlockPrunedError
+
+	// htlc
+	GetHTLCError
 )
 
 // Standard JSON-RPC 2.0 errors.
@@ -249,6 +258,15 @@ var ErrCodeMessage = map[int]struct {
 	RestoreCandidateShardWaitingForCurrentRandom:  {-12007, "Restore candidate shard waiting for current random"},
 	RestoreCandidateShardWaitingForNextRandom:     {-12008, "Restore candidate shard waiting for next random"},
 	GetAllBeaconViews:                             {-12009, "Get all beacon views"},
+
+	// archive
+	GetHistoricalStateError: {-12100, "Get state at a block of the past error"},
+
+	// pruning
+	BlockPrunedError: {-12200, "Block is pruned, the node keeps the header and the state only"},
+
+	// htlc
+	GetHTLCError: {-12300, "Get htlc error"},
 }
 
 // RPCError represents an error that is used as a part of a JSON-RPC JsonResponse
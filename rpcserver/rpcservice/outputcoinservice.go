@@ -59,14 +59,14 @@ func (coinService CoinService) ListUnspentOutputCoinsByKey(listKeyParams []inter
 			if outCoin.CoinDetails.GetValue() == 0 {
 				continue
 			}
-			item = append(item, jsonresult.NewOutCoin(outCoin))
+			item = append(item, jsonresult.NewOutCoinWithMemo(outCoin, keyWallet.KeySet.ReadonlyKey.Rk))
 		}
 		result.Outputs[privateKeyStr] = item
 	}
 	return result, nil
 }
 
-func (coinService CoinService) ListOutputCoinsByKey(listKeyParams []interface{}, tokenID common.Hash) (*jsonresult.ListOutputCoins, *RPCError) {
+func (coinService CoinService) ListOutputCoinsByKey(listKeyParams []interface{}, tokenID common.Hash, commitment Commitment) (*jsonresult.ListOutputCoins, *RPCError) {
 	result := &jsonresult.ListOutputCoins{
 		Outputs: make(map[string][]jsonresult.OutCoin),
 	}
@@ -108,7 +108,7 @@ func (coinService CoinService) ListOutputCoinsByKey(listKeyParams []interface{},
 		}
 		lastByte := keySet.PaymentAddress.Pk[len(keySet.PaymentAddress.Pk)-1]
 		shardIDSender := common.GetShardIDFromLastByte(lastByte)
-		outputCoins, err := coinService.BlockChain.GetListOutputCoinsByKeyset(&keySet, shardIDSender, &tokenID)
+		outputCoins, err := GetListOutputCoinsByKeyset(coinService.BlockChain, &keySet, shardIDSender, &tokenID, commitment)
 		if err != nil {
 			Logger.log.Debugf("handleListOutputCoins result: %+v, err: %+v", nil, err)
 			return nil, NewRPCError(ListOutputCoinsByKeyError, err)
@@ -116,7 +116,7 @@ func (coinService CoinService) ListOutputCoinsByKey(listKeyParams []interface{},
 		item := make([]jsonresult.OutCoin, 0)
 
 		for _, outCoin := range outputCoins {
-			item = append(item, jsonresult.NewOutCoin(outCoin))
+			item = append(item, jsonresult.NewOutCoinWithMemo(outCoin, keySet.ReadonlyKey.Rk))
 		}
 		if readonlyKey != nil && len(readonlyKey.KeySet.ReadonlyKey.Rk) > 0 {
 			result.Outputs[readonlyKeyStr] = item
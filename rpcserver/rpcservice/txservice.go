@@ -61,17 +61,13 @@ func (txService TxService) ListCommitmentIndices(tokenID common.Hash, shardID by
 	return statedb.ListCommitmentIndices(transactionStateDB, tokenID, shardID)
 }
 
-func (txService TxService) HasSerialNumbers(paymentAddressStr string, serialNumbersStr []interface{}, tokenID common.Hash) ([]bool, error) {
+func (txService TxService) HasSerialNumbers(paymentAddressStr string, serialNumbersStr []string, tokenID common.Hash) ([]bool, error) {
 	_, shardIDSender, err := GetKeySetFromPaymentAddressParam(paymentAddressStr)
 	if err != nil {
 		return nil, err
 	}
 	result := make([]bool, 0)
-	for _, item := range serialNumbersStr {
-		itemStr, okParam := item.(string)
-		if !okParam {
-			return nil, fmt.Errorf("Invalid serial number param, %+v", item)
-		}
+	for _, itemStr := range serialNumbersStr {
 		serialNumber, _, err := base58.Base58Check{}.Decode(itemStr)
 		if err != nil {
 			return nil, fmt.Errorf("Decode serial number failed, %+v", itemStr)
@@ -90,17 +86,13 @@ func (txService TxService) HasSerialNumbers(paymentAddressStr string, serialNumb
 	return result, nil
 }
 
-func (txService TxService) HasSnDerivators(paymentAddressStr string, snDerivatorStr []interface{}, tokenID common.Hash) ([]bool, error) {
+func (txService TxService) HasSnDerivators(paymentAddressStr string, snDerivatorStr []string, tokenID common.Hash) ([]bool, error) {
 	_, shardIDSender, err := GetKeySetFromPaymentAddressParam(paymentAddressStr)
 	if err != nil {
 		return nil, err
 	}
 	result := make([]bool, 0)
-	for _, item := range snDerivatorStr {
-		itemStr, okParam := item.(string)
-		if !okParam {
-			return nil, errors.New("Invalid serial number derivator param")
-		}
+	for _, itemStr := range snDerivatorStr {
 		snderivator, _, err := base58.Base58Check{}.Decode(itemStr)
 		if err != nil {
 			return nil, errors.New("Invalid serial number derivator param")
@@ -183,16 +175,9 @@ func (txService TxService) chooseOutsCoinByKeyset(
 	privacyCustomTokenParams *transaction.CustomTokenPrivacyParamTx,
 	isGetFeePToken bool,
 	unitFeePToken int64,
+	commitmentRingSize int,
+	stealth bool,
 ) ([]*privacy.InputCoin, uint64, *RPCError) {
-	// estimate fee according to 8 recent block
-	if numBlock == 0 {
-		numBlock = 1000
-	}
-	// calculate total amount to send
-	totalAmmount := uint64(0)
-	for _, receiver := range paymentInfos {
-		totalAmmount += receiver.Amount
-	}
 	// get list outputcoins tx
 	prvCoinID := &common.Hash{}
 	prvCoinID.SetBytes(common.PRVCoinID[:])
@@ -205,6 +190,31 @@ func (txService TxService) chooseOutsCoinByKeyset(
 	if err != nil {
 		return nil, 0, NewRPCError(GetOutputCoinError, err)
 	}
+	return txService.chooseInputCoinsFromOutCoins(outCoins, paymentInfos, unitFeeNativeToken, numBlock, keySet.PaymentAddress, shardIDSender, hasPrivacy, metadataParam, privacyCustomTokenParams, commitmentRingSize, stealth)
+}
+
+// chooseInputCoinsFromOutCoins returns list of input coins native token to spent among unspent outCoins of sender,
+// change of sender is counted in estimating fee, commitmentRingSize is the ring size of the one out of many proofs of
+// the tx, 0 is the default one, stealth is whether its output coins are stealth coins
+func (txService TxService) chooseInputCoinsFromOutCoins(
+	outCoins []*privacy.OutputCoin,
+	paymentInfos []*privacy.PaymentInfo,
+	unitFeeNativeToken int64, numBlock uint64, senderPaymentAddress privacy.PaymentAddress, shardIDSender byte,
+	hasPrivacy bool,
+	metadataParam metadata.Metadata,
+	privacyCustomTokenParams *transaction.CustomTokenPrivacyParamTx,
+	commitmentRingSize int,
+	stealth bool,
+) ([]*privacy.InputCoin, uint64, *RPCError) {
+	// estimate fee according to 8 recent block
+	if numBlock == 0 {
+		numBlock = 1000
+	}
+	// calculate total amount to send
+	totalAmmount := uint64(0)
+	for _, receiver := range paymentInfos {
+		totalAmmount += receiver.Amount
+	}
 	if len(outCoins) == 0 && totalAmmount > 0 {
 		return nil, 0, NewRPCError(GetOutputCoinError, errors.New("not enough output coin"))
 	}
@@ -218,7 +228,7 @@ func (txService TxService) chooseOutsCoinByKeyset(
 	if overBalanceAmount > 0 {
 		// add more into output for estimate fee
 		paymentInfos = append(paymentInfos, &privacy.PaymentInfo{
-			PaymentAddress: keySet.PaymentAddress,
+			PaymentAddress: senderPaymentAddress,
 			Amount:         overBalanceAmount,
 		})
 	}
@@ -231,7 +241,7 @@ func (txService TxService) chooseOutsCoinByKeyset(
 	realFee, _, _, err := txService.EstimateFee(unitFeeNativeToken, false, candidateOutputCoins,
 		paymentInfos, shardIDSender, numBlock, hasPrivacy,
 		metadataParam,
-		privacyCustomTokenParams, int64(beaconHeight))
+		privacyCustomTokenParams, int64(beaconHeight), commitmentRingSize, stealth)
 	if err != nil {
 		return nil, 0, NewRPCError(RejectInvalidTxFeeError, err)
 	}
@@ -270,6 +280,8 @@ func (txService TxService) chooseOutsCoinByKeyset(
 // EstimateFee - estimate fee from tx data and return real full fee, fee per kb and real tx size
 // if isGetPTokenFee == true: return fee for ptoken
 // if isGetPTokenFee == false: return fee for native token
+// commitmentRingSize is the ring size of the one out of many proofs of the tx, 0 is the default one
+// stealth is whether the output coins of the tx are stealth coins
 func (txService TxService) EstimateFee(
 	defaultFee int64,
 	isGetPTokenFee bool,
@@ -278,7 +290,7 @@ func (txService TxService) EstimateFee(
 	numBlock uint64, hasPrivacy bool,
 	metadata metadata.Metadata,
 	privacyCustomTokenParams *transaction.CustomTokenPrivacyParamTx,
-	beaconHeight int64) (uint64, uint64, uint64, error) {
+	beaconHeight int64, commitmentRingSize int, stealth bool) (uint64, uint64, uint64, error) {
 	if numBlock == 0 {
 		numBlock = 1000
 	}
@@ -305,7 +317,7 @@ func (txService TxService) EstimateFee(
 	if feeEstimator, ok := txService.FeeEstimator[shardID]; ok {
 		limitFee = feeEstimator.GetLimitFeeForNativeToken()
 	}
-	estimateTxSizeInKb = transaction.EstimateTxSize(transaction.NewEstimateTxSizeParam(len(candidateOutputCoins), len(paymentInfos), hasPrivacy, metadata, privacyCustomTokenParams, limitFee))
+	estimateTxSizeInKb = transaction.EstimateTxSize(transaction.NewEstimateTxSizeParam(len(candidateOutputCoins), len(paymentInfos), hasPrivacy, metadata, privacyCustomTokenParams, limitFee).SetCommitmentRingSize(commitmentRingSize).SetStealth(stealth))
 	realFee = uint64(estimateFeeCoinPerKb) * uint64(estimateTxSizeInKb)
 	return realFee, estimateFeeCoinPerKb, estimateTxSizeInKb, nil
 }
@@ -362,7 +374,7 @@ func (txService TxService) BuildRawTransaction(params *bean.CreateRawTxParam, me
 	inputCoins, realFee, err1 := txService.chooseOutsCoinByKeyset(
 		params.PaymentInfos, params.EstimateFeeCoinPerKb, 0,
 		params.SenderKeySet, params.ShardIDSender, params.HasPrivacyCoin,
-		meta, nil, false, int64(0))
+		meta, nil, false, int64(0), params.CommitmentRingSize, params.Stealth)
 	if err1 != nil {
 		return nil, err1
 	}
@@ -379,13 +391,161 @@ func (txService TxService) BuildRawTransaction(params *bean.CreateRawTxParam, me
 			nil, // use for prv coin -> nil is valid
 			meta,
 			params.Info,
-		))
+		).SetCommitmentRingSize(params.CommitmentRingSize).SetStealth(params.Stealth))
 	if err != nil {
 		return nil, NewRPCError(CreateTxDataError, err)
 	}
 	return &tx, nil
 }
 
+// BuildUnsignedTransaction returns a tx template of a PRV tx, it has to be signed with the private key of sender
+// by transaction.Tx.InitFromTemplate before being sent
+func (txService TxService) BuildUnsignedTransaction(params *bean.CreateUnsignedTxParam) (*transaction.TxTemplate, *RPCError) {
+	prvCoinID := &common.Hash{}
+	prvCoinID.SetBytes(common.PRVCoinID[:])
+	outCoins, err := txService.BlockChain.GetListOutputCoinsByKeyset(params.SenderKeySet, params.ShardIDSender, prvCoinID)
+	if err != nil {
+		return nil, NewRPCError(GetOutputCoinError, err)
+	}
+	// without private key, spent coins are only known from the serial numbers computed by sender
+	if params.SerialNumbers != nil {
+		outCoins, err = txService.filterOutcoinsBySerialNumbers(outCoins, params.SerialNumbers, params.ShardIDSender, prvCoinID)
+		if err != nil {
+			return nil, NewRPCError(GetOutputCoinError, err)
+		}
+	}
+	inputCoins, realFee, rpcErr := txService.chooseInputCoinsFromOutCoins(
+		outCoins, params.PaymentInfos, params.EstimateFeeCoinPerKb, 0,
+		params.SenderKeySet.PaymentAddress, params.ShardIDSender, params.HasPrivacyCoin,
+		nil, nil, params.CommitmentRingSize, params.Stealth)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	template, err := transaction.NewTxTemplate(
+		transaction.NewTxPrivacyInitParams(
+			nil,
+			params.PaymentInfos,
+			inputCoins,
+			realFee,
+			params.HasPrivacyCoin,
+			txService.BlockChain.GetBestStateShard(params.ShardIDSender).GetCopiedTransactionStateDB(),
+			nil, // use for prv coin -> nil is valid
+			nil,
+			params.Info,
+		).SetCommitmentRingSize(params.CommitmentRingSize).SetStealth(params.Stealth),
+		params.SenderKeySet.PaymentAddress)
+	if err != nil {
+		return nil, NewRPCError(CreateTxDataError, err)
+	}
+	return template, nil
+}
+
+// BuildUnsignedPrivacyTokenTransaction returns a template of a privacy token transfer, it has to be signed with the
+// private key of sender by transaction.TxCustomTokenPrivacy.InitFromTemplate before being sent
+func (txService TxService) BuildUnsignedPrivacyTokenTransaction(params *bean.CreateUnsignedPrivacyTokenTxParam) (*transaction.TxPrivacyTokenTemplate, *RPCError) {
+	/******* START choose output token coins, which are transferred *****/
+	outputTokens, err := txService.BlockChain.GetListOutputCoinsByKeyset(params.SenderKeySet, params.ShardIDSender, params.TokenID)
+	if err != nil {
+		return nil, NewRPCError(GetOutputCoinError, err)
+	}
+	if params.TokenSerialNumbers != nil {
+		outputTokens, err = txService.filterOutcoinsBySerialNumbers(outputTokens, params.TokenSerialNumbers, params.ShardIDSender, params.TokenID)
+		if err != nil {
+			return nil, NewRPCError(GetOutputCoinError, err)
+		}
+	}
+	tokenAmount := params.TokenFee
+	for _, receiver := range params.TokenReceivers {
+		tokenAmount += receiver.Amount
+	}
+	candidateOutputTokens, _, _, err := txService.chooseBestOutCoinsToSpent(outputTokens, tokenAmount)
+	if err != nil {
+		return nil, NewRPCError(GetOutputCoinError, err)
+	}
+	tokenParams := &transaction.CustomTokenPrivacyParamTx{
+		PropertyID:  params.TokenID.String(),
+		TokenTxType: transaction.CustomTokenTransfer,
+		Amount:      tokenAmount,
+		Receiver:    params.TokenReceivers,
+		TokenInput:  transaction.ConvertOutputCoinToInputCoin(candidateOutputTokens),
+		Fee:         params.TokenFee,
+	}
+	/******* END choose output token coins *****/
+
+	/******* START choose output native coins(PRV), which is used to create tx *****/
+	prvCoinID := &common.Hash{}
+	prvCoinID.SetBytes(common.PRVCoinID[:])
+	outCoins, err := txService.BlockChain.GetListOutputCoinsByKeyset(params.SenderKeySet, params.ShardIDSender, prvCoinID)
+	if err != nil {
+		return nil, NewRPCError(GetOutputCoinError, err)
+	}
+	if params.SerialNumbers != nil {
+		outCoins, err = txService.filterOutcoinsBySerialNumbers(outCoins, params.SerialNumbers, params.ShardIDSender, prvCoinID)
+		if err != nil {
+			return nil, NewRPCError(GetOutputCoinError, err)
+		}
+	}
+	inputCoins, realFeePRV, rpcErr := txService.chooseInputCoinsFromOutCoins(
+		outCoins, params.PaymentInfos, params.EstimateFeeCoinPerKb, 0,
+		params.SenderKeySet.PaymentAddress, params.ShardIDSender, params.HasPrivacyCoin,
+		nil, tokenParams, 0, false)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	hasPrivacyCoin := params.HasPrivacyCoin
+	if len(params.PaymentInfos) == 0 && realFeePRV == 0 {
+		hasPrivacyCoin = false
+	}
+	/******* END choose output native coins(PRV) *****/
+
+	beaconView := txService.BlockChain.BeaconChain.GetFinalViewState()
+	template, err := transaction.NewTxPrivacyTokenTemplate(
+		transaction.NewTxPrivacyTokenInitParams(nil,
+			params.PaymentInfos,
+			inputCoins,
+			realFeePRV,
+			tokenParams,
+			txService.BlockChain.GetBestStateShard(params.ShardIDSender).GetCopiedTransactionStateDB(),
+			nil,
+			hasPrivacyCoin,
+			params.HasPrivacyToken,
+			params.ShardIDSender, params.Info,
+			beaconView.GetBeaconFeatureStateDB()),
+		params.SenderKeySet.PaymentAddress)
+	if err != nil {
+		return nil, NewRPCError(CreateTxDataError, err)
+	}
+	return template, nil
+}
+
+// filterOutcoinsBySerialNumbers keeps coins having a serial number in serialNumbers (keyed by base58 SNDerivator)
+// which is neither in transactionStateDB nor in mem pool
+func (txService TxService) filterOutcoinsBySerialNumbers(outCoins []*privacy.OutputCoin, serialNumbers map[string][]byte, shardID byte, tokenID *common.Hash) ([]*privacy.OutputCoin, error) {
+	transactionStateDB := txService.BlockChain.GetBestStateShard(shardID).GetCopiedTransactionStateDB()
+	remainOutputCoins := make([]*privacy.OutputCoin, 0)
+	for _, outCoin := range outCoins {
+		snd := base58.Base58Check{}.Encode(outCoin.CoinDetails.GetSNDerivator().ToBytesS(), common.ZeroByte)
+		serialNumber, ok := serialNumbers[snd]
+		if !ok {
+			continue
+		}
+		serialNumberPoint, err := new(privacy.Point).FromBytesS(serialNumber)
+		if err != nil {
+			return nil, fmt.Errorf("serial number of SND %+v is invalid", snd)
+		}
+		spent, err := statedb.HasSerialNumber(transactionStateDB, *tokenID, serialNumber, shardID)
+		if spent || err != nil {
+			continue
+		}
+		if txService.TxMemPool != nil && txService.TxMemPool.ValidateSerialNumberHashH(serialNumber) != nil {
+			continue
+		}
+		outCoin.CoinDetails.SetSerialNumber(serialNumberPoint)
+		remainOutputCoins = append(remainOutputCoins, outCoin)
+	}
+	return remainOutputCoins, nil
+}
+
 func (txService TxService) CreateRawTransaction(params *bean.CreateRawTxParam, meta metadata.Metadata) (*common.Hash, []byte, byte, *RPCError) {
 	var err error
 	tx, err := txService.BuildRawTransaction(params, meta)
@@ -402,7 +562,9 @@ func (txService TxService) CreateRawTransaction(params *bean.CreateRawTxParam, m
 	return tx.Hash(), txBytes, txShardID, nil
 }
 
-func (txService TxService) SendRawTransaction(txB58Check string) (wire.Message, *common.Hash, byte, *RPCError) {
+// SendRawTransaction - add a PRV transaction to the mempool, a transaction relayed privately is not broadcast by the
+// mempool until its stem phase is over
+func (txService TxService) SendRawTransaction(txB58Check string, isPrivate bool) (wire.Message, *common.Hash, byte, *RPCError) {
 	// Decode base58check data of tx
 	rawTxBytes, _, err := base58.Base58Check{}.Decode(txB58Check)
 	if err != nil {
@@ -425,7 +587,7 @@ func (txService TxService) SendRawTransaction(txB58Check string) (wire.Message,
 		Logger.log.Errorf("Send Raw Transaction can not get beacon best state with error %+v", err)
 	}
 	// Try add tx in to mempool of node
-	hash, _, err := txService.TxMemPool.MaybeAcceptTransaction(&tx, beaconHeigh)
+	hash, err := txService.maybeAcceptLocalTransaction(&tx, beaconHeigh, isPrivate)
 	if err != nil {
 		Logger.log.Errorf("Send Raw Transaction Error, try add tx into mempool of node: %+v", err)
 		mempoolErr, ok := err.(*mempool.MempoolTxError)
@@ -749,6 +911,12 @@ func (txService TxService) BuildRawPrivacyCustomTokenTransaction(params interfac
 	if errParam != nil {
 		return nil, NewRPCError(RPCInvalidParamsError, errParam)
 	}
+	return txService.BuildRawPrivacyCustomTokenTransactionFromParam(txParam, metaData)
+}
+
+// BuildRawPrivacyCustomTokenTransactionFromParam - build a privacy token tx from its parsed params, the memos of txParam
+// are encrypted to the receivers of PRV and token
+func (txService TxService) BuildRawPrivacyCustomTokenTransactionFromParam(txParam *bean.CreateRawPrivacyTokenTxParam, metaData metadata.Metadata) (*transaction.TxCustomTokenPrivacy, *RPCError) {
 	tokenParamsRaw := txParam.TokenParamsRaw
 	var err error
 	tokenParams, err := txService.BuildTokenParam(tokenParamsRaw, txParam.SenderKeySet, txParam.ShardIDSender)
@@ -760,12 +928,15 @@ func (txService TxService) BuildRawPrivacyCustomTokenTransaction(params interfac
 	if tokenParams == nil {
 		return nil, NewRPCError(RPCInvalidParamsError, errors.New("can not build token params for request"))
 	}
+	if err := txParam.SetPaymentMemos(tokenParams.Receiver); err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, err)
+	}
 	/******* START choose output native coins(PRV), which is used to create tx *****/
 	var inputCoins []*privacy.InputCoin
 	realFeePRV := uint64(0)
 	inputCoins, realFeePRV, err = txService.chooseOutsCoinByKeyset(txParam.PaymentInfos,
 		txParam.EstimateFeeCoinPerKb, 0, txParam.SenderKeySet,
-		txParam.ShardIDSender, txParam.HasPrivacyCoin, nil, tokenParams, txParam.IsGetPTokenFee, txParam.UnitPTokenFee)
+		txParam.ShardIDSender, txParam.HasPrivacyCoin, nil, tokenParams, txParam.IsGetPTokenFee, txParam.UnitPTokenFee, 0, false)
 	if err.(*RPCError) != nil {
 		return nil, err.(*RPCError)
 	}
@@ -817,7 +988,7 @@ func (txService TxService) BuildRawPrivacyCustomTokenTransactionV2(params interf
 	realFeePRV := uint64(0)
 	inputCoins, realFeePRV, err = txService.chooseOutsCoinByKeyset(txParam.PaymentInfos,
 		txParam.EstimateFeeCoinPerKb, 0, txParam.SenderKeySet,
-		txParam.ShardIDSender, txParam.HasPrivacyCoin, nil, tokenParams, txParam.IsGetPTokenFee, txParam.UnitPTokenFee)
+		txParam.ShardIDSender, txParam.HasPrivacyCoin, nil, tokenParams, txParam.IsGetPTokenFee, txParam.UnitPTokenFee, 0, false)
 	if err.(*RPCError) != nil {
 		return nil, err.(*RPCError)
 	}
@@ -872,6 +1043,9 @@ func (txService TxService) GetTransactionByHash(txHashStr string) (*jsonresult.T
 	Logger.log.Infof("Get Transaction By Hash %+v", *txHash)
 
 	shardID, blockHash, blockHeight, index, tx, err := txService.BlockChain.GetTransactionByHash(*txHash)
+	if blockchain.IsBlockPrunedError(err) {
+		return nil, NewRPCError(BlockPrunedError, err)
+	}
 	if err != nil {
 		// maybe tx is still in tx mempool -> check mempool
 		tx, errM := txService.TxMemPool.GetTx(txHash)
@@ -892,10 +1066,35 @@ func (txService TxService) GetTransactionByHash(txHashStr string) (*jsonresult.T
 		return nil, NewRPCError(UnexpectedError, err)
 	}
 	result.IsInBlock = true
+	result.Confirmations, result.IsFinalized = txService.BlockChain.GetShardBlockFinality(shardID, blockHeight, blockHash)
 	Logger.log.Debugf("handleGetTransactionByHash result: %+v", result)
 	return result, nil
 }
 
+// GetCrossShardTxStatus - return the status of the outputs of a transaction sent to other shards,
+// pending while the transaction is in the mempool
+func (txService TxService) GetCrossShardTxStatus(txHashStr string) (*jsonresult.CrossShardTxStatus, *RPCError) {
+	txHash, err := common.Hash{}.NewHashFromStr(txHashStr)
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("tx hash is invalid"))
+	}
+	status, err := txService.BlockChain.GetCrossShardTxStatus(*txHash)
+	if err != nil {
+		tx, errM := txService.TxMemPool.GetTx(txHash)
+		if errM != nil {
+			return nil, NewRPCError(TxNotExistedInMemAndBLockError, errors.New("Tx is not existed in block or mempool"))
+		}
+		return &jsonresult.CrossShardTxStatus{
+			TxID:        txHash.String(),
+			Status:      blockchain.CrossShardTxPending,
+			FromShardID: common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte()),
+			IsInMempool: true,
+			Receipts:    []jsonresult.CrossShardTxReceipt{},
+		}, nil
+	}
+	return jsonresult.NewCrossShardTxStatus(status), nil
+}
+
 func (txService TxService) ListPrivacyCustomToken() (map[common.Hash]*statedb.TokenState, error) {
 	tokenStates, err := txService.BlockChain.ListAllPrivacyCustomTokenAndPRV()
 	if err != nil {
@@ -1016,7 +1215,7 @@ func (txService TxService) GetListPrivacyCustomTokenBalance(privateKey string) (
 	return result, nil
 }
 
-func (txService TxService) GetBalancePrivacyCustomToken(privateKey string, tokenIDStr string) (uint64, *RPCError) {
+func (txService TxService) GetBalancePrivacyCustomToken(privateKey string, tokenIDStr string, commitment Commitment) (uint64, *RPCError) {
 	var totalValue uint64 = 0
 	account, err := wallet.Base58CheckDeserialize(privateKey)
 	if err != nil {
@@ -1043,7 +1242,7 @@ func (txService TxService) GetBalancePrivacyCustomToken(privateKey string, token
 	if isExisted {
 		lastByte := account.KeySet.PaymentAddress.Pk[len(account.KeySet.PaymentAddress.Pk)-1]
 		shardIDSender := common.GetShardIDFromLastByte(lastByte)
-		outcoints, err := txService.BlockChain.GetListOutputCoinsByKeyset(&account.KeySet, shardIDSender, tokenID)
+		outcoints, err := GetListOutputCoinsByKeyset(txService.BlockChain, &account.KeySet, shardIDSender, tokenID, commitment)
 		if err != nil {
 			Logger.log.Debugf("handleGetBalancePrivacyCustomToken result: %+v, err: %+v", nil, err)
 			return uint64(0), NewRPCError(UnexpectedError, err)
@@ -1070,7 +1269,7 @@ func (txService TxService) GetBalancePrivacyCustomToken(privateKey string, token
 					if tokenID.IsEqual(tempTokenID) {
 						lastByte := account.KeySet.PaymentAddress.Pk[len(account.KeySet.PaymentAddress.Pk)-1]
 						shardIDSender := common.GetShardIDFromLastByte(lastByte)
-						outcoints, err := txService.BlockChain.GetListOutputCoinsByKeyset(&account.KeySet, shardIDSender, tempTokenID)
+						outcoints, err := GetListOutputCoinsByKeyset(txService.BlockChain, &account.KeySet, shardIDSender, tempTokenID, commitment)
 						if err != nil {
 							Logger.log.Debugf("handleGetBalancePrivacyCustomToken result: %+v, err: %+v", nil, err)
 							return uint64(0), NewRPCError(UnexpectedError, err)
@@ -1174,7 +1373,18 @@ func (txService TxService) RandomCommitments(paymentAddressStr string, outputs [
 	return commitmentIndexs, myCommitmentIndexs, commitments, nil
 }
 
-func (txService TxService) SendRawPrivacyCustomTokenTransaction(base58CheckData string) (wire.Message, *transaction.TxCustomTokenPrivacy, *RPCError) {
+// SendRawPrivacyCustomTokenTransaction - add a privacy token transaction to the mempool, a transaction relayed
+// privately is not broadcast by the mempool until its stem phase is over
+func (txService TxService) maybeAcceptLocalTransaction(tx metadata.Transaction, beaconHeight int64, isPrivate bool) (*common.Hash, error) {
+	if isPrivate {
+		hash, _, err := txService.TxMemPool.MaybeAcceptStemTransaction(tx, beaconHeight, true)
+		return hash, err
+	}
+	hash, _, err := txService.TxMemPool.MaybeAcceptLocalTransaction(tx, beaconHeight)
+	return hash, err
+}
+
+func (txService TxService) SendRawPrivacyCustomTokenTransaction(base58CheckData string, isPrivate bool) (wire.Message, *transaction.TxCustomTokenPrivacy, *RPCError) {
 	rawTxBytes, _, err := base58.Base58Check{}.Decode(base58CheckData)
 	if err != nil {
 		Logger.log.Debugf("handleSendRawPrivacyCustomTokenTransaction result: %+v, err: %+v", nil, err)
@@ -1193,7 +1403,7 @@ func (txService TxService) SendRawPrivacyCustomTokenTransaction(base58CheckData
 	if err == nil {
 		beaconHeigh = int64(beaconBestState.BeaconHeight)
 	}
-	hash, _, err := txService.TxMemPool.MaybeAcceptTransaction(&tx, beaconHeigh)
+	hash, err := txService.maybeAcceptLocalTransaction(&tx, beaconHeigh, isPrivate)
 	if err != nil {
 		Logger.log.Errorf("txService.SendRawPrivacyCustomTokenTransaction Try add tx into mempool of node with err: %+v", err)
 		mempoolErr, ok := err.(*mempool.MempoolTxError)
@@ -1328,7 +1538,7 @@ func (txService TxService) BuildRawDefragmentAccountTransaction(params interface
 	beaconState := txService.BlockChain.GetBeaconBestState()
 	beaconHeight := beaconState.BeaconHeight
 	realFee, _, _, _ := txService.EstimateFee(
-		estimateFeeCoinPerKb, isGetPTokenFee, outCoins, paymentInfos, shardIDSender, 8, hasPrivacyCoin, nil, nil, int64(beaconHeight))
+		estimateFeeCoinPerKb, isGetPTokenFee, outCoins, paymentInfos, shardIDSender, 8, hasPrivacyCoin, nil, nil, int64(beaconHeight), 0, false)
 	if len(outCoins) == 0 {
 		realFee = 0
 	}
@@ -1401,7 +1611,7 @@ func (txService TxService) calculateOutputCoinsByMinValue(outCoins []*privacy.Ou
 	}
 
 	// Try add tx in to mempool of node
-	hash, _, err := txService.TxMemPool.MaybeAcceptTransaction(&tx, beaconHeigh)
+	hash, _, err := txService.TxMemPool.MaybeAcceptLocalTransaction(&tx, beaconHeigh)
 	if err != nil {
 		Logger.log.Errorf("txService.SendRawTxWithMetadata Try add tx into mempool of node with err: %+v", err)
 		mempoolErr, ok := err.(*mempool.MempoolTxError)
@@ -1720,7 +1930,7 @@ func (txService TxService) BuildRawDefragmentPrivacyCustomTokenTransaction(param
 	realFeePRV := uint64(0)
 	inputCoins, realFeePRV, err = txService.chooseOutsCoinByKeyset(txParam.PaymentInfos,
 		txParam.EstimateFeeCoinPerKb, 0, txParam.SenderKeySet,
-		txParam.ShardIDSender, txParam.HasPrivacyCoin, nil, tokenParams, txParam.IsGetPTokenFee, txParam.UnitPTokenFee)
+		txParam.ShardIDSender, txParam.HasPrivacyCoin, nil, tokenParams, txParam.IsGetPTokenFee, txParam.UnitPTokenFee, 0, false)
 	if err.(*RPCError) != nil {
 		return nil, err.(*RPCError)
 	}
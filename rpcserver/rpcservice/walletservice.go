@@ -107,7 +107,7 @@ func (walletService *WalletService) RemoveAccount(privateKey string, passPhrase
 	return true, nil
 }
 
-func (walletService WalletService) GetBalanceByPrivateKey(privateKey string) (uint64, *RPCError) {
+func (walletService WalletService) GetBalanceByPrivateKey(privateKey string, commitment Commitment) (uint64, *RPCError) {
 	keySet, shardIDSender, err := GetKeySetFromPrivateKeyParams(privateKey)
 	if err != nil {
 		return uint64(0), NewRPCError(RPCInvalidParamsError, err)
@@ -120,7 +120,7 @@ func (walletService WalletService) GetBalanceByPrivateKey(privateKey string) (ui
 	if err != nil {
 		return uint64(0), NewRPCError(TokenIsInvalidError, err)
 	}
-	outcoints, err := walletService.BlockChain.GetListOutputCoinsByKeyset(keySet, shardIDSender, prvCoinID)
+	outcoints, err := GetListOutputCoinsByKeyset(walletService.BlockChain, keySet, shardIDSender, prvCoinID, commitment)
 	log.Println(err)
 	if err != nil {
 		return uint64(0), NewRPCError(UnexpectedError, err)
@@ -135,7 +135,7 @@ func (walletService WalletService) GetBalanceByPrivateKey(privateKey string) (ui
 	return balance, nil
 }
 
-func (walletService WalletService) GetBalanceByPaymentAddress(paymentAddress string) (uint64, *RPCError) {
+func (walletService WalletService) GetBalanceByPaymentAddress(paymentAddress string, commitment Commitment) (uint64, *RPCError) {
 	keySet, shardIDSender, err := GetKeySetFromPaymentAddressParam(paymentAddress)
 	if err != nil {
 		return uint64(0), NewRPCError(RPCInvalidParamsError, errors.New("payment address is invalid"))
@@ -146,7 +146,7 @@ func (walletService WalletService) GetBalanceByPaymentAddress(paymentAddress str
 	if err1 != nil {
 		return uint64(0), NewRPCError(TokenIsInvalidError, err1)
 	}
-	outcoints, err := walletService.BlockChain.GetListOutputCoinsByKeyset(keySet, shardIDSender, prvCoinID)
+	outcoints, err := GetListOutputCoinsByKeyset(walletService.BlockChain, keySet, shardIDSender, prvCoinID, commitment)
 	Logger.log.Debugf("OutCoins: %+v", outcoints)
 	Logger.log.Debugf("shardIDSender: %+v", shardIDSender)
 	Logger.log.Debugf("accountWithPaymentAddress.KeySet: %+v", keySet)
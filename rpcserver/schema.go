@@ -0,0 +1,543 @@
+package rpcserver
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/pkg/errors"
+)
+
+/*
+Params of a registered rpc method are described by a struct, each exported field is one positional param in order.
+The json tag of a field gives the name of the param (the key for object params), `rpc:"optional"` marks a param
+which can be omitted or null, `rpc:"variadic"` on a last slice field collects every remaining param, and the desc tag
+is a human description used in the generated schema, ex:
+
+	type getBlockCountParams struct {
+		ShardID int `json:"shardID" desc:"shard id, -1 for beacon"`
+	}
+
+Nested structs describe object params the same way, their fields are the keys of the object.
+*/
+
+// rpcMethodSchema describe params and result of one rpc method
+type rpcMethodSchema struct {
+	Description string
+	Params      interface{} // zero value of the params struct
+	Result      interface{} // zero value of the result, nil when the result is undocumented
+}
+
+// rpcDecimal is an uint64 sent as decimal string, as parsed by common.AssertAndConvertStrToNumber (amount params V2)
+type rpcDecimal uint64
+
+func (decimal *rpcDecimal) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	value, err := common.AssertAndConvertStrToNumber(raw)
+	if err != nil {
+		return err
+	}
+	*decimal = rpcDecimal(value)
+	return nil
+}
+
+func (decimal rpcDecimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("%d", uint64(decimal)))
+}
+
+var (
+	rpcDecimalType     = reflect.TypeOf(rpcDecimal(0))
+	backupSourceType   = reflect.TypeOf(backupSource{})
+	textMarshalerType  = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	rawJsonMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+/*
+validateRPCParams check params of a registered method against its params struct before the handler is called,
+so every handler return the same error for a missing or mistyped param. Unregistered methods are not checked.
+*/
+func validateRPCParams(method string, params interface{}) *rpcservice.RPCError {
+	schema, ok := rpcSchemas[method]
+	if !ok {
+		return nil
+	}
+	// methods without params ignore whatever is sent, ex: "" by older clients
+	if len(rpcParamFields(reflect.TypeOf(schema.Params))) == 0 {
+		return nil
+	}
+	arrayParams, err := rpcParamsArray(params, reflect.TypeOf(schema.Params))
+	if err != nil {
+		return err
+	}
+	return checkRPCParams(arrayParams, reflect.TypeOf(schema.Params))
+}
+
+// rpcParamsArray return params as an array, the only param of a method can also be sent bare (ex: the tx id of getmempoolentry)
+func rpcParamsArray(params interface{}, paramsType reflect.Type) ([]interface{}, *rpcservice.RPCError) {
+	if params == nil {
+		return nil, nil
+	}
+	if arrayParams, ok := params.([]interface{}); ok {
+		return arrayParams, nil
+	}
+	if len(rpcParamFields(paramsType)) == 1 {
+		return []interface{}{params}, nil
+	}
+	return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("params must be an array"))
+}
+
+// decodeRPCParams decode positional params into out, a pointer to a params struct
+func decodeRPCParams(params interface{}, out interface{}) *rpcservice.RPCError {
+	outValue := reflect.ValueOf(out)
+	if outValue.Kind() != reflect.Ptr || outValue.Elem().Kind() != reflect.Struct {
+		return rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("decode params into non struct pointer"))
+	}
+	arrayParams, err := rpcParamsArray(params, outValue.Elem().Type())
+	if err != nil {
+		return err
+	}
+	if err := checkRPCParams(arrayParams, outValue.Elem().Type()); err != nil {
+		return err
+	}
+	structValue := outValue.Elem()
+	for i, field := range rpcParamFields(structValue.Type()) {
+		if field.variadic {
+			if i < len(arrayParams) {
+				if err := convertRPCValue(arrayParams[i:], structValue.FieldByIndex(field.index).Addr().Interface()); err != nil {
+					return rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("params from #%d (%s): %+v", i+1, field.name, err))
+				}
+			}
+			break
+		}
+		if i >= len(arrayParams) || arrayParams[i] == nil {
+			continue
+		}
+		if err := convertRPCValue(arrayParams[i], structValue.FieldByIndex(field.index).Addr().Interface()); err != nil {
+			return rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("param #%d (%s): %+v", i+1, field.name, err))
+		}
+	}
+	return nil
+}
+
+func checkRPCParams(arrayParams []interface{}, paramsType reflect.Type) *rpcservice.RPCError {
+	for i, field := range rpcParamFields(paramsType) {
+		if field.variadic {
+			for j := i; j < len(arrayParams); j++ {
+				if err := checkRPCValue(arrayParams[j], field.typ.Elem(), false); err != nil {
+					return rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("param #%d (%s): %+v", j+1, field.name, err))
+				}
+			}
+			break
+		}
+		var value interface{}
+		if i < len(arrayParams) {
+			value = arrayParams[i]
+		}
+		if err := checkRPCValue(value, field.typ, field.optional); err != nil {
+			return rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("param #%d (%s): %+v", i+1, field.name, err))
+		}
+	}
+	return nil
+}
+
+func checkRPCValue(value interface{}, typ reflect.Type, optional bool) error {
+	if value == nil {
+		if optional || isNullableKind(typ.Kind()) {
+			return nil
+		}
+		return fmt.Errorf("is required, expect %s", jsonTypeName(typ))
+	}
+	if isPlainStruct(typ) {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expect object")
+		}
+		for _, field := range rpcParamFields(typ) {
+			if err := checkRPCValue(object[field.name], field.typ, field.optional); err != nil {
+				return fmt.Errorf("key %s %+v", field.name, err)
+			}
+		}
+		return nil
+	}
+	if err := convertRPCValue(value, reflect.New(typ).Interface()); err != nil {
+		return fmt.Errorf("expect %s", jsonTypeName(typ))
+	}
+	return nil
+}
+
+// convertRPCValue convert a value decoded by encoding/json into out
+func convertRPCValue(value interface{}, out interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+type rpcParamField struct {
+	index       []int
+	name        string
+	optional    bool
+	variadic    bool
+	description string
+	typ         reflect.Type
+}
+
+func rpcParamFields(typ reflect.Type) []rpcParamField {
+	fields := []rpcParamField{}
+	for i := 0; i < typ.NumField(); i++ {
+		structField := typ.Field(i)
+		name := strings.Split(structField.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		// embedded struct without json name are flatten by encoding/json
+		if structField.Anonymous && name == "" && isPlainStruct(structField.Type) {
+			for _, field := range rpcParamFields(structField.Type) {
+				field.index = append([]int{i}, field.index...)
+				fields = append(fields, field)
+			}
+			continue
+		}
+		if structField.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = structField.Name
+		}
+		fields = append(fields, rpcParamField{
+			index:       []int{i},
+			name:        name,
+			optional:    structField.Tag.Get("rpc") == "optional" || structField.Tag.Get("rpc") == "variadic",
+			variadic:    structField.Tag.Get("rpc") == "variadic" && structField.Type.Kind() == reflect.Slice,
+			description: structField.Tag.Get("desc"),
+			typ:         structField.Type,
+		})
+	}
+	return fields
+}
+
+func isNullableKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Interface:
+		return true
+	}
+	return false
+}
+
+// isPlainStruct is true for a struct encoded field by field, not by its own marshaller
+func isPlainStruct(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+	ptr := reflect.PtrTo(typ)
+	return !ptr.Implements(jsonMarshalerType) && !ptr.Implements(textMarshalerType)
+}
+
+func jsonTypeName(typ reflect.Type) string {
+	if typ == rpcDecimalType {
+		return "decimal string"
+	}
+	if typ == backupSourceType {
+		return "epoch or chain name"
+	}
+	if typeName, ok := jsonSchemaOf(typ, map[reflect.Type]bool{})["type"].(string); ok {
+		return typeName
+	}
+	return "value"
+}
+
+/*
+jsonSchemaOf build the JSON schema (draft 2020-12) of a go type as encoded by encoding/json.
+visiting protect against recursive types, which are described as a plain object.
+*/
+func jsonSchemaOf(typ reflect.Type, visiting map[reflect.Type]bool) map[string]interface{} {
+	if typ == rpcDecimalType {
+		return map[string]interface{}{"type": "string", "pattern": "^[0-9]+$"}
+	}
+	if typ == rawJsonMessageType {
+		return map[string]interface{}{}
+	}
+	if typ == backupSourceType {
+		return map[string]interface{}{"anyOf": []interface{}{
+			map[string]interface{}{"type": "integer", "minimum": 1},
+			map[string]interface{}{"type": "string"},
+		}}
+	}
+	if typ.Kind() == reflect.Ptr {
+		return jsonSchemaOf(typ.Elem(), visiting)
+	}
+	if typ.Implements(jsonMarshalerType) || reflect.PtrTo(typ).Implements(jsonMarshalerType) {
+		return map[string]interface{}{}
+	}
+	if typ.Implements(textMarshalerType) || reflect.PtrTo(typ).Implements(textMarshalerType) {
+		return map[string]interface{}{"type": "string"}
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 && typ.Kind() == reflect.Slice {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]interface{}{"type": "array", "items": jsonSchemaOf(typ.Elem(), visiting)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": jsonSchemaOf(typ.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[typ] {
+			return map[string]interface{}{"type": "object"}
+		}
+		visiting[typ] = true
+		defer delete(visiting, typ)
+		properties := map[string]interface{}{}
+		required := []string{}
+		collectProperties(typ, visiting, properties, &required)
+		schema := map[string]interface{}{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	}
+	return map[string]interface{}{}
+}
+
+func collectProperties(typ reflect.Type, visiting map[reflect.Type]bool, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < typ.NumField(); i++ {
+		structField := typ.Field(i)
+		jsonTag := structField.Tag.Get("json")
+		name := strings.Split(jsonTag, ",")[0]
+		if name == "-" {
+			continue
+		}
+		// embedded struct without json name are flatten by encoding/json
+		if structField.Anonymous && name == "" {
+			fieldType := structField.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if isPlainStruct(fieldType) {
+				collectProperties(fieldType, visiting, properties, required)
+				continue
+			}
+		}
+		if structField.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = structField.Name
+		}
+		property := jsonSchemaOf(structField.Type, visiting)
+		if description := structField.Tag.Get("desc"); description != "" {
+			property = withDescription(property, description)
+		}
+		properties[name] = property
+		if structField.Tag.Get("rpc") != "optional" && !strings.Contains(jsonTag, ",omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func withDescription(schema map[string]interface{}, description string) map[string]interface{} {
+	result := make(map[string]interface{}, len(schema)+1)
+	for key, value := range schema {
+		result[key] = value
+	}
+	result["description"] = description
+	return result
+}
+
+// paramsSchemaOf describe positional params as an array schema, one prefix item per field
+func paramsSchemaOf(paramsType reflect.Type) map[string]interface{} {
+	items := []interface{}{}
+	minItems := 0
+	for i, field := range rpcParamFields(paramsType) {
+		if field.variadic {
+			rest := jsonSchemaOf(field.typ.Elem(), map[reflect.Type]bool{})
+			rest["title"] = field.name
+			if field.description != "" {
+				rest["description"] = field.description
+			}
+			return map[string]interface{}{"type": "array", "prefixItems": items, "minItems": minItems, "items": rest}
+		}
+		item := jsonSchemaOf(field.typ, map[reflect.Type]bool{})
+		item["title"] = field.name
+		if field.description != "" {
+			item["description"] = field.description
+		}
+		if field.optional || isNullableKind(field.typ.Kind()) {
+			item = map[string]interface{}{"anyOf": []interface{}{item, map[string]interface{}{"type": "null"}}, "title": field.name}
+		}
+		if !field.optional {
+			minItems = i + 1
+		}
+		items = append(items, item)
+	}
+	return map[string]interface{}{"type": "array", "prefixItems": items, "minItems": minItems}
+}
+
+// rpcMethodSchemaDocument is the schema of one method returned by getrpcschema
+type rpcMethodSchemaDocument struct {
+	Method      string                 `json:"method"`
+	Description string                 `json:"description,omitempty"`
+	Typed       bool                   `json:"typed"`
+	Params      map[string]interface{} `json:"params"`
+	Result      map[string]interface{} `json:"result"`
+}
+
+func newRPCMethodSchemaDocument(method string) rpcMethodSchemaDocument {
+	schema, ok := rpcSchemas[method]
+	if !ok {
+		return rpcMethodSchemaDocument{
+			Method: method,
+			Params: map[string]interface{}{"type": "array"},
+			Result: map[string]interface{}{},
+		}
+	}
+	document := rpcMethodSchemaDocument{
+		Method:      method,
+		Description: schema.Description,
+		Typed:       true,
+		Params:      paramsSchemaOf(reflect.TypeOf(schema.Params)),
+		Result:      map[string]interface{}{},
+	}
+	if schema.Result != nil {
+		document.Result = jsonSchemaOf(reflect.TypeOf(schema.Result), map[reflect.Type]bool{})
+	}
+	return document
+}
+
+// rpcMethodNames list every http method, registered or not, sorted.
+// It is filled in init because the handler tables refer to handleGetRPCSchema.
+var rpcMethodNames []string
+
+func init() {
+	for method := range HttpHandler {
+		rpcMethodNames = append(rpcMethodNames, method)
+	}
+	for method := range LimitedHttpHandler {
+		rpcMethodNames = append(rpcMethodNames, method)
+	}
+	// downloadbackup is not in the handler tables, it streams on the connection
+	rpcMethodNames = append(rpcMethodNames, downloadBackup)
+	sort.Strings(rpcMethodNames)
+}
+
+func isRPCMethod(method string) bool {
+	index := sort.SearchStrings(rpcMethodNames, method)
+	return index < len(rpcMethodNames) && rpcMethodNames[index] == method
+}
+
+/*
+handleGetRPCSchema RPC return the schema of params and result of one method, or of every method if no method is given.
+Methods without registered params struct are returned with typed false and an untyped params array.
+*/
+func (httpServer *HttpServer) handleGetRPCSchema(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	schemaParams := getRPCSchemaParams{}
+	if err := decodeRPCParams(params, &schemaParams); err != nil {
+		return nil, err
+	}
+	if schemaParams.Method != "" {
+		if !isRPCMethod(schemaParams.Method) {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCMethodNotFoundError, errors.New("Method not found: "+schemaParams.Method))
+		}
+		return newRPCMethodSchemaDocument(schemaParams.Method), nil
+	}
+	result := []rpcMethodSchemaDocument{}
+	for _, method := range rpcMethodNames {
+		result = append(result, newRPCMethodSchemaDocument(method))
+	}
+	return result, nil
+}
+
+/*
+openAPIDocument build an OpenAPI 3.1 document from the registry. Every method is POSTed to "/" with the method name in
+the JSON-RPC envelope, so each method is described under the path "/#<method>", the usual convention for JSON-RPC.
+*/
+func openAPIDocument() map[string]interface{} {
+	rpcErrorSchema := jsonSchemaOf(reflect.TypeOf(rpcservice.RPCError{}), map[reflect.Type]bool{})
+	paths := map[string]interface{}{}
+	for _, method := range rpcMethodNames {
+		document := newRPCMethodSchemaDocument(method)
+		request := map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"Jsonrpc": map[string]interface{}{"type": "string", "const": "1.0"},
+				"Method":  map[string]interface{}{"type": "string", "const": method},
+				"Params":  document.Params,
+				"Id":      map[string]interface{}{},
+			},
+			"required": []string{"Method", "Params", "Id"},
+		}
+		response := map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"Id":      map[string]interface{}{},
+				"Result":  document.Result,
+				"Error":   map[string]interface{}{"anyOf": []interface{}{rpcErrorSchema, map[string]interface{}{"type": "null"}}},
+				"Params":  map[string]interface{}{},
+				"Method":  map[string]interface{}{"type": "string"},
+				"Jsonrpc": map[string]interface{}{"type": "string"},
+			},
+		}
+		operation := map[string]interface{}{
+			"operationId": method,
+			"summary":     document.Description,
+			"requestBody": map[string]interface{}{
+				"required": true,
+				"content":  map[string]interface{}{"application/json": map[string]interface{}{"schema": request}},
+			},
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "JSON-RPC response, Error is set when the call failed",
+					"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": response}},
+				},
+			},
+		}
+		if !document.Typed {
+			operation["tags"] = []string{"untyped"}
+		}
+		paths["/#"+method] = map[string]interface{}{"post": operation}
+	}
+	return map[string]interface{}{
+		"openapi": "3.1.0",
+		"info": map[string]interface{}{
+			"title":   "Incognito chain JSON-RPC",
+			"version": "1.0",
+		},
+		"paths": paths,
+	}
+}
+
+// handleOpenAPI serve the OpenAPI document of the rpc methods on GET /openapi.json
+func (httpServer *HttpServer) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "405 method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	document, err := json.Marshal(openAPIDocument())
+	if err != nil {
+		Logger.log.Errorf("Failed to marshal openapi document: %+v", err)
+		http.Error(w, "500 "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(document)
+}
@@ -0,0 +1,2400 @@
+package rpcserver
+
+import (
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// Params structs of registered rpc methods, see schema.go for the tags
+
+type noParams struct{}
+
+type getRPCSchemaParams struct {
+	Method string `json:"method" rpc:"optional" desc:"method name, every method is returned when omitted"`
+}
+
+type shardIDParams struct {
+	ShardID int `json:"shardID" desc:"shard id"`
+}
+
+type getBlockCountParams struct {
+	ShardID int `json:"shardID" desc:"shard id, -1 for beacon"`
+}
+
+type getBlockHashParams struct {
+	ShardID int    `json:"shardID" rpc:"optional" desc:"shard id, -1 for beacon (default 0)"`
+	Height  uint64 `json:"height" rpc:"optional" desc:"block height (default 1)"`
+}
+
+type getBlocksParams struct {
+	NumBlock int `json:"numBlock" rpc:"optional" desc:"number of latest blocks (default 0)"`
+	ShardID  int `json:"shardID" rpc:"optional" desc:"shard id, -1 for beacon (default 0)"`
+}
+
+type retrieveBlockParams struct {
+	Hash      string `json:"hash" desc:"block hash"`
+	Verbosity string `json:"verbosity" desc:"\"0\": serialized block, \"1\": block info, \"2\": block info and transactions"`
+}
+
+type retrieveBlockByHeightParams struct {
+	Height    uint64 `json:"height" desc:"block height"`
+	ShardID   int    `json:"shardID" desc:"shard id"`
+	Verbosity string `json:"verbosity" desc:"\"0\": serialized block, \"1\": block info, \"2\": block info and transactions"`
+}
+
+type retrieveBeaconBlockParams struct {
+	Hash string `json:"hash" desc:"beacon block hash"`
+}
+
+type retrieveBeaconBlockByHeightParams struct {
+	Height uint64 `json:"height" desc:"beacon block height"`
+}
+
+type getBlockHeaderParams struct {
+	GetBy   string `json:"getBy" rpc:"optional" desc:"\"blockhash\" or \"blocknum\""`
+	Block   string `json:"block" rpc:"optional" desc:"block hash or block number, as a string"`
+	ShardID int    `json:"shardID" rpc:"optional" desc:"shard id"`
+}
+
+type getCrossShardBlockParams struct {
+	ShardID int    `json:"shardID" desc:"shard id"`
+	Height  uint64 `json:"height" desc:"shard block height"`
+}
+
+type txHashParams struct {
+	TxHash string `json:"txHash" desc:"transaction hash"`
+}
+
+type sendRawTransactionParams struct {
+	Base58CheckData string `json:"base58CheckData" desc:"serialized transaction returned by a createraw method"`
+	RelayMode       string `json:"relayMode" rpc:"optional" desc:"\"public\" to broadcast the transaction, \"private\" to relay it along a stem of single peers before it is broadcast (default is the relay mode of the node)"`
+}
+
+type getBalanceParams struct {
+	PrivateKey string      `json:"privateKey" desc:"base58 private key"`
+	Commitment interface{} `json:"commitment" rpc:"optional" desc:"\"best\" for the balance at the best block of the shard (default), \"finalized\" at its final block, a block height or a block hash at this block (archive nodes only)"`
+}
+
+type getCommitteeListParams struct {
+	Commitment interface{} `json:"commitment" rpc:"optional" desc:"\"best\" for the committees at the best beacon block (default), \"finalized\" at the final beacon block, a beacon block height or hash at this block (archive nodes only)"`
+}
+
+// createTxParams is the layout of bean.NewCreateRawTxParam, amounts are numbers
+type createTxParams struct {
+	PrivateKey string                 `json:"privateKey" desc:"base58 private key of sender"`
+	Receivers  map[string]uint64      `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee        int64                  `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                    `json:"privacy" rpc:"optional" desc:"1 to send with privacy, -1 without (default -1)"`
+	Metadata   map[string]interface{} `json:"metadata" rpc:"optional" desc:"metadata params of the method"`
+	Info       string                 `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+	RingSize   int                    `json:"ringSize" rpc:"optional" desc:"number of commitments hiding each spent coin in a transaction with privacy: 8, 16, 32 or 64 (default 8)"`
+	Stealth    bool                   `json:"stealth" rpc:"optional" desc:"true to send output coins with one-time public keys, needs privacy (default false)"`
+	Memos      map[string]string      `json:"memos" rpc:"optional" desc:"payment address of a receiver to a memo only it reads, at most 170 bytes"`
+}
+
+// createUnsignedTxParams is the layout of bean.NewCreateUnsignedTxParam, amounts are numbers
+type createUnsignedTxParams struct {
+	PaymentAddress string            `json:"paymentAddress" desc:"base58 payment address of sender"`
+	ReadonlyKey    string            `json:"readonlyKey" desc:"base58 readonly key of sender, to read the value of its coins"`
+	Receivers      map[string]uint64 `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee            int64             `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy        int               `json:"privacy" rpc:"optional" desc:"1 to send with privacy, -1 without (default -1)"`
+	Info           string            `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+	SerialNumbers  map[string]string `json:"serialNumbers" rpc:"optional" desc:"SNDerivator to serial number of coins allowed to spend, computed offline; coins already spent are skipped"`
+	RingSize       int               `json:"ringSize" rpc:"optional" desc:"number of commitments hiding each spent coin in a transaction with privacy: 8, 16, 32 or 64 (default 8)"`
+	Stealth        bool              `json:"stealth" rpc:"optional" desc:"true to send output coins with one-time public keys, needs privacy (default false)"`
+	Memos          map[string]string `json:"memos" rpc:"optional" desc:"payment address of a receiver to a memo only it reads, at most 170 bytes"`
+}
+
+// createUnsignedPrivacyTokenTxParams is the layout of bean.NewCreateUnsignedPrivacyTokenTxParam, the PRV part pays the fee
+type createUnsignedPrivacyTokenTxParams struct {
+	PaymentAddress     string            `json:"paymentAddress" desc:"base58 payment address of sender"`
+	ReadonlyKey        string            `json:"readonlyKey" desc:"base58 readonly key of sender, to read the value of its coins"`
+	Receivers          map[string]uint64 `json:"receivers" desc:"payment address to amount (nano PRV), can be empty"`
+	Fee                int64             `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy            int               `json:"privacy" desc:"1 to send PRV with privacy, -1 without"`
+	TokenID            string            `json:"tokenID" desc:"id of the transferred token"`
+	TokenReceivers     map[string]uint64 `json:"tokenReceivers" desc:"payment address to token amount"`
+	TokenFee           uint64            `json:"tokenFee" rpc:"optional" desc:"fee paid in token (default 0)"`
+	HasPrivacyToken    int               `json:"hasPrivacyToken" rpc:"optional" desc:"1 to send token with privacy, -1 without (default 1)"`
+	Info               string            `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+	SerialNumbers      map[string]string `json:"serialNumbers" rpc:"optional" desc:"SNDerivator to serial number of PRV coins allowed to spend, computed offline; coins already spent are skipped"`
+	TokenSerialNumbers map[string]string `json:"tokenSerialNumbers" rpc:"optional" desc:"SNDerivator to serial number of token coins allowed to spend, computed offline; coins already spent are skipped"`
+	Memos              map[string]string `json:"memos" rpc:"optional" desc:"payment address of a receiver of PRV or token to a memo only it reads, at most 170 bytes"`
+}
+
+// delegationMetadataParams is the metadata object read by handleCreateRawDelegationTransaction
+type delegationMetadataParams struct {
+	DelegationType        int    `json:"DelegationType" desc:"210: delegate, 211: undelegate, 212: redelegate"`
+	CommitteePublicKey    string `json:"CommitteePublicKey" desc:"committee public key of the validator"`
+	NewCommitteePublicKey string `json:"NewCommitteePublicKey" rpc:"optional" desc:"committee public key of the new validator, required to redelegate"`
+	Amount                uint64 `json:"Amount" desc:"amount in nano PRV, burnt to delegate"`
+}
+
+// createDelegationTxParams is the layout of bean.NewCreateRawTxParam with the delegation metadata, the sender is the
+// delegator and the receiver is the burning address
+type createDelegationTxParams struct {
+	PrivateKey string                   `json:"privateKey" desc:"base58 private key of delegator"`
+	Receivers  map[string]uint64        `json:"receivers" desc:"burning address to Amount to delegate, to 0 to undelegate or redelegate"`
+	Fee        int64                    `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                      `json:"privacy" rpc:"optional" desc:"-1, delegation txs have no privacy"`
+	Metadata   delegationMetadataParams `json:"metadata" desc:"delegation request"`
+}
+
+// delegationCommissionMetadataParams is the metadata object read by handleCreateRawDelegationCommissionTransaction
+type delegationCommissionMetadataParams struct {
+	CommitteePublicKey string `json:"CommitteePublicKey" desc:"committee public key of the validator"`
+	CommissionPercent  uint64 `json:"CommissionPercent" desc:"share of the reward of the delegators kept by the validator, 0 to 100"`
+}
+
+// createDelegationCommissionTxParams is the layout of bean.NewCreateRawTxParam with the commission metadata, the
+// sender is the sender of the staking tx of the validator and the receiver is the burning address
+type createDelegationCommissionTxParams struct {
+	PrivateKey string                             `json:"privateKey" desc:"base58 private key of the staker"`
+	Receivers  map[string]uint64                  `json:"receivers" desc:"burning address to 0"`
+	Fee        int64                              `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                                `json:"privacy" rpc:"optional" desc:"-1, commission txs have no privacy"`
+	Metadata   delegationCommissionMetadataParams `json:"metadata" desc:"commission request"`
+}
+
+type getDelegationsParams struct {
+	CommitteePublicKey string `json:"committeePublicKey" desc:"committee public key of the validator"`
+}
+
+// htlcLockMetadataParams is the metadata object read by handleCreateRawTxWithHTLCLock
+type htlcLockMetadataParams struct {
+	ReceiverAddressStr  string `json:"ReceiverAddressStr" desc:"payment address which claims the htlc"`
+	Amount              uint64 `json:"Amount" desc:"amount in nano PRV, burnt to lock"`
+	HashLock            string `json:"HashLock" desc:"hex encoded sha256 of the 32 bytes preimage"`
+	TimeoutBeaconHeight uint64 `json:"TimeoutBeaconHeight" desc:"beacon height from which the sender refunds the htlc"`
+}
+
+// createHTLCLockTxParams is the layout of bean.NewCreateRawTxParam with the htlc lock metadata, the sender is the
+// signer and the receiver is the burning address
+type createHTLCLockTxParams struct {
+	PrivateKey string                 `json:"privateKey" desc:"base58 private key of sender"`
+	Receivers  map[string]uint64      `json:"receivers" desc:"burning address to Amount"`
+	Fee        int64                  `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                    `json:"privacy" desc:"1 to send with privacy, -1 without"`
+	Metadata   htlcLockMetadataParams `json:"metadata" desc:"htlc lock request"`
+}
+
+// pTokenHTLCLockParams is privacyTokenParams with the htlc fields, TokenReceivers burn TokenAmount of TokenID
+type pTokenHTLCLockParams struct {
+	privacyTokenParams
+	ReceiverAddressStr  string `json:"ReceiverAddressStr" desc:"payment address which claims the htlc"`
+	HashLock            string `json:"HashLock" desc:"hex encoded sha256 of the 32 bytes preimage"`
+	TimeoutBeaconHeight uint64 `json:"TimeoutBeaconHeight" desc:"beacon height from which the sender refunds the htlc"`
+}
+
+// createPTokenHTLCLockTxParams is the layout of bean.NewCreateRawPrivacyTokenTxParam with the htlc lock fields
+type createPTokenHTLCLockTxParams struct {
+	PrivateKey      string               `json:"privateKey" desc:"base58 private key of sender"`
+	Receivers       map[string]uint64    `json:"receivers" desc:"payment address to amount (nano PRV), usually empty"`
+	Fee             int64                `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy         int                  `json:"privacy" desc:"1 to send PRV with privacy, -1 without"`
+	TokenParams     pTokenHTLCLockParams `json:"tokenParams" desc:"token transfer to the burning address and htlc lock request"`
+	Info            string               `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+	HasPrivacyToken int                  `json:"hasPrivacyToken" rpc:"optional" desc:"1 to send token with privacy, -1 without (default 1)"`
+}
+
+// htlcSettleMetadataParams is the metadata object read by createRawTxWithHTLCSettle
+type htlcSettleMetadataParams struct {
+	LockTxID string `json:"LockTxID" desc:"hash of the lock tx of the htlc"`
+	Preimage string `json:"Preimage" rpc:"optional" desc:"hex encoded preimage of the hashlock, required to claim"`
+}
+
+// createHTLCSettleTxParams is the layout of bean.NewCreateRawTxParam with the htlc claim or refund metadata
+type createHTLCSettleTxParams struct {
+	PrivateKey string                   `json:"privateKey" desc:"base58 private key paying the fee, anyone may settle an htlc"`
+	Receivers  map[string]uint64        `json:"receivers" desc:"payment address to amount (nano PRV), usually empty"`
+	Fee        int64                    `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                      `json:"privacy" desc:"1 to send with privacy, -1 without"`
+	Metadata   htlcSettleMetadataParams `json:"metadata" desc:"htlc claim or refund request"`
+}
+
+type getHTLCParams struct {
+	LockTxID string `json:"lockTxID" desc:"hash of the lock tx of the htlc"`
+}
+
+// privacyTokenParams is the token object read by TxService.BuildTokenParam, amounts are numbers
+type privacyTokenParams struct {
+	Privacy        bool              `json:"Privacy" desc:"true for privacy token"`
+	TokenID        string            `json:"TokenID" rpc:"optional" desc:"token id, required when Privacy is true"`
+	TokenName      string            `json:"TokenName" rpc:"optional" desc:"token name, required when Privacy is true"`
+	TokenSymbol    string            `json:"TokenSymbol" rpc:"optional" desc:"token symbol, required when Privacy is true"`
+	TokenTxType    int               `json:"TokenTxType" rpc:"optional" desc:"0: init, 1: transfer, required when Privacy is true"`
+	TokenAmount    uint64            `json:"TokenAmount" rpc:"optional" desc:"token amount, required when Privacy is true"`
+	TokenFee       uint64            `json:"TokenFee" rpc:"optional" desc:"fee paid in token, required when Privacy is true"`
+	TokenReceivers map[string]uint64 `json:"TokenReceivers" rpc:"optional" desc:"payment address to token amount, required when Privacy is true"`
+	IsGetPTokenFee bool              `json:"IsGetPTokenFee" rpc:"optional" desc:"pay the fee in token"`
+	UnitPTokenFee  int64             `json:"UnitPTokenFee" rpc:"optional" desc:"fee per kb in token"`
+}
+
+// createPrivacyTokenTxParams is the layout of bean.NewCreateRawPrivacyTokenTxParam with the memos
+type createPrivacyTokenTxParams struct {
+	PrivateKey      string             `json:"privateKey" desc:"base58 private key of sender"`
+	Receivers       map[string]uint64  `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee             int64              `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy         int                `json:"privacy" desc:"1 to send PRV with privacy, -1 without"`
+	TokenParams     privacyTokenParams `json:"tokenParams" desc:"token transfer params"`
+	Info            string             `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+	HasPrivacyToken int                `json:"hasPrivacyToken" rpc:"optional" desc:"1 to send token with privacy, -1 without (default 1)"`
+	Memos           map[string]string  `json:"memos" rpc:"optional" desc:"payment address of a receiver of PRV or token to a memo only it reads, at most 170 bytes"`
+}
+
+// defragmentAccountTokenParams is the layout of bean.NewCreateRawPrivacyTokenTxParam read by
+// BuildRawDefragmentPrivacyCustomTokenTransaction, the token coins are sent to the sender
+type defragmentAccountTokenParams struct {
+	PrivateKey      string             `json:"privateKey" desc:"base58 private key of sender"`
+	Receivers       map[string]uint64  `json:"receivers" desc:"payment address to amount (nano PRV), usually empty"`
+	Fee             int64              `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy         int                `json:"privacy" desc:"1 to send PRV with privacy, -1 without"`
+	TokenParams     privacyTokenParams `json:"tokenParams" desc:"token to defragment"`
+	Info            string             `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+	HasPrivacyToken int                `json:"hasPrivacyToken" rpc:"optional" desc:"1 to send token with privacy, -1 without (default 1)"`
+}
+
+type prvCrossPoolTradeMetadata struct {
+	TokenIDToBuyStr     string     `json:"TokenIDToBuyStr" desc:"token id to buy"`
+	TokenIDToSellStr    string     `json:"TokenIDToSellStr" desc:"token id to sell, PRV id"`
+	SellAmount          rpcDecimal `json:"SellAmount" desc:"amount to sell"`
+	TraderAddressStr    string     `json:"TraderAddressStr" desc:"payment address receiving the bought token"`
+	MinAcceptableAmount rpcDecimal `json:"MinAcceptableAmount" desc:"minimum amount to receive, the trade is refunded otherwise"`
+	TradingFee          rpcDecimal `json:"TradingFee" desc:"trading fee in PRV"`
+}
+
+// createAndSendTxWithPRVCrossPoolTradeReqParams is the layout of bean.NewCreateRawTxParamV2 with a trade metadata
+type createAndSendTxWithPRVCrossPoolTradeReqParams struct {
+	PrivateKey string                    `json:"privateKey" desc:"base58 private key of trader"`
+	Receivers  map[string]rpcDecimal     `json:"receivers" desc:"burning address to SellAmount + TradingFee"`
+	Fee        int64                     `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                       `json:"privacy" desc:"1 to send with privacy, -1 without"`
+	Metadata   prvCrossPoolTradeMetadata `json:"metadata" desc:"trade request"`
+	Info       string                    `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+type pTokenCrossPoolTradeTokenParams struct {
+	TokenIDToBuyStr     string                `json:"TokenIDToBuyStr" desc:"token id to buy"`
+	TokenIDToSellStr    string                `json:"TokenIDToSellStr" desc:"token id to sell"`
+	SellAmount          rpcDecimal            `json:"SellAmount" desc:"amount to sell"`
+	TraderAddressStr    string                `json:"TraderAddressStr" desc:"payment address receiving the bought token"`
+	MinAcceptableAmount rpcDecimal            `json:"MinAcceptableAmount" desc:"minimum amount to receive, the trade is refunded otherwise"`
+	TradingFee          rpcDecimal            `json:"TradingFee" desc:"trading fee in PRV"`
+	Privacy             bool                  `json:"Privacy" desc:"must be true"`
+	TokenID             string                `json:"TokenID" desc:"token id to sell"`
+	TokenName           string                `json:"TokenName" desc:"token name"`
+	TokenSymbol         string                `json:"TokenSymbol" desc:"token symbol"`
+	TokenTxType         int                   `json:"TokenTxType" desc:"1: transfer"`
+	TokenAmount         rpcDecimal            `json:"TokenAmount" desc:"SellAmount"`
+	TokenFee            rpcDecimal            `json:"TokenFee" desc:"fee paid in token, 0 to pay in PRV"`
+	TokenReceivers      map[string]rpcDecimal `json:"TokenReceivers" desc:"burning address to SellAmount"`
+	IsGetPTokenFee      bool                  `json:"IsGetPTokenFee" rpc:"optional" desc:"pay the fee in token"`
+	UnitPTokenFee       int64                 `json:"UnitPTokenFee" rpc:"optional" desc:"fee per kb in token"`
+}
+
+// createAndSendTxWithPTokenCrossPoolTradeReqParams is the layout of bean.NewCreateRawPrivacyTokenTxParamV2 with trade keys
+type createAndSendTxWithPTokenCrossPoolTradeReqParams struct {
+	PrivateKey      string                          `json:"privateKey" desc:"base58 private key of trader"`
+	Receivers       map[string]rpcDecimal           `json:"receivers" desc:"burning address to TradingFee in PRV, null when there is no PRV to send"`
+	Fee             int64                           `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy         int                             `json:"privacy" desc:"1 to send PRV with privacy, -1 without"`
+	TokenParams     pTokenCrossPoolTradeTokenParams `json:"tokenParams" desc:"trade request and token transfer"`
+	Info            string                          `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+	HasPrivacyToken int                             `json:"hasPrivacyToken" rpc:"optional" desc:"must be 0 or -1, the token is sent without privacy"`
+}
+
+type peerIDParams struct {
+	PeerID string `json:"peerID" rpc:"optional" desc:"peer id, every peer when omitted"`
+}
+
+type checkHashValueParams struct {
+	Hash string `json:"hash" desc:"hash of a transaction, a shard block or a beacon block"`
+}
+
+type getStakingAmountParams struct {
+	StakingType int `json:"stakingType" desc:"0: shard validator, 1: beacon validator"`
+}
+
+type hashToIdenticonParams struct {
+	Hashes []string `json:"hashes" rpc:"variadic" desc:"hashes, one identicon per hash"`
+}
+
+type generateTokenIDParams struct {
+	Network   string `json:"network" desc:"network of the token, ex: ETH"`
+	TokenName string `json:"tokenName" desc:"token name on this network"`
+}
+
+type canPubkeyStakeParams struct {
+	PublicKey string `json:"publicKey" desc:"incognito public key, base58 encoded"`
+}
+
+type enableMiningParams struct {
+	Enable       bool   `json:"enable" desc:"true to mine, false to stop"`
+	ValidatorKey string `json:"validatorKey" desc:"validator key of one of the mining keys of this node"`
+}
+
+type getChainMiningStatusParams struct {
+	ChainID int `json:"chainID" desc:"shard id, -1 for beacon"`
+}
+
+type miningKeyParams struct {
+	MiningKey string `json:"miningKey" desc:"consensus public key as keyType:key, ex: bls:..."`
+}
+
+type validatorKeyParams struct {
+	ValidatorKey string `json:"validatorKey" desc:"validator key, base58 encoded"`
+}
+
+type incognitoPublicKeyParams struct {
+	PublicKey string `json:"publicKey" desc:"incognito public key, base58 encoded"`
+}
+
+type getAllViewParams struct {
+	ShardID  int `json:"shardID" desc:"shard id, -1 for beacon"`
+	NumBlock int `json:"numBlock" desc:"number of latest blocks on chain to list before the views"`
+}
+
+type removeTxInMempoolParams struct {
+	TxHashes []string `json:"txHashes" rpc:"variadic" desc:"hashes of the transactions to remove"`
+}
+
+type setBackupParams struct {
+	Backup bool `json:"backup" desc:"true to back up the beacon database every epoch"`
+}
+
+type getLatestBackupParams struct {
+	ChainName string `json:"chainName" rpc:"optional" desc:"chain name of the backup, 0 is returned when omitted"`
+}
+
+// downloadBackupParams is read by handleDownloadBackup, the latest backup of chainName is streamed when source is
+// omitted, the latest backup of another chain when it is a chain name, and fileName of an increment when it is an epoch
+type downloadBackupParams struct {
+	ChainName string        `json:"chainName" desc:"chain name of the backup"`
+	Source    *backupSource `json:"source" rpc:"optional" desc:"epoch of an increment or chain name of another backup"`
+	FileName  string        `json:"fileName" rpc:"optional" desc:"file of the increment, the manifest or a database file it lists"`
+}
+
+type beaconHeightParams struct {
+	BeaconHeight uint64 `json:"beaconHeight" desc:"beacon height"`
+}
+
+type getRewardAmountByEpochParams struct {
+	ShardID int    `json:"shardID" desc:"shard id"`
+	Epoch   uint64 `json:"epoch" desc:"epoch"`
+}
+
+type getAndSendTxsFromFileParams struct {
+	ShardID  int    `json:"shardID" desc:"shard id of the transaction file"`
+	TxType   string `json:"txType" desc:"kind of transactions, ex: privacy, noprivacy, cstokenprivacy"`
+	IsSent   bool   `json:"isSent" desc:"true to broadcast the transactions, false to only add them to the mempool"`
+	Interval int64  `json:"interval" desc:"milliseconds between two transactions"`
+}
+
+type paymentAddressParams struct {
+	PaymentAddress string `json:"paymentAddress" desc:"base58 payment address"`
+}
+
+type privateKeyParams struct {
+	PrivateKey string `json:"privateKey" desc:"base58 private key"`
+}
+
+type tokenIDParams struct {
+	TokenID string `json:"tokenID" desc:"token id"`
+}
+
+// receiverKeysParams is the key object of a receiver, its readonly key reveals the value of the coins
+type receiverKeysParams struct {
+	PaymentAddress string `json:"PaymentAddress" rpc:"optional" desc:"base58 payment address"`
+	ReadonlyKey    string `json:"ReadonlyKey" rpc:"optional" desc:"base58 readonly key"`
+}
+
+type getTransactionByReceiverParams struct {
+	Keys receiverKeysParams `json:"keys" desc:"keys of the receiver"`
+}
+
+type decryptOutputCoinKeysParams struct {
+	PaymentAddress string `json:"PaymentAddress" desc:"base58 payment address"`
+	ReadonlyKey    string `json:"ReadonlyKey" rpc:"optional" desc:"base58 readonly key, to decrypt the value of the coins"`
+}
+
+type decryptOutputCoinParams struct {
+	TxID string                      `json:"txID" desc:"transaction hash"`
+	Keys decryptOutputCoinKeysParams `json:"keys" desc:"keys of the receiver"`
+}
+
+type getBalancePrivacyCustomTokenParams struct {
+	PrivateKey string      `json:"privateKey" desc:"base58 private key"`
+	TokenID    string      `json:"tokenID" desc:"token id"`
+	Commitment interface{} `json:"commitment" rpc:"optional" desc:"\"best\" for the balance at the best block of the shard (default), \"finalized\" at its final block, a block height or a block hash at this block (archive nodes only)"`
+}
+
+type randomCommitmentsParams struct {
+	PaymentAddress string        `json:"paymentAddress" desc:"base58 payment address of the owner of the coins"`
+	Outputs        []interface{} `json:"outputs" desc:"output coins to spend, as returned by listoutputcoins"`
+	TokenID        string        `json:"tokenID" rpc:"optional" desc:"token id of the coins (default PRV)"`
+}
+
+type listByTokenParams struct {
+	TokenID string `json:"tokenID" rpc:"optional" desc:"token id (default PRV)"`
+	ShardID int    `json:"shardID" rpc:"optional" desc:"shard id (default 0)"`
+}
+
+type hasSerialNumbersParams struct {
+	PaymentAddress string   `json:"paymentAddress" desc:"base58 payment address, its shard is searched"`
+	SerialNumbers  []string `json:"serialNumbers" desc:"base58 serial numbers"`
+	TokenID        string   `json:"tokenID" rpc:"optional" desc:"token id (default PRV)"`
+}
+
+type hasSNDerivatorsParams struct {
+	PaymentAddress string   `json:"paymentAddress" desc:"base58 payment address, its shard is searched"`
+	SNDerivators   []string `json:"snDerivators" desc:"base58 serial number derivators"`
+	TokenID        string   `json:"tokenID" rpc:"optional" desc:"token id (default PRV)"`
+}
+
+// stakingMetadataParams is the metadata object read by handleCreateRawStakingTransaction
+type stakingMetadataParams struct {
+	StakingType                  int    `json:"StakingType" desc:"63: shard validator, 64: beacon validator"`
+	CandidatePaymentAddress      string `json:"CandidatePaymentAddress" desc:"base58 payment address of the validator"`
+	PrivateSeed                  string `json:"PrivateSeed" desc:"base58 mining key of the validator"`
+	RewardReceiverPaymentAddress string `json:"RewardReceiverPaymentAddress" desc:"base58 payment address receiving the reward"`
+	AutoReStaking                bool   `json:"AutoReStaking" desc:"true to stake again at the end of each term"`
+}
+
+// createStakingTxParams is the layout of bean.NewCreateRawTxParam with the staking metadata, the receiver is the
+// burning address
+type createStakingTxParams struct {
+	PrivateKey string                `json:"privateKey" desc:"base58 private key of the funder"`
+	Receivers  map[string]uint64     `json:"receivers" desc:"burning address to the staking amount"`
+	Fee        int64                 `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                   `json:"privacy" rpc:"optional" desc:"-1, staking txs have no privacy"`
+	Metadata   stakingMetadataParams `json:"metadata" desc:"staking request"`
+}
+
+// stopAutoStakingMetadataParams is the metadata object read by handleCreateRawStopAutoStakingTransaction
+type stopAutoStakingMetadataParams struct {
+	StopAutoStakingType     int    `json:"StopAutoStakingType" desc:"127"`
+	CandidatePaymentAddress string `json:"CandidatePaymentAddress" desc:"base58 payment address of the validator"`
+	PrivateSeed             string `json:"PrivateSeed" desc:"base58 mining key of the validator"`
+}
+
+// createStopAutoStakingTxParams is the layout of bean.NewCreateRawTxParam with the stop auto staking metadata, the
+// receiver is the burning address
+type createStopAutoStakingTxParams struct {
+	PrivateKey string                        `json:"privateKey" desc:"base58 private key of the funder"`
+	Receivers  map[string]uint64             `json:"receivers" desc:"burning address to 0"`
+	Fee        int64                         `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                           `json:"privacy" rpc:"optional" desc:"-1, stop auto staking txs have no privacy"`
+	Metadata   stopAutoStakingMetadataParams `json:"metadata" desc:"stop auto staking request"`
+}
+
+type listUnspentOutputCoinsParams struct {
+	Min     int           `json:"min" rpc:"optional" desc:"unused, kept for compatibility"`
+	Max     int           `json:"max" rpc:"optional" desc:"unused, kept for compatibility"`
+	Keys    []interface{} `json:"keys" desc:"key objects {\"PrivateKey\": base58 private key} of the owners"`
+	TokenID string        `json:"tokenID" rpc:"optional" desc:"token id (default PRV)"`
+}
+
+type listOutputCoinsParams struct {
+	Min        int           `json:"min" desc:"unused, kept for compatibility"`
+	Max        int           `json:"max" desc:"unused, kept for compatibility"`
+	Keys       []interface{} `json:"keys" desc:"key objects {\"PaymentAddress\", \"ReadonlyKey\" (optional)} of the owners"`
+	TokenID    string        `json:"tokenID" rpc:"optional" desc:"token id (default PRV)"`
+	Commitment interface{}   `json:"commitment" rpc:"optional" desc:"\"best\" for the coins at the best block of the shard (default), \"finalized\" at its final block, a block height or a block hash at this block (archive nodes only)"`
+}
+
+type accountNameParams struct {
+	AccountName string `json:"accountName" desc:"account name in the wallet of the node"`
+}
+
+type importAccountParams struct {
+	PrivateKey  string `json:"privateKey" desc:"base58 private key"`
+	AccountName string `json:"accountName" desc:"account name in the wallet of the node"`
+	PassPhrase  string `json:"passPhrase" desc:"passphrase of the wallet"`
+}
+
+type removeAccountParams struct {
+	PrivateKey string `json:"privateKey" desc:"base58 private key"`
+	PassPhrase string `json:"passPhrase" desc:"passphrase of the wallet"`
+}
+
+type getBalanceByPaymentAddressParams struct {
+	PaymentAddress string      `json:"paymentAddress" desc:"base58 payment address"`
+	Commitment     interface{} `json:"commitment" rpc:"optional" desc:"\"best\" for the balance at the best block of the shard (default), \"finalized\" at its final block, a block height or a block hash at this block (archive nodes only)"`
+}
+
+type walletAccountParams struct {
+	AccountName string `json:"accountName" desc:"account name in the wallet of the node, \"*\" for all accounts"`
+	Min         int    `json:"min" desc:"unused, kept for compatibility"`
+	PassPhrase  string `json:"passPhrase" desc:"passphrase of the wallet"`
+}
+
+type setTxFeeParams struct {
+	Fee uint64 `json:"fee" desc:"fee per kb in nano PRV"`
+}
+
+type listPrivacyCustomTokenParams struct {
+	WithTxs interface{} `json:"withTxs" rpc:"optional" desc:"any value but \"\" to list the transactions of every token"`
+}
+
+type estimateFeeParams struct {
+	PrivateKey  string                 `json:"privateKey" desc:"base58 private key of sender"`
+	Receivers   map[string]interface{} `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee         int64                  `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy     int                    `json:"privacy" desc:"1 to send with privacy, -1 without"`
+	TokenParams map[string]interface{} `json:"tokenParams" rpc:"optional" desc:"privacy token params, as for createrawprivacycustomtokentransaction"`
+}
+
+type estimateFeeWithEstimatorParams struct {
+	Fee            int64  `json:"fee" desc:"fee per kb in nano PRV wanted by the client, -1 to estimate"`
+	PaymentAddress string `json:"paymentAddress" desc:"base58 payment address of sender, its shard is estimated"`
+	NumBlock       uint64 `json:"numBlock" rpc:"optional" desc:"number of blocks to include the transaction (default 8)"`
+	TokenID        string `json:"tokenID" rpc:"optional" desc:"token id to estimate a fee paid in token (default PRV)"`
+}
+
+// withdrawRewardMetadataParams is the metadata object read by handleCreateRawWithDrawTransaction
+type withdrawRewardMetadataParams struct {
+	TokenID string `json:"TokenID" desc:"token id of the reward"`
+	Version *int   `json:"Version" rpc:"optional" desc:"version of the request"`
+}
+
+// createWithdrawRewardTxParams is the layout of bean.NewCreateRawTxParam with the withdraw reward metadata, the
+// receivers are ignored
+type createWithdrawRewardTxParams struct {
+	PrivateKey string                       `json:"privateKey" desc:"base58 private key of the reward receiver"`
+	Receivers  interface{}                  `json:"receivers" desc:"ignored"`
+	Fee        int64                        `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                          `json:"privacy" rpc:"optional" desc:"1 to send with privacy, -1 without (default -1)"`
+	Metadata   withdrawRewardMetadataParams `json:"metadata" desc:"withdraw request"`
+}
+
+// defragmentAccountParams is the layout read by TxService.BuildRawDefragmentAccountTransaction
+type defragmentAccountParams struct {
+	PrivateKey  string `json:"privateKey" desc:"base58 private key"`
+	MaxValue    uint64 `json:"maxValue" desc:"only coins below this value are combined"`
+	Fee         int64  `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy     int    `json:"privacy" desc:"1 to send with privacy, -1 without"`
+	MaxQuantity int    `json:"maxQuantity" rpc:"optional" desc:"maximum number of coins combined, at most 32 (default 32)"`
+}
+
+// createTxWithMetadataParams is the layout of bean.NewCreateRawTxParam read by createRawTxWithMetadata, the metadata
+// object is parsed by the metadata constructor of the method
+type createTxWithMetadataParams struct {
+	PrivateKey string                 `json:"privateKey" desc:"base58 private key of sender"`
+	Receivers  interface{}            `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee        int64                  `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                    `json:"privacy" rpc:"optional" desc:"1 to send with privacy, -1 without (default -1)"`
+	Metadata   map[string]interface{} `json:"metadata" desc:"metadata params of the method"`
+}
+
+// issuingMetadataParams is the metadata object read by metadata.NewIssuingRequestFromMap
+type issuingMetadataParams struct {
+	ReceiveAddress  string  `json:"ReceiveAddress" desc:"base58 payment address receiving the issued token"`
+	DepositedAmount float64 `json:"DepositedAmount" desc:"issued amount"`
+	TokenID         string  `json:"TokenID" desc:"id of the issued token"`
+	TokenName       string  `json:"TokenName" desc:"name of the issued token"`
+}
+
+type createIssuingTxParams struct {
+	PrivateKey string                `json:"privateKey" desc:"base58 private key of the requester"`
+	Receivers  map[string]uint64     `json:"receivers" desc:"payment address to amount (nano PRV), can be empty"`
+	Fee        int64                 `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                   `json:"privacy" rpc:"optional" desc:"1 to send with privacy, -1 without (default -1)"`
+	Metadata   issuingMetadataParams `json:"metadata" desc:"issuing request"`
+}
+
+// issuingETHMetadataParams is the metadata object read by metadata.NewIssuingETHRequestFromMap
+type issuingETHMetadataParams struct {
+	BlockHash  string   `json:"BlockHash" desc:"hash of the ethereum block of the deposit"`
+	TxIndex    uint     `json:"TxIndex" desc:"index of the deposit tx in the ethereum block"`
+	ProofStrs  []string `json:"ProofStrs" desc:"merkle proof of the receipt of the deposit tx"`
+	IncTokenID string   `json:"IncTokenID" desc:"id of the incognito token of the deposited ethereum token"`
+}
+
+type createIssuingETHTxParams struct {
+	PrivateKey string                   `json:"privateKey" desc:"base58 private key of the requester"`
+	Receivers  map[string]uint64        `json:"receivers" desc:"payment address to amount (nano PRV), can be empty"`
+	Fee        int64                    `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                      `json:"privacy" rpc:"optional" desc:"1 to send with privacy, -1 without (default -1)"`
+	Metadata   issuingETHMetadataParams `json:"metadata" desc:"shielding request of an ethereum deposit"`
+}
+
+// contractingTokenParams is the token object of a contracting request, read by TxService.BuildTokenParam
+type contractingTokenParams struct {
+	Privacy        bool                   `json:"Privacy" rpc:"optional" desc:"true for privacy token"`
+	TokenID        string                 `json:"TokenID" desc:"id of the burned token"`
+	TokenName      string                 `json:"TokenName" rpc:"optional" desc:"name of the burned token"`
+	TokenSymbol    string                 `json:"TokenSymbol" rpc:"optional" desc:"symbol of the burned token"`
+	TokenTxType    int                    `json:"TokenTxType" rpc:"optional" desc:"1: transfer"`
+	TokenAmount    uint64                 `json:"TokenAmount" rpc:"optional" desc:"burned amount"`
+	TokenFee       uint64                 `json:"TokenFee" rpc:"optional" desc:"fee paid in token"`
+	TokenReceivers map[string]interface{} `json:"TokenReceivers" desc:"burning address to the burned amount"`
+}
+
+// createContractingTxParams is the layout of bean.NewCreateRawPrivacyTokenTxParam with a contracting request
+type createContractingTxParams struct {
+	PrivateKey      string                 `json:"privateKey" desc:"base58 private key of sender"`
+	Receivers       map[string]uint64      `json:"receivers" desc:"payment address to amount (nano PRV), can be empty"`
+	Fee             int64                  `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy         int                    `json:"privacy" desc:"1 to send PRV with privacy, -1 without"`
+	TokenParams     contractingTokenParams `json:"tokenParams" desc:"burned token"`
+	Info            string                 `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+	HasPrivacyToken int                    `json:"hasPrivacyToken" rpc:"optional" desc:"must be -1 or 0, the token is burned without privacy"`
+}
+
+// burningTokenParams is the token object of a burning request, read by TxService.BuildTokenParam
+type burningTokenParams struct {
+	Privacy        bool                   `json:"Privacy" rpc:"optional" desc:"true for privacy token"`
+	TokenID        string                 `json:"TokenID" desc:"id of the burned token"`
+	TokenName      string                 `json:"TokenName" desc:"name of the burned token"`
+	TokenSymbol    string                 `json:"TokenSymbol" rpc:"optional" desc:"symbol of the burned token"`
+	TokenTxType    int                    `json:"TokenTxType" rpc:"optional" desc:"1: transfer"`
+	TokenAmount    uint64                 `json:"TokenAmount" rpc:"optional" desc:"burned amount"`
+	TokenFee       uint64                 `json:"TokenFee" rpc:"optional" desc:"fee paid in token"`
+	TokenReceivers map[string]interface{} `json:"TokenReceivers" desc:"burning address to the burned amount"`
+	RemoteAddress  string                 `json:"RemoteAddress" desc:"ethereum address receiving the unshielded token, without 0x"`
+}
+
+// createBurningTxParams is the layout of bean.NewCreateRawPrivacyTokenTxParam with a burning request
+type createBurningTxParams struct {
+	PrivateKey      string             `json:"privateKey" desc:"base58 private key of sender"`
+	Receivers       map[string]uint64  `json:"receivers" desc:"payment address to amount (nano PRV), can be empty"`
+	Fee             int64              `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy         int                `json:"privacy" desc:"1 to send PRV with privacy, -1 without"`
+	TokenParams     burningTokenParams `json:"tokenParams" desc:"burned token"`
+	Info            string             `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+	HasPrivacyToken int                `json:"hasPrivacyToken" rpc:"optional" desc:"must be -1 or 0, the token is burned without privacy"`
+}
+
+// ethTxParams identifies an ethereum deposit tx
+type ethTxParams struct {
+	BlockHash string `json:"BlockHash" desc:"hash of the ethereum block of the deposit"`
+	TxIndex   uint   `json:"TxIndex" desc:"index of the deposit tx in the ethereum block"`
+}
+
+type checkETHHashIssuedParams struct {
+	Data ethTxParams `json:"data" desc:"ethereum deposit tx"`
+}
+
+type ethBlockHashParams struct {
+	ETHBlockHash string `json:"ethBlockHash" desc:"hash of the ethereum block"`
+}
+
+type bridgeReqParams struct {
+	TxReqID string `json:"TxReqID" desc:"hash of the shielding request tx"`
+}
+
+type getBridgeReqWithStatusParams struct {
+	Data bridgeReqParams `json:"data" desc:"shielding request"`
+}
+
+// relayingHeaderMetadataParams is the metadata object read by handleCreateRawTxWithRelayingHeader
+type relayingHeaderMetadataParams struct {
+	SenderAddress string     `json:"SenderAddress" desc:"base58 payment address of the relayer"`
+	Header        string     `json:"Header" desc:"base64 encoded header, with the last commit for bnb"`
+	BlockHeight   rpcDecimal `json:"BlockHeight" desc:"height of the relayed block"`
+}
+
+// createRelayingHeaderTxParams is the layout of bean.NewCreateRawTxParam with a relaying header, sent without privacy
+type createRelayingHeaderTxParams struct {
+	PrivateKey string                       `json:"privateKey" desc:"base58 private key of the relayer"`
+	Receivers  map[string]uint64            `json:"receivers" desc:"payment address to amount (nano PRV), can be empty"`
+	Fee        int64                        `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                          `json:"privacy" rpc:"optional" desc:"ignored, relaying txs have no privacy"`
+	Metadata   relayingHeaderMetadataParams `json:"metadata" desc:"relayed header"`
+}
+
+type blockHeightParams struct {
+	BlockHeight rpcDecimal `json:"BlockHeight" desc:"height of the block"`
+}
+
+type getRelayingBNBHeaderByBlockHeightParams struct {
+	Data blockHeightParams `json:"data" desc:"bnb block"`
+}
+
+type btcBlockHashParams struct {
+	BTCBlockHash string `json:"btcBlockHash" desc:"hash of the btc block"`
+}
+
+type getBurningAddressParams struct {
+	BeaconHeight uint64 `json:"beaconHeight" rpc:"optional" desc:"beacon height, the burning address changes with it (default 0)"`
+}
+
+// pdeContributionMetadataParams is the metadata object read by handleCreateRawTxWithPRVContribution, amounts are numbers
+type pdeContributionMetadataParams struct {
+	PDEContributionPairID string `json:"PDEContributionPairID" desc:"id shared by the two contributions of a pair"`
+	ContributorAddressStr string `json:"ContributorAddressStr" desc:"payment address of the contributor"`
+	ContributedAmount     uint64 `json:"ContributedAmount" desc:"contributed amount"`
+	TokenIDStr            string `json:"TokenIDStr" desc:"id of the contributed token"`
+}
+
+// createPRVContributionTxParams is the layout of bean.NewCreateRawTxParam with a contribution metadata
+type createPRVContributionTxParams struct {
+	PrivateKey string                        `json:"privateKey" desc:"base58 private key of contributor"`
+	Receivers  map[string]uint64             `json:"receivers" desc:"burning address to ContributedAmount"`
+	Fee        int64                         `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                           `json:"privacy" desc:"1 to send with privacy, -1 without"`
+	Metadata   pdeContributionMetadataParams `json:"metadata" desc:"contribution request"`
+	Info       string                        `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+// pTokenContributionTokenParams is privacyTokenParams with the contribution fields, TokenReceivers burn ContributedAmount
+type pTokenContributionTokenParams struct {
+	privacyTokenParams
+	pdeContributionMetadataParams
+}
+
+// createPTokenContributionTxParams is the layout of bean.NewCreateRawPrivacyTokenTxParam with contribution keys
+type createPTokenContributionTxParams struct {
+	PrivateKey      string                        `json:"privateKey" desc:"base58 private key of contributor"`
+	Receivers       map[string]uint64             `json:"receivers" desc:"payment address to amount (nano PRV), usually empty"`
+	Fee             int64                         `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy         int                           `json:"privacy" desc:"1 to send PRV with privacy, -1 without"`
+	TokenParams     pTokenContributionTokenParams `json:"tokenParams" desc:"contribution request and token transfer"`
+	Info            string                        `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+	HasPrivacyToken int                           `json:"hasPrivacyToken" rpc:"optional" desc:"must be 0 or -1, the token is sent without privacy"`
+}
+
+// pdeTradeMetadataParams is the metadata object read by handleCreateRawTxWithPRVTradeReq, amounts are numbers
+type pdeTradeMetadataParams struct {
+	TokenIDToBuyStr     string `json:"TokenIDToBuyStr" desc:"token id to buy"`
+	TokenIDToSellStr    string `json:"TokenIDToSellStr" desc:"token id to sell"`
+	SellAmount          uint64 `json:"SellAmount" desc:"amount to sell"`
+	TraderAddressStr    string `json:"TraderAddressStr" desc:"payment address receiving the bought token"`
+	MinAcceptableAmount uint64 `json:"MinAcceptableAmount" desc:"minimum amount to receive, the trade is refunded otherwise"`
+	TradingFee          uint64 `json:"TradingFee" desc:"trading fee in PRV"`
+}
+
+// createPRVTradeTxParams is the layout of bean.NewCreateRawTxParam with a trade metadata
+type createPRVTradeTxParams struct {
+	PrivateKey string                 `json:"privateKey" desc:"base58 private key of trader"`
+	Receivers  map[string]uint64      `json:"receivers" desc:"burning address to SellAmount + TradingFee"`
+	Fee        int64                  `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                    `json:"privacy" desc:"1 to send with privacy, -1 without"`
+	Metadata   pdeTradeMetadataParams `json:"metadata" desc:"trade request"`
+	Info       string                 `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+// pTokenTradeTokenParams is privacyTokenParams with the trade fields, TokenReceivers burn SellAmount
+type pTokenTradeTokenParams struct {
+	privacyTokenParams
+	pdeTradeMetadataParams
+}
+
+// createPTokenTradeTxParams is the layout of bean.NewCreateRawPrivacyTokenTxParam with trade keys
+type createPTokenTradeTxParams struct {
+	PrivateKey      string                 `json:"privateKey" desc:"base58 private key of trader"`
+	Receivers       map[string]uint64      `json:"receivers" desc:"burning address to TradingFee in PRV, can be empty"`
+	Fee             int64                  `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy         int                    `json:"privacy" desc:"1 to send PRV with privacy, -1 without"`
+	TokenParams     pTokenTradeTokenParams `json:"tokenParams" desc:"trade request and token transfer"`
+	Info            string                 `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+	HasPrivacyToken int                    `json:"hasPrivacyToken" rpc:"optional" desc:"must be 0 or -1, the token is sent without privacy"`
+}
+
+// pdeWithdrawalMetadataParams is the metadata object read by handleCreateRawTxWithWithdrawalReq, amounts are numbers
+type pdeWithdrawalMetadataParams struct {
+	WithdrawerAddressStr  string `json:"WithdrawerAddressStr" desc:"payment address of the contributor"`
+	WithdrawalToken1IDStr string `json:"WithdrawalToken1IDStr" desc:"first token id of the pair"`
+	WithdrawalToken2IDStr string `json:"WithdrawalToken2IDStr" desc:"second token id of the pair"`
+	WithdrawalShareAmt    uint64 `json:"WithdrawalShareAmt" desc:"amount of shares to withdraw"`
+}
+
+// createPDEWithdrawalTxParams is the layout of bean.NewCreateRawTxParam with a withdrawal metadata
+type createPDEWithdrawalTxParams struct {
+	PrivateKey string                      `json:"privateKey" desc:"base58 private key of contributor"`
+	Receivers  map[string]uint64           `json:"receivers" desc:"payment address to amount (nano PRV), usually empty"`
+	Fee        int64                       `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                         `json:"privacy" desc:"1 to send with privacy, -1 without"`
+	Metadata   pdeWithdrawalMetadataParams `json:"metadata" desc:"withdrawal request"`
+	Info       string                      `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+// privacyTokenParamsV2 is the token object read by TxService.BuildTokenParamV2, amounts are decimal strings
+type privacyTokenParamsV2 struct {
+	Privacy        bool                  `json:"Privacy" desc:"true for privacy token"`
+	TokenID        string                `json:"TokenID" desc:"token id"`
+	TokenName      string                `json:"TokenName" desc:"token name"`
+	TokenSymbol    string                `json:"TokenSymbol" desc:"token symbol"`
+	TokenTxType    int                   `json:"TokenTxType" desc:"0: init, 1: transfer"`
+	TokenAmount    rpcDecimal            `json:"TokenAmount" desc:"token amount"`
+	TokenFee       rpcDecimal            `json:"TokenFee" desc:"fee paid in token, 0 to pay in PRV"`
+	TokenReceivers map[string]rpcDecimal `json:"TokenReceivers" desc:"payment address to token amount"`
+	IsGetPTokenFee bool                  `json:"IsGetPTokenFee" rpc:"optional" desc:"pay the fee in token"`
+	UnitPTokenFee  int64                 `json:"UnitPTokenFee" rpc:"optional" desc:"fee per kb in token"`
+}
+
+// pdeContributionMetadataParamsV2 is pdeContributionMetadataParams with a decimal string amount
+type pdeContributionMetadataParamsV2 struct {
+	PDEContributionPairID string     `json:"PDEContributionPairID" desc:"id shared by the two contributions of a pair"`
+	ContributorAddressStr string     `json:"ContributorAddressStr" desc:"payment address of the contributor"`
+	ContributedAmount     rpcDecimal `json:"ContributedAmount" desc:"contributed amount"`
+	TokenIDStr            string     `json:"TokenIDStr" desc:"id of the contributed token"`
+}
+
+// createPRVContributionTxParamsV2 is the layout of bean.NewCreateRawTxParamV2 with a contribution metadata
+type createPRVContributionTxParamsV2 struct {
+	PrivateKey string                          `json:"privateKey" desc:"base58 private key of contributor"`
+	Receivers  map[string]rpcDecimal           `json:"receivers" desc:"burning address to ContributedAmount"`
+	Fee        int64                           `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                             `json:"privacy" desc:"1 to send with privacy, -1 without"`
+	Metadata   pdeContributionMetadataParamsV2 `json:"metadata" desc:"contribution request"`
+	Info       string                          `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+// pTokenContributionTokenParamsV2 is privacyTokenParamsV2 with the contribution fields, TokenReceivers burn
+// ContributedAmount
+type pTokenContributionTokenParamsV2 struct {
+	privacyTokenParamsV2
+	pdeContributionMetadataParamsV2
+}
+
+// createPTokenContributionTxParamsV2 is the layout of bean.NewCreateRawPrivacyTokenTxParamV2 with contribution keys
+type createPTokenContributionTxParamsV2 struct {
+	PrivateKey      string                          `json:"privateKey" desc:"base58 private key of contributor"`
+	Receivers       map[string]rpcDecimal           `json:"receivers" desc:"payment address to amount (nano PRV), null when there is no PRV to send"`
+	Fee             int64                           `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy         int                             `json:"privacy" desc:"1 to send PRV with privacy, -1 without"`
+	TokenParams     pTokenContributionTokenParamsV2 `json:"tokenParams" desc:"contribution request and token transfer"`
+	Info            string                          `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+	HasPrivacyToken int                             `json:"hasPrivacyToken" rpc:"optional" desc:"must be 0 or -1, the token is sent without privacy"`
+}
+
+// pdeWithdrawalMetadataParamsV2 is pdeWithdrawalMetadataParams with a decimal string amount
+type pdeWithdrawalMetadataParamsV2 struct {
+	WithdrawerAddressStr  string     `json:"WithdrawerAddressStr" desc:"payment address of the contributor"`
+	WithdrawalToken1IDStr string     `json:"WithdrawalToken1IDStr" desc:"first token id of the pair"`
+	WithdrawalToken2IDStr string     `json:"WithdrawalToken2IDStr" desc:"second token id of the pair"`
+	WithdrawalShareAmt    rpcDecimal `json:"WithdrawalShareAmt" desc:"amount of shares to withdraw"`
+}
+
+// createPDEWithdrawalTxParamsV2 is the layout of bean.NewCreateRawTxParamV2 with a withdrawal metadata
+type createPDEWithdrawalTxParamsV2 struct {
+	PrivateKey string                        `json:"privateKey" desc:"base58 private key of contributor"`
+	Receivers  map[string]rpcDecimal         `json:"receivers" desc:"payment address to amount (nano PRV), usually empty"`
+	Fee        int64                         `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                           `json:"privacy" desc:"1 to send with privacy, -1 without"`
+	Metadata   pdeWithdrawalMetadataParamsV2 `json:"metadata" desc:"withdrawal request"`
+	Info       string                        `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+type pdeFeeWithdrawalMetadataParams struct {
+	WithdrawerAddressStr  string     `json:"WithdrawerAddressStr" desc:"payment address of the contributor"`
+	WithdrawalToken1IDStr string     `json:"WithdrawalToken1IDStr" desc:"first token id of the pair"`
+	WithdrawalToken2IDStr string     `json:"WithdrawalToken2IDStr" desc:"second token id of the pair"`
+	WithdrawalFeeAmt      rpcDecimal `json:"WithdrawalFeeAmt" desc:"amount of trading fees to withdraw"`
+}
+
+// createPDEFeeWithdrawalTxParams is the layout of bean.NewCreateRawTxParamV2 with a fee withdrawal metadata
+type createPDEFeeWithdrawalTxParams struct {
+	PrivateKey string                         `json:"privateKey" desc:"base58 private key of contributor"`
+	Receivers  map[string]rpcDecimal          `json:"receivers" desc:"payment address to amount (nano PRV), usually empty"`
+	Fee        int64                          `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                            `json:"privacy" desc:"1 to send with privacy, -1 without"`
+	Metadata   pdeFeeWithdrawalMetadataParams `json:"metadata" desc:"fee withdrawal request"`
+	Info       string                         `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+type beaconBlockParams struct {
+	BeaconHeight *uint64 `json:"BeaconHeight" rpc:"optional" desc:"beacon height, required when BeaconHash is omitted"`
+	BeaconHash   string  `json:"BeaconHash" rpc:"optional" desc:"beacon block hash, read before BeaconHeight"`
+}
+
+type getPDEStateParams struct {
+	Data beaconBlockParams `json:"data" desc:"beacon block of the state"`
+}
+
+type pdeConvertNativeTokenParams struct {
+	BeaconHeight      uint64 `json:"BeaconHeight" desc:"beacon height of the pool"`
+	NativeTokenAmount uint64 `json:"NativeTokenAmount" desc:"amount of PRV"`
+	TokenID           string `json:"TokenID" desc:"token id to convert to"`
+}
+
+type convertNativeTokenToPrivacyTokenParams struct {
+	Data pdeConvertNativeTokenParams `json:"data" desc:"conversion"`
+}
+
+type pdeConvertPrivacyTokenParams struct {
+	BeaconHeight       uint64 `json:"BeaconHeight" desc:"beacon height of the pool"`
+	PrivacyTokenAmount uint64 `json:"PrivacyTokenAmount" desc:"amount of token"`
+	TokenID            string `json:"TokenID" desc:"token id to convert from"`
+}
+
+type convertPrivacyTokenToNativeTokenParams struct {
+	Data pdeConvertPrivacyTokenParams `json:"data" desc:"conversion"`
+}
+
+type pdeContributionPairParams struct {
+	ContributionPairID string `json:"ContributionPairID" desc:"id shared by the two contributions of a pair"`
+}
+
+type getPDEContributionStatusParams struct {
+	Data pdeContributionPairParams `json:"data" desc:"contribution"`
+}
+
+type pdeRequestParams struct {
+	TxRequestIDStr string `json:"TxRequestIDStr" desc:"hash of the request tx"`
+}
+
+type getPDERequestStatusParams struct {
+	Data pdeRequestParams `json:"data" desc:"request"`
+}
+
+type pdeBeaconHeightParams struct {
+	BeaconHeight uint64 `json:"BeaconHeight" desc:"beacon height"`
+}
+
+type extractPDEInstsFromBeaconBlockParams struct {
+	Data pdeBeaconHeightParams `json:"data" desc:"beacon block"`
+}
+
+type pdePriceParams struct {
+	FromTokenIDStr string `json:"FromTokenIDStr" desc:"token id to sell"`
+	ToTokenIDStr   string `json:"ToTokenIDStr" desc:"token id to buy, all for every pool of FromTokenIDStr"`
+	Amount         uint64 `json:"Amount" desc:"amount to sell, not 0"`
+}
+
+type convertPDEPricesParams struct {
+	Data pdePriceParams `json:"data" desc:"trade to price"`
+}
+
+// custodianDepositMetadataParams is the metadata object read by handleCreateRawTxWithCustodianDeposit
+type custodianDepositMetadataParams struct {
+	IncognitoAddress string            `json:"IncognitoAddress" desc:"payment address of the custodian"`
+	RemoteAddresses  map[string]string `json:"RemoteAddresses" desc:"portal token id to the address of the custodian on its chain"`
+	DepositedAmount  rpcDecimal        `json:"DepositedAmount" desc:"PRV collateral"`
+}
+
+// createCustodianDepositTxParams is the layout of bean.NewCreateRawTxParamV2 with a custodian deposit
+type createCustodianDepositTxParams struct {
+	PrivateKey string                         `json:"privateKey" desc:"base58 private key of custodian"`
+	Receivers  map[string]rpcDecimal          `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee        int64                          `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                            `json:"privacy" rpc:"optional" desc:"ignored, portal txs are sent without privacy"`
+	Metadata   custodianDepositMetadataParams `json:"metadata" desc:"custodian deposit"`
+	Info       string                         `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+// portalReqPTokenMetadataParams is the metadata object read by handleCreateRawTxWithReqPToken
+type portalReqPTokenMetadataParams struct {
+	UniquePortingID string     `json:"UniquePortingID" desc:"id of the porting request"`
+	TokenID         string     `json:"TokenID" desc:"portal token id"`
+	IncogAddressStr string     `json:"IncogAddressStr" desc:"payment address receiving the ptoken"`
+	PortingAmount   rpcDecimal `json:"PortingAmount" desc:"ported amount"`
+	PortingProof    string     `json:"PortingProof" desc:"proof of the transfer to the custodians"`
+}
+
+// createPortalReqPTokenTxParams is the layout of bean.NewCreateRawTxParamV2 with a ptoken request
+type createPortalReqPTokenTxParams struct {
+	PrivateKey string                        `json:"privateKey" desc:"base58 private key of user"`
+	Receivers  map[string]rpcDecimal         `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee        int64                         `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                           `json:"privacy" rpc:"optional" desc:"ignored, portal txs are sent without privacy"`
+	Metadata   portalReqPTokenMetadataParams `json:"metadata" desc:"ptoken request"`
+	Info       string                        `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+type getPortalStateParams struct {
+	Data portalBeaconBlockParams `json:"data" desc:"beacon block of the state"`
+}
+
+type portalBeaconBlockParams struct {
+	BeaconHeight *rpcDecimal `json:"BeaconHeight" rpc:"optional" desc:"beacon height, required when BeaconHash is omitted"`
+	BeaconHash   string      `json:"BeaconHash" rpc:"optional" desc:"beacon block hash, read before BeaconHeight"`
+}
+
+type portalDepositTxParams struct {
+	DepositTxID string `json:"DepositTxID" desc:"hash of the deposit tx"`
+}
+
+type getPortalCustodianDepositStatusParams struct {
+	Data portalDepositTxParams `json:"data" desc:"custodian deposit"`
+}
+
+type portalReqTxParams struct {
+	ReqTxID string `json:"ReqTxID" desc:"hash of the request tx"`
+}
+
+type getPortalReqStatusParams struct {
+	Data portalReqTxParams `json:"data" desc:"request"`
+}
+
+type portalRedeemIDParams struct {
+	RedeemID string `json:"RedeemID" desc:"id of the redeem request"`
+}
+
+type getPortalReqRedeemStatusParams struct {
+	Data portalRedeemIDParams `json:"data" desc:"redeem request"`
+}
+
+type custodianLiquidationParams struct {
+	RedeemID            string `json:"RedeemID" desc:"id of the redeem request"`
+	CustodianIncAddress string `json:"CustodianIncAddress" desc:"payment address of the custodian"`
+}
+
+type getCustodianLiquidationStatusParams struct {
+	Data custodianLiquidationParams `json:"data" desc:"liquidated redeem request"`
+}
+
+// portalRedeemTokenParams is privacyTokenParamsV2 with the redeem fields, TokenReceivers burn RedeemAmount + RedeemFee
+type portalRedeemTokenParams struct {
+	privacyTokenParamsV2
+	UniqueRedeemID        string     `json:"UniqueRedeemID" desc:"id of the redeem request"`
+	RedeemTokenID         string     `json:"RedeemTokenID" desc:"portal token id"`
+	RedeemAmount          rpcDecimal `json:"RedeemAmount" desc:"redeemed amount"`
+	RedeemFee             rpcDecimal `json:"RedeemFee" desc:"redeem fee in PRV"`
+	RedeemerIncAddressStr string     `json:"RedeemerIncAddressStr" desc:"payment address of the redeemer"`
+	RemoteAddress         string     `json:"RemoteAddress" desc:"address receiving the public token"`
+}
+
+// createPortalRedeemTxParams is the layout of bean.NewCreateRawPrivacyTokenTxParamV2 with redeem keys
+type createPortalRedeemTxParams struct {
+	PrivateKey      string                  `json:"privateKey" desc:"base58 private key of redeemer"`
+	Receivers       map[string]rpcDecimal   `json:"receivers" desc:"burning address to RedeemFee in PRV"`
+	Fee             int64                   `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy         int                     `json:"privacy" desc:"1 to send PRV with privacy, -1 without"`
+	TokenParams     portalRedeemTokenParams `json:"tokenParams" desc:"redeem request and token transfer"`
+	Info            string                  `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+	HasPrivacyToken int                     `json:"hasPrivacyToken" rpc:"optional" desc:"must be 0 or -1, the token is sent without privacy"`
+}
+
+type custodianWithdrawMetadataParams struct {
+	PaymentAddress string     `json:"PaymentAddress" desc:"payment address of the custodian"`
+	Amount         rpcDecimal `json:"Amount" desc:"free collateral to withdraw"`
+}
+
+// createCustodianWithdrawTxParams is the layout of bean.NewCreateRawTxParamV2 with a custodian withdrawal
+type createCustodianWithdrawTxParams struct {
+	PrivateKey string                          `json:"privateKey" desc:"base58 private key of custodian"`
+	Receivers  map[string]rpcDecimal           `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee        int64                           `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                             `json:"privacy" desc:"1 to send with privacy, -1 without"`
+	Metadata   custodianWithdrawMetadataParams `json:"metadata" desc:"custodian withdrawal"`
+	Info       string                          `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+// portalReqUnlockCollateralMetadataParams is the metadata object read by handleCreateRawTxWithReqUnlockCollateral
+type portalReqUnlockCollateralMetadataParams struct {
+	UniqueRedeemID      string     `json:"UniqueRedeemID" desc:"id of the redeem request"`
+	TokenID             string     `json:"TokenID" desc:"portal token id"`
+	CustodianAddressStr string     `json:"CustodianAddressStr" desc:"payment address of the custodian"`
+	RedeemAmount        rpcDecimal `json:"RedeemAmount" desc:"redeemed amount"`
+	RedeemProof         string     `json:"RedeemProof" desc:"proof of the transfer to the redeemer"`
+}
+
+// createPortalReqUnlockCollateralTxParams is the layout of bean.NewCreateRawTxParamV2 with an unlock collateral request
+type createPortalReqUnlockCollateralTxParams struct {
+	PrivateKey string                                  `json:"privateKey" desc:"base58 private key of custodian"`
+	Receivers  map[string]rpcDecimal                   `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee        int64                                   `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                                     `json:"privacy" rpc:"optional" desc:"ignored, portal txs are sent without privacy"`
+	Metadata   portalReqUnlockCollateralMetadataParams `json:"metadata" desc:"unlock collateral request"`
+	Info       string                                  `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+type custodianWithdrawTxParams struct {
+	TxId string `json:"TxId" desc:"hash of the withdrawal tx"`
+}
+
+type getCustodianWithdrawByTxIdParams struct {
+	Data custodianWithdrawTxParams `json:"data" desc:"custodian withdrawal"`
+}
+
+type portalReqWithdrawRewardMetadataParams struct {
+	CustodianAddressStr string `json:"CustodianAddressStr" desc:"payment address of the custodian"`
+	TokenID             string `json:"TokenID" desc:"id of the reward token"`
+}
+
+// createPortalReqWithdrawRewardTxParams is the layout of bean.NewCreateRawTxParamV2 with a portal reward withdrawal
+type createPortalReqWithdrawRewardTxParams struct {
+	PrivateKey string                                `json:"privateKey" desc:"base58 private key of custodian"`
+	Receivers  map[string]rpcDecimal                 `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee        int64                                 `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                                   `json:"privacy" rpc:"optional" desc:"ignored, portal txs are sent without privacy"`
+	Metadata   portalReqWithdrawRewardMetadataParams `json:"metadata" desc:"reward withdrawal"`
+	Info       string                                `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+type portalIncognitoAddressParams struct {
+	IncognitoAddress string `json:"IncognitoAddress" desc:"payment address of the custodian"`
+}
+
+type getPortalRewardParams struct {
+	Data portalIncognitoAddressParams `json:"data" desc:"custodian"`
+}
+
+type rewardFeatureParams struct {
+	FeatureName string     `json:"FeatureName" desc:"name of the feature, as portal"`
+	Epoch       rpcDecimal `json:"Epoch" desc:"epoch, from 1"`
+}
+
+type getRewardFeatureParams struct {
+	Data rewardFeatureParams `json:"data" desc:"feature reward"`
+}
+
+type portalReqMatchingRedeemMetadataParams struct {
+	CustodianAddressStr string `json:"CustodianAddressStr" desc:"payment address of the custodian"`
+	RedeemID            string `json:"RedeemID" desc:"id of the redeem request"`
+}
+
+// createPortalReqMatchingRedeemTxParams is the layout of bean.NewCreateRawTxParamV2 with a matching redeem request
+type createPortalReqMatchingRedeemTxParams struct {
+	PrivateKey string                                `json:"privateKey" desc:"base58 private key of custodian"`
+	Receivers  map[string]rpcDecimal                 `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee        int64                                 `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                                   `json:"privacy" rpc:"optional" desc:"ignored, portal txs are sent without privacy"`
+	Metadata   portalReqMatchingRedeemMetadataParams `json:"metadata" desc:"matching redeem request"`
+	Info       string                                `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+type portalExchangeRatesMetadataParams struct {
+	SenderAddress string                `json:"SenderAddress" desc:"payment address of the feeder"`
+	Rates         map[string]rpcDecimal `json:"Rates" desc:"exchange rate token id to its rate, not 0"`
+}
+
+// createPortalExchangeRatesTxParams is the layout of bean.NewCreateRawTxParamV2 with exchange rates
+type createPortalExchangeRatesTxParams struct {
+	PrivateKey string                            `json:"privateKey" desc:"base58 private key of feeder"`
+	Receivers  map[string]rpcDecimal             `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee        int64                             `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                               `json:"privacy" desc:"1 to send with privacy, -1 without"`
+	Metadata   portalExchangeRatesMetadataParams `json:"metadata" desc:"exchange rates"`
+	Info       string                            `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+type portalBeaconHeightParams struct {
+	BeaconHeight rpcDecimal `json:"BeaconHeight" desc:"beacon height"`
+}
+
+type getPortalFinalExchangeRatesParams struct {
+	Data portalBeaconHeightParams `json:"data" desc:"beacon block"`
+}
+
+type portalTokenValueParams struct {
+	TokenID      string     `json:"TokenID" desc:"portal token id"`
+	ValuePToken  rpcDecimal `json:"ValuePToken" desc:"amount of token"`
+	BeaconHeight rpcDecimal `json:"BeaconHeight" desc:"beacon height of the exchange rates"`
+}
+
+type convertExchangeRatesParams struct {
+	Data portalTokenValueParams `json:"data" desc:"amount to convert"`
+}
+
+type getPortingRequestFeesParams struct {
+	Data portalTokenValueParams `json:"data" desc:"ported amount"`
+}
+
+type liquidationPoolParams struct {
+	TokenID      string     `json:"TokenID" desc:"exchange rate token id"`
+	BeaconHeight rpcDecimal `json:"BeaconHeight" desc:"beacon height"`
+}
+
+type getLiquidationExchangeRatesPoolParams struct {
+	Data liquidationPoolParams `json:"data" desc:"liquidation pool"`
+}
+
+type custodianTokenParams struct {
+	CustodianAddress string     `json:"CustodianAddress" desc:"payment address of the custodian"`
+	TokenID          string     `json:"TokenID" desc:"portal token id"`
+	BeaconHeight     rpcDecimal `json:"BeaconHeight" desc:"beacon height"`
+}
+
+type getAmountNeededForCustodianDepositLiquidationParams struct {
+	Data custodianTokenParams `json:"data" desc:"custodian"`
+}
+
+// redeemLiquidationTokenParams is privacyTokenParamsV2 with the redeem fields, TokenReceivers burn RedeemAmount
+type redeemLiquidationTokenParams struct {
+	privacyTokenParamsV2
+	RedeemTokenID         string     `json:"RedeemTokenID" desc:"portal token id"`
+	RedeemAmount          rpcDecimal `json:"RedeemAmount" desc:"redeemed amount"`
+	RedeemerIncAddressStr string     `json:"RedeemerIncAddressStr" desc:"payment address of the redeemer"`
+}
+
+// createRedeemLiquidationExchangeRatesTxParams is the layout of bean.NewCreateRawPrivacyTokenTxParamV2 with redeem keys
+type createRedeemLiquidationExchangeRatesTxParams struct {
+	PrivateKey      string                       `json:"privateKey" desc:"base58 private key of redeemer"`
+	Receivers       map[string]rpcDecimal        `json:"receivers" desc:"payment address to amount (nano PRV), null when there is no PRV to send"`
+	Fee             int64                        `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy         int                          `json:"privacy" desc:"1 to send PRV with privacy, -1 without"`
+	TokenParams     redeemLiquidationTokenParams `json:"tokenParams" desc:"redeem request and token transfer"`
+	Info            string                       `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+	HasPrivacyToken int                          `json:"hasPrivacyToken" rpc:"optional" desc:"must be 0 or -1, the token is sent without privacy"`
+}
+
+type liquidationCustodianDepositMetadataParams struct {
+	IncognitoAddress     string     `json:"IncognitoAddress" desc:"payment address of the custodian"`
+	PTokenId             string     `json:"PTokenId" desc:"portal token id"`
+	DepositedAmount      rpcDecimal `json:"DepositedAmount" desc:"PRV collateral"`
+	FreeCollateralAmount rpcDecimal `json:"FreeCollateralAmount" desc:"free collateral of the custodian used too"`
+}
+
+// createLiquidationCustodianDepositTxParams is the layout of bean.NewCreateRawTxParamV2 with a custodian top up
+type createLiquidationCustodianDepositTxParams struct {
+	PrivateKey string                                    `json:"privateKey" desc:"base58 private key of custodian"`
+	Receivers  map[string]rpcDecimal                     `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee        int64                                     `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                                       `json:"privacy" rpc:"optional" desc:"ignored, portal txs are sent without privacy"`
+	Metadata   liquidationCustodianDepositMetadataParams `json:"metadata" desc:"custodian top up"`
+	Info       string                                    `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+type topUpWaitingPortingMetadataParams struct {
+	PortingID            string     `json:"PortingID" desc:"id of the waiting porting request"`
+	IncognitoAddress     string     `json:"IncognitoAddress" desc:"payment address of the custodian"`
+	PTokenId             string     `json:"PTokenId" desc:"portal token id"`
+	DepositedAmount      rpcDecimal `json:"DepositedAmount" desc:"PRV collateral"`
+	FreeCollateralAmount rpcDecimal `json:"FreeCollateralAmount" desc:"free collateral of the custodian used too"`
+}
+
+// createTopUpWaitingPortingTxParams is the layout of bean.NewCreateRawTxParamV2 with a top up of a waiting porting request
+type createTopUpWaitingPortingTxParams struct {
+	PrivateKey string                            `json:"privateKey" desc:"base58 private key of custodian"`
+	Receivers  map[string]rpcDecimal             `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee        int64                             `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                               `json:"privacy" rpc:"optional" desc:"ignored, portal txs are sent without privacy"`
+	Metadata   topUpWaitingPortingMetadataParams `json:"metadata" desc:"top up"`
+	Info       string                            `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+type portalTxIDParams struct {
+	TxID string `json:"TxID" desc:"hash of the top up tx"`
+}
+
+type getPortalCustodianTopupStatusParams struct {
+	Data portalTxIDParams `json:"data" desc:"top up"`
+}
+
+type custodianAddressParams struct {
+	CustodianAddress string `json:"CustodianAddress" desc:"payment address of the custodian"`
+}
+
+type getAmountTopUpWaitingPortingParams struct {
+	Data custodianAddressParams `json:"data" desc:"custodian"`
+}
+
+type registerPortingMetadataParams struct {
+	UniqueRegisterId string     `json:"UniqueRegisterId" desc:"id of the porting request"`
+	IncogAddressStr  string     `json:"IncogAddressStr" desc:"payment address of the user"`
+	PTokenId         string     `json:"PTokenId" desc:"portal token id"`
+	RegisterAmount   rpcDecimal `json:"RegisterAmount" desc:"ported amount"`
+	PortingFee       rpcDecimal `json:"PortingFee" desc:"porting fee in PRV"`
+}
+
+// createRegisterPortingPublicTokensTxParams is the layout of bean.NewCreateRawTxParamV2 with a porting request
+type createRegisterPortingPublicTokensTxParams struct {
+	PrivateKey string                        `json:"privateKey" desc:"base58 private key of user"`
+	Receivers  map[string]rpcDecimal         `json:"receivers" desc:"payment address to amount (nano PRV)"`
+	Fee        int64                         `json:"fee" desc:"fee per kb in nano PRV, -1 to estimate"`
+	Privacy    int                           `json:"privacy" desc:"1 to send with privacy, -1 without"`
+	Metadata   registerPortingMetadataParams `json:"metadata" desc:"porting request"`
+	Info       string                        `json:"info" rpc:"optional" desc:"memo stored in the transaction"`
+}
+
+type portingTxHashParams struct {
+	TxHash string `json:"TxHash" desc:"hash of the porting request tx"`
+}
+
+type getPortingRequestByKeyParams struct {
+	Data portingTxHashParams `json:"data" desc:"porting request"`
+}
+
+type portingIDParams struct {
+	PortingId string `json:"PortingId" desc:"id of the porting request"`
+}
+
+type getPortingRequestByPortingIdParams struct {
+	Data portingIDParams `json:"data" desc:"porting request"`
+}
+
+// params of the websocket subscriptions, decoded with decodeRPCParams but not listed in rpcSchemas as they are not
+// http methods
+
+type wsShardParams struct {
+	ShardID int `json:"shardID" desc:"shard id"`
+}
+
+type wsPublicKeyParams struct {
+	PublicKey string `json:"publicKey" desc:"base58 committee public key"`
+}
+
+type wsTxHashParams struct {
+	TxHash string `json:"txHash" desc:"hash of the tx"`
+}
+
+type wsPrivateKeyParams struct {
+	PrivateKey string `json:"privateKey" desc:"base58 private key of the receiver"`
+}
+
+// rpcSchemas is the registry of typed rpc methods, params of these methods are validated before the handler is called
+var rpcSchemas = map[string]rpcMethodSchema{
+	getRPCSchema: {
+		Description: "JSON schema of params and result of rpc methods",
+		Params:      getRPCSchemaParams{},
+	},
+
+	// block
+	getBestBlockHash: {
+		Description: "best block hash of every shard, key -1 is beacon",
+		Params:      noParams{},
+		Result:      jsonresult.GetBestBlockHashResult{},
+	},
+	getBlockCount: {
+		Description: "height of the best block of a shard or beacon",
+		Params:      getBlockCountParams{},
+		Result:      uint64(0),
+	},
+	getBlockHash: {
+		Description: "hashes of blocks at a height",
+		Params:      getBlockHashParams{},
+		Result:      []string{},
+	},
+	getBlocks: {
+		Description: "latest blocks of a shard or beacon",
+		Params:      getBlocksParams{},
+	},
+	retrieveBlock: {
+		Description: "shard block by hash",
+		Params:      retrieveBlockParams{},
+		Result:      jsonresult.GetShardBlockResult{},
+	},
+	retrieveBlockByHeight: {
+		Description: "shard blocks at a height",
+		Params:      retrieveBlockByHeightParams{},
+		Result:      []jsonresult.GetShardBlockResult{},
+	},
+	retrieveBeaconBlock: {
+		Description: "beacon block by hash",
+		Params:      retrieveBeaconBlockParams{},
+		Result:      jsonresult.GetBeaconBlockResult{},
+	},
+	retrieveBeaconBlockByHeight: {
+		Description: "beacon blocks at a height",
+		Params:      retrieveBeaconBlockByHeightParams{},
+		Result:      []jsonresult.GetBeaconBlockResult{},
+	},
+
+	getBlockTemplate: {
+		Description: "pending txs a producer of the shard would put in its next block, by fee per KB and within the quota of each metadata type",
+		Params:      shardIDParams{},
+		Result:      blockchain.BlockTemplate{},
+	},
+	getBestBlock: {
+		Description: "best block height and hash of every shard, key -1 is beacon",
+		Params:      noParams{},
+		Result:      jsonresult.GetBestBlockResult{},
+	},
+	getBlockChainInfo: {
+		Description: "chain name, active shards and best block of every shard, key -1 is beacon",
+		Params:      noParams{},
+		Result:      jsonresult.GetBlockChainInfoResult{},
+	},
+	getBlockHeader: {
+		Description: "shard block headers by hash or number",
+		Params:      getBlockHeaderParams{},
+		Result:      []jsonresult.GetHeaderResult{},
+	},
+	getCrossShardBlock: {
+		Description: "outputs sent to other shards by the shard blocks at a height, by block hash",
+		Params:      getCrossShardBlockParams{},
+		Result:      map[string]jsonresult.CrossShardDataResult{},
+	},
+
+	// best state
+	getShardBestState: {
+		Description: "best state of a shard",
+		Params:      shardIDParams{},
+		Result:      jsonresult.GetShardBestState{},
+	},
+	getCommitteeList: {
+		Description: "committees and substitute validators of the beacon and the shards",
+		Params:      getCommitteeListParams{},
+		Result:      jsonresult.CommitteeListsResult{},
+	},
+	getBeaconBestState: {
+		Description: "beacon best state restored from the stored views",
+		Params:      noParams{},
+		Result:      jsonresult.GetBeaconBestState{},
+	},
+	getBeaconBestStateDetail: {
+		Description: "beacon best state with its committees and candidates",
+		Params:      noParams{},
+		Result:      jsonresult.GetBeaconBestStateDetail{},
+	},
+	getShardBestStateDetail: {
+		Description: "best state of a shard with its committees",
+		Params:      shardIDParams{},
+		Result:      jsonresult.GetShardBestStateDetail{},
+	},
+	getCandidateList: {
+		Description: "candidates waiting for the current and the next random number",
+		Params:      noParams{},
+		Result:      jsonresult.CandidateListsResult{},
+	},
+	canPubkeyStake: {
+		Description: "whether a public key is not staked yet",
+		Params:      canPubkeyStakeParams{},
+		Result:      jsonresult.StakeResult{},
+	},
+	getTotalTransaction: {
+		Description: "number of transactions of a shard",
+		Params:      shardIDParams{},
+		Result:      jsonresult.TotalTransactionInShard{},
+	},
+
+	// transaction
+	getTransactionByHash: {
+		Description: "transaction detail by hash",
+		Params:      txHashParams{},
+		Result:      jsonresult.TransactionDetail{},
+	},
+	getCrossShardTxStatus: {
+		Description: "status of the outputs of a transaction sent to other shards: pending, included or finalized",
+		Params:      txHashParams{},
+		Result:      jsonresult.CrossShardTxStatus{},
+	},
+	sendRawTransaction: {
+		Description: "send a PRV transaction built by createtransaction",
+		Params:      sendRawTransactionParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createRawTransaction: {
+		Description: "build and sign a PRV transaction without sending it",
+		Params:      createTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createUnsignedTransaction: {
+		Description: "build a PRV transaction template without private key, to be signed offline then sent by sendtransaction",
+		Params:      createUnsignedTxParams{},
+		Result:      jsonresult.CreateUnsignedTransactionResult{},
+	},
+	createAndSendTransaction: {
+		Description: "build, sign and broadcast a PRV transaction",
+		Params:      createTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendPrivacyCustomTokenTransaction: {
+		Description: "build, sign and broadcast a privacy token transaction",
+		Params:      createPrivacyTokenTxParams{},
+		Result:      jsonresult.CreateTransactionTokenResult{},
+	},
+	createUnsignedPrivacyTokenTx: {
+		Description: "build a privacy token transfer template without private key, to be signed offline then sent by sendrawprivacycustomtokentransaction",
+		Params:      createUnsignedPrivacyTokenTxParams{},
+		Result:      jsonresult.CreateUnsignedPrivacyTokenTransactionResult{},
+	},
+	sendRawPrivacyCustomTokenTransaction: {
+		Description: "send a privacy token transaction built by createrawprivacycustomtokentransaction",
+		Params:      sendRawTransactionParams{},
+		Result:      jsonresult.CreateTransactionTokenResult{},
+	},
+	createRawPrivacyCustomTokenTransaction: {
+		Description: "build and sign a privacy token transaction without sending it",
+		Params:      createPrivacyTokenTxParams{},
+		Result:      jsonresult.CreateTransactionTokenResult{},
+	},
+	createAndSendStakingTransaction: {
+		Description: "build, sign and broadcast a request to stake PRV for a shard or beacon validator",
+		Params:      createStakingTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendStopAutoStakingTransaction: {
+		Description: "build, sign and broadcast a request to stop staking a validator again at the end of its term",
+		Params:      createStopAutoStakingTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	defragmentAccount: {
+		Description: "build, sign and broadcast a PRV transaction combining small coins of an account into one",
+		Params:      defragmentAccountParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	defragmentAccountToken: {
+		Description: "build, sign and broadcast a privacy token transaction combining small token coins of an account into one",
+		Params:      defragmentAccountTokenParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	estimateFee: {
+		Description: "fee per kb and size of a PRV or privacy token transaction sent by a private key",
+		Params:      estimateFeeParams{},
+		Result:      jsonresult.EstimateFeeResult{},
+	},
+	estimateFeeWithEstimator: {
+		Description: "fee per kb estimated from the latest blocks of the shard of a payment address",
+		Params:      estimateFeeWithEstimatorParams{},
+		Result:      jsonresult.EstimateFeeResult{},
+	},
+	gettransactionhashbyreceiver: {
+		Description: "hashes of the transactions sent to a payment address, by shard",
+		Params:      paymentAddressParams{},
+		Result:      map[byte][]string{},
+	},
+	gettransactionbyreceiver: {
+		Description: "transactions sent to a payment address, with the value of the coins when the readonly key is given",
+		Params:      getTransactionByReceiverParams{},
+		Result:      jsonresult.ListReceivedTransaction{},
+	},
+	decryptoutputcoinbykeyoftransaction: {
+		Description: "output coins of a transaction sent to a payment address, decrypted by its readonly key",
+		Params:      decryptOutputCoinParams{},
+		Result:      map[string]interface{}{},
+	},
+	listOutputCoins: {
+		Description: "unspent output coins of payment addresses, with their value when the readonly key is given",
+		Params:      listOutputCoinsParams{},
+		Result:      jsonresult.ListOutputCoins{},
+	},
+	randomCommitments: {
+		Description: "random commitments of the shard of a payment address hiding the coins it spends",
+		Params:      randomCommitmentsParams{},
+		Result:      jsonresult.RandomCommitmentResult{},
+	},
+	hasSerialNumbers: {
+		Description: "whether each serial number is spent in the shard of a payment address",
+		Params:      hasSerialNumbersParams{},
+		Result:      []bool{},
+	},
+	hasSnDerivators: {
+		Description: "whether each serial number derivator is used in the shard of a payment address",
+		Params:      hasSNDerivatorsParams{},
+		Result:      []bool{},
+	},
+	listSerialNumbers: {
+		Description: "serial numbers spent in a shard",
+		Params:      listByTokenParams{},
+		Result:      map[string]struct{}{},
+	},
+	listCommitments: {
+		Description: "commitments of a shard to their index",
+		Params:      listByTokenParams{},
+		Result:      map[string]uint64{},
+	},
+	listCommitmentIndices: {
+		Description: "indices of the commitments of a shard to the commitment",
+		Params:      listByTokenParams{},
+		Result:      map[uint64]string{},
+	},
+	privacyCustomTokenTxs: {
+		Description: "privacy token with the hashes of its transactions",
+		Params:      tokenIDParams{},
+		Result:      jsonresult.CustomToken{},
+	},
+	getListPrivacyCustomTokenBalance: {
+		Description: "balance of every privacy token of a private key",
+		Params:      privateKeyParams{},
+		Result:      jsonresult.ListCustomTokenBalance{},
+	},
+	getBalancePrivacyCustomToken: {
+		Description: "privacy token balance of a private key",
+		Params:      getBalancePrivacyCustomTokenParams{},
+		Result:      uint64(0),
+	},
+
+	// wallet
+	getBalanceByPrivatekey: {
+		Description: "PRV balance of a private key",
+		Params:      getBalanceParams{},
+		Result:      uint64(0),
+	},
+	getBalanceByPaymentAddress: {
+		Description: "PRV balance of a payment address, coins are counted without their value being checked",
+		Params:      getBalanceByPaymentAddressParams{},
+		Result:      uint64(0),
+	},
+	listUnspentOutputCoins: {
+		Description: "unspent output coins of private keys, with their value and memo",
+		Params:      listUnspentOutputCoinsParams{},
+		Result:      jsonresult.ListOutputCoins{},
+	},
+	listAccounts: {
+		Description: "accounts of the wallet of the node with their PRV balance",
+		Params:      noParams{},
+		Result:      jsonresult.ListAccounts{},
+	},
+	getAccount: {
+		Description: "name of the account of a payment address in the wallet of the node, null when not found",
+		Params:      paymentAddressParams{},
+		Result:      "",
+	},
+	getAddressesByAccount: {
+		Description: "payment addresses of an account of the wallet of the node",
+		Params:      accountNameParams{},
+		Result:      jsonresult.GetAddressesByAccount{},
+	},
+	getAccountAddress: {
+		Description: "keys of an account of the wallet of the node, the account is created when missing",
+		Params:      accountNameParams{},
+		Result:      wallet.KeySerializedData{},
+	},
+	dumpPrivkey: {
+		Description: "keys of the account of a payment address in the wallet of the node",
+		Params:      paymentAddressParams{},
+		Result:      wallet.KeySerializedData{},
+	},
+	importAccount: {
+		Description: "add the account of a private key to the wallet of the node",
+		Params:      importAccountParams{},
+		Result:      wallet.KeySerializedData{},
+	},
+	removeAccount: {
+		Description: "remove the account of a private key from the wallet of the node",
+		Params:      removeAccountParams{},
+		Result:      false,
+	},
+	getBalance: {
+		Description: "PRV balance of an account of the wallet of the node, or of all its accounts",
+		Params:      walletAccountParams{},
+		Result:      uint64(0),
+	},
+	getReceivedByAccount: {
+		Description: "PRV received by an account of the wallet of the node",
+		Params:      walletAccountParams{},
+		Result:      uint64(0),
+	},
+	setTxFee: {
+		Description: "fee per kb of the transactions created by the wallet of the node",
+		Params:      setTxFeeParams{},
+		Result:      false,
+	},
+	listPrivacyCustomToken: {
+		Description: "privacy tokens and bridge tokens of every shard",
+		Params:      listPrivacyCustomTokenParams{},
+		Result:      jsonresult.ListCustomToken{},
+	},
+	getPrivacyCustomToken: {
+		Description: "privacy token by id",
+		Params:      tokenIDParams{},
+		Result:      jsonresult.GetCustomToken{},
+	},
+	listPrivacyCustomTokenByShard: {
+		Description: "privacy tokens of a shard",
+		Params:      shardIDParams{},
+		Result:      jsonresult.ListCustomToken{},
+	},
+	getPublicKeyFromPaymentAddress: {
+		Description: "public key of a payment address",
+		Params:      paymentAddressParams{},
+		Result:      jsonresult.GetPublicKeyFromPaymentAddressResult{},
+	},
+
+	// delegation
+	createAndSendDelegationTransaction: {
+		Description: "build, sign and broadcast a request to delegate PRV to a validator, undelegate it or move it to another validator",
+		Params:      createDelegationTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendDelegationCommissionTx: {
+		Description: "build, sign and broadcast a request setting the share of the reward of its delegators a validator keeps",
+		Params:      createDelegationCommissionTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	getDelegations: {
+		Description: "PRV delegated to a validator by each delegator and its commission at the beacon best state",
+		Params:      getDelegationsParams{},
+		Result:      jsonresult.GetDelegationsResult{},
+	},
+
+	// htlc
+	createAndSendTxWithHTLCLock: {
+		Description: "build, sign and broadcast a request to lock PRV to a hashlock until a timeout in beacon height",
+		Params:      createHTLCLockTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithPTokenHTLCLock: {
+		Description: "build, sign and broadcast a request to lock a token to a hashlock until a timeout in beacon height",
+		Params:      createPTokenHTLCLockTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithHTLCClaim: {
+		Description: "build, sign and broadcast a claim of an htlc with the preimage of its hashlock, paid out to its receiver",
+		Params:      createHTLCSettleTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithHTLCRefund: {
+		Description: "build, sign and broadcast a refund of an htlc from its timeout on, paid out to its sender",
+		Params:      createHTLCSettleTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	getHTLC: {
+		Description: "htlc of a lock tx at the beacon best state, with its preimage once claimed",
+		Params:      getHTLCParams{},
+		Result:      jsonresult.GetHTLCResult{},
+	},
+
+	// pde
+	createAndSendTxWithPRVCrossPoolTradeReq: {
+		Description: "trade PRV for a token through the pde, crossing pools when needed",
+		Params:      createAndSendTxWithPRVCrossPoolTradeReqParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithPTokenCrossPoolTradeReq: {
+		Description: "trade a token for PRV or another token through the pde, crossing pools when needed",
+		Params:      createAndSendTxWithPTokenCrossPoolTradeReqParams{},
+		Result:      jsonresult.CreateTransactionTokenResult{},
+	},
+	createAndSendTxWithPRVContribution: {
+		Description: "contribute PRV to a pde pool pair",
+		Params:      createPRVContributionTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithPTokenContribution: {
+		Description: "contribute a token to a pde pool pair",
+		Params:      createPTokenContributionTxParams{},
+		Result:      jsonresult.CreateTransactionTokenResult{},
+	},
+	createAndSendTxWithPRVContributionV2: {
+		Description: "contribute PRV to a pde pool pair, with decimal string amounts",
+		Params:      createPRVContributionTxParamsV2{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithPTokenContributionV2: {
+		Description: "contribute a token to a pde pool pair, with decimal string amounts",
+		Params:      createPTokenContributionTxParamsV2{},
+		Result:      jsonresult.CreateTransactionTokenResult{},
+	},
+	createAndSendTxWithPRVTradeReq: {
+		Description: "trade PRV for a token through a single pde pool",
+		Params:      createPRVTradeTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithPTokenTradeReq: {
+		Description: "trade a token for PRV through a single pde pool",
+		Params:      createPTokenTradeTxParams{},
+		Result:      jsonresult.CreateTransactionTokenResult{},
+	},
+	createAndSendTxWithWithdrawalReq: {
+		Description: "withdraw shares of a pde pool pair",
+		Params:      createPDEWithdrawalTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithWithdrawalReqV2: {
+		Description: "withdraw shares of a pde pool pair, with a decimal string amount",
+		Params:      createPDEWithdrawalTxParamsV2{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithPDEFeeWithdrawalReq: {
+		Description: "withdraw trading fees earned by a contributor of a pde pool pair",
+		Params:      createPDEFeeWithdrawalTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	getPDEState: {
+		Description: "pools, shares, trading fees and waiting contributions of the pde at a beacon block",
+		Params:      getPDEStateParams{},
+	},
+	convertNativeTokenToPrivacyToken: {
+		Description: "amount of a token worth an amount of PRV in the pde at a beacon height",
+		Params:      convertNativeTokenToPrivacyTokenParams{},
+		Result:      float64(0),
+	},
+	convertPrivacyTokenToNativeToken: {
+		Description: "amount of PRV worth an amount of a token in the pde at a beacon height",
+		Params:      convertPrivacyTokenToNativeTokenParams{},
+		Result:      float64(0),
+	},
+	getPDEContributionStatus: {
+		Description: "status of a contribution pair",
+		Params:      getPDEContributionStatusParams{},
+		Result:      byte(0),
+	},
+	getPDEContributionStatusV2: {
+		Description: "status of a contribution pair with its contributed and returned amounts",
+		Params:      getPDEContributionStatusParams{},
+		Result:      metadata.PDEContributionStatus{},
+	},
+	getPDETradeStatus: {
+		Description: "status of a trade request",
+		Params:      getPDERequestStatusParams{},
+		Result:      byte(0),
+	},
+	getPDEWithdrawalStatus: {
+		Description: "status of a withdrawal request",
+		Params:      getPDERequestStatusParams{},
+		Result:      byte(0),
+	},
+	getPDEFeeWithdrawalStatus: {
+		Description: "status of a fee withdrawal request",
+		Params:      getPDERequestStatusParams{},
+		Result:      byte(0),
+	},
+	convertPDEPrices: {
+		Description: "amount of tokens bought by selling an amount of a token at the best state, against one or every pool",
+		Params:      convertPDEPricesParams{},
+		Result:      []*ConvertedPrice{},
+	},
+	extractPDEInstsFromBeaconBlock: {
+		Description: "contributions, trades and withdrawals accepted in a beacon block",
+		Params:      extractPDEInstsFromBeaconBlockParams{},
+		Result:      PDEInfoFromBeaconBlock{},
+	},
+
+	// general
+	getInOutMessageCount: {
+		Description: "number of inbound and outbound messages by type of a peer, or of every peer",
+		Params:      peerIDParams{},
+		Result:      jsonresult.GetInOutMessageCountResult{},
+	},
+	getInOutMessages: {
+		Description: "inbound and outbound messages by type of a peer, or their number of every peer",
+		Params:      peerIDParams{},
+		Result:      jsonresult.GetInOutMessageResult{},
+	},
+	getAllConnectedPeers: {
+		Description: "peers connected to this node",
+		Params:      noParams{},
+		Result:      jsonresult.GetAllConnectedPeersResult{},
+	},
+	getAllPeers: {
+		Description: "peers known by the address manager",
+		Params:      noParams{},
+		Result:      jsonresult.GetAllPeersResult{},
+	},
+	getNodeRole: {
+		Description: "role of this node in the committees",
+		Params:      noParams{},
+		Result:      "",
+	},
+	getNetworkInfo: {
+		Description: "protocol version, network interfaces and relay fee of this node",
+		Params:      noParams{},
+		Result:      jsonresult.GetNetworkInfoResult{},
+	},
+	checkHashValue: {
+		Description: "whether a hash is a transaction, a shard block or a beacon block",
+		Params:      checkHashValueParams{},
+		Result:      jsonresult.HashValueDetail{},
+	},
+	getConnectionCount: {
+		Description: "number of connections to other nodes",
+		Params:      noParams{},
+		Result:      0,
+	},
+	getActiveShards: {
+		Description: "number of active shards",
+		Params:      noParams{},
+		Result:      0,
+	},
+	getMaxShardsNumber: {
+		Description: "maximum number of shards",
+		Params:      noParams{},
+		Result:      0,
+	},
+	getStackingAmount: {
+		Description: "PRV to stake for a validator",
+		Params:      getStakingAmountParams{},
+		Result:      uint64(0),
+	},
+	hashToIdenticon: {
+		Description: "identicon images of hashes, base64 encoded",
+		Params:      hashToIdenticonParams{},
+		Result:      []string{},
+	},
+	getPublickeyMining: {
+		Description: "mining public keys of this node",
+		Params:      noParams{},
+		Result:      []string{},
+	},
+	generateTokenID: {
+		Description: "id of a token bridged from another network",
+		Params:      generateTokenIDParams{},
+		Result:      "",
+	},
+
+	// mining
+	getMiningInfo: {
+		Description: "mining status, role and mempool size of this node",
+		Params:      noParams{},
+		Result:      jsonresult.GetMiningInfoResult{},
+	},
+	enableMining: {
+		Description: "start or stop mining with the keys of this node",
+		Params:      enableMiningParams{},
+	},
+	getChainMiningStatus: {
+		Description: "mining status of this node on a shard or beacon",
+		Params:      getChainMiningStatusParams{},
+		Result:      "",
+	},
+	getPublicKeyRole: {
+		Description: "role of a consensus public key, -1: not staked, 0: candidate, 1: committee",
+		Params:      miningKeyParams{},
+		Result: struct {
+			Role    int
+			ShardID int
+		}{},
+	},
+	getRoleByValidatorKey: {
+		Description: "role of the consensus public key of a validator key, -1: not staked, 0: candidate, 1: committee",
+		Params:      validatorKeyParams{},
+		Result: struct {
+			Role    int
+			ShardID int
+		}{},
+	},
+	getIncognitoPublicKeyRole: {
+		Description: "role of an incognito public key, -1: not staked, 0: candidate, 1: pending, 2: committee",
+		Params:      incognitoPublicKeyParams{},
+		Result: struct {
+			Role     int
+			IsBeacon bool
+			ShardID  int
+		}{},
+	},
+	getMinerRewardFromMiningKey: {
+		Description: "reward of a miner by token id",
+		Params:      miningKeyParams{},
+		Result:      map[string]uint64{},
+	},
+	getProducersBlackList: {
+		Description: "not supported anymore, always null",
+		Params:      noParams{},
+	},
+	getProducersBlackListDetail: {
+		Description: "not supported anymore, always null",
+		Params:      noParams{},
+	},
+
+	// pool
+	getBeaconPoolInfo: {
+		Description: "beacon blocks waiting in the pool",
+		Params:      noParams{},
+		Result:      jsonresult.PoolInfo{},
+	},
+	getShardToBeaconPoolInfo: {
+		Description: "shard to beacon blocks waiting in the pool",
+		Params:      noParams{},
+		Result:      jsonresult.PoolInfo{},
+	},
+	getShardPoolInfo: {
+		Description: "shard blocks waiting in the pool",
+		Params:      shardIDParams{},
+		Result:      jsonresult.PoolInfo{},
+	},
+	getCrossShardPoolInfo: {
+		Description: "cross shard blocks to a shard waiting in the pool",
+		Params:      shardIDParams{},
+		Result:      jsonresult.PoolInfo{},
+	},
+	getAllView: {
+		Description: "latest blocks on chain followed by the blocks of every view in the pool",
+		Params:      getAllViewParams{},
+		Result:      []jsonresult.GetViewResult{},
+	},
+	getAllViewDetail: {
+		Description: "views of a shard or beacon",
+		Params:      shardIDParams{},
+		Result:      []jsonresult.GetViewResult{},
+	},
+
+	// mempool
+	getMempoolInfo: {
+		Description: "size and transactions of the mempool",
+		Params:      noParams{},
+		Result:      jsonresult.GetMempoolInfo{},
+	},
+	getRawMempool: {
+		Description: "hashes of the transactions in the mempool",
+		Params:      noParams{},
+		Result:      jsonresult.GetRawMempoolResult{},
+	},
+	getNumberOfTxsInMempool: {
+		Description: "number of transactions in the mempool",
+		Params:      noParams{},
+		Result:      0,
+	},
+	getMempoolEntry: {
+		Description: "transaction in the mempool by hash",
+		Params:      txHashParams{},
+		Result:      jsonresult.TransactionDetail{},
+	},
+	removeTxInMempool: {
+		Description: "remove transactions from the mempool, whether each one was removed",
+		Params:      removeTxInMempoolParams{},
+		Result:      []bool{},
+	},
+	getPendingTxsInBlockgen: {
+		Description: "transactions waiting in blockgen",
+		Params:      noParams{},
+		Result:      jsonresult.GetPendingTxsInBlockgenResult{},
+	},
+
+	// node
+	startProfiling: {
+		Description: "start a cpu profile of this node",
+		Params:      noParams{},
+	},
+	stopProfiling: {
+		Description: "stop the cpu profile of this node",
+		Params:      noParams{},
+	},
+	exportMetrics: {
+		Description: "metrics of this node",
+		Params:      noParams{},
+	},
+	setBackup: {
+		Description: "enable or disable the backup of the beacon database",
+		Params:      setBackupParams{},
+		Result:      false,
+	},
+	getLatestBackup: {
+		Description: "latest backup epoch of a chain",
+		Params:      getLatestBackupParams{},
+		Result: struct {
+			LatestEpoch int
+		}{},
+	},
+	downloadBackup: {
+		Description: "stream a file of a backup as application/octet-stream, invalid params are answered in json",
+		Params:      downloadBackupParams{},
+	},
+
+	// testing
+	testHttpServer: {
+		Description: "always null, to check the http server",
+		Params:      noParams{},
+	},
+	unlockMempool: {
+		Description: "send the transactions of the mempool to blockgen",
+		Params:      noParams{},
+	},
+	getAutoStakingByHeight: {
+		Description: "consensus state root hash at a beacon height",
+		Params:      beaconHeightParams{},
+	},
+	getRewardAmountByEpoch: {
+		Description: "PRV reward of a shard in an epoch",
+		Params:      getRewardAmountByEpochParams{},
+		Result:      uint64(0),
+	},
+	getAndSendTxsFromFile: {
+		Description: "benchmark: add or broadcast the transactions of a file in bin",
+		Params:      getAndSendTxsFromFileParams{},
+		Result:      CountResult{},
+	},
+	getAndSendTxsFromFileV2: {
+		Description: "benchmark: add or broadcast the transactions of the files in utility",
+		Params:      getAndSendTxsFromFileParams{},
+		Result:      CountResult{},
+	},
+
+	// reward
+	CreateRawWithDrawTransaction: {
+		Description: "build, sign and broadcast a request to pay out the reward of a private key in a token",
+		Params:      createWithdrawRewardTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	getRewardAmount: {
+		Description: "reward of a payment address by token",
+		Params:      paymentAddressParams{},
+		Result:      map[string]uint64{},
+	},
+	getRewardAmountByPublicKey: {
+		Description: "reward of a public key by token",
+		Params:      incognitoPublicKeyParams{},
+		Result:      map[string]uint64{},
+	},
+	listRewardAmount: {
+		Description: "reward of every committee public key by token",
+		Params:      noParams{},
+		Result:      map[string]map[string]uint64{},
+	},
+
+	// bridge
+	createIssuingRequest: {
+		Description: "build and sign a request to issue a centralized bridge token without sending it",
+		Params:      createIssuingTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	sendIssuingRequest: {
+		Description: "send an issuing request built by createissuingrequest",
+		Params:      sendRawTransactionParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendIssuingRequest: {
+		Description: "build, sign and broadcast a request to issue a centralized bridge token",
+		Params:      createIssuingTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendContractingRequest: {
+		Description: "build, sign and broadcast a request burning a centralized bridge token to withdraw it",
+		Params:      createContractingTxParams{},
+		Result:      jsonresult.CreateTransactionTokenResult{},
+	},
+	createAndSendTxWithIssuingETHReq: {
+		Description: "build, sign and broadcast a request to shield an ethereum deposit",
+		Params:      createIssuingETHTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendBurningRequest: {
+		Description: "build, sign and broadcast a request burning a token to unshield it to ethereum",
+		Params:      createBurningTxParams{},
+		Result:      jsonresult.CreateTransactionTokenResult{},
+	},
+	createAndSendBurningForDepositToSCRequest: {
+		Description: "build, sign and broadcast a request burning a token to deposit it to the ethereum smart contract",
+		Params:      createBurningTxParams{},
+		Result:      jsonresult.CreateTransactionTokenResult{},
+	},
+	checkETHHashIssued: {
+		Description: "whether an ethereum deposit is already shielded",
+		Params:      checkETHHashIssuedParams{},
+		Result:      false,
+	},
+	getAllBridgeTokens: {
+		Description: "bridge tokens with their amount",
+		Params:      noParams{},
+	},
+	getETHHeaderByHash: {
+		Description: "header of an ethereum block, read from the ethereum node of the incognito node",
+		Params:      ethBlockHashParams{},
+	},
+	getBridgeReqWithStatus: {
+		Description: "status of a shielding request: 0 not found, 1 processing, 2 accepted, 3 rejected",
+		Params:      getBridgeReqWithStatusParams{},
+		Result:      0,
+	},
+	getBurningAddress: {
+		Description: "burning address at a beacon height",
+		Params:      getBurningAddressParams{},
+		Result:      "",
+	},
+	getBurnProof: {
+		Description: "proof of a burning request for the ethereum vault",
+		Params:      txHashParams{},
+		Result:      jsonresult.GetInstructionProof{},
+	},
+	getBurnProofForDepositToSC: {
+		Description: "proof of a burning request for the ethereum smart contract",
+		Params:      txHashParams{},
+		Result:      jsonresult.GetInstructionProof{},
+	},
+	getBeaconSwapProof: {
+		Description: "proof of the beacon committee swap at a beacon height",
+		Params:      beaconHeightParams{},
+		Result:      jsonresult.GetInstructionProof{},
+	},
+	getLatestBeaconSwapProof: {
+		Description: "proof of the latest beacon committee swap",
+		Params:      noParams{},
+		Result:      jsonresult.GetInstructionProof{},
+	},
+	getBridgeSwapProof: {
+		Description: "proof of the bridge committee swap at a beacon height",
+		Params:      beaconHeightParams{},
+		Result:      jsonresult.GetInstructionProof{},
+	},
+	getLatestBridgeSwapProof: {
+		Description: "proof of the latest bridge committee swap",
+		Params:      noParams{},
+		Result:      jsonresult.GetInstructionProof{},
+	},
+
+	// relaying
+	createAndSendTxWithRelayingBNBHeader: {
+		Description: "build, sign and broadcast a bnb header relayed to the beacon",
+		Params:      createRelayingHeaderTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithRelayingBTCHeader: {
+		Description: "build, sign and broadcast a btc header relayed to the beacon",
+		Params:      createRelayingHeaderTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	getRelayingBNBHeaderState: {
+		Description: "latest, candidate and orphan bnb blocks relayed to the beacon",
+		Params:      noParams{},
+	},
+	getRelayingBNBHeaderByBlockHeight: {
+		Description: "bnb block relayed to the beacon by height",
+		Params:      getRelayingBNBHeaderByBlockHeightParams{},
+	},
+	getLatestBNBHeaderBlockHeight: {
+		Description: "height of the latest bnb block relayed to the beacon",
+		Params:      noParams{},
+		Result:      int64(0),
+	},
+	getBTCRelayingBestState: {
+		Description: "best state of the btc chain relayed to the beacon",
+		Params:      noParams{},
+	},
+	getBTCBlockByHash: {
+		Description: "btc block relayed to the beacon by hash",
+		Params:      btcBlockHashParams{},
+	},
+
+	// portal
+	createAndSendTxWithCustodianDeposit: {
+		Description: "deposit PRV collateral to become a portal custodian",
+		Params:      createCustodianDepositTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendRegisterPortingPublicTokens: {
+		Description: "request to port a public token, matched with custodians",
+		Params:      createRegisterPortingPublicTokensTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithReqPToken: {
+		Description: "request the ptoken of a porting request with the proof of the transfer to the custodians",
+		Params:      createPortalReqPTokenTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithRedeemReq: {
+		Description: "burn a ptoken to redeem its public token from custodians",
+		Params:      createPortalRedeemTxParams{},
+		Result:      jsonresult.CreateTransactionTokenResult{},
+	},
+	createAndSendTxWithReqMatchingRedeem: {
+		Description: "match a custodian with a waiting redeem request",
+		Params:      createPortalReqMatchingRedeemTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithReqUnlockCollateral: {
+		Description: "unlock the collateral of a custodian with the proof of the transfer to the redeemer",
+		Params:      createPortalReqUnlockCollateralTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendCustodianWithdrawRequest: {
+		Description: "withdraw free collateral of a custodian",
+		Params:      createCustodianWithdrawTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithReqWithdrawRewardPortal: {
+		Description: "withdraw portal rewards of a custodian",
+		Params:      createPortalReqWithdrawRewardTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendPortalExchangeRates: {
+		Description: "feed exchange rates of portal tokens",
+		Params:      createPortalExchangeRatesTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendRedeemLiquidationExchangeRates: {
+		Description: "burn a ptoken to redeem PRV from the liquidation pool",
+		Params:      createRedeemLiquidationExchangeRatesTxParams{},
+		Result:      jsonresult.CreateTransactionTokenResult{},
+	},
+	createAndSendLiquidationCustodianDeposit: {
+		Description: "top up the collateral of a custodian close to liquidation",
+		Params:      createLiquidationCustodianDepositTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTopUpWaitingPorting: {
+		Description: "top up the collateral of a custodian for a waiting porting request",
+		Params:      createTopUpWaitingPortingTxParams{},
+		Result:      jsonresult.CreateTransactionResult{},
+	},
+	getPortalState: {
+		Description: "custodians, waiting requests, exchange rates and liquidation pool of the portal at a beacon block",
+		Params:      getPortalStateParams{},
+	},
+	getPortalCustodianDepositStatus: {
+		Description: "status of a custodian deposit",
+		Params:      getPortalCustodianDepositStatusParams{},
+		Result:      metadata.PortalCustodianDepositStatus{},
+	},
+	getPortalReqPTokenStatus: {
+		Description: "status of a ptoken request",
+		Params:      getPortalReqStatusParams{},
+		Result:      metadata.PortalRequestPTokensStatus{},
+	},
+	getPortalReqRedeemStatus: {
+		Description: "status of a redeem request by redeem id",
+		Params:      getPortalReqRedeemStatusParams{},
+		Result:      metadata.PortalRedeemRequestStatus{},
+	},
+	getPortalReqRedeemByTxIDStatus: {
+		Description: "status of a redeem request by tx hash",
+		Params:      getPortalReqStatusParams{},
+		Result:      metadata.PortalRedeemRequestStatus{},
+	},
+	getReqMatchingRedeemStatus: {
+		Description: "status of a matching redeem request",
+		Params:      getPortalReqStatusParams{},
+		Result:      metadata.PortalReqMatchingRedeemStatus{},
+	},
+	getPortalReqUnlockCollateralStatus: {
+		Description: "status of an unlock collateral request",
+		Params:      getPortalReqStatusParams{},
+		Result:      metadata.PortalRequestUnlockCollateralStatus{},
+	},
+	getCustodianLiquidationStatus: {
+		Description: "liquidation of a custodian failing a redeem request",
+		Params:      getCustodianLiquidationStatusParams{},
+		Result:      metadata.PortalLiquidateCustodianStatus{},
+	},
+	getCustodianWithdrawByTxId: {
+		Description: "custodian withdrawal by tx hash",
+		Params:      getCustodianWithdrawByTxIdParams{},
+		Result:      jsonresult.PortalCustodianWithdrawRequest{},
+	},
+	getPortalReward: {
+		Description: "portal rewards of a custodian by token id at the best state",
+		Params:      getPortalRewardParams{},
+		Result:      map[string]uint64{},
+	},
+	getRequestWithdrawPortalRewardStatus: {
+		Description: "status of a portal reward withdrawal",
+		Params:      getPortalReqStatusParams{},
+		Result:      metadata.PortalRequestWithdrawRewardStatus{},
+	},
+	getRewardFeature: {
+		Description: "rewards of a feature by token id in an epoch",
+		Params:      getRewardFeatureParams{},
+		Result:      map[string]uint64{},
+	},
+	getPortalFinalExchangeRates: {
+		Description: "exchange rates of portal tokens at a beacon height",
+		Params:      getPortalFinalExchangeRatesParams{},
+		Result:      jsonresult.FinalExchangeRatesResult{},
+	},
+	convertExchangeRates: {
+		Description: "value of an amount of a portal token in PRV at a beacon height",
+		Params:      convertExchangeRatesParams{},
+		Result:      map[string]uint64{},
+	},
+	getPortingRequestFees: {
+		Description: "porting fees of an amount of a portal token at a beacon height",
+		Params:      getPortingRequestFeesParams{},
+		Result:      map[string]uint64{},
+	},
+	getPortalPortingRequestByKey: {
+		Description: "porting request by tx hash",
+		Params:      getPortingRequestByKeyParams{},
+		Result:      jsonresult.PortalPortingRequest{},
+	},
+	getPortalPortingRequestByPortingId: {
+		Description: "porting request by porting id",
+		Params:      getPortingRequestByPortingIdParams{},
+		Result:      jsonresult.PortalPortingRequest{},
+	},
+	getLiquidationExchangeRatesPool: {
+		Description: "liquidation pool of an exchange rate token at a beacon height",
+		Params:      getLiquidationExchangeRatesPoolParams{},
+		Result:      jsonresult.GetLiquidateExchangeRates{},
+	},
+	getAmountNeededForCustodianDepositLiquidation: {
+		Description: "collateral a custodian needs to top up to avoid liquidation",
+		Params:      getAmountNeededForCustodianDepositLiquidationParams{},
+		Result:      jsonresult.GetLiquidateAmountNeededCustodianDeposit{},
+	},
+	getPortalCustodianTopupStatus: {
+		Description: "status of a custodian top up",
+		Params:      getPortalCustodianTopupStatusParams{},
+		Result:      metadata.LiquidationCustodianDepositStatusV2{},
+	},
+	getPortalCustodianTopupWaitingPortingStatus: {
+		Description: "status of a top up of a waiting porting request",
+		Params:      getPortalCustodianTopupStatusParams{},
+		Result:      metadata.PortalTopUpWaitingPortingRequestStatus{},
+	},
+	getAmountTopUpWaitingPorting: {
+		Description: "collateral a custodian needs to top up for its waiting porting requests by token id",
+		Params:      getAmountTopUpWaitingPortingParams{},
+		Result:      map[string]uint64{},
+	},
+	getReqRedeemFromLiquidationPoolByTxIDStatus: {
+		Description: "status of a redeem from the liquidation pool",
+		Params:      getPortalReqStatusParams{},
+		Result:      metadata.RedeemLiquidateExchangeRatesStatus{},
+	},
+}
@@ -188,7 +188,7 @@ func (wsServer *WsServer) subscribe(subManager *SubcriptionManager, subRequest *
 	var cResult chan RpcSubResult
 	var closeChan = make(chan struct{})
 	defer func() {
-		close(closeChan)
+		RemoveSubcription(subManager, subRequest, closeChan)
 	}()
 	var jsonErr error
 	request := subRequest.JsonRequest
@@ -257,7 +257,8 @@ func (wsServer *WsServer) unsubscribe(subManager *SubcriptionManager, subRequest
 	}
 	if paramsList, ok := subManager.subRequestList[subRequest.JsonRequest.Method]; ok {
 		if closeCh, ok := paramsList[hash]; ok {
-			closeCh <- struct{}{}
+			// the method may have returned already, closing does not wait for it
+			close(closeCh)
 			delete(paramsList, hash)
 			return
 		} else {
@@ -279,20 +280,27 @@ func (wsServer *WsServer) unsubscribe(subManager *SubcriptionManager, subRequest
 		subManager.wsMtx.Lock()
 		if err := subManager.ws.WriteMessage(msgType, res); err != nil {
 			Logger.log.Errorf("Failed to write reply message: %+v", err)
-			subManager.wsMtx.Unlock()
 		}
 		subManager.wsMtx.Unlock()
 	}
 }
-func RemoveSubcription(subManager *SubcriptionManager, subRequest *SubcriptionRequest) error {
+
+// RemoveSubcription removes the subscription of closeChan when it is still in the list and closes closeChan, unless
+// unsubscribe closed it already. Both close it under subMtx
+func RemoveSubcription(subManager *SubcriptionManager, subRequest *SubcriptionRequest, closeChan chan struct{}) error {
 	subManager.subMtx.Lock()
 	defer subManager.subMtx.Unlock()
+	select {
+	case <-closeChan:
+	default:
+		close(closeChan)
+	}
 	hash, err := common.HashArrayInterface(subRequest.JsonRequest.Params)
 	if err != nil {
 		return err
 	}
 	if paramsList, ok := subManager.subRequestList[subRequest.JsonRequest.Method]; ok {
-		if _, ok := paramsList[hash]; ok {
+		if closeCh, ok := paramsList[hash]; ok && closeCh == closeChan {
 			delete(paramsList, hash)
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
@@ -22,7 +23,12 @@ func (wsServer *WsServer) handleSubcribeCrossOutputCoinByPrivateKey(params inter
 		cResult <- RpcSubResult{Error: err}
 		return
 	}
-	privateKey := arrayParams[0].(string)
+	subscribeParams := wsPrivateKeyParams{}
+	if err := decodeRPCParams(params, &subscribeParams); err != nil {
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	privateKey := subscribeParams.PrivateKey
 	keyWallet, err := wallet.Base58CheckDeserialize(privateKey)
 	if err != nil {
 		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
@@ -56,6 +62,7 @@ func (wsServer *WsServer) handleSubcribeCrossOutputCoinByPrivateKey(params inter
 					continue
 				}
 				m := make(map[byte]uint64)
+				memos := make(map[byte][]string)
 				for senderShardID, crossTransactions := range shardBlock.Body.CrossTransactions {
 					for _, crossTransaction := range crossTransactions {
 						for _, crossOutputCoin := range crossTransaction.OutputCoin {
@@ -72,6 +79,9 @@ func (wsServer *WsServer) handleSubcribeCrossOutputCoinByPrivateKey(params inter
 									m[senderShardID] = processedOutputCoin.CoinDetails.GetValue()
 								}
 							}
+							if memo, err := privacy.DecryptMemo(processedOutputCoin.CoinDetails.GetInfo(), keyWallet.KeySet.ReadonlyKey.Rk); err == nil {
+								memos[senderShardID] = append(memos[senderShardID], string(memo))
+							}
 						}
 					}
 				}
@@ -84,6 +94,7 @@ func (wsServer *WsServer) handleSubcribeCrossOutputCoinByPrivateKey(params inter
 							BlockHash:       shardBlock.Header.Hash().String(),
 							PaymentAddress:  keyWallet.Base58CheckSerialize(wallet.PaymentAddressType),
 							Value:           value,
+							Memos:           memos[senderShardID],
 						}, Error: nil}
 					}
 				}
@@ -104,8 +115,13 @@ func (wsServer *WsServer) handleSubcribeCrossCustomTokenPrivacyByPrivateKey(para
 		cResult <- RpcSubResult{Error: err}
 		return
 	}
-	privateKey, ok := arrayParams[0].(string)
-	if !ok {
+	subscribeParams := wsPrivateKeyParams{}
+	if err := decodeRPCParams(params, &subscribeParams); err != nil {
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	privateKey := subscribeParams.PrivateKey
+	if privateKey == "" {
 		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params is invalid"))
 		cResult <- RpcSubResult{Error: err}
 		return
@@ -143,6 +159,7 @@ func (wsServer *WsServer) handleSubcribeCrossCustomTokenPrivacyByPrivateKey(para
 					continue
 				}
 				m := make(map[byte]map[common.Hash]uint64)
+				memos := make(map[byte]map[common.Hash][]string)
 				for senderShardID, crossTransactions := range shardBlock.Body.CrossTransactions {
 					for _, crossTransaction := range crossTransactions {
 						for _, crossTokenPrivacyData := range crossTransaction.TokenPrivacyData {
@@ -158,6 +175,12 @@ func (wsServer *WsServer) handleSubcribeCrossCustomTokenPrivacyByPrivateKey(para
 									} else {
 										m[senderShardID][crossTokenPrivacyData.PropertyID] = processedOutputCoin.CoinDetails.GetValue()
 									}
+									if memo, err := privacy.DecryptMemo(processedOutputCoin.CoinDetails.GetInfo(), keyWallet.KeySet.ReadonlyKey.Rk); err == nil {
+										if memos[senderShardID] == nil {
+											memos[senderShardID] = make(map[common.Hash][]string)
+										}
+										memos[senderShardID][crossTokenPrivacyData.PropertyID] = append(memos[senderShardID][crossTokenPrivacyData.PropertyID], string(memo))
+									}
 								}
 							}
 						}
@@ -174,6 +197,7 @@ func (wsServer *WsServer) handleSubcribeCrossCustomTokenPrivacyByPrivateKey(para
 								PaymentAddress:  keyWallet.Base58CheckSerialize(wallet.PaymentAddressType),
 								TokenID:         tokenID.String(),
 								Value:           value,
+								Memos:           memos[senderShardID][tokenID],
 							}, Error: nil}
 						}
 					}
@@ -17,7 +17,12 @@ func (wsServer *WsServer) handleSubscribeShardBestState(params interface{}, subc
 		cResult <- RpcSubResult{Error: err}
 		return
 	}
-	shardID := byte(arrayParams[0].(float64))
+	subscribeParams := wsShardParams{}
+	if err := decodeRPCParams(params, &subscribeParams); err != nil {
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	shardID := byte(subscribeParams.ShardID)
 	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.ShardBeststateTopic)
 	if err != nil {
 		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
@@ -19,7 +19,12 @@ func (wsServer *WsServer) handleSubscribeNewShardBlock(params interface{}, subcr
 		cResult <- RpcSubResult{Error: err}
 		return
 	}
-	shardID := byte(arrayParams[0].(float64))
+	subscribeParams := wsShardParams{}
+	if err := decodeRPCParams(params, &subscribeParams); err != nil {
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	shardID := byte(subscribeParams.ShardID)
 	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.NewShardblockTopic)
 	if err != nil {
 		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
@@ -49,6 +54,8 @@ func (wsServer *WsServer) handleSubscribeNewShardBlock(params interface{}, subcr
 					return
 				}
 				blockResult := jsonresult.NewGetBlockResult(shardBlock, uint64(len(blockBytes)), common.EmptyString)
+				confirmations, isFinalized := wsServer.config.BlockChain.GetShardBlockFinality(shardID, shardBlock.Header.Height, *shardBlock.Hash())
+				blockResult.Confirmations, blockResult.IsFinalized = int64(confirmations), isFinalized
 				cResult <- RpcSubResult{Result: blockResult, Error: nil}
 			}
 		case <-closeChan:
@@ -94,6 +101,8 @@ func (wsServer *WsServer) handleSubscribeNewBeaconBlock(params interface{}, subc
 					return
 				}
 				blockBeaconResult := jsonresult.NewGetBlocksBeaconResult(beaconBlock, uint64(len(blockBytes)), common.EmptyString)
+				confirmations, isFinalized := wsServer.config.BlockChain.GetBeaconBlockFinality(beaconBlock.Header.Height, *beaconBlock.Hash())
+				blockBeaconResult.Confirmations, blockBeaconResult.IsFinalized = int64(confirmations), isFinalized
 				cResult <- RpcSubResult{Result: blockBeaconResult, Error: nil}
 			}
 		case <-closeChan:
@@ -104,3 +113,114 @@ func (wsServer *WsServer) handleSubscribeNewBeaconBlock(params interface{}, subc
 		}
 	}
 }
+
+// handleSubscribeFinalizedShardBlock - send the blocks of a shard when they become final, lowest first
+func (wsServer *WsServer) handleSubscribeFinalizedShardBlock(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	Logger.log.Info("Handle Subscribe Finalized Shard Block", params, subcription)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain 1 params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	subscribeParams := wsShardParams{}
+	if err := decodeRPCParams(params, &subscribeParams); err != nil {
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	shardIDParam := subscribeParams.ShardID
+	if shardIDParam < 0 || shardIDParam >= wsServer.config.BlockChain.GetActiveShardNumber() {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Shard ID"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	shardID := byte(shardIDParam)
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.FinalizedShardBlockTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Finalized Shard Block ShardID ", shardID)
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.FinalizedShardBlockTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				shardBlock, ok := msg.Value.(*blockchain.ShardBlock)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.ShardBlock, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				if shardBlock.Header.ShardID != shardID {
+					continue
+				}
+				blockBytes, err := json.Marshal(shardBlock)
+				if err != nil {
+					cResult <- RpcSubResult{Error: rpcservice.NewRPCError(rpcservice.UnexpectedError, err)}
+					return
+				}
+				blockResult := jsonresult.NewGetBlockResult(shardBlock, uint64(len(blockBytes)), common.EmptyString)
+				confirmations, isFinalized := wsServer.config.BlockChain.GetShardBlockFinality(shardID, shardBlock.Header.Height, *shardBlock.Hash())
+				blockResult.Confirmations, blockResult.IsFinalized = int64(confirmations), isFinalized
+				cResult <- RpcSubResult{Result: blockResult, Error: nil}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Finalized Shard Block"}}
+				return
+			}
+		}
+	}
+}
+
+// handleSubscribeFinalizedBeaconBlock - send the beacon blocks when they become final, lowest first
+func (wsServer *WsServer) handleSubscribeFinalizedBeaconBlock(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	Logger.log.Info("Handle Subscribe Finalized Beacon Block", params, subcription)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 0 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain NO params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.FinalizedBeaconBlockTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Finalized Beacon Block")
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.FinalizedBeaconBlockTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				beaconBlock, ok := msg.Value.(*blockchain.BeaconBlock)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.BeaconBlock, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				blockBytes, err := json.Marshal(beaconBlock)
+				if err != nil {
+					cResult <- RpcSubResult{Error: rpcservice.NewRPCError(rpcservice.UnexpectedError, err)}
+					return
+				}
+				blockBeaconResult := jsonresult.NewGetBlocksBeaconResult(beaconBlock, uint64(len(blockBytes)), common.EmptyString)
+				confirmations, isFinalized := wsServer.config.BlockChain.GetBeaconBlockFinality(beaconBlock.Header.Height, *beaconBlock.Hash())
+				blockBeaconResult.Confirmations, blockBeaconResult.IsFinalized = int64(confirmations), isFinalized
+				cResult <- RpcSubResult{Result: blockBeaconResult, Error: nil}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Finalized Beacon Block"}}
+				return
+			}
+		}
+	}
+}
@@ -20,10 +20,16 @@ func (wsServer *WsServer) handleSubcribeShardCandidateByPublickey(params interfa
 		cResult <- RpcSubResult{Error: err}
 		return
 	}
-	candidate, ok := arrayParams[0].(string)
-	if !ok {
+	subscribeParams := wsPublicKeyParams{}
+	if err := decodeRPCParams(params, &subscribeParams); err != nil {
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	candidate := subscribeParams.PublicKey
+	if candidate == "" {
 		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Public Key"))
 		cResult <- RpcSubResult{Error: err}
+		return
 	}
 	// try to get candidate from beacon beststate
 	candidates, err := incognitokey.CommitteeKeyListToString(wsServer.config.BlockChain.GetBeaconBestState().GetShardCandidate())
@@ -82,10 +88,16 @@ func (wsServer *WsServer) handleSubcribeShardPendingValidatorByPublickey(params
 		cResult <- RpcSubResult{Error: err}
 		return
 	}
-	validator, ok := arrayParams[0].(string)
-	if !ok {
+	subscribeParams := wsPublicKeyParams{}
+	if err := decodeRPCParams(params, &subscribeParams); err != nil {
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	validator := subscribeParams.PublicKey
+	if validator == "" {
 		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Public Key"))
 		cResult <- RpcSubResult{Error: err}
+		return
 	}
 	// try to get validator from beacon beststate
 	var err error
@@ -154,10 +166,16 @@ func (wsServer *WsServer) handleSubcribeShardCommitteeByPublickey(params interfa
 		cResult <- RpcSubResult{Error: err}
 		return
 	}
-	committee, ok := arrayParams[0].(string)
-	if !ok {
+	subscribeParams := wsPublicKeyParams{}
+	if err := decodeRPCParams(params, &subscribeParams); err != nil {
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	committee := subscribeParams.PublicKey
+	if committee == "" {
 		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Public Key"))
 		cResult <- RpcSubResult{Error: err}
+		return
 	}
 	// try to get committee from beacon beststate
 	allCommittees := make(map[byte][]string)
@@ -226,10 +244,16 @@ func (wsServer *WsServer) handleSubcribeBeaconCandidateByPublickey(params interf
 		cResult <- RpcSubResult{Error: err}
 		return
 	}
-	candidate, ok := arrayParams[0].(string)
-	if !ok {
+	subscribeParams := wsPublicKeyParams{}
+	if err := decodeRPCParams(params, &subscribeParams); err != nil {
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	candidate := subscribeParams.PublicKey
+	if candidate == "" {
 		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Public Key"))
 		cResult <- RpcSubResult{Error: err}
+		return
 	}
 	// try to get candidate from beacon beststate
 	candidates, err := incognitokey.CommitteeKeyListToString(wsServer.config.BlockChain.GetBeaconBestState().GetBeaconCandidate())
@@ -286,10 +310,16 @@ func (wsServer *WsServer) handleSubcribeBeaconPendingValidatorByPublickey(params
 		cResult <- RpcSubResult{Error: err}
 		return
 	}
-	validator, ok := arrayParams[0].(string)
-	if !ok {
+	subscribeParams := wsPublicKeyParams{}
+	if err := decodeRPCParams(params, &subscribeParams); err != nil {
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	validator := subscribeParams.PublicKey
+	if validator == "" {
 		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Public Key"))
 		cResult <- RpcSubResult{Error: err}
+		return
 	}
 	// try to get validator from beacon beststate
 	validators, err := incognitokey.CommitteeKeyListToString(wsServer.config.BlockChain.GetBeaconBestState().GetBeaconPendingValidator())
@@ -346,10 +376,16 @@ func (wsServer *WsServer) handleSubcribeBeaconCommitteeByPublickey(params interf
 		cResult <- RpcSubResult{Error: err}
 		return
 	}
-	committee, ok := arrayParams[0].(string)
-	if !ok {
+	subscribeParams := wsPublicKeyParams{}
+	if err := decodeRPCParams(params, &subscribeParams); err != nil {
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	committee := subscribeParams.PublicKey
+	if committee == "" {
 		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Public Key"))
 		cResult <- RpcSubResult{Error: err}
+		return
 	}
 	// try to get candidate from beacon beststate
 	committees, err := incognitokey.CommitteeKeyListToString(wsServer.config.BlockChain.GetBeaconBestState().GetBeaconCommittee())
@@ -18,8 +18,13 @@ func (wsServer *WsServer) handleSubscribePendingTransaction(params interface{},
 		cResult <- RpcSubResult{Error: err}
 		return
 	}
-	txHashTemp, ok := arrayParams[0].(string)
-	if !ok || txHashTemp == "" {
+	subscribeParams := wsTxHashParams{}
+	if err := decodeRPCParams(params, &subscribeParams); err != nil {
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	txHashTemp := subscribeParams.TxHash
+	if txHashTemp == "" {
 		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Tx Hash"))
 		cResult <- RpcSubResult{Error: err}
 		return
@@ -36,7 +41,13 @@ func (wsServer *WsServer) handleSubscribePendingTransaction(params interface{},
 		shardBlock, _, err := wsServer.config.BlockChain.GetShardBlockByHash(blockHash)
 		if err == nil {
 			res, err := jsonresult.NewTransactionDetail(tx, shardBlock.Hash(), shardBlock.Header.Height, index, shardBlock.Header.ShardID)
-			cResult <- RpcSubResult{Result: res, Error: rpcservice.NewRPCError(rpcservice.UnexpectedError, err)}
+			if err != nil {
+				cResult <- RpcSubResult{Result: res, Error: rpcservice.NewRPCError(rpcservice.UnexpectedError, err)}
+			} else {
+				res.IsInBlock = true
+				res.Confirmations, res.IsFinalized = wsServer.config.BlockChain.GetShardBlockFinality(shardBlock.Header.ShardID, shardBlock.Header.Height, *shardBlock.Hash())
+				cResult <- RpcSubResult{Result: res, Error: nil}
+			}
 			return
 		}
 	}
@@ -67,6 +78,8 @@ func (wsServer *WsServer) handleSubscribePendingTransaction(params interface{},
 						if err != nil {
 							cResult <- RpcSubResult{Result: res, Error: rpcservice.NewRPCError(rpcservice.UnexpectedError, err)}
 						} else {
+							res.IsInBlock = true
+							res.Confirmations, res.IsFinalized = wsServer.config.BlockChain.GetShardBlockFinality(shardBlock.Header.ShardID, shardBlock.Header.Height, *shardBlock.Hash())
 							cResult <- RpcSubResult{Result: res, Error: nil}
 						}
 						return
@@ -81,3 +94,81 @@ func (wsServer *WsServer) handleSubscribePendingTransaction(params interface{},
 		}
 	}
 }
+
+// handleSubscribeCrossShardTxStatus - send the status of the outputs of a transaction sent to other shards,
+// then again every time it changes, until it is finalized
+func (wsServer *WsServer) handleSubscribeCrossShardTxStatus(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain 1 params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	subscribeParams := wsTxHashParams{}
+	if err := decodeRPCParams(params, &subscribeParams); err != nil {
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	txHashTemp := subscribeParams.TxHash
+	if txHashTemp == "" {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Tx Hash"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	txHash, err := common.Hash{}.NewHashFromStr(txHashTemp)
+	if err != nil {
+		err1 := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		cResult <- RpcSubResult{Error: err1}
+		return
+	}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.NewShardblockTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Cross Shard Tx Status ", txHashTemp)
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.NewShardblockTopic, subId)
+		close(cResult)
+	}()
+	var lastStatus *jsonresult.CrossShardTxStatus
+	// send the status when it changes, stop when it is final
+	notify := func() bool {
+		status := &jsonresult.CrossShardTxStatus{
+			TxID:     txHash.String(),
+			Status:   blockchain.CrossShardTxPending,
+			Receipts: []jsonresult.CrossShardTxReceipt{},
+		}
+		if crossShardTxStatus, err := wsServer.config.BlockChain.GetCrossShardTxStatus(*txHash); err == nil {
+			status = jsonresult.NewCrossShardTxStatus(crossShardTxStatus)
+		}
+		if !reflect.DeepEqual(status, lastStatus) {
+			cResult <- RpcSubResult{Result: status, Error: nil}
+			lastStatus = status
+		}
+		return status.Status == blockchain.CrossShardTxFinalized
+	}
+	if notify() {
+		return
+	}
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				if _, ok := msg.Value.(*blockchain.ShardBlock); !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.ShardBlock, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				if notify() {
+					return
+				}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Cross Shard Tx Status " + txHashTemp}}
+				return
+			}
+		}
+	}
+}
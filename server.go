@@ -37,6 +37,7 @@ import (
 	"github.com/incognitochain/incognito-chain/connmanager"
 	"github.com/incognitochain/incognito-chain/consensus"
 	"github.com/incognitochain/incognito-chain/databasemp"
+	"github.com/incognitochain/incognito-chain/devnet"
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/memcache"
@@ -48,6 +49,7 @@ import (
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 	"github.com/incognitochain/incognito-chain/rpcserver"
 	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/txrelay"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/incognitochain/incognito-chain/wire"
 	libp2p "github.com/libp2p/go-libp2p-peer"
@@ -78,6 +80,7 @@ type Server struct {
 	// userKeySet        *incognitokey.KeySet
 	miningKeys      string
 	privateKey      string
+	remoteSigner    string
 	wallet          *wallet.Wallet
 	consensusEngine *consensus.Engine
 	blockgen        *blockchain.BlockGenerator
@@ -86,6 +89,10 @@ type Server struct {
 	// the mempool before they are mined into blocks.
 	feeEstimator map[byte]*mempool.FeeEstimator
 	highway      *peerv2.ConnManager
+	stemRelay    *peerv2.StemRelay
+	txRelay      *txrelay.TxRelay
+	// devnet runs the validators of all chains in this process, it replaces highway
+	devnet *devnet.Devnet
 
 	cQuit     chan struct{}
 	cNewPeers chan *peer.Peer
@@ -225,7 +232,8 @@ func (serverObj *Server) NewServer(
 
 	serverObj.miningKeys = cfg.MiningKeys
 	serverObj.privateKey = cfg.PrivateKey
-	if serverObj.miningKeys == "" && serverObj.privateKey == "" {
+	serverObj.remoteSigner = cfg.RemoteSigner
+	if serverObj.miningKeys == "" && serverObj.privateKey == "" && serverObj.remoteSigner == "" {
 		if cfg.NodeMode == common.NodeModeAuto || cfg.NodeMode == common.NodeModeBeacon || cfg.NodeMode == common.NodeModeShard {
 			panic("miningkeys can't be empty in this node mode")
 		}
@@ -302,6 +310,9 @@ func (serverObj *Server) NewServer(
 		},
 		BC: serverObj.blockChain,
 	}
+	if serverObj.devnet != nil {
+		serverObj.devnet.Init(serverObj.blockChain, dispatcher)
+	}
 	monitor.SetBlockChainObj(serverObj.blockChain)
 	monitor.SetGlobalParam("Bootnode", cfg.DiscoverPeersAddress)
 	monitor.SetGlobalParam("ExternalAddress", cfg.ExternalAddress)
@@ -315,6 +326,23 @@ func (serverObj *Server) NewServer(
 		cfg.NodeMode,
 		relayShards,
 	)
+	serverObj.stemRelay, err = peerv2.NewStemRelay(host, cfg.StemPeers, serverObj.OnStemTx)
+	if err != nil {
+		return err
+	}
+	serverObj.txRelay, err = txrelay.New(txrelay.Config{
+		FluffProbability: cfg.StemFluffProbability,
+		Epoch:            cfg.StemEpoch,
+		Embargo:          cfg.StemEmbargo,
+		FailSafe:         !cfg.DisableStemFailSafe,
+		StemPeers:        serverObj.stemRelay.Peers,
+		Stem:             serverObj.pushTransactionToStemPeer,
+		Fluff:            serverObj.fluffTransaction,
+		IsStem:           serverObj.memPool.IsStemTransaction,
+	})
+	if err != nil {
+		return err
+	}
 
 	err = serverObj.blockChain.Init(&blockchain.Config{
 		BTCChain:      btcChain,
@@ -336,6 +364,8 @@ func (serverObj *Server) NewServer(
 		ConsensusEngine: serverObj.consensusEngine,
 		Highway:         serverObj.highway,
 		GenesisParams:   blockchain.GenesisParam,
+		Archive:         cfg.Archive,
+		PruneEpochs:     cfg.PruneEpochs,
 	})
 	if err != nil {
 		return err
@@ -412,6 +442,7 @@ func (serverObj *Server) NewServer(
 		DataBaseMempool:   dbmp,
 		IsLoadFromMempool: cfg.LoadMempool,
 		PersistMempool:    cfg.PersistMempool,
+		RebroadcastBlocks: cfg.MempoolRebroadcastBlocks,
 		RelayShards:       relayShards,
 		// UserKeyset:        serverObj.userKeySet,
 		PubSubManager: serverObj.pusubManager,
@@ -545,6 +576,8 @@ func (serverObj *Server) NewServer(
 			ConsensusEngine:             serverObj.consensusEngine,
 			MemCache:                    serverObj.memCache,
 			Syncker:                     serverObj.syncker,
+			TxRelay:                     serverObj.txRelay,
+			TxRelayMode:                 cfg.TxRelayMode,
 		}
 		serverObj.rpcServer = &rpcserver.RpcServer{}
 		serverObj.rpcServer.Init(&rpcConfig)
@@ -627,6 +660,10 @@ func (serverObj *Server) Stop() error {
 		}
 	}
 
+	if serverObj.devnet != nil {
+		serverObj.devnet.Stop()
+	}
+	serverObj.txRelay.Stop()
 	err := serverObj.consensusEngine.Stop()
 	if err != nil {
 		Logger.log.Error(err)
@@ -710,7 +747,9 @@ func (serverObj Server) Start() {
 
 	serverObj.netSync.Start()
 
-	go serverObj.highway.Start(serverObj.netSync)
+	if serverObj.devnet == nil {
+		go serverObj.highway.Start(serverObj.netSync)
+	}
 
 	if !cfg.DisableRPC && serverObj.rpcServer != nil {
 		serverObj.waitGroup.Add(1)
@@ -722,7 +761,7 @@ func (serverObj Server) Start() {
 		serverObj.rpcServer.Start()
 	}
 
-	if cfg.NodeMode != common.NodeModeRelay {
+	if cfg.NodeMode != common.NodeModeRelay || serverObj.devnet != nil {
 		serverObj.memPool.IsBlockGenStarted = true
 		serverObj.blockChain.SetIsBlockGenStarted(true)
 		// for _, shardPool := range serverObj.shardPool {
@@ -740,12 +779,23 @@ func (serverObj Server) Start() {
 		if err != nil {
 			Logger.log.Error(err)
 		}
-		go serverObj.TransactionPoolBroadcastLoop()
+		if serverObj.devnet == nil {
+			go serverObj.TransactionPoolBroadcastLoop()
+			if cfg.MempoolRebroadcastBlocks > 0 {
+				go serverObj.TransactionRebroadcastLoop()
+			}
+		}
 		go serverObj.memPool.Start(serverObj.cQuit)
 		go serverObj.memPool.MonitorPool()
 	}
 	go serverObj.pusubManager.Start()
 
+	if serverObj.devnet != nil {
+		if err := serverObj.devnet.Start(); err != nil {
+			Logger.log.Error(err)
+		}
+		return
+	}
 	err := serverObj.consensusEngine.Start()
 	if err != nil {
 		Logger.log.Error(err)
@@ -775,41 +825,91 @@ func (serverObj *Server) TransactionPoolBroadcastLoop() {
 
 		for _, txDesc := range txDescs {
 			time.Sleep(50 * time.Millisecond)
-			if !txDesc.IsFowardMessage {
+			if !txDesc.IsFowardMessage && !txDesc.IsStem {
 				tx := txDesc.Desc.Tx
-				switch tx.GetType() {
-				case common.TxNormalType:
-					{
-						txMsg, err := wire.MakeEmptyMessage(wire.CmdTx)
-						if err != nil {
-							continue
-						}
-						normalTx := tx.(*transaction.Tx)
-						txMsg.(*wire.MessageTx).Transaction = normalTx
-						err = serverObj.PushMessageToAll(txMsg)
-						if err == nil {
-							serverObj.memPool.MarkForwardedTransaction(*tx.Hash())
-						}
-					}
-				case common.TxCustomTokenPrivacyType:
-					{
-						txMsg, err := wire.MakeEmptyMessage(wire.CmdPrivacyCustomToken)
-						if err != nil {
-							continue
-						}
-						customPrivacyTokenTx := tx.(*transaction.TxCustomTokenPrivacy)
-						txMsg.(*wire.MessageTxPrivacyToken).Transaction = customPrivacyTokenTx
-						err = serverObj.PushMessageToAll(txMsg)
-						if err == nil {
-							serverObj.memPool.MarkForwardedTransaction(*tx.Hash())
-						}
-					}
+				err := serverObj.pushTransactionToAll(tx)
+				if err == nil {
+					serverObj.memPool.MarkForwardedTransaction(*tx.Hash())
 				}
 			}
 		}
 	}
 }
 
+// TransactionRebroadcastLoop - relay again the local transactions of the mempool which are still not in a block
+// some blocks after they were relayed
+func (serverObj *Server) TransactionRebroadcastLoop() {
+	_, subChan, err := serverObj.pusubManager.RegisterNewSubscriber(pubsub.NewShardblockTopic)
+	if err != nil {
+		Logger.log.Error(err)
+		return
+	}
+	for msg := range subChan {
+		shardBlock, ok := msg.Value.(*blockchain.ShardBlock)
+		if !ok {
+			continue
+		}
+		txs := serverObj.memPool.GetTxsToRebroadcast(shardBlock.Header.ShardID, shardBlock.Header.Height)
+		for _, tx := range txs {
+			err := serverObj.pushTransactionToAll(tx)
+			if err != nil {
+				Logger.log.Errorf("Rebroadcast tx %+v with error %+v", tx.Hash().String(), err)
+				continue
+			}
+			Logger.log.Infof("Rebroadcast tx %+v not in a block of shard %+v at height %+v", tx.Hash().String(), shardBlock.Header.ShardID, shardBlock.Header.Height)
+			serverObj.memPool.MarkForwardedTransaction(*tx.Hash())
+		}
+	}
+}
+
+// pushTransactionToAll - push the message of a PRV or privacy token transaction to all peers
+func (serverObj *Server) pushTransactionToAll(tx metadata.Transaction) error {
+	txMsg, err := makeTransactionMessage(tx)
+	if err != nil {
+		return err
+	}
+	return serverObj.PushMessageToAll(txMsg)
+}
+
+// pushTransactionToStemPeer - send a transaction relayed privately to a single stem peer
+func (serverObj *Server) pushTransactionToStemPeer(tx metadata.Transaction, peerID string) error {
+	txMsg, err := makeTransactionMessage(tx)
+	if err != nil {
+		return err
+	}
+	return serverObj.stemRelay.SendStem(txMsg, peerID)
+}
+
+// fluffTransaction - end the stem phase of a transaction relayed privately by broadcasting it
+func (serverObj *Server) fluffTransaction(tx metadata.Transaction) error {
+	err := serverObj.pushTransactionToAll(tx)
+	if err != nil {
+		return err
+	}
+	serverObj.memPool.MarkForwardedTransaction(*tx.Hash())
+	return nil
+}
+
+func makeTransactionMessage(tx metadata.Transaction) (wire.Message, error) {
+	switch tx.GetType() {
+	case common.TxNormalType:
+		txMsg, err := wire.MakeEmptyMessage(wire.CmdTx)
+		if err != nil {
+			return nil, err
+		}
+		txMsg.(*wire.MessageTx).Transaction = tx.(*transaction.Tx)
+		return txMsg, nil
+	case common.TxCustomTokenPrivacyType:
+		txMsg, err := wire.MakeEmptyMessage(wire.CmdPrivacyCustomToken)
+		if err != nil {
+			return nil, err
+		}
+		txMsg.(*wire.MessageTxPrivacyToken).Transaction = tx.(*transaction.TxCustomTokenPrivacy)
+		return txMsg, nil
+	}
+	return nil, fmt.Errorf("can not push tx of type %+v", tx.GetType())
+}
+
 // CheckForceUpdateSourceCode - loop to check current version with update version is equal
 // Force source code to be updated and remove data
 func (serverObject Server) CheckForceUpdateSourceCode() {
@@ -1074,6 +1174,29 @@ func (serverObj *Server) OnTxPrivacyToken(peer *peer.PeerConn, msg *wire.Message
 	Logger.log.Debug("Receive a new transaction(privacy token) END")
 }
 
+// OnStemTx is invoked when a peer forwards a transaction of the stem phase to this node, it is kept in the mempool
+// without being broadcast and relayed along the stem
+func (serverObj *Server) OnStemTx(msg wire.Message) {
+	var tx metadata.Transaction
+	switch msg := msg.(type) {
+	case *wire.MessageTx:
+		tx = msg.Transaction
+	case *wire.MessageTxPrivacyToken:
+		tx = msg.Transaction
+	default:
+		return
+	}
+	beaconHeight := serverObj.blockChain.GetBeaconBestState().BestBlock.GetHeight()
+	_, _, err := serverObj.memPool.MaybeAcceptStemTransaction(tx, int64(beaconHeight), false)
+	if err != nil {
+		Logger.log.Errorf("Drop stem tx %+v: %+v", tx.Hash().String(), err)
+		return
+	}
+	if err := serverObj.txRelay.RelayStem(tx); err != nil {
+		Logger.log.Errorf("Relay stem tx %+v with error %+v", tx.Hash().String(), err)
+	}
+}
+
 /*
 // OnVersion is invoked when a peer receives a version message
 // and is used to negotiate the protocol version details as well as kick start
@@ -1297,7 +1420,7 @@ func (serverObj *Server) GetPeerIDsFromPublicKey(pubKey string) []libp2p.ID {
 }
 
 func (serverObj *Server) GetNodeRole() string {
-	if serverObj.miningKeys == "" && serverObj.privateKey == "" {
+	if serverObj.miningKeys == "" && serverObj.privateKey == "" && serverObj.remoteSigner == "" {
 		return ""
 	}
 	if cfg.NodeMode == "relay" {
@@ -1319,6 +1442,9 @@ PushMessageToAll broadcast msg
 */
 func (serverObj *Server) PushMessageToAll(msg wire.Message) error {
 	Logger.log.Debug("Push msg to all peers")
+	if serverObj.devnet != nil {
+		return serverObj.devnet.PublishMessage(msg)
+	}
 
 	// Publish message to highway
 	if err := serverObj.highway.PublishMessage(msg); err != nil {
@@ -1370,6 +1496,9 @@ PushMessageToPeer push msg to pbk
 */
 func (serverObj *Server) PushMessageToShard(msg wire.Message, shard byte, exclusivePeerIDs map[libp2p.ID]bool) error {
 	Logger.log.Debugf("Push msg to shard %d", shard)
+	if serverObj.devnet != nil {
+		return serverObj.devnet.PublishMessage(msg)
+	}
 
 	// Publish message to highway
 	if err := serverObj.highway.PublishMessageToShard(msg, shard); err != nil {
@@ -1405,6 +1534,9 @@ func (serverObj *Server) PushRawBytesToShard(p *peer.PeerConn, msgBytes *[]byte,
 PushMessageToPeer push msg to beacon node
 */
 func (serverObj *Server) PushMessageToBeacon(msg wire.Message, exclusivePeerIDs map[libp2p.ID]bool) error {
+	if serverObj.devnet != nil {
+		return serverObj.devnet.PublishMessage(msg)
+	}
 	// Publish message to highway
 	if err := serverObj.highway.PublishMessage(msg); err != nil {
 		return err
@@ -1729,6 +1861,7 @@ func (serverObj *Server) PublishNodeState(userLayer string, shardID int) error {
 		bBestState.BeaconHeight,
 		bBestState.BestBlockHash,
 		bBestState.Hash(),
+		0,
 	}
 
 	if userLayer != common.BeaconRole {
@@ -1738,6 +1871,7 @@ func (serverObj *Server) PublishNodeState(userLayer string, shardID int) error {
 			sBestState.ShardHeight,
 			sBestState.BestBlockHash,
 			sBestState.Hash(),
+			serverObj.blockChain.GetShardPrunedHeight(byte(shardID)),
 		}
 	} else {
 		s2bMap := make(map[byte][]uint64)
@@ -1854,6 +1988,10 @@ func (serverObj *Server) GetPrivateKey() string {
 	return serverObj.privateKey
 }
 
+func (serverObj *Server) GetRemoteSigner() string {
+	return serverObj.remoteSigner
+}
+
 func (serverObj *Server) PushMessageToChain(msg wire.Message, chain common.ChainInterface) error {
 	chainID := chain.GetShardID()
 	if chainID == -1 {
@@ -2196,6 +2334,10 @@ func (serverObj *Server) RequestShardToBeaconBlocksByHashViaStream(ctx context.C
 
 func (serverObj *Server) requestBlocksViaStream(ctx context.Context, peerID string, req *proto.BlockByHeightRequest) (blockCh chan common.BlockInterface, err error) {
 	Logger.log.Infof("[stream] Request Block type %v from peer %v from cID %v, [%v %v] ", req.Type, peerID, req.GetFrom(), req.Heights[0], req.Heights[len(req.Heights)-1])
+	// the nodes of a devnet share the chains of this process, there is nothing to stream
+	if serverObj.devnet != nil {
+		return nil, errors.New("no peer to stream blocks from in the devnet")
+	}
 	blockCh = make(chan common.BlockInterface, blockchain.DefaultMaxBlkReqPerPeer)
 	stream, err := serverObj.highway.Requester.StreamBlockByHeight(ctx, req)
 	if err != nil {
@@ -2258,6 +2400,10 @@ func (serverObj *Server) requestBlocksViaStream(ctx context.Context, peerID stri
 
 func (serverObj *Server) requestBlocksByHashViaStream(ctx context.Context, peerID string, req *proto.BlockByHashRequest) (blockCh chan common.BlockInterface, err error) {
 	Logger.log.Infof("SYNCKER Request Block by hash from peerID %v, from CID %v, total %v blocks", peerID, req.From, len(req.Hashes))
+	// the nodes of a devnet share the chains of this process, there is nothing to stream
+	if serverObj.devnet != nil {
+		return nil, errors.New("no peer to stream blocks from in the devnet")
+	}
 	blockCh = make(chan common.BlockInterface, blockchain.DefaultMaxBlkReqPerPeer)
 	stream, err := serverObj.highway.Requester.StreamBlockByHash(ctx, req)
 	if err != nil {
@@ -12,6 +12,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/wire"
 )
 
@@ -324,6 +325,9 @@ func (s *BeaconSyncProcess) streamFromPeer(peerID string, pState BeaconPeerState
 
 	//receive
 	requestCnt++
+	traceCtx, span := tracing.StartSpan(context.Background(), "syncker.streamBeaconFromPeer",
+		tracing.String("peerID", peerID), tracing.Uint64("toHeight", toHeight))
+	defer span.End()
 	insertTime := time.Now()
 	for {
 		select {
@@ -337,7 +341,7 @@ func (s *BeaconSyncProcess) streamFromPeer(peerID string, pState BeaconPeerState
 				insertBlkCnt := 0
 				for {
 					time1 := time.Now()
-					if successBlk, err := InsertBatchBlock(s.chain, blockBuffer); err != nil {
+					if successBlk, err := InsertBatchBlock(traceCtx, s.chain, blockBuffer); err != nil {
 						if successBlk == 0 {
 							fmt.Println(err)
 						}
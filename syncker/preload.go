@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 
 	"github.com/incognitochain/incognito-chain/incdb"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
@@ -113,21 +114,14 @@ func preloadDatabase(chainID int, currentEpoch int, url string, db incdb.Databas
 	}
 
 	if currentEpoch < result.LatestEpoch-2 {
-		backupFile := "./data/preload/" + chainName
-
-		fd, err := os.OpenFile(backupFile, os.O_CREATE|os.O_WRONLY, 0666)
+		backupFolder := "./data/preload/" + chainName
+		err = downloadBackup(url, chainName, backupFolder, uint64(result.LatestEpoch))
 		if err != nil {
 			return err
 		}
-		fd.Truncate(0)
-		err = makeRPCDownloadRequest(url, "downloadbackup", fd, chainName)
-		if err != nil {
-			return err
-		}
-		fd.Close()
 
 		if chainName == "beacon" {
-			fd, err = os.OpenFile("./data/preload/btc", os.O_CREATE|os.O_WRONLY, 0666)
+			fd, err := os.OpenFile("./data/preload/btc", os.O_CREATE|os.O_WRONLY, 0666)
 			if err != nil {
 				return err
 			}
@@ -145,7 +139,7 @@ func preloadDatabase(chainID int, currentEpoch int, url string, db incdb.Databas
 		defer db.ReOpen()
 
 		//restore beacon|shard
-		err = db.PreloadBackup(backupFile)
+		err = db.PreloadBackup(incdb.BackupIncrementPath(backupFolder, uint64(result.LatestEpoch)))
 		if err != nil {
 			return err
 		}
@@ -160,3 +154,66 @@ func preloadDatabase(chainID int, currentEpoch int, url string, db incdb.Databas
 	}
 	return nil
 }
+
+// downloadBackup download the increments of the backup of epoch in backupFolder, the files kept from a previous
+// preload are not downloaded again
+func downloadBackup(url string, chainName string, backupFolder string, epoch uint64) error {
+	// a backup of a previous version is a single file
+	if stat, err := os.Stat(backupFolder); err == nil && !stat.IsDir() {
+		if err := os.Remove(backupFolder); err != nil {
+			return err
+		}
+	}
+	type increment struct {
+		manifest *incdb.BackupManifest
+		data     []byte
+	}
+	increments := []increment{}
+	for incrementEpoch := epoch; ; {
+		data := &bytes.Buffer{}
+		err := makeRPCDownloadRequest(url, "downloadbackup", data, chainName, incrementEpoch, incdb.BackupManifestFile)
+		if err != nil {
+			return err
+		}
+		manifest := &incdb.BackupManifest{}
+		if err := json.Unmarshal(data.Bytes(), manifest); err != nil {
+			return fmt.Errorf("manifest of backup %v epoch %v: %v", chainName, incrementEpoch, err)
+		}
+		if manifest.Epoch != incrementEpoch || (!manifest.IsFull() && manifest.PreviousEpoch >= incrementEpoch) {
+			return fmt.Errorf("manifest of backup %v epoch %v is for epoch %v", chainName, incrementEpoch, manifest.Epoch)
+		}
+		increments = append(increments, increment{manifest: manifest, data: data.Bytes()})
+		if manifest.IsFull() {
+			break
+		}
+		incrementEpoch = manifest.PreviousEpoch
+	}
+	for _, increment := range increments {
+		incrementPath := incdb.BackupIncrementPath(backupFolder, increment.manifest.Epoch)
+		if err := os.MkdirAll(incrementPath, 0700); err != nil {
+			return err
+		}
+		for _, file := range increment.manifest.StoredFiles() {
+			if incdb.VerifyBackupFile(backupFolder, file) == nil {
+				continue
+			}
+			fd, err := os.OpenFile(filepath.Join(incrementPath, file.Name), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+			if err != nil {
+				return err
+			}
+			err = makeRPCDownloadRequest(url, "downloadbackup", fd, chainName, increment.manifest.Epoch, file.Name)
+			fd.Close()
+			if err != nil {
+				return err
+			}
+		}
+		// the manifest last, the increment is complete once it is written
+		if err := ioutil.WriteFile(filepath.Join(incrementPath, incdb.BackupManifestFile), increment.data, 0600); err != nil {
+			return err
+		}
+	}
+	if _, err := incdb.VerifyBackup(backupFolder, epoch); err != nil {
+		return err
+	}
+	return incdb.RemoveUnusedBackups(backupFolder)
+}
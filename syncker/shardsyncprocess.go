@@ -11,6 +11,7 @@ import (
 
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/wire"
 )
 
@@ -18,6 +19,7 @@ type ShardPeerState struct {
 	Timestamp      int64
 	BestViewHash   string
 	BestViewHeight uint64
+	PrunedHeight   uint64
 	processed      bool
 }
 
@@ -77,10 +79,16 @@ func NewShardSyncProcess(shardID int, server Server, beaconChain BeaconChainInte
 			case shardPeerState := <-s.shardPeerStateCh:
 				for sid, peerShardState := range shardPeerState.Shards {
 					if int(sid) == s.shardID {
+						// a broadcast block carries no pruned height, keep the one of the peer state
+						prunedHeight := peerShardState.PrunedHeight
+						if prunedHeight < s.shardPeerState[shardPeerState.SenderID].PrunedHeight {
+							prunedHeight = s.shardPeerState[shardPeerState.SenderID].PrunedHeight
+						}
 						s.shardPeerState[shardPeerState.SenderID] = ShardPeerState{
 							Timestamp:      shardPeerState.Timestamp,
 							BestViewHash:   peerShardState.BlockHash.String(),
 							BestViewHeight: peerShardState.Height,
+							PrunedHeight:   prunedHeight,
 						}
 						s.Chain.SetReady(true)
 					}
@@ -228,6 +236,11 @@ func (s *ShardSyncProcess) streamFromPeer(peerID string, pState ShardPeerState)
 		return
 	}
 
+	//a pruning peer cannot send the blocks it has pruned
+	if pState.PrunedHeight > s.Chain.GetFinalViewHeight() {
+		return
+	}
+
 	//fmt.Println("SYNCKER Request Shard Block", peerID, s.ShardID, s.Chain.GetBestViewHeight()+1, pState.BestViewHeight)
 	ch, err := s.Server.RequestShardBlocksViaStream(ctx, peerID, s.shardID, s.Chain.GetFinalViewHeight()+1, toHeight)
 	// ch, err := s.Server.RequestShardBlocksViaStream(ctx, "", s.shardID, s.Chain.GetBestViewHeight()+1, pState.BestViewHeight)
@@ -237,6 +250,9 @@ func (s *ShardSyncProcess) streamFromPeer(peerID string, pState ShardPeerState)
 	}
 
 	requestCnt++
+	traceCtx, span := tracing.StartSpan(context.Background(), "syncker.streamShardFromPeer",
+		tracing.String("peerID", peerID), tracing.Int("shardID", s.shardID), tracing.Uint64("toHeight", toHeight))
+	defer span.End()
 	insertTime := time.Now()
 	for {
 		select {
@@ -257,7 +273,7 @@ func (s *ShardSyncProcess) streamFromPeer(peerID string, pState ShardPeerState)
 				insertBlkCnt := 0
 				for {
 					time1 := time.Now()
-					if successBlk, err := InsertBatchBlock(s.Chain, blockBuffer); err != nil {
+					if successBlk, err := InsertBatchBlock(traceCtx, s.Chain, blockBuffer); err != nil {
 						return
 					} else {
 						insertBlkCnt += successBlk
@@ -1,9 +1,11 @@
 package syncker
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/tracing"
 )
 
 const RUNNING_SYNC = "running_sync"
@@ -13,7 +15,9 @@ func isNil(v interface{}) bool {
 	return v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil())
 }
 
-func InsertBatchBlock(chain Chain, blocks []common.BlockInterface) (int, error) {
+func InsertBatchBlock(ctx context.Context, chain Chain, blocks []common.BlockInterface) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "syncker.InsertBatchBlock", tracing.Int("blocks", len(blocks)))
+	defer span.End()
 	curEpoch := chain.GetEpoch()
 	sameCommitteeBlock := blocks
 	for i, v := range blocks {
@@ -41,15 +45,18 @@ func InsertBatchBlock(chain Chain, blocks []common.BlockInterface) (int, error)
 		}
 	}
 
+	span.SetAttributes(tracing.Int("sameCommitteeBlocks", len(sameCommitteeBlock)))
 	for i, v := range sameCommitteeBlock {
 		if !chain.CheckExistedBlk(v) {
 			var err error
+			tracing.RememberBlock(v.Hash().String(), ctx)
 			if i == len(sameCommitteeBlock)-1 {
 				err = chain.InsertBlk(v, true)
 			} else {
 				err = chain.InsertBlk(v, false)
 			}
 			if err != nil {
+				span.SetError(err)
 				return 0, err
 			}
 		}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// operators of a matcher, a matcher with other keys is an object the result must contain
+var operators = map[string]bool{
+	"equals":   true,
+	"not":      true,
+	"gt":       true,
+	"gte":      true,
+	"lt":       true,
+	"lte":      true,
+	"contains": true,
+	"exists":   true,
+	"len":      true,
+	"matches":  true,
+}
+
+// checkThat checks result against the matchers of that, by json path. The matchers are interpolated with vars
+func checkThat(result interface{}, that yaml.MapSlice, vars map[string]interface{}) error {
+	for _, item := range that {
+		path := fmt.Sprint(item.Key)
+		matcher, err := interpolate(item.Value, vars)
+		if err != nil {
+			return err
+		}
+		actual, err := evalPath(result, path)
+		if err != nil && err != errPathNotFound {
+			return err
+		}
+		if err := match(actual, err == nil, matcher); err != nil {
+			return fmt.Errorf("%v: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// match checks actual against matcher: a map of operators, an object actual must contain, or a value actual must equal
+func match(actual interface{}, found bool, matcher interface{}) error {
+	object, isObject := matcher.(map[string]interface{})
+	if isObject && isOperatorMap(object) {
+		for _, operator := range sortedKeys(object) {
+			if err := matchOperator(actual, found, operator, object[operator]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if !found {
+		return errPathNotFound
+	}
+	if isObject {
+		return matchSubset(actual, object)
+	}
+	if !equalValues(actual, matcher) {
+		return fmt.Errorf("got %v, want %v", formatValue(actual), formatValue(matcher))
+	}
+	return nil
+}
+
+func isOperatorMap(object map[string]interface{}) bool {
+	if len(object) == 0 {
+		return false
+	}
+	for key := range object {
+		if !operators[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// matchSubset checks that actual has every key of expected, with a value matching it
+func matchSubset(actual interface{}, expected map[string]interface{}) error {
+	object, ok := actual.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("got %v, want an object", formatValue(actual))
+	}
+	for _, key := range sortedKeys(expected) {
+		value, found := object[key]
+		if err := match(value, found, expected[key]); err != nil {
+			return fmt.Errorf("%v: %v", key, err)
+		}
+	}
+	return nil
+}
+
+func matchOperator(actual interface{}, found bool, operator string, want interface{}) error {
+	if operator == "exists" {
+		exists, ok := want.(bool)
+		if !ok {
+			return errors.New("exists takes true or false")
+		}
+		if found != exists {
+			return fmt.Errorf("exists is %v, want %v", found, exists)
+		}
+		return nil
+	}
+	if !found {
+		return errPathNotFound
+	}
+	switch operator {
+	case "equals":
+		if !equalValues(actual, want) {
+			return fmt.Errorf("got %v, want %v", formatValue(actual), formatValue(want))
+		}
+	case "not":
+		if equalValues(actual, want) {
+			return fmt.Errorf("got %v", formatValue(actual))
+		}
+	case "gt", "gte", "lt", "lte":
+		x, ok := toNumber(actual, true)
+		if !ok {
+			return fmt.Errorf("got %v, want a number", formatValue(actual))
+		}
+		y, ok := toNumber(want, true)
+		if !ok {
+			return fmt.Errorf("%v takes a number, not %v", operator, formatValue(want))
+		}
+		cmp := x.Cmp(y)
+		if (operator == "gt" && cmp <= 0) || (operator == "gte" && cmp < 0) || (operator == "lt" && cmp >= 0) || (operator == "lte" && cmp > 0) {
+			return fmt.Errorf("got %v, want %v %v", formatValue(actual), operator, formatValue(want))
+		}
+	case "contains":
+		switch v := actual.(type) {
+		case string:
+			if !strings.Contains(v, formatValue(want)) {
+				return fmt.Errorf("got %v, want it to contain %v", v, formatValue(want))
+			}
+		case []interface{}:
+			for _, item := range v {
+				if match(item, true, want) == nil {
+					return nil
+				}
+			}
+			return fmt.Errorf("got %v, want an element matching %v", formatValue(actual), formatValue(want))
+		case map[string]interface{}:
+			if _, ok := v[formatValue(want)]; !ok {
+				return fmt.Errorf("got %v, want the key %v", formatValue(actual), formatValue(want))
+			}
+		default:
+			return fmt.Errorf("got %v, contains takes a string, a list or an object", formatValue(actual))
+		}
+	case "len":
+		length := 0
+		switch v := actual.(type) {
+		case string:
+			length = len(v)
+		case []interface{}:
+			length = len(v)
+		case map[string]interface{}:
+			length = len(v)
+		default:
+			return fmt.Errorf("got %v, len takes a string, a list or an object", formatValue(actual))
+		}
+		if err := match(length, true, want); err != nil {
+			return fmt.Errorf("len: %v", err)
+		}
+	case "matches":
+		pattern, ok := want.(string)
+		if !ok {
+			return errors.New("matches takes a regular expression")
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(formatValue(actual)) {
+			return fmt.Errorf("got %v, want it to match %v", formatValue(actual), pattern)
+		}
+	}
+	return nil
+}
+
+// checkError checks the error of a call against the error expected by a step: nil for no error, true for any error
+// of the node, or a map with its code and a part of its message
+func checkError(err error, expected interface{}, vars map[string]interface{}) error {
+	expected, interpolateErr := interpolate(expected, vars)
+	if interpolateErr != nil {
+		return interpolateErr
+	}
+	nodeErr, isNodeErr := err.(*nodeError)
+	if err != nil && !isNodeErr {
+		return err
+	}
+	switch want := expected.(type) {
+	case nil:
+		if err != nil {
+			return fmt.Errorf("unexpected error %v", err)
+		}
+		return nil
+	case bool:
+		if want != (err != nil) {
+			return fmt.Errorf("error is %v, want an error %v", err, want)
+		}
+		return nil
+	case map[string]interface{}:
+		if err == nil {
+			return fmt.Errorf("no error, want %v", formatValue(want))
+		}
+		for key, value := range want {
+			switch key {
+			case "code":
+				if !equalValues(nodeErr.Code, value) {
+					return fmt.Errorf("error code is %v, want %v", nodeErr.Code, formatValue(value))
+				}
+			case "contains":
+				if !strings.Contains(nodeErr.Error(), formatValue(value)) {
+					return fmt.Errorf("error %v does not contain %v", nodeErr, formatValue(value))
+				}
+			default:
+				return fmt.Errorf("expected error has code and contains, not %v", key)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("expected error %v is not null, true or a map", formatValue(expected))
+}
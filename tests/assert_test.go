@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func readThat(t *testing.T, s string) yaml.MapSlice {
+	that := yaml.MapSlice{}
+	assert.Nil(t, yaml.Unmarshal([]byte(s), &that))
+	return that
+}
+
+func TestCheckThat(t *testing.T) {
+	result, err := decodeJSON([]byte(`{"Balance": 1750000000000000001, "TxID": "abc", "List": [{"ID": "t1", "Amount": 5}, {"ID": "t2"}], "Keys": {"k": 1}, "Ok": true}`))
+	assert.Nil(t, err)
+	vars := map[string]interface{}{"id": "t2", "amount": 5, "big": "1750000000000000000"}
+
+	passing := []string{
+		`$.Balance: 1750000000000000001`,
+		`$.Balance: {gt: "${big}"}`,
+		`$.Balance: {gte: 1750000000000000001, lt: 1750000000000000002}`,
+		`$.TxID: {not: "", len: 3, matches: "^[a-f]+$"}`,
+		`$.TxID: {contains: b}`,
+		`$.List: {len: {gte: 2}}`,
+		`$.List: {contains: {ID: "${id}"}}`,
+		`$.List[*].ID: {contains: "${id}"}`,
+		`$.List[0]: {ID: t1, Amount: "${amount}"}`,
+		`$.Keys: {contains: k}`,
+		`$.Missing: {exists: false}`,
+		`$.Ok: {exists: true, equals: true}`,
+	}
+	for _, s := range passing {
+		assert.Nil(t, checkThat(result, readThat(t, s), vars), s)
+	}
+
+	failing := []string{
+		`$.Balance: 1750000000000000000`,
+		`$.Balance: {lt: "${big}"}`,
+		`$.TxID: {gt: 1}`,
+		`$.TxID: {contains: z}`,
+		`$.List: {contains: {ID: t3}}`,
+		`$.List[1]: {Amount: 5}`,
+		`$.Missing: 1`,
+		`$.Missing: {exists: true}`,
+		`$.Ok: {equals: "${unset}"}`,
+	}
+	for _, s := range failing {
+		assert.NotNil(t, checkThat(result, readThat(t, s), vars), s)
+	}
+}
+
+func TestCheckError(t *testing.T) {
+	nodeErr := &nodeError{Code: -1002, Message: "Invalid parameters", StackTrace: "private key is invalid\nstack"}
+	readExpected := func(s string) interface{} {
+		var expected interface{}
+		assert.Nil(t, yaml.Unmarshal([]byte(s), &expected))
+		return expected
+	}
+
+	assert.Nil(t, checkError(nil, nil, nil))
+	assert.NotNil(t, checkError(nodeErr, nil, nil))
+	assert.Nil(t, checkError(nodeErr, true, nil))
+	assert.NotNil(t, checkError(nil, true, nil))
+	assert.Nil(t, checkError(nodeErr, readExpected(`{code: -1002, contains: private key}`), nil))
+	assert.NotNil(t, checkError(nodeErr, readExpected(`{code: -1001}`), nil))
+	assert.NotNil(t, checkError(nodeErr, readExpected(`{contains: "stack"}`), nil))
+	assert.NotNil(t, checkError(nil, readExpected(`{code: -1002}`), nil))
+	// a network error is never what a step expects
+	assert.NotNil(t, checkError(errors.New("connection refused"), true, nil))
+}
+
+func TestInterpolate(t *testing.T) {
+	vars := map[string]interface{}{
+		"alice":    map[string]interface{}{"PaymentAddress": "12S", "ShardID": 1},
+		"accounts": []interface{}{map[string]interface{}{"PrivateKey": "112t"}},
+		"amount":   1000,
+	}
+	params := []interface{}{"${accounts[0].PrivateKey}", map[interface{}]interface{}{"${alice.PaymentAddress}": "${amount}"}, "shard ${alice.ShardID}", "${alice}"}
+	result, err := interpolate(params, vars)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"112t", map[string]interface{}{"12S": 1000}, "shard 1", vars["alice"]}, result)
+
+	_, err = interpolate("${bob.PaymentAddress}", vars)
+	assert.NotNil(t, err)
+}
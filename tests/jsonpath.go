@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var errPathNotFound = errors.New("path not found")
+
+type pathToken struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parsePath parses the json path subset of scenarios: $ is the root, followed by .key, ['key'], [index] (negative from
+// the end), [*] and .* for every element
+func parsePath(path string) ([]pathToken, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("json path %v does not start with $", path)
+	}
+	tokens := []pathToken{}
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			key := rest[:end]
+			if key == "" {
+				return nil, fmt.Errorf("json path %v has an empty key", path)
+			}
+			if key == "*" {
+				tokens = append(tokens, pathToken{wildcard: true})
+			} else {
+				tokens = append(tokens, pathToken{key: key})
+			}
+			rest = rest[end:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("json path %v has an unclosed [", path)
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				tokens = append(tokens, pathToken{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				tokens = append(tokens, pathToken{key: inner[1 : len(inner)-1]})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("json path %v has an invalid index %v", path, inner)
+				}
+				tokens = append(tokens, pathToken{index: index, isIndex: true})
+			}
+		default:
+			return nil, fmt.Errorf("json path %v is invalid at %v", path, rest)
+		}
+	}
+	return tokens, nil
+}
+
+// evalPath returns the value at path of value. A path with a wildcard returns the list of the values it matches, the
+// elements without the rest of the path are left out
+func evalPath(value interface{}, path string) (interface{}, error) {
+	tokens, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	matches, wildcard, err := walk([]interface{}{value}, tokens)
+	if err != nil {
+		return nil, err
+	}
+	if wildcard {
+		return matches, nil
+	}
+	if len(matches) == 0 {
+		return nil, errPathNotFound
+	}
+	return matches[0], nil
+}
+
+func walk(values []interface{}, tokens []pathToken) ([]interface{}, bool, error) {
+	wildcard := false
+	for _, token := range tokens {
+		next := []interface{}{}
+		for _, value := range values {
+			switch {
+			case token.wildcard:
+				wildcard = true
+				switch v := value.(type) {
+				case []interface{}:
+					next = append(next, v...)
+				case map[string]interface{}:
+					for _, key := range sortedKeys(v) {
+						next = append(next, v[key])
+					}
+				}
+			case token.isIndex:
+				list, ok := value.([]interface{})
+				if !ok {
+					continue
+				}
+				index := token.index
+				if index < 0 {
+					index += len(list)
+				}
+				if index >= 0 && index < len(list) {
+					next = append(next, list[index])
+				}
+			default:
+				object, ok := value.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if v, ok := object[token.key]; ok {
+					next = append(next, v)
+				}
+			}
+		}
+		if len(next) == 0 && !wildcard {
+			return nil, false, errPathNotFound
+		}
+		values = next
+	}
+	return values, wildcard, nil
+}
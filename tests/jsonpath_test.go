@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalPath(t *testing.T) {
+	value, err := decodeJSON([]byte(`{"a": {"b": [1, 2, {"c": "x"}]}, "m": {"k1": {"v": 1}, "k2": {"v": 2}, "k3": {}}, "d.e": true}`))
+	assert.Nil(t, err)
+
+	for path, want := range map[string]interface{}{
+		"$.a.b[0]":       1,
+		"$.a.b[-1].c":    "x",
+		"$['a']['b'][1]": 2,
+		"$['d.e']":       true,
+		"$.m.*.v":        []interface{}{1, 2},
+		"$.a.b[*]":       []interface{}{1, 2, map[string]interface{}{"c": "x"}},
+		"$.m.*.x":        []interface{}{},
+	} {
+		got, err := evalPath(value, path)
+		if assert.Nil(t, err, path) {
+			assert.True(t, equalValues(normalize(want), got), "%v: %v", path, got)
+		}
+	}
+
+	root, err := evalPath(value, "$")
+	assert.Nil(t, err)
+	assert.Equal(t, value, root)
+
+	for _, path := range []string{"$.x", "$.a.b[3]", "$.a.b.c", "$.a.b[0].c"} {
+		_, err := evalPath(value, path)
+		assert.Equal(t, errPathNotFound, err, path)
+	}
+	for _, path := range []string{"a.b", "$.a[", "$.a[x]", "$..a", "$a"} {
+		_, err := evalPath(value, path)
+		assert.NotNil(t, err, path)
+		assert.NotEqual(t, errPathNotFound, err, path)
+	}
+}
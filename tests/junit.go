@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit writes the results as one JUnit test suite, a test case by scenario with the log of its steps
+func writeJUnit(file string, start time.Time, results []*result) error {
+	suite := junitTestSuite{
+		Name:      "scenarios",
+		Tests:     len(results),
+		Timestamp: start.UTC().Format("2006-01-02T15:04:05"),
+		Time:      seconds(time.Since(start)),
+	}
+	for _, res := range results {
+		testCase := junitTestCase{
+			Name:      res.scenario.Name,
+			ClassName: res.scenario.file,
+			Time:      seconds(res.duration),
+			SystemOut: res.output.String(),
+		}
+		if res.err != nil {
+			suite.Failures++
+			testCase.Failure = &junitFailure{Message: res.err.Error(), Text: res.output.String()}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	data, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, append([]byte(xml.Header), data...), 0644)
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -1,174 +1,141 @@
+// incognito-test runs yaml scenarios against a devnet it starts, or against running nodes, and writes a JUnit report.
+// See README.md for the scenario format
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"os"
-	"os/exec"
-	"strconv"
-)
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
 
-var (
-	shard0 []*Client
-	shard1 []*Client
-	beacon []*Client
+	"github.com/jessevdk/go-flags"
+	"gopkg.in/yaml.v2"
 )
 
+type options struct {
+	Incognito string `long:"incognito" default:"./incognito" description:"Binary of the node, started for the devnet topologies"`
+	Topology  string `long:"topology" description:"Yaml file of a topology replacing the topology of every scenario, e.g. running nodes"`
+	JUnit     string `long:"junit" description:"File of the JUnit report"`
+	Run       string `long:"run" description:"Run only the scenarios whose name matches this regular expression"`
+	LogDir    string `long:"logdir" description:"Directory of the logs of the devnets, a temporary directory when empty"`
+}
+
 func main() {
-	//var nodefile = "sample-config.json"
-	var testcase = "testcase.json"
-	var err error
-	// -1: beacon
-	// 0, 1: shard
-	var shardIndex = []int{-1, 0, 1}
-	var nodeFileData = make(map[string]interface{})
-	var testcaseData = make(map[string]interface{})
-	testcaseBytes, err := ioutil.ReadFile(testcase)
+	opts := options{}
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.Usage = "[OPTIONS] [scenario files or directories, default scenarios]"
+	args, err := parser.Parse()
 	if err != nil {
-		panic("Failed to get config file")
+		os.Exit(1)
 	}
-	err = json.Unmarshal(testcaseBytes, &testcaseData)
-	for _, shard := range shardIndex {
-		if nodes, ok := nodeFileData[strconv.Itoa(shard)]; ok {
-			nodeInfos, ok := nodes.(map[string]interface{})
-			if !ok {
-				panic("Failed to read data from config file")
-			}
-			for _, nodeInfo := range nodeInfos {
-				nodeInfoData, ok := nodeInfo.(map[string]interface{})
-				if !ok {
-					panic("Failed to read data from config file")
-				}
-				client := &Client{
-					host: nodeInfoData["host"].(string),
-					port: nodeInfoData["port"].(string),
-				}
-				if shard == -1 {
-					beacon = append(beacon, client)
-				}
-				if shard == 0 {
-					shard0 = append(shard0, client)
-				}
-				if shard == 1 {
-					shard1 = append(shard1, client)
-				}
-			}
-		}
+	if len(args) == 0 {
+		args = []string{"scenarios"}
 	}
-	switch os.Args[1] {
-	case "all":
-		fmt.Println("Test all")
-	case "client":
-		tempInitTestcase, ok := testcaseData["client"]
-		if !ok {
-			log.Println("Failed to get init testcase")
-			os.Exit(0)
-		}
-		var initTestcase = []string{}
-		for _, value := range tempInitTestcase.([]interface{}) {
-			temp, ok := value.(string)
-			if !ok {
-				log.Println("Failed to load init testcase")
-				os.Exit(1)
-			}
-			initTestcase = append(initTestcase, temp)
-		}
+	ok, err := run(&opts, args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
 
-		log.Println("Begin to run Init Testcase")
-		for _, initTestcaseName := range initTestcase {
-			cmd := exec.Command("go", "test", "-run", initTestcaseName)
-			msg, err := cmd.Output()
-			if err != nil {
-				panic("Failed to run test " + initTestcaseName + " : " + string(msg))
-			} else {
-				log.Printf("%+v Message: %+v \n", initTestcaseName, string(msg))
-			}
-		}
-	case "blockchain":
-		log.Println("Begin to run testcase Blockchain")
-	case "transaction":
-		tempTransactionTestcase, ok := testcaseData["transaction"]
-		if !ok {
-			log.Println("Failed to load transaction testcase")
-			os.Exit(0)
+// run runs the scenarios of args and returns whether they all passed
+func run(opts *options, args []string) (bool, error) {
+	scenarios, err := readScenarios(args)
+	if err != nil {
+		return false, err
+	}
+	if opts.Run != "" {
+		re, err := regexp.Compile(opts.Run)
+		if err != nil {
+			return false, err
 		}
-		var transactionTestcase = []string{}
-		for _, value := range tempTransactionTestcase.([]interface{}) {
-			temp, ok := value.(string)
-			if !ok {
-				log.Println("Failed to load transaction testcase")
-				os.Exit(1)
+		selected := []*Scenario{}
+		for _, scenario := range scenarios {
+			if re.MatchString(scenario.Name) {
+				selected = append(selected, scenario)
 			}
-			transactionTestcase = append(transactionTestcase, temp)
 		}
-		log.Println("Begin to run Transaction Testcase")
-		for _, transactionTestcaseName := range transactionTestcase {
-			log.Printf("Begin to run %+v Testcase \n", transactionTestcaseName)
-			cmd := exec.Command("go", "test", "-run", transactionTestcaseName)
-			msg, err := cmd.Output()
-			if err != nil {
-				panic("Failed to run test " + transactionTestcaseName + " : " + string(msg))
-			} else {
-				log.Printf("%+v Message: %+v \n", transactionTestcaseName, string(msg))
-			}
+		scenarios = selected
+	}
+	var topology *Topology
+	if opts.Topology != "" {
+		data, err := ioutil.ReadFile(opts.Topology)
+		if err != nil {
+			return false, err
 		}
-	case "crossshard":
-		tempCrossShardTestcase, ok := testcaseData["crossshard"]
-		if !ok {
-			log.Println("Failed to load crossshard testcase")
-			os.Exit(0)
+		topology = &Topology{}
+		if err := yaml.UnmarshalStrict(data, topology); err != nil {
+			return false, fmt.Errorf("%v: %v", opts.Topology, err)
 		}
-		var crossShardTestcase = []string{}
-		for _, value := range tempCrossShardTestcase.([]interface{}) {
-			temp, ok := value.(string)
-			if !ok {
-				log.Println("Failed to get crossshard testcase")
-				os.Exit(1)
-			}
-			crossShardTestcase = append(crossShardTestcase, temp)
+	}
+	logDir := opts.LogDir
+	if logDir == "" {
+		logDir, err = ioutil.TempDir("", "incognito-test")
+		if err != nil {
+			return false, err
 		}
+	}
 
-		log.Println("Begin to run Crossshard Testcase")
-		for _, crossShardTestcaseName := range crossShardTestcase {
-			log.Printf("Begin to run %+v Testcase \n", crossShardTestcaseName)
-			cmd := exec.Command("go", "test", "-run", crossShardTestcaseName)
-			msg, err := cmd.Output()
-			if err != nil {
-				panic("Failed to run test " + crossShardTestcaseName + " : " + string(msg))
-			} else {
-				log.Printf("%+v Message: %+v \n", crossShardTestcaseName, string(msg))
-			}
-		}
-	case "stake":
-		tempStakeTestcase, ok := testcaseData["stake"]
-		if !ok {
-			log.Println("Failed to get stake testcase")
-			os.Exit(0)
+	r := newRunner(opts.Incognito, logDir, topology, os.Stdout)
+	defer r.close()
+	start := time.Now()
+	results := []*result{}
+	failures := 0
+	for _, scenario := range scenarios {
+		fmt.Printf("=== %v (%v)\n", scenario.Name, scenario.file)
+		res := r.run(scenario)
+		results = append(results, res)
+		if res.err != nil {
+			failures++
+			fmt.Printf("--- FAIL %v (%.1fs): %v\n", scenario.Name, res.duration.Seconds(), res.err)
+		} else {
+			fmt.Printf("--- PASS %v (%.1fs)\n", scenario.Name, res.duration.Seconds())
 		}
-		var stakeTestcase = []string{}
-		for _, value := range tempStakeTestcase.([]interface{}) {
-			temp, ok := value.(string)
-			if !ok {
-				log.Println("Failed to get stake testcase")
-				os.Exit(1)
-			}
-			stakeTestcase = append(stakeTestcase, temp)
+	}
+	fmt.Printf("%v scenarios, %v failed, logs of the devnets in %v\n", len(results), failures, logDir)
+	if opts.JUnit != "" {
+		if err := writeJUnit(opts.JUnit, start, results); err != nil {
+			return false, err
 		}
+	}
+	return failures == 0, nil
+}
 
-		log.Println("Begin to run Stake Testcase")
-		for _, stakeTestcaseName := range stakeTestcase {
-			log.Printf("Begin to run %+v Testcase \n", stakeTestcaseName)
-			cmd := exec.Command("go", "test", "-run", stakeTestcaseName)
-			msg, err := cmd.Output()
+// readScenarios reads the scenario files of args, a directory gives its yaml files but not those of its
+// subdirectories, e.g. the scenarios of lib used by others
+func readScenarios(args []string) ([]*Scenario, error) {
+	files := []string{}
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		for _, pattern := range []string{"*.yaml", "*.yml"} {
+			matches, err := filepath.Glob(filepath.Join(arg, pattern))
 			if err != nil {
-				panic("Failed to run test " + stakeTestcaseName + " : " + string(msg))
-			} else {
-				log.Printf("%+v Message: %+v \n", stakeTestcaseName, string(msg))
+				return nil, err
 			}
+			sort.Strings(matches)
+			files = append(files, matches...)
+		}
+	}
+	scenarios := []*Scenario{}
+	for _, file := range files {
+		scenario, err := readScenario(file)
+		if err != nil {
+			return nil, err
 		}
-	default:
-		log.Println("Please choose the right test to run")
+		scenarios = append(scenarios, scenario)
 	}
+	return scenarios, nil
 }
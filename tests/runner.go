@@ -0,0 +1,293 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/devnet"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// scenarios using scenarios using scenarios... stop there, it is most likely a loop
+const maxUseDepth = 8
+
+// result of a scenario run
+type result struct {
+	scenario *Scenario
+	duration time.Duration
+	output   strings.Builder
+	err      error
+}
+
+// runner runs scenarios, each against the environment of its topology. An environment is started once and shared by
+// the scenarios of the same topology
+type runner struct {
+	incognito string
+	logDir    string
+	topology  *Topology // replaces the topology of every scenario when set
+	envs      map[string]*environment
+	out       io.Writer
+	runID     string // fresh keys of two runs differ
+}
+
+func newRunner(incognito string, logDir string, topology *Topology, out io.Writer) *runner {
+	return &runner{
+		incognito: incognito,
+		logDir:    logDir,
+		topology:  topology,
+		envs:      make(map[string]*environment),
+		out:       out,
+		runID:     fmt.Sprint(time.Now().UnixNano()),
+	}
+}
+
+func (r *runner) close() {
+	for _, env := range r.envs {
+		env.close()
+	}
+}
+
+// environment returns the running environment of topology, started on first use
+func (r *runner) environment(topology *Topology) (*environment, error) {
+	id, err := json.Marshal(topology)
+	if err != nil {
+		return nil, err
+	}
+	if env, ok := r.envs[string(id)]; ok {
+		return env, nil
+	}
+	var env *environment
+	if topology.Devnet != nil {
+		fmt.Fprintf(r.out, "Starting devnet %+v\n", *topology.Devnet)
+		env, err = startDevnet(r.incognito, topology.Devnet, r.logDir)
+	} else {
+		env, err = connectNodes(topology)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.envs[string(id)] = env
+	return env, nil
+}
+
+func (r *runner) run(scenario *Scenario) *result {
+	res := &result{scenario: scenario}
+	start := time.Now()
+	defer func() {
+		res.duration = time.Since(start)
+	}()
+	topology := r.topology
+	if topology == nil {
+		topology = scenario.Topology
+	}
+	if topology == nil {
+		res.err = fmt.Errorf("%v has no topology, set one with --topology", scenario.file)
+		return res
+	}
+	env, err := r.environment(topology)
+	if err != nil {
+		res.err = err
+		return res
+	}
+	x := &execution{
+		env:     env,
+		out:     io.MultiWriter(r.out, &res.output),
+		keySeed: r.runID + "/" + scenario.file,
+		used:    make(map[string]*Scenario),
+	}
+	vars := make(map[string]interface{})
+	for name, value := range env.vars {
+		vars[name] = value
+	}
+	res.err = x.runScenario(scenario, vars, 0)
+	return res
+}
+
+// execution runs the steps of a scenario and of the scenarios it uses
+type execution struct {
+	env     *environment
+	out     io.Writer
+	keySeed string
+	keys    int
+	used    map[string]*Scenario
+}
+
+func (x *execution) runScenario(scenario *Scenario, vars map[string]interface{}, depth int) error {
+	for _, item := range scenario.Vars {
+		value, err := interpolate(item.Value, vars)
+		if err != nil {
+			return fmt.Errorf("var %v: %v", item.Key, err)
+		}
+		vars[fmt.Sprint(item.Key)] = value
+	}
+	for i, step := range scenario.Steps {
+		start := time.Now()
+		err := x.runStep(scenario, step, vars, depth)
+		status := "ok  "
+		if err != nil {
+			status = "FAIL"
+		}
+		fmt.Fprintf(x.out, "%v%v %v (%.1fs)\n", strings.Repeat("  ", depth+1), status, step, time.Since(start).Seconds())
+		if err != nil {
+			return fmt.Errorf("step %v (%v): %v", i+1, step, err)
+		}
+	}
+	return nil
+}
+
+func (x *execution) runStep(scenario *Scenario, step *Step, vars map[string]interface{}, depth int) error {
+	switch {
+	case step.Sleep > 0:
+		time.Sleep(step.Sleep)
+		return nil
+	case step.Key != "":
+		return x.newKey(step, vars)
+	case step.Use != "":
+		return x.use(scenario, step, vars, depth)
+	}
+
+	node, err := x.env.node(step.Node)
+	if err != nil {
+		return err
+	}
+	interpolated, err := interpolate(step.Params, vars)
+	if err != nil {
+		return err
+	}
+	params, _ := interpolated.([]interface{})
+	var value interface{}
+	var callErr error
+	switch {
+	case step.Subscribe != "":
+		value, callErr = node.subscribe(step.Subscribe, params, step.timeout())
+	case len(step.Until) > 0:
+		value, callErr = poll(node, step, params, vars)
+	default:
+		value, callErr = node.call(step.Call, params)
+	}
+
+	expect := step.Expect
+	if expect == nil {
+		expect = &Expect{}
+	}
+	if err := checkError(callErr, expect.Error, vars); err != nil {
+		return err
+	}
+	if callErr != nil {
+		return nil
+	}
+	if err := checkThat(value, expect.That, vars); err != nil {
+		return err
+	}
+	for _, item := range step.Capture {
+		path, ok := item.Value.(string)
+		if !ok {
+			return fmt.Errorf("capture %v is not a json path", item.Key)
+		}
+		captured, err := evalPath(value, path)
+		if err != nil {
+			return fmt.Errorf("capture %v: %v %v", item.Key, path, err)
+		}
+		vars[fmt.Sprint(item.Key)] = captured
+	}
+	return nil
+}
+
+// poll calls the method of step until its result meets the until matchers, errors are retried until the timeout
+func poll(node *endpoint, step *Step, params []interface{}, vars map[string]interface{}) (interface{}, error) {
+	deadline := time.Now().Add(step.timeout())
+	for {
+		value, err := node.call(step.Call, params)
+		if err == nil {
+			err = checkThat(value, step.Until, vars)
+			if err == nil {
+				return value, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("not done after %v: %v", step.timeout(), err)
+		}
+		time.Sleep(step.interval())
+	}
+}
+
+// newKey sets the variable named by the step to a fresh key of its shard, or of any shard
+func (x *execution) newKey(step *Step, vars map[string]interface{}) error {
+	shards, err := x.env.shards()
+	if err != nil {
+		return err
+	}
+	shardID := -1
+	if step.Shard != nil {
+		shardID = *step.Shard
+		if shardID < 0 || shardID >= shards {
+			return fmt.Errorf("shard %v is not active", shardID)
+		}
+	}
+	x.keys++
+	keys, err := devnet.GenerateKeys(fmt.Sprintf("%v/%v", x.keySeed, x.keys), shards, shardID, 1)
+	if err != nil {
+		return err
+	}
+	key, err := toJSON(keys[0])
+	if err != nil {
+		return err
+	}
+	// committees and reward receivers of the beacon best state are keyed by the incognito public key
+	committeeKey := incognitokey.NewCommitteePublicKey()
+	if err := committeeKey.FromBase58(keys[0].CommitteePublicKey); err != nil {
+		return err
+	}
+	key.(map[string]interface{})["IncPubKey"] = committeeKey.GetIncKeyBase58()
+	vars[step.Key] = key
+	return nil
+}
+
+// use runs the scenario of the step, found relative to the using scenario. The used scenario sees the variables of
+// the environment and its inputs, set by with, and gives back its outputs
+func (x *execution) use(scenario *Scenario, step *Step, vars map[string]interface{}, depth int) error {
+	if depth >= maxUseDepth {
+		return fmt.Errorf("scenarios are used more than %v levels deep", maxUseDepth)
+	}
+	file := filepath.Join(filepath.Dir(scenario.file), step.Use)
+	used, ok := x.used[file]
+	if !ok {
+		var err error
+		used, err = readScenario(file)
+		if err != nil {
+			return err
+		}
+		x.used[file] = used
+	}
+	usedVars := make(map[string]interface{})
+	for name, value := range x.env.vars {
+		usedVars[name] = value
+	}
+	for name, value := range step.With {
+		interpolated, err := interpolate(value, vars)
+		if err != nil {
+			return fmt.Errorf("with %v: %v", name, err)
+		}
+		usedVars[name] = interpolated
+	}
+	for _, input := range used.Inputs {
+		if _, ok := usedVars[input]; !ok {
+			return fmt.Errorf("input %v of %v is not set", input, step.Use)
+		}
+	}
+	if err := x.runScenario(used, usedVars, depth+1); err != nil {
+		return err
+	}
+	for _, output := range used.Outputs {
+		value, ok := usedVars[output]
+		if !ok {
+			return fmt.Errorf("output %v of %v is not set", output, step.Use)
+		}
+		vars[output] = value
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeNode answers the rpc methods of the test scenario, a transaction is in a block after two polls
+func fakeNode(t *testing.T) *httptest.Server {
+	var mtx sync.Mutex
+	polls := 0
+	sent := map[string]float64{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		request := struct {
+			Method string
+			Params []interface{}
+		}{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+		response := map[string]interface{}{"Id": 1}
+		switch request.Method {
+		case "getactiveshards":
+			response["Result"] = 2
+		case "createandsendtransaction":
+			for address, amount := range request.Params[1].(map[string]interface{}) {
+				sent[address] += amount.(float64)
+			}
+			response["Result"] = map[string]interface{}{"TxID": "tx1", "ShardID": 0}
+		case "gettransactionbyhash":
+			polls++
+			response["Result"] = map[string]interface{}{"Hash": request.Params[0], "IsInBlock": polls > 2}
+		case "getbalancebyprivatekey":
+			response["Result"] = 0
+			if request.Params[0] == "rich" {
+				response["Result"] = 1000
+			}
+		case "getbalancebypaymentaddress":
+			response["Result"] = sent[request.Params[0].(string)]
+		default:
+			response["Error"] = map[string]interface{}{"Code": -1, "Message": "Method not found", "StackTrace": request.Method}
+		}
+		assert.Nil(t, json.NewEncoder(w).Encode(response))
+	}))
+}
+
+func writeFile(t *testing.T, file string, content string) {
+	assert.Nil(t, os.MkdirAll(filepath.Dir(file), 0755))
+	assert.Nil(t, ioutil.WriteFile(file, []byte(content), 0644))
+}
+
+func TestRunScenario(t *testing.T) {
+	server := fakeNode(t)
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "scenarios")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFile(t, filepath.Join(dir, "lib", "wait-tx.yaml"), `
+inputs: [txid]
+outputs: [hash]
+steps:
+  - call: gettransactionbyhash
+    params: ["${txid}"]
+    until:
+      $.IsInBlock: true
+    interval: 10ms
+    capture:
+      hash: $.Hash
+`)
+	writeFile(t, filepath.Join(dir, "send.yaml"), `
+name: send
+topology:
+  nodes:
+    beacon: {rpc: "`+server.URL+`"}
+  vars:
+    funded: {PrivateKey: rich}
+vars:
+  amount: 100
+steps:
+  - key: alice
+    shard: 1
+  - call: createandsendtransaction
+    params: ["${funded.PrivateKey}", {"${alice.PaymentAddress}": "${amount}"}, -1, 0]
+    capture:
+      txid: $.TxID
+  - use: lib/wait-tx.yaml
+    with: {txid: "${txid}"}
+  - name: receiver
+    call: getbalancebypaymentaddress
+    params: ["${alice.PaymentAddress}"]
+    expect:
+      that:
+        $: "${amount}"
+  - call: getbalancebyprivatekey
+    params: ["${hash}"]
+    expect:
+      that:
+        $: 0
+  - name: unknown method
+    call: nosuchmethod
+    expect:
+      error: {code: -1, contains: nosuchmethod}
+`)
+	writeFile(t, filepath.Join(dir, "fail.yaml"), `
+name: fail
+topology:
+  nodes:
+    beacon: {rpc: "`+server.URL+`"}
+  vars:
+    funded: {PrivateKey: rich}
+steps:
+  - call: getbalancebyprivatekey
+    params: [rich]
+    expect:
+      that:
+        $: {lt: 1000}
+`)
+
+	scenarios, err := readScenarios([]string{dir})
+	assert.Nil(t, err)
+	if !assert.Len(t, scenarios, 2) {
+		return
+	}
+	r := newRunner("", dir, nil, ioutil.Discard)
+	defer r.close()
+	start := time.Now()
+	failed := r.run(scenarios[0])
+	passed := r.run(scenarios[1])
+	assert.Nil(t, passed.err)
+	assert.Contains(t, passed.output.String(), "  ok   receiver")
+	assert.Contains(t, passed.output.String(), "    ok   gettransactionbyhash")
+	// same topology, same environment
+	assert.Len(t, r.envs, 1)
+	if assert.NotNil(t, failed.err) {
+		assert.Contains(t, failed.err.Error(), "step 1 (getbalancebyprivatekey): $: got 1000, want lt 1000")
+	}
+
+	file := filepath.Join(dir, "junit.xml")
+	assert.Nil(t, writeJUnit(file, start, []*result{passed, failed}))
+	data, err := ioutil.ReadFile(file)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `<testsuite name="scenarios" tests="2" failures="1"`)
+	assert.Equal(t, 1, strings.Count(string(data), "<failure "))
+}
+
+func TestReadScenarioErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scenarios")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	for name, content := range map[string]string{
+		"unknown-field.yaml": "steps:\n  - call: getblockcount\n    param: []\n",
+		"two-actions.yaml":   "steps:\n  - call: getblockcount\n    sleep: 1s\n",
+		"no-action.yaml":     "steps:\n  - name: nothing\n",
+		"until.yaml":         "steps:\n  - key: alice\n    until: {$: 1}\n",
+		"topology.yaml":      "topology:\n  devnet: {}\n  nodes: {beacon: {rpc: http://localhost:9334}}\nsteps: []\n",
+	} {
+		file := filepath.Join(dir, name)
+		writeFile(t, file, content)
+		_, err := readScenario(file)
+		assert.NotNil(t, err, name)
+	}
+}
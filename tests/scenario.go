@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	defaultTimeout  = 2 * time.Minute
+	defaultInterval = 2 * time.Second
+	defaultNode     = "beacon"
+)
+
+// Scenario is a yaml file of steps run against the nodes of a topology. A scenario used by another one declares its
+// inputs, set by the with of the using step, and its outputs, variables copied back to the using scenario
+type Scenario struct {
+	Name     string        `yaml:"name"`
+	Topology *Topology     `yaml:"topology"`
+	Vars     yaml.MapSlice `yaml:"vars"`
+	Inputs   []string      `yaml:"inputs"`
+	Outputs  []string      `yaml:"outputs"`
+	Steps    []*Step       `yaml:"steps"`
+
+	file string
+}
+
+// Step does one of: call an rpc method (polled until a condition when until is set), wait for the first message of a
+// websocket subscription, generate a fresh key, use another scenario or sleep
+type Step struct {
+	Name string `yaml:"name"`
+	Node string `yaml:"node"`
+
+	Call      string        `yaml:"call"`
+	Subscribe string        `yaml:"subscribe"`
+	Params    []interface{} `yaml:"params"`
+	Expect    *Expect       `yaml:"expect"`
+	Until     yaml.MapSlice `yaml:"until"`
+	Timeout   time.Duration `yaml:"timeout"`
+	Interval  time.Duration `yaml:"interval"`
+	Capture   yaml.MapSlice `yaml:"capture"`
+
+	Key   string `yaml:"key"`
+	Shard *int   `yaml:"shard"`
+
+	Use  string                 `yaml:"use"`
+	With map[string]interface{} `yaml:"with"`
+
+	Sleep time.Duration `yaml:"sleep"`
+}
+
+// Expect checks the response of a step. Error is absent for a call that must succeed, true for any error of the node,
+// or a map with the code and a part of the message of the expected error. That maps json paths of the result to
+// matchers
+type Expect struct {
+	Error interface{}   `yaml:"error"`
+	That  yaml.MapSlice `yaml:"that"`
+}
+
+// Topology is either a devnet started by the runner or the rpc endpoints of running nodes, by name. Vars of running
+// nodes are given to the scenarios, e.g. the funded accounts the devnet gives as accounts
+type Topology struct {
+	Devnet *DevnetTopology        `yaml:"devnet"`
+	Nodes  map[string]*Node       `yaml:"nodes"`
+	Vars   map[string]interface{} `yaml:"vars"`
+}
+
+type DevnetTopology struct {
+	Shards        int           `yaml:"shards"`
+	CommitteeSize int           `yaml:"committeesize"`
+	BlockTime     time.Duration `yaml:"blocktime"`
+	Accounts      int           `yaml:"accounts"`
+	Seed          string        `yaml:"seed"`
+	Archive       bool          `yaml:"archive"`
+}
+
+type Node struct {
+	RPC string `yaml:"rpc"`
+	WS  string `yaml:"ws"`
+}
+
+func readScenario(file string) (*Scenario, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	scenario := &Scenario{}
+	if err := yaml.UnmarshalStrict(data, scenario); err != nil {
+		return nil, fmt.Errorf("%v: %v", file, err)
+	}
+	scenario.file = file
+	if scenario.Name == "" {
+		scenario.Name = filepath.Base(file)
+	}
+	if err := scenario.validate(); err != nil {
+		return nil, fmt.Errorf("%v: %v", file, err)
+	}
+	return scenario, nil
+}
+
+func (scenario *Scenario) validate() error {
+	if scenario.Topology != nil {
+		if (scenario.Topology.Devnet == nil) == (len(scenario.Topology.Nodes) == 0) {
+			return errors.New("topology is either a devnet or nodes")
+		}
+		for name, node := range scenario.Topology.Nodes {
+			if node == nil || node.RPC == "" {
+				return fmt.Errorf("node %v has no rpc endpoint", name)
+			}
+		}
+	}
+	for i, step := range scenario.Steps {
+		actions := 0
+		for _, set := range []bool{step.Call != "", step.Subscribe != "", step.Key != "", step.Use != "", step.Sleep > 0} {
+			if set {
+				actions++
+			}
+		}
+		if actions != 1 {
+			return fmt.Errorf("step %v does not have exactly one of call, subscribe, key, use and sleep", i+1)
+		}
+		if len(step.Until) > 0 && step.Call == "" {
+			return fmt.Errorf("step %v: until polls a call", i+1)
+		}
+	}
+	return nil
+}
+
+// name of the step in logs and failures
+func (step *Step) String() string {
+	if step.Name != "" {
+		return step.Name
+	}
+	switch {
+	case step.Call != "":
+		return step.Call
+	case step.Subscribe != "":
+		return step.Subscribe
+	case step.Key != "":
+		return "key " + step.Key
+	case step.Use != "":
+		return "use " + step.Use
+	}
+	return "sleep " + step.Sleep.String()
+}
+
+func (step *Step) timeout() time.Duration {
+	if step.Timeout > 0 {
+		return step.Timeout
+	}
+	return defaultTimeout
+}
+
+func (step *Step) interval() time.Duration {
+	if step.Interval > 0 {
+		return step.Interval
+	}
+	return defaultInterval
+}
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/devnet"
+	"github.com/incognitochain/incognito-chain/rpcclient"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+const (
+	devnetStartTimeout = 2 * time.Minute
+	devnetStopTimeout  = 30 * time.Second
+	// blocks of every chain before the devnet is ready, the first block after genesis takes longer
+	devnetReadyHeight = 2
+)
+
+// nodeError is an error returned by the node, as opposed to a network error
+type nodeError struct {
+	Code       int
+	Message    string
+	StackTrace string
+}
+
+func (e *nodeError) Error() string {
+	detail := strings.SplitN(strings.TrimSpace(e.StackTrace), "\n", 2)[0]
+	return fmt.Sprintf("%v: %v %v", e.Code, e.Message, detail)
+}
+
+// endpoint calls the rpc methods of a node
+type endpoint struct {
+	client *rpcclient.Client
+	wsURL  string
+	mtx    sync.Mutex
+	ws     *rpcclient.WsClient
+}
+
+func newEndpoint(node *Node) *endpoint {
+	return &endpoint{client: rpcclient.NewClient(node.RPC), wsURL: node.WS}
+}
+
+func (e *endpoint) call(method string, params []interface{}) (interface{}, error) {
+	response, err := e.client.CallRaw(method, params)
+	if rpcErr, ok := err.(*rpcservice.RPCError); ok {
+		return nil, &nodeError{Code: rpcErr.Code, Message: rpcErr.Message, StackTrace: rpcErr.StackTrace}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON(response.Result)
+}
+
+// subscribe returns the first message of a websocket subscription
+func (e *endpoint) subscribe(method string, params []interface{}, timeout time.Duration) (interface{}, error) {
+	ws, err := e.wsClient()
+	if err != nil {
+		return nil, err
+	}
+	type message struct {
+		raw json.RawMessage
+		err error
+	}
+	messages := make(chan message, 1)
+	subscription, err := ws.Subscribe(method, params, func(raw json.RawMessage, err error) {
+		select {
+		case messages <- message{raw: raw, err: err}:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer subscription.Unsubscribe()
+	select {
+	case m := <-messages:
+		if rpcErr, ok := m.err.(*rpcservice.RPCError); ok {
+			return nil, &nodeError{Code: rpcErr.Code, Message: rpcErr.Message, StackTrace: rpcErr.StackTrace}
+		}
+		if m.err != nil {
+			return nil, m.err
+		}
+		return decodeJSON(m.raw)
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("no message of %v after %v", method, timeout)
+	}
+}
+
+func (e *endpoint) wsClient() (*rpcclient.WsClient, error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.wsURL == "" {
+		return nil, errors.New("the node has no websocket endpoint")
+	}
+	if e.ws == nil {
+		ws, err := rpcclient.NewWsClient(e.wsURL)
+		if err != nil {
+			return nil, err
+		}
+		e.ws = ws
+	}
+	return e.ws, nil
+}
+
+func (e *endpoint) close() {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.ws != nil {
+		e.ws.Close()
+	}
+}
+
+// environment is a running topology: its nodes by name and the variables it gives to the scenarios
+type environment struct {
+	nodes        map[string]*endpoint
+	vars         map[string]interface{}
+	activeShards int
+	stop         func()
+}
+
+// node returns the node of a step, the beacon node or the only node when the step does not name one
+func (env *environment) node(name string) (*endpoint, error) {
+	if name == "" {
+		if len(env.nodes) == 1 {
+			for _, node := range env.nodes {
+				return node, nil
+			}
+		}
+		name = defaultNode
+	}
+	node, ok := env.nodes[name]
+	if !ok {
+		return nil, fmt.Errorf("topology has no node %v", name)
+	}
+	return node, nil
+}
+
+func (env *environment) shards() (int, error) {
+	if env.activeShards > 0 {
+		return env.activeShards, nil
+	}
+	node, err := env.node("")
+	if err != nil {
+		return 0, err
+	}
+	result, err := node.call("getactiveshards", nil)
+	if err != nil {
+		return 0, err
+	}
+	shards, ok := toNumber(result, false)
+	if !ok {
+		return 0, fmt.Errorf("getactiveshards returned %v", formatValue(result))
+	}
+	count, _ := shards.Int64()
+	env.activeShards = int(count)
+	return env.activeShards, nil
+}
+
+func (env *environment) close() {
+	for _, node := range env.nodes {
+		node.close()
+	}
+	if env.stop != nil {
+		env.stop()
+	}
+}
+
+// connectNodes is the environment of running nodes
+func connectNodes(topology *Topology) (*environment, error) {
+	env := &environment{nodes: make(map[string]*endpoint), vars: make(map[string]interface{})}
+	for name, node := range topology.Nodes {
+		env.nodes[name] = newEndpoint(node)
+	}
+	for name, value := range topology.Vars {
+		env.vars[name] = normalize(value)
+	}
+	return env, nil
+}
+
+// startDevnet starts a devnet node of topology, its beacon and shards are named beacon and shard0, shard1... The
+// accounts funded in its genesis block are the variable accounts
+func startDevnet(incognito string, topology *DevnetTopology, logDir string) (*environment, error) {
+	config := devnet.Config{
+		Shards:        topology.Shards,
+		CommitteeSize: topology.CommitteeSize,
+		BlockTime:     topology.BlockTime,
+		Accounts:      topology.Accounts,
+		Balance:       devnet.DefaultBalance,
+		Seed:          topology.Seed,
+	}
+	if config.Shards == 0 {
+		config.Shards = devnet.DefaultShards
+	}
+	if config.CommitteeSize == 0 {
+		config.CommitteeSize = devnet.DefaultCommitteeSize
+	}
+	if config.BlockTime == 0 {
+		config.BlockTime = devnet.DefaultBlockTime
+	}
+	if config.Accounts == 0 {
+		config.Accounts = devnet.DefaultAccounts
+	}
+	if config.Seed == "" {
+		config.Seed = devnet.DefaultSeed
+	}
+	// the node generates the same keys from the same config
+	d, err := devnet.New(config)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := toJSON(d.Accounts())
+	if err != nil {
+		return nil, err
+	}
+
+	rpcPort, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	wsPort, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	logDir = filepath.Join(logDir, fmt.Sprintf("devnet-%v", rpcPort))
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return nil, err
+	}
+	logFile, err := os.Create(filepath.Join(logDir, "output.log"))
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"devnet",
+		"--devnetshards", strconv.Itoa(config.Shards),
+		"--devnetcommitteesize", strconv.Itoa(config.CommitteeSize),
+		"--devnetblocktime", config.BlockTime.String(),
+		"--devnetaccounts", strconv.Itoa(config.Accounts),
+		"--devnetseed", config.Seed,
+		"--rpclisten", fmt.Sprintf("127.0.0.1:%v", rpcPort),
+		"--rpcwslisten", fmt.Sprintf("127.0.0.1:%v", wsPort),
+		"--logdir", logDir,
+	}
+	if topology.Archive {
+		args = append(args, "--archive")
+	}
+	cmd := exec.Command(incognito, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		logFile.Close()
+		return nil, err
+	}
+	exited := make(chan struct{})
+	go func() {
+		cmd.Wait()
+		logFile.Close()
+		close(exited)
+	}()
+	stop := func() {
+		// the devnet removes its data directory on interrupt
+		cmd.Process.Signal(os.Interrupt)
+		select {
+		case <-exited:
+		case <-time.After(devnetStopTimeout):
+			cmd.Process.Kill()
+			<-exited
+		}
+	}
+
+	node := newEndpoint(&Node{
+		RPC: fmt.Sprintf("http://127.0.0.1:%v", rpcPort),
+		WS:  fmt.Sprintf("ws://127.0.0.1:%v", wsPort),
+	})
+	env := &environment{
+		nodes:        map[string]*endpoint{defaultNode: node},
+		vars:         map[string]interface{}{"accounts": accounts},
+		activeShards: config.Shards,
+		stop:         stop,
+	}
+	for shardID := 0; shardID < config.Shards; shardID++ {
+		env.nodes[fmt.Sprintf("shard%v", shardID)] = node
+	}
+	if err := waitDevnet(node, config.Shards, exited); err != nil {
+		env.close()
+		return nil, fmt.Errorf("devnet did not start, see %v: %v", logFile.Name(), err)
+	}
+	return env, nil
+}
+
+// waitDevnet waits for blocks on every chain of the devnet
+func waitDevnet(node *endpoint, shards int, exited chan struct{}) error {
+	deadline := time.Now().Add(devnetStartTimeout)
+	var err error
+	for time.Now().Before(deadline) {
+		select {
+		case <-exited:
+			return errors.New("the node exited")
+		case <-time.After(time.Second):
+		}
+		var info interface{}
+		info, err = node.call("getblockchaininfo", nil)
+		if err != nil {
+			continue
+		}
+		ready := true
+		for chainID := -1; chainID < shards; chainID++ {
+			height, pathErr := evalPath(info, fmt.Sprintf("$.BestBlocks['%v'].Height", chainID))
+			if pathErr != nil {
+				ready = false
+				break
+			}
+			if h, ok := toNumber(height, false); !ok || h.Cmp(big.NewFloat(devnetReadyHeight)) < 0 {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			return nil
+		}
+		err = errors.New("the chains have no block yet")
+	}
+	return fmt.Errorf("not ready after %v: %v", devnetStartTimeout, err)
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
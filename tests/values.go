@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ${expression} in strings of scenarios, the expression is a json path in the variables without its leading $
+var varPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// normalize turns yaml maps into json objects, keys are strings
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		object := make(map[string]interface{})
+		for _, item := range v {
+			object[fmt.Sprint(item.Key)] = normalize(item.Value)
+		}
+		return object
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{})
+		for key, item := range v {
+			object[fmt.Sprint(key)] = normalize(item)
+		}
+		return object
+	case map[string]interface{}:
+		object := make(map[string]interface{})
+		for key, item := range v {
+			object[key] = normalize(item)
+		}
+		return object
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = normalize(item)
+		}
+		return list
+	}
+	return value
+}
+
+// decodeJSON decodes an rpc result, numbers are kept as json.Number to compare amounts exactly
+func decodeJSON(raw []byte) (interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// toJSON converts a go value, e.g. a struct, to the values of decodeJSON
+func toJSON(value interface{}) (interface{}, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON(raw)
+}
+
+// interpolate replaces ${expression} in the strings of value by the variables. A string that is only ${expression}
+// becomes the value of the expression with its type, e.g. a number or an object
+func interpolate(value interface{}, vars map[string]interface{}) (interface{}, error) {
+	switch v := normalize(value).(type) {
+	case string:
+		return interpolateString(v, vars)
+	case map[string]interface{}:
+		// keys too, e.g. the payment addresses of receivers
+		object := make(map[string]interface{})
+		for key, item := range v {
+			interpolatedKey, err := interpolateString(key, vars)
+			if err != nil {
+				return nil, err
+			}
+			result, err := interpolate(item, vars)
+			if err != nil {
+				return nil, err
+			}
+			object[formatValue(interpolatedKey)] = result
+		}
+		return object, nil
+	case []interface{}:
+		for i, item := range v {
+			result, err := interpolate(item, vars)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = result
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+func interpolateString(s string, vars map[string]interface{}) (interface{}, error) {
+	if match := varPattern.FindStringSubmatch(s); match != nil && match[0] == s {
+		return lookupVar(vars, match[1])
+	}
+	var err error
+	result := varPattern.ReplaceAllStringFunc(s, func(expression string) string {
+		value, lookupErr := lookupVar(vars, varPattern.FindStringSubmatch(expression)[1])
+		if lookupErr != nil {
+			err = lookupErr
+			return expression
+		}
+		return formatValue(value)
+	})
+	return result, err
+}
+
+func lookupVar(vars map[string]interface{}, expression string) (interface{}, error) {
+	path := "$." + expression
+	if strings.HasPrefix(expression, "[") {
+		path = "$" + expression
+	}
+	value, err := evalPath(vars, path)
+	if err == errPathNotFound {
+		return nil, fmt.Errorf("variable %v is not set", expression)
+	}
+	return value, err
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	case map[string]interface{}, []interface{}:
+		raw, _ := json.Marshal(v)
+		return string(raw)
+	}
+	return fmt.Sprint(value)
+}
+
+// toNumber returns the number of json numbers, yaml numbers and, when numericStrings is set, strings of a number
+func toNumber(value interface{}, numericStrings bool) (*big.Float, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		f, ok := new(big.Float).SetString(v.String())
+		return f, ok
+	case int:
+		return new(big.Float).SetInt64(int64(v)), true
+	case int64:
+		return new(big.Float).SetInt64(v), true
+	case uint64:
+		return new(big.Float).SetUint64(v), true
+	case float64:
+		return big.NewFloat(v), true
+	case string:
+		if numericStrings {
+			f, ok := new(big.Float).SetString(v)
+			return f, ok
+		}
+	}
+	return nil, false
+}
+
+// equalValues compares json values, numbers by value whatever their go type
+func equalValues(a interface{}, b interface{}) bool {
+	if x, ok := toNumber(a, false); ok {
+		y, ok := toNumber(b, false)
+		return ok && x.Cmp(y) == 0
+	}
+	switch x := a.(type) {
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, item := range x {
+			if other, ok := y[key]; !ok || !equalValues(item, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equalValues(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	UnexpectedError = iota
+	InvalidTraceParentError
+	UnknownExporterError
+	ExportSpansError
+)
+
+var ErrCodeMessage = map[int]struct {
+	Code    int
+	Message string
+}{
+	UnexpectedError:         {-1000, "Unexpected Error"},
+	InvalidTraceParentError: {-1001, "Invalid Trace Parent Error"},
+	UnknownExporterError:    {-1002, "Unknown Span Exporter Error"},
+	ExportSpansError:        {-1003, "Export Spans Error"},
+}
+
+type TracingError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e TracingError) Error() string {
+	return fmt.Sprintf("%d: %s %+v", e.Code, e.Message, e.Err)
+}
+
+func NewTracingError(key int, err error) *TracingError {
+	return &TracingError{
+		Code:    ErrCodeMessage[key].Code,
+		Message: ErrCodeMessage[key].Message,
+		Err:     errors.Wrap(err, ErrCodeMessage[key].Message),
+	}
+}
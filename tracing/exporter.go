@@ -0,0 +1,106 @@
+package tracing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	ExporterOTLP   = "otlp"
+	ExporterFile   = "file"
+	ExporterStdout = "stdout"
+)
+
+// Exporter ship batches of finished spans out of the process
+type Exporter interface {
+	ExportSpans(serviceName string, spans []*SpanData) error
+	Shutdown() error
+}
+
+// NewExporter build exporter by name, target is the OTLP endpoint for otlp and the file path for file
+func NewExporter(name string, target string) (Exporter, error) {
+	switch name {
+	case ExporterOTLP:
+		return NewOTLPExporter(target), nil
+	case ExporterFile:
+		return NewFileExporter(target)
+	case ExporterStdout:
+		return NewWriterExporter(os.Stdout), nil
+	default:
+		return nil, NewTracingError(UnknownExporterError, fmt.Errorf("exporter %+v", name))
+	}
+}
+
+// jsonSpan is the line format of writer exporters, one span per line
+type jsonSpan struct {
+	Service      string                 `json:"service"`
+	TraceID      string                 `json:"traceId"`
+	SpanID       string                 `json:"spanId"`
+	ParentSpanID string                 `json:"parentSpanId,omitempty"`
+	Name         string                 `json:"name"`
+	Start        time.Time              `json:"start"`
+	DurationMs   float64                `json:"durationMs"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+}
+
+type writerExporter struct {
+	mtx    sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewWriterExporter write spans as JSON lines to w, used for stdout exporter
+func NewWriterExporter(w io.Writer) Exporter {
+	return &writerExporter{w: w}
+}
+
+// NewFileExporter append spans as JSON lines to file at path, for offline analysis
+func NewFileExporter(path string) (Exporter, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, NewTracingError(UnexpectedError, err)
+	}
+	return &writerExporter{w: f, closer: f}, nil
+}
+
+func (e *writerExporter) ExportSpans(serviceName string, spans []*SpanData) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	encoder := json.NewEncoder(e.w)
+	for _, span := range spans {
+		line := jsonSpan{
+			Service:    serviceName,
+			TraceID:    span.SpanContext.TraceID.String(),
+			SpanID:     span.SpanContext.SpanID.String(),
+			Name:       span.Name,
+			Start:      span.StartTime,
+			DurationMs: float64(span.EndTime.Sub(span.StartTime)) / float64(time.Millisecond),
+			Error:      span.Err,
+		}
+		if span.ParentSpanID != (SpanID{}) {
+			line.ParentSpanID = span.ParentSpanID.String()
+		}
+		if len(span.Attributes) > 0 {
+			line.Attributes = make(map[string]interface{})
+			for _, attribute := range span.Attributes {
+				line.Attributes[attribute.Key] = attribute.Value
+			}
+		}
+		if err := encoder.Encode(line); err != nil {
+			return NewTracingError(ExportSpansError, err)
+		}
+	}
+	return nil
+}
+
+func (e *writerExporter) Shutdown() error {
+	if e.closer != nil {
+		return e.closer.Close()
+	}
+	return nil
+}
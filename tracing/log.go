@@ -0,0 +1,14 @@
+package tracing
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type TracingLogger struct {
+	log common.Logger
+}
+
+func (tracingLogger *TracingLogger) Init(inst common.Logger) {
+	tracingLogger.log = inst
+}
+
+// Global instant to use
+var Logger = TracingLogger{}
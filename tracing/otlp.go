@@ -0,0 +1,159 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultOTLPEndpoint  = "http://localhost:4318"
+	otlpTracesPath       = "/v1/traces"
+	otlpSpanKindInternal = 1
+	otlpStatusCodeError  = 2
+)
+
+// otlpExporter send spans to an OpenTelemetry collector using OTLP/HTTP with JSON encoding
+type otlpExporter struct {
+	url    string
+	client *http.Client
+}
+
+func NewOTLPExporter(endpoint string) Exporter {
+	if endpoint == "" {
+		endpoint = DefaultOTLPEndpoint
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	if !strings.HasSuffix(endpoint, otlpTracesPath) {
+		endpoint += otlpTracesPath
+	}
+	return &otlpExporter{
+		url:    endpoint,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpScopeSpans struct {
+	Scope struct {
+		Name string `json:"name"`
+	} `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpResourceSpans struct {
+	Resource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	} `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpTraceRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+func toOTLPValue(v interface{}) otlpAnyValue {
+	switch value := v.(type) {
+	case string:
+		return otlpAnyValue{StringValue: &value}
+	case int64:
+		s := strconv.FormatInt(value, 10)
+		return otlpAnyValue{IntValue: &s}
+	case bool:
+		return otlpAnyValue{BoolValue: &value}
+	case float64:
+		return otlpAnyValue{DoubleValue: &value}
+	default:
+		s := fmt.Sprintf("%+v", value)
+		return otlpAnyValue{StringValue: &s}
+	}
+}
+
+func buildOTLPRequest(serviceName string, spans []*SpanData) *otlpTraceRequest {
+	resourceSpans := otlpResourceSpans{}
+	resourceSpans.Resource.Attributes = []otlpKeyValue{{Key: "service.name", Value: toOTLPValue(serviceName)}}
+	scopeSpans := otlpScopeSpans{}
+	scopeSpans.Scope.Name = DefaultServiceName
+	for _, span := range spans {
+		s := otlpSpan{
+			TraceID:           span.SpanContext.TraceID.String(),
+			SpanID:            span.SpanContext.SpanID.String(),
+			Name:              span.Name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(span.StartTime.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+		}
+		if span.ParentSpanID != (SpanID{}) {
+			s.ParentSpanID = span.ParentSpanID.String()
+		}
+		for _, attribute := range span.Attributes {
+			s.Attributes = append(s.Attributes, otlpKeyValue{Key: attribute.Key, Value: toOTLPValue(attribute.Value)})
+		}
+		if span.Err != "" {
+			s.Status = otlpStatus{Code: otlpStatusCodeError, Message: span.Err}
+		}
+		scopeSpans.Spans = append(scopeSpans.Spans, s)
+	}
+	resourceSpans.ScopeSpans = []otlpScopeSpans{scopeSpans}
+	return &otlpTraceRequest{ResourceSpans: []otlpResourceSpans{resourceSpans}}
+}
+
+func (e *otlpExporter) ExportSpans(serviceName string, spans []*SpanData) error {
+	body, err := json.Marshal(buildOTLPRequest(serviceName, spans))
+	if err != nil {
+		return NewTracingError(ExportSpansError, err)
+	}
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return NewTracingError(ExportSpansError, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return NewTracingError(ExportSpansError, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return NewTracingError(ExportSpansError, fmt.Errorf("collector %+v return status %+v", e.url, resp.Status))
+	}
+	return nil
+}
+
+func (e *otlpExporter) Shutdown() error {
+	return nil
+}
@@ -0,0 +1,224 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+type TraceID [16]byte
+type SpanID [8]byte
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+// SpanContext identifies a span across process boundaries
+// it is encoded as a W3C traceparent header when it travels inside network messages
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{} && sc.SpanID != SpanID{}
+}
+
+// TraceParent return W3C traceparent representation of span context: version-traceid-spanid-flags
+func (sc SpanContext) TraceParent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceParent decode W3C traceparent string into span context
+func ParseTraceParent(traceParent string) (SpanContext, error) {
+	sc := SpanContext{}
+	parts := strings.Split(traceParent, "-")
+	if len(parts) != 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, NewTracingError(InvalidTraceParentError, fmt.Errorf("malformed traceparent %+v", traceParent))
+	}
+	if parts[0] == "ff" {
+		return sc, NewTracingError(InvalidTraceParentError, fmt.Errorf("unsupported traceparent version %+v", parts[0]))
+	}
+	traceID, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return sc, NewTracingError(InvalidTraceParentError, err)
+	}
+	spanID, err := hex.DecodeString(parts[2])
+	if err != nil {
+		return sc, NewTracingError(InvalidTraceParentError, err)
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return sc, NewTracingError(InvalidTraceParentError, err)
+	}
+	copy(sc.TraceID[:], traceID)
+	copy(sc.SpanID[:], spanID)
+	sc.Sampled = flags[0]&1 == 1
+	if !sc.IsValid() {
+		return sc, NewTracingError(InvalidTraceParentError, fmt.Errorf("zero trace id or span id in %+v", traceParent))
+	}
+	return sc, nil
+}
+
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key string, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: int64(value)}
+}
+
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Uint64(key string, value uint64) Attribute {
+	return Attribute{Key: key, Value: int64(value)}
+}
+
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// SpanData is the immutable snapshot of a finished span handed to exporters
+type SpanData struct {
+	Name         string
+	SpanContext  SpanContext
+	ParentSpanID SpanID
+	Remote       bool
+	StartTime    time.Time
+	EndTime      time.Time
+	Attributes   []Attribute
+	Err          string
+}
+
+// Span records one timed operation. A nil *Span is valid and does nothing,
+// which keeps call sites free of checks when tracing is disabled.
+type Span struct {
+	mtx    sync.Mutex
+	tracer *Tracer
+	data   SpanData
+	ended  bool
+}
+
+func (span *Span) Context() SpanContext {
+	if span == nil {
+		return SpanContext{}
+	}
+	return span.data.SpanContext
+}
+
+func (span *Span) SetAttributes(attributes ...Attribute) {
+	if span == nil {
+		return
+	}
+	span.mtx.Lock()
+	defer span.mtx.Unlock()
+	span.data.Attributes = append(span.data.Attributes, attributes...)
+}
+
+// SetError mark span as failed, nil error is ignored
+func (span *Span) SetError(err error) {
+	if span == nil || err == nil {
+		return
+	}
+	span.mtx.Lock()
+	defer span.mtx.Unlock()
+	span.data.Err = err.Error()
+}
+
+func (span *Span) End() {
+	if span == nil {
+		return
+	}
+	span.mtx.Lock()
+	if span.ended {
+		span.mtx.Unlock()
+		return
+	}
+	span.ended = true
+	span.data.EndTime = time.Now()
+	data := span.data
+	span.mtx.Unlock()
+	if data.SpanContext.Sampled {
+		span.tracer.enqueue(&data)
+	}
+}
+
+type spanContextKey struct{}
+
+type currentSpanContext struct {
+	sc     SpanContext
+	remote bool
+}
+
+// ContextWithSpan return a copy of ctx carrying span as the current span
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey{}, currentSpanContext{sc: span.Context()})
+}
+
+// ContextWithRemoteParent return a copy of ctx whose parent is the span encoded in traceParent,
+// an invalid traceParent leaves ctx untouched
+func ContextWithRemoteParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	sc, err := ParseTraceParent(traceParent)
+	if err != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey{}, currentSpanContext{sc: sc, remote: true})
+}
+
+// SpanContextFromContext return the span context current in ctx and whether it come from a remote node
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	if ctx == nil {
+		return SpanContext{}, false
+	}
+	if current, ok := ctx.Value(spanContextKey{}).(currentSpanContext); ok {
+		return current.sc, current.remote
+	}
+	return SpanContext{}, false
+}
+
+// TraceParentFromContext return the W3C traceparent of the current span in ctx, empty if there is none
+func TraceParentFromContext(ctx context.Context) string {
+	sc, _ := SpanContextFromContext(ctx)
+	return sc.TraceParent()
+}
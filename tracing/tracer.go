@@ -0,0 +1,252 @@
+package tracing
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	DefaultServiceName    = "incognito-chain"
+	DefaultBatchSize      = 512
+	DefaultQueueSize      = 4096
+	DefaultFlushInterval  = 5 * time.Second
+	DefaultBlockCacheSize = 2048
+)
+
+type Config struct {
+	ServiceName   string
+	Exporter      Exporter
+	SampleRatio   float64 // 0 or greater than 1 means sample every trace
+	BatchSize     int
+	QueueSize     int
+	FlushInterval time.Duration
+}
+
+// Tracer batches finished spans and hands them to an exporter in background
+type Tracer struct {
+	serviceName   string
+	exporter      Exporter
+	sampleRatio   float64
+	batchSize     int
+	flushInterval time.Duration
+	queue         chan *SpanData
+	blockContexts *lru.Cache // block hash -> SpanContext
+	stopCh        chan struct{}
+	doneCh        chan struct{}
+}
+
+var (
+	globalTracer *Tracer
+	globalMtx    sync.RWMutex
+)
+
+func NewTracer(config Config) (*Tracer, error) {
+	if config.ServiceName == "" {
+		config.ServiceName = DefaultServiceName
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = DefaultQueueSize
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = DefaultFlushInterval
+	}
+	blockContexts, err := lru.New(DefaultBlockCacheSize)
+	if err != nil {
+		return nil, NewTracingError(UnexpectedError, err)
+	}
+	tracer := &Tracer{
+		serviceName:   config.ServiceName,
+		exporter:      config.Exporter,
+		sampleRatio:   config.SampleRatio,
+		batchSize:     config.BatchSize,
+		flushInterval: config.FlushInterval,
+		queue:         make(chan *SpanData, config.QueueSize),
+		blockContexts: blockContexts,
+		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+	go tracer.run()
+	return tracer, nil
+}
+
+// Init install tracer built from config as the process wide tracer
+func Init(config Config) error {
+	tracer, err := NewTracer(config)
+	if err != nil {
+		return err
+	}
+	globalMtx.Lock()
+	old := globalTracer
+	globalTracer = tracer
+	globalMtx.Unlock()
+	if old != nil {
+		old.Shutdown()
+	}
+	return nil
+}
+
+// Shutdown flush pending spans of the process wide tracer and disable tracing
+func Shutdown() {
+	globalMtx.Lock()
+	tracer := globalTracer
+	globalTracer = nil
+	globalMtx.Unlock()
+	if tracer != nil {
+		tracer.Shutdown()
+	}
+}
+
+func getTracer() *Tracer {
+	globalMtx.RLock()
+	defer globalMtx.RUnlock()
+	return globalTracer
+}
+
+func IsEnabled() bool {
+	return getTracer() != nil
+}
+
+// StartSpan start a span named name as child of the span current in ctx (local or remote).
+// When tracing is disabled it returns ctx and a nil span, both are safe to use.
+func StartSpan(ctx context.Context, name string, attributes ...Attribute) (context.Context, *Span) {
+	tracer := getTracer()
+	if tracer == nil {
+		return ctx, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	span := tracer.newSpan(ctx, name, attributes)
+	return ContextWithSpan(ctx, span), span
+}
+
+func (tracer *Tracer) newSpan(ctx context.Context, name string, attributes []Attribute) *Span {
+	parent, remote := SpanContextFromContext(ctx)
+	sc := SpanContext{SpanID: newSpanID()}
+	if parent.IsValid() {
+		sc.TraceID = parent.TraceID
+		sc.Sampled = parent.Sampled
+	} else {
+		sc.TraceID = newTraceID()
+		sc.Sampled = tracer.shouldSample(sc.TraceID)
+	}
+	span := &Span{
+		tracer: tracer,
+		data: SpanData{
+			Name:        name,
+			SpanContext: sc,
+			Remote:      remote,
+			StartTime:   time.Now(),
+			Attributes:  attributes,
+		},
+	}
+	if parent.IsValid() {
+		span.data.ParentSpanID = parent.SpanID
+	}
+	return span
+}
+
+// shouldSample decide from trace id so every node keeps or drops the same traces
+func (tracer *Tracer) shouldSample(traceID TraceID) bool {
+	if tracer.sampleRatio <= 0 || tracer.sampleRatio >= 1 {
+		return true
+	}
+	bound := uint64(tracer.sampleRatio * float64(^uint64(0)>>1))
+	return binary.BigEndian.Uint64(traceID[8:])>>1 < bound
+}
+
+func (tracer *Tracer) enqueue(data *SpanData) {
+	select {
+	case tracer.queue <- data:
+	default:
+		Logger.log.Debugf("Tracing queue is full, drop span %+v", data.Name)
+	}
+}
+
+func (tracer *Tracer) run() {
+	defer close(tracer.doneCh)
+	ticker := time.NewTicker(tracer.flushInterval)
+	defer ticker.Stop()
+	batch := make([]*SpanData, 0, tracer.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if tracer.exporter != nil {
+			if err := tracer.exporter.ExportSpans(tracer.serviceName, batch); err != nil {
+				Logger.log.Error(err)
+			}
+		}
+		batch = make([]*SpanData, 0, tracer.batchSize)
+	}
+	for {
+		select {
+		case data := <-tracer.queue:
+			batch = append(batch, data)
+			if len(batch) >= tracer.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-tracer.stopCh:
+			for {
+				select {
+				case data := <-tracer.queue:
+					batch = append(batch, data)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (tracer *Tracer) Shutdown() {
+	select {
+	case <-tracer.stopCh:
+		return
+	default:
+		close(tracer.stopCh)
+	}
+	<-tracer.doneCh
+	if tracer.exporter != nil {
+		if err := tracer.exporter.Shutdown(); err != nil {
+			Logger.log.Error(err)
+		}
+	}
+}
+
+// RememberBlock bind the lifecycle of a block to the span context that first saw it.
+// Later stages that only know the block (vote, commit, insert) use ContextForBlock to join the same trace.
+func RememberBlock(blockHash string, ctx context.Context) {
+	tracer := getTracer()
+	if tracer == nil {
+		return
+	}
+	sc, _ := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	tracer.blockContexts.ContainsOrAdd(blockHash, sc)
+}
+
+// ContextForBlock return a context whose current span is the one bound to blockHash by RememberBlock,
+// or context.Background if the block is unknown
+func ContextForBlock(blockHash string) context.Context {
+	tracer := getTracer()
+	if tracer == nil {
+		return context.Background()
+	}
+	if v, ok := tracer.blockContexts.Get(blockHash); ok {
+		return context.WithValue(context.Background(), spanContextKey{}, currentSpanContext{sc: v.(SpanContext)})
+	}
+	return context.Background()
+}
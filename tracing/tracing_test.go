@@ -0,0 +1,164 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func init() {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+type memoryExporter struct {
+	mtx   sync.Mutex
+	spans []*SpanData
+}
+
+func (e *memoryExporter) ExportSpans(serviceName string, spans []*SpanData) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *memoryExporter) Shutdown() error {
+	return nil
+}
+
+func TestTraceParentRoundTrip(t *testing.T) {
+	sc := SpanContext{TraceID: newTraceID(), SpanID: newSpanID(), Sampled: true}
+	decoded, err := ParseTraceParent(sc.TraceParent())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded != sc {
+		t.Errorf("expect %+v got %+v", sc, decoded)
+	}
+	for _, invalid := range []string{
+		"",
+		"00-abc-def-01",
+		"00-00000000000000000000000000000000-0000000000000000-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	} {
+		if _, err := ParseTraceParent(invalid); err == nil {
+			t.Errorf("expect error for %+v", invalid)
+		}
+	}
+}
+
+func TestDisabledTracingIsNoop(t *testing.T) {
+	Shutdown()
+	ctx, span := StartSpan(context.Background(), "noop")
+	span.SetAttributes(String("k", "v"))
+	span.SetError(errors.New("err"))
+	span.End()
+	if span != nil || TraceParentFromContext(ctx) != "" {
+		t.Error("expect no span when tracing is disabled")
+	}
+}
+
+func TestSpanParentingAndBlockContext(t *testing.T) {
+	exporter := &memoryExporter{}
+	if err := Init(Config{Exporter: exporter, FlushInterval: time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	ctx, root := StartSpan(context.Background(), "root", Uint64("height", 10))
+	RememberBlock("blockhash", ctx)
+	_, child := StartSpan(ContextForBlock("blockhash"), "child")
+	child.SetError(errors.New("failed"))
+	child.End()
+	root.End()
+
+	remoteCtx := ContextWithRemoteParent(context.Background(), root.Context().TraceParent())
+	_, remote := StartSpan(remoteCtx, "remote")
+	remote.End()
+	Shutdown()
+
+	if len(exporter.spans) != 3 {
+		t.Fatalf("expect 3 spans got %+v", len(exporter.spans))
+	}
+	byName := make(map[string]*SpanData)
+	for _, span := range exporter.spans {
+		byName[span.Name] = span
+	}
+	if byName["child"].ParentSpanID != root.Context().SpanID || byName["child"].SpanContext.TraceID != root.Context().TraceID {
+		t.Error("child span is not linked to root")
+	}
+	if byName["child"].Err != "failed" {
+		t.Error("expect child error recorded")
+	}
+	if !byName["remote"].Remote || byName["remote"].ParentSpanID != root.Context().SpanID {
+		t.Error("remote span is not linked to root")
+	}
+}
+
+func TestWriterExporter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	exporter := NewWriterExporter(buf)
+	now := time.Now()
+	err := exporter.ExportSpans("svc", []*SpanData{{
+		Name:        "span",
+		SpanContext: SpanContext{TraceID: newTraceID(), SpanID: newSpanID(), Sampled: true},
+		StartTime:   now,
+		EndTime:     now.Add(time.Second),
+		Attributes:  []Attribute{Int("txs", 3)},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	line := jsonSpan{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatal(err)
+	}
+	if line.Name != "span" || line.Service != "svc" || line.DurationMs != 1000 || line.Attributes["txs"] != float64(3) {
+		t.Errorf("unexpected exported line %+v", buf.String())
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	received := make(chan otlpTraceRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != otlpTracesPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		req := otlpTraceRequest{}
+		if err := json.Unmarshal(body, &req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- req
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPExporter(server.URL)
+	sc := SpanContext{TraceID: newTraceID(), SpanID: newSpanID(), Sampled: true}
+	err := exporter.ExportSpans("svc", []*SpanData{{Name: "span", SpanContext: sc, StartTime: time.Now(), EndTime: time.Now(), Err: "boom"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := <-received
+	span := req.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if span.TraceID != sc.TraceID.String() || span.Status.Code != otlpStatusCodeError {
+		t.Errorf("unexpected otlp span %+v", span)
+	}
+	if *req.ResourceSpans[0].Resource.Attributes[0].Value.StringValue != "svc" {
+		t.Error("missing service name")
+	}
+
+	badExporter := NewOTLPExporter(server.URL + "/wrong")
+	if err := badExporter.ExportSpans("svc", []*SpanData{{SpanContext: sc}}); err == nil {
+		t.Error("expect error on non 2xx status")
+	}
+}
@@ -1,6 +1,7 @@
 package transaction
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/aggregaterange"
+	"github.com/incognitochain/incognito-chain/tracing"
 )
 
 type batchTransaction struct {
@@ -23,10 +25,17 @@ func (b *batchTransaction) AddTxs(txs []metadata.Transaction) {
 }
 
 func (b *batchTransaction) Validate(transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB) (bool, error, int) {
-	return b.validateBatchTxsByItself(b.txs, transactionStateDB, bridgeStateDB)
+	return b.validateBatchTxsByItself(context.Background(), b.txs, transactionStateDB, bridgeStateDB)
 }
 
-func (b *batchTransaction) validateBatchTxsByItself(txList []metadata.Transaction, transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB) (bool, error, int) {
+// ValidateWithContext is Validate with tracing spans attached to the span current in ctx
+func (b *batchTransaction) ValidateWithContext(ctx context.Context, transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB) (bool, error, int) {
+	return b.validateBatchTxsByItself(ctx, b.txs, transactionStateDB, bridgeStateDB)
+}
+
+func (b *batchTransaction) validateBatchTxsByItself(ctx context.Context, txList []metadata.Transaction, transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB) (bool, error, int) {
+	ctx, span := tracing.StartSpan(ctx, "transaction.validateBatchTxs", tracing.Int("txs", len(txList)))
+	defer span.End()
 	prvCoinID := &common.Hash{}
 	err := prvCoinID.SetBytes(common.PRVCoinID[:])
 	if err != nil {
@@ -57,7 +66,10 @@ func (b *batchTransaction) validateBatchTxsByItself(txList []metadata.Transactio
 		}
 	}
 	//TODO: add go routine
+	_, proofSpan := tracing.StartSpan(ctx, "transaction.verifyBatchingAggregatedRangeProofs", tracing.Int("proofs", len(bulletProofList)))
 	ok, err, i := aggregaterange.VerifyBatchingAggregatedRangeProofs(bulletProofList)
+	proofSpan.SetError(err)
+	proofSpan.End()
 	if err != nil {
 		return false, NewTransactionErr(TxProofVerifyFailError, err), -1
 	}
@@ -91,9 +91,11 @@ func RandomCommitmentsProcess(param *RandomCommitmentsProcessParam) (commitmentI
 		return
 	}
 	if lenCommitment.Uint64() == 1 && len(param.usableInputCoins) == 1 {
-		commitmentIndexs = []uint64{0, 0, 0, 0, 0, 0, 0}
 		temp := param.usableInputCoins[0].CoinDetails.GetCoinCommitment().ToBytesS()
-		commitments = [][]byte{temp, temp, temp, temp, temp, temp, temp}
+		for i := 0; i < cpRandNum; i++ {
+			commitmentIndexs = append(commitmentIndexs, 0)
+			commitments = append(commitments, temp)
+		}
 	} else {
 		for i := 0; i < cpRandNum; i++ {
 			for {
@@ -129,6 +131,18 @@ func RandomCommitmentsProcess(param *RandomCommitmentsProcessParam) (commitmentI
 	return commitmentIndexs, myCommitmentIndexs, commitments
 }
 
+// getTxVersion returns the version of a tx with one-out-of-many proofs of ringSize commitments, and with stealth
+// output coins or not
+func getTxVersion(ringSize int, stealth bool) int8 {
+	if stealth {
+		return txVersionStealth
+	}
+	if ringSize == privacy.CommitmentRingSize {
+		return txVersion
+	}
+	return txVersionRingSize
+}
+
 // CheckSNDerivatorExistence return true if snd exists in snDerivators list
 func CheckSNDerivatorExistence(tokenID *common.Hash, snd *privacy.Scalar, stateDB *statedb.StateDB) (bool, error) {
 	ok, err := statedb.HasSNDerivator(stateDB, *tokenID, snd.ToBytesS())
@@ -145,6 +159,8 @@ type EstimateTxSizeParam struct {
 	metadata                 metadata.Metadata
 	privacyCustomTokenParams *CustomTokenPrivacyParamTx
 	limitFee                 uint64
+	commitmentRingSize       int // 0 is privacy.CommitmentRingSize
+	stealth                  bool
 }
 
 func NewEstimateTxSizeParam(numInputCoins, numPayments int,
@@ -162,6 +178,18 @@ func NewEstimateTxSizeParam(numInputCoins, numPayments int,
 	return estimateTxSizeParam
 }
 
+// SetCommitmentRingSize sets the ring size of the one-out-of-many proofs of the tx
+func (param *EstimateTxSizeParam) SetCommitmentRingSize(ringSize int) *EstimateTxSizeParam {
+	param.commitmentRingSize = ringSize
+	return param
+}
+
+// SetStealth sets whether the output coins of the tx are stealth coins
+func (param *EstimateTxSizeParam) SetStealth(stealth bool) *EstimateTxSizeParam {
+	param.stealth = stealth
+	return param
+}
+
 // EstimateTxSize returns the estimated size of the tx in kilobyte
 func EstimateTxSize(estimateTxSizeParam *EstimateTxSizeParam) uint64 {
 
@@ -180,13 +208,18 @@ func EstimateTxSize(estimateTxSizeParam *EstimateTxSizeParam) uint64 {
 
 	sizeProof := uint64(0)
 	if estimateTxSizeParam.numInputCoins != 0 || estimateTxSizeParam.numPayments != 0 {
-		sizeProof = utils.EstimateProofSize(estimateTxSizeParam.numInputCoins, estimateTxSizeParam.numPayments, estimateTxSizeParam.hasPrivacy)
+		sizeProof = utils.EstimateProofSize(estimateTxSizeParam.numInputCoins, estimateTxSizeParam.numPayments, estimateTxSizeParam.hasPrivacy, estimateTxSizeParam.commitmentRingSize)
 	} else {
 		if estimateTxSizeParam.limitFee > 0 {
-			sizeProof = utils.EstimateProofSize(1, 1, estimateTxSizeParam.hasPrivacy)
+			sizeProof = utils.EstimateProofSize(1, 1, estimateTxSizeParam.hasPrivacy, estimateTxSizeParam.commitmentRingSize)
 		}
 	}
 
+	// a stealth coin for each payment and the change
+	if estimateTxSizeParam.stealth {
+		sizeProof += uint64(estimateTxSizeParam.numPayments+1) * privacy.StealthCoinExtraSize
+	}
+
 	sizePubKeyLastByte := uint64(1)
 
 	sizeMetadata := uint64(0)
@@ -218,7 +251,7 @@ func EstimateTxSize(estimateTxSizeParam *EstimateTxSizeParam) uint64 {
 		customTokenDataSize += uint64(common.SigPrivacySize) // sig
 
 		// Proof
-		customTokenDataSize += utils.EstimateProofSize(len(estimateTxSizeParam.privacyCustomTokenParams.TokenInput), len(estimateTxSizeParam.privacyCustomTokenParams.Receiver), true)
+		customTokenDataSize += utils.EstimateProofSize(len(estimateTxSizeParam.privacyCustomTokenParams.TokenInput), len(estimateTxSizeParam.privacyCustomTokenParams.Receiver), true, 0)
 
 		customTokenDataSize += uint64(1) //PubKeyLastByte
 
@@ -1,8 +1,14 @@
 package transaction
 
 const (
-	// txVersion is the current latest supported transaction version.
-	txVersion                        = 1
+	// txVersion is the version of a transaction with one-out-of-many proofs of privacy.CommitmentRingSize commitments.
+	txVersion = 1
+	// txVersionRingSize is the version of a transaction whose sender chooses the ring size of the one-out-of-many
+	// proofs among 8, 16, 32 and 64, from the beacon height BeaconHeightBreakPointRingSize.
+	txVersionRingSize = 2
+	// txVersionStealth is the current latest supported transaction version, its output coins have one-time public
+	// keys (privacy.CoinVersionStealth) from the beacon height BeaconHeightBreakPointStealthAddress.
+	txVersionStealth                 = 3
 	ValidateTimeForOneoutOfManyProof = 1574985600 // GMT: Friday, November 29, 2019 12:00:00 AM
 )
 
@@ -65,6 +65,7 @@ const (
 	RejectTxType
 	RejectTxInfoSize
 	RejectTxMedataWithBlockChain
+	InvalidTxTemplateError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -114,6 +115,7 @@ var ErrCodeMessage = map[int]struct {
 	RejectTxMedataWithBlockChain:                  {-1039, "Reject invalid metadata with blockchain"},
 	BatchTxProofVerifyFailError:                   {-1040, "Can not verify proof of batch txs %s"},
 	VerifyOneOutOfManyProofFailedErr:              {-1041, "Verify one out of many proof failed"},
+	InvalidTxTemplateError:                        {-1042, "Invalid tx template"},
 
 	// for PRV
 	InvalidSanityDataPRVError:  {-2000, "Invalid sanity data for PRV"},
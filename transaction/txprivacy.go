@@ -78,6 +78,9 @@ type TxPrivacyInitParams struct {
 	tokenID     *common.Hash // default is nil -> use for prv coin
 	metaData    metadata.Metadata
 	info        []byte // 512 bytes
+
+	commitmentRingSize int // 0 is privacy.CommitmentRingSize
+	stealth            bool
 }
 
 func NewTxPrivacyInitParams(senderSK *privacy.PrivateKey,
@@ -103,76 +106,71 @@ func NewTxPrivacyInitParams(senderSK *privacy.PrivateKey,
 	return params
 }
 
+// SetCommitmentRingSize sets the number of commitments of the one-out-of-many proof of each input coin, a ring size
+// other than privacy.CommitmentRingSize makes a tx of version txVersionRingSize
+func (params *TxPrivacyInitParams) SetCommitmentRingSize(ringSize int) *TxPrivacyInitParams {
+	params.commitmentRingSize = ringSize
+	return params
+}
+
+// SetStealth sets whether the output coins have one-time public keys, it makes a tx of version txVersionStealth
+func (params *TxPrivacyInitParams) SetStealth(stealth bool) *TxPrivacyInitParams {
+	params.stealth = stealth
+	return params
+}
+
 // Init - init value for tx from inputcoin(old output coin from old tx)
 // create new outputcoin and build privacy proof
 // if not want to create a privacy tx proof, set hashPrivacy = false
 // database is used like an interface which use to query info from transactionStateDB in building tx
 func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 	Logger.log.Debugf("CREATING TX........\n")
-	tx.Version = txVersion
-	var err error
-	if len(params.inputCoins) > 255 {
-		return NewTransactionErr(InputCoinIsVeryLargeError, nil, strconv.Itoa(len(params.inputCoins)))
-	}
-	if len(params.paymentInfo) > 254 {
-		return NewTransactionErr(PaymentInfoIsVeryLargeError, nil, strconv.Itoa(len(params.paymentInfo)))
-	}
-	limitFee := uint64(0)
-	estimateTxSizeParam := NewEstimateTxSizeParam(len(params.inputCoins), len(params.paymentInfo),
-		params.hasPrivacy, nil, nil, limitFee)
-	if txSize := EstimateTxSize(estimateTxSizeParam); txSize > common.MaxTxSize {
-		return NewTransactionErr(ExceedSizeTx, nil, strconv.Itoa(int(txSize)))
+	// create sender's key set from sender's spending key
+	senderFullKey := incognitokey.KeySet{}
+	err := senderFullKey.InitFromPrivateKey(params.senderSK)
+	if err != nil {
+		Logger.log.Error(errors.New(fmt.Sprintf("Can not import Private key for sender keyset from %+v", params.senderSK)))
+		return NewTransactionErr(PrivateKeySenderInvalidError, err)
 	}
-
-	if params.tokenID == nil {
-		// using default PRV
-		params.tokenID = &common.Hash{}
-		err := params.tokenID.SetBytes(common.PRVCoinID[:])
-		if err != nil {
-			return NewTransactionErr(TokenIDInvalidError, err, params.tokenID.String())
-		}
+	template, err := newTxTemplate(params, senderFullKey.PaymentAddress, tx.LockTime)
+	if err != nil {
+		return err
 	}
+	return tx.InitFromTemplate(template, params.senderSK)
+}
 
+// InitFromTemplate - create output coins, build privacy proof and sign tx from an unsigned tx template,
+// it does not read the chain state so it can run on a machine without database, ex: to sign offline
+func (tx *Tx) InitFromTemplate(template *TxTemplate, senderSK *privacy.PrivateKey) error {
 	// Calculate execution time
 	start := time.Now()
 
-	if tx.LockTime == 0 {
-		tx.LockTime = time.Now().Unix()
-	}
-
-	// create sender's key set from sender's spending key
 	senderFullKey := incognitokey.KeySet{}
-	err = senderFullKey.InitFromPrivateKey(params.senderSK)
+	err := senderFullKey.InitFromPrivateKey(senderSK)
 	if err != nil {
-		Logger.log.Error(errors.New(fmt.Sprintf("Can not import Private key for sender keyset from %+v", params.senderSK)))
+		Logger.log.Error(errors.New(fmt.Sprintf("Can not import Private key for sender keyset from %+v", senderSK)))
 		return NewTransactionErr(PrivateKeySenderInvalidError, err)
 	}
+	if !bytes.Equal(senderFullKey.PaymentAddress.Pk, template.SenderPaymentAddress.Pk) {
+		return NewTransactionErr(PrivateKeySenderInvalidError, errors.New("private key does not belong to the sender of tx template"))
+	}
+	if err := template.validateSanity(); err != nil {
+		return err
+	}
 	// get public key last byte of sender
 	pkLastByteSender := senderFullKey.PaymentAddress.Pk[len(senderFullKey.PaymentAddress.Pk)-1]
 
-	// init info of tx
-	tx.Info = []byte{}
-	lenTxInfo := len(params.info)
-
-	if lenTxInfo > 0 {
-		if lenTxInfo > MaxSizeInfo {
-			return NewTransactionErr(ExceedSizeInfoTxError, nil)
-		}
-
-		tx.Info = params.info
-	}
-
-	// set metadata
-	tx.Metadata = params.metaData
-
-	// set tx type
+	tx.Version = template.Version
+	tx.LockTime = template.LockTime
+	tx.Info = template.Info
+	tx.Metadata = template.Metadata
 	tx.Type = common.TxNormalType
-	Logger.log.Debugf("len(inputCoins), fee, hasPrivacy: %d, %d, %v\n", len(params.inputCoins), params.fee, params.hasPrivacy)
+	Logger.log.Debugf("len(inputCoins), fee, hasPrivacy: %d, %d, %v\n", len(template.InputCoins), template.Fee, template.HasPrivacy)
 
-	if len(params.inputCoins) == 0 && params.fee == 0 && !params.hasPrivacy {
+	if len(template.InputCoins) == 0 && template.Fee == 0 && !template.HasPrivacy {
 		Logger.log.Debugf("len(inputCoins) == 0 && fee == 0 && !hasPrivacy\n")
-		tx.Fee = params.fee
-		tx.sigPrivKey = *params.senderSK
+		tx.Fee = template.Fee
+		tx.sigPrivKey = *senderSK
 		tx.PubKeyLastByteSender = pkLastByteSender
 		err := tx.signTx()
 		if err != nil {
@@ -182,94 +180,20 @@ func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 		return nil
 	}
 
-	shardID := common.GetShardIDFromLastByte(pkLastByteSender)
-	var commitmentIndexs []uint64   // array index random of commitments in transactionStateDB
-	var myCommitmentIndexs []uint64 // index in array index random of commitment in transactionStateDB
-
-	if params.hasPrivacy {
-		if len(params.inputCoins) == 0 {
-			return NewTransactionErr(RandomCommitmentError, fmt.Errorf("input is empty"))
-		}
-		randomParams := NewRandomCommitmentsProcessParam(params.inputCoins, privacy.CommitmentRingSize, params.stateDB, shardID, params.tokenID)
-		commitmentIndexs, myCommitmentIndexs, _ = RandomCommitmentsProcess(randomParams)
-
-		// Check number of list of random commitments, list of random commitment indices
-		if len(commitmentIndexs) != len(params.inputCoins)*privacy.CommitmentRingSize {
-			return NewTransactionErr(RandomCommitmentError, nil)
-		}
-
-		if len(myCommitmentIndexs) != len(params.inputCoins) {
-			return NewTransactionErr(RandomCommitmentError, errors.New("number of list my commitment indices must be equal to number of input coins"))
-		}
-	}
-
 	// Calculate execution time for creating payment proof
 	startPrivacy := time.Now()
 
-	// Calculate sum of all output coins' value
-	sumOutputValue := uint64(0)
-	for _, p := range params.paymentInfo {
-		sumOutputValue += p.Amount
-	}
-
-	// Calculate sum of all input coins' value
-	sumInputValue := uint64(0)
-	for _, coin := range params.inputCoins {
-		sumInputValue += coin.CoinDetails.GetValue()
-	}
-	Logger.log.Debugf("sumInputValue: %d\n", sumInputValue)
-
-	// Calculate over balance, it will be returned to sender
-	overBalance := int64(sumInputValue - sumOutputValue - params.fee)
-
-	// Check if sum of input coins' value is at least sum of output coins' value and tx fee
-	if overBalance < 0 {
-		return NewTransactionErr(WrongInputError, errors.New(fmt.Sprintf("input value less than output value. sumInputValue=%d sumOutputValue=%d fee=%d", sumInputValue, sumOutputValue, params.fee)))
-	}
-
-	// if overBalance > 0, create a new payment info with pk is sender's pk and amount is overBalance
-	if overBalance > 0 {
-		changePaymentInfo := new(privacy.PaymentInfo)
-		changePaymentInfo.Amount = uint64(overBalance)
-		changePaymentInfo.PaymentAddress = senderFullKey.PaymentAddress
-		params.paymentInfo = append(params.paymentInfo, changePaymentInfo)
-	}
-
-	// create new output coins
-	outputCoins := make([]*privacy.OutputCoin, len(params.paymentInfo))
-
-	// create SNDs for output coins
-	ok := true
-	sndOuts := make([]*privacy.Scalar, 0)
-
-	for ok {
-		for i := 0; i < len(params.paymentInfo); i++ {
-			sndOut := privacy.RandomScalar()
-			for {
-
-				ok1, err := CheckSNDerivatorExistence(params.tokenID, sndOut, params.stateDB)
-				if err != nil {
-					Logger.log.Error(err)
-				}
-				// if sndOut existed, then re-random it
-				if ok1 {
-					sndOut = privacy.RandomScalar()
-				} else {
-					break
-				}
-			}
-			sndOuts = append(sndOuts, sndOut)
-		}
-
-		// if sndOuts has two elements that have same value, then re-generates it
-		ok = privacy.CheckDuplicateScalarArray(sndOuts)
-		if ok {
-			sndOuts = make([]*privacy.Scalar, 0)
-		}
+	// serial numbers of input coins are derived from the private key, the template builder may not know it
+	for _, inputCoin := range template.InputCoins {
+		inputCoin.CoinDetails.SetSerialNumber(new(privacy.Point).Derive(
+			privacy.PedCom.G[privacy.PedersenPrivateKeyIndex],
+			new(privacy.Scalar).FromBytesS(*senderSK),
+			inputCoin.CoinDetails.GetSNDerivator()))
 	}
 
 	// create new output coins with info: Pk, value, last byte of pk, snd
-	for i, pInfo := range params.paymentInfo {
+	outputCoins := make([]*privacy.OutputCoin, len(template.PaymentInfos))
+	for i, pInfo := range template.PaymentInfos {
 		outputCoins[i] = new(privacy.OutputCoin)
 		outputCoins[i].CoinDetails = new(privacy.Coin)
 		outputCoins[i].CoinDetails.SetValue(pInfo.Amount)
@@ -280,49 +204,51 @@ func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 		}
 		outputCoins[i].CoinDetails.SetInfo(pInfo.Message)
 
-		PK, err := new(privacy.Point).FromBytesS(pInfo.PaymentAddress.Pk)
-		if err != nil {
-			Logger.log.Error(errors.New(fmt.Sprintf("can not decompress public key from %+v", pInfo.PaymentAddress)))
-			return NewTransactionErr(DecompressPaymentAddressError, err, pInfo.PaymentAddress)
+		if template.Stealth {
+			err = outputCoins[i].CoinDetails.SetStealthPublicKey(pInfo.PaymentAddress)
+			if err != nil {
+				Logger.log.Error(errors.New(fmt.Sprintf("can not derive one-time public key from %+v", pInfo.PaymentAddress)))
+				return NewTransactionErr(DecompressPaymentAddressError, err, pInfo.PaymentAddress)
+			}
+		} else {
+			PK, err := new(privacy.Point).FromBytesS(pInfo.PaymentAddress.Pk)
+			if err != nil {
+				Logger.log.Error(errors.New(fmt.Sprintf("can not decompress public key from %+v", pInfo.PaymentAddress)))
+				return NewTransactionErr(DecompressPaymentAddressError, err, pInfo.PaymentAddress)
+			}
+			outputCoins[i].CoinDetails.SetPublicKey(PK)
 		}
-		outputCoins[i].CoinDetails.SetPublicKey(PK)
-		outputCoins[i].CoinDetails.SetSNDerivator(sndOuts[i])
+		outputCoins[i].CoinDetails.SetSNDerivator(new(privacy.Scalar).FromBytesS(template.OutputSNDs[i]))
 	}
 
 	// assign fee tx
-	tx.Fee = params.fee
+	tx.Fee = template.Fee
 
 	// create zero knowledge proof of payment
 	tx.Proof = &zkp.PaymentProof{}
 
 	// get list of commitments for proving one-out-of-many from commitmentIndexs
-	commitmentProving := make([]*privacy.Point, len(commitmentIndexs))
-	for i, cmIndex := range commitmentIndexs {
-		temp, err := statedb.GetCommitmentByIndex(params.stateDB, *params.tokenID, cmIndex, shardID)
+	commitmentProving := make([]*privacy.Point, len(template.Commitments))
+	for i, commitment := range template.Commitments {
+		commitmentProving[i], err = new(privacy.Point).FromBytesS(commitment)
 		if err != nil {
-			Logger.log.Error(fmt.Errorf("can not get commitment from index=%d shardID=%+v", cmIndex, shardID))
-			return NewTransactionErr(CanNotGetCommitmentFromIndexError, err, cmIndex, shardID)
-		}
-		commitmentProving[i] = new(privacy.Point)
-		commitmentProving[i], err = commitmentProving[i].FromBytesS(temp)
-		if err != nil {
-			Logger.log.Error(errors.New(fmt.Sprintf("can not get commitment from index=%d shardID=%+v value=%+v", cmIndex, shardID, temp)))
-			return NewTransactionErr(CanNotDecompressCommitmentFromIndexError, err, cmIndex, shardID, temp)
+			Logger.log.Error(errors.New(fmt.Sprintf("can not get commitment from index=%d value=%+v", template.CommitmentIndices[i], commitment)))
+			return NewTransactionErr(CanNotDecompressCommitmentFromIndexError, err, template.CommitmentIndices[i], common.GetShardIDFromLastByte(pkLastByteSender), commitment)
 		}
 	}
 
 	// prepare witness for proving
 	witness := new(zkp.PaymentWitness)
 	paymentWitnessParam := zkp.PaymentWitnessParam{
-		HasPrivacy:              params.hasPrivacy,
-		PrivateKey:              new(privacy.Scalar).FromBytesS(*params.senderSK),
-		InputCoins:              params.inputCoins,
+		HasPrivacy:              template.HasPrivacy,
+		PrivateKey:              new(privacy.Scalar).FromBytesS(*senderSK),
+		InputCoins:              template.InputCoins,
 		OutputCoins:             outputCoins,
 		PublicKeyLastByteSender: pkLastByteSender,
 		Commitments:             commitmentProving,
-		CommitmentIndices:       commitmentIndexs,
-		MyCommitmentIndices:     myCommitmentIndexs,
-		Fee:                     params.fee,
+		CommitmentIndices:       template.CommitmentIndices,
+		MyCommitmentIndices:     template.MyCommitmentIndices,
+		Fee:                     template.Fee,
 	}
 	err = witness.Init(paymentWitnessParam)
 	if err.(*privacy.PrivacyError) != nil {
@@ -331,24 +257,24 @@ func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 		return NewTransactionErr(InitWithnessError, err, string(jsonParam))
 	}
 
-	tx.Proof, err = witness.Prove(params.hasPrivacy)
+	tx.Proof, err = witness.Prove(template.HasPrivacy)
 	if err.(*privacy.PrivacyError) != nil {
 		Logger.log.Error(err)
 		jsonParam, _ := json.MarshalIndent(paymentWitnessParam, common.EmptyString, "  ")
-		return NewTransactionErr(WithnessProveError, err, params.hasPrivacy, string(jsonParam))
+		return NewTransactionErr(WithnessProveError, err, template.HasPrivacy, string(jsonParam))
 	}
 
 	Logger.log.Debugf("DONE PROVING........\n")
 
 	// set private key for signing tx
-	if params.hasPrivacy {
+	if template.HasPrivacy {
 		randSK := witness.GetRandSecretKey()
-		tx.sigPrivKey = append(*params.senderSK, randSK.ToBytesS()...)
+		tx.sigPrivKey = append(*senderSK, randSK.ToBytesS()...)
 
 		// encrypt coin details (Randomness)
 		// hide information of output coins except coin commitments, public key, snDerivators
 		for i := 0; i < len(tx.Proof.GetOutputCoins()); i++ {
-			err = tx.Proof.GetOutputCoins()[i].Encrypt(params.paymentInfo[i].PaymentAddress.Tk)
+			err = tx.Proof.GetOutputCoins()[i].Encrypt(template.PaymentInfos[i].PaymentAddress.Tk)
 			if err.(*privacy.PrivacyError) != nil {
 				Logger.log.Error(err)
 				return NewTransactionErr(EncryptOutputError, err)
@@ -365,12 +291,14 @@ func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 			tx.Proof.GetInputCoins()[i].CoinDetails.SetSNDerivator(nil)
 			tx.Proof.GetInputCoins()[i].CoinDetails.SetPublicKey(nil)
 			tx.Proof.GetInputCoins()[i].CoinDetails.SetRandomness(nil)
+			tx.Proof.GetInputCoins()[i].CoinDetails.SetTxRandom(nil)
+			tx.Proof.GetInputCoins()[i].CoinDetails.SetViewTag(0)
 		}
 
 	} else {
 		tx.sigPrivKey = []byte{}
 		randSK := big.NewInt(0)
-		tx.sigPrivKey = append(*params.senderSK, randSK.Bytes()...)
+		tx.sigPrivKey = append(*senderSK, randSK.Bytes()...)
 	}
 
 	// sign tx
@@ -846,8 +774,8 @@ func (tx Tx) ValidateTxWithBlockChain(chainRetriever metadata.ChainRetriever, sh
 
 func (tx Tx) validateNormalTxSanityData() (bool, error) {
 	//check version
-	if tx.Version > txVersion {
-		return false, NewTransactionErr(RejectTxVersion, fmt.Errorf("tx version is %d. Wrong version tx. Only support for version >= %d", tx.Version, txVersion))
+	if tx.Version > txVersionStealth {
+		return false, NewTransactionErr(RejectTxVersion, fmt.Errorf("tx version is %d. Wrong version tx. Only support for version <= %d", tx.Version, txVersionStealth))
 	}
 	// check LockTime before now
 	if int64(tx.LockTime) > time.Now().Unix() {
@@ -918,6 +846,25 @@ func (txN Tx) validateSanityDataOfProof() (bool, error) {
 			isPrivacy = false
 		}
 
+		// a stealth output coin needs a tx of version txVersionStealth with privacy, a stealth input coin can only be
+		// spent with privacy which shows its serial number only
+		for i, outCoin := range txN.Proof.GetOutputCoins() {
+			if outCoin.CoinDetails.GetVersion() != privacy.CoinVersionStealth {
+				continue
+			}
+			if txN.Version < txVersionStealth || !isPrivacy {
+				return false, NewTransactionErr(RejectTxVersion, fmt.Errorf("output coin %d of tx version %d with privacy %v can not be a stealth coin", i, txN.Version, isPrivacy))
+			}
+			if !outCoin.CoinDetails.GetTxRandom().PointValid() {
+				return false, errors.New("validate sanity TxRandom of output coin failed")
+			}
+		}
+		for _, inCoin := range txN.Proof.GetInputCoins() {
+			if inCoin.CoinDetails.GetTxRandom() != nil {
+				return false, errors.New("validate sanity TxRandom of input coin failed")
+			}
+		}
+
 		if isPrivacy {
 			if !txN.Proof.GetAggregatedRangeProof().ValidateSanity() {
 				return false, errors.New("validate sanity Aggregated range proof failed")
@@ -998,9 +945,13 @@ func (txN Tx) validateSanityDataOfProof() (bool, error) {
 					return false, errors.New("validate sanity ComOutputValue of proof failed")
 				}
 			}
-			if len(txN.Proof.GetCommitmentIndices()) != len(txN.Proof.GetInputCoins())*privacy.CommitmentRingSize {
+			// the one out of many proofs of all input coins have the ring size given by the version of tx
+			ringSize := txN.Proof.GetCommitmentRingSize()
+			if ringSize == 0 || len(txN.Proof.GetCommitmentIndices()) != len(txN.Proof.GetInputCoins())*ringSize {
 				return false, errors.New("validate sanity CommitmentIndices of proof failed")
-
+			}
+			if txN.Version < txVersionRingSize && ringSize != privacy.CommitmentRingSize {
+				return false, NewTransactionErr(RejectTxVersion, fmt.Errorf("tx version %d can not have a ring size %d", txN.Version, ringSize))
 			}
 		}
 
@@ -1065,9 +1016,34 @@ func (tx Tx) ValidateSanityData(chainRetriever metadata.ChainRetriever, shardVie
 		}
 	}
 	Logger.log.Debugf("\n\n\n END sanity data of metadata%+v\n\n\n")
+	if err := tx.validateTxVersionActivation(chainRetriever, shardViewRetriever, beaconHeight); err != nil {
+		return false, err
+	}
 	return tx.validateNormalTxSanityData()
 }
 
+// validateTxVersionActivation rejects a tx of version txVersionRingSize before the beacon height
+// BeaconHeightBreakPointRingSize and a tx of version txVersionStealth before the beacon height
+// BeaconHeightBreakPointStealthAddress, the beacon height of a new tx is the one of the shard view.
+// A tx of version txVersionStealth can also choose its ring size, BlockChain.Init rejects params whose stealth
+// address break point is before the ring size one.
+func (tx Tx) validateTxVersionActivation(chainRetriever metadata.ChainRetriever, shardViewRetriever metadata.ShardViewRetriever, beaconHeight uint64) error {
+	if tx.Version < txVersionRingSize || chainRetriever == nil {
+		return nil
+	}
+	if beaconHeight == 0 && shardViewRetriever != nil {
+		beaconHeight = shardViewRetriever.GetBeaconHeight()
+	}
+	breakPoint := chainRetriever.GetBeaconHeightBreakPointRingSize()
+	if tx.Version >= txVersionStealth {
+		breakPoint = chainRetriever.GetBeaconHeightBreakPointStealthAddress()
+	}
+	if beaconHeight < breakPoint {
+		return NewTransactionErr(RejectTxVersion, fmt.Errorf("tx version %d is not accepted before beacon height %d, beacon height is %d", tx.Version, breakPoint, beaconHeight))
+	}
+	return nil
+}
+
 func (tx Tx) ValidateTxByItself(hasPrivacy bool, transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB, chainRetriever metadata.ChainRetriever, shardID byte, isNewTransaction bool, shardViewRetriever metadata.ShardViewRetriever, beaconViewRetriever metadata.BeaconViewRetriever) (bool, error) {
 	prvCoinID := &common.Hash{}
 	err := prvCoinID.SetBytes(common.PRVCoinID[:])
@@ -1464,8 +1440,15 @@ func (tx *Tx) InitForASM(params *TxPrivacyInitParamsForASM, serverTime int64) er
 	shardID := common.GetShardIDFromLastByte(pkLastByteSender)
 
 	if params.txParam.hasPrivacy {
-		// Check number of list of random commitments, list of random commitment indices
-		if len(params.commitmentIndices) != len(params.txParam.inputCoins)*privacy.CommitmentRingSize {
+		// Check number of list of random commitments, list of random commitment indices, the ring size chosen by the
+		// sender gives the version of tx
+		if len(params.txParam.inputCoins) > 0 {
+			ringSize := len(params.commitmentIndices) / len(params.txParam.inputCoins)
+			if _, ok := privacy.GetCommitmentRingSizeExp(ringSize); !ok || len(params.commitmentIndices) != len(params.txParam.inputCoins)*ringSize {
+				return NewTransactionErr(RandomCommitmentError, nil)
+			}
+			tx.Version = getTxVersion(ringSize, false)
+		} else if len(params.commitmentIndices) != 0 {
 			return NewTransactionErr(RandomCommitmentError, nil)
 		}
 
@@ -1607,6 +1590,8 @@ func (tx *Tx) InitForASM(params *TxPrivacyInitParamsForASM, serverTime int64) er
 			tx.Proof.GetInputCoins()[i].CoinDetails.SetSNDerivator(nil)
 			tx.Proof.GetInputCoins()[i].CoinDetails.SetPublicKey(nil)
 			tx.Proof.GetInputCoins()[i].CoinDetails.SetRandomness(nil)
+			tx.Proof.GetInputCoins()[i].CoinDetails.SetTxRandom(nil)
+			tx.Proof.GetInputCoins()[i].CoinDetails.SetViewTag(0)
 		}
 
 	} else {
@@ -301,29 +301,8 @@ func (txCustomTokenPrivacy *TxCustomTokenPrivacy) Init(params *TxPrivacyTokenIni
 			// fee always 0 and reuse function of normal tx for custom token ID
 			temp := Tx{}
 			propertyID, _ := common.Hash{}.NewHashFromStr(params.tokenParams.PropertyID)
-			existed := statedb.PrivacyTokenIDExisted(params.transactionStateDB, *propertyID)
-			if !existed {
-				isBridgeToken := false
-				allBridgeTokensBytes, err := statedb.GetAllBridgeTokens(params.bridgeStateDB)
-				if err != nil {
-					return NewTransactionErr(TokenIDExistedError, err)
-				}
-				if len(allBridgeTokensBytes) > 0 {
-					var allBridgeTokens []*rawdbv2.BridgeTokenInfo
-					err = json.Unmarshal(allBridgeTokensBytes, &allBridgeTokens)
-					if err != nil {
-						return NewTransactionErr(TokenIDExistedError, err)
-					}
-					for _, bridgeTokens := range allBridgeTokens {
-						if propertyID.IsEqual(bridgeTokens.TokenID) {
-							isBridgeToken = true
-							break
-						}
-					}
-				}
-				if !isBridgeToken {
-					return NewTransactionErr(TokenIDExistedError, errors.New("invalid Token ID"))
-				}
+			if err := checkTransferredTokenID(propertyID, params.transactionStateDB, params.bridgeStateDB); err != nil {
+				return err
 			}
 			Logger.log.Debugf("Token %+v wil be transfered with", propertyID)
 			txCustomTokenPrivacy.TxPrivacyTokenData = TxPrivacyTokenData{
@@ -354,6 +333,63 @@ func (txCustomTokenPrivacy *TxCustomTokenPrivacy) Init(params *TxPrivacyTokenIni
 	return nil
 }
 
+// checkTransferredTokenID - a transferred token is either a privacy token issued on the network or a bridge token
+func checkTransferredTokenID(propertyID *common.Hash, transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB) error {
+	existed := statedb.PrivacyTokenIDExisted(transactionStateDB, *propertyID)
+	if existed {
+		return nil
+	}
+	allBridgeTokensBytes, err := statedb.GetAllBridgeTokens(bridgeStateDB)
+	if err != nil {
+		return NewTransactionErr(TokenIDExistedError, err)
+	}
+	if len(allBridgeTokensBytes) > 0 {
+		var allBridgeTokens []*rawdbv2.BridgeTokenInfo
+		err = json.Unmarshal(allBridgeTokensBytes, &allBridgeTokens)
+		if err != nil {
+			return NewTransactionErr(TokenIDExistedError, err)
+		}
+		for _, bridgeTokens := range allBridgeTokens {
+			if propertyID.IsEqual(bridgeTokens.TokenID) {
+				return nil
+			}
+		}
+	}
+	return NewTransactionErr(TokenIDExistedError, errors.New("invalid Token ID"))
+}
+
+// InitFromTemplate - prove and sign the PRV part and the token part of an unsigned privacy token transfer,
+// like Tx.InitFromTemplate it does not read the chain state
+func (txCustomTokenPrivacy *TxCustomTokenPrivacy) InitFromTemplate(template *TxPrivacyTokenTemplate, senderSK *privacy.PrivateKey) error {
+	if err := template.validateSanity(); err != nil {
+		return err
+	}
+	// init data for tx PRV for fee
+	normalTx := Tx{}
+	err := normalTx.InitFromTemplate(template.Template, senderSK)
+	if err != nil {
+		return NewTransactionErr(PrivacyTokenInitPRVError, err)
+	}
+	// override TxCustomTokenPrivacyType type
+	normalTx.Type = common.TxCustomTokenPrivacyType
+	txCustomTokenPrivacy.Tx = normalTx
+
+	txCustomTokenPrivacy.TxPrivacyTokenData = TxPrivacyTokenData{
+		Type:           CustomTokenTransfer,
+		PropertyName:   template.PropertyName,
+		PropertySymbol: template.PropertySymbol,
+		PropertyID:     template.PropertyID,
+		Mintable:       template.Mintable,
+	}
+	temp := Tx{}
+	err = temp.InitFromTemplate(template.TokenTemplate, senderSK)
+	if err != nil {
+		return NewTransactionErr(PrivacyTokenInitTokenDataError, err)
+	}
+	txCustomTokenPrivacy.TxPrivacyTokenData.TxNormal = temp
+	return nil
+}
+
 // ValidateType - check type of tx
 func (txCustomTokenPrivacy TxCustomTokenPrivacy) ValidateType() bool {
 	return txCustomTokenPrivacy.Type == common.TxCustomTokenPrivacyType
@@ -0,0 +1,386 @@
+package transaction
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+// TxTemplate is an unsigned normal tx. Everything Init reads from the chain state is resolved in the template:
+// input coins, random commitments for the one-out-of-many proof, output SNDs and change output.
+// Proving and signing only need the sender private key, see Tx.InitFromTemplate.
+type TxTemplate struct {
+	Version              int8
+	LockTime             int64
+	Fee                  uint64
+	Info                 []byte
+	HasPrivacy           bool
+	TokenID              common.Hash
+	Metadata             metadata.Metadata
+	SenderPaymentAddress privacy.PaymentAddress
+	InputCoins           []*privacy.InputCoin
+	PaymentInfos         []*privacy.PaymentInfo // change of sender included
+	OutputSNDs           [][]byte               // one per payment info
+	CommitmentIndices    []uint64
+	MyCommitmentIndices  []uint64
+	Commitments          [][]byte // commitments at CommitmentIndices
+	Stealth              bool     // output coins have one-time public keys, see privacy/stealth.go
+}
+
+func (template *TxTemplate) UnmarshalJSON(data []byte) error {
+	type Alias TxTemplate
+	temp := &struct {
+		Metadata *json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(template),
+	}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return NewTransactionErr(InvalidTxTemplateError, err)
+	}
+	if temp.Metadata == nil {
+		template.Metadata = nil
+		return nil
+	}
+	meta, parseErr := metadata.ParseMetadata(temp.Metadata)
+	if parseErr != nil {
+		return NewTransactionErr(InvalidTxTemplateError, parseErr)
+	}
+	template.Metadata = meta
+	return nil
+}
+
+// NewTxTemplate - choose random commitments and output SNDs from transactionStateDB to build an unsigned tx,
+// sender private key in params is not used and can be nil
+func NewTxTemplate(params *TxPrivacyInitParams, senderPaymentAddress privacy.PaymentAddress) (*TxTemplate, error) {
+	if len(senderPaymentAddress.Pk) == 0 {
+		return nil, NewTransactionErr(InvalidTxTemplateError, errors.New("sender payment address is empty"))
+	}
+	return newTxTemplate(params, senderPaymentAddress, 0)
+}
+
+func newTxTemplate(params *TxPrivacyInitParams, senderPaymentAddress privacy.PaymentAddress, lockTime int64) (*TxTemplate, error) {
+	if len(params.inputCoins) > 255 {
+		return nil, NewTransactionErr(InputCoinIsVeryLargeError, nil, strconv.Itoa(len(params.inputCoins)))
+	}
+	if len(params.paymentInfo) > 254 {
+		return nil, NewTransactionErr(PaymentInfoIsVeryLargeError, nil, strconv.Itoa(len(params.paymentInfo)))
+	}
+	ringSize := params.commitmentRingSize
+	if ringSize == 0 {
+		ringSize = privacy.CommitmentRingSize
+	}
+	if _, ok := privacy.GetCommitmentRingSizeExp(ringSize); !ok {
+		return nil, NewTransactionErr(RandomCommitmentError, fmt.Errorf("ring size %d is not supported", ringSize))
+	}
+	limitFee := uint64(0)
+	estimateTxSizeParam := NewEstimateTxSizeParam(len(params.inputCoins), len(params.paymentInfo),
+		params.hasPrivacy, nil, nil, limitFee).SetCommitmentRingSize(ringSize).SetStealth(params.stealth)
+	if txSize := EstimateTxSize(estimateTxSizeParam); txSize > common.MaxTxSize {
+		return nil, NewTransactionErr(ExceedSizeTx, nil, strconv.Itoa(int(txSize)))
+	}
+
+	if params.tokenID == nil {
+		// using default PRV
+		params.tokenID = &common.Hash{}
+		err := params.tokenID.SetBytes(common.PRVCoinID[:])
+		if err != nil {
+			return nil, NewTransactionErr(TokenIDInvalidError, err, params.tokenID.String())
+		}
+	}
+
+	if lockTime == 0 {
+		lockTime = time.Now().Unix()
+	}
+
+	// init info of tx
+	info := []byte{}
+	if len(params.info) > 0 {
+		if len(params.info) > MaxSizeInfo {
+			return nil, NewTransactionErr(ExceedSizeInfoTxError, nil)
+		}
+		info = params.info
+	}
+
+	template := &TxTemplate{
+		Version:              getTxVersion(ringSize, params.stealth),
+		LockTime:             lockTime,
+		Fee:                  params.fee,
+		Info:                 info,
+		HasPrivacy:           params.hasPrivacy,
+		TokenID:              *params.tokenID,
+		Metadata:             params.metaData,
+		SenderPaymentAddress: senderPaymentAddress,
+		InputCoins:           params.inputCoins,
+		PaymentInfos:         params.paymentInfo,
+		Stealth:              params.stealth,
+	}
+	if len(params.inputCoins) == 0 && params.fee == 0 && !params.hasPrivacy {
+		return template, nil
+	}
+
+	// get public key last byte of sender
+	pkLastByteSender := senderPaymentAddress.Pk[len(senderPaymentAddress.Pk)-1]
+	shardID := common.GetShardIDFromLastByte(pkLastByteSender)
+
+	if params.hasPrivacy {
+		if len(params.inputCoins) == 0 {
+			return nil, NewTransactionErr(RandomCommitmentError, fmt.Errorf("input is empty"))
+		}
+		randomParams := NewRandomCommitmentsProcessParam(params.inputCoins, ringSize, params.stateDB, shardID, params.tokenID)
+		template.CommitmentIndices, template.MyCommitmentIndices, _ = RandomCommitmentsProcess(randomParams)
+
+		// Check number of list of random commitments, list of random commitment indices
+		if len(template.CommitmentIndices) != len(params.inputCoins)*ringSize {
+			return nil, NewTransactionErr(RandomCommitmentError, nil)
+		}
+
+		if len(template.MyCommitmentIndices) != len(params.inputCoins) {
+			return nil, NewTransactionErr(RandomCommitmentError, errors.New("number of list my commitment indices must be equal to number of input coins"))
+		}
+	}
+
+	// Calculate sum of all output coins' value
+	sumOutputValue := uint64(0)
+	for _, p := range params.paymentInfo {
+		sumOutputValue += p.Amount
+	}
+
+	// Calculate sum of all input coins' value
+	sumInputValue := uint64(0)
+	for _, coin := range params.inputCoins {
+		sumInputValue += coin.CoinDetails.GetValue()
+	}
+	Logger.log.Debugf("sumInputValue: %d\n", sumInputValue)
+
+	// Calculate over balance, it will be returned to sender
+	overBalance := int64(sumInputValue - sumOutputValue - params.fee)
+
+	// Check if sum of input coins' value is at least sum of output coins' value and tx fee
+	if overBalance < 0 {
+		return nil, NewTransactionErr(WrongInputError, errors.New(fmt.Sprintf("input value less than output value. sumInputValue=%d sumOutputValue=%d fee=%d", sumInputValue, sumOutputValue, params.fee)))
+	}
+
+	// if overBalance > 0, create a new payment info with pk is sender's pk and amount is overBalance
+	if overBalance > 0 {
+		changePaymentInfo := new(privacy.PaymentInfo)
+		changePaymentInfo.Amount = uint64(overBalance)
+		changePaymentInfo.PaymentAddress = senderPaymentAddress
+		template.PaymentInfos = append(template.PaymentInfos, changePaymentInfo)
+	}
+
+	// create SNDs for output coins
+	ok := true
+	sndOuts := make([]*privacy.Scalar, 0)
+
+	for ok {
+		for i := 0; i < len(template.PaymentInfos); i++ {
+			sndOut := privacy.RandomScalar()
+			for {
+
+				ok1, err := CheckSNDerivatorExistence(params.tokenID, sndOut, params.stateDB)
+				if err != nil {
+					Logger.log.Error(err)
+				}
+				// if sndOut existed, then re-random it
+				if ok1 {
+					sndOut = privacy.RandomScalar()
+				} else {
+					break
+				}
+			}
+			sndOuts = append(sndOuts, sndOut)
+		}
+
+		// if sndOuts has two elements that have same value, then re-generates it
+		ok = privacy.CheckDuplicateScalarArray(sndOuts)
+		if ok {
+			sndOuts = make([]*privacy.Scalar, 0)
+		}
+	}
+	template.OutputSNDs = make([][]byte, len(sndOuts))
+	for i, sndOut := range sndOuts {
+		template.OutputSNDs[i] = sndOut.ToBytesS()
+	}
+
+	// get list of commitments for proving one-out-of-many from commitmentIndexs
+	template.Commitments = make([][]byte, len(template.CommitmentIndices))
+	for i, cmIndex := range template.CommitmentIndices {
+		temp, err := statedb.GetCommitmentByIndex(params.stateDB, *params.tokenID, cmIndex, shardID)
+		if err != nil {
+			Logger.log.Error(fmt.Errorf("can not get commitment from index=%d shardID=%+v", cmIndex, shardID))
+			return nil, NewTransactionErr(CanNotGetCommitmentFromIndexError, err, cmIndex, shardID)
+		}
+		template.Commitments[i] = temp
+	}
+	return template, nil
+}
+
+// validateSanity check a template received from outside has consistent lengths before proving
+func (template *TxTemplate) validateSanity() error {
+	if len(template.SenderPaymentAddress.Pk) == 0 {
+		return NewTransactionErr(InvalidTxTemplateError, errors.New("sender payment address is empty"))
+	}
+	if len(template.Info) > MaxSizeInfo {
+		return NewTransactionErr(ExceedSizeInfoTxError, nil)
+	}
+	// metadata may be checked against the public keys of output coins, a stealth coin hides it
+	if template.Stealth && (!template.HasPrivacy || template.Metadata != nil) {
+		return NewTransactionErr(InvalidTxTemplateError, errors.New("stealth output coins need a tx with privacy and without metadata"))
+	}
+	// the serial number proof without privacy shows the public key of the sender, not the one-time one of a coin
+	if !template.HasPrivacy {
+		for _, coin := range template.InputCoins {
+			if coin != nil && coin.CoinDetails != nil && coin.CoinDetails.GetVersion() == privacy.CoinVersionStealth {
+				return NewTransactionErr(InvalidTxTemplateError, errors.New("a stealth coin can only be spent by a tx with privacy"))
+			}
+		}
+	}
+	if len(template.InputCoins) == 0 && template.Fee == 0 && !template.HasPrivacy {
+		return nil
+	}
+	if len(template.OutputSNDs) != len(template.PaymentInfos) {
+		return NewTransactionErr(InvalidTxTemplateError, fmt.Errorf("expect %d output SNDs got %d", len(template.PaymentInfos), len(template.OutputSNDs)))
+	}
+	if len(template.Commitments) != len(template.CommitmentIndices) {
+		return NewTransactionErr(InvalidTxTemplateError, fmt.Errorf("expect %d commitments got %d", len(template.CommitmentIndices), len(template.Commitments)))
+	}
+	if template.HasPrivacy {
+		if len(template.MyCommitmentIndices) != len(template.InputCoins) {
+			return NewTransactionErr(RandomCommitmentError, nil)
+		}
+		if len(template.InputCoins) > 0 {
+			// all input coins have rings of the same size, it has to match the version of the template
+			ringSize := len(template.CommitmentIndices) / len(template.InputCoins)
+			if _, ok := privacy.GetCommitmentRingSizeExp(ringSize); !ok || len(template.CommitmentIndices) != len(template.InputCoins)*ringSize {
+				return NewTransactionErr(RandomCommitmentError, fmt.Errorf("ring size %d is not supported", ringSize))
+			}
+			if template.Version != getTxVersion(ringSize, template.Stealth) {
+				return NewTransactionErr(RejectTxVersion, fmt.Errorf("tx version %d can not have a ring size %d with stealth output coins %v", template.Version, ringSize, template.Stealth))
+			}
+		} else if len(template.CommitmentIndices) != 0 {
+			return NewTransactionErr(RandomCommitmentError, nil)
+		}
+	}
+	sumInputValue := uint64(0)
+	for _, coin := range template.InputCoins {
+		if coin == nil || coin.CoinDetails == nil || coin.CoinDetails.GetSNDerivator() == nil {
+			return NewTransactionErr(InvalidTxTemplateError, errors.New("input coin is invalid"))
+		}
+		// amounts are picked by whoever built the template, a wrapped sum would balance any outputs
+		if sumInputValue+coin.CoinDetails.GetValue() < sumInputValue {
+			return NewTransactionErr(InvalidTxTemplateError, errors.New("sum of input values overflows"))
+		}
+		sumInputValue += coin.CoinDetails.GetValue()
+	}
+	sumOutputValue := uint64(0)
+	for _, paymentInfo := range template.PaymentInfos {
+		if paymentInfo == nil {
+			return NewTransactionErr(InvalidTxTemplateError, errors.New("payment info is invalid"))
+		}
+		if sumOutputValue+paymentInfo.Amount < sumOutputValue {
+			return NewTransactionErr(InvalidTxTemplateError, errors.New("sum of output values overflows"))
+		}
+		sumOutputValue += paymentInfo.Amount
+	}
+	if sumOutputValue+template.Fee < sumOutputValue {
+		return NewTransactionErr(InvalidTxTemplateError, errors.New("sum of output values and fee overflows"))
+	}
+	if sumInputValue != sumOutputValue+template.Fee {
+		return NewTransactionErr(WrongInputError, fmt.Errorf("input value must equal output value and fee. sumInputValue=%d sumOutputValue=%d fee=%d", sumInputValue, sumOutputValue, template.Fee))
+	}
+	return nil
+}
+
+// TxPrivacyTokenTemplate is an unsigned privacy token transfer: Template pays the PRV fee and PRV outputs,
+// TokenTemplate spends the token. See TxCustomTokenPrivacy.InitFromTemplate
+type TxPrivacyTokenTemplate struct {
+	Template       *TxTemplate
+	TokenTemplate  *TxTemplate
+	PropertyID     common.Hash
+	PropertyName   string
+	PropertySymbol string
+	Mintable       bool
+}
+
+// NewTxPrivacyTokenTemplate - build the templates of both the PRV and the token part of a privacy token transfer,
+// sender private key in params is not used and can be nil. Issuing a token is not supported
+func NewTxPrivacyTokenTemplate(params *TxPrivacyTokenInitParams, senderPaymentAddress privacy.PaymentAddress) (*TxPrivacyTokenTemplate, error) {
+	if len(senderPaymentAddress.Pk) == 0 {
+		return nil, NewTransactionErr(InvalidTxTemplateError, errors.New("sender payment address is empty"))
+	}
+	if params.tokenParams == nil || params.tokenParams.TokenTxType != CustomTokenTransfer {
+		return nil, NewTransactionErr(PrivacyTokenTxTypeNotHandleError, errors.New("only a token transfer can be built as a template"))
+	}
+	limitFee := uint64(0)
+	estimateTxSizeParam := NewEstimateTxSizeParam(len(params.inputCoin), len(params.paymentInfo),
+		params.hasPrivacyCoin, nil, params.tokenParams, limitFee)
+	if txSize := EstimateTxSize(estimateTxSizeParam); txSize > common.MaxTxSize {
+		return nil, NewTransactionErr(ExceedSizeTx, nil, strconv.Itoa(int(txSize)))
+	}
+	propertyID, err := common.Hash{}.NewHashFromStr(params.tokenParams.PropertyID)
+	if err != nil {
+		return nil, NewTransactionErr(TokenIDInvalidError, err, params.tokenParams.PropertyID)
+	}
+	if err := checkTransferredTokenID(propertyID, params.transactionStateDB, params.bridgeStateDB); err != nil {
+		return nil, err
+	}
+
+	template, err := newTxTemplate(NewTxPrivacyInitParams(nil,
+		params.paymentInfo,
+		params.inputCoin,
+		params.feeNativeCoin,
+		params.hasPrivacyCoin,
+		params.transactionStateDB,
+		nil,
+		params.metaData,
+		params.info), senderPaymentAddress, 0)
+	if err != nil {
+		return nil, NewTransactionErr(PrivacyTokenInitPRVError, err)
+	}
+	tokenTemplate, err := newTxTemplate(NewTxPrivacyInitParams(nil,
+		params.tokenParams.Receiver,
+		params.tokenParams.TokenInput,
+		params.tokenParams.Fee,
+		params.hasPrivacyToken,
+		params.transactionStateDB,
+		propertyID,
+		nil,
+		nil), senderPaymentAddress, template.LockTime)
+	if err != nil {
+		return nil, NewTransactionErr(PrivacyTokenInitTokenDataError, err)
+	}
+	return &TxPrivacyTokenTemplate{
+		Template:       template,
+		TokenTemplate:  tokenTemplate,
+		PropertyID:     *propertyID,
+		PropertyName:   params.tokenParams.PropertyName,
+		PropertySymbol: params.tokenParams.PropertySymbol,
+		Mintable:       params.tokenParams.Mintable,
+	}, nil
+}
+
+// validateSanity check the PRV part pays PRV and the token part spends the token of the template
+func (template *TxPrivacyTokenTemplate) validateSanity() error {
+	if template.Template == nil || template.TokenTemplate == nil {
+		return NewTransactionErr(InvalidTxTemplateError, errors.New("template of PRV or token is empty"))
+	}
+	if !template.Template.TokenID.IsEqual(&common.PRVCoinID) {
+		return NewTransactionErr(InvalidTxTemplateError, fmt.Errorf("template of PRV spends token %s", template.Template.TokenID.String()))
+	}
+	if !template.TokenTemplate.TokenID.IsEqual(&template.PropertyID) || template.PropertyID.IsEqual(&common.PRVCoinID) {
+		return NewTransactionErr(InvalidTxTemplateError, fmt.Errorf("template of token %s spends token %s", template.PropertyID.String(), template.TokenTemplate.TokenID.String()))
+	}
+	if template.TokenTemplate.Metadata != nil {
+		return NewTransactionErr(InvalidTxTemplateError, errors.New("template of token has metadata"))
+	}
+	return nil
+}
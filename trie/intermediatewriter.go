@@ -1,7 +1,7 @@
 package trie
 
 import (
-	"encoding/binary"
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -9,7 +9,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/allegro/bigcache"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incdb"
@@ -32,7 +31,7 @@ const secureKeyLength = 11 + 32
 type IntermediateWriter struct {
 	diskdb incdb.Database // Persistent storage for matured trie nodes
 
-	cleans  *bigcache.BigCache          // GC friendly memory cache of clean node RLPs
+	cleans  *NodeCache                  // Memory cache of clean node RLPs, may be shared
 	dirties map[common.Hash]*cachedNode // Data and references relationships of dirty nodes
 	oldest  common.Hash                 // Oldest tracked node, flush-list head
 	newest  common.Hash                 // Newest tracked node, flush-list tail
@@ -238,19 +237,6 @@ func expandNode(hash hashNode, n node) node {
 	}
 }
 
-// trienodeHasher is a struct to be used with BigCache, which uses a Hasher to
-// determine which shard to place an entry into. It's not a cryptographic hash,
-// just to provide a bit of anti-collision (default is FNV64a).
-//
-// Since trie keys are already hashes, we can just use the key directly to
-// map shard id.
-type trienodeHasher struct{}
-
-// Sum64 implements the bigcache.Hasher interface.
-func (t trienodeHasher) Sum64(key string) uint64 {
-	return binary.BigEndian.Uint64([]byte(key))
-}
-
 // NewIntermediateWriter creates a new trie database to store ephemeral trie content before
 // its written out to disk or garbage collected. No read cache is created, so all
 // data retrievals will hit the underlying disk database.
@@ -260,19 +246,15 @@ func NewIntermediateWriter(diskdb incdb.Database) *IntermediateWriter {
 
 // NewDatabaseWithCache creates a new trie database to store ephemeral trie content
 // before its written out to disk or garbage collected. It also acts as a read cache
-// for nodes loaded from disk.
+// of cache megabytes for nodes loaded from disk.
 func NewDatabaseWithCache(diskdb incdb.Database, cache int) *IntermediateWriter {
-	var cleans *bigcache.BigCache
-	if cache > 0 {
-		cleans, _ = bigcache.NewBigCache(bigcache.Config{
-			Shards:             1024,
-			LifeWindow:         time.Hour,
-			MaxEntriesInWindow: cache * 1024,
-			MaxEntrySize:       512,
-			HardMaxCacheSize:   cache,
-			Hasher:             trienodeHasher{},
-		})
-	}
+	return NewIntermediateWriterWithCache(diskdb, NewNodeCache(cache*1024*1024))
+}
+
+// NewIntermediateWriterWithCache creates a new trie database to store ephemeral trie
+// content before its written out to disk or garbage collected, reading the nodes
+// loaded from disk through cleans. The cache can be shared by several trie databases.
+func NewIntermediateWriterWithCache(diskdb incdb.Database, cleans *NodeCache) *IntermediateWriter {
 	return &IntermediateWriter{
 		diskdb: diskdb,
 		cleans: cleans,
@@ -347,9 +329,7 @@ func (intermediateWriter *IntermediateWriter) insertPreimage(hash common.Hash, p
 func (intermediateWriter *IntermediateWriter) node(hash common.Hash) node {
 	// Retrieve the node from the clean cache if available
 	if intermediateWriter.cleans != nil {
-		if enc, err := intermediateWriter.cleans.Get(string(hash[:])); err == nil && enc != nil {
-			//memcacheCleanHitMeter.Mark(1)
-			//memcacheCleanReadMeter.Mark(int64(len(enc)))
+		if enc, ok := intermediateWriter.cleans.Get(hash); ok {
 			return mustDecodeNode(hash[:], enc)
 		}
 	}
@@ -367,9 +347,7 @@ func (intermediateWriter *IntermediateWriter) node(hash common.Hash) node {
 		return nil
 	}
 	if intermediateWriter.cleans != nil {
-		intermediateWriter.cleans.Set(string(hash[:]), enc)
-		//memcacheCleanMissMeter.Mark(1)
-		//memcacheCleanWriteMeter.Mark(int64(len(enc)))
+		intermediateWriter.cleans.Set(hash, enc)
 	}
 	return mustDecodeNode(hash[:], enc)
 }
@@ -383,9 +361,7 @@ func (intermediateWriter *IntermediateWriter) Node(hash common.Hash) ([]byte, er
 	}
 	// Retrieve the node from the clean cache if available
 	if intermediateWriter.cleans != nil {
-		if enc, err := intermediateWriter.cleans.Get(string(hash[:])); err == nil && enc != nil {
-			//memcacheCleanHitMeter.Mark(1)
-			//memcacheCleanReadMeter.Mark(int64(len(enc)))
+		if enc, ok := intermediateWriter.cleans.Get(hash); ok {
 			return enc, nil
 		}
 	}
@@ -401,9 +377,7 @@ func (intermediateWriter *IntermediateWriter) Node(hash common.Hash) ([]byte, er
 	enc, err := intermediateWriter.diskdb.Get(hash[:])
 	if err == nil && enc != nil {
 		if intermediateWriter.cleans != nil {
-			//memcacheCleanMissMeter.Mark(1)
-			//memcacheCleanWriteMeter.Mark(int64(len(enc)))
-			intermediateWriter.cleans.Set(string(hash[:]), enc)
+			intermediateWriter.cleans.Set(hash, enc)
 		}
 	}
 	return enc, err
@@ -755,6 +729,66 @@ func (intermediateWriter *IntermediateWriter) commit(hash common.Hash, batch inc
 	return nil
 }
 
+// CommitToBatch iterates over all the children of a particular node and puts them,
+// with the pre-images accumulated up to this point, into batch. The caller writes
+// the batch, with the other data of its block, then calls Uncache. Until then the
+// nodes stay in memory, so the trie can be read while the batch is not written.
+//
+// Note, the commits of several roots to one trie database are not concurrent.
+func (intermediateWriter *IntermediateWriter) CommitToBatch(node common.Hash, batch incdb.Batch) error {
+	start, size := time.Now(), batch.ValueSize()
+	// Nothing is removed from memory, the tries being hashed meanwhile only wait
+	intermediateWriter.lock.RLock()
+	defer intermediateWriter.lock.RUnlock()
+
+	for hash, preimage := range intermediateWriter.preimages {
+		if err := batch.Put(intermediateWriter.secureKey(hash[:]), preimage); err != nil {
+			return err
+		}
+	}
+	nodes, err := intermediateWriter.commitToBatch(node, batch, make(map[common.Hash]struct{}))
+	if err != nil {
+		Logger.log.Error("Failed to commit trie from trie database", "err", err)
+		return err
+	}
+	memcacheCommitTimeTimer.Update(time.Since(start))
+	memcacheCommitNodesMeter.Mark(int64(nodes))
+	memcacheCommitSizeMeter.Mark(int64(batch.ValueSize() - size))
+	return nil
+}
+
+// commitToBatch is the private version of CommitToBatch, it skips the nodes already
+// put in the batch.
+func (intermediateWriter *IntermediateWriter) commitToBatch(hash common.Hash, batch incdb.Batch, committed map[common.Hash]struct{}) (int, error) {
+	// If the node does not exist, it's a previously committed node
+	node, ok := intermediateWriter.dirties[hash]
+	if !ok {
+		return 0, nil
+	}
+	if _, ok := committed[hash]; ok {
+		return 0, nil
+	}
+	nodes := 1
+	for _, child := range node.childs() {
+		childNodes, err := intermediateWriter.commitToBatch(child, batch, committed)
+		if err != nil {
+			return 0, err
+		}
+		nodes += childNodes
+	}
+	committed[hash] = struct{}{}
+	return nodes, batch.Put(hash[:], node.rlp())
+}
+
+// Uncache moves the nodes of a batch written to disk from the dirty cache to the
+// clean cache, after CommitToBatch.
+func (intermediateWriter *IntermediateWriter) Uncache(batch incdb.Batch) error {
+	intermediateWriter.lock.Lock()
+	defer intermediateWriter.lock.Unlock()
+
+	return batch.Replay(&cleaner{intermediateWriter})
+}
+
 // cleaner is a database batch replayer that takes a batch of write operations
 // and cleans up the trie database from anything written to disk.
 type cleaner struct {
@@ -767,6 +801,18 @@ type cleaner struct {
 // the two-phase commit is to ensure ensure data availability while moving from
 // memory to disk.
 func (c *cleaner) Put(key []byte, rlp []byte) error {
+	// The batch of a block holds the pre-images and other data than the trie nodes
+	if len(key) == secureKeyLength && bytes.HasPrefix(key, secureKeyPrefix) {
+		hash := common.BytesToHash(key[len(secureKeyPrefix):])
+		if preimage, ok := c.db.preimages[hash]; ok {
+			delete(c.db.preimages, hash)
+			c.db.preimagesSize -= common.StorageSize(common.HashSize + len(preimage))
+		}
+		return nil
+	}
+	if len(key) != common.HashSize {
+		return nil
+	}
 	hash := common.BytesToHash(key)
 
 	// If the node does not exist, we're done on this path
@@ -794,13 +840,14 @@ func (c *cleaner) Put(key []byte, rlp []byte) error {
 	}
 	// Move the flushed node into the clean cache to prevent insta-reloads
 	if c.db.cleans != nil {
-		c.db.cleans.Set(string(hash[:]), rlp)
+		c.db.cleans.Set(hash, rlp)
 	}
 	return nil
 }
 
+// Delete ignores the deletes of the batch, trie nodes are never deleted by a commit.
 func (c *cleaner) Delete(key []byte) error {
-	panic("Not implemented")
+	return nil
 }
 
 // Size returns the current storage size of the memory cache in front of the
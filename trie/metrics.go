@@ -0,0 +1,19 @@
+package trie
+
+import (
+	"github.com/incognitochain/incognito-chain/metrics"
+)
+
+var (
+	memcacheCleanHitMeter   = metrics.NewRegisteredMeter("trie/memcache/clean/hit", nil)
+	memcacheCleanMissMeter  = metrics.NewRegisteredMeter("trie/memcache/clean/miss", nil)
+	memcacheCleanReadMeter  = metrics.NewRegisteredMeter("trie/memcache/clean/read", nil)
+	memcacheCleanWriteMeter = metrics.NewRegisteredMeter("trie/memcache/clean/write", nil)
+	memcacheCleanEvictMeter = metrics.NewRegisteredMeter("trie/memcache/clean/evict", nil)
+	memcacheCleanSizeGauge  = metrics.NewRegisteredGauge("trie/memcache/clean/size", nil)
+	memcacheCleanNodesGauge = metrics.NewRegisteredGauge("trie/memcache/clean/nodes", nil)
+
+	memcacheCommitTimeTimer  = metrics.NewRegisteredTimer("trie/memcache/commit/time", nil)
+	memcacheCommitNodesMeter = metrics.NewRegisteredMeter("trie/memcache/commit/nodes", nil)
+	memcacheCommitSizeMeter  = metrics.NewRegisteredMeter("trie/memcache/commit/size", nil)
+)
@@ -0,0 +1,104 @@
+package trie
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// nodeCacheEntryOverhead approximates the memory taken by an entry besides its blob: the hash in the map and in
+// the list element, the element itself and the map bucket
+const nodeCacheEntryOverhead = 2*common.HashSize + 64
+
+// NodeCache is a least recently used cache of the encoded trie nodes which are on disk, bounded by the size of the
+// cached nodes in bytes. A node is keyed by its hash, so one cache is shared by the intermediate writers of every
+// state database, of every chain. It is safe for concurrent use.
+type NodeCache struct {
+	maxSize int
+	size    int
+	entries map[common.Hash]*list.Element
+	lru     *list.List // most recently used first
+	lock    sync.Mutex
+}
+
+type nodeCacheEntry struct {
+	hash common.Hash
+	blob []byte
+}
+
+// NodeCacheStats - the content of a node cache
+type NodeCacheStats struct {
+	Nodes   int
+	Size    int
+	MaxSize int
+}
+
+// NewNodeCache creates a cache of trie nodes holding up to maxSize bytes, nil for a size of 0 or less which means
+// no cache
+func NewNodeCache(maxSize int) *NodeCache {
+	if maxSize <= 0 {
+		return nil
+	}
+	return &NodeCache{
+		maxSize: maxSize,
+		entries: make(map[common.Hash]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get returns a copy of the encoded node of hash, the caller may keep it
+func (cache *NodeCache) Get(hash common.Hash) ([]byte, bool) {
+	cache.lock.Lock()
+	element, ok := cache.entries[hash]
+	if !ok {
+		cache.lock.Unlock()
+		memcacheCleanMissMeter.Mark(1)
+		return nil, false
+	}
+	cache.lru.MoveToFront(element)
+	blob := common.CopyBytes(element.Value.(*nodeCacheEntry).blob)
+	cache.lock.Unlock()
+
+	memcacheCleanHitMeter.Mark(1)
+	memcacheCleanReadMeter.Mark(int64(len(blob)))
+	return blob, true
+}
+
+// Set caches a copy of the encoded node of hash and evicts the least recently used nodes over the size of the cache
+func (cache *NodeCache) Set(hash common.Hash, blob []byte) {
+	entrySize := len(blob) + nodeCacheEntryOverhead
+	if entrySize > cache.maxSize {
+		return
+	}
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if element, ok := cache.entries[hash]; ok {
+		cache.lru.MoveToFront(element)
+		return
+	}
+	cache.entries[hash] = cache.lru.PushFront(&nodeCacheEntry{hash: hash, blob: common.CopyBytes(blob)})
+	cache.size += entrySize
+	for cache.size > cache.maxSize {
+		oldest := cache.lru.Back()
+		entry := cache.lru.Remove(oldest).(*nodeCacheEntry)
+		delete(cache.entries, entry.hash)
+		cache.size -= len(entry.blob) + nodeCacheEntryOverhead
+		memcacheCleanEvictMeter.Mark(1)
+	}
+	memcacheCleanWriteMeter.Mark(int64(len(blob)))
+	memcacheCleanSizeGauge.Update(int64(cache.size))
+	memcacheCleanNodesGauge.Update(int64(len(cache.entries)))
+}
+
+// Stats returns the number of cached nodes and their size
+func (cache *NodeCache) Stats() NodeCacheStats {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return NodeCacheStats{
+		Nodes:   len(cache.entries),
+		Size:    cache.size,
+		MaxSize: cache.maxSize,
+	}
+}
@@ -0,0 +1,42 @@
+package trie
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func TestNodeCacheEviction(t *testing.T) {
+	blob := bytes.Repeat([]byte{1}, 100)
+	entrySize := len(blob) + nodeCacheEntryOverhead
+	cache := NewNodeCache(3 * entrySize)
+	hashes := []common.Hash{common.HashH([]byte{0}), common.HashH([]byte{1}), common.HashH([]byte{2}), common.HashH([]byte{3})}
+	for _, hash := range hashes[:3] {
+		cache.Set(hash, blob)
+	}
+	// the first node is used, the second is the least recently used
+	if _, ok := cache.Get(hashes[0]); !ok {
+		t.Fatalf("node %x is not cached", hashes[0])
+	}
+	cache.Set(hashes[3], blob)
+	if _, ok := cache.Get(hashes[1]); ok {
+		t.Errorf("least recently used node %x is not evicted", hashes[1])
+	}
+	for _, hash := range []common.Hash{hashes[0], hashes[2], hashes[3]} {
+		if got, ok := cache.Get(hash); !ok || !bytes.Equal(got, blob) {
+			t.Errorf("node %x is not cached, got %x", hash, got)
+		}
+	}
+	if stats := cache.Stats(); stats.Nodes != 3 || stats.Size != 3*entrySize {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	// the cache holds a copy of the blob
+	blob[0] = 2
+	if got, _ := cache.Get(hashes[3]); got[0] != 1 {
+		t.Errorf("cached node changed with the blob it was set from")
+	}
+	if NewNodeCache(0) != nil {
+		t.Errorf("a cache of size 0 must be nil")
+	}
+}
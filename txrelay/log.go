@@ -0,0 +1,14 @@
+package txrelay
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type TxRelayLogger struct {
+	log common.Logger
+}
+
+func (txRelayLogger *TxRelayLogger) Init(inst common.Logger) {
+	txRelayLogger.log = inst
+}
+
+// Global instant to use
+var Logger = TxRelayLogger{}
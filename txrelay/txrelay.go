@@ -0,0 +1,184 @@
+// Package txrelay relays the transactions sent in private mode the way of Dandelion++: in the stem phase a
+// transaction goes from node to node, each one forwarding it to a single peer, until a node of the fluff phase
+// broadcasts it to the network. The node where a transaction starts can not be told from the nodes of its stem by
+// watching the broadcasts.
+//
+// Every epoch a node picks one of its stem peers and draws whether it is a fluff node: a stem node forwards all the
+// transactions it receives from the stem to its stem peer, a fluff node broadcasts them. A transaction sent to the
+// node through RPC is always forwarded to the stem peer. Every node of the stem keeps the transaction under an
+// embargo: if the transaction is not seen broadcast once the embargo expires, the node broadcasts it itself, so a
+// node dropping the stem transactions can not stop them.
+package txrelay
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// Relay modes of a transaction
+const (
+	ModePublic  = "public"  // broadcast to all the peers
+	ModePrivate = "private" // stem phase, then broadcast
+)
+
+const (
+	DefaultMode             = ModePublic
+	DefaultFluffProbability = 0.1
+	DefaultEpoch            = 10 * time.Minute
+	DefaultEmbargo          = 30 * time.Second
+)
+
+var ErrNoStemPeer = errors.New("no stem peer to relay the transaction privately")
+
+type Config struct {
+	FluffProbability float64       // probability of the node to be a fluff node in an epoch
+	Epoch            time.Duration // time before the node picks its stem peer and its phase again
+	Embargo          time.Duration // the embargo of a transaction expires after a random time between Embargo and twice Embargo
+	FailSafe         bool          // broadcast a transaction sent through RPC which can not be forwarded to a stem peer instead of failing, the transactions received from the stem are always broadcast then
+
+	StemPeers func() []string                                  // peers which receive the stem transactions
+	Stem      func(tx metadata.Transaction, peer string) error // forward a transaction to a stem peer
+	Fluff     func(tx metadata.Transaction) error              // broadcast a transaction to all the peers
+	IsStem    func(txHash common.Hash) bool                    // the transaction is still in the stem phase, it is not seen broadcast nor in a block
+}
+
+type TxRelay struct {
+	config Config
+
+	mtx         sync.Mutex
+	rand        *rand.Rand
+	epochEnd    time.Time
+	isFluffNode bool
+	stemPeer    string
+	embargoes   map[common.Hash]*time.Timer
+}
+
+// New checks the config and returns a relay which draws its first epoch with the first transaction
+func New(config Config) (*TxRelay, error) {
+	if config.FluffProbability < 0 || config.FluffProbability > 1 {
+		return nil, errors.New("the fluff probability must be between 0 and 1")
+	}
+	if config.Epoch <= 0 || config.Embargo <= 0 {
+		return nil, errors.New("the epoch and the embargo must be positive")
+	}
+	if config.StemPeers == nil || config.Stem == nil || config.Fluff == nil || config.IsStem == nil {
+		return nil, errors.New("the relay needs its stem peers, stem, fluff and stem status functions")
+	}
+	return &TxRelay{
+		config:    config,
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		embargoes: make(map[common.Hash]*time.Timer),
+	}, nil
+}
+
+// IsValidMode - mode is a relay mode of a transaction
+func IsValidMode(mode string) bool {
+	return mode == ModePublic || mode == ModePrivate
+}
+
+// RelayLocal - forward a transaction sent to the node through RPC to the stem peer
+func (relay *TxRelay) RelayLocal(tx metadata.Transaction) error {
+	return relay.relay(tx, false)
+}
+
+// RelayStem - forward or broadcast a transaction received from the stem, depending on the phase of the node
+func (relay *TxRelay) RelayStem(tx metadata.Transaction) error {
+	return relay.relay(tx, true)
+}
+
+func (relay *TxRelay) relay(tx metadata.Transaction, fromStem bool) error {
+	isFluffNode, stemPeer := relay.epoch()
+	if fromStem && isFluffNode {
+		Logger.log.Infof("Fluff tx %+v received from the stem", tx.Hash().String())
+		return relay.config.Fluff(tx)
+	}
+	err := ErrNoStemPeer
+	if stemPeer != "" {
+		err = relay.config.Stem(tx, stemPeer)
+	}
+	if err == nil {
+		Logger.log.Infof("Forward tx %+v to stem peer %+v", tx.Hash().String(), stemPeer)
+		relay.startEmbargo(tx)
+		return nil
+	}
+	Logger.log.Errorf("Can not forward tx %+v to stem peer %+v: %+v", tx.Hash().String(), stemPeer, err)
+	if !fromStem && !relay.config.FailSafe {
+		return err
+	}
+	return relay.config.Fluff(tx)
+}
+
+// epoch - phase and stem peer of the current epoch, they are drawn again when the epoch is over, when the stem peer
+// is gone or when stem peers are back after an epoch without one
+func (relay *TxRelay) epoch() (bool, string) {
+	relay.mtx.Lock()
+	defer relay.mtx.Unlock()
+	peers := relay.config.StemPeers()
+	now := time.Now()
+	isStemPeerGone := relay.stemPeer != "" && !hasPeer(peers, relay.stemPeer)
+	isStemPeerBack := relay.stemPeer == "" && len(peers) > 0
+	if now.Before(relay.epochEnd) && !isStemPeerGone && !isStemPeerBack {
+		return relay.isFluffNode, relay.stemPeer
+	}
+	relay.epochEnd = now.Add(relay.config.Epoch)
+	relay.isFluffNode = relay.rand.Float64() < relay.config.FluffProbability
+	relay.stemPeer = ""
+	if len(peers) > 0 {
+		relay.stemPeer = peers[relay.rand.Intn(len(peers))]
+	}
+	Logger.log.Infof("New stem epoch: fluff node %+v, stem peer %+v", relay.isFluffNode, relay.stemPeer)
+	return relay.isFluffNode, relay.stemPeer
+}
+
+func (relay *TxRelay) startEmbargo(tx metadata.Transaction) {
+	relay.mtx.Lock()
+	defer relay.mtx.Unlock()
+	txHash := *tx.Hash()
+	if _, ok := relay.embargoes[txHash]; ok {
+		return
+	}
+	embargo := relay.config.Embargo + time.Duration(relay.rand.Int63n(int64(relay.config.Embargo)))
+	relay.embargoes[txHash] = time.AfterFunc(embargo, func() {
+		relay.stopEmbargo(txHash)
+		if !relay.config.IsStem(txHash) {
+			return
+		}
+		Logger.log.Infof("Embargo of tx %+v expired, fluff it", txHash.String())
+		if err := relay.config.Fluff(tx); err != nil {
+			Logger.log.Errorf("Can not fluff tx %+v: %+v", txHash.String(), err)
+		}
+	})
+}
+
+func (relay *TxRelay) stopEmbargo(txHash common.Hash) {
+	relay.mtx.Lock()
+	defer relay.mtx.Unlock()
+	if timer, ok := relay.embargoes[txHash]; ok {
+		timer.Stop()
+		delete(relay.embargoes, txHash)
+	}
+}
+
+// Stop - stop the embargo timers, the transactions still in the stem phase are not broadcast by this node
+func (relay *TxRelay) Stop() {
+	relay.mtx.Lock()
+	defer relay.mtx.Unlock()
+	for txHash, timer := range relay.embargoes {
+		timer.Stop()
+		delete(relay.embargoes, txHash)
+	}
+}
+
+func hasPeer(peers []string, peer string) bool {
+	for _, p := range peers {
+		if p == peer {
+			return true
+		}
+	}
+	return false
+}
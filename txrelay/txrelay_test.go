@@ -0,0 +1,149 @@
+package txrelay
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+func init() {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+type testNetwork struct {
+	mtx     sync.Mutex
+	peers   []string
+	stemErr error
+	stems   map[string][]common.Hash
+	fluffs  []common.Hash
+	isStem  bool
+}
+
+func newTestRelay(t *testing.T, network *testNetwork, fluffProbability float64, failSafe bool) *TxRelay {
+	network.stems = make(map[string][]common.Hash)
+	relay, err := New(Config{
+		FluffProbability: fluffProbability,
+		Epoch:            time.Minute,
+		Embargo:          50 * time.Millisecond,
+		FailSafe:         failSafe,
+		StemPeers: func() []string {
+			return network.peers
+		},
+		Stem: func(tx metadata.Transaction, peer string) error {
+			network.mtx.Lock()
+			defer network.mtx.Unlock()
+			if network.stemErr != nil {
+				return network.stemErr
+			}
+			network.stems[peer] = append(network.stems[peer], *tx.Hash())
+			return nil
+		},
+		Fluff: func(tx metadata.Transaction) error {
+			network.mtx.Lock()
+			defer network.mtx.Unlock()
+			network.fluffs = append(network.fluffs, *tx.Hash())
+			return nil
+		},
+		IsStem: func(txHash common.Hash) bool {
+			network.mtx.Lock()
+			defer network.mtx.Unlock()
+			return network.isStem
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return relay
+}
+
+func (network *testNetwork) fluffCount() int {
+	network.mtx.Lock()
+	defer network.mtx.Unlock()
+	return len(network.fluffs)
+}
+
+func TestRelayLocalStemsToOnePeer(t *testing.T) {
+	network := &testNetwork{peers: []string{"a", "b", "c"}, isStem: true}
+	// a local transaction is forwarded even by a fluff node
+	relay := newTestRelay(t, network, 1, true)
+	defer relay.Stop()
+	for i := 0; i < 5; i++ {
+		if err := relay.RelayLocal(&transaction.Tx{LockTime: int64(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(network.stems) != 1 {
+		t.Fatalf("Expect the txs of an epoch sent to one stem peer but get %+v", network.stems)
+	}
+	if network.fluffCount() != 0 {
+		t.Fatal("Expect no tx broadcast during the embargo")
+	}
+}
+
+func TestRelayStemFluffNode(t *testing.T) {
+	network := &testNetwork{peers: []string{"a"}}
+	relay := newTestRelay(t, network, 1, true)
+	defer relay.Stop()
+	if err := relay.RelayStem(&transaction.Tx{}); err != nil {
+		t.Fatal(err)
+	}
+	if network.fluffCount() != 1 || len(network.stems) != 0 {
+		t.Fatalf("Expect the fluff node to broadcast the tx, get %d broadcasts and stems %+v", network.fluffCount(), network.stems)
+	}
+
+	relay = newTestRelay(t, network, 0, true)
+	defer relay.Stop()
+	if err := relay.RelayStem(&transaction.Tx{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(network.stems["a"]) != 1 {
+		t.Fatalf("Expect the stem node to forward the tx, get stems %+v", network.stems)
+	}
+}
+
+func TestRelayEmbargo(t *testing.T) {
+	network := &testNetwork{peers: []string{"a"}, isStem: true}
+	relay := newTestRelay(t, network, 0, true)
+	defer relay.Stop()
+	relay.RelayLocal(&transaction.Tx{})
+	time.Sleep(150 * time.Millisecond)
+	if network.fluffCount() != 1 {
+		t.Fatal("Expect the tx broadcast once its embargo expired")
+	}
+
+	// the tx was seen broadcast before the embargo expired
+	network = &testNetwork{peers: []string{"a"}, isStem: false}
+	relay = newTestRelay(t, network, 0, true)
+	defer relay.Stop()
+	relay.RelayLocal(&transaction.Tx{})
+	time.Sleep(150 * time.Millisecond)
+	if network.fluffCount() != 0 {
+		t.Fatal("Expect no broadcast of a tx seen broadcast")
+	}
+}
+
+func TestRelayFailSafe(t *testing.T) {
+	network := &testNetwork{}
+	relay := newTestRelay(t, network, 0, false)
+	defer relay.Stop()
+	if err := relay.RelayLocal(&transaction.Tx{}); err != ErrNoStemPeer {
+		t.Fatalf("Expect ErrNoStemPeer but get %+v", err)
+	}
+	// a transaction of the stem is never dropped
+	if err := relay.RelayStem(&transaction.Tx{}); err != nil || network.fluffCount() != 1 {
+		t.Fatalf("Expect the stem tx broadcast, get %d broadcasts, error %+v", network.fluffCount(), err)
+	}
+
+	network = &testNetwork{peers: []string{"a"}}
+	relay = newTestRelay(t, network, 0, true)
+	defer relay.Stop()
+	network.stemErr = errors.New("stream reset")
+	if err := relay.RelayLocal(&transaction.Tx{}); err != nil || network.fluffCount() != 1 {
+		t.Fatalf("Expect the tx broadcast, get %d broadcasts, error %+v", network.fluffCount(), err)
+	}
+}
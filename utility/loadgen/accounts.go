@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// account sends the load. It has at most one tx in flight: the change of a tx is spendable only once the tx is in a
+// block
+type account struct {
+	privateKey     string
+	paymentAddress string
+	shardID        byte
+	busy           int32
+}
+
+func newAccount(keyWallet *wallet.KeyWallet) *account {
+	pubKey := keyWallet.KeySet.PaymentAddress.Pk
+	return &account{
+		privateKey:     keyWallet.Base58CheckSerialize(wallet.PriKeyType),
+		paymentAddress: keyWallet.Base58CheckSerialize(wallet.PaymentAddressType),
+		shardID:        common.GetShardIDFromLastByte(pubKey[len(pubKey)-1]),
+	}
+}
+
+func (a *account) acquire() bool {
+	return atomic.CompareAndSwapInt32(&a.busy, 0, 1)
+}
+
+func (a *account) release() {
+	atomic.StoreInt32(&a.busy, 0)
+}
+
+// readFunders reads the funded private keys of file, either the keys.json written by the devnet or a json array of
+// private keys
+func readFunders(file string) ([]*account, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	privateKeys := []string{}
+	if err := json.Unmarshal(data, &privateKeys); err != nil {
+		devnetKeys := struct {
+			Accounts []struct {
+				PrivateKey string
+			}
+		}{}
+		if err := json.Unmarshal(data, &devnetKeys); err != nil {
+			return nil, errors.New("keys file is neither a json array of private keys nor a devnet keys.json")
+		}
+		for _, key := range devnetKeys.Accounts {
+			privateKeys = append(privateKeys, key.PrivateKey)
+		}
+	}
+	funders := []*account{}
+	for _, privateKey := range privateKeys {
+		keyWallet, err := wallet.Base58CheckDeserialize(strings.TrimSpace(privateKey))
+		if err != nil {
+			return nil, err
+		}
+		if len(keyWallet.KeySet.PrivateKey) == 0 {
+			return nil, errors.New("private key is invalid")
+		}
+		if err := keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey); err != nil {
+			return nil, err
+		}
+		funders = append(funders, newAccount(keyWallet))
+	}
+	if len(funders) == 0 {
+		return nil, errors.New("keys file has no private key")
+	}
+	return funders, nil
+}
+
+// deriveAccounts derives count accounts from seed, spread evenly over the active shards. The same seed gives the same
+// accounts, a new run reuses the accounts funded by the previous one
+func deriveAccounts(seed string, count int, activeShards int) (*accountPool, error) {
+	master, err := wallet.NewMasterKey(common.HashB([]byte(seed)))
+	if err != nil {
+		return nil, err
+	}
+	pool := &accountPool{
+		shards: make([][]*account, activeShards),
+		next:   make([]uint32, activeShards),
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for child := uint32(0); pool.size() < count; child++ {
+		keyWallet, err := master.NewChildKey(child)
+		if err != nil {
+			return nil, err
+		}
+		a := newAccount(keyWallet)
+		shardID := int(a.shardID)
+		// the first count%activeShards shards get one more account
+		wanted := count / activeShards
+		if shardID < count%activeShards {
+			wanted++
+		}
+		if shardID >= activeShards || len(pool.shards[shardID]) >= wanted {
+			continue
+		}
+		pool.shards[shardID] = append(pool.shards[shardID], a)
+	}
+	return pool, nil
+}
+
+// accountPool hands out idle accounts, by shard and in turn
+type accountPool struct {
+	shards    [][]*account
+	next      []uint32
+	nextShard uint32
+	mtx       sync.Mutex
+	rand      *rand.Rand
+}
+
+func (pool *accountPool) size() int {
+	size := 0
+	for _, accounts := range pool.shards {
+		size += len(accounts)
+	}
+	return size
+}
+
+func (pool *accountPool) all() []*account {
+	all := []*account{}
+	for _, accounts := range pool.shards {
+		all = append(all, accounts...)
+	}
+	return all
+}
+
+// acquire returns an idle account of the next shard in turn, nil if all its accounts have a tx in flight
+func (pool *accountPool) acquire() *account {
+	shardID := int(atomic.AddUint32(&pool.nextShard, 1)-1) % len(pool.shards)
+	accounts := pool.shards[shardID]
+	if len(accounts) == 0 {
+		return nil
+	}
+	start := atomic.AddUint32(&pool.next[shardID], 1) - 1
+	for i := 0; i < len(accounts); i++ {
+		a := accounts[(int(start)+i)%len(accounts)]
+		if a.acquire() {
+			return a
+		}
+	}
+	return nil
+}
+
+// receiver returns a random account other than from, of the shard of from or of another shard
+func (pool *accountPool) receiver(from *account, crossShard bool) *account {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	candidates := []*account{}
+	for shardID, accounts := range pool.shards {
+		if (shardID != int(from.shardID)) != crossShard {
+			continue
+		}
+		for _, a := range accounts {
+			if a != from {
+				candidates = append(candidates, a)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return from
+	}
+	return candidates[pool.rand.Intn(len(candidates))]
+}
@@ -0,0 +1,315 @@
+// loadgen sends a sustained mix of txs to a node at a target rate and reports throughput and latency percentiles as
+// json. It funds load accounts from funded keys, e.g. the keys.json of a devnet, then follows the inclusion of every
+// tx through the websocket block events of the node
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/rpcclient"
+	"github.com/jessevdk/go-flags"
+)
+
+const (
+	setupTimeout  = 5 * time.Minute
+	pollInterval  = 2 * time.Second
+	tokenSymbol   = "LOAD"
+	maxRPCWorkers = 256
+)
+
+type params struct {
+	RPC              string        `long:"rpc" default:"http://127.0.0.1:9334" description:"RPC endpoint of the node"`
+	WS               string        `long:"ws" default:"ws://127.0.0.1:19334" description:"Websocket endpoint of the node"`
+	RPCUser          string        `long:"rpcuser" description:"Username for RPC connections"`
+	RPCPass          string        `long:"rpcpass" description:"Password for RPC connections"`
+	KeysFile         string        `long:"keys" short:"k" description:"Funded private keys: keys.json of a devnet or a json array of private keys"`
+	Seed             string        `long:"seed" default:"loadgen" description:"Seed of the load accounts, the same seed reuses the accounts funded by a previous run"`
+	Accounts         int           `long:"accounts" default:"40" description:"Number of load accounts, each has at most one tx in flight"`
+	TPS              float64       `long:"tps" default:"5" description:"Target txs by second"`
+	Duration         time.Duration `long:"duration" default:"1m" description:"Duration of the load"`
+	Mix              string        `long:"mix" default:"prv:70,crossshard:20,token:10" description:"Weights of tx types: prv, crossshard, token and pdetrade"`
+	Concurrency      int           `long:"concurrency" default:"16" description:"Txs created by the node at the same time"`
+	FundAmount       uint64        `long:"fundamount" default:"100000000000" description:"PRV (nano) of each load account, accounts with less than half are funded"`
+	TokenFundAmount  uint64        `long:"tokenfundamount" default:"1000000" description:"Token of each load account when the mix has token txs"`
+	TokenID          string        `long:"tokenid" description:"Privacy token of token and pdetrade txs, held by the first key. Minted by the first key when empty"`
+	Amount           uint64        `long:"amount" default:"100" description:"Amount of each transfer and PRV sold by each trade"`
+	FeePerKb         int64         `long:"feeperkb" default:"-1" description:"Fee (nano PRV) by kb of txs, -1 to let the node estimate it"`
+	FanOut           int           `long:"fanout" default:"20" description:"Receivers by funding tx"`
+	InclusionTimeout time.Duration `long:"inclusiontimeout" default:"2m" description:"A tx not in a block after this time is dropped"`
+	Drain            time.Duration `long:"drain" default:"1m" description:"Time to wait for the txs in flight after the load"`
+	Output           string        `long:"output" short:"o" description:"File of the json report, stdout when empty"`
+}
+
+func main() {
+	cfg := params{}
+	parser := flags.NewParser(&cfg, flags.Default)
+	if _, err := parser.Parse(); err != nil {
+		os.Exit(1)
+	}
+	report, err := run(&cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	output, _ := json.MarshalIndent(report, "", "  ")
+	if cfg.Output == "" {
+		fmt.Println(string(output))
+		return
+	}
+	if err := ioutil.WriteFile(cfg.Output, output, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func logf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, time.Now().Format("15:04:05 ")+format+"\n", args...)
+}
+
+func run(cfg *params) (*Report, error) {
+	if cfg.KeysFile == "" {
+		return nil, errors.New("--keys is required")
+	}
+	if cfg.TPS <= 0 || cfg.Accounts < 1 || cfg.FanOut < 1 || cfg.Concurrency < 1 || cfg.Concurrency > maxRPCWorkers {
+		return nil, fmt.Errorf("--tps, --accounts and --fanout must be positive, --concurrency between 1 and %v", maxRPCWorkers)
+	}
+	txMix, err := parseMix(cfg.Mix)
+	if err != nil {
+		return nil, err
+	}
+	funders, err := readFunders(cfg.KeysFile)
+	if err != nil {
+		return nil, err
+	}
+	client := rpcclient.NewClient(cfg.RPC)
+	if cfg.RPCUser != "" {
+		client = rpcclient.NewClientWithAuth(cfg.RPC, cfg.RPCUser, cfg.RPCPass)
+	}
+	wsClient, err := rpcclient.NewWsClient(cfg.WS)
+	if err != nil {
+		return nil, err
+	}
+	defer wsClient.Close()
+
+	activeShards, err := client.GetActiveShards()
+	if err != nil {
+		return nil, err
+	}
+	if txMix.has(crossShardTx) && activeShards < 2 {
+		return nil, errors.New("crossshard txs need at least 2 active shards")
+	}
+	pool, err := deriveAccounts(cfg.Seed, cfg.Accounts, activeShards)
+	if err != nil {
+		return nil, err
+	}
+	sender := &txSender{
+		client:   client,
+		feePerKb: float64(cfg.FeePerKb),
+		amount:   cfg.Amount,
+		tokenID:  cfg.TokenID,
+	}
+	if err := setup(cfg, txMix, sender, wsClient, funders, pool); err != nil {
+		return nil, err
+	}
+
+	recorder := newRecorder()
+	t := newTracker(wsClient, recorder, cfg.InclusionTimeout)
+	if err := t.start(activeShards); err != nil {
+		return nil, err
+	}
+	defer t.stop()
+	logf("Sending %v txs/s for %v from %v accounts, mix %v", cfg.TPS, cfg.Duration, pool.size(), cfg.Mix)
+	start := time.Now()
+	load(cfg, txMix, sender, pool, t, recorder)
+	duration := time.Since(start)
+
+	drainEnd := time.Now().Add(cfg.Drain)
+	for t.size() > 0 && time.Now().Before(drainEnd) {
+		time.Sleep(sweepInterval)
+	}
+	report := recorder.report(start, duration, t.pendingByType())
+	report.Accounts = pool.size()
+	report.TargetTPS = cfg.TPS
+	return report, nil
+}
+
+type job struct {
+	txType string
+	from   *account
+}
+
+// load sends a tx every 1/TPS for the duration, to the concurrent senders. A tick is skipped when no account is idle
+// or all senders are busy
+func load(cfg *params, txMix *mix, sender *txSender, pool *accountPool, t *tracker, recorder *recorder) {
+	jobs := make(chan job)
+	wg := sync.WaitGroup{}
+	for i := 0; i < cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				sentAt := time.Now()
+				txHash, err := sender.send(j.txType, j.from, pool)
+				if err != nil {
+					recorder.failed(j.txType, err)
+					j.from.release()
+					continue
+				}
+				acceptedAt := time.Now()
+				recorder.sent(j.txType, acceptedAt.Sub(sentAt))
+				t.add(txHash, &pendingTx{txType: j.txType, from: j.from, sentAt: acceptedAt})
+			}
+		}()
+	}
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / cfg.TPS))
+	defer ticker.Stop()
+	end := time.After(cfg.Duration)
+	for {
+		select {
+		case <-end:
+			close(jobs)
+			wg.Wait()
+			return
+		case <-ticker.C:
+			from := pool.acquire()
+			if from == nil {
+				recorder.skip()
+				continue
+			}
+			select {
+			case jobs <- job{txType: txMix.pick(r), from: from}:
+			default:
+				from.release()
+				recorder.skip()
+			}
+		}
+	}
+}
+
+// setup funds the load accounts with PRV, and with the token when the mix has token txs. The token is minted first
+// when --tokenid is empty and the mix needs one
+func setup(cfg *params, txMix *mix, sender *txSender, wsClient *rpcclient.WsClient, funders []*account, pool *accountPool) error {
+	accounts := pool.all()
+	prvBalance := func(a *account) (uint64, error) {
+		raw, err := sender.client.GetBalanceByPrivatekey(a.privateKey)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseUint(string(raw), 10, 64)
+	}
+	if err := fund(cfg, accounts, funders, prvBalance, cfg.FundAmount, sender.sendPRV, wsClient); err != nil {
+		return err
+	}
+
+	if (txMix.has(tokenTx) || txMix.has(pdeTradeTx)) && sender.tokenID == "" {
+		// the token id is a hash of the token data, a token of a previous run can not be minted again
+		name := fmt.Sprintf("%v-%v", tokenSymbol, time.Now().Unix())
+		txHash, tokenID, err := sender.mintToken(funders[0], name, uint64(len(accounts))*cfg.TokenFundAmount*2)
+		if err != nil {
+			return err
+		}
+		logf("Minting token %v in tx %v, reuse it with --tokenid", tokenID, txHash)
+		if err := waitTx(wsClient, txHash, setupTimeout); err != nil {
+			return err
+		}
+		sender.tokenID = tokenID
+	}
+	if txMix.has(tokenTx) {
+		tokenBalance := func(a *account) (uint64, error) {
+			return sender.client.GetBalancePrivacyCustomToken(a.privateKey, sender.tokenID)
+		}
+		if err := fund(cfg, accounts, funders[:1], tokenBalance, cfg.TokenFundAmount, sender.sendToken, wsClient); err != nil {
+			return err
+		}
+	}
+	if txMix.has(pdeTradeTx) {
+		burningAddress, err := sender.client.GetBurningAddress()
+		if err != nil {
+			return err
+		}
+		sender.burningAddress = burningAddress
+	}
+	return nil
+}
+
+// fund sends amount to the accounts with less than half of it, fanout receivers by tx. Each funder sends its txs one
+// after the other, its change is spendable once the previous tx is in a block. Returns when every account has amount
+func fund(cfg *params, accounts []*account, funders []*account, balance func(*account) (uint64, error), amount uint64, send func(*account, map[string]uint64) (string, error), wsClient *rpcclient.WsClient) error {
+	toFund := []*account{}
+	for _, a := range accounts {
+		b, err := balance(a)
+		if err != nil {
+			return err
+		}
+		if b < amount/2 {
+			toFund = append(toFund, a)
+		}
+	}
+	if len(toFund) == 0 {
+		return nil
+	}
+	logf("Funding %v accounts with %v", len(toFund), amount)
+	batches := make([][]*account, len(funders))
+	for i, a := range toFund {
+		batches[i%len(funders)] = append(batches[i%len(funders)], a)
+	}
+	errCh := make(chan error, len(funders))
+	for i, funder := range funders {
+		go func(funder *account, receivers []*account) {
+			for len(receivers) > 0 {
+				n := cfg.FanOut
+				if n > len(receivers) {
+					n = len(receivers)
+				}
+				payments := make(map[string]uint64)
+				for _, a := range receivers[:n] {
+					payments[a.paymentAddress] = amount
+				}
+				receivers = receivers[n:]
+				txHash, err := send(funder, payments)
+				if err != nil {
+					errCh <- err
+					return
+				}
+				if err := waitTx(wsClient, txHash, setupTimeout); err != nil {
+					errCh <- err
+					return
+				}
+			}
+			errCh <- nil
+		}(funder, batches[i])
+	}
+	for range funders {
+		if err := <-errCh; err != nil {
+			return err
+		}
+	}
+
+	// cross shard outputs reach their shard a few blocks later
+	deadline := time.Now().Add(setupTimeout)
+	for _, a := range toFund {
+		for {
+			b, err := balance(a)
+			if err != nil {
+				return err
+			}
+			if b >= amount {
+				break
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("account %v is not funded after %v", a.paymentAddress, setupTimeout)
+			}
+			time.Sleep(pollInterval)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMix(t *testing.T) {
+	m, err := parseMix("prv:70, crossshard:20,token:10,pdetrade:0")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{prvTx, crossShardTx, tokenTx}, m.types)
+	assert.Equal(t, 100, m.total)
+	assert.True(t, m.has(tokenTx))
+	assert.False(t, m.has(pdeTradeTx))
+
+	r := rand.New(rand.NewSource(1))
+	counts := make(map[string]int)
+	for i := 0; i < 10000; i++ {
+		counts[m.pick(r)]++
+	}
+	assert.InDelta(t, 7000, counts[prvTx], 300)
+	assert.InDelta(t, 2000, counts[crossShardTx], 300)
+	assert.InDelta(t, 1000, counts[tokenTx], 300)
+
+	for _, s := range []string{"", "prv:0", "prv", "prv:-1", "prv:x", "mint:10"} {
+		_, err := parseMix(s)
+		assert.NotNil(t, err, s)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	latencies := []time.Duration{}
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	report := latencyReport(latencies)
+	assert.Equal(t, LatencyReport{Mean: 50.5, P50: 50, P90: 90, P99: 99, Max: 100}, report)
+	assert.Equal(t, LatencyReport{}, latencyReport(nil))
+	assert.Equal(t, 3*time.Millisecond, percentile([]time.Duration{3 * time.Millisecond}, 50))
+}
+
+func TestDeriveAccounts(t *testing.T) {
+	pool, err := deriveAccounts("test", 10, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, pool.size())
+	for shardID, accounts := range pool.shards {
+		wanted := 2
+		if shardID < 2 {
+			wanted = 3
+		}
+		assert.Len(t, accounts, wanted)
+		for _, a := range accounts {
+			assert.Equal(t, byte(shardID), a.shardID)
+		}
+	}
+	again, err := deriveAccounts("test", 10, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, pool.shards[0][0].privateKey, again.shards[0][0].privateKey)
+
+	from := pool.shards[1][0]
+	assert.Equal(t, from.shardID, pool.receiver(from, false).shardID)
+	assert.NotEqual(t, from, pool.receiver(from, false))
+	assert.NotEqual(t, from.shardID, pool.receiver(from, true).shardID)
+
+	// every account is handed out once, then none until released
+	acquired := make(map[*account]bool)
+	for i := 0; i < 40; i++ {
+		if a := pool.acquire(); a != nil {
+			acquired[a] = true
+		}
+	}
+	assert.Len(t, acquired, 10)
+	assert.Nil(t, pool.acquire())
+	from.release()
+	var released *account
+	for i := 0; i < len(pool.shards) && released == nil; i++ {
+		released = pool.acquire()
+	}
+	assert.Equal(t, from, released)
+}
+
+func TestReadFunders(t *testing.T) {
+	pool, err := deriveAccounts("funders", 2, 1)
+	assert.Nil(t, err)
+	dir, err := ioutil.TempDir(os.TempDir(), "loadgen")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	keys := pool.all()
+	arrayFile := filepath.Join(dir, "keys.json")
+	assert.Nil(t, ioutil.WriteFile(arrayFile, []byte(`["`+keys[0].privateKey+`","`+keys[1].privateKey+`"]`), 0600))
+	funders, err := readFunders(arrayFile)
+	assert.Nil(t, err)
+	assert.Len(t, funders, 2)
+	assert.Equal(t, keys[1].paymentAddress, funders[1].paymentAddress)
+
+	devnetFile := filepath.Join(dir, "devnet.json")
+	assert.Nil(t, ioutil.WriteFile(devnetFile, []byte(`{"Accounts":[{"PrivateKey":"`+keys[0].privateKey+`"}]}`), 0600))
+	funders, err = readFunders(devnetFile)
+	assert.Nil(t, err)
+	assert.Len(t, funders, 1)
+
+	assert.Nil(t, ioutil.WriteFile(devnetFile, []byte(`{"Accounts":[]}`), 0600))
+	_, err = readFunders(devnetFile)
+	assert.NotNil(t, err)
+}
+
+func TestRecorderReport(t *testing.T) {
+	r := newRecorder()
+	start := time.Now()
+	r.sent(prvTx, 10*time.Millisecond)
+	r.sent(prvTx, 30*time.Millisecond)
+	r.sent(tokenTx, 20*time.Millisecond)
+	r.included(prvTx, time.Second)
+	r.dropped(prvTx)
+	r.failed(tokenTx, errors.New("not enough balance"))
+	r.failed(tokenTx, errors.New("not enough balance"))
+	r.skip()
+
+	report := r.report(start, 2*time.Second, map[string]int{tokenTx: 1})
+	assert.Equal(t, 1.5, report.SubmittedTPS)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, 3, report.Total.Sent)
+	assert.Equal(t, 1, report.Total.Included)
+	assert.Equal(t, 1, report.Total.Dropped)
+	assert.Equal(t, 2, report.Total.Failed)
+	assert.Equal(t, 1, report.Total.Pending)
+	assert.Equal(t, 20.0, report.Total.SubmitLatency.Mean)
+	assert.Equal(t, 2, report.ByType[tokenTx].Errors["not enough balance"])
+	assert.Equal(t, 1000.0, report.ByType[prvTx].InclusionLatency.Max)
+	assert.True(t, report.IncludedTPS > 0)
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const maxErrorLength = 120
+
+// LatencyReport is in milliseconds
+type LatencyReport struct {
+	Mean float64
+	P50  float64
+	P90  float64
+	P99  float64
+	Max  float64
+}
+
+// TypeReport counts the txs of one type. Submit latency is the time of the rpc creating and sending a tx, inclusion
+// latency the time from its acceptance by the node to the block event with it
+type TypeReport struct {
+	Sent             int
+	Included         int
+	Failed           int            // rejected by the node
+	Dropped          int            // not in a block after the inclusion timeout
+	Pending          int            // still in flight at the end of the drain
+	Errors           map[string]int `json:",omitempty"`
+	SubmitLatency    LatencyReport
+	InclusionLatency LatencyReport
+}
+
+type Report struct {
+	Accounts     int
+	TargetTPS    float64
+	Duration     float64 // seconds of load
+	SubmittedTPS float64 // txs accepted by the node by second of load
+	IncludedTPS  float64 // txs in a block by second, from the start of the load to the last inclusion
+	Skipped      int     // ticks without an idle account or a free sender, more accounts or concurrency are needed
+	Total        *TypeReport
+	ByType       map[string]*TypeReport
+}
+
+type typeStats struct {
+	sent               int
+	included           int
+	failed             int
+	dropped            int
+	errors             map[string]int
+	submitLatencies    []time.Duration
+	inclusionLatencies []time.Duration
+}
+
+// recorder collects the results of the txs of the load
+type recorder struct {
+	mtx           sync.Mutex
+	stats         map[string]*typeStats
+	skipped       int
+	lastInclusion time.Time
+}
+
+func newRecorder() *recorder {
+	return &recorder{stats: make(map[string]*typeStats)}
+}
+
+func (r *recorder) get(txType string) *typeStats {
+	stats, ok := r.stats[txType]
+	if !ok {
+		stats = &typeStats{errors: make(map[string]int)}
+		r.stats[txType] = stats
+	}
+	return stats
+}
+
+func (r *recorder) sent(txType string, submitLatency time.Duration) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	stats := r.get(txType)
+	stats.sent++
+	stats.submitLatencies = append(stats.submitLatencies, submitLatency)
+}
+
+func (r *recorder) failed(txType string, err error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	stats := r.get(txType)
+	stats.failed++
+	message := err.Error()
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength]
+	}
+	stats.errors[message]++
+}
+
+func (r *recorder) included(txType string, inclusionLatency time.Duration) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	stats := r.get(txType)
+	stats.included++
+	stats.inclusionLatencies = append(stats.inclusionLatencies, inclusionLatency)
+	r.lastInclusion = time.Now()
+}
+
+func (r *recorder) dropped(txType string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.get(txType).dropped++
+}
+
+func (r *recorder) skip() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.skipped++
+}
+
+// report summarizes the load started at start and lasting duration, pending are the txs in flight by type
+func (r *recorder) report(start time.Time, duration time.Duration, pending map[string]int) *Report {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	report := &Report{
+		Duration: duration.Seconds(),
+		Skipped:  r.skipped,
+		ByType:   make(map[string]*TypeReport),
+	}
+	total := &typeStats{errors: make(map[string]int)}
+	for txType, stats := range r.stats {
+		report.ByType[txType] = stats.report(pending[txType])
+		total.sent += stats.sent
+		total.included += stats.included
+		total.failed += stats.failed
+		total.dropped += stats.dropped
+		for message, count := range stats.errors {
+			total.errors[message] += count
+		}
+		total.submitLatencies = append(total.submitLatencies, stats.submitLatencies...)
+		total.inclusionLatencies = append(total.inclusionLatencies, stats.inclusionLatencies...)
+	}
+	totalPending := 0
+	for _, count := range pending {
+		totalPending += count
+	}
+	report.Total = total.report(totalPending)
+	if duration > 0 {
+		report.SubmittedTPS = float64(total.sent) / duration.Seconds()
+	}
+	if elapsed := r.lastInclusion.Sub(start); total.included > 0 && elapsed > 0 {
+		report.IncludedTPS = float64(total.included) / elapsed.Seconds()
+	}
+	return report
+}
+
+func (stats *typeStats) report(pending int) *TypeReport {
+	return &TypeReport{
+		Sent:             stats.sent,
+		Included:         stats.included,
+		Failed:           stats.failed,
+		Dropped:          stats.dropped,
+		Pending:          pending,
+		Errors:           stats.errors,
+		SubmitLatency:    latencyReport(stats.submitLatencies),
+		InclusionLatency: latencyReport(stats.inclusionLatencies),
+	}
+}
+
+func latencyReport(latencies []time.Duration) LatencyReport {
+	if len(latencies) == 0 {
+		return LatencyReport{}
+	}
+	sorted := append([]time.Duration{}, latencies...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	sum := time.Duration(0)
+	for _, latency := range sorted {
+		sum += latency
+	}
+	return LatencyReport{
+		Mean: milliseconds(sum / time.Duration(len(sorted))),
+		P50:  milliseconds(percentile(sorted, 50)),
+		P90:  milliseconds(percentile(sorted, 90)),
+		P99:  milliseconds(percentile(sorted, 99)),
+		Max:  milliseconds(sorted[len(sorted)-1]),
+	}
+}
+
+// percentile of sorted latencies, nearest rank
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/rpcclient"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+)
+
+const (
+	subscribeNewShardBlock      = "subcribenewshardblock"
+	subscribePendingTransaction = "subcribependingtransaction"
+	sweepInterval               = time.Second
+	seenTTL                     = 10 * time.Second
+)
+
+type pendingTx struct {
+	txType string
+	from   *account
+	sentAt time.Time
+}
+
+// tracker follows the txs of the load through the new shard block events of the node: a tx is included when a block
+// of its shard has it, then its sender is idle again
+type tracker struct {
+	wsClient      *rpcclient.WsClient
+	recorder      *recorder
+	timeout       time.Duration
+	mtx           sync.Mutex
+	pending       map[string]*pendingTx
+	seen          map[string]time.Time // txs in blocks, a block event may come before the rpc sending its tx returns
+	subscriptions []*rpcclient.Subscription
+	stopCh        chan struct{}
+}
+
+func newTracker(wsClient *rpcclient.WsClient, recorder *recorder, timeout time.Duration) *tracker {
+	return &tracker{
+		wsClient: wsClient,
+		recorder: recorder,
+		timeout:  timeout,
+		pending:  make(map[string]*pendingTx),
+		seen:     make(map[string]time.Time),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+func (t *tracker) start(activeShards int) error {
+	for shardID := 0; shardID < activeShards; shardID++ {
+		subscription, err := t.wsClient.Subscribe(subscribeNewShardBlock, []interface{}{shardID}, t.onBlock)
+		if err != nil {
+			return err
+		}
+		t.subscriptions = append(t.subscriptions, subscription)
+	}
+	go t.sweepLoop()
+	return nil
+}
+
+func (t *tracker) stop() {
+	close(t.stopCh)
+	for _, subscription := range t.subscriptions {
+		subscription.Unsubscribe()
+	}
+}
+
+func (t *tracker) add(txHash string, tx *pendingTx) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if _, ok := t.seen[txHash]; ok {
+		t.recorder.included(tx.txType, 0)
+		tx.from.release()
+		return
+	}
+	t.pending[txHash] = tx
+}
+
+func (t *tracker) onBlock(raw json.RawMessage, err error) {
+	if err != nil {
+		return
+	}
+	block := jsonresult.GetShardBlockResult{}
+	if err := json.Unmarshal(raw, &block); err != nil {
+		return
+	}
+	now := time.Now()
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	for _, txHash := range block.TxHashes {
+		tx, ok := t.pending[txHash]
+		if !ok {
+			t.seen[txHash] = now
+			continue
+		}
+		delete(t.pending, txHash)
+		t.recorder.included(tx.txType, now.Sub(tx.sentAt))
+		tx.from.release()
+	}
+}
+
+// sweepLoop drops the txs not in a block after the timeout, their senders may send again
+func (t *tracker) sweepLoop() {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.stopCh:
+			return
+		case now := <-ticker.C:
+			t.mtx.Lock()
+			for txHash, tx := range t.pending {
+				if now.Sub(tx.sentAt) > t.timeout {
+					delete(t.pending, txHash)
+					t.recorder.dropped(tx.txType)
+					tx.from.release()
+				}
+			}
+			for txHash, seenAt := range t.seen {
+				if now.Sub(seenAt) > seenTTL {
+					delete(t.seen, txHash)
+				}
+			}
+			t.mtx.Unlock()
+		}
+	}
+}
+
+func (t *tracker) size() int {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return len(t.pending)
+}
+
+func (t *tracker) pendingByType() map[string]int {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	result := make(map[string]int)
+	for _, tx := range t.pending {
+		result[tx.txType]++
+	}
+	return result
+}
+
+// waitTx waits for txHash to be in a block through the pending transaction subscription of the node, used for the
+// few txs funding the load
+func waitTx(wsClient *rpcclient.WsClient, txHash string, timeout time.Duration) error {
+	done := make(chan error, 1)
+	subscription, err := wsClient.Subscribe(subscribePendingTransaction, []interface{}{txHash}, func(raw json.RawMessage, err error) {
+		select {
+		case done <- err:
+		default:
+		}
+	})
+	if err != nil {
+		return err
+	}
+	defer subscription.Unsubscribe()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("tx %v is not in a block after %v", txHash, timeout)
+	}
+}
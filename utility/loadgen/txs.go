@@ -0,0 +1,176 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcclient"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+const (
+	prvTx        = "prv"        // PRV transfer in the shard of the sender
+	crossShardTx = "crossshard" // PRV transfer to another shard
+	tokenTx      = "token"      // privacy token transfer in the shard of the sender
+	pdeTradeTx   = "pdetrade"   // PDE trade selling PRV for the token
+
+	withPrivacy    = 1
+	withoutPrivacy = 0
+)
+
+var txTypes = []string{prvTx, crossShardTx, tokenTx, pdeTradeTx}
+
+// mix picks the type of each tx of the load by weight
+type mix struct {
+	types   []string
+	weights []int
+	total   int
+}
+
+// parseMix parses weights of tx types, e.g. "prv:70,crossshard:20,token:10"
+func parseMix(s string) (*mix, error) {
+	m := &mix{}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("mix item %v is not type:weight", item)
+		}
+		if ok, _ := common.SliceExists(txTypes, parts[0]); !ok {
+			return nil, fmt.Errorf("unknown tx type %v, list types: %v", parts[0], txTypes)
+		}
+		weight, err := strconv.Atoi(parts[1])
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("weight of %v is invalid", parts[0])
+		}
+		if weight == 0 {
+			continue
+		}
+		m.types = append(m.types, parts[0])
+		m.weights = append(m.weights, weight)
+		m.total += weight
+	}
+	if m.total == 0 {
+		return nil, errors.New("mix is empty")
+	}
+	return m, nil
+}
+
+func (m *mix) has(txType string) bool {
+	ok, _ := common.SliceExists(m.types, txType)
+	return ok
+}
+
+func (m *mix) pick(r *rand.Rand) string {
+	n := r.Intn(m.total)
+	for i, weight := range m.weights {
+		if n < weight {
+			return m.types[i]
+		}
+		n -= weight
+	}
+	return m.types[len(m.types)-1]
+}
+
+// txSender creates and sends txs through the createandsend rpcs, the node proves and signs them
+type txSender struct {
+	client         *rpcclient.Client
+	feePerKb       float64
+	amount         uint64
+	tokenID        string
+	burningAddress string
+}
+
+func (s *txSender) sendPRV(from *account, receivers map[string]uint64) (string, error) {
+	result, err := s.client.CreateAndSendTransaction(from.privateKey, amounts(receivers), s.feePerKb, withPrivacy)
+	if err != nil {
+		return "", err
+	}
+	return result.TxID, nil
+}
+
+func (s *txSender) sendToken(from *account, receivers map[string]uint64) (string, error) {
+	tokenParams := map[string]interface{}{
+		"Privacy":        true,
+		"TokenID":        s.tokenID,
+		"TokenName":      "",
+		"TokenSymbol":    "",
+		"TokenTxType":    transaction.CustomTokenTransfer,
+		"TokenAmount":    0,
+		"TokenReceivers": amounts(receivers),
+		"TokenFee":       0,
+	}
+	result, err := s.client.CreateAndSendPrivacyCustomTokenTransaction(from.privateKey, nil, s.feePerKb, withPrivacy, tokenParams, "", withPrivacy)
+	if err != nil {
+		return "", err
+	}
+	return result.TxID, nil
+}
+
+// mintToken creates a privacy token of amount owned by to and returns the tx and the token ids
+func (s *txSender) mintToken(to *account, name string, amount uint64) (string, string, error) {
+	tokenParams := map[string]interface{}{
+		"Privacy":        true,
+		"TokenID":        "",
+		"TokenName":      name,
+		"TokenSymbol":    tokenSymbol,
+		"TokenTxType":    transaction.CustomTokenInit,
+		"TokenAmount":    amount,
+		"TokenReceivers": amounts(map[string]uint64{to.paymentAddress: amount}),
+		"TokenFee":       0,
+	}
+	result, err := s.client.CreateAndSendPrivacyCustomTokenTransaction(to.privateKey, nil, s.feePerKb, withPrivacy, tokenParams, "", withPrivacy)
+	if err != nil {
+		return "", "", err
+	}
+	return result.TxID, result.TokenID, nil
+}
+
+// sendTrade sells amount PRV for the token, without minimum: when the pool does not exist the beacon refunds it
+func (s *txSender) sendTrade(from *account) (string, error) {
+	tradeParams := map[string]interface{}{
+		"TokenIDToBuyStr":     s.tokenID,
+		"TokenIDToSellStr":    common.PRVCoinID.String(),
+		"SellAmount":          s.amount,
+		"MinAcceptableAmount": 0,
+		"TradingFee":          0,
+		"TraderAddressStr":    from.paymentAddress,
+	}
+	receivers := map[string]uint64{s.burningAddress: s.amount}
+	result, err := s.client.CreateAndSendTxWithPRVTradeReq(from.privateKey, amounts(receivers), s.feePerKb, withoutPrivacy, tradeParams)
+	if err != nil {
+		return "", err
+	}
+	return result.TxID, nil
+}
+
+// send sends a tx of txType from from, to a receiver of pool
+func (s *txSender) send(txType string, from *account, pool *accountPool) (string, error) {
+	switch txType {
+	case prvTx:
+		return s.sendPRV(from, map[string]uint64{pool.receiver(from, false).paymentAddress: s.amount})
+	case crossShardTx:
+		return s.sendPRV(from, map[string]uint64{pool.receiver(from, true).paymentAddress: s.amount})
+	case tokenTx:
+		return s.sendToken(from, map[string]uint64{pool.receiver(from, false).paymentAddress: s.amount})
+	case pdeTradeTx:
+		return s.sendTrade(from)
+	}
+	return "", fmt.Errorf("unknown tx type %v", txType)
+}
+
+// amounts is the receivers param of the rpcs, amounts are json numbers
+func amounts(receivers map[string]uint64) map[string]interface{} {
+	result := make(map[string]interface{})
+	for paymentAddress, amount := range receivers {
+		result[paymentAddress] = amount
+	}
+	return result
+}
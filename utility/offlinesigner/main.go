@@ -0,0 +1,318 @@
+// offlinesigner signs transactions built by the createunsignedtransaction and
+// createunsignedprivacycustomtokentransaction rpcs on a machine without network,
+// the private key is read from a file or stdin and never leaves this machine
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/jessevdk/go-flags"
+)
+
+const (
+	serialNumbersCmd = "serialnumbers"
+	signCmd          = "sign"
+)
+
+var cmdList = []string{serialNumbersCmd, signCmd}
+
+type params struct {
+	Command     string `long:"cmd" short:"c" description:"Command name: serialnumbers or sign"`
+	KeyFile     string `long:"keyfile" short:"k" description:"File containing the base58 private key, read from stdin when omitted"`
+	Template    string `long:"template" short:"t" description:"File containing the result of createunsignedtransaction or createunsignedprivacycustomtokentransaction (sign)"`
+	SNDs        string `long:"snds" description:"SNDerivators of coins returned by listoutputcoins, separated by \",\" (serialnumbers)"`
+	Expect      string `long:"expect" description:"Expected outputs paymentaddress:amount[:tokenid] separated by \",\", change excluded, signs without confirmation (sign)"`
+	MaxFee      uint64 `long:"maxfee" description:"Highest PRV fee signed with --expect (sign)"`
+	MaxTokenFee uint64 `long:"maxtokenfee" description:"Highest token fee signed with --expect (sign)"`
+}
+
+func main() {
+	backend := common.NewBackend(nil)
+	transaction.Logger.Init(backend.Logger("Transaction log", true))
+	privacy.Logger.Init(backend.Logger("Privacy log", true))
+
+	cfg := params{}
+	parser := flags.NewParser(&cfg, flags.Default)
+	if _, err := parser.Parse(); err != nil {
+		os.Exit(1)
+	}
+	if ok, _ := common.SliceExists(cmdList, cfg.Command); !ok {
+		fmt.Fprintf(os.Stderr, "Unknown cmd %+v, list cmd: %+v\n", cfg.Command, cmdList)
+		os.Exit(1)
+	}
+	stdin := bufio.NewReader(os.Stdin)
+	keySet, err := readKeySet(cfg.KeyFile, stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var result interface{}
+	switch cfg.Command {
+	case serialNumbersCmd:
+		result, err = serialNumbers(keySet, strings.Split(cfg.SNDs, ","))
+	case signCmd:
+		approve := confirmOutputs(keySet, stdin, os.Stderr)
+		if cfg.Expect != "" {
+			approve, err = expectOutputs(keySet, cfg.Expect, cfg.MaxFee, cfg.MaxTokenFee, os.Stderr)
+		}
+		var data []byte
+		if err == nil {
+			data, err = ioutil.ReadFile(cfg.Template)
+		}
+		if err == nil {
+			result, err = sign(keySet, data, approve)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	output, _ := json.MarshalIndent(result, "", "  ")
+	fmt.Println(string(output))
+}
+
+// readKeySet reads the private key from keyFile, or a line of stdin which is shared with the confirmation of sign
+func readKeySet(keyFile string, stdin *bufio.Reader) (*incognitokey.KeySet, error) {
+	var privateKeyStr string
+	if keyFile != "" {
+		data, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		privateKeyStr = string(data)
+	} else {
+		fmt.Fprint(os.Stderr, "Private key: ")
+		line, err := stdin.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		privateKeyStr = line
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(strings.TrimSpace(privateKeyStr))
+	if err != nil {
+		return nil, err
+	}
+	if len(keyWallet.KeySet.PrivateKey) == 0 {
+		return nil, errors.New("private key is invalid")
+	}
+	err = keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &keyWallet.KeySet, nil
+}
+
+// serialNumbers returns SNDerivator to serial number of coins, the serialNumbers param of createunsignedtransaction
+func serialNumbers(keySet *incognitokey.KeySet, snds []string) (map[string]string, error) {
+	result := make(map[string]string)
+	privateKey := new(privacy.Scalar).FromBytesS(keySet.PrivateKey)
+	for _, sndStr := range snds {
+		sndStr = strings.TrimSpace(sndStr)
+		if sndStr == "" {
+			continue
+		}
+		snd, _, err := base58.Base58Check{}.Decode(sndStr)
+		if err != nil {
+			return nil, fmt.Errorf("SND %+v is invalid", sndStr)
+		}
+		serialNumber := new(privacy.Point).Derive(privacy.PedCom.G[privacy.PedersenPrivateKeyIndex], privateKey, new(privacy.Scalar).FromBytesS(snd))
+		result[sndStr] = base58.Base58Check{}.Encode(serialNumber.ToBytesS(), common.ZeroByte)
+	}
+	return result, nil
+}
+
+// approveFunc decides whether templates built by the node, which is not trusted, can be signed: the template of a PRV
+// tx, or the PRV and the token template of a privacy token tx
+type approveFunc func(templates []*transaction.TxTemplate) error
+
+// sign proves and signs the template in data once approve accepts it. data is either the result of
+// createunsignedtransaction or createunsignedprivacycustomtokentransaction, or the template alone.
+// Base58CheckData of the result is the param of sendtransaction, or sendrawprivacycustomtokentransaction for a token
+func sign(keySet *incognitokey.KeySet, data []byte, approve approveFunc) (*jsonresult.CreateTransactionResult, error) {
+	envelope := struct {
+		Template      json.RawMessage
+		TokenTemplate json.RawMessage
+	}{}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+	// a token template alone has a Template too, the PRV part
+	if len(envelope.Template) > 0 && len(envelope.TokenTemplate) == 0 {
+		data = envelope.Template
+		envelope.Template, envelope.TokenTemplate = nil, nil
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			return nil, err
+		}
+	}
+
+	var tx interface {
+		Hash() *common.Hash
+		GetSenderAddrLastByte() byte
+	}
+	if len(envelope.TokenTemplate) > 0 {
+		template := new(transaction.TxPrivacyTokenTemplate)
+		if err := json.Unmarshal(data, template); err != nil {
+			return nil, err
+		}
+		if err := approve([]*transaction.TxTemplate{template.Template, template.TokenTemplate}); err != nil {
+			return nil, err
+		}
+		tokenTx := &transaction.TxCustomTokenPrivacy{}
+		if err := tokenTx.InitFromTemplate(template, &keySet.PrivateKey); err != nil {
+			return nil, err
+		}
+		tx = tokenTx
+	} else {
+		template := new(transaction.TxTemplate)
+		if err := json.Unmarshal(data, template); err != nil {
+			return nil, err
+		}
+		if err := approve([]*transaction.TxTemplate{template}); err != nil {
+			return nil, err
+		}
+		normalTx := &transaction.Tx{}
+		if err := normalTx.InitFromTemplate(template, &keySet.PrivateKey); err != nil {
+			return nil, err
+		}
+		tx = normalTx
+	}
+	txBytes, err := json.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	result := jsonresult.NewCreateTransactionResult(tx.Hash(), common.EmptyString, txBytes, common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte()))
+	return &result, nil
+}
+
+func paymentAddressString(paymentAddress privacy.PaymentAddress) string {
+	keyWallet := wallet.KeyWallet{KeySet: incognitokey.KeySet{PaymentAddress: paymentAddress}}
+	return keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+}
+
+func isChange(keySet *incognitokey.KeySet, paymentInfo *privacy.PaymentInfo) bool {
+	return bytes.Equal(paymentInfo.PaymentAddress.Pk, keySet.PaymentAddress.Pk) && bytes.Equal(paymentInfo.PaymentAddress.Tk, keySet.PaymentAddress.Tk)
+}
+
+// describeTemplates writes the token, outputs, fee and metadata type of each template, what the signed tx spends
+func describeTemplates(out io.Writer, keySet *incognitokey.KeySet, templates []*transaction.TxTemplate) {
+	for _, template := range templates {
+		if template == nil {
+			continue
+		}
+		fmt.Fprintf(out, "Token: %s\n", template.TokenID.String())
+		for _, paymentInfo := range template.PaymentInfos {
+			if paymentInfo == nil {
+				continue
+			}
+			if isChange(keySet, paymentInfo) {
+				fmt.Fprintf(out, "  Output: %s %d (change)\n", paymentAddressString(paymentInfo.PaymentAddress), paymentInfo.Amount)
+			} else {
+				fmt.Fprintf(out, "  Output: %s %d\n", paymentAddressString(paymentInfo.PaymentAddress), paymentInfo.Amount)
+			}
+		}
+		fmt.Fprintf(out, "  Fee: %d\n", template.Fee)
+		if template.Metadata != nil {
+			fmt.Fprintf(out, "  Metadata type: %d\n", template.Metadata.GetType())
+		}
+	}
+}
+
+// confirmOutputs describes the templates to out and approves them when the user answers y on in
+func confirmOutputs(keySet *incognitokey.KeySet, in *bufio.Reader, out io.Writer) approveFunc {
+	return func(templates []*transaction.TxTemplate) error {
+		describeTemplates(out, keySet, templates)
+		fmt.Fprint(out, "Sign? [y/N]: ")
+		line, err := in.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if strings.ToLower(strings.TrimSpace(line)) != "y" {
+			return errors.New("signing is cancelled")
+		}
+		return nil
+	}
+}
+
+// expectOutputs approves templates whose outputs other than change are exactly the expected
+// "paymentaddress:amount[:tokenid],..." ones, PRV when the token id is omitted, and whose fee is at most maxFee for
+// PRV and maxTokenFee for a token
+func expectOutputs(keySet *incognitokey.KeySet, expected string, maxFee uint64, maxTokenFee uint64, out io.Writer) (approveFunc, error) {
+	expectedOutputs := make(map[string]int)
+	for _, item := range strings.Split(expected, ",") {
+		item = strings.TrimSpace(item)
+		parts := strings.Split(item, ":")
+		if len(parts) != 2 && len(parts) != 3 {
+			return nil, fmt.Errorf("expected output %+v is not paymentaddress:amount[:tokenid]", item)
+		}
+		keyWallet, err := wallet.Base58CheckDeserialize(parts[0])
+		if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+			return nil, fmt.Errorf("payment address of expected output %+v is invalid", item)
+		}
+		amount, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("amount of expected output %+v is invalid", item)
+		}
+		tokenID := &common.PRVCoinID
+		if len(parts) == 3 {
+			tokenID, err = common.Hash{}.NewHashFromStr(parts[2])
+			if err != nil {
+				return nil, fmt.Errorf("token id of expected output %+v is invalid", item)
+			}
+		}
+		expectedOutputs[fmt.Sprintf("%s:%d:%s", paymentAddressString(keyWallet.KeySet.PaymentAddress), amount, tokenID.String())]++
+	}
+	return func(templates []*transaction.TxTemplate) error {
+		describeTemplates(out, keySet, templates)
+		remaining := make(map[string]int)
+		for output, count := range expectedOutputs {
+			remaining[output] = count
+		}
+		for _, template := range templates {
+			if template == nil {
+				continue
+			}
+			if template.TokenID.IsEqual(&common.PRVCoinID) && template.Fee > maxFee {
+				return fmt.Errorf("fee %d is more than max fee %d", template.Fee, maxFee)
+			}
+			if !template.TokenID.IsEqual(&common.PRVCoinID) && template.Fee > maxTokenFee {
+				return fmt.Errorf("token fee %d is more than max token fee %d", template.Fee, maxTokenFee)
+			}
+			for _, paymentInfo := range template.PaymentInfos {
+				if paymentInfo == nil {
+					continue
+				}
+				output := fmt.Sprintf("%s:%d:%s", paymentAddressString(paymentInfo.PaymentAddress), paymentInfo.Amount, template.TokenID.String())
+				if remaining[output] > 0 {
+					remaining[output]--
+					continue
+				}
+				if !isChange(keySet, paymentInfo) {
+					return fmt.Errorf("output %s is not expected", output)
+				}
+			}
+		}
+		for output, count := range remaining {
+			if count > 0 {
+				return fmt.Errorf("expected output %s is missing", output)
+			}
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+const testPrivateKey = "112t8rnXBS7jJ4iqFon5rM66ex1Fc7sstNrJA9iMKgNURMUf3rywYfJ4c5Kpxw1BgL1frj9Nu5uL5vpemn9mLUW25CD1w7khX88WdauTVyKa"
+
+func init() {
+	backend := common.NewBackend(nil)
+	transaction.Logger.Init(backend.Logger("test", true))
+	privacy.Logger.Init(backend.Logger("test", true))
+}
+
+// newTestStateDB returns an empty transaction state db
+func newTestStateDB(t *testing.T) (*statedb.StateDB, func()) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "offlinesigner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	diskDB, err := incdb.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateDB, err := statedb.NewWithPrefixTrie(common.HexToHash(common.HexEmptyRoot), statedb.NewDatabaseAccessWarper(diskDB))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stateDB, func() {
+		diskDB.Close()
+		os.RemoveAll(dbPath)
+	}
+}
+
+// newTestCoins stores commitments of numCoins coins of tokenID of keySet in stateDB
+func newTestCoins(t *testing.T, stateDB *statedb.StateDB, keySet *incognitokey.KeySet, tokenID common.Hash, numCoins int) []*privacy.InputCoin {
+	shardID := common.GetShardIDFromLastByte(keySet.PaymentAddress.Pk[len(keySet.PaymentAddress.Pk)-1])
+	publicKey, err := new(privacy.Point).FromBytesS(keySet.PaymentAddress.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coins := make([]*privacy.InputCoin, numCoins)
+	commitments := make([][]byte, numCoins)
+	for i := range coins {
+		coin := new(privacy.Coin)
+		coin.SetPublicKey(publicKey)
+		coin.SetValue(1000)
+		coin.SetRandomness(privacy.RandomScalar())
+		coin.SetSNDerivator(privacy.RandomScalar())
+		if err := coin.CommitAll(); err != nil {
+			t.Fatal(err)
+		}
+		coins[i] = &privacy.InputCoin{CoinDetails: coin}
+		commitments[i] = coin.GetCoinCommitment().ToBytesS()
+	}
+	if err := statedb.StoreCommitments(stateDB, tokenID, keySet.PaymentAddress.Pk, commitments, shardID); err != nil {
+		t.Fatal(err)
+	}
+	return coins
+}
+
+func TestSignTemplate(t *testing.T) {
+	keySet, err := readKeySet("", bufio.NewReader(strings.NewReader(testPrivateKey+"\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateDB, cleanup := newTestStateDB(t)
+	defer cleanup()
+	coins := newTestCoins(t, stateDB, keySet, common.PRVCoinID, 10)
+	receiver := new(incognitokey.KeySet).GenerateKey([]byte("receiver"))
+	paymentInfos := []*privacy.PaymentInfo{{PaymentAddress: receiver.PaymentAddress, Amount: 1500}}
+
+	// built by the node without private key
+	template, err := transaction.NewTxTemplate(
+		transaction.NewTxPrivacyInitParams(nil, paymentInfos, coins[:2], 10, true, stateDB, nil, nil, []byte("offline")),
+		keySet.PaymentAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(template.PaymentInfos) != 2 || template.PaymentInfos[1].Amount != 490 {
+		t.Fatalf("expect change of 490 got %+v", template.PaymentInfos)
+	}
+	data, err := json.Marshal(struct{ Template *transaction.TxTemplate }{template})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// outputs other than change and fee are checked before signing
+	receiverOutput := fmt.Sprintf("%s:%d", paymentAddressString(receiver.PaymentAddress), 1500)
+	for _, expected := range []struct {
+		outputs string
+		maxFee  uint64
+	}{
+		{paymentAddressString(receiver.PaymentAddress) + ":1400", 10},
+		{receiverOutput + "," + receiverOutput, 10},
+		{receiverOutput, 9},
+	} {
+		approve, err := expectOutputs(keySet, expected.outputs, expected.maxFee, 0, ioutil.Discard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sign(keySet, data, approve); err == nil {
+			t.Errorf("expect error signing with expected outputs %+v and max fee %d", expected.outputs, expected.maxFee)
+		}
+	}
+	if _, err := sign(keySet, data, confirmOutputs(keySet, bufio.NewReader(strings.NewReader("n\n")), ioutil.Discard)); err == nil {
+		t.Error("expect error signing without confirmation")
+	}
+	if _, err := sign(keySet, data, confirmOutputs(keySet, bufio.NewReader(strings.NewReader("y\n")), ioutil.Discard)); err != nil {
+		t.Errorf("expect confirmed template to be signed got %+v", err)
+	}
+
+	approve, err := expectOutputs(keySet, receiverOutput, 10, 0, ioutil.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := sign(keySet, data, approve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txBytes, _, err := base58.Base58Check{}.Decode(result.Base58CheckData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := transaction.Tx{}
+	if err := json.Unmarshal(txBytes, &tx); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash().String() != result.TxID || tx.Fee != 10 || string(tx.Info) != "offline" {
+		t.Errorf("unexpected tx %+v", result)
+	}
+	shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
+	ok, err := tx.ValidateTransaction(true, stateDB, nil, shardID, &common.PRVCoinID, false, true)
+	if !ok || err != nil {
+		t.Fatalf("expect valid tx got %+v", err)
+	}
+
+	// serial numbers given to createunsignedtransaction are those spent by the signed tx
+	snds := make([]string, 0)
+	for _, coin := range coins[:2] {
+		snds = append(snds, base58.Base58Check{}.Encode(coin.CoinDetails.GetSNDerivator().ToBytesS(), common.ZeroByte))
+	}
+	serialNumbersMap, err := serialNumbers(keySet, snds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, input := range tx.Proof.GetInputCoins() {
+		serialNumber := base58.Base58Check{}.Encode(input.CoinDetails.GetSerialNumber().ToBytesS(), common.ZeroByte)
+		if serialNumbersMap[snds[i]] != serialNumber {
+			t.Errorf("unexpected serial number of input %d", i)
+		}
+	}
+
+	// template of another sender is rejected
+	if _, err := sign(receiver, data, approve); err == nil {
+		t.Error("expect error signing with another private key")
+	}
+
+	// amounts wrapping around to the input value are rejected
+	template.PaymentInfos = []*privacy.PaymentInfo{
+		{PaymentAddress: receiver.PaymentAddress, Amount: 1500 + 1<<63},
+		{PaymentAddress: keySet.PaymentAddress, Amount: 490 + 1<<63},
+	}
+	data, err = json.Marshal(struct{ Template *transaction.TxTemplate }{template})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sign(keySet, data, func([]*transaction.TxTemplate) error { return nil }); err == nil {
+		t.Error("expect error signing a template whose output values overflow")
+	}
+}
+
+func TestSignPrivacyTokenTemplate(t *testing.T) {
+	keySet, err := readKeySet("", bufio.NewReader(strings.NewReader(testPrivateKey+"\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateDB, cleanup := newTestStateDB(t)
+	defer cleanup()
+	tokenID := common.HashH([]byte("token"))
+	if err := statedb.StorePrivacyToken(stateDB, tokenID, "Token", "TKN", statedb.InitToken, false, 2000, nil, common.Hash{}); err != nil {
+		t.Fatal(err)
+	}
+	prvCoins := newTestCoins(t, stateDB, keySet, common.PRVCoinID, 10)
+	tokenCoins := newTestCoins(t, stateDB, keySet, tokenID, 10)
+	receiver := new(incognitokey.KeySet).GenerateKey([]byte("receiver"))
+	tokenParams := &transaction.CustomTokenPrivacyParamTx{
+		PropertyID:     tokenID.String(),
+		PropertyName:   "Token",
+		PropertySymbol: "TKN",
+		TokenTxType:    transaction.CustomTokenTransfer,
+		Amount:         1500,
+		Receiver:       []*privacy.PaymentInfo{{PaymentAddress: receiver.PaymentAddress, Amount: 1500}},
+		TokenInput:     tokenCoins[:2],
+	}
+	shardID := common.GetShardIDFromLastByte(keySet.PaymentAddress.Pk[len(keySet.PaymentAddress.Pk)-1])
+
+	// built by the node without private key
+	template, err := transaction.NewTxPrivacyTokenTemplate(
+		transaction.NewTxPrivacyTokenInitParams(nil, nil, prvCoins[:1], 10, tokenParams, stateDB, nil, true, true, shardID, nil, nil),
+		keySet.PaymentAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(struct{ Template *transaction.TxPrivacyTokenTemplate }{template})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// token outputs are told apart from PRV ones
+	approve, err := expectOutputs(keySet, fmt.Sprintf("%s:1500", paymentAddressString(receiver.PaymentAddress)), 10, 0, ioutil.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sign(keySet, data, approve); err == nil {
+		t.Error("expect error signing a token output expected as PRV")
+	}
+
+	approve, err = expectOutputs(keySet, fmt.Sprintf("%s:1500:%s", paymentAddressString(receiver.PaymentAddress), tokenID.String()), 10, 0, ioutil.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := sign(keySet, data, approve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txBytes, _, err := base58.Base58Check{}.Decode(result.Base58CheckData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := transaction.TxCustomTokenPrivacy{}
+	if err := json.Unmarshal(txBytes, &tx); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash().String() != result.TxID || tx.Type != common.TxCustomTokenPrivacyType || tx.Fee != 10 || tx.TxPrivacyTokenData.PropertyID != tokenID {
+		t.Errorf("unexpected tx %+v", result)
+	}
+	ok, err := tx.ValidateTransaction(true, stateDB, nil, shardID, nil, false, true)
+	if !ok || err != nil {
+		t.Fatalf("expect valid tx got %+v", err)
+	}
+
+	// the token part has to spend the token of the template
+	template.PropertyID = common.HashH([]byte("another token"))
+	data, err = json.Marshal(template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sign(keySet, data, func([]*transaction.TxTemplate) error { return nil }); err == nil {
+		t.Error("expect error signing a token template of another token")
+	}
+}
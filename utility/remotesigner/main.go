@@ -0,0 +1,87 @@
+// remotesigner holds the consensus mining keys of a validator and signs blocks for its node started with
+// --remotesigner, it refuses to sign two blocks at the same height and timeslot of a chain
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
+	"github.com/jessevdk/go-flags"
+)
+
+type params struct {
+	Listen     string `long:"listen" short:"l" default:"unix:signer.sock" description:"Address to serve, unix:<path> or tcp:<host:port>"`
+	KeyFile    string `long:"keyfile" short:"k" description:"File containing the mining key, as in --miningkeys of the node, read from stdin when omitted"`
+	PrivateKey bool   `long:"privatekey" description:"The key is a base58 wallet private key, as in --privatekey of the node"`
+	StateFile  string `long:"statefile" short:"s" default:"signer-state.json" description:"File keeping the last block signed on each chain"`
+}
+
+func main() {
+	cfg := params{}
+	parser := flags.NewParser(&cfg, flags.Default)
+	if _, err := parser.Parse(); err != nil {
+		os.Exit(1)
+	}
+	privateSeed, err := readPrivateSeed(cfg.KeyFile, cfg.PrivateKey, os.Stdin)
+	if err != nil {
+		log.Fatal(err)
+	}
+	localSigner, err := signer.NewLocalSigner(privateSeed, cfg.StateFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	listener, err := signer.Listen(cfg.Listen)
+	if err != nil {
+		log.Fatal(err)
+	}
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-interrupt
+		listener.Close()
+	}()
+	log.Printf("Serving signer on %v", cfg.Listen)
+	if err := signer.Serve(listener, localSigner); err != nil {
+		log.Println(err)
+	}
+}
+
+// readPrivateSeed returns the base58 private seed of the mining key read from keyFile or stdin
+func readPrivateSeed(keyFile string, isPrivateKey bool, stdin io.Reader) (string, error) {
+	var keyStr string
+	if keyFile != "" {
+		data, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return "", err
+		}
+		keyStr = string(data)
+	} else {
+		fmt.Fprint(os.Stderr, "Mining key: ")
+		line, err := bufio.NewReader(stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		keyStr = line
+	}
+	keyStr = strings.TrimSpace(keyStr)
+	if isPrivateKey {
+		return blsbftv2.LoadUserKeyFromIncPrivateKey(keyStr)
+	}
+	// --miningkeys is "bls:<private seed>"
+	if parts := strings.Split(keyStr, ":"); len(parts) == 2 {
+		keyStr = parts[1]
+	}
+	if keyStr == "" {
+		return "", fmt.Errorf("mining key is empty")
+	}
+	return keyStr, nil
+}
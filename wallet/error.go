@@ -26,6 +26,7 @@ const (
 	NewMnemonicError
 	MnemonicInvalidError
 	InvalidSeserializedKey
+	InvalidMemoErr
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -53,6 +54,8 @@ var ErrCodeMessage = map[int]struct {
 	NewMnemonicError:       {-1015, "Can not create mnemonic"},
 	MnemonicInvalidError:   {-1016, "Mnemonic is invalid"},
 	InvalidSeserializedKey: {-1016, "Serialized key is invalid"},
+
+	InvalidMemoErr: {-1017, "Memo can not be encrypted or decrypted with the key"},
 }
 
 type WalletError struct {
@@ -0,0 +1,44 @@
+package wallet
+
+import (
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+// EncryptMemo returns the info of an output coin to paymentAddressStr carrying memo, only the receiver of the coin
+// reads it (see privacy.EncryptMemo)
+func EncryptMemo(paymentAddressStr string, memo string) ([]byte, error) {
+	keyWallet, err := Base58CheckDeserialize(paymentAddressStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(keyWallet.KeySet.PaymentAddress.Tk) == 0 {
+		return nil, NewWalletError(InvalidKeyTypeErr, nil)
+	}
+	info, err := privacy.EncryptMemo([]byte(memo), keyWallet.KeySet.PaymentAddress.Tk)
+	if err != nil {
+		return nil, NewWalletError(InvalidMemoErr, err)
+	}
+	return info, nil
+}
+
+// DecryptMemo returns the memo carried by the info of an output coin with keyStr, the serialized private key or
+// readonly key of the receiver of the coin
+func DecryptMemo(keyStr string, info []byte) (string, error) {
+	keyWallet, err := Base58CheckDeserialize(keyStr)
+	if err != nil {
+		return "", err
+	}
+	if len(keyWallet.KeySet.PrivateKey) > 0 {
+		if err := keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey); err != nil {
+			return "", err
+		}
+	}
+	if len(keyWallet.KeySet.ReadonlyKey.Rk) == 0 {
+		return "", NewWalletError(InvalidKeyTypeErr, nil)
+	}
+	memo, err := privacy.DecryptMemo(info, keyWallet.KeySet.ReadonlyKey.Rk)
+	if err != nil {
+		return "", NewWalletError(InvalidMemoErr, err)
+	}
+	return string(memo), nil
+}
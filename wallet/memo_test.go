@@ -0,0 +1,35 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptDecryptMemo(t *testing.T) {
+	masterKey, err := NewMasterKey([]byte("memo seed"))
+	assert.Equal(t, nil, err)
+	receiver, err := masterKey.NewChildKey(0)
+	assert.Equal(t, nil, err)
+	other, err := masterKey.NewChildKey(1)
+	assert.Equal(t, nil, err)
+	err = receiver.KeySet.InitFromPrivateKey(&receiver.KeySet.PrivateKey)
+	assert.Equal(t, nil, err)
+
+	info, err := EncryptMemo(receiver.Base58CheckSerialize(PaymentAddressType), "deposit 42")
+	assert.Equal(t, nil, err)
+
+	// the private key and the readonly key of the receiver read the memo
+	memo, err := DecryptMemo(receiver.Base58CheckSerialize(PriKeyType), info)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "deposit 42", memo)
+	memo, err = DecryptMemo(receiver.Base58CheckSerialize(ReadonlyKeyType), info)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "deposit 42", memo)
+
+	// another key or a payment address does not
+	_, err = DecryptMemo(other.Base58CheckSerialize(PriKeyType), info)
+	assert.NotEqual(t, nil, err)
+	_, err = DecryptMemo(receiver.Base58CheckSerialize(PaymentAddressType), info)
+	assert.NotEqual(t, nil, err)
+}
@@ -85,6 +85,20 @@ func InitParamCreatePrivacyTx(args string) (*transaction.TxPrivacyInitParamsForA
 			}
 		}
 
+		// a memo is encrypted to the receiver in the message of the payment
+		if tmp["memo"] != nil {
+			memo, ok := tmp["memo"].(string)
+			if !ok || len(msgBytes) > 0 {
+				println("Invalid payment info param memo")
+				return nil, errors.New("Invalid payment info param memo")
+			}
+			msgBytes, err = wallet.EncryptMemo(paymentAddrStr, memo)
+			if err != nil {
+				println("Can not encrypt memo in payment info")
+				return nil, err
+			}
+		}
+
 		paymentInfoTmp := new(privacy.PaymentInfo)
 		keyWallet, err := wallet.Base58CheckDeserialize(paymentAddrStr)
 		if err != nil {
@@ -326,6 +340,20 @@ func InitParamCreatePrivacyTokenTx(args string) (*transaction.TxPrivacyTokenInit
 			}
 		}
 
+		// a memo is encrypted to the receiver in the message of the payment
+		if tmp["memo"] != nil {
+			memo, ok := tmp["memo"].(string)
+			if !ok || len(msgBytes) > 0 {
+				println("Invalid payment info param memo")
+				return nil, errors.New("Invalid payment info param memo")
+			}
+			msgBytes, err = wallet.EncryptMemo(paymentAddrStr, memo)
+			if err != nil {
+				println("Can not encrypt memo in payment info")
+				return nil, err
+			}
+		}
+
 		paymentInfoTmp := new(privacy.PaymentInfo)
 		keyWallet, err := wallet.Base58CheckDeserialize(paymentAddrStr)
 		if err != nil {
@@ -638,6 +666,20 @@ func InitParamCreatePrivacyTokenTx(args string) (*transaction.TxPrivacyTokenInit
 			}
 		}
 
+		// a memo is encrypted to the receiver in the message of the payment
+		if tmp["memo"] != nil {
+			memo, ok := tmp["memo"].(string)
+			if !ok || len(msgBytes) > 0 {
+				println("Invalid payment info param memo")
+				return nil, errors.New("Invalid payment info param memo")
+			}
+			msgBytes, err = wallet.EncryptMemo(paymentAddrStr, memo)
+			if err != nil {
+				println("Can not encrypt memo in payment info")
+				return nil, err
+			}
+		}
+
 		paymentInfoTmp := new(privacy.PaymentInfo)
 		keyWallet, err := wallet.Base58CheckDeserialize(paymentAddrStr)
 		if err != nil {
@@ -198,3 +198,32 @@ func HybridDecryptionASM(dataB64Encode string) (string, error) {
 	res := base64.StdEncoding.EncodeToString(plaintextBytes)
 	return res, nil
 }
+
+// args: {"key": private key or readonly key of the receiver, "info": base58 check encoded info of an output coin}
+// returns the memo encrypted in the info to the receiver
+func DecryptMemo(args string) (string, error) {
+	paramMaps := make(map[string]interface{})
+	err := json.Unmarshal([]byte(args), &paramMaps)
+	if err != nil {
+		println("Error can not unmarshal data : %v\n", err)
+		return "", err
+	}
+
+	keyStr, ok := paramMaps["key"].(string)
+	if !ok {
+		println("Invalid key")
+		return "", errors.New("Invalid key")
+	}
+	infoStr, ok := paramMaps["info"].(string)
+	if !ok {
+		println("Invalid info")
+		return "", errors.New("Invalid info")
+	}
+	info, _, err := base58.Base58Check{}.Decode(infoStr)
+	if err != nil {
+		println("Can not decode info")
+		return "", errors.New("Can not decode info")
+	}
+
+	return wallet.DecryptMemo(keyStr, info)
+}
@@ -184,6 +184,15 @@ func hybridDecryptionASM(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
+func decryptMemo(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.DecryptMemo(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
 func main() {
 	c := make(chan struct{}, 0)
 	println("Hello WASM")
@@ -211,6 +220,7 @@ func main() {
 
 	js.Global().Set("hybridEncryptionASM", js.FuncOf(hybridEncryptionASM))
 	js.Global().Set("hybridDecryptionASM", js.FuncOf(hybridDecryptionASM))
+	js.Global().Set("decryptMemo", js.FuncOf(decryptMemo))
 
 	<-c
 }
@@ -18,6 +18,7 @@ type ChainState struct {
 	Height        uint64
 	BlockHash     common.Hash
 	BestStateHash common.Hash
+	PrunedHeight  uint64 // the blocks up to this height have no body on the peer, 0 for a peer keeping every block
 }
 
 type MessagePeerState struct {